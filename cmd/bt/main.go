@@ -8,28 +8,37 @@ import (
 
 	"github.com/alecthomas/kong"
 	"github.com/carlosarraes/bt/pkg/cmd"
+	"github.com/carlosarraes/bt/pkg/cmd/alias"
+	"github.com/carlosarraes/bt/pkg/cmd/shared"
 	"github.com/carlosarraes/bt/pkg/cmd/skill"
+	"github.com/carlosarraes/bt/pkg/config"
+	"github.com/carlosarraes/bt/pkg/output"
 	"github.com/carlosarraes/bt/pkg/version"
 )
 
 var cli struct {
-	// Global flags
-	Verbose     bool   `short:"v"`
-	ConfigFile  string `default:"~/.config/bt/config.yml"`
-	NoColor     bool
-	Help        bool `short:"h"`
-	VersionFlag bool `name:"version" help:"Show version information"`
-	LLM         bool `help:"Show LLM-optimized usage guide and examples"`
+	// Global flags, listed after the command's own in help
+	Verbose    bool   `short:"v" env:"BT_VERBOSE" help:"Enable verbose output" group:"global"`
+	ConfigFile string `name:"config-file" aliases:"config" help:"Config file path (default $BT_CONFIG_PATH or ~/.config/bt/config.yml)" group:"global"`
+	NoColor    bool   `help:"Disable colored output (also BT_NO_COLOR, NO_COLOR)" group:"global"`
+	NoPager    bool   `help:"Don't page long output through $BT_PAGER or $PAGER" group:"global"`
+	DryRun     bool   `help:"Show the API calls mutating commands would make without making them" group:"global"`
+	NoTruncate bool   `help:"Print table cells in full instead of fitting tables to the terminal" group:"global"`
+	Help       bool   `short:"h" help:"Show help for command" group:"global"`
+	LLM        bool   `help:"Show LLM-optimized usage guide and examples" group:"global"`
 
 	// Commands
-	Version cmd.VersionCmd `cmd:""`
-	Auth    cmd.AuthCmd    `cmd:""`
-	Run     cmd.RunCmd     `cmd:""`
-	Config  cmd.ConfigCmd  `cmd:""`
-	Repo    cmd.RepoCmd    `cmd:""`
-	PR      cmd.PRCmd      `cmd:""`
-	Pick    cmd.PickCmd    `cmd:""`
-	Skill   cmd.SkillCmd   `cmd:""`
+	Version cmd.VersionCmd `cmd:"" help:"Show bt version"`
+	Auth    cmd.AuthCmd    `cmd:"" help:"Authenticate bt and git with Bitbucket"`
+	Run     cmd.RunCmd     `cmd:"" help:"View and manage pipeline runs"`
+	Config  cmd.ConfigCmd  `cmd:"" help:"Manage configuration for bt"`
+	Repo    cmd.RepoCmd    `cmd:"" help:"Work with repositories"`
+	PR      cmd.PRCmd      `cmd:"" help:"Manage pull requests"`
+	Issue   cmd.IssueCmd   `cmd:"" help:"Manage issues of the repository's issue tracker"`
+	Pick    cmd.PickCmd    `cmd:"" help:"Cherry-pick commits between PRD/HML branches"`
+	Skill   cmd.SkillCmd   `cmd:"" help:"Manage AI agent skills (Claude, Cursor, Codex)"`
+	API     cmd.APICmd     `cmd:"" help:"Inspect the Bitbucket API rate limit"`
+	Alias   cmd.AliasCmd   `cmd:"" help:"Create shortcuts for bt commands"`
 }
 
 func main() {
@@ -40,7 +49,8 @@ func main() {
 	originalArgs := os.Args
 	args := os.Args[1:]
 
-	// Check for --version flag
+	// Check for --version flag. It is only global in first position, so
+	// subcommands such as pr create can define their own --version.
 	if len(args) >= 1 && args[0] == "--version" {
 		fmt.Println(version.GetBuildInfo().String())
 		return
@@ -63,85 +73,222 @@ func main() {
 		return
 	}
 
-	// Handle subcommand help
-	if len(args) == 2 && args[1] == "--help" {
-		switch args[0] {
-		case "auth":
-			showAuthHelp()
-			return
-		case "run":
-			showRunHelp()
-			return
-		case "pr":
-			showPRHelp()
-			return
-		case "config":
-			showConfigHelp()
-			return
-		case "pick":
-			showPickHelp()
-			return
-		case "skill":
-			showSkillHelp()
-			return
-		}
-	}
+	// Help is printed for the command the remaining arguments select, once
+	// the parser knows the commands and aliases are expanded
+	helpRequested := false
 
 	// Temporarily remove help, version, and llm flags from args to prevent Kong from intercepting
 	filteredArgs := []string{originalArgs[0]}
-	for _, arg := range args {
-		if arg != "--help" && arg != "-h" && arg != "--version" && arg != "--llm" {
+	for i, arg := range args {
+		if arg == "--" {
+			filteredArgs = append(filteredArgs, args[i:]...)
+			break
+		}
+		if arg == "--help" || arg == "-h" {
+			helpRequested = true
+			continue
+		}
+		if arg != "--llm" {
 			filteredArgs = append(filteredArgs, arg)
 		}
 	}
-	// Insert -- before config set values starting with - so Kong treats them as positional args
+	// Move config set key and values starting with - after a -- so Kong
+	// treats them as positional args, keeping flags that follow them
 	if len(filteredArgs) >= 5 && filteredArgs[1] == "config" && filteredArgs[2] == "set" && strings.HasPrefix(filteredArgs[4], "-") {
-		filteredArgs = append(filteredArgs[:4], append([]string{"--"}, filteredArgs[4:]...)...)
+		positional := []string{"--", filteredArgs[3], filteredArgs[4]}
+		filteredArgs = append(append(filteredArgs[:3:3], filteredArgs[5:]...), positional...)
 	}
 
 	os.Args = filteredArgs
 
-	ctx := kong.Parse(&cli,
-		kong.Name("bt"),
-		kong.Description("Work seamlessly with Bitbucket from the command line."),
-		kong.NoDefaultHelp(),
-		kong.Vars{
-			"version": version.Version,
-		},
-		kong.BindTo(appCtx, (*context.Context)(nil)),
-	)
+	// The config file is loaded below, before Kong parses the flags, and by
+	// every command through config.NewLoader, which reads BT_CONFIG_PATH
+	if path := configFileArg(filteredArgs[1:]); path != "" {
+		expanded, err := config.ExpandPath(path)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		os.Setenv(config.EnvConfigPath, expanded)
+	}
+
+	// Resolve the default -o value once so every command honors
+	// BT_OUTPUT_FORMAT and defaults.output_format. Config errors are
+	// reported later by the command itself.
+	cfg, _ := config.NewLoader().Load()
+	vars := kong.Vars{
+		"version": version.Version,
+	}
+	for k, v := range shared.OutputFormatVars(shared.ResolveDefaultOutputFormat(cfg)) {
+		vars[k] = v
+	}
+
+	parser := newParser(appCtx, vars)
+
+	// Aliases expand before parsing, so they take the flags of the command
+	// they stand for
+	parseArgs := os.Args[1:]
+	if cfg != nil {
+		expansion, err := alias.Expand(parseArgs, cfg.Aliases, alias.CommandNames(parser.Model.Node), os.Stderr)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		if expansion.Shell != "" && helpRequested {
+			fmt.Printf("%s is a shell alias for: %s\n", expansion.Name, expansion.Shell)
+			return
+		}
+		if expansion.Shell != "" {
+			code, err := alias.RunShell(appCtx, expansion, os.Stdin, os.Stdout, os.Stderr)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			}
+			os.Exit(code)
+		}
+		parseArgs = expansion.Args
+	}
+
+	if helpRequested {
+		if err := showCommandHelp(parser, parseArgs); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	ctx, err := parser.Parse(parseArgs)
+	parser.FatalIfErrorf(err)
 
 	// Update context with global flags
 	if cli.Verbose {
 		appCtx = context.WithValue(appCtx, "verbose", true)
 	}
-	if cli.NoColor {
+	if shared.ResolveNoColor(flagSet(ctx, "no-color"), cli.NoColor) {
 		appCtx = context.WithValue(appCtx, "no-color", true)
 	}
-	appCtx = context.WithValue(appCtx, "config-path", cli.ConfigFile)
-
-	// Check if help flag was set after Kong parsing
-	if cli.Help {
-		showMainHelp()
-		return
+	if cli.NoPager {
+		appCtx = context.WithValue(appCtx, "no-pager", true)
+	}
+	if cli.DryRun {
+		appCtx = context.WithValue(appCtx, "dry-run", true)
+	}
+	if configPath := os.Getenv(config.EnvConfigPath); configPath != "" {
+		appCtx = context.WithValue(appCtx, "config-path", configPath)
 	}
 
-	// Check if version flag was set after Kong parsing
-	if cli.VersionFlag {
-		fmt.Println(version.GetBuildInfo().String())
-		return
+	maxWidth := 0
+	if cfg != nil {
+		maxWidth = cfg.UI.MaxWidth
 	}
+	output.SetTableLayout(maxWidth, cli.NoTruncate)
 
-	// Execute the selected command
-	err := ctx.Run(appCtx)
+	// Execute the selected command. The context is rebound because the one
+	// bound at parse time doesn't carry the global flag values.
+	ctx.BindTo(appCtx, (*context.Context)(nil))
+	err = ctx.Run()
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		if flagValue(ctx, "output") == "json" {
+			_ = shared.WriteJSONError(os.Stderr, err)
+		} else {
+			fmt.Fprint(os.Stderr, shared.FormatError(err, cli.Verbose))
+		}
 		os.Exit(1)
 	}
 
 	skill.CheckForUpdate()
 }
 
+// newParser builds the parser of the bt command line, with vars
+// interpolated into help and flag defaults
+func newParser(appCtx context.Context, vars kong.Vars) *kong.Kong {
+	return kong.Must(&cli,
+		kong.Name("bt"),
+		kong.Description("Work seamlessly with Bitbucket from the command line."),
+		kong.NoDefaultHelp(),
+		kong.ExplicitGroups([]kong.Group{{Key: "global", Title: "Global flags:"}}),
+		vars,
+		kong.BindTo(appCtx, (*context.Context)(nil)),
+	)
+}
+
+// flagSet reports whether the named flag was given on the command line.
+func flagSet(ctx *kong.Context, name string) bool {
+	for _, el := range ctx.Path {
+		if el.Flag != nil && el.Flag.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// configFileArg returns the value of --config-file (or --config) in args,
+// or "" when it isn't given
+func configFileArg(args []string) string {
+	for i, arg := range args {
+		if arg == "--" {
+			break
+		}
+		for _, name := range []string{"--config-file", "--config"} {
+			if value, ok := strings.CutPrefix(arg, name+"="); ok {
+				return value
+			}
+			if arg == name && i+1 < len(args) {
+				return args[i+1]
+			}
+		}
+	}
+	return ""
+}
+
+// flagValue returns the resolved value of the named flag on the selected
+// command, including its default, or "" when the command has no such flag.
+func flagValue(ctx *kong.Context, name string) string {
+	for _, flag := range ctx.Flags() {
+		if flag.Name == name {
+			if v, ok := ctx.FlagValue(flag).(string); ok {
+				return v
+			}
+		}
+	}
+	return ""
+}
+
+// groupHelp holds the hand-written overviews of the command groups
+var groupHelp = map[string]func(){
+	"auth":   showAuthHelp,
+	"run":    showRunHelp,
+	"pr":     showPRHelp,
+	"repo":   showRepoHelp,
+	"issue":  showIssueHelp,
+	"config": showConfigHelp,
+	"pick":   showPickHelp,
+	"skill":  showSkillHelp,
+	"api":    showAPIHelp,
+	"alias":  showAliasHelp,
+}
+
+// showCommandHelp prints the help of the command args select: the main help
+// without one, the overview of a command group, or the usage, arguments and
+// flags kong knows for any other command. Arguments past the command, even
+// invalid ones, are ignored, so --help never runs anything.
+func showCommandHelp(parser *kong.Kong, args []string) error {
+	trace, err := kong.Trace(parser, args)
+	if err != nil {
+		return err
+	}
+
+	selected := trace.Selected()
+	if selected == nil {
+		showMainHelp()
+		return nil
+	}
+	if show, ok := groupHelp[selected.Name]; ok && selected.Parent == parser.Model.Node {
+		show()
+		return nil
+	}
+	return trace.PrintUsage(false)
+}
+
 func showMainHelp() {
 	fmt.Print(`Work seamlessly with Bitbucket from the command line.
 
@@ -150,12 +297,15 @@ USAGE
 
 CORE COMMANDS
   auth:          Authenticate bt and git with Bitbucket
+  issue:         Manage issues of the repository's issue tracker
   pick:          Cherry-pick commits between PRD/HML branches
   pr:            Manage pull requests
-  repo:          Manage repositories (not yet implemented)
+  repo:          Work with repositories
   run:           View and manage pipeline runs
 
 ADDITIONAL COMMANDS
+  alias:         Create shortcuts for bt commands
+  api:           Inspect the Bitbucket API rate limit
   config:        Manage configuration for bt
   skill:         Manage AI agent skills (Claude, Cursor, Codex)
   version:       Show bt version
@@ -164,13 +314,17 @@ FLAGS
   --help              Show help for command
   --version           Show bt version
   -v, --verbose       Enable verbose output
-  --config-file=PATH  Config file path
-  --no-color          Disable colored output
+  --config-file=PATH  Config file path; ~ and $VARS are expanded (also --config, BT_CONFIG_PATH)
+  --no-color          Disable colored output (also BT_NO_COLOR, NO_COLOR)
+  --no-pager          Don't page long output (pager: BT_PAGER, PAGER, default less -FRX)
+  --no-truncate       Print table cells in full (tables fit the terminal, or ui.max_width)
+  --dry-run           Print the API calls of pr merge/close/review, run cancel, issue create and repo cherry-pick without sending them
   --llm               Show LLM-optimized usage guide and examples
 
 EXAMPLES
   $ bt pr create
   $ bt pr list --state open
+  $ bt run latest --log-failed
   $ bt run view 123
   $ bt auth login
 
@@ -191,6 +345,7 @@ AVAILABLE COMMANDS
   logout:        Log out of Bitbucket
   status:        View authentication status
   refresh:       Refresh stored authentication credentials
+  create-token:  Create a repository access token
 
 FLAGS
   --help   Show help for command
@@ -198,7 +353,10 @@ FLAGS
 EXAMPLES
   $ bt auth login
   $ bt auth status
+  $ bt auth status --output json
   $ bt auth login --with-token YOUR_TOKEN
+  $ bt auth login --git-protocol https
+  $ bt auth create-token --repo myworkspace/myrepo --scopes repository,pipeline:write
 
 LEARN MORE
   Use 'bt auth <command> --help' for more information about a command.
@@ -219,6 +377,15 @@ AVAILABLE COMMANDS
   cancel:        Cancel a running pipeline
   rerun:         Rerun a pipeline (optionally failed steps only)
   report:        SonarCloud coverage/issues report for a pipeline
+  compare:       Compare steps, durations and tests of two pipeline runs
+  status:        Latest main-branch pipeline of every repository in a workspace
+  status set:    Publish a build status on a commit
+  deployments:   List deployment environments and their latest deployment
+  deploy:        Deploy a pipeline to an environment
+  grep:          Search the logs of recent pipelines for a pattern
+  for-commit:    List the pipelines that ran on a commit
+  latest:        Show the latest pipeline of the current branch
+  download:      Download a build artifact, verifying its size
 
 FLAGS
   -R, --repo [HOST/]OWNER/REPO   Select another repository using the [HOST/]OWNER/REPO format
@@ -230,10 +397,34 @@ INHERITED FLAGS
 
 EXAMPLES
   $ bt run list
+  $ bt run list --output compact
+  $ bt run latest --log-failed
+  $ bt run for-commit $(git rev-parse --short HEAD)
   $ bt run view 123
+  $ bt run view 123 --step-timing
+  $ bt run view 123 --step ut
+  $ bt run view 123 --log-failed --include-logs -o json
+  $ bt run view 123 --tests --test-output full
+  $ bt run view 123 --tests --history
+  $ bt run view 123 --tests -o json
+  $ bt run view 123 --pr --web
   $ bt run report 123 --coverage
+  $ bt run compare 120 123
   $ bt run logs 123 --errors-only
+  $ bt run logs 123 --only-failed
+  $ bt run logs 123 --merge-steps
+  $ bt run view --status failed      # pick among recent failures
   $ bt run watch 123
+  $ bt run watch 123 --retry-on-transient
+  $ bt run watch 123 --events jsonl
+  $ bt run rerun 123 --failed --watch
+  $ bt run cancel 123 --wait
+  $ bt run download app-1.4.0.tar.gz --dir dist
+  $ bt run status --workspace myteam --failed
+  $ bt run status set --commit abc123 --state SUCCESSFUL --key lint --url https://ci.example.com/1
+  $ bt run deployments
+  $ bt run deploy 123 --env production
+  $ bt run grep "connection refused" --limit 30 --branch main
 
 LEARN MORE
   Use 'bt run <command> --help' for more information about a command.
@@ -268,6 +459,7 @@ TARGETED COMMANDS
   ready:         Mark a pull request as ready for review
   reopen:        Reopen a pull request
   review:        Add a review to a pull request
+  review-history: Collect an author's comments across all PRs in the repo
   unlock:        Unlock pull request conversation
   update-branch: Update a pull request branch
   view:          View a pull request
@@ -289,10 +481,32 @@ ARGUMENTS
 EXAMPLES
   $ bt pr create
   $ bt pr create --fill
+  $ bt pr create --fill-first-commit
   $ bt pr create --ai --template portuguese
+  $ bt pr create --milestone "Sprint 12" --version 1.4
+  $ bt pr create --lint-commits
+  $ bt pr create --max-size M
+  $ bt pr create --base-auto
   $ bt pr list --state open
+  $ bt pr list --all --stale 14d
+  $ bt pr list --all --base @default
+  $ bt pr list-all --refresh
+  $ bt pr open 123 --pipeline
   $ bt pr view 123
+  $ bt pr view 123 --commits
+  $ bt pr view 123 --checks
+  $ bt pr view 123 --comments --tree
+  $ bt pr view 123 --patch | git am --patch-format=mboxrd
   $ bt pr checkout 123
+  $ bt pr checkout              # pick from open PRs
+  $ bt pr checkout 123 --cleanup
+  $ bt pr diff 123 --apply --3way
+  $ bt pr comment 123 --from-diff "fmt\.Println" -b "Use the logger" --force
+  $ bt pr review 123 --request-changes --from-pipeline 456
+  $ bt pr diff 123 --only-added
+  $ bt pr diff 123 --word-diff
+  $ bt pr files 123 --sort additions
+  $ bt pr ready 123 --when-checks-pass
   $ bt pr merge 123
 
 LEARN MORE
@@ -300,6 +514,72 @@ LEARN MORE
 `)
 }
 
+func showRepoHelp() {
+	fmt.Print(`Work with Bitbucket repositories.
+
+USAGE
+  bt repo <command> [flags]
+
+AVAILABLE COMMANDS
+  clone:         Clone a repository (--pr <id> checks out a pull request)
+  commits:       List commits with their signature verification status
+  cherry-pick:   Backport commits onto a branch (--onto), optionally opening a PR (--pr)
+  variables:     Manage pipeline variables (list, get, set, delete)
+  changelog:     Changelog of the pull requests merged since a tag or date (--since)
+
+FLAGS
+  --help   Show help for command
+
+INHERITED FLAGS
+  -o, --output=FORMAT   Output format (table, json, yaml)
+  --no-color           Disable colored output
+
+EXAMPLES
+  $ bt repo clone myworkspace/api --pr 42
+  $ bt repo commits
+  $ bt repo commits develop --limit 10
+  $ bt repo commits --author-email jane@example.com
+  $ bt repo cherry-pick 1a2b3c4 --onto release/2.1 --pr
+  $ bt repo changelog --since v1.4.0
+  $ bt repo changelog --since 2024-03-01 --until v1.5.0 -o json
+  $ bt repo variables list --environment production
+  $ echo "$TOKEN" | bt repo variables set DEPLOY_TOKEN --secured
+
+LEARN MORE
+  Use 'bt repo <command> --help' for more information about a command.
+`)
+}
+
+func showIssueHelp() {
+	fmt.Print(`Work with the repository's built-in Bitbucket issue tracker.
+
+USAGE
+  bt issue <command> [flags]
+
+AVAILABLE COMMANDS
+  list:          List issues (unresolved by default)
+  view:          Show the details of an issue
+  create:        Open a new issue
+
+FLAGS
+  --help   Show help for command
+
+INHERITED FLAGS
+  -o, --output=FORMAT   Output format (table, json, yaml)
+  --no-color           Disable colored output
+
+EXAMPLES
+  $ bt issue list
+  $ bt issue list --state resolved --assignee @me
+  $ bt issue view 42
+  $ bt issue view 42 --web
+  $ bt issue create -t "Crash on start" -b "Steps to reproduce..." --kind bug --priority critical
+
+LEARN MORE
+  Use 'bt issue <command> --help' for more information about a command.
+`)
+}
+
 func showConfigHelp() {
 	fmt.Print(`Manage configuration for bt.
 
@@ -311,6 +591,8 @@ AVAILABLE COMMANDS
   set:           Set configuration values
   list:          List configuration settings
   unset:         Remove configuration values
+  export:        Export shareable configuration (credentials excluded)
+  import:        Merge an exported configuration into yours
 
 FLAGS
   --help   Show help for command
@@ -318,8 +600,13 @@ FLAGS
 EXAMPLES
   $ bt config list
   $ bt config get auth.default_workspace
+  $ bt config get --resolved auth.default_workspace
+  $ bt config get --all-sources api.timeout
   $ bt config set auth.default_workspace myworkspace
   $ bt config unset auth.default_workspace
+  $ bt config set api.timeout 60s -o json
+  $ bt config export --file team.yml
+  $ bt config import team.yml --dry-run
 
 LEARN MORE
   Use 'bt config <command> --help' for more information about a command.
@@ -359,6 +646,65 @@ LEARN MORE
 `)
 }
 
+func showAPIHelp() {
+	fmt.Print(`Inspect the Bitbucket API as seen by your credentials.
+
+USAGE
+  bt api <command> [flags]
+
+AVAILABLE COMMANDS
+  rate-limit:    Show the remaining rate limit quota and when it resets
+
+FLAGS
+  --help   Show help for command
+
+EXAMPLES
+  $ bt api rate-limit
+  $ bt api rate-limit -o json
+
+LEARN MORE
+  Bitbucket counts requests per credential. Commands warn on stderr when a
+  response reports the quota is nearly used up.
+`)
+}
+
+func showAliasHelp() {
+	fmt.Print(`Create shortcuts for bt commands.
+
+USAGE
+  bt alias <command> [flags]
+
+AVAILABLE COMMANDS
+  set:           Create or change an alias
+  list:          List aliases
+  delete:        Delete an alias
+
+FLAGS
+  --help   Show help for command
+
+EXAMPLES
+  $ bt alias set failed 'run list --status failed'
+  $ bt failed --branch main
+  $ bt alias set co 'pr checkout $1'
+  $ bt alias set mine 'pr list --author $1 --state $2'
+  $ bt alias set cleanup '!git branch --merged | xargs git branch -d'
+  $ bt alias list
+  $ bt alias delete failed
+
+LEARN MORE
+  The expansion is a bt command line without 'bt'. $1 to $9 take the
+  arguments given to the alias and $@ all of them; arguments no placeholder
+  takes are appended. Aliases are saved under aliases in the config file, and
+  a repository's .bt.yml can define aliases for the whole team. An alias
+  can't take the name of a built-in command.
+
+  An expansion starting with ! runs in your shell, with the alias arguments
+  as "$1" ... and "$@" (appended when the command doesn't use them). Shell
+  aliases run anything with your privileges: only add ones you have read,
+  and note that .bt.yml can't define them.
+`)
+}
+
 func showPickHelp() {
 	fmt.Print(`Cherry-pick commits between production and homologation branches.
 
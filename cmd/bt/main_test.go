@@ -0,0 +1,119 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"os"
+	"regexp"
+	"testing"
+
+	"github.com/alecthomas/kong"
+
+	"github.com/carlosarraes/bt/pkg/cmd"
+	"github.com/carlosarraes/bt/pkg/cmd/shared"
+)
+
+// helpEntryRe matches the "  name:   description" lines help lists commands with
+var helpEntryRe = regexp.MustCompile(`(?m)^  ([a-z][a-z-]*):\s`)
+
+func testParser(t *testing.T) *kong.Kong {
+	t.Helper()
+	vars := kong.Vars{"version": "test"}
+	for k, v := range shared.OutputFormatVars("table") {
+		vars[k] = v
+	}
+	return newParser(context.Background(), vars)
+}
+
+// listedCommands returns the commands a hand-written help lists
+func listedCommands(t *testing.T, show func()) map[string]bool {
+	t.Helper()
+
+	stdout := os.Stdout
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	os.Stdout = w
+	show()
+	w.Close()
+	os.Stdout = stdout
+
+	var out bytes.Buffer
+	io.Copy(&out, r)
+
+	listed := make(map[string]bool)
+	for _, m := range helpEntryRe.FindAllStringSubmatch(out.String(), -1) {
+		listed[m[1]] = true
+	}
+	return listed
+}
+
+// TestHelpMatchesCommands keeps the hand-written help in step with the
+// commands that are registered: each visible command is listed, and nothing
+// listed is missing
+func TestHelpMatchesCommands(t *testing.T) {
+	parser := testParser(t)
+	mainListed := listedCommands(t, showMainHelp)
+
+	for _, group := range parser.Model.Node.Children {
+		if group.Hidden {
+			continue
+		}
+		if !mainListed[group.Name] {
+			t.Errorf("bt --help doesn't list %s", group.Name)
+		}
+		delete(mainListed, group.Name)
+
+		if len(group.Children) == 0 {
+			continue
+		}
+		show, ok := groupHelp[group.Name]
+		if !ok {
+			t.Errorf("%s has subcommands but no help overview", group.Name)
+			continue
+		}
+		listed := listedCommands(t, show)
+		for _, command := range group.Children {
+			if command.Hidden {
+				continue
+			}
+			if !listed[command.Name] {
+				t.Errorf("bt %s --help doesn't list %s", group.Name, command.Name)
+			}
+			delete(listed, command.Name)
+		}
+		for name := range listed {
+			t.Errorf("bt %s --help lists %s, which isn't a command", group.Name, name)
+		}
+	}
+
+	for name := range mainListed {
+		t.Errorf("bt --help lists %s, which isn't a command", name)
+	}
+}
+
+func TestCommandsHaveLLMHelp(t *testing.T) {
+	for _, group := range testParser(t).Model.Node.Children {
+		if group.Hidden || len(group.Children) == 0 {
+			continue
+		}
+		if !cmd.HasLLMHelp(group.Name) {
+			t.Errorf("bt %s --llm has no guide", group.Name)
+		}
+	}
+}
+
+func TestShowCommandHelp_SelectsCommand(t *testing.T) {
+	parser := testParser(t)
+
+	var out bytes.Buffer
+	parser.Stdout = &out
+	if err := showCommandHelp(parser, []string{"pr", "merge", "123"}); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Contains(out.Bytes(), []byte("Usage: bt pr merge")) {
+		t.Errorf("help is not for pr merge:\n%s", out.String())
+	}
+}
@@ -3,7 +3,6 @@ package ai
 import (
 	"context"
 	"fmt"
-	"os"
 	"strings"
 	"time"
 
@@ -38,7 +37,7 @@ func NewDescriptionGenerator(client *api.Client, repo *git.Repository, workspace
 type GenerateOptions struct {
 	SourceBranch string
 	TargetBranch string
-	JiraFile     string
+	Jira         JiraSource
 	Verbose      bool
 	Debug        bool
 }
@@ -76,12 +75,12 @@ func (g *DescriptionGenerator) GenerateDescription(ctx context.Context, opts *Ge
 	}
 
 	var jiraContext string
-	if opts.JiraFile != "" {
+	if opts.Jira != nil {
 		if opts.Verbose {
 			g.logStep("📋 Reading JIRA context...")
 		}
 
-		jiraContext, err = g.readJiraContext(opts.JiraFile)
+		jiraContext, err = opts.Jira.JiraContext(ctx)
 		if err != nil {
 			return nil, fmt.Errorf("failed to read JIRA context: %w", err)
 		}
@@ -207,7 +206,7 @@ func (g *DescriptionGenerator) generateWithOpenAI(ctx context.Context, opts *Gen
 		Metadata: map[string]interface{}{
 			"branch_name":   opts.SourceBranch,
 			"target_branch": opts.TargetBranch,
-			"has_jira":      opts.JiraFile != "",
+			"has_jira":      opts.Jira != nil,
 			"openai_used":   true,
 			"files_changed": diffData.Stats.FilesChanged,
 			"lines_added":   diffData.Stats.LinesAdded,
@@ -252,7 +251,7 @@ func (g *DescriptionGenerator) generateWithLocalTemplates(_ context.Context, opt
 		Metadata: map[string]interface{}{
 			"branch_name":   opts.SourceBranch,
 			"target_branch": opts.TargetBranch,
-			"has_jira":      opts.JiraFile != "",
+			"has_jira":      opts.Jira != nil,
 			"change_types":  analysis.ChangeTypes,
 			"openai_used":   false,
 			"files_changed": diffData.Stats.FilesChanged,
@@ -363,19 +362,6 @@ func (g *DescriptionGenerator) analyzeDiff(diffData *DiffData) (*DiffAnalysis, e
 	return analyzer.Analyze(diffData)
 }
 
-func (g *DescriptionGenerator) readJiraContext(jiraFile string) (string, error) {
-	if _, err := os.Stat(jiraFile); os.IsNotExist(err) {
-		return "", fmt.Errorf("JIRA context file not found: %s", jiraFile)
-	}
-
-	content, err := os.ReadFile(jiraFile)
-	if err != nil {
-		return "", fmt.Errorf("failed to read JIRA context file: %w", err)
-	}
-
-	return string(content), nil
-}
-
 func (g *DescriptionGenerator) buildTemplateVariables(branchContext *BranchContext, analysis *DiffAnalysis, jiraContext string, stats *utils.DiffStats) map[string]interface{} {
 	changeType := g.detectChangeType(branchContext)
 
@@ -0,0 +1,157 @@
+package ai
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// Environment variables holding the JIRA credentials used to fetch tickets
+const (
+	EnvJiraToken = "JIRA_API_TOKEN"
+	EnvJiraEmail = "JIRA_EMAIL"
+)
+
+// jiraKeyPattern matches a JIRA ticket key such as PROJ-123
+var jiraKeyPattern = regexp.MustCompile(`^[A-Z][A-Z0-9_]+-[0-9]+$`)
+
+// JiraSource supplies the JIRA context a pull request description is
+// generated from
+type JiraSource interface {
+	JiraContext(ctx context.Context) (string, error)
+}
+
+// NewJiraSource resolves a --jira value. An existing file is read as a
+// markdown context file; a ticket key such as PROJ-123 is fetched from the
+// JIRA instance at baseURL with the token in JIRA_API_TOKEN.
+func NewJiraSource(value, baseURL string) (JiraSource, error) {
+	if _, err := os.Stat(value); err == nil {
+		return JiraFile(value), nil
+	}
+	if !jiraKeyPattern.MatchString(value) {
+		return nil, fmt.Errorf("JIRA context file not found: %s", value)
+	}
+
+	if baseURL == "" {
+		return nil, fmt.Errorf("%s looks like a JIRA ticket; set jira.base_url to fetch it (bt config set jira.base_url https://yourcompany.atlassian.net) or pass a context file", value)
+	}
+	token := os.Getenv(EnvJiraToken)
+	if token == "" {
+		return nil, fmt.Errorf("%s environment variable is required to fetch JIRA ticket %s", EnvJiraToken, value)
+	}
+
+	return &JiraIssue{
+		BaseURL: strings.TrimRight(baseURL, "/"),
+		Key:     value,
+		Email:   os.Getenv(EnvJiraEmail),
+		Token:   token,
+	}, nil
+}
+
+// JiraFile is a markdown file holding the JIRA context
+type JiraFile string
+
+func (f JiraFile) JiraContext(_ context.Context) (string, error) {
+	content, err := os.ReadFile(string(f))
+	if err != nil {
+		return "", fmt.Errorf("failed to read JIRA context file: %w", err)
+	}
+	return string(content), nil
+}
+
+// JiraIssue is a ticket fetched from a JIRA instance's REST API
+type JiraIssue struct {
+	BaseURL string
+	Key     string
+	// Email and Token authenticate with basic auth, as JIRA Cloud expects;
+	// without Email, Token is sent as a bearer token (JIRA Server and Data
+	// Center personal access tokens)
+	Email      string
+	Token      string
+	HTTPClient *http.Client
+}
+
+type jiraIssueResponse struct {
+	Key    string `json:"key"`
+	Fields struct {
+		Summary     string `json:"summary"`
+		Description string `json:"description"`
+		IssueType   *struct {
+			Name string `json:"name"`
+		} `json:"issuetype"`
+		Status *struct {
+			Name string `json:"name"`
+		} `json:"status"`
+	} `json:"fields"`
+}
+
+func (j *JiraIssue) JiraContext(ctx context.Context) (string, error) {
+	endpoint := fmt.Sprintf("%s/rest/api/2/issue/%s?fields=summary,description,issuetype,status", j.BaseURL, url.PathEscape(j.Key))
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to build JIRA request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+	if j.Email != "" {
+		req.SetBasicAuth(j.Email, j.Token)
+	} else {
+		req.Header.Set("Authorization", "Bearer "+j.Token)
+	}
+
+	client := j.HTTPClient
+	if client == nil {
+		client = &http.Client{Timeout: 30 * time.Second}
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to fetch JIRA ticket %s: %w", j.Key, err)
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		return "", fmt.Errorf("JIRA ticket %s not found", j.Key)
+	case resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden:
+		return "", fmt.Errorf("JIRA rejected the credentials fetching %s (HTTP %d); check %s and %s", j.Key, resp.StatusCode, EnvJiraToken, EnvJiraEmail)
+	case resp.StatusCode >= 300:
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return "", fmt.Errorf("failed to fetch JIRA ticket %s: HTTP %d: %s", j.Key, resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+
+	var issue jiraIssueResponse
+	if err := json.NewDecoder(resp.Body).Decode(&issue); err != nil {
+		return "", fmt.Errorf("failed to parse JIRA ticket %s: %w", j.Key, err)
+	}
+	return j.format(&issue), nil
+}
+
+// format renders the ticket as the markdown a context file would hold,
+// leading with the key so the template can link it
+func (j *JiraIssue) format(issue *jiraIssueResponse) string {
+	key := issue.Key
+	if key == "" {
+		key = j.Key
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "JIRA ticket: %s\n", key)
+	fmt.Fprintf(&b, "URL: %s/browse/%s\n", j.BaseURL, key)
+	fmt.Fprintf(&b, "Summary: %s\n", issue.Fields.Summary)
+	if issue.Fields.IssueType != nil && issue.Fields.IssueType.Name != "" {
+		fmt.Fprintf(&b, "Type: %s\n", issue.Fields.IssueType.Name)
+	}
+	if issue.Fields.Status != nil && issue.Fields.Status.Name != "" {
+		fmt.Fprintf(&b, "Status: %s\n", issue.Fields.Status.Name)
+	}
+	if description := strings.TrimSpace(issue.Fields.Description); description != "" {
+		fmt.Fprintf(&b, "\nDescription:\n%s\n", description)
+	}
+	return b.String()
+}
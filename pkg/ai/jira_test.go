@@ -0,0 +1,87 @@
+package ai
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewJiraSource(t *testing.T) {
+	t.Setenv(EnvJiraToken, "")
+	t.Setenv(EnvJiraEmail, "")
+
+	file := filepath.Join(t.TempDir(), "PROJ-1.md")
+	require.NoError(t, os.WriteFile(file, []byte("PROJ-1 context"), 0644))
+
+	source, err := NewJiraSource(file, "")
+	require.NoError(t, err)
+	assert.Equal(t, JiraFile(file), source, "an existing file is read as before")
+
+	_, err = NewJiraSource("missing.md", "https://jira.example.com")
+	assert.EqualError(t, err, "JIRA context file not found: missing.md")
+
+	_, err = NewJiraSource("PROJ-123", "")
+	assert.ErrorContains(t, err, "set jira.base_url")
+
+	_, err = NewJiraSource("PROJ-123", "https://jira.example.com")
+	assert.ErrorContains(t, err, "JIRA_API_TOKEN environment variable is required")
+
+	t.Setenv(EnvJiraToken, "secret")
+	t.Setenv(EnvJiraEmail, "dev@example.com")
+	source, err = NewJiraSource("PROJ-123", "https://jira.example.com/")
+	require.NoError(t, err)
+	assert.Equal(t, &JiraIssue{BaseURL: "https://jira.example.com", Key: "PROJ-123", Email: "dev@example.com", Token: "secret"}, source)
+}
+
+func TestJiraIssue_JiraContext(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/rest/api/2/issue/PROJ-123" {
+			http.NotFound(w, r)
+			return
+		}
+		if user, pass, ok := r.BasicAuth(); !ok || user != "dev@example.com" || pass != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Write([]byte(`{"key":"PROJ-123","fields":{"summary":"Fix login timeout","description":"Sessions expire after 5 minutes.","issuetype":{"name":"Bug"},"status":{"name":"In Progress"}}}`))
+	}))
+	defer server.Close()
+
+	issue := &JiraIssue{BaseURL: server.URL, Key: "PROJ-123", Email: "dev@example.com", Token: "secret"}
+	jiraContext, err := issue.JiraContext(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "JIRA ticket: PROJ-123\n"+
+		"URL: "+server.URL+"/browse/PROJ-123\n"+
+		"Summary: Fix login timeout\n"+
+		"Type: Bug\n"+
+		"Status: In Progress\n"+
+		"\nDescription:\nSessions expire after 5 minutes.\n", jiraContext)
+	assert.Equal(t, "PROJ-123", (&DescriptionGenerator{}).extractJiraTicket(jiraContext))
+
+	issue.Token = "wrong"
+	_, err = issue.JiraContext(context.Background())
+	assert.ErrorContains(t, err, "JIRA rejected the credentials")
+
+	issue.Key = "PROJ-404"
+	_, err = issue.JiraContext(context.Background())
+	assert.EqualError(t, err, "JIRA ticket PROJ-404 not found")
+}
+
+func TestJiraIssue_BearerToken(t *testing.T) {
+	var authorization string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authorization = r.Header.Get("Authorization")
+		w.Write([]byte(`{"key":"OPS-7","fields":{"summary":"Rotate keys"}}`))
+	}))
+	defer server.Close()
+
+	_, err := (&JiraIssue{BaseURL: server.URL, Key: "OPS-7", Token: "pat"}).JiraContext(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "Bearer pat", authorization)
+}
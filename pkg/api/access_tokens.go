@@ -0,0 +1,97 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// RepositoryTokenScopes are the scopes a repository access token may be granted
+var RepositoryTokenScopes = []string{
+	"repository",
+	"repository:write",
+	"repository:admin",
+	"repository:delete",
+	"pullrequest",
+	"pullrequest:write",
+	"webhook",
+	"pipeline",
+	"pipeline:write",
+	"pipeline:variable",
+	"runner",
+	"runner:write",
+}
+
+// AccessTokenService handles repository access token operations
+type AccessTokenService struct {
+	client *Client
+}
+
+// NewAccessTokenService creates a new access token service
+func NewAccessTokenService(client *Client) *AccessTokenService {
+	return &AccessTokenService{
+		client: client,
+	}
+}
+
+// AccessToken is a repository access token. Token is only populated in the
+// response to a create request; Bitbucket never returns it again.
+type AccessToken struct {
+	ID        string     `json:"id,omitempty"`
+	Name      string     `json:"name"`
+	Scopes    []string   `json:"scopes"`
+	Token     string     `json:"token,omitempty"`
+	CreatedOn *time.Time `json:"created_on,omitempty"`
+	ExpiresOn *time.Time `json:"expires_on,omitempty"`
+}
+
+// CreateAccessTokenRequest is the body of a repository access token create request
+type CreateAccessTokenRequest struct {
+	Name      string     `json:"name"`
+	Scopes    []string   `json:"scopes"`
+	ExpiresOn *time.Time `json:"expires_on,omitempty"`
+}
+
+// CreateRepositoryAccessToken creates an access token scoped to one repository
+func (s *AccessTokenService) CreateRepositoryAccessToken(ctx context.Context, workspace, repoSlug string, request *CreateAccessTokenRequest) (*AccessToken, error) {
+	if workspace == "" || repoSlug == "" {
+		return nil, NewValidationError("workspace and repository slug are required", "")
+	}
+
+	if request == nil || request.Name == "" {
+		return nil, NewValidationError("access token name is required", "")
+	}
+
+	if err := ValidateTokenScopes(request.Scopes); err != nil {
+		return nil, err
+	}
+
+	endpoint := fmt.Sprintf("repositories/%s/%s/access-tokens", workspace, repoSlug)
+
+	var result AccessToken
+	if err := s.client.PostJSON(ctx, endpoint, request, &result); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// ValidateTokenScopes checks that scopes is non-empty and only contains
+// scopes a repository access token can hold
+func ValidateTokenScopes(scopes []string) error {
+	if len(scopes) == 0 {
+		return NewValidationError("at least one scope is required", "")
+	}
+
+	allowed := make(map[string]bool, len(RepositoryTokenScopes))
+	for _, scope := range RepositoryTokenScopes {
+		allowed[scope] = true
+	}
+
+	for _, scope := range scopes {
+		if !allowed[scope] {
+			return NewValidationError(fmt.Sprintf("invalid scope %q", scope), fmt.Sprintf("allowed scopes: %v", RepositoryTokenScopes))
+		}
+	}
+	return nil
+}
@@ -0,0 +1,91 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func newAccessTokenTestClient(t *testing.T, handler http.HandlerFunc) *Client {
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	mockAuth := &MockAuthManager{}
+	mockAuth.On("SetHTTPHeaders", mock.AnythingOfType("*http.Request")).Return(nil)
+
+	client, err := NewClient(mockAuth, &ClientConfig{
+		BaseURL:       server.URL,
+		Timeout:       5 * time.Second,
+		RetryAttempts: 1,
+		UserAgent:     "bt/test",
+	})
+	require.NoError(t, err)
+	return client
+}
+
+func TestAccessTokenService_CreateRepositoryAccessToken(t *testing.T) {
+	client := newAccessTokenTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "/repositories/ws/repo/access-tokens", r.URL.Path)
+
+		var body CreateAccessTokenRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		assert.Equal(t, "ci", body.Name)
+		assert.Equal(t, []string{"repository", "pipeline:write"}, body.Scopes)
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"id":"42","name":"ci","scopes":["repository","pipeline:write"],"token":"secret"}`))
+	})
+
+	token, err := client.AccessTokens.CreateRepositoryAccessToken(context.Background(), "ws", "repo", &CreateAccessTokenRequest{
+		Name:   "ci",
+		Scopes: []string{"repository", "pipeline:write"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "42", token.ID)
+	assert.Equal(t, "secret", token.Token)
+	assert.Equal(t, []string{"repository", "pipeline:write"}, token.Scopes)
+}
+
+func TestAccessTokenService_CreateRepositoryAccessToken_Validation(t *testing.T) {
+	client := newAccessTokenTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request to %s", r.URL.Path)
+	})
+
+	tests := []struct {
+		name      string
+		workspace string
+		repo      string
+		request   *CreateAccessTokenRequest
+	}{
+		{"missing repository", "ws", "", &CreateAccessTokenRequest{Name: "ci", Scopes: []string{"repository"}}},
+		{"missing name", "ws", "repo", &CreateAccessTokenRequest{Scopes: []string{"repository"}}},
+		{"no scopes", "ws", "repo", &CreateAccessTokenRequest{Name: "ci"}},
+		{"unknown scope", "ws", "repo", &CreateAccessTokenRequest{Name: "ci", Scopes: []string{"account"}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := client.AccessTokens.CreateRepositoryAccessToken(context.Background(), tt.workspace, tt.repo, tt.request)
+			var bbErr *BitbucketError
+			require.ErrorAs(t, err, &bbErr)
+			assert.Equal(t, ErrorTypeValidation, bbErr.Type)
+		})
+	}
+}
+
+func TestValidateTokenScopes(t *testing.T) {
+	assert.NoError(t, ValidateTokenScopes(RepositoryTokenScopes))
+
+	err := ValidateTokenScopes([]string{"repository", "pullrequest:admin"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "pullrequest:admin")
+}
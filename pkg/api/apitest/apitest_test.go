@@ -0,0 +1,115 @@
+package apitest
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func get(t *testing.T, transport http.RoundTripper, url string) (int, string, error) {
+	t.Helper()
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	require.NoError(t, err)
+
+	resp, err := transport.RoundTrip(req)
+	if err != nil {
+		return 0, "", err
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	return resp.StatusCode, string(body), nil
+}
+
+func TestReplayTransport_Matching(t *testing.T) {
+	transport := NewReplayTransport(
+		Fixture{Method: "GET", Path: "/repositories/ws/repo/pipelines", Query: "page=2", Body: []byte(`{"page":2}`)},
+		Fixture{Method: "GET", Path: "/repositories/ws/repo/pipelines", Body: []byte(`{"page":1}`)},
+	)
+
+	status, body, err := get(t, transport, "https://api.bitbucket.org/2.0/repositories/ws/repo/pipelines?page=2&pagelen=10")
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, status)
+	assert.JSONEq(t, `{"page":2}`, body)
+
+	_, body, err = get(t, transport, "https://api.bitbucket.org/2.0/repositories/ws/repo/pipelines?pagelen=10")
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"page":1}`, body)
+
+	_, _, err = get(t, transport, "https://api.bitbucket.org/2.0/repositories/ws/repo/pullrequests")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "no fixture for GET /2.0/repositories/ws/repo/pullrequests")
+
+	requests := transport.Requests()
+	require.Len(t, requests, 3)
+	assert.Equal(t, "/repositories/ws/repo/pipelines", requests[0].Path)
+}
+
+func TestReplayTransport_Sequence(t *testing.T) {
+	transport := NewReplayTransport(
+		Fixture{Method: "GET", Path: "/p", Body: []byte(`{"state":"IN_PROGRESS"}`)},
+		Fixture{Method: "GET", Path: "/p", Body: []byte(`{"state":"COMPLETED"}`)},
+		Fixture{Method: "GET", Path: "/missing", Status: http.StatusNotFound, Body: []byte(`{"error":{"message":"gone"}}`)},
+	)
+
+	var states []string
+	for i := 0; i < 3; i++ {
+		_, body, err := get(t, transport, "https://api.bitbucket.org/2.0/p")
+		require.NoError(t, err)
+		states = append(states, body)
+	}
+	assert.Equal(t, []string{`{"state":"IN_PROGRESS"}`, `{"state":"COMPLETED"}`, `{"state":"COMPLETED"}`}, states)
+
+	status, _, err := get(t, transport, "https://api.bitbucket.org/2.0/missing")
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusNotFound, status)
+}
+
+func TestRecordingTransport_RoundTrip(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/2.0/repositories/ws/repo":
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(`{"full_name":"ws/repo"}`))
+		default:
+			w.Header().Set("Content-Type", "text/plain")
+			w.Write([]byte("+ make test\nok\n"))
+		}
+	}))
+	defer server.Close()
+
+	dir := t.TempDir()
+	recorder := NewRecordingTransport(dir, nil)
+
+	_, body, err := get(t, recorder, server.URL+"/2.0/repositories/ws/repo")
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"full_name":"ws/repo"}`, body)
+	_, _, err = get(t, recorder, server.URL+"/2.0/repositories/ws/repo/pipelines/1/steps/2/log")
+	require.NoError(t, err)
+
+	fixtures, err := LoadFixtures(dir)
+	require.NoError(t, err)
+	require.Len(t, fixtures, 2)
+	assert.Equal(t, "/repositories/ws/repo", fixtures[0].Path)
+	assert.Equal(t, "+ make test\nok\n", fixtures[1].RawBody)
+
+	// What was recorded replays through an API client
+	replay, err := LoadReplayTransport(dir)
+	require.NoError(t, err)
+	client := NewClient(t, replay)
+
+	repository, err := client.Repositories.GetRepository(context.Background(), "ws", "repo")
+	require.NoError(t, err)
+	assert.Equal(t, "ws/repo", repository.FullName)
+}
+
+func TestFixtureSlug(t *testing.T) {
+	assert.Equal(t, "ws_repo_pullrequests_7_diffstat", fixtureSlug("/repositories/ws/repo/pullrequests/7/diffstat"))
+	assert.False(t, strings.ContainsAny(fixtureSlug("/repositories/ws/repo/refs/branches/{uuid}"), "/{}"))
+}
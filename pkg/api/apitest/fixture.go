@@ -0,0 +1,128 @@
+// Package apitest serves canned Bitbucket API responses, so services and
+// commands can be tested without a live account or an httptest server per
+// test. Responses are golden files recorded with RecordingTransport and
+// replayed with ReplayTransport.
+package apitest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// APIPrefix is the version prefix of Bitbucket API paths. Fixture paths leave
+// it out so they read like the endpoints used in pkg/api.
+const APIPrefix = "/2.0"
+
+// Fixture is one recorded API exchange, stored as a JSON golden file
+type Fixture struct {
+	Method string `json:"method"`
+	// Path is the request path without APIPrefix, e.g.
+	// /repositories/ws/repo/pullrequests/1
+	Path string `json:"path"`
+	// Query, when set, must be a subset of the request's query parameters
+	Query  string            `json:"query,omitempty"`
+	Status int               `json:"status"`
+	Header map[string]string `json:"header,omitempty"`
+	// Body holds JSON responses; RawBody holds anything else, such as logs
+	Body    json.RawMessage `json:"body,omitempty"`
+	RawBody string          `json:"raw_body,omitempty"`
+}
+
+// matches reports whether the fixture answers a request for method and u
+func (f *Fixture) matches(method string, u *url.URL) bool {
+	if !strings.EqualFold(f.Method, method) || f.Path != trimAPIPrefix(u.Path) {
+		return false
+	}
+	if f.Query == "" {
+		return true
+	}
+
+	want, err := url.ParseQuery(f.Query)
+	if err != nil {
+		return false
+	}
+	got := u.Query()
+	for key, values := range want {
+		if strings.Join(got[key], ",") != strings.Join(values, ",") {
+			return false
+		}
+	}
+	return true
+}
+
+func (f *Fixture) body() []byte {
+	if len(f.Body) > 0 {
+		return f.Body
+	}
+	return []byte(f.RawBody)
+}
+
+// LoadFixtures reads every *.json fixture in dir, in file name order
+func LoadFixtures(dir string) ([]Fixture, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(paths)
+
+	fixtures := make([]Fixture, 0, len(paths))
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read fixture: %w", err)
+		}
+
+		var fixture Fixture
+		if err := json.Unmarshal(data, &fixture); err != nil {
+			return nil, fmt.Errorf("invalid fixture %s: %w", path, err)
+		}
+		if fixture.Status == 0 {
+			fixture.Status = 200
+		}
+		fixtures = append(fixtures, fixture)
+	}
+
+	return fixtures, nil
+}
+
+// SaveFixture writes fixture to dir as <seq>_<method>_<path>.json
+func SaveFixture(dir string, seq int, fixture Fixture) error {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(fixture, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	name := fmt.Sprintf("%03d_%s_%s.json", seq, strings.ToLower(fixture.Method), fixtureSlug(fixture.Path))
+	return os.WriteFile(filepath.Join(dir, name), append(data, '\n'), 0644)
+}
+
+// fixtureSlug turns an API path into a file name fragment
+func fixtureSlug(path string) string {
+	path = strings.TrimPrefix(path, "/repositories/")
+	slug := strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '-':
+			return r
+		default:
+			return '_'
+		}
+	}, strings.Trim(path, "/"))
+
+	if len(slug) > 80 {
+		slug = slug[:80]
+	}
+	return slug
+}
+
+func trimAPIPrefix(path string) string {
+	return strings.TrimPrefix(path, APIPrefix)
+}
@@ -0,0 +1,72 @@
+package apitest
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"sync"
+)
+
+// RecordingTransport passes requests to Base and saves every response to
+// Dir as a fixture ReplayTransport can serve. Only the response body, status
+// and content type are saved, never request headers or credentials.
+type RecordingTransport struct {
+	Base http.RoundTripper
+	Dir  string
+
+	mu  sync.Mutex
+	seq int
+}
+
+// NewRecordingTransport records into dir; a nil base uses
+// http.DefaultTransport
+func NewRecordingTransport(dir string, base http.RoundTripper) *RecordingTransport {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return &RecordingTransport{Base: base, Dir: dir}
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *RecordingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.Base.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	fixture := Fixture{
+		Method: req.Method,
+		Path:   trimAPIPrefix(req.URL.Path),
+		Query:  req.URL.RawQuery,
+		Status: resp.StatusCode,
+	}
+
+	var indented bytes.Buffer
+	if json.Indent(&indented, body, "", "  ") == nil {
+		fixture.Body = indented.Bytes()
+	} else {
+		fixture.RawBody = string(body)
+		if contentType := resp.Header.Get("Content-Type"); contentType != "" {
+			fixture.Header = map[string]string{"Content-Type": contentType}
+		}
+	}
+
+	t.mu.Lock()
+	t.seq++
+	seq := t.seq
+	t.mu.Unlock()
+
+	if err := SaveFixture(t.Dir, seq, fixture); err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
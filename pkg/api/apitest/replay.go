@@ -0,0 +1,124 @@
+package apitest
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+)
+
+// Request is a request seen by ReplayTransport, kept for assertions
+type Request struct {
+	Method string
+	Path   string
+	Query  string
+	Body   []byte
+}
+
+// ReplayTransport answers requests from fixtures. Each fixture is served
+// once, in order, so repeated requests (polling, retries) can get different
+// responses; once every matching fixture is used the last one keeps being
+// served. Requests without a fixture fail with an error naming them.
+type ReplayTransport struct {
+	mu       sync.Mutex
+	fixtures []Fixture
+	used     []bool
+	requests []Request
+}
+
+// NewReplayTransport creates a transport serving fixtures
+func NewReplayTransport(fixtures ...Fixture) *ReplayTransport {
+	t := &ReplayTransport{}
+	for _, f := range fixtures {
+		t.Add(f)
+	}
+	return t
+}
+
+// LoadReplayTransport creates a transport serving the fixtures in dir
+func LoadReplayTransport(dir string) (*ReplayTransport, error) {
+	fixtures, err := LoadFixtures(dir)
+	if err != nil {
+		return nil, err
+	}
+	return NewReplayTransport(fixtures...), nil
+}
+
+// Add appends a fixture
+func (t *ReplayTransport) Add(f Fixture) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if f.Status == 0 {
+		f.Status = http.StatusOK
+	}
+	t.fixtures = append(t.fixtures, f)
+	t.used = append(t.used, false)
+}
+
+// Requests returns the requests served so far
+func (t *ReplayTransport) Requests() []Request {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return append([]Request(nil), t.requests...)
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *ReplayTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	var body []byte
+	if req.Body != nil {
+		var err error
+		if body, err = io.ReadAll(req.Body); err != nil {
+			return nil, err
+		}
+		req.Body.Close()
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.requests = append(t.requests, Request{
+		Method: req.Method,
+		Path:   trimAPIPrefix(req.URL.Path),
+		Query:  req.URL.RawQuery,
+		Body:   body,
+	})
+
+	match := -1
+	for i := range t.fixtures {
+		if !t.fixtures[i].matches(req.Method, req.URL) {
+			continue
+		}
+		match = i
+		if !t.used[i] {
+			break
+		}
+	}
+	if match < 0 {
+		return nil, fmt.Errorf("apitest: no fixture for %s %s", req.Method, req.URL.RequestURI())
+	}
+	t.used[match] = true
+
+	fixture := t.fixtures[match]
+	header := make(http.Header)
+	header.Set("Content-Type", "application/json")
+	if len(fixture.Body) == 0 && fixture.RawBody != "" {
+		header.Set("Content-Type", "text/plain")
+	}
+	for key, value := range fixture.Header {
+		header.Set(key, value)
+	}
+
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", fixture.Status, http.StatusText(fixture.Status)),
+		StatusCode:    fixture.Status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader(fixture.body())),
+		ContentLength: int64(len(fixture.body())),
+		Request:       req,
+	}, nil
+}
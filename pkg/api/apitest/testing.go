@@ -0,0 +1,74 @@
+package apitest
+
+import (
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/carlosarraes/bt/pkg/api"
+)
+
+// RecordEnv turns on recording: with BT_RECORD_FIXTURES=1, Transport calls
+// the live API and rewrites the fixtures
+const RecordEnv = "BT_RECORD_FIXTURES"
+
+// Recording reports whether tests record fixtures instead of replaying them
+func Recording() bool {
+	return os.Getenv(RecordEnv) == "1"
+}
+
+// Transport returns the transport for a test's fixture directory: it replays
+// dir, or records into it against the live API when BT_RECORD_FIXTURES=1
+func Transport(t testing.TB, dir string) http.RoundTripper {
+	t.Helper()
+
+	if Recording() {
+		if err := os.RemoveAll(dir); err != nil {
+			t.Fatalf("failed to clear fixtures: %v", err)
+		}
+		return NewRecordingTransport(dir, nil)
+	}
+
+	transport, err := LoadReplayTransport(dir)
+	if err != nil {
+		t.Fatalf("failed to load fixtures: %v", err)
+	}
+	return transport
+}
+
+// NewClient returns an unauthenticated API client whose requests go to
+// transport
+func NewClient(t testing.TB, transport http.RoundTripper) *api.Client {
+	t.Helper()
+
+	client, err := api.NewClient(nil, &api.ClientConfig{
+		BaseURL:       api.DefaultBaseURL,
+		Timeout:       5 * time.Second,
+		RetryAttempts: 0,
+		UserAgent:     "bt/test",
+		Transport:     transport,
+	})
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	return client
+}
+
+// CommandEnv prepares the environment so commands can build their context
+// without a login or a Bitbucket git remote: workspace and repository come
+// from BITBUCKET_WORKSPACE/BITBUCKET_REPOSITORY and the config is empty.
+// While recording, the real credentials are left in place.
+func CommandEnv(t testing.TB, workspace, repository string) {
+	t.Helper()
+
+	t.Setenv("BT_CONFIG_PATH", filepath.Join(t.TempDir(), "config.yml"))
+	t.Setenv("BITBUCKET_WORKSPACE", workspace)
+	t.Setenv("BITBUCKET_REPOSITORY", repository)
+
+	if !Recording() {
+		t.Setenv("BITBUCKET_EMAIL", "test@example.com")
+		t.Setenv("BITBUCKET_API_TOKEN", "test-token")
+	}
+}
@@ -0,0 +1,161 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+)
+
+// DefaultArtifactDownloadAttempts is how many times DownloadArtifactToFile
+// tries a download before giving up
+const DefaultArtifactDownloadAttempts = 3
+
+// ArtifactDownloadOptions controls DownloadArtifactToFile
+type ArtifactDownloadOptions struct {
+	// Verify checks the downloaded file against the artifact's size and
+	// downloads again on a mismatch. Bitbucket's download metadata carries
+	// no checksum, so the size is all there is to verify.
+	Verify bool
+	// Attempts defaults to DefaultArtifactDownloadAttempts
+	Attempts int
+}
+
+// DownloadArtifactToFile downloads artifact to path and returns the size of
+// the file. The download goes to path.part, which is renamed to path once it
+// is complete, so an existing file at path is only ever replaced whole. A
+// .part file left by an interrupted download is resumed with a Range request
+// instead of being fetched again.
+func (p *PipelineService) DownloadArtifactToFile(ctx context.Context, workspace, repoSlug string, artifact *Artifact, path string, opts *ArtifactDownloadOptions) (int64, error) {
+	if workspace == "" || repoSlug == "" || artifact == nil || artifact.Name == "" {
+		return 0, NewValidationError("workspace, repository slug, and artifact name are required", "")
+	}
+	if opts == nil {
+		opts = &ArtifactDownloadOptions{Verify: true}
+	}
+	attempts := opts.Attempts
+	if attempts <= 0 {
+		attempts = DefaultArtifactDownloadAttempts
+	}
+	verify := opts.Verify && artifact.Size > 0
+	partPath := path + ".part"
+
+	var lastErr error
+	for attempt := 0; attempt < attempts; attempt++ {
+		offset, err := partialSize(partPath)
+		if err != nil {
+			return 0, err
+		}
+
+		if verify && offset > artifact.Size {
+			// Longer than the artifact: nothing in it can be trusted
+			if err := os.Truncate(partPath, 0); err != nil {
+				return 0, fmt.Errorf("failed to reset %s: %w", partPath, err)
+			}
+			offset = 0
+		}
+		if verify && offset == artifact.Size {
+			return offset, finishArtifactDownload(partPath, path)
+		}
+
+		size, err := p.downloadArtifactFrom(ctx, workspace, repoSlug, artifact.Name, partPath, offset)
+		if err != nil {
+			if ctx.Err() != nil {
+				return 0, ctx.Err()
+			}
+			lastErr = err
+			continue
+		}
+
+		if !verify || size == artifact.Size {
+			return size, finishArtifactDownload(partPath, path)
+		}
+		if size > artifact.Size {
+			// Resuming won't shrink it; start over on the next attempt
+			if err := os.Truncate(partPath, 0); err != nil {
+				return 0, fmt.Errorf("failed to reset %s: %w", partPath, err)
+			}
+		}
+		lastErr = fmt.Errorf("downloaded %d bytes, expected %d", size, artifact.Size)
+	}
+
+	return 0, fmt.Errorf("failed to download artifact %s after %d attempt(s): %w", artifact.Name, attempts, lastErr)
+}
+
+// finishArtifactDownload moves a completed download into place
+func finishArtifactDownload(partPath, path string) error {
+	if err := os.Rename(partPath, path); err != nil {
+		return fmt.Errorf("failed to move download to %s: %w", path, err)
+	}
+	return nil
+}
+
+// downloadArtifactFrom fetches the artifact from offset onwards into path,
+// appending when the server honours the range and rewriting the file when it
+// sends everything, and returns the resulting file size
+func (p *PipelineService) downloadArtifactFrom(ctx context.Context, workspace, repoSlug, name, path string, offset int64) (int64, error) {
+	endpoint := fmt.Sprintf("repositories/%s/%s/downloads/%s", workspace, repoSlug, url.PathEscape(name))
+
+	fullURL, err := p.client.buildURL(endpoint)
+	if err != nil {
+		return 0, fmt.Errorf("failed to build URL: %w", err)
+	}
+
+	req, err := p.client.createRequest(ctx, "GET", fullURL, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Accept", "*/*")
+	if offset > 0 {
+		req.Header.Set("Range", fmt.Sprintf("bytes=%d-", offset))
+	}
+
+	resp, err := p.client.doRequestWithRetry(req)
+	if err != nil {
+		var bbErr *BitbucketError
+		if offset > 0 && errors.As(err, &bbErr) && bbErr.StatusCode == http.StatusRequestedRangeNotSatisfiable {
+			// The partial file doesn't fit the artifact; start over
+			if truncErr := os.Truncate(path, 0); truncErr != nil {
+				return 0, fmt.Errorf("failed to reset %s: %w", path, truncErr)
+			}
+		}
+		return 0, fmt.Errorf("failed to download artifact: %w", err)
+	}
+	defer resp.Body.Close()
+
+	flags := os.O_CREATE | os.O_WRONLY | os.O_TRUNC
+	if resp.StatusCode == http.StatusPartialContent {
+		flags = os.O_CREATE | os.O_WRONLY | os.O_APPEND
+	}
+
+	file, err := os.OpenFile(path, flags, 0o644)
+	if err != nil {
+		return 0, fmt.Errorf("failed to open %s: %w", path, err)
+	}
+
+	_, copyErr := io.Copy(file, resp.Body)
+	closeErr := file.Close()
+	if copyErr != nil {
+		return 0, fmt.Errorf("download interrupted: %w", copyErr)
+	}
+	if closeErr != nil {
+		return 0, fmt.Errorf("failed to write %s: %w", path, closeErr)
+	}
+
+	return partialSize(path)
+}
+
+// partialSize returns the size of the file at path, 0 when it doesn't exist
+func partialSize(path string) (int64, error) {
+	info, err := os.Stat(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to stat %s: %w", path, err)
+	}
+	return info.Size(), nil
+}
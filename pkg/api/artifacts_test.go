@@ -0,0 +1,115 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var artifactContent = []byte(strings.Repeat("release-tarball-", 64))
+
+func TestDownloadArtifactToFile_ResumesPartialDownload(t *testing.T) {
+	var ranges []string
+	client := newAccessTokenTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/repositories/ws/repo/downloads/app v1.tar.gz", r.URL.Path)
+		ranges = append(ranges, r.Header.Get("Range"))
+		http.ServeContent(w, r, "app.tar.gz", time.Time{}, bytes.NewReader(artifactContent))
+	})
+
+	path := filepath.Join(t.TempDir(), "app.tar.gz")
+	require.NoError(t, os.WriteFile(path+".part", artifactContent[:100], 0o644))
+
+	artifact := &Artifact{Name: "app v1.tar.gz", Size: int64(len(artifactContent))}
+	size, err := client.Pipelines.DownloadArtifactToFile(context.Background(), "ws", "repo", artifact, path, nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, int64(len(artifactContent)), size)
+	assert.Equal(t, []string{"bytes=100-"}, ranges)
+	got, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, artifactContent, got)
+	assert.NoFileExists(t, path+".part")
+}
+
+func TestDownloadArtifactToFile_ReplacesExistingFile(t *testing.T) {
+	var ranges []string
+	client := newAccessTokenTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		ranges = append(ranges, r.Header.Get("Range"))
+		http.ServeContent(w, r, "app.tar.gz", time.Time{}, bytes.NewReader(artifactContent))
+	})
+
+	// Same size as the artifact but different content: it must not be
+	// taken as already downloaded or appended to
+	path := filepath.Join(t.TempDir(), "app.tar.gz")
+	require.NoError(t, os.WriteFile(path, bytes.Repeat([]byte("x"), len(artifactContent)), 0o644))
+
+	artifact := &Artifact{Name: "app.tar.gz", Size: int64(len(artifactContent))}
+	_, err := client.Pipelines.DownloadArtifactToFile(context.Background(), "ws", "repo", artifact, path, nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{""}, ranges)
+	got, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, artifactContent, got)
+}
+
+func TestDownloadArtifactToFile_RetriesOnSizeMismatch(t *testing.T) {
+	requests := 0
+	client := newAccessTokenTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if requests == 1 {
+			// A download cut short without an error
+			w.Write(artifactContent[:10])
+			return
+		}
+		http.ServeContent(w, r, "app.tar.gz", time.Time{}, bytes.NewReader(artifactContent))
+	})
+
+	path := filepath.Join(t.TempDir(), "app.tar.gz")
+	artifact := &Artifact{Name: "app.tar.gz", Size: int64(len(artifactContent))}
+	_, err := client.Pipelines.DownloadArtifactToFile(context.Background(), "ws", "repo", artifact, path, &ArtifactDownloadOptions{Verify: true})
+	require.NoError(t, err)
+
+	assert.Equal(t, 2, requests)
+	got, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, artifactContent, got)
+}
+
+func TestDownloadArtifactToFile_GivesUpAfterAttempts(t *testing.T) {
+	requests := 0
+	client := newAccessTokenTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Write([]byte("short"))
+	})
+
+	path := filepath.Join(t.TempDir(), "app.tar.gz")
+	artifact := &Artifact{Name: "app.tar.gz", Size: 1000}
+	_, err := client.Pipelines.DownloadArtifactToFile(context.Background(), "ws", "repo", artifact, path, &ArtifactDownloadOptions{Verify: true, Attempts: 2})
+
+	assert.ErrorContains(t, err, "after 2 attempt(s)")
+	assert.ErrorContains(t, err, "expected 1000")
+	assert.Equal(t, 2, requests)
+	assert.NoFileExists(t, path, "an incomplete download is never moved into place")
+}
+
+func TestDownloadArtifactToFile_WithoutVerify(t *testing.T) {
+	client := newAccessTokenTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("short"))
+	})
+
+	path := filepath.Join(t.TempDir(), "app.tar.gz")
+	artifact := &Artifact{Name: "app.tar.gz", Size: 1000}
+	size, err := client.Pipelines.DownloadArtifactToFile(context.Background(), "ws", "repo", artifact, path, &ArtifactDownloadOptions{})
+
+	require.NoError(t, err)
+	assert.Equal(t, int64(5), size)
+}
@@ -0,0 +1,100 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+)
+
+// Branch restriction kinds that decide whether a pull request can be merged
+const (
+	RestrictionRequirePassingBuilds = "require_passing_builds_to_merge"
+	RestrictionEnforceMergeChecks   = "enforce_merge_checks"
+)
+
+// BranchRestrictionService handles branch restrictions and the branching
+// model they can refer to
+type BranchRestrictionService struct {
+	client *Client
+}
+
+// NewBranchRestrictionService creates a new branch restriction service
+func NewBranchRestrictionService(client *Client) *BranchRestrictionService {
+	return &BranchRestrictionService{
+		client: client,
+	}
+}
+
+// BranchRestriction is a rule applied to the branches matching either a glob
+// Pattern or, with BranchMatchKind "branching_model", a BranchType
+type BranchRestriction struct {
+	Type            string `json:"type,omitempty"`
+	ID              int    `json:"id"`
+	Kind            string `json:"kind"`
+	BranchMatchKind string `json:"branch_match_kind"`
+	BranchType      string `json:"branch_type,omitempty"`
+	Pattern         string `json:"pattern,omitempty"`
+	// Value is the minimum count for kinds that take one, such as the
+	// successful builds required by require_passing_builds_to_merge
+	Value *int `json:"value,omitempty"`
+}
+
+// BranchingModel is the effective branching model of a repository
+type BranchingModel struct {
+	Type        string                `json:"type,omitempty"`
+	Development *BranchingModelBranch `json:"development,omitempty"`
+	Production  *BranchingModelBranch `json:"production,omitempty"`
+	BranchTypes []*BranchType         `json:"branch_types,omitempty"`
+}
+
+// BranchingModelBranch is the development or production branch of a
+// branching model
+type BranchingModelBranch struct {
+	Name   string  `json:"name,omitempty"`
+	Branch *Branch `json:"branch,omitempty"`
+}
+
+// BranchType is a branch prefix of a branching model (feature/, bugfix/...)
+type BranchType struct {
+	Kind   string `json:"kind"`
+	Prefix string `json:"prefix"`
+}
+
+// ListBranchRestrictions returns the branch restrictions of a repository,
+// only those of kind when it is not empty. Reading them requires admin
+// access to the repository.
+func (s *BranchRestrictionService) ListBranchRestrictions(ctx context.Context, workspace, repoSlug, kind string) ([]*BranchRestriction, error) {
+	if workspace == "" || repoSlug == "" {
+		return nil, NewValidationError("workspace and repository slug are required", "")
+	}
+
+	endpoint := fmt.Sprintf("repositories/%s/%s/branch-restrictions", workspace, repoSlug)
+	if kind != "" {
+		endpoint += "?kind=" + url.QueryEscape(kind)
+	}
+
+	var restrictions []*BranchRestriction
+	paginator := s.client.Paginate(endpoint, &PageOptions{PageLen: 50})
+	if err := paginator.FetchAllTyped(ctx, &restrictions); err != nil {
+		return nil, fmt.Errorf("failed to fetch branch restrictions: %w", err)
+	}
+
+	return restrictions, nil
+}
+
+// GetBranchingModel returns the branching model in effect for a repository,
+// inherited from the project when the repository doesn't override it
+func (s *BranchRestrictionService) GetBranchingModel(ctx context.Context, workspace, repoSlug string) (*BranchingModel, error) {
+	if workspace == "" || repoSlug == "" {
+		return nil, NewValidationError("workspace and repository slug are required", "")
+	}
+
+	endpoint := fmt.Sprintf("repositories/%s/%s/effective-branching-model", workspace, repoSlug)
+
+	var model BranchingModel
+	if err := s.client.GetJSON(ctx, endpoint, &model); err != nil {
+		return nil, err
+	}
+
+	return &model, nil
+}
@@ -0,0 +1,43 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBranchRestrictionService_ListBranchRestrictions(t *testing.T) {
+	client := newAccessTokenTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/repositories/ws/repo/branch-restrictions", r.URL.Path)
+		assert.Equal(t, RestrictionRequirePassingBuilds, r.URL.Query().Get("kind"))
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"values":[{"id":7,"kind":"require_passing_builds_to_merge","branch_match_kind":"glob","pattern":"main","value":2}]}`))
+	})
+
+	restrictions, err := client.BranchRestrictions.ListBranchRestrictions(context.Background(), "ws", "repo", RestrictionRequirePassingBuilds)
+	require.NoError(t, err)
+	require.Len(t, restrictions, 1)
+	assert.Equal(t, "main", restrictions[0].Pattern)
+	require.NotNil(t, restrictions[0].Value)
+	assert.Equal(t, 2, *restrictions[0].Value)
+}
+
+func TestBranchRestrictionService_GetBranchingModel(t *testing.T) {
+	client := newAccessTokenTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/repositories/ws/repo/effective-branching-model", r.URL.Path)
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"development":{"name":"develop","branch":{"name":"develop"}},"branch_types":[{"kind":"release","prefix":"release/"}]}`))
+	})
+
+	model, err := client.BranchRestrictions.GetBranchingModel(context.Background(), "ws", "repo")
+	require.NoError(t, err)
+	assert.Equal(t, "develop", model.Development.Branch.Name)
+	assert.Nil(t, model.Production)
+	require.Len(t, model.BranchTypes, 1)
+	assert.Equal(t, "release/", model.BranchTypes[0].Prefix)
+}
@@ -13,6 +13,7 @@ import (
 	"net/url"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/carlosarraes/bt/pkg/auth"
@@ -34,6 +35,14 @@ const (
 
 	// MaxPageSize is the maximum page size allowed by Bitbucket
 	MaxPageSize = 100
+
+	// DefaultMaxIdleConnsPerHost is the number of keep-alive connections kept
+	// per host. net/http defaults to 2, which forces fan-out commands to
+	// redial for every request beyond the second in flight.
+	DefaultMaxIdleConnsPerHost = 16
+
+	// DefaultIdleConnTimeout is how long an idle keep-alive connection is kept
+	DefaultIdleConnTimeout = 90 * time.Second
 )
 
 // ClientConfig contains configuration options for the API client
@@ -44,17 +53,77 @@ type ClientConfig struct {
 	EnableLogging bool
 	Logger        *log.Logger
 	UserAgent     string
+
+	// Connection pool tuning; zero values use the defaults above
+	MaxIdleConnsPerHost int
+	IdleConnTimeout     time.Duration
+
+	// Transport replaces the shared pooled transport when set, e.g. with an
+	// apitest.ReplayTransport serving canned responses
+	Transport http.RoundTripper
+
+	// OnRateLimitLow is called once per client, the first time a response
+	// reports the rate limit quota is nearly used up
+	OnRateLimitLow func(RateLimit)
+
+	// DryRun makes the client refuse every request but GET and HEAD with
+	// ErrDryRun
+	DryRun bool
 }
 
 // DefaultClientConfig returns a configuration with sensible defaults
 func DefaultClientConfig() *ClientConfig {
 	return &ClientConfig{
-		BaseURL:       DefaultBaseURL,
-		Timeout:       DefaultTimeout,
-		RetryAttempts: DefaultRetryAttempts,
-		EnableLogging: false,
-		UserAgent:     fmt.Sprintf("bt/%s", version.Version),
+		BaseURL:             DefaultBaseURL,
+		Timeout:             DefaultTimeout,
+		RetryAttempts:       DefaultRetryAttempts,
+		EnableLogging:       false,
+		UserAgent:           fmt.Sprintf("bt/%s", version.Version),
+		MaxIdleConnsPerHost: DefaultMaxIdleConnsPerHost,
+		IdleConnTimeout:     DefaultIdleConnTimeout,
+	}
+}
+
+type transportKey struct {
+	maxIdleConnsPerHost int
+	idleConnTimeout     time.Duration
+}
+
+var (
+	transportsMu sync.Mutex
+	transports   = map[transportKey]*http.Transport{}
+)
+
+// sharedTransport returns the transport for the given pool settings, creating
+// it on first use. Clients with the same settings share one connection pool,
+// so a command that builds several clients still reuses warm connections.
+func sharedTransport(config *ClientConfig) *http.Transport {
+	key := transportKey{
+		maxIdleConnsPerHost: config.MaxIdleConnsPerHost,
+		idleConnTimeout:     config.IdleConnTimeout,
 	}
+	if key.maxIdleConnsPerHost <= 0 {
+		key.maxIdleConnsPerHost = DefaultMaxIdleConnsPerHost
+	}
+	if key.idleConnTimeout <= 0 {
+		key.idleConnTimeout = DefaultIdleConnTimeout
+	}
+
+	transportsMu.Lock()
+	defer transportsMu.Unlock()
+
+	if t, ok := transports[key]; ok {
+		return t
+	}
+
+	t := http.DefaultTransport.(*http.Transport).Clone()
+	t.MaxIdleConnsPerHost = key.maxIdleConnsPerHost
+	if t.MaxIdleConns < key.maxIdleConnsPerHost {
+		t.MaxIdleConns = key.maxIdleConnsPerHost
+	}
+	t.IdleConnTimeout = key.idleConnTimeout
+	transports[key] = t
+	return t
 }
 
 // Client is the main Bitbucket API client
@@ -64,10 +133,20 @@ type Client struct {
 	config      *ClientConfig
 	baseURL     *url.URL
 
+	rateLimitMu     sync.Mutex
+	rateLimit       *RateLimit
+	rateLimitWarned bool
+
 	// Services
-	Pipelines    *PipelineService
-	PullRequests *PullRequestService
-	Repositories *RepositoryService
+	Pipelines          *PipelineService
+	PullRequests       *PullRequestService
+	Repositories       *RepositoryService
+	AccessTokens       *AccessTokenService
+	CommitStatuses     *CommitStatusService
+	Variables          *VariableService
+	Deployments        *DeploymentService
+	BranchRestrictions *BranchRestrictionService
+	Issues             *IssueService
 }
 
 // NewClient creates a new Bitbucket API client
@@ -81,8 +160,14 @@ func NewClient(authManager auth.AuthManager, config *ClientConfig) (*Client, err
 		return nil, fmt.Errorf("invalid base URL: %w", err)
 	}
 
+	var transport http.RoundTripper = config.Transport
+	if transport == nil {
+		transport = sharedTransport(config)
+	}
+
 	httpClient := &http.Client{
-		Timeout: config.Timeout,
+		Timeout:   config.Timeout,
+		Transport: transport,
 	}
 
 	client := &Client{
@@ -96,6 +181,12 @@ func NewClient(authManager auth.AuthManager, config *ClientConfig) (*Client, err
 	client.Pipelines = NewPipelineService(client)
 	client.PullRequests = NewPullRequestService(client)
 	client.Repositories = NewRepositoryService(client)
+	client.AccessTokens = NewAccessTokenService(client)
+	client.CommitStatuses = NewCommitStatusService(client)
+	client.Variables = NewVariableService(client)
+	client.Deployments = NewDeploymentService(client)
+	client.BranchRestrictions = NewBranchRestrictionService(client)
+	client.Issues = NewIssueService(client)
 
 	return client, nil
 }
@@ -152,6 +243,10 @@ func (c *Client) getLogsRequest(ctx context.Context, endpoint string) (*http.Res
 
 // Request performs an HTTP request with automatic retries and error handling
 func (c *Client) Request(ctx context.Context, method, endpoint string, body interface{}) (*http.Response, error) {
+	if err := c.checkDryRun(method, endpoint); err != nil {
+		return nil, err
+	}
+
 	// Build the full URL
 	fullURL, err := c.buildURL(endpoint)
 	if err != nil {
@@ -317,6 +412,7 @@ func (c *Client) doRequestWithRetry(req *http.Request) (*http.Response, error) {
 
 		// Log response if enabled
 		c.logResponse(resp)
+		c.recordRateLimit(resp)
 
 		// Check if we should retry based on status code
 		if c.shouldRetry(resp, attempt) {
@@ -4,10 +4,12 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"net"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
@@ -475,3 +477,91 @@ func BenchmarkClientGet(b *testing.B) {
 		resp.Body.Close()
 	}
 }
+
+func TestSharedTransport(t *testing.T) {
+	tuned := &ClientConfig{MaxIdleConnsPerHost: 8, IdleConnTimeout: time.Minute}
+
+	first := sharedTransport(tuned)
+	assert.Same(t, first, sharedTransport(&ClientConfig{MaxIdleConnsPerHost: 8, IdleConnTimeout: time.Minute}))
+	assert.Equal(t, 8, first.MaxIdleConnsPerHost)
+	assert.Equal(t, time.Minute, first.IdleConnTimeout)
+
+	defaults := sharedTransport(&ClientConfig{})
+	assert.Equal(t, DefaultMaxIdleConnsPerHost, defaults.MaxIdleConnsPerHost)
+	assert.Equal(t, DefaultIdleConnTimeout, defaults.IdleConnTimeout)
+	assert.NotSame(t, first, defaults)
+}
+
+// BenchmarkMultiRepoScan simulates a list-all style fan-out: concurrent GETs
+// against one host, one per repository. With net/http's default of two idle
+// connections per host most requests redial; the tuned pool reuses them.
+func BenchmarkMultiRepoScan(b *testing.B) {
+	const repos, workers = 64, 16
+
+	for _, tc := range []struct {
+		name    string
+		maxIdle int
+	}{
+		{"net-http-default", 2},
+		{"tuned", DefaultMaxIdleConnsPerHost},
+	} {
+		b.Run(tc.name, func(b *testing.B) {
+			mockAuth := &MockAuthManager{}
+			mockAuth.On("SetHTTPHeaders", mock.AnythingOfType("*http.Request")).Return(nil)
+
+			var dials int64
+			var mu sync.Mutex
+			server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				time.Sleep(time.Millisecond)
+				w.Header().Set("Content-Type", "application/json")
+				fmt.Fprint(w, `{"values": [], "size": 0}`)
+			}))
+			server.Config.ConnState = func(c net.Conn, state http.ConnState) {
+				if state == http.StateNew {
+					mu.Lock()
+					dials++
+					mu.Unlock()
+				}
+			}
+			server.Start()
+			defer server.Close()
+
+			client, err := NewClient(mockAuth, &ClientConfig{
+				BaseURL:             server.URL,
+				Timeout:             5 * time.Second,
+				MaxIdleConnsPerHost: tc.maxIdle,
+				IdleConnTimeout:     time.Minute,
+			})
+			require.NoError(b, err)
+
+			ctx := context.Background()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				jobs := make(chan int)
+				var wg sync.WaitGroup
+				for w := 0; w < workers; w++ {
+					wg.Add(1)
+					go func() {
+						defer wg.Done()
+						for repo := range jobs {
+							var out map[string]interface{}
+							if err := client.GetJSON(ctx, fmt.Sprintf("repositories/ws/repo-%d/pullrequests", repo), &out); err != nil {
+								b.Error(err)
+							}
+						}
+					}()
+				}
+				for repo := 0; repo < repos; repo++ {
+					jobs <- repo
+				}
+				close(jobs)
+				wg.Wait()
+			}
+			b.StopTimer()
+
+			mu.Lock()
+			b.ReportMetric(float64(dials)/float64(b.N), "dials/op")
+			mu.Unlock()
+		})
+	}
+}
@@ -0,0 +1,38 @@
+package api
+
+// ParentID returns the ID of the comment this one replies to, or 0 for a
+// top-level comment. Bitbucket only sends the parent's id and links.
+func (c *PullRequestComment) ParentID() int {
+	if c.Parent == nil {
+		return 0
+	}
+	return c.Parent.ID
+}
+
+// CommentThread is a comment with its replies nested beneath it
+type CommentThread struct {
+	Comment *PullRequestComment `json:"comment" yaml:"comment"`
+	Replies []*CommentThread    `json:"replies,omitempty" yaml:"replies,omitempty"`
+}
+
+// ThreadComments links comments to their parents and returns the top-level
+// threads in the order given. Replies whose parent isn't in comments (for
+// example deleted or on another page) become top-level threads.
+func ThreadComments(comments []PullRequestComment) []*CommentThread {
+	threads := make(map[int]*CommentThread, len(comments))
+	for i := range comments {
+		threads[comments[i].ID] = &CommentThread{Comment: &comments[i]}
+	}
+
+	var roots []*CommentThread
+	for i := range comments {
+		thread := threads[comments[i].ID]
+		parentID := comments[i].ParentID()
+		if parent, ok := threads[parentID]; ok && parentID != comments[i].ID {
+			parent.Replies = append(parent.Replies, thread)
+			continue
+		}
+		roots = append(roots, thread)
+	}
+	return roots
+}
@@ -0,0 +1,41 @@
+package api
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestThreadComments(t *testing.T) {
+	raw := `[
+		{"id": 1, "content": {"raw": "general"}},
+		{"id": 2, "content": {"raw": "inline"}, "inline": {"path": "main.go", "to": 10}},
+		{"id": 3, "content": {"raw": "reply to 1"}, "parent": {"id": 1, "links": {}}},
+		{"id": 4, "content": {"raw": "reply to 3"}, "parent": {"id": 3}},
+		{"id": 5, "content": {"raw": "reply to 2"}, "parent": {"id": 2}},
+		{"id": 6, "content": {"raw": "orphan"}, "parent": {"id": 99}}
+	]`
+
+	var comments []PullRequestComment
+	require.NoError(t, json.Unmarshal([]byte(raw), &comments))
+	assert.Equal(t, 1, comments[2].ParentID())
+	assert.Equal(t, 0, comments[0].ParentID())
+
+	threads := ThreadComments(comments)
+	require.Len(t, threads, 3)
+
+	assert.Equal(t, 1, threads[0].Comment.ID)
+	require.Len(t, threads[0].Replies, 1)
+	assert.Equal(t, 3, threads[0].Replies[0].Comment.ID)
+	require.Len(t, threads[0].Replies[0].Replies, 1)
+	assert.Equal(t, 4, threads[0].Replies[0].Replies[0].Comment.ID)
+
+	assert.Equal(t, 2, threads[1].Comment.ID)
+	require.Len(t, threads[1].Replies, 1)
+	assert.Equal(t, 5, threads[1].Replies[0].Comment.ID)
+
+	assert.Equal(t, 6, threads[2].Comment.ID)
+	assert.Empty(t, threads[2].Replies)
+}
@@ -0,0 +1,101 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// CommitStatusStates are the states a build status can be reported with
+var CommitStatusStates = []string{"SUCCESSFUL", "FAILED", "INPROGRESS", "STOPPED"}
+
+// CommitStatusService handles commit (build) status operations
+type CommitStatusService struct {
+	client *Client
+}
+
+// NewCommitStatusService creates a new commit status service
+func NewCommitStatusService(client *Client) *CommitStatusService {
+	return &CommitStatusService{
+		client: client,
+	}
+}
+
+// CommitStatus is a build status attached to a commit, as reported by
+// Bitbucket Pipelines or an external CI system
+type CommitStatus struct {
+	Type        string     `json:"type,omitempty"`
+	Key         string     `json:"key"`
+	State       string     `json:"state"`
+	Name        string     `json:"name,omitempty"`
+	URL         string     `json:"url"`
+	Description string     `json:"description,omitempty"`
+	Refname     string     `json:"refname,omitempty"`
+	CreatedOn   *time.Time `json:"created_on,omitempty"`
+	UpdatedOn   *time.Time `json:"updated_on,omitempty"`
+}
+
+// ListCommitStatuses returns every status reported for a commit
+func (s *CommitStatusService) ListCommitStatuses(ctx context.Context, workspace, repoSlug, commitSHA string) ([]*CommitStatus, error) {
+	if workspace == "" || repoSlug == "" || commitSHA == "" {
+		return nil, NewValidationError("workspace, repository slug, and commit SHA are required", "")
+	}
+
+	endpoint := fmt.Sprintf("repositories/%s/%s/commit/%s/statuses", workspace, repoSlug, commitSHA)
+
+	var statuses []*CommitStatus
+	paginator := s.client.Paginate(endpoint, &PageOptions{PageLen: 50})
+	if err := paginator.FetchAllTyped(ctx, &statuses); err != nil {
+		return nil, fmt.Errorf("failed to fetch statuses for commit: %w", err)
+	}
+
+	return statuses, nil
+}
+
+// SetBuildStatus creates or updates the build status identified by
+// status.Key on a commit
+func (s *CommitStatusService) SetBuildStatus(ctx context.Context, workspace, repoSlug, commitSHA string, status *CommitStatus) (*CommitStatus, error) {
+	if workspace == "" || repoSlug == "" || commitSHA == "" {
+		return nil, NewValidationError("workspace, repository slug, and commit SHA are required", "")
+	}
+
+	if err := ValidateCommitStatus(status); err != nil {
+		return nil, err
+	}
+
+	endpoint := fmt.Sprintf("repositories/%s/%s/commit/%s/statuses/build", workspace, repoSlug, commitSHA)
+
+	var result CommitStatus
+	if err := s.client.PostJSON(ctx, endpoint, status, &result); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// ValidateCommitStatus checks the fields Bitbucket requires on a build status
+func ValidateCommitStatus(status *CommitStatus) error {
+	if status == nil {
+		return NewValidationError("commit status is required", "")
+	}
+
+	if status.Key == "" {
+		return NewValidationError("status key is required", "")
+	}
+
+	if status.URL == "" {
+		return NewValidationError("status URL is required", "")
+	}
+
+	for _, state := range CommitStatusStates {
+		if status.State == state {
+			return nil
+		}
+	}
+
+	return NewValidationError(
+		fmt.Sprintf("invalid status state %q", status.State),
+		fmt.Sprintf("valid states: %s", strings.Join(CommitStatusStates, ", ")),
+	)
+}
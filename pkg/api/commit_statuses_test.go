@@ -0,0 +1,81 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCommitStatusService_SetBuildStatus(t *testing.T) {
+	client := newAccessTokenTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "/repositories/ws/repo/commit/abc123/statuses/build", r.URL.Path)
+
+		var body CommitStatus
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		assert.Equal(t, "lint", body.Key)
+		assert.Equal(t, "SUCCESSFUL", body.State)
+		assert.Equal(t, "https://ci.example.com/1", body.URL)
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"type":"build","key":"lint","state":"SUCCESSFUL","name":"Lint","url":"https://ci.example.com/1"}`))
+	})
+
+	status, err := client.CommitStatuses.SetBuildStatus(context.Background(), "ws", "repo", "abc123", &CommitStatus{
+		Key:   "lint",
+		State: "SUCCESSFUL",
+		Name:  "Lint",
+		URL:   "https://ci.example.com/1",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "build", status.Type)
+	assert.Equal(t, "Lint", status.Name)
+}
+
+func TestCommitStatusService_ListCommitStatuses(t *testing.T) {
+	client := newAccessTokenTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodGet, r.Method)
+		assert.Equal(t, "/repositories/ws/repo/commit/abc123/statuses", r.URL.Path)
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"values":[{"key":"lint","state":"FAILED","url":"https://ci.example.com/1"},{"key":"e2e","state":"INPROGRESS","url":"https://ci.example.com/2"}]}`))
+	})
+
+	statuses, err := client.CommitStatuses.ListCommitStatuses(context.Background(), "ws", "repo", "abc123")
+	require.NoError(t, err)
+	require.Len(t, statuses, 2)
+	assert.Equal(t, "lint", statuses[0].Key)
+	assert.Equal(t, "INPROGRESS", statuses[1].State)
+}
+
+func TestValidateCommitStatus(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  *CommitStatus
+		wantErr bool
+	}{
+		{"valid", &CommitStatus{Key: "lint", State: "STOPPED", URL: "https://ci.example.com"}, false},
+		{"nil", nil, true},
+		{"missing key", &CommitStatus{State: "FAILED", URL: "https://ci.example.com"}, true},
+		{"missing url", &CommitStatus{Key: "lint", State: "FAILED"}, true},
+		{"invalid state", &CommitStatus{Key: "lint", State: "PASSED", URL: "https://ci.example.com"}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateCommitStatus(tt.status)
+			if !tt.wantErr {
+				assert.NoError(t, err)
+				return
+			}
+			var bbErr *BitbucketError
+			require.ErrorAs(t, err, &bbErr)
+			assert.Equal(t, ErrorTypeValidation, bbErr.Type)
+		})
+	}
+}
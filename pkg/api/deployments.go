@@ -0,0 +1,155 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// DeploymentService handles deployment environment and deployment operations
+type DeploymentService struct {
+	client *Client
+}
+
+// NewDeploymentService creates a new deployment service
+func NewDeploymentService(client *Client) *DeploymentService {
+	return &DeploymentService{
+		client: client,
+	}
+}
+
+// DeploymentEnvironment is a deployment environment of a repository
+// (e.g. Test, Staging, Production)
+type DeploymentEnvironment struct {
+	Type            string                     `json:"type,omitempty"`
+	UUID            string                     `json:"uuid"`
+	Name            string                     `json:"name"`
+	Slug            string                     `json:"slug,omitempty"`
+	Rank            int                        `json:"rank,omitempty"`
+	EnvironmentType *DeploymentEnvironmentType `json:"environment_type,omitempty"`
+}
+
+// DeploymentEnvironmentType is the tier of a deployment environment
+// (Test, Staging or Production)
+type DeploymentEnvironmentType struct {
+	Name string `json:"name"`
+}
+
+// Deployment is one deployment of a release to an environment. Manual
+// deployment steps that have not been run yet show up as UNDEPLOYED.
+type Deployment struct {
+	Type        string                 `json:"type,omitempty"`
+	UUID        string                 `json:"uuid"`
+	State       *DeploymentState       `json:"state,omitempty"`
+	Environment *DeploymentEnvironment `json:"environment,omitempty"`
+	Release     *DeploymentRelease     `json:"release,omitempty"`
+	Step        *PipelineStep          `json:"step,omitempty"`
+}
+
+// DeploymentState is the progress of a deployment: UNDEPLOYED, IN_PROGRESS
+// or COMPLETED, with Status (SUCCESSFUL, FAILED, STOPPED) once completed
+type DeploymentState struct {
+	Type        string            `json:"type,omitempty"`
+	Name        string            `json:"name"`
+	Status      *DeploymentStatus `json:"status,omitempty"`
+	URL         string            `json:"url,omitempty"`
+	StartedOn   *time.Time        `json:"started_on,omitempty"`
+	CompletedOn *time.Time        `json:"completed_on,omitempty"`
+}
+
+// DeploymentStatus is the outcome of a completed deployment
+type DeploymentStatus struct {
+	Type string `json:"type,omitempty"`
+	Name string `json:"name"`
+}
+
+// DeploymentRelease is the build that was deployed
+type DeploymentRelease struct {
+	Type      string     `json:"type,omitempty"`
+	UUID      string     `json:"uuid,omitempty"`
+	Name      string     `json:"name,omitempty"`
+	URL       string     `json:"url,omitempty"`
+	Commit    *Commit    `json:"commit,omitempty"`
+	Pipeline  *Pipeline  `json:"pipeline,omitempty"`
+	CreatedOn *time.Time `json:"created_on,omitempty"`
+}
+
+// ListEnvironments returns the deployment environments of a repository
+func (s *DeploymentService) ListEnvironments(ctx context.Context, workspace, repoSlug string) ([]*DeploymentEnvironment, error) {
+	if workspace == "" || repoSlug == "" {
+		return nil, NewValidationError("workspace and repository slug are required", "")
+	}
+
+	endpoint := fmt.Sprintf("repositories/%s/%s/environments", workspace, repoSlug)
+
+	var environments []*DeploymentEnvironment
+	paginator := s.client.Paginate(endpoint, &PageOptions{PageLen: 50})
+	if err := paginator.FetchAllTyped(ctx, &environments); err != nil {
+		return nil, fmt.Errorf("failed to fetch deployment environments: %w", err)
+	}
+
+	return environments, nil
+}
+
+// ListDeployments returns up to limit of the most recently started
+// deployments of a repository, across all environments
+func (s *DeploymentService) ListDeployments(ctx context.Context, workspace, repoSlug string, limit int) ([]*Deployment, error) {
+	if workspace == "" || repoSlug == "" {
+		return nil, NewValidationError("workspace and repository slug are required", "")
+	}
+
+	endpoint := fmt.Sprintf("repositories/%s/%s/deployments?sort=-state.started_on", workspace, repoSlug)
+
+	pageOptions := &PageOptions{PageLen: 50}
+	if limit > 0 {
+		pageOptions.Limit = limit
+		if limit < pageOptions.PageLen {
+			pageOptions.PageLen = limit
+		}
+	}
+
+	var deployments []*Deployment
+	paginator := s.client.Paginate(endpoint, pageOptions)
+	if err := paginator.FetchAllTyped(ctx, &deployments); err != nil {
+		return nil, fmt.Errorf("failed to fetch deployments: %w", err)
+	}
+
+	return deployments, nil
+}
+
+// errDeploymentFound stops FindDeployment's paging once a match is seen
+var errDeploymentFound = errors.New("deployment found")
+
+// FindDeployment pages through a repository's deployments, most recently
+// started first, and returns the first one match accepts. It returns nil
+// when no deployment matches.
+func (s *DeploymentService) FindDeployment(ctx context.Context, workspace, repoSlug string, match func(*Deployment) bool) (*Deployment, error) {
+	if workspace == "" || repoSlug == "" {
+		return nil, NewValidationError("workspace and repository slug are required", "")
+	}
+
+	endpoint := fmt.Sprintf("repositories/%s/%s/deployments?sort=-state.started_on", workspace, repoSlug)
+
+	var found *Deployment
+	paginator := s.client.Paginate(endpoint, &PageOptions{PageLen: 50})
+	err := paginator.ForEachPage(ctx, func(values []json.RawMessage) error {
+		for _, raw := range values {
+			var deployment Deployment
+			if err := json.Unmarshal(raw, &deployment); err != nil {
+				return fmt.Errorf("failed to parse deployment: %w", err)
+			}
+			if match(&deployment) {
+				found = &deployment
+				return errDeploymentFound
+			}
+		}
+		return nil
+	})
+	if err != nil && !errors.Is(err, errDeploymentFound) {
+		return nil, fmt.Errorf("failed to fetch deployments: %w", err)
+	}
+
+	return found, nil
+}
@@ -0,0 +1,47 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeploymentService_ListEnvironments(t *testing.T) {
+	client := newAccessTokenTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/repositories/ws/repo/environments", r.URL.Path)
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"values":[{"uuid":"{env-1}","name":"Production","slug":"production","environment_type":{"name":"Production"}}]}`))
+	})
+
+	environments, err := client.Deployments.ListEnvironments(context.Background(), "ws", "repo")
+	require.NoError(t, err)
+	require.Len(t, environments, 1)
+	assert.Equal(t, "Production", environments[0].Name)
+	assert.Equal(t, "Production", environments[0].EnvironmentType.Name)
+}
+
+func TestDeploymentService_ListDeployments(t *testing.T) {
+	client := newAccessTokenTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/repositories/ws/repo/deployments", r.URL.Path)
+		assert.Equal(t, "-state.started_on", r.URL.Query().Get("sort"))
+		assert.Equal(t, "2", r.URL.Query().Get("pagelen"))
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"values":[
+			{"uuid":"{d2}","environment":{"uuid":"{env-1}"},"step":{"uuid":"{s2}"},"state":{"name":"UNDEPLOYED"}},
+			{"uuid":"{d1}","environment":{"uuid":"{env-1}"},"step":{"uuid":"{s1}"},"state":{"name":"COMPLETED","status":{"name":"SUCCESSFUL"}},"release":{"name":"#41","commit":{"hash":"abc123"}}}
+		]}`))
+	})
+
+	deployments, err := client.Deployments.ListDeployments(context.Background(), "ws", "repo", 2)
+	require.NoError(t, err)
+	require.Len(t, deployments, 2)
+	assert.Equal(t, "UNDEPLOYED", deployments[0].State.Name)
+	assert.Equal(t, "SUCCESSFUL", deployments[1].State.Status.Name)
+	assert.Equal(t, "abc123", deployments[1].Release.Commit.Hash)
+	assert.Equal(t, "{s1}", deployments[1].Step.UUID)
+}
@@ -0,0 +1,131 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// DefaultDiffStreamAttempts is how many times a diff stream broken midway
+// is reopened before the error is returned
+const DefaultDiffStreamAttempts = 3
+
+// StreamPullRequestDiff opens the unified diff of a pull request to be read
+// as it arrives, so that very large diffs needn't be held in memory. The
+// caller closes the stream. When the connection breaks midway, the diff is
+// requested again from where it stopped: with a Range request, or by
+// skipping what was already read when the server sends it all again.
+func (p *PullRequestService) StreamPullRequestDiff(ctx context.Context, workspace, repoSlug string, id int) (io.ReadCloser, error) {
+	if workspace == "" || repoSlug == "" {
+		return nil, NewValidationError("workspace and repository slug are required", "")
+	}
+
+	if id <= 0 {
+		return nil, NewValidationError("pull request ID must be positive", "")
+	}
+
+	endpoint := fmt.Sprintf("repositories/%s/%s/pullrequests/%d/diff", workspace, repoSlug, id)
+	stream := &resumableStream{
+		open: func(offset int64) (*http.Response, error) {
+			return p.client.getFrom(ctx, endpoint, offset)
+		},
+		attempts: DefaultDiffStreamAttempts,
+	}
+	if err := stream.resume(); err != nil {
+		return nil, err
+	}
+	return stream, nil
+}
+
+// getFrom performs a GET request for the content of endpoint from offset on
+func (c *Client) getFrom(ctx context.Context, endpoint string, offset int64) (*http.Response, error) {
+	fullURL, err := c.buildURL(endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build URL: %w", err)
+	}
+
+	req, err := c.createRequest(ctx, "GET", fullURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Accept", "*/*")
+	if offset > 0 {
+		req.Header.Set("Range", fmt.Sprintf("bytes=%d-", offset))
+	}
+
+	return c.doRequestWithRetry(req)
+}
+
+// resumableStream reads a response body, reopening it where it stopped when
+// a read fails
+type resumableStream struct {
+	open     func(offset int64) (*http.Response, error)
+	body     io.ReadCloser
+	offset   int64
+	attempts int
+	// broken is a read error that came with data, kept for the next read
+	broken error
+	// failed is the error every read returns once resuming failed
+	failed error
+}
+
+func (s *resumableStream) Read(b []byte) (int, error) {
+	if s.failed != nil {
+		return 0, s.failed
+	}
+	for {
+		n, err := s.read(b)
+		s.offset += int64(n)
+		if err == nil || err == io.EOF {
+			return n, err
+		}
+		// Hand over what was read first and resume on the next read
+		if n > 0 {
+			s.broken = err
+			return n, nil
+		}
+		if s.attempts == 0 {
+			return 0, err
+		}
+		s.attempts--
+
+		s.body.Close()
+		if resumeErr := s.resume(); resumeErr != nil {
+			s.failed = fmt.Errorf("diff stream interrupted (%v) and could not be resumed: %w", err, resumeErr)
+			return 0, s.failed
+		}
+	}
+}
+
+// read reads from the body, unless its last read already failed
+func (s *resumableStream) read(b []byte) (int, error) {
+	if err := s.broken; err != nil {
+		s.broken = nil
+		return 0, err
+	}
+	return s.body.Read(b)
+}
+
+// resume opens the body again at the current offset
+func (s *resumableStream) resume() error {
+	resp, err := s.open(s.offset)
+	if err != nil {
+		s.body = http.NoBody
+		return err
+	}
+
+	if s.offset > 0 && resp.StatusCode != http.StatusPartialContent {
+		if _, err := io.CopyN(io.Discard, resp.Body, s.offset); err != nil {
+			resp.Body.Close()
+			s.body = http.NoBody
+			return fmt.Errorf("failed to skip the %d bytes already read: %w", s.offset, err)
+		}
+	}
+	s.body = resp.Body
+	return nil
+}
+
+func (s *resumableStream) Close() error {
+	return s.body.Close()
+}
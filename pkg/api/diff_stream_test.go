@@ -0,0 +1,127 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var streamedDiff = []byte(strings.Repeat("diff --git a/main.go b/main.go\n+added line\n", 512))
+
+// cutShort sends the headers of the whole diff and only part of the body,
+// so that the client's read fails midway
+func cutShort(w http.ResponseWriter, n int) {
+	w.Header().Set("Content-Length", strconv.Itoa(len(streamedDiff)))
+	w.Write(streamedDiff[:n])
+}
+
+func TestStreamPullRequestDiff_ResumesWithRange(t *testing.T) {
+	var ranges []string
+	client := newAccessTokenTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/repositories/ws/repo/pullrequests/7/diff", r.URL.Path)
+		ranges = append(ranges, r.Header.Get("Range"))
+		if len(ranges) == 1 {
+			cutShort(w, 1000)
+			return
+		}
+		http.ServeContent(w, r, "diff", time.Time{}, bytes.NewReader(streamedDiff))
+	})
+
+	stream, err := client.PullRequests.StreamPullRequestDiff(context.Background(), "ws", "repo", 7)
+	require.NoError(t, err)
+	defer stream.Close()
+
+	got, err := io.ReadAll(stream)
+	require.NoError(t, err)
+	assert.Equal(t, streamedDiff, got)
+	assert.Equal(t, []string{"", "bytes=1000-"}, ranges)
+}
+
+func TestStreamPullRequestDiff_SkipsWhatWasReadWithoutRange(t *testing.T) {
+	requests := 0
+	client := newAccessTokenTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if requests == 1 {
+			cutShort(w, 1000)
+			return
+		}
+		w.Write(streamedDiff)
+	})
+
+	diff, err := client.PullRequests.GetPullRequestDiff(context.Background(), "ws", "repo", 7)
+	require.NoError(t, err)
+	assert.Equal(t, string(streamedDiff), diff)
+	assert.Equal(t, 2, requests)
+}
+
+func TestStreamPullRequestDiff_GivesUp(t *testing.T) {
+	requests := 0
+	client := newAccessTokenTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		cutShort(w, 1000)
+	})
+
+	stream, err := client.PullRequests.StreamPullRequestDiff(context.Background(), "ws", "repo", 7)
+	require.NoError(t, err)
+	defer stream.Close()
+
+	_, err = io.ReadAll(stream)
+	assert.ErrorIs(t, err, io.ErrUnexpectedEOF)
+	assert.Equal(t, 1+DefaultDiffStreamAttempts, requests)
+}
+
+func TestStreamPullRequestDiff_Validation(t *testing.T) {
+	client := newAccessTokenTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request to %s", r.URL.Path)
+	})
+
+	_, err := client.PullRequests.StreamPullRequestDiff(context.Background(), "ws", "repo", 0)
+	assert.Error(t, err)
+}
+
+// failingReader returns data together with an error, once
+type failingReader struct {
+	data []byte
+	err  error
+}
+
+func (r *failingReader) Read(b []byte) (int, error) {
+	if len(r.data) == 0 {
+		return 0, io.EOF
+	}
+	n := copy(b, r.data)
+	r.data = r.data[n:]
+	return n, r.err
+}
+
+func TestResumableStream_KeepsDataReadWithAnError(t *testing.T) {
+	var offsets []int64
+	stream := &resumableStream{
+		body: io.NopCloser(&failingReader{data: streamedDiff[:1000], err: io.ErrUnexpectedEOF}),
+		open: func(offset int64) (*http.Response, error) {
+			offsets = append(offsets, offset)
+			return &http.Response{
+				StatusCode: http.StatusPartialContent,
+				Body:       io.NopCloser(bytes.NewReader(streamedDiff[offset:])),
+			}, nil
+		},
+		attempts: 1,
+	}
+
+	n, err := stream.Read(make([]byte, 4096))
+	assert.Equal(t, 1000, n)
+	assert.NoError(t, err, "data read along with an error is returned on its own")
+
+	rest, err := io.ReadAll(stream)
+	require.NoError(t, err)
+	assert.Equal(t, streamedDiff[1000:], rest)
+	assert.Equal(t, []int64{1000}, offsets)
+}
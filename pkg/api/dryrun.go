@@ -0,0 +1,26 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// ErrDryRun is returned instead of sending a mutating request from a client
+// configured with DryRun
+var ErrDryRun = errors.New("dry run: mutating requests are disabled")
+
+// IsDryRunError reports whether err stopped a request under DryRun
+func IsDryRunError(err error) bool {
+	return errors.Is(err, ErrDryRun)
+}
+
+// checkDryRun refuses any request that could change something on Bitbucket
+// when the client runs in dry-run mode. Commands with a dry-run plan never
+// get here; this keeps the ones without one from mutating anything.
+func (c *Client) checkDryRun(method, endpoint string) error {
+	if !c.config.DryRun || method == http.MethodGet || method == http.MethodHead {
+		return nil
+	}
+	return fmt.Errorf("%w (would send %s %s)", ErrDryRun, method, endpoint)
+}
@@ -0,0 +1,34 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_DryRunRefusesMutatingRequests(t *testing.T) {
+	var requests int32
+	client := newAccessTokenTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{}`))
+	})
+	client.config.DryRun = true
+
+	resp, err := client.Get(context.Background(), "user")
+	require.NoError(t, err)
+	resp.Body.Close()
+
+	for _, method := range []string{http.MethodPost, http.MethodPut, http.MethodDelete} {
+		_, err := client.Request(context.Background(), method, "repositories/ws/repo/pullrequests/1/merge", nil)
+		require.Error(t, err, method)
+		assert.True(t, IsDryRunError(err))
+		assert.Contains(t, err.Error(), method+" repositories/ws/repo/pullrequests/1/merge")
+	}
+
+	assert.Equal(t, int32(1), atomic.LoadInt32(&requests), "only the GET should reach the server")
+}
@@ -0,0 +1,173 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// IssueService handles operations on a repository's built-in issue tracker
+type IssueService struct {
+	client *Client
+}
+
+// NewIssueService creates a new issue service
+func NewIssueService(client *Client) *IssueService {
+	return &IssueService{
+		client: client,
+	}
+}
+
+// Issue is an issue of a repository's built-in issue tracker
+type Issue struct {
+	Type      string        `json:"type,omitempty"`
+	ID        int           `json:"id"`
+	Title     string        `json:"title"`
+	Content   *IssueContent `json:"content,omitempty"`
+	State     string        `json:"state,omitempty"`
+	Kind      string        `json:"kind,omitempty"`
+	Priority  string        `json:"priority,omitempty"`
+	Reporter  *User         `json:"reporter,omitempty"`
+	Assignee  *User         `json:"assignee,omitempty"`
+	Milestone *TrackerField `json:"milestone,omitempty"`
+	Version   *TrackerField `json:"version,omitempty"`
+	Component *TrackerField `json:"component,omitempty"`
+	Votes     int           `json:"votes,omitempty"`
+	CreatedOn *time.Time    `json:"created_on,omitempty"`
+	UpdatedOn *time.Time    `json:"updated_on,omitempty"`
+	Links     *Links        `json:"links,omitempty"`
+}
+
+// IssueContent is the body of an issue
+type IssueContent struct {
+	Raw    string `json:"raw"`
+	Markup string `json:"markup,omitempty"`
+	HTML   string `json:"html,omitempty"`
+}
+
+// Issue tracker values accepted by Bitbucket
+var (
+	IssueStates     = []string{"new", "open", "submitted", "resolved", "on hold", "invalid", "duplicate", "wontfix", "closed"}
+	IssueKinds      = []string{"bug", "enhancement", "proposal", "task"}
+	IssuePriorities = []string{"trivial", "minor", "major", "critical", "blocker"}
+)
+
+// IssueListOptions filters ListIssues
+type IssueListOptions struct {
+	States   []string // Any of IssueStates; empty for every state
+	Assignee string   // Assignee username, account_id or {uuid}
+	Limit    int
+}
+
+// CreateIssueRequest is the body of a new issue. Kind and Priority default
+// to bug and major on Bitbucket's side when left empty.
+type CreateIssueRequest struct {
+	Title    string        `json:"title"`
+	Content  *IssueContent `json:"content,omitempty"`
+	Kind     string        `json:"kind,omitempty"`
+	Priority string        `json:"priority,omitempty"`
+	Assignee *User         `json:"assignee,omitempty"`
+}
+
+// ListIssues returns up to limit issues of a repository, most recently
+// updated first
+func (s *IssueService) ListIssues(ctx context.Context, workspace, repoSlug string, options *IssueListOptions) ([]*Issue, error) {
+	if workspace == "" || repoSlug == "" {
+		return nil, NewValidationError("workspace and repository slug are required", "")
+	}
+
+	endpoint := fmt.Sprintf("repositories/%s/%s/issues", workspace, repoSlug)
+
+	queryParams := url.Values{}
+	queryParams.Set("sort", "-updated_on")
+
+	pageOptions := &PageOptions{Page: 1, PageLen: 50}
+	if options != nil {
+		var filterParts []string
+		if len(options.States) > 0 {
+			stateParts := make([]string, len(options.States))
+			for i, state := range options.States {
+				stateParts[i] = fmt.Sprintf("state=\"%s\"", state)
+			}
+			filterParts = append(filterParts, "("+strings.Join(stateParts, " OR ")+")")
+		}
+		if options.Assignee != "" {
+			filterParts = append(filterParts, fmt.Sprintf("%s=\"%s\"", assigneeField(options.Assignee), options.Assignee))
+		}
+		if len(filterParts) > 0 {
+			queryParams.Set("q", strings.Join(filterParts, " AND "))
+		}
+
+		if options.Limit > 0 {
+			pageOptions.Limit = options.Limit
+			if options.Limit < pageOptions.PageLen {
+				pageOptions.PageLen = options.Limit
+			}
+		}
+	}
+	endpoint += "?" + queryParams.Encode()
+
+	var issues []*Issue
+	paginator := s.client.Paginate(endpoint, pageOptions)
+	if err := paginator.FetchAllTyped(ctx, &issues); err != nil {
+		return nil, err
+	}
+
+	return issues, nil
+}
+
+// assigneeField is the issue field matching the form of an assignee
+// selector: Bitbucket identifies users by UUID ("{...}"), account_id
+// ("557058:...") or username
+func assigneeField(assignee string) string {
+	switch {
+	case strings.HasPrefix(assignee, "{") && strings.HasSuffix(assignee, "}"):
+		return "assignee.uuid"
+	case strings.Contains(assignee, ":"):
+		return "assignee.account_id"
+	default:
+		return "assignee.username"
+	}
+}
+
+// GetIssue retrieves a single issue
+func (s *IssueService) GetIssue(ctx context.Context, workspace, repoSlug string, id int) (*Issue, error) {
+	if workspace == "" || repoSlug == "" {
+		return nil, NewValidationError("workspace and repository slug are required", "")
+	}
+
+	if id <= 0 {
+		return nil, NewValidationError("issue ID must be positive", "")
+	}
+
+	endpoint := fmt.Sprintf("repositories/%s/%s/issues/%d", workspace, repoSlug, id)
+
+	var issue Issue
+	if err := s.client.GetJSON(ctx, endpoint, &issue); err != nil {
+		return nil, err
+	}
+
+	return &issue, nil
+}
+
+// CreateIssue opens a new issue
+func (s *IssueService) CreateIssue(ctx context.Context, workspace, repoSlug string, request *CreateIssueRequest) (*Issue, error) {
+	if workspace == "" || repoSlug == "" {
+		return nil, NewValidationError("workspace and repository slug are required", "")
+	}
+
+	if request == nil || request.Title == "" {
+		return nil, NewValidationError("issue title is required", "")
+	}
+
+	endpoint := fmt.Sprintf("repositories/%s/%s/issues", workspace, repoSlug)
+
+	var issue Issue
+	if err := s.client.PostJSON(ctx, endpoint, request, &issue); err != nil {
+		return nil, err
+	}
+
+	return &issue, nil
+}
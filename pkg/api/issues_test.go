@@ -0,0 +1,78 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIssueService_ListIssues(t *testing.T) {
+	client := newAccessTokenTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/repositories/ws/repo/issues", r.URL.Path)
+		assert.Equal(t, `(state="new" OR state="open") AND assignee.username="alice"`, r.URL.Query().Get("q"))
+		assert.Equal(t, "-updated_on", r.URL.Query().Get("sort"))
+		assert.Equal(t, "5", r.URL.Query().Get("pagelen"))
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"values":[{"id":7,"title":"Crash on start","state":"open","kind":"bug","priority":"critical","assignee":{"username":"alice"}}]}`))
+	})
+
+	issues, err := client.Issues.ListIssues(context.Background(), "ws", "repo", &IssueListOptions{States: []string{"new", "open"}, Assignee: "alice", Limit: 5})
+	require.NoError(t, err)
+	require.Len(t, issues, 1)
+	assert.Equal(t, 7, issues[0].ID)
+	assert.Equal(t, "critical", issues[0].Priority)
+	assert.Equal(t, "alice", issues[0].Assignee.Username)
+}
+
+func TestIssueService_GetIssue(t *testing.T) {
+	client := newAccessTokenTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/repositories/ws/repo/issues/7", r.URL.Path)
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"id":7,"title":"Crash on start","content":{"raw":"Steps to reproduce"}}`))
+	})
+
+	issue, err := client.Issues.GetIssue(context.Background(), "ws", "repo", 7)
+	require.NoError(t, err)
+	assert.Equal(t, "Steps to reproduce", issue.Content.Raw)
+
+	_, err = client.Issues.GetIssue(context.Background(), "ws", "repo", 0)
+	assert.Error(t, err)
+}
+
+func TestIssueService_CreateIssue(t *testing.T) {
+	client := newAccessTokenTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "POST", r.Method)
+		assert.Equal(t, "/repositories/ws/repo/issues", r.URL.Path)
+
+		raw, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		var body map[string]interface{}
+		require.NoError(t, json.Unmarshal(raw, &body))
+		assert.Equal(t, "Crash on start", body["title"])
+		assert.Equal(t, "task", body["kind"])
+		assert.Equal(t, map[string]interface{}{"raw": "Steps"}, body["content"])
+		assert.NotContains(t, body, "priority")
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"id":8,"title":"Crash on start","state":"new","kind":"task"}`))
+	})
+
+	issue, err := client.Issues.CreateIssue(context.Background(), "ws", "repo", &CreateIssueRequest{
+		Title:   "Crash on start",
+		Content: &IssueContent{Raw: "Steps"},
+		Kind:    "task",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 8, issue.ID)
+
+	_, err = client.Issues.CreateIssue(context.Background(), "ws", "repo", &CreateIssueRequest{})
+	assert.Error(t, err)
+}
@@ -55,6 +55,7 @@ type Paginator struct {
 	options      *PageOptions
 	pageInfo     *PageInfo
 	totalFetched int
+	pagesFetched int
 }
 
 // NewPaginator creates a new paginator for the given URL
@@ -82,7 +83,7 @@ func (p *Paginator) NextPage(ctx context.Context) (*PaginatedResponse, error) {
 	}
 
 	// Check if we have no more pages to fetch
-	if p.totalFetched > 0 && !p.pageInfo.HasNext {
+	if p.pagesFetched > 0 && !p.pageInfo.HasNext {
 		return nil, nil // No more pages available
 	}
 
@@ -145,6 +146,7 @@ func (p *Paginator) NextPage(ctx context.Context) (*PaginatedResponse, error) {
 
 	// Update total fetched counter
 	p.totalFetched += paginatedResp.Size
+	p.pagesFetched++
 
 	return &paginatedResp, nil
 }
@@ -157,7 +159,7 @@ func (p *Paginator) HasNextPage() bool {
 	}
 
 	// If we haven't fetched any pages yet, we should try to fetch the first page
-	if p.totalFetched == 0 {
+	if p.pagesFetched == 0 {
 		return true
 	}
 
@@ -171,6 +173,7 @@ func (p *Paginator) Reset() {
 		PageLen: p.options.PageLen,
 	}
 	p.totalFetched = 0
+	p.pagesFetched = 0
 }
 
 // GetPageInfo returns the current pagination information
@@ -178,14 +181,33 @@ func (p *Paginator) GetPageInfo() *PageInfo {
 	return p.pageInfo
 }
 
-// FetchAll fetches all pages and returns all values as a single slice
-func (p *Paginator) FetchAll(ctx context.Context) ([]json.RawMessage, error) {
-	var allValues []json.RawMessage
+// PageError reports a page that failed partway through a multi-page fetch.
+// Values from the pages before it are still returned alongside the error.
+type PageError struct {
+	Page    int // 1-based index of the page that failed, counted from the first fetched page
+	Fetched int // number of items collected before the failure
+	Err     error
+}
+
+func (e *PageError) Error() string {
+	return fmt.Sprintf("page %d failed after %d items: %v", e.Page, e.Fetched, e.Err)
+}
+
+func (e *PageError) Unwrap() error {
+	return e.Err
+}
+
+// ForEachPage fetches pages in order and calls fn with each page's values.
+// It stops at the first fetch failure, returning a *PageError, or at the first
+// error returned by fn, which is passed through unchanged.
+func (p *Paginator) ForEachPage(ctx context.Context, fn func(values []json.RawMessage) error) error {
+	pageNum, fetched := 0, 0
 
 	for p.HasNextPage() {
+		pageNum++
 		page, err := p.NextPage(ctx)
 		if err != nil {
-			return nil, err
+			return &PageError{Page: pageNum, Fetched: fetched, Err: err}
 		}
 
 		if page == nil {
@@ -195,29 +217,47 @@ func (p *Paginator) FetchAll(ctx context.Context) ([]json.RawMessage, error) {
 		// Parse the values array
 		var values []json.RawMessage
 		if err := json.Unmarshal(page.Values, &values); err != nil {
-			return nil, fmt.Errorf("failed to unmarshal values: %w", err)
+			return &PageError{Page: pageNum, Fetched: fetched, Err: fmt.Errorf("failed to unmarshal values: %w", err)}
 		}
 
-		allValues = append(allValues, values...)
+		// Trim to the exact limit
+		if p.options.Limit > 0 && fetched+len(values) > p.options.Limit {
+			values = values[:p.options.Limit-fetched]
+		}
+		fetched += len(values)
 
-		// Check if we've reached our limit
-		if p.options.Limit > 0 && len(allValues) >= p.options.Limit {
-			// Trim to exact limit
-			if len(allValues) > p.options.Limit {
-				allValues = allValues[:p.options.Limit]
-			}
+		if err := fn(values); err != nil {
+			return err
+		}
+
+		if p.options.Limit > 0 && fetched >= p.options.Limit {
 			break
 		}
 	}
 
-	return allValues, nil
+	return nil
+}
+
+// FetchAll fetches all pages and returns all values as a single slice. If a
+// page fails, the values fetched so far are returned with a *PageError.
+func (p *Paginator) FetchAll(ctx context.Context) ([]json.RawMessage, error) {
+	var allValues []json.RawMessage
+
+	err := p.ForEachPage(ctx, func(values []json.RawMessage) error {
+		allValues = append(allValues, values...)
+		return nil
+	})
+
+	return allValues, err
 }
 
-// FetchAllTyped fetches all pages and unmarshals values into the provided slice
+// FetchAllTyped fetches all pages and unmarshals values into the provided
+// slice. On a *PageError the slice still holds the values fetched before the
+// failing page, so callers can show partial results.
 func (p *Paginator) FetchAllTyped(ctx context.Context, result interface{}) error {
-	allValues, err := p.FetchAll(ctx)
-	if err != nil {
-		return err
+	allValues, fetchErr := p.FetchAll(ctx)
+	if fetchErr != nil && len(allValues) == 0 {
+		return fetchErr
 	}
 
 	// Marshal all values as a JSON array
@@ -231,7 +271,7 @@ func (p *Paginator) FetchAllTyped(ctx context.Context, result interface{}) error
 		return fmt.Errorf("failed to unmarshal into result: %w", err)
 	}
 
-	return nil
+	return fetchErr
 }
 
 // Iterator provides an iterator interface for paginated results
@@ -3,6 +3,7 @@ package api
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
@@ -55,6 +56,8 @@ func (suite *PaginationTestSuite) paginationHandler(w http.ResponseWriter, r *ht
 		suite.handleEmptyPagination(w, r)
 	case "/single-page":
 		suite.handleSinglePagePagination(w, r)
+	case "/flaky":
+		suite.handleFlakyPagination(w, r, page)
 	case "/error":
 		w.WriteHeader(http.StatusInternalServerError)
 		fmt.Fprintf(w, `{"error": {"message": "Server error"}}`)
@@ -115,6 +118,39 @@ func (suite *PaginationTestSuite) handleItemsPagination(w http.ResponseWriter, r
 	json.NewEncoder(w).Encode(response)
 }
 
+// handleFlakyPagination serves three pages of two items, except that page 2
+// always fails with a non-retryable error.
+func (suite *PaginationTestSuite) handleFlakyPagination(w http.ResponseWriter, r *http.Request, page string) {
+	currentPage, _ := strconv.Atoi(page)
+	if currentPage == 0 {
+		currentPage = 1
+	}
+
+	if currentPage == 2 {
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprintf(w, `{"error": {"message": "page unavailable"}}`)
+		return
+	}
+
+	items := []map[string]interface{}{
+		{"id": currentPage*2 - 1},
+		{"id": currentPage * 2},
+	}
+	itemsJSON, _ := json.Marshal(items)
+
+	response := PaginatedResponse{
+		Size:    len(items),
+		Page:    currentPage,
+		PageLen: 2,
+		Values:  json.RawMessage(itemsJSON),
+	}
+	if currentPage < 3 {
+		response.Next = fmt.Sprintf("%s/flaky?page=%d&pagelen=2", suite.server.URL, currentPage+1)
+	}
+
+	json.NewEncoder(w).Encode(response)
+}
+
 func (suite *PaginationTestSuite) handleEmptyPagination(w http.ResponseWriter, r *http.Request) {
 	response := PaginatedResponse{
 		Size:    0,
@@ -409,6 +445,62 @@ func (suite *PaginationTestSuite) TestPaginatorWithLimitReached() {
 	assert.False(suite.T(), paginator.HasNextPage())
 }
 
+func (suite *PaginationTestSuite) TestFetchAllPartialResults() {
+	paginator := NewPaginator(suite.client, "/flaky", &PageOptions{Page: 1, PageLen: 2})
+
+	allValues, err := paginator.FetchAll(context.Background())
+	require.Error(suite.T(), err)
+	assert.Len(suite.T(), allValues, 2)
+
+	var pageErr *PageError
+	require.True(suite.T(), errors.As(err, &pageErr))
+	assert.Equal(suite.T(), 2, pageErr.Page)
+	assert.Equal(suite.T(), 2, pageErr.Fetched)
+	assert.Contains(suite.T(), err.Error(), "page 2 failed after 2 items")
+}
+
+func (suite *PaginationTestSuite) TestFetchAllTypedPartialResults() {
+	paginator := NewPaginator(suite.client, "/flaky", &PageOptions{Page: 1, PageLen: 2})
+
+	var items []struct {
+		ID int `json:"id"`
+	}
+	err := paginator.FetchAllTyped(context.Background(), &items)
+
+	var pageErr *PageError
+	require.True(suite.T(), errors.As(err, &pageErr))
+	require.Len(suite.T(), items, 2)
+	assert.Equal(suite.T(), 1, items[0].ID)
+	assert.Equal(suite.T(), 2, items[1].ID)
+}
+
+func (suite *PaginationTestSuite) TestForEachPageStopsOnCallbackError() {
+	paginator := NewPaginator(suite.client, "/items", &PageOptions{Page: 1, PageLen: 2})
+	stop := errors.New("stop")
+
+	pages := 0
+	err := paginator.ForEachPage(context.Background(), func(values []json.RawMessage) error {
+		pages++
+		return stop
+	})
+
+	assert.Same(suite.T(), stop, err)
+	assert.Equal(suite.T(), 1, pages)
+}
+
+func (suite *PaginationTestSuite) TestForEachPageRespectsLimit() {
+	paginator := NewPaginator(suite.client, "/items", &PageOptions{Page: 1, PageLen: 2, Limit: 3})
+
+	var seen int
+	err := paginator.ForEachPage(context.Background(), func(values []json.RawMessage) error {
+		seen += len(values)
+		return nil
+	})
+
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), 3, seen)
+}
+
 // TestPagination runs the pagination test suite
 func TestPagination(t *testing.T) {
 	suite.Run(t, new(PaginationTestSuite))
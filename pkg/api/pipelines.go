@@ -194,7 +194,7 @@ func (p *PipelineService) GetStepLogs(ctx context.Context, workspace, repoSlug,
 	}
 
 	if targetStep == nil {
-		return nil, fmt.Errorf("all direct log endpoints failed (first error: %v), step with UUID %s not found in pipeline", originalErr, stepUUID)
+		return nil, fmt.Errorf("all direct log endpoints failed (first error: %w), step with UUID %s not found in pipeline", originalErr, stepUUID)
 	}
 
 	// Check if the step has logs available via the logs link
@@ -203,7 +203,7 @@ func (p *PipelineService) GetStepLogs(ctx context.Context, workspace, repoSlug,
 		if targetStep.State != nil {
 			stepStatus = targetStep.State.Name
 		}
-		return nil, fmt.Errorf("no logs available: direct endpoints failed (%v), and no logs link for step '%s' (status: %s)", originalErr, targetStep.Name, stepStatus)
+		return nil, fmt.Errorf("no logs available: direct endpoints failed (%w), and no logs link for step '%s' (status: %s)", originalErr, targetStep.Name, stepStatus)
 	}
 
 	// Try the container-specific logs URL
@@ -365,6 +365,33 @@ func (p *PipelineService) CancelPipeline(ctx context.Context, workspace, repoSlu
 	return nil
 }
 
+// StartStep starts a manual step of a pipeline, such as a deployment step
+// that is waiting to be run
+func (p *PipelineService) StartStep(ctx context.Context, workspace, repoSlug, pipelineUUID, stepUUID string) error {
+	if workspace == "" || repoSlug == "" || pipelineUUID == "" || stepUUID == "" {
+		return NewValidationError("workspace, repository slug, pipeline UUID, and step UUID are required", "")
+	}
+
+	endpoint := StartStepEndpoint(workspace, repoSlug, pipelineUUID, stepUUID)
+
+	resp, err := p.client.Post(ctx, endpoint, nil)
+	if err != nil {
+		return fmt.Errorf("failed to start step: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return ParseError(resp)
+	}
+
+	return nil
+}
+
+// StartStepEndpoint is the endpoint StartStep posts to
+func StartStepEndpoint(workspace, repoSlug, pipelineUUID, stepUUID string) string {
+	return fmt.Sprintf("repositories/%s/%s/pipelines/%s/steps/%s/start", workspace, repoSlug, pipelineUUID, stepUUID)
+}
+
 // TriggerPipeline creates and starts a new pipeline
 func (p *PipelineService) TriggerPipeline(ctx context.Context, workspace, repoSlug string, request *TriggerPipelineRequest) (*Pipeline, error) {
 	if workspace == "" || repoSlug == "" {
@@ -630,10 +657,11 @@ func (p *PipelineService) WaitForPipelineCompletion(ctx context.Context, workspa
 				return nil, fmt.Errorf("failed to check pipeline status: %w", err)
 			}
 
-			// Check if pipeline is completed
+			// Check if pipeline is completed; Bitbucket reports COMPLETED
+			// with the outcome in the state's result
 			if pipeline.State != nil {
 				switch pipeline.State.Name {
-				case "SUCCESSFUL", "FAILED", "ERROR", "STOPPED":
+				case "COMPLETED", "SUCCESSFUL", "FAILED", "ERROR", "STOPPED":
 					return pipeline, nil
 				case "PENDING", "IN_PROGRESS":
 					// Continue polling
@@ -669,3 +697,20 @@ func (p *PipelineService) GetPipelinesByCommit(ctx context.Context, workspace, r
 
 	return pipelines, nil
 }
+
+// GetPipelinesConfig returns the Pipelines configuration of a repository,
+// which tells whether Pipelines are enabled for it
+func (p *PipelineService) GetPipelinesConfig(ctx context.Context, workspace, repoSlug string) (*PipelinesConfig, error) {
+	if workspace == "" || repoSlug == "" {
+		return nil, NewValidationError("workspace and repository slug are required", "")
+	}
+
+	endpoint := fmt.Sprintf("repositories/%s/%s/pipelines_config", workspace, repoSlug)
+
+	var config PipelinesConfig
+	if err := p.client.GetJSON(ctx, endpoint, &config); err != nil {
+		return nil, fmt.Errorf("failed to get pipelines config: %w", err)
+	}
+
+	return &config, nil
+}
@@ -18,6 +18,7 @@ type PullRequest struct {
 	CommentCount      int                       `json:"comment_count"`
 	TaskCount         int                       `json:"task_count"`
 	CloseSourceBranch bool                      `json:"close_source_branch"`
+	Draft             bool                      `json:"draft,omitempty"`
 	ClosedBy          *User                     `json:"closed_by,omitempty"`
 	Reason            string                    `json:"reason,omitempty"`
 	CreatedOn         *time.Time                `json:"created_on,omitempty"`
@@ -26,6 +27,9 @@ type PullRequest struct {
 	Participants      []*PullRequestParticipant `json:"participants,omitempty"`
 	Links             *PullRequestLinks         `json:"links,omitempty"`
 	Summary           *PullRequestSummary       `json:"summary,omitempty"`
+	Milestone         *TrackerField             `json:"milestone,omitempty"`
+	Version           *TrackerField             `json:"version,omitempty"`
+	Component         *TrackerField             `json:"component,omitempty"`
 }
 
 // PullRequestBranch represents a branch in a pull request (source or destination)
@@ -42,6 +46,13 @@ type Branch struct {
 	Links *Links `json:"links,omitempty"`
 }
 
+// BranchRef is a branch as returned by the refs API, with the commit it
+// points at
+type BranchRef struct {
+	Name   string  `json:"name"`
+	Target *Commit `json:"target,omitempty"`
+}
+
 // PullRequestParticipant represents a participant in a pull request
 type PullRequestParticipant struct {
 	Type           string     `json:"type"`
@@ -201,12 +212,16 @@ func (p PullRequestParticipantState) String() string {
 
 // PullRequestListOptions represents options for listing pull requests
 type PullRequestListOptions struct {
-	State    string `json:"state,omitempty"`    // OPEN, MERGED, DECLINED, SUPERSEDED
-	Author   string `json:"author,omitempty"`   // Filter by author username
-	Reviewer string `json:"reviewer,omitempty"` // Filter by reviewer username
-	Sort     string `json:"sort,omitempty"`     // Sort field (created_on, updated_on, priority, title)
-	Page     int    `json:"page,omitempty"`     // Page number
-	PageLen  int    `json:"pagelen,omitempty"`  // Items per page
+	State         string     `json:"state,omitempty"`          // OPEN, MERGED, DECLINED, SUPERSEDED
+	States        []string   `json:"states,omitempty"`         // Several states at once; without any, Bitbucket lists open pull requests only
+	Author        string     `json:"author,omitempty"`         // Filter by author username
+	Reviewer      string     `json:"reviewer,omitempty"`       // Filter by reviewer username
+	Destination   string     `json:"destination,omitempty"`    // Filter by destination (base) branch name
+	UpdatedBefore *time.Time `json:"updated_before,omitempty"` // Only pull requests last updated before this time
+	UpdatedAfter  *time.Time `json:"updated_after,omitempty"`  // Only pull requests last updated after this time
+	Sort          string     `json:"sort,omitempty"`           // Sort field (created_on, updated_on, priority, title)
+	Page          int        `json:"page,omitempty"`           // Page number
+	PageLen       int        `json:"pagelen,omitempty"`        // Items per page
 }
 
 // CreatePullRequestRequest represents a request to create a pull request
@@ -219,6 +234,15 @@ type CreatePullRequestRequest struct {
 	Reviewers         []*PullRequestParticipant `json:"reviewers,omitempty"`
 	CloseSourceBranch bool                      `json:"close_source_branch,omitempty"`
 	Draft             bool                      `json:"draft,omitempty"`
+	Milestone         *TrackerField             `json:"milestone,omitempty"`
+	Version           *TrackerField             `json:"version,omitempty"`
+	Component         *TrackerField             `json:"component,omitempty"`
+}
+
+// TrackerField references a milestone, version or component of the
+// repository's built-in issue tracker by name
+type TrackerField struct {
+	Name string `json:"name"`
 }
 
 // UpdatePullRequestRequest represents a request to update a pull request
@@ -229,6 +253,7 @@ type UpdatePullRequestRequest struct {
 	State             string                    `json:"state,omitempty"`
 	Reviewers         []*PullRequestParticipant `json:"reviewers,omitempty"`
 	CloseSourceBranch *bool                     `json:"close_source_branch,omitempty"`
+	Draft             *bool                     `json:"draft,omitempty"`
 }
 
 // AddCommentRequest represents a request to add a comment to a pull request
@@ -6,6 +6,7 @@ import (
 	"io"
 	"net/url"
 	"strings"
+	"time"
 )
 
 // PullRequestService provides pull request-related API operations
@@ -31,30 +32,7 @@ func (p *PullRequestService) ListPullRequests(ctx context.Context, workspace, re
 	queryParams := url.Values{}
 	queryParams.Set("fields", "+values.reviewers,+values.participants")
 
-	// Add query parameters if options are provided
-	if options != nil {
-		var filterParts []string
-
-		if options.State != "" {
-			filterParts = append(filterParts, fmt.Sprintf("state=\"%s\"", options.State))
-		}
-
-		if options.Author != "" {
-			filterParts = append(filterParts, fmt.Sprintf("author.username=\"%s\"", options.Author))
-		}
-
-		if options.Reviewer != "" {
-			filterParts = append(filterParts, fmt.Sprintf("reviewers.username=\"%s\"", options.Reviewer))
-		}
-
-		if len(filterParts) > 0 {
-			queryParams.Set("q", strings.Join(filterParts, " AND "))
-		}
-
-		if options.Sort != "" {
-			queryParams.Set("sort", options.Sort)
-		}
-	}
+	addPullRequestListFilters(queryParams, options, true)
 
 	if encodedParams := queryParams.Encode(); encodedParams != "" {
 		endpoint += "?" + encodedParams
@@ -80,6 +58,81 @@ func (p *PullRequestService) ListPullRequests(ctx context.Context, workspace, re
 	return paginator.NextPage(ctx)
 }
 
+// ListWorkspacePullRequests lists the pull requests user authored in any
+// repository of the workspace, following every page. options filter them as
+// for ListPullRequests, except that the author is always user.
+func (p *PullRequestService) ListWorkspacePullRequests(ctx context.Context, workspace, user string, options *PullRequestListOptions) ([]*PullRequest, error) {
+	if workspace == "" || user == "" {
+		return nil, NewValidationError("workspace and user are required", "")
+	}
+
+	endpoint := fmt.Sprintf("workspaces/%s/pullrequests/%s", workspace, url.PathEscape(user))
+
+	queryParams := url.Values{}
+	addPullRequestListFilters(queryParams, options, false)
+	if encodedParams := queryParams.Encode(); encodedParams != "" {
+		endpoint += "?" + encodedParams
+	}
+
+	pageOptions := &PageOptions{Page: 1, PageLen: 50}
+	if options != nil && options.PageLen > 0 {
+		pageOptions.PageLen = options.PageLen
+	}
+
+	var pullRequests []*PullRequest
+	if err := p.client.Paginate(endpoint, pageOptions).FetchAllTyped(ctx, &pullRequests); err != nil {
+		return nil, err
+	}
+	return pullRequests, nil
+}
+
+// addPullRequestListFilters adds the filters and sort order of options to a
+// pull request listing query. withAuthor is false for endpoints that are
+// already scoped to one author.
+func addPullRequestListFilters(queryParams url.Values, options *PullRequestListOptions, withAuthor bool) {
+	if options == nil {
+		return
+	}
+
+	var filterParts []string
+
+	if options.State != "" {
+		filterParts = append(filterParts, fmt.Sprintf("state=\"%s\"", options.State))
+	}
+
+	if withAuthor && options.Author != "" {
+		filterParts = append(filterParts, fmt.Sprintf("author.username=\"%s\"", options.Author))
+	}
+
+	if options.Reviewer != "" {
+		filterParts = append(filterParts, fmt.Sprintf("reviewers.username=\"%s\"", options.Reviewer))
+	}
+
+	if options.Destination != "" {
+		filterParts = append(filterParts, fmt.Sprintf("destination.branch.name=\"%s\"", options.Destination))
+	}
+
+	if options.UpdatedBefore != nil {
+		filterParts = append(filterParts, fmt.Sprintf("updated_on<%s", options.UpdatedBefore.UTC().Format(time.RFC3339)))
+	}
+
+	if options.UpdatedAfter != nil {
+		filterParts = append(filterParts, fmt.Sprintf("updated_on>%s", options.UpdatedAfter.UTC().Format(time.RFC3339)))
+	}
+
+	if len(filterParts) > 0 {
+		queryParams.Set("q", strings.Join(filterParts, " AND "))
+	}
+
+	if options.Sort != "" {
+		queryParams.Set("sort", options.Sort)
+	}
+
+	for _, state := range options.States {
+		queryParams.Add("state", state)
+	}
+}
+
 // GetPullRequest retrieves detailed information about a specific pull request
 func (p *PullRequestService) GetPullRequest(ctx context.Context, workspace, repoSlug string, id int) (*PullRequest, error) {
 	if workspace == "" || repoSlug == "" {
@@ -101,26 +154,17 @@ func (p *PullRequestService) GetPullRequest(ctx context.Context, workspace, repo
 	return &pullRequest, nil
 }
 
-// GetPullRequestDiff retrieves the unified diff for a pull request
+// GetPullRequestDiff retrieves the unified diff for a pull request. Very
+// large diffs are better read with StreamPullRequestDiff.
 func (p *PullRequestService) GetPullRequestDiff(ctx context.Context, workspace, repoSlug string, id int) (string, error) {
-	if workspace == "" || repoSlug == "" {
-		return "", NewValidationError("workspace and repository slug are required", "")
-	}
-
-	if id <= 0 {
-		return "", NewValidationError("pull request ID must be positive", "")
-	}
-
-	endpoint := fmt.Sprintf("repositories/%s/%s/pullrequests/%d/diff", workspace, repoSlug, id)
-
-	resp, err := p.client.Get(ctx, endpoint)
+	stream, err := p.StreamPullRequestDiff(ctx, workspace, repoSlug, id)
 	if err != nil {
 		return "", err
 	}
-	defer resp.Body.Close()
+	defer stream.Close()
 
 	// Read the raw diff content
-	diffBytes, err := io.ReadAll(resp.Body)
+	diffBytes, err := io.ReadAll(stream)
 	if err != nil {
 		return "", fmt.Errorf("failed to read diff content: %w", err)
 	}
@@ -141,14 +185,67 @@ func (p *PullRequestService) GetPullRequestFiles(ctx context.Context, workspace,
 	endpoint := fmt.Sprintf("repositories/%s/%s/pullrequests/%d/diffstat", workspace, repoSlug, id)
 
 	var diffStat PullRequestDiffStat
-	err := p.client.GetJSON(ctx, endpoint, &diffStat)
-	if err != nil {
-		return nil, err
+	for endpoint != "" {
+		var page diffStatPage
+		if err := p.client.GetJSON(ctx, endpoint, &page); err != nil {
+			return nil, err
+		}
+		if page.Values == nil {
+			// Already summarized: nothing to add up
+			return &page.PullRequestDiffStat, nil
+		}
+
+		for _, entry := range page.Values {
+			file := entry.file()
+			diffStat.Files = append(diffStat.Files, file)
+			diffStat.LinesAdded += file.LinesAdded
+			diffStat.LinesRemoved += file.LinesRemoved
+		}
+		endpoint = page.Next
 	}
+	diffStat.FilesChanged = len(diffStat.Files)
 
 	return &diffStat, nil
 }
 
+// diffStatPage is a page of the diffstat endpoint, which lists one entry per
+// changed file; the embedded summary is only filled by older responses
+type diffStatPage struct {
+	PullRequestDiffStat
+	Values []*diffStatEntry `json:"values"`
+	Next   string           `json:"next,omitempty"`
+}
+
+// diffStatEntry is one changed file as the diffstat endpoint reports it
+type diffStatEntry struct {
+	Type         string        `json:"type"`
+	Status       string        `json:"status"`
+	LinesAdded   int           `json:"lines_added"`
+	LinesRemoved int           `json:"lines_removed"`
+	Old          *diffStatPath `json:"old,omitempty"`
+	New          *diffStatPath `json:"new,omitempty"`
+}
+
+type diffStatPath struct {
+	Path string `json:"path"`
+}
+
+func (e *diffStatEntry) file() *PullRequestFile {
+	file := &PullRequestFile{
+		Type:         e.Type,
+		Status:       e.Status,
+		LinesAdded:   e.LinesAdded,
+		LinesRemoved: e.LinesRemoved,
+	}
+	if e.Old != nil {
+		file.OldPath = e.Old.Path
+	}
+	if e.New != nil {
+		file.NewPath = e.New.Path
+	}
+	return file
+}
+
 // ApprovePullRequest approves a pull request
 func (p *PullRequestService) ApprovePullRequest(ctx context.Context, workspace, repoSlug string, id int) (*PullRequestApproval, error) {
 	if workspace == "" || repoSlug == "" {
@@ -296,6 +393,27 @@ func (p *PullRequestService) GetAllComments(ctx context.Context, workspace, repo
 	return comments, nil
 }
 
+// GetPullRequestCommits retrieves every commit on a pull request across all pages
+func (p *PullRequestService) GetPullRequestCommits(ctx context.Context, workspace, repoSlug string, id int) ([]Commit, error) {
+	if workspace == "" || repoSlug == "" {
+		return nil, NewValidationError("workspace and repository slug are required", "")
+	}
+
+	if id <= 0 {
+		return nil, NewValidationError("pull request ID must be positive", "")
+	}
+
+	endpoint := fmt.Sprintf("repositories/%s/%s/pullrequests/%d/commits", workspace, repoSlug, id)
+
+	var commits []Commit
+	paginator := p.client.Paginate(endpoint, nil)
+	if err := paginator.FetchAllTyped(ctx, &commits); err != nil {
+		return nil, err
+	}
+
+	return commits, nil
+}
+
 // GetAllPullRequests fetches every pull request in a repository across all
 // pages, filtered only by state ("OPEN", "MERGED", "DECLINED", "SUPERSEDED",
 // or "" for all). Unlike ListPullRequests it applies no author filter, so it
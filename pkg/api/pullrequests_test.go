@@ -369,6 +369,32 @@ func TestPullRequestService_GetPullRequestFiles(t *testing.T) {
 	assert.Equal(t, "modified", diffStat.Files[0].Status)
 }
 
+func TestGetPullRequestFiles_SumsDiffstatPages(t *testing.T) {
+	var serverURL string
+	client := newAccessTokenTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/repositories/ws/repo/pullrequests/7/diffstat", r.URL.Path)
+
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Query().Get("page") == "2" {
+			w.Write([]byte(`{"values":[{"status":"added","lines_added":30,"lines_removed":0,"new":{"path":"docs/new.md"}}]}`))
+			return
+		}
+		w.Write([]byte(`{"values":[{"status":"modified","lines_added":12,"lines_removed":3,"old":{"path":"main.go"},"new":{"path":"main.go"}}],
+			"next":"` + serverURL + `/repositories/ws/repo/pullrequests/7/diffstat?page=2"}`))
+	})
+	serverURL = client.baseURL.String()
+
+	diffStat, err := client.PullRequests.GetPullRequestFiles(context.Background(), "ws", "repo", 7)
+	require.NoError(t, err)
+	assert.Equal(t, 42, diffStat.LinesAdded)
+	assert.Equal(t, 3, diffStat.LinesRemoved)
+	assert.Equal(t, 2, diffStat.FilesChanged)
+	require.Len(t, diffStat.Files, 2)
+	assert.Equal(t, "main.go", diffStat.Files[0].OldPath)
+	assert.Equal(t, "docs/new.md", diffStat.Files[1].NewPath)
+	assert.Empty(t, diffStat.Files[1].OldPath)
+}
+
 func TestPullRequestService_ApprovePullRequest(t *testing.T) {
 	// Create a test server
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
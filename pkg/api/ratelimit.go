@@ -0,0 +1,131 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Rate limit response headers sent by Bitbucket
+const (
+	HeaderRateLimitLimit     = "X-RateLimit-Limit"
+	HeaderRateLimitRemaining = "X-RateLimit-Remaining"
+	HeaderRateLimitReset     = "X-RateLimit-Reset"
+	HeaderRateLimitResource  = "X-RateLimit-Resource"
+	HeaderRateLimitNearLimit = "X-RateLimit-NearLimit"
+)
+
+// RateLimit is the API quota reported by the latest response. Bitbucket
+// doesn't send every header on every endpoint, so unreported values are left
+// zero (or nil for Remaining, where zero is meaningful).
+type RateLimit struct {
+	Resource   string     `json:"resource,omitempty" yaml:"resource,omitempty"`
+	Limit      int        `json:"limit,omitempty" yaml:"limit,omitempty"`
+	Remaining  *int       `json:"remaining,omitempty" yaml:"remaining,omitempty"`
+	Reset      *time.Time `json:"reset,omitempty" yaml:"reset,omitempty"`
+	NearLimit  bool       `json:"near_limit" yaml:"near_limit"`
+	Throttled  bool       `json:"throttled" yaml:"throttled"`
+	ObservedAt time.Time  `json:"observed_at" yaml:"observed_at"`
+}
+
+// Low reports whether the quota is nearly used up: Bitbucket says so, the
+// last request was throttled, or under a tenth of the limit remains
+func (r *RateLimit) Low() bool {
+	if r.NearLimit || r.Throttled {
+		return true
+	}
+	return r.Remaining != nil && r.Limit > 0 && *r.Remaining*10 <= r.Limit
+}
+
+// parseRateLimit reads the rate limit headers of resp, or returns nil when
+// it carries none
+func parseRateLimit(resp *http.Response, now time.Time) *RateLimit {
+	header := resp.Header
+	throttled := resp.StatusCode == http.StatusTooManyRequests
+
+	if !throttled && header.Get(HeaderRateLimitLimit) == "" && header.Get(HeaderRateLimitRemaining) == "" &&
+		header.Get(HeaderRateLimitResource) == "" && header.Get(HeaderRateLimitNearLimit) == "" {
+		return nil
+	}
+
+	limit := &RateLimit{
+		Resource:   header.Get(HeaderRateLimitResource),
+		NearLimit:  strings.EqualFold(header.Get(HeaderRateLimitNearLimit), "true"),
+		Throttled:  throttled,
+		ObservedAt: now,
+	}
+
+	if value, err := strconv.Atoi(header.Get(HeaderRateLimitLimit)); err == nil {
+		limit.Limit = value
+	}
+	if value, err := strconv.Atoi(header.Get(HeaderRateLimitRemaining)); err == nil {
+		limit.Remaining = &value
+	} else if throttled {
+		zero := 0
+		limit.Remaining = &zero
+	}
+
+	if value, err := strconv.ParseInt(header.Get(HeaderRateLimitReset), 10, 64); err == nil {
+		reset := time.Unix(value, 0)
+		limit.Reset = &reset
+	} else if seconds, err := strconv.Atoi(header.Get("Retry-After")); err == nil && throttled {
+		reset := now.Add(time.Duration(seconds) * time.Second)
+		limit.Reset = &reset
+	}
+
+	return limit
+}
+
+// recordRateLimit keeps the quota reported by resp and, the first time it
+// is low, tells the OnRateLimitLow hook
+func (c *Client) recordRateLimit(resp *http.Response) {
+	limit := parseRateLimit(resp, time.Now())
+	if limit == nil {
+		return
+	}
+
+	c.rateLimitMu.Lock()
+	c.rateLimit = limit
+	notify := limit.Low() && !c.rateLimitWarned && c.config.OnRateLimitLow != nil
+	if notify {
+		c.rateLimitWarned = true
+	}
+	c.rateLimitMu.Unlock()
+
+	if notify {
+		c.config.OnRateLimitLow(*limit)
+	}
+}
+
+// RateLimit returns the quota reported by the latest response, or nil when
+// no response carried rate limit headers yet
+func (c *Client) RateLimit() *RateLimit {
+	c.rateLimitMu.Lock()
+	defer c.rateLimitMu.Unlock()
+
+	if c.rateLimit == nil {
+		return nil
+	}
+	limit := *c.rateLimit
+	return &limit
+}
+
+// ProbeRateLimit sends a lightweight request for the current user and
+// returns the quota it reports, nil when Bitbucket sent no rate limit headers
+func (c *Client) ProbeRateLimit(ctx context.Context) (*RateLimit, error) {
+	resp, err := c.Get(ctx, "user")
+	if err != nil {
+		// A throttled probe still says when the quota resets
+		if IsRateLimitError(err) {
+			if limit := c.RateLimit(); limit != nil {
+				return limit, nil
+			}
+		}
+		return nil, err
+	}
+	resp.Body.Close()
+
+	return c.RateLimit(), nil
+}
@@ -0,0 +1,90 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseRateLimit(t *testing.T) {
+	now := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	resp := &http.Response{StatusCode: 200, Header: http.Header{}}
+	assert.Nil(t, parseRateLimit(resp, now), "no headers, no rate limit")
+
+	resp.Header.Set(HeaderRateLimitLimit, "1000")
+	resp.Header.Set(HeaderRateLimitRemaining, "80")
+	resp.Header.Set(HeaderRateLimitReset, "1709295000")
+	resp.Header.Set(HeaderRateLimitResource, "api")
+	limit := parseRateLimit(resp, now)
+	require.NotNil(t, limit)
+	assert.Equal(t, "api", limit.Resource)
+	assert.Equal(t, 1000, limit.Limit)
+	require.NotNil(t, limit.Remaining)
+	assert.Equal(t, 80, *limit.Remaining)
+	assert.Equal(t, time.Unix(1709295000, 0), *limit.Reset)
+	assert.True(t, limit.Low(), "under a tenth of the quota left")
+
+	resp.Header.Set(HeaderRateLimitRemaining, "500")
+	assert.False(t, parseRateLimit(resp, now).Low())
+
+	resp.Header.Set(HeaderRateLimitNearLimit, "true")
+	assert.True(t, parseRateLimit(resp, now).Low())
+}
+
+func TestParseRateLimit_Throttled(t *testing.T) {
+	now := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	resp := &http.Response{StatusCode: http.StatusTooManyRequests, Header: http.Header{"Retry-After": {"30"}}}
+
+	limit := parseRateLimit(resp, now)
+	require.NotNil(t, limit)
+	assert.True(t, limit.Throttled)
+	assert.Equal(t, 0, *limit.Remaining)
+	assert.Equal(t, now.Add(30*time.Second), *limit.Reset)
+	assert.True(t, limit.Low())
+}
+
+func TestClient_RateLimitTracking(t *testing.T) {
+	remaining := "900"
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set(HeaderRateLimitLimit, "1000")
+		w.Header().Set(HeaderRateLimitRemaining, remaining)
+		w.Write([]byte(`{}`))
+	}))
+	t.Cleanup(server.Close)
+
+	mockAuth := &MockAuthManager{}
+	mockAuth.On("SetHTTPHeaders", mock.AnythingOfType("*http.Request")).Return(nil)
+
+	var warnings []RateLimit
+	client, err := NewClient(mockAuth, &ClientConfig{
+		BaseURL:        server.URL,
+		Timeout:        5 * time.Second,
+		UserAgent:      "bt/test",
+		OnRateLimitLow: func(limit RateLimit) { warnings = append(warnings, limit) },
+	})
+	require.NoError(t, err)
+
+	assert.Nil(t, client.RateLimit(), "nothing observed before the first request")
+
+	limit, err := client.ProbeRateLimit(context.Background())
+	require.NoError(t, err)
+	require.NotNil(t, limit)
+	assert.Equal(t, 900, *limit.Remaining)
+	assert.Empty(t, warnings)
+
+	remaining = "50"
+	for i := 0; i < 2; i++ {
+		var out map[string]interface{}
+		require.NoError(t, client.GetJSON(context.Background(), "user", &out))
+	}
+	require.Len(t, warnings, 1, "low quota is reported once per client")
+	assert.Equal(t, 50, *warnings[0].Remaining)
+	assert.Equal(t, 50, *client.RateLimit().Remaining)
+}
@@ -3,6 +3,7 @@ package api
 import (
 	"context"
 	"fmt"
+	"io"
 	"net/url"
 )
 
@@ -65,3 +66,188 @@ func (r *RepositoryService) ListRepositories(ctx context.Context, workspace stri
 	paginator := r.client.Paginate(endpoint, pageOptions)
 	return paginator.NextPage(ctx)
 }
+
+// ListAllRepositories retrieves every repository in a workspace across all
+// pages. If a later page fails, the repositories fetched so far are returned
+// together with a *PageError.
+func (r *RepositoryService) ListAllRepositories(ctx context.Context, workspace string, options *RepositoryListOptions) ([]*Repository, error) {
+	if workspace == "" {
+		return nil, NewValidationError("workspace is required", "")
+	}
+
+	endpoint := fmt.Sprintf("repositories/%s", workspace)
+
+	pageOptions := &PageOptions{
+		Page:    1,
+		PageLen: MaxPageSize,
+	}
+
+	if options != nil {
+		queryParams := url.Values{}
+		if options.Role != "" {
+			queryParams.Set("role", options.Role)
+		}
+		if options.Query != "" {
+			queryParams.Set("q", options.Query)
+		}
+		if options.Sort != "" {
+			queryParams.Set("sort", options.Sort)
+		}
+		if len(queryParams) > 0 {
+			endpoint += "?" + queryParams.Encode()
+		}
+		if options.PageLen > 0 {
+			pageOptions.PageLen = options.PageLen
+		}
+	}
+
+	var repositories []*Repository
+	paginator := r.client.Paginate(endpoint, pageOptions)
+	err := paginator.FetchAllTyped(ctx, &repositories)
+	return repositories, err
+}
+
+// GetRepository retrieves a single repository
+func (r *RepositoryService) GetRepository(ctx context.Context, workspace, repoSlug string) (*Repository, error) {
+	if workspace == "" || repoSlug == "" {
+		return nil, NewValidationError("workspace and repository slug are required", "")
+	}
+
+	endpoint := fmt.Sprintf("repositories/%s/%s", workspace, repoSlug)
+
+	var repository Repository
+	if err := r.client.GetJSON(ctx, endpoint, &repository); err != nil {
+		return nil, err
+	}
+
+	return &repository, nil
+}
+
+// GetBranch retrieves a branch together with the commit it points at
+func (r *RepositoryService) GetBranch(ctx context.Context, workspace, repoSlug, branch string) (*BranchRef, error) {
+	if workspace == "" || repoSlug == "" || branch == "" {
+		return nil, NewValidationError("workspace, repository slug and branch are required", "")
+	}
+
+	endpoint := fmt.Sprintf("repositories/%s/%s/refs/branches/%s", workspace, repoSlug, branch)
+
+	var ref BranchRef
+	if err := r.client.GetJSON(ctx, endpoint, &ref); err != nil {
+		return nil, err
+	}
+
+	return &ref, nil
+}
+
+// DeleteBranch deletes a branch from the repository
+func (r *RepositoryService) DeleteBranch(ctx context.Context, workspace, repoSlug, branch string) error {
+	if workspace == "" || repoSlug == "" || branch == "" {
+		return NewValidationError("workspace, repository slug and branch are required", "")
+	}
+
+	endpoint := fmt.Sprintf("repositories/%s/%s/refs/branches/%s", workspace, repoSlug, branch)
+
+	resp, err := r.client.Delete(ctx, endpoint)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+
+	return nil
+}
+
+// TrackerFieldKinds are the issue tracker fields ListTrackerFields can list
+var TrackerFieldKinds = []string{"milestones", "versions", "components"}
+
+// ListTrackerFields returns the names defined for one of the issue tracker
+// fields of a repository: "milestones", "versions" or "components"
+func (r *RepositoryService) ListTrackerFields(ctx context.Context, workspace, repoSlug, kind string) ([]string, error) {
+	if workspace == "" || repoSlug == "" {
+		return nil, NewValidationError("workspace and repository slug are required", "")
+	}
+
+	valid := false
+	for _, k := range TrackerFieldKinds {
+		if k == kind {
+			valid = true
+			break
+		}
+	}
+	if !valid {
+		return nil, NewValidationError(fmt.Sprintf("unknown issue tracker field %q", kind), "")
+	}
+
+	endpoint := fmt.Sprintf("repositories/%s/%s/%s", workspace, repoSlug, kind)
+
+	var fields []*TrackerField
+	paginator := r.client.Paginate(endpoint, &PageOptions{PageLen: 50})
+	if err := paginator.FetchAllTyped(ctx, &fields); err != nil {
+		return nil, fmt.Errorf("failed to fetch %s: %w", kind, err)
+	}
+
+	names := make([]string, 0, len(fields))
+	for _, field := range fields {
+		names = append(names, field.Name)
+	}
+	return names, nil
+}
+
+type CommitListOptions struct {
+	Revision string
+	Limit    int
+}
+
+// ListCommits retrieves the commit history of a repository, newest first.
+// Revision selects a branch, tag or hash to start from (default: main branch).
+func (r *RepositoryService) ListCommits(ctx context.Context, workspace, repoSlug string, options *CommitListOptions) ([]Commit, error) {
+	if workspace == "" || repoSlug == "" {
+		return nil, NewValidationError("workspace and repository slug are required", "")
+	}
+
+	endpoint := fmt.Sprintf("repositories/%s/%s/commits", workspace, repoSlug)
+	if options != nil && options.Revision != "" {
+		endpoint += "/" + url.PathEscape(options.Revision)
+	}
+
+	pageOptions := &PageOptions{
+		Page:    1,
+		PageLen: 30,
+	}
+	if options != nil && options.Limit > 0 {
+		pageOptions.Limit = options.Limit
+		if options.Limit < pageOptions.PageLen {
+			pageOptions.PageLen = options.Limit
+		}
+	}
+
+	var commits []Commit
+	paginator := r.client.Paginate(endpoint, pageOptions)
+	if err := paginator.FetchAllTyped(ctx, &commits); err != nil {
+		return nil, err
+	}
+
+	return commits, nil
+}
+
+// GetCommitDiff retrieves the unified diff a commit introduces, against its
+// first parent
+func (r *RepositoryService) GetCommitDiff(ctx context.Context, workspace, repoSlug, hash string) (string, error) {
+	if workspace == "" || repoSlug == "" || hash == "" {
+		return "", NewValidationError("workspace, repository slug and commit hash are required", "")
+	}
+
+	endpoint := fmt.Sprintf("repositories/%s/%s/diff/%s", workspace, repoSlug, url.PathEscape(hash))
+
+	resp, err := r.client.Get(ctx, endpoint)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	diff, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", fmt.Errorf("failed to read diff content: %w", err)
+	}
+
+	return string(diff), nil
+}
@@ -0,0 +1,69 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepositoryService_GetRepository(t *testing.T) {
+	client := newAccessTokenTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/repositories/ws/repo", r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"type":"repository","name":"repo","full_name":"ws/repo","has_issues":true}`))
+	})
+
+	repository, err := client.Repositories.GetRepository(context.Background(), "ws", "repo")
+	require.NoError(t, err)
+	assert.Equal(t, "ws/repo", repository.FullName)
+	assert.True(t, repository.HasIssues)
+}
+
+func TestRepositoryService_ListTrackerFields(t *testing.T) {
+	client := newAccessTokenTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/repositories/ws/repo/milestones", r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"values":[{"name":"M1","id":1},{"name":"M2","id":2}]}`))
+	})
+
+	names, err := client.Repositories.ListTrackerFields(context.Background(), "ws", "repo", "milestones")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"M1", "M2"}, names)
+
+	_, err = client.Repositories.ListTrackerFields(context.Background(), "ws", "repo", "labels")
+	var bbErr *BitbucketError
+	require.ErrorAs(t, err, &bbErr)
+	assert.Equal(t, ErrorTypeValidation, bbErr.Type)
+}
+
+func TestRepositoryService_GetBranch(t *testing.T) {
+	client := newAccessTokenTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/repositories/ws/repo/refs/branches/feature/login", r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"name":"feature/login","target":{"type":"commit","hash":"abc123def456"}}`))
+	})
+
+	branch, err := client.Repositories.GetBranch(context.Background(), "ws", "repo", "feature/login")
+	require.NoError(t, err)
+	assert.Equal(t, "feature/login", branch.Name)
+	require.NotNil(t, branch.Target)
+	assert.Equal(t, "abc123def456", branch.Target.Hash)
+}
+
+func TestRepositoryService_DeleteBranch(t *testing.T) {
+	client := newAccessTokenTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodDelete, r.Method)
+		assert.Equal(t, "/repositories/ws/repo/refs/branches/feature/login", r.URL.Path)
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	require.NoError(t, client.Repositories.DeleteBranch(context.Background(), "ws", "repo", "feature/login"))
+
+	err := client.Repositories.DeleteBranch(context.Background(), "ws", "repo", "")
+	var bbErr *BitbucketError
+	require.ErrorAs(t, err, &bbErr)
+	assert.Equal(t, ErrorTypeValidation, bbErr.Type)
+}
@@ -50,6 +50,14 @@ type PipelineTarget struct {
 	Selector      *Selector `json:"selector,omitempty"`
 	Commit        *Commit   `json:"commit,omitempty"`
 	PullRequestId *int      `json:"pull_request_id,omitempty"`
+	// PullRequest is the pull request a pipeline_pullrequest_target runs for
+	PullRequest *PipelinePullRequest `json:"pullrequest,omitempty"`
+}
+
+// PipelinePullRequest references the pull request of a pipeline target
+type PipelinePullRequest struct {
+	ID    int    `json:"id"`
+	Title string `json:"title,omitempty"`
 }
 
 // PipelineTrigger represents what triggered the pipeline
@@ -93,6 +101,14 @@ type PipelineStep struct {
 	Logs             *Link              `json:"logs,omitempty"`
 	MaxTime          int                `json:"max_time,omitempty"`
 	BuildSecondsUsed int                `json:"build_seconds_used"`
+	ParallelGroup    *PipelineStepGroup `json:"parallel_group,omitempty"`
+}
+
+// PipelineStepGroup identifies the parallel block a pipeline step runs in.
+// Steps outside a parallel block have none.
+type PipelineStepGroup struct {
+	UUID string `json:"uuid,omitempty"`
+	Name string `json:"name,omitempty"`
 }
 
 // PipelineImage represents the Docker image used in a pipeline step
@@ -109,6 +125,11 @@ type PipelineCommand struct {
 	Command string `json:"command"`
 }
 
+// PipelinesConfig is a repository's Pipelines configuration
+type PipelinesConfig struct {
+	Enabled bool `json:"enabled"`
+}
+
 // User represents a Bitbucket user
 type User struct {
 	Type        string `json:"type"`
@@ -122,11 +143,14 @@ type User struct {
 
 // Repository represents a Bitbucket repository
 type Repository struct {
-	Type     string `json:"type"`
-	UUID     string `json:"uuid,omitempty"`
-	Name     string `json:"name"`
-	FullName string `json:"full_name"`
-	Links    *Links `json:"links,omitempty"`
+	Type      string `json:"type"`
+	UUID      string `json:"uuid,omitempty"`
+	Name      string `json:"name"`
+	FullName  string `json:"full_name"`
+	HasIssues bool   `json:"has_issues,omitempty"`
+	// MainBranch is the repository's default branch
+	MainBranch *Branch `json:"mainbranch,omitempty"`
+	Links      *Links  `json:"links,omitempty"`
 }
 
 // Selector represents a pipeline selector
@@ -137,12 +161,33 @@ type Selector struct {
 
 // Commit represents a Git commit
 type Commit struct {
-	Type    string     `json:"type"`
-	Hash    string     `json:"hash"`
-	Message string     `json:"message,omitempty"`
-	Date    *time.Time `json:"date,omitempty"`
-	Author  *User      `json:"author,omitempty"`
-	Links   *Links     `json:"links,omitempty"`
+	Type      string           `json:"type"`
+	Hash      string           `json:"hash"`
+	Message   string           `json:"message,omitempty"`
+	Date      *time.Time       `json:"date,omitempty"`
+	Author    *CommitAuthor    `json:"author,omitempty"`
+	Committer *CommitAuthor    `json:"committer,omitempty"`
+	Signature *CommitSignature `json:"signature,omitempty"`
+	Parents   []Commit         `json:"parents,omitempty"`
+	Links     *Links           `json:"links,omitempty"`
+}
+
+// CommitAuthor represents the author or committer of a commit: the raw git
+// identity and, when Bitbucket can map it, the matching account
+type CommitAuthor struct {
+	Type string `json:"type,omitempty"`
+	Raw  string `json:"raw,omitempty"`
+	User *User  `json:"user,omitempty"`
+}
+
+// CommitSignature represents the signature verification status of a commit.
+// Bitbucket only includes it for signed commits on some plans, so a nil value
+// means "not reported" rather than "unsigned"
+type CommitSignature struct {
+	Signed   bool   `json:"signed"`
+	Verified bool   `json:"verified"`
+	Signer   string `json:"signer,omitempty"`
+	Reason   string `json:"reason,omitempty"`
 }
 
 // Link represents a hypermedia link
@@ -0,0 +1,102 @@
+package api
+
+import (
+	"context"
+	"fmt"
+)
+
+// VariableService handles repository and deployment environment variable
+// operations
+type VariableService struct {
+	client *Client
+}
+
+// NewVariableService creates a new variable service
+func NewVariableService(client *Client) *VariableService {
+	return &VariableService{
+		client: client,
+	}
+}
+
+// variablesEndpoint returns the endpoint of the repository variables, or of
+// the variables of one deployment environment when environmentUUID is set
+func variablesEndpoint(workspace, repoSlug, environmentUUID string) string {
+	if environmentUUID != "" {
+		return fmt.Sprintf("repositories/%s/%s/deployments_config/environments/%s/variables", workspace, repoSlug, environmentUUID)
+	}
+	return fmt.Sprintf("repositories/%s/%s/pipelines_config/variables", workspace, repoSlug)
+}
+
+// ListVariables returns the pipeline variables of a repository, or of one
+// deployment environment when environmentUUID is set. Secured variables are
+// returned without their value.
+func (s *VariableService) ListVariables(ctx context.Context, workspace, repoSlug, environmentUUID string) ([]*PipelineVariable, error) {
+	if workspace == "" || repoSlug == "" {
+		return nil, NewValidationError("workspace and repository slug are required", "")
+	}
+
+	var variables []*PipelineVariable
+	paginator := s.client.Paginate(variablesEndpoint(workspace, repoSlug, environmentUUID), &PageOptions{PageLen: 100})
+	if err := paginator.FetchAllTyped(ctx, &variables); err != nil {
+		return nil, fmt.Errorf("failed to fetch variables: %w", err)
+	}
+
+	return variables, nil
+}
+
+// CreateVariable adds a variable to a repository, or to one deployment
+// environment when environmentUUID is set
+func (s *VariableService) CreateVariable(ctx context.Context, workspace, repoSlug, environmentUUID string, variable *PipelineVariable) (*PipelineVariable, error) {
+	if workspace == "" || repoSlug == "" {
+		return nil, NewValidationError("workspace and repository slug are required", "")
+	}
+
+	if variable == nil || variable.Key == "" {
+		return nil, NewValidationError("variable key is required", "")
+	}
+
+	var result PipelineVariable
+	if err := s.client.PostJSON(ctx, variablesEndpoint(workspace, repoSlug, environmentUUID), variable, &result); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// UpdateVariable replaces the value and secured flag of an existing variable
+func (s *VariableService) UpdateVariable(ctx context.Context, workspace, repoSlug, environmentUUID, variableUUID string, variable *PipelineVariable) (*PipelineVariable, error) {
+	if workspace == "" || repoSlug == "" || variableUUID == "" {
+		return nil, NewValidationError("workspace, repository slug, and variable UUID are required", "")
+	}
+
+	if variable == nil || variable.Key == "" {
+		return nil, NewValidationError("variable key is required", "")
+	}
+
+	endpoint := variablesEndpoint(workspace, repoSlug, environmentUUID) + "/" + variableUUID
+
+	var result PipelineVariable
+	if err := s.client.PutJSON(ctx, endpoint, variable, &result); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// DeleteVariable removes a variable from a repository, or from one
+// deployment environment when environmentUUID is set
+func (s *VariableService) DeleteVariable(ctx context.Context, workspace, repoSlug, environmentUUID, variableUUID string) error {
+	if workspace == "" || repoSlug == "" || variableUUID == "" {
+		return NewValidationError("workspace, repository slug, and variable UUID are required", "")
+	}
+
+	endpoint := variablesEndpoint(workspace, repoSlug, environmentUUID) + "/" + variableUUID
+
+	resp, err := s.client.Delete(ctx, endpoint)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+
+	return nil
+}
@@ -0,0 +1,94 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVariableService_ListVariables(t *testing.T) {
+	tests := []struct {
+		name        string
+		environment string
+		wantPath    string
+	}{
+		{"repository", "", "/repositories/ws/repo/pipelines_config/variables"},
+		{"environment", "{env-1}", "/repositories/ws/repo/deployments_config/environments/{env-1}/variables"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := newAccessTokenTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, http.MethodGet, r.Method)
+				assert.Equal(t, tt.wantPath, r.URL.Path)
+
+				w.Header().Set("Content-Type", "application/json")
+				w.Write([]byte(`{"values":[{"uuid":"{v1}","key":"REGION","value":"eu-west-1"},{"uuid":"{v2}","key":"TOKEN","secured":true}]}`))
+			})
+
+			variables, err := client.Variables.ListVariables(context.Background(), "ws", "repo", tt.environment)
+			require.NoError(t, err)
+			require.Len(t, variables, 2)
+			assert.Equal(t, "eu-west-1", variables[0].Value)
+			assert.True(t, variables[1].Secured)
+			assert.Empty(t, variables[1].Value)
+		})
+	}
+}
+
+func TestVariableService_CreateVariable(t *testing.T) {
+	client := newAccessTokenTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "/repositories/ws/repo/pipelines_config/variables", r.URL.Path)
+
+		var body PipelineVariable
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		assert.Equal(t, "TOKEN", body.Key)
+		assert.Equal(t, "s3cret", body.Value)
+		assert.True(t, body.Secured)
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"uuid":"{v2}","key":"TOKEN","secured":true}`))
+	})
+
+	variable, err := client.Variables.CreateVariable(context.Background(), "ws", "repo", "", &PipelineVariable{
+		Key:     "TOKEN",
+		Value:   "s3cret",
+		Secured: true,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "{v2}", variable.UUID)
+
+	_, err = client.Variables.CreateVariable(context.Background(), "ws", "repo", "", &PipelineVariable{})
+	assert.Error(t, err)
+}
+
+func TestVariableService_UpdateAndDeleteVariable(t *testing.T) {
+	var methods []string
+	client := newAccessTokenTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/repositories/ws/repo/deployments_config/environments/{env-1}/variables/{v1}", r.URL.Path)
+		methods = append(methods, r.Method)
+
+		if r.Method == http.MethodDelete {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"uuid":"{v1}","key":"REGION","value":"us-east-1"}`))
+	})
+
+	variable, err := client.Variables.UpdateVariable(context.Background(), "ws", "repo", "{env-1}", "{v1}", &PipelineVariable{
+		Key:   "REGION",
+		Value: "us-east-1",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "us-east-1", variable.Value)
+
+	require.NoError(t, client.Variables.DeleteVariable(context.Background(), "ws", "repo", "{env-1}", "{v1}"))
+	assert.Equal(t, []string{http.MethodPut, http.MethodDelete}, methods)
+}
@@ -0,0 +1,35 @@
+package alias
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/carlosarraes/bt/pkg/config"
+)
+
+// DeleteCmd handles the alias delete command
+type DeleteCmd struct {
+	Name string
+}
+
+// Run executes the alias delete command
+func (cmd *DeleteCmd) Run(ctx context.Context) error {
+	loader := config.NewLoader().WithoutRepoConfig()
+	cfg, err := loader.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	expansion, ok := cfg.Aliases[cmd.Name]
+	if !ok {
+		return fmt.Errorf("no alias named %s", cmd.Name)
+	}
+	delete(cfg.Aliases, cmd.Name)
+
+	if err := loader.Save(cfg); err != nil {
+		return fmt.Errorf("failed to save configuration: %w", err)
+	}
+
+	fmt.Printf("✓ Deleted alias %s (was %q)\n", cmd.Name, expansion)
+	return nil
+}
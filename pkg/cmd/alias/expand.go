@@ -0,0 +1,213 @@
+package alias
+
+import (
+	"fmt"
+	"io"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/alecthomas/kong"
+)
+
+// maxExpansionDepth bounds how many aliases may expand into one another
+const maxExpansionDepth = 10
+
+// placeholderRe matches the $1 to $9 placeholders of an expansion
+var placeholderRe = regexp.MustCompile(`\$([1-9])`)
+
+// nameRe is what an alias name may look like. Dots are left out because
+// config keys are dotted.
+var nameRe = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9_-]*$`)
+
+// CommandNames lists the names and aliases of the top-level commands of
+// app, which aliases can't shadow
+func CommandNames(app *kong.Node) []string {
+	var names []string
+	for _, node := range app.Children {
+		names = append(names, node.Name)
+		names = append(names, node.Aliases...)
+	}
+	return names
+}
+
+// Expansion is what a command line expands to: the arguments bt parses,
+// or a shell command to run instead when the alias starts with "!"
+type Expansion struct {
+	Args []string
+	// Shell is the command of a shell alias, Name the alias and ShellArgs
+	// the arguments given to it
+	Shell     string
+	Name      string
+	ShellArgs []string
+}
+
+// Expand replaces an alias in args with the command line it stands for.
+// Placeholders $1 to $9 take the arguments after the alias and $@ all of
+// them; the arguments no placeholder took are appended. An expansion
+// starting with another alias is expanded in turn, and one starting with !
+// is a shell command, which gets the remaining arguments as they are.
+// Leading global flags are kept in front. Aliases named after a built-in
+// command never expand, with a warning written to stderr.
+func Expand(args []string, aliases map[string]string, builtins []string, stderr io.Writer) (Expansion, error) {
+	if len(aliases) == 0 {
+		return Expansion{Args: args}, nil
+	}
+
+	start := commandIndex(args)
+	if start >= len(args) {
+		return Expansion{Args: args}, nil
+	}
+
+	var chain []string
+	rest := args[start+1:]
+	name := args[start]
+	for {
+		expansion, ok := aliases[name]
+		if !ok {
+			break
+		}
+		if slices.Contains(builtins, name) {
+			fmt.Fprintf(stderr, "Warning: alias %q is ignored because it shadows the built-in %s command\n", name, name)
+			break
+		}
+		if slices.Contains(chain, name) {
+			return Expansion{}, fmt.Errorf("alias loop: %s -> %s", strings.Join(chain, " -> "), name)
+		}
+		if len(chain) == maxExpansionDepth {
+			return Expansion{}, fmt.Errorf("alias %s expands through more than %d aliases", chain[0], maxExpansionDepth)
+		}
+		chain = append(chain, name)
+
+		if script, ok := strings.CutPrefix(expansion, "!"); ok {
+			return Expansion{Shell: script, Name: name, ShellArgs: rest}, nil
+		}
+
+		expanded, err := substitute(name, expansion, rest)
+		if err != nil {
+			return Expansion{}, err
+		}
+		if len(expanded) == 0 {
+			return Expansion{}, fmt.Errorf("alias %s expands to nothing", name)
+		}
+		name, rest = expanded[0], expanded[1:]
+	}
+
+	if len(chain) == 0 {
+		return Expansion{Args: args}, nil
+	}
+
+	result := append([]string{}, args[:start]...)
+	result = append(result, name)
+	return Expansion{Args: append(result, rest...)}, nil
+}
+
+// commandIndex returns the index of the first argument that isn't a global
+// flag or the value of one
+func commandIndex(args []string) int {
+	for i := 0; i < len(args); i++ {
+		switch arg := args[i]; {
+		case arg == "--":
+			return i + 1
+		case arg == "--config-file" || arg == "--config":
+			i++
+		case !strings.HasPrefix(arg, "-"):
+			return i
+		}
+	}
+	return len(args)
+}
+
+// substitute splits an expansion into arguments and fills in its
+// placeholders from args
+func substitute(name, expansion string, args []string) ([]string, error) {
+	words, err := SplitWords(expansion)
+	if err != nil {
+		return nil, fmt.Errorf("alias %s: %w", name, err)
+	}
+
+	used := 0
+	allUsed := false
+	var result []string
+	for _, word := range words {
+		if word == "$@" {
+			result = append(result, args...)
+			allUsed = true
+			continue
+		}
+
+		var missing int
+		word = placeholderRe.ReplaceAllStringFunc(word, func(placeholder string) string {
+			n, _ := strconv.Atoi(placeholder[1:])
+			used = max(used, n)
+			if n > len(args) {
+				missing = max(missing, n)
+				return placeholder
+			}
+			return args[n-1]
+		})
+		if missing > 0 {
+			return nil, fmt.Errorf("alias %s needs %d argument(s), got %d", name, missing, len(args))
+		}
+		result = append(result, word)
+	}
+
+	if !allUsed && used < len(args) {
+		result = append(result, args[used:]...)
+	}
+	return result, nil
+}
+
+// SplitWords splits an alias expansion into arguments the way a shell
+// would: on whitespace, keeping single- and double-quoted text together and
+// honoring backslash escapes outside single quotes
+func SplitWords(s string) ([]string, error) {
+	var words []string
+	var word strings.Builder
+	inWord := false
+	var quote rune
+
+	runes := []rune(s)
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+		switch {
+		case quote == '\'':
+			if r == '\'' {
+				quote = 0
+			} else {
+				word.WriteRune(r)
+			}
+		case r == '\\' && i+1 < len(runes) && (quote == 0 || strings.ContainsRune(`"\$`, runes[i+1])):
+			i++
+			word.WriteRune(runes[i])
+			inWord = true
+		case quote == '"':
+			if r == '"' {
+				quote = 0
+			} else {
+				word.WriteRune(r)
+			}
+		case r == '\'' || r == '"':
+			quote = r
+			inWord = true
+		case r == ' ' || r == '\t' || r == '\n':
+			if inWord {
+				words = append(words, word.String())
+				word.Reset()
+				inWord = false
+			}
+		default:
+			word.WriteRune(r)
+			inWord = true
+		}
+	}
+
+	if quote != 0 {
+		return nil, fmt.Errorf("unterminated %c quote in %q", quote, s)
+	}
+	if inWord {
+		words = append(words, word.String())
+	}
+	return words, nil
+}
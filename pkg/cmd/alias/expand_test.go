@@ -0,0 +1,103 @@
+package alias
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testBuiltins = []string{"run", "pr", "repo", "config", "alias"}
+
+func TestExpand(t *testing.T) {
+	aliases := map[string]string{
+		"failed": "run list --status failed",
+		"co":     "pr checkout $1",
+		"mine":   `pr list --author $1 --state "$2"`,
+		"all":    "pr view $@ --comments",
+		"ff":     "failed --branch main",
+		"titled": `pr create --title "WIP: $1"`,
+	}
+
+	tests := []struct {
+		name string
+		args []string
+		want []string
+	}{
+		{"not an alias", []string{"run", "list"}, []string{"run", "list"}},
+		{"plain", []string{"failed"}, []string{"run", "list", "--status", "failed"}},
+		{"extra args appended", []string{"failed", "--limit", "5"}, []string{"run", "list", "--status", "failed", "--limit", "5"}},
+		{"positional", []string{"co", "42"}, []string{"pr", "checkout", "42"}},
+		{"positional with extras", []string{"mine", "@me", "merged", "-o", "json"}, []string{"pr", "list", "--author", "@me", "--state", "merged", "-o", "json"}},
+		{"all args", []string{"all", "1", "2"}, []string{"pr", "view", "1", "2", "--comments"}},
+		{"placeholder inside a word", []string{"titled", "login fix"}, []string{"pr", "create", "--title", "WIP: login fix"}},
+		{"alias of an alias", []string{"ff", "-o", "json"}, []string{"run", "list", "--status", "failed", "--branch", "main", "-o", "json"}},
+		{"global flags kept in front", []string{"--no-color", "--config-file", "x.yml", "failed"}, []string{"--no-color", "--config-file", "x.yml", "run", "list", "--status", "failed"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Expand(tt.args, aliases, testBuiltins, &bytes.Buffer{})
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got.Args)
+		})
+	}
+}
+
+func TestExpand_Errors(t *testing.T) {
+	aliases := map[string]string{
+		"co":   "pr checkout $1",
+		"a":    "b --x",
+		"b":    "a --y",
+		"bad":  `pr list --title "unterminated`,
+		"self": "self",
+	}
+
+	tests := []struct {
+		name    string
+		args    []string
+		wantErr string
+	}{
+		{"missing argument", []string{"co"}, "alias co needs 1 argument(s), got 0"},
+		{"loop", []string{"a"}, "alias loop: a -> b -> a"},
+		{"self loop", []string{"self"}, "alias loop: self -> self"},
+		{"bad quoting", []string{"bad"}, "unterminated"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Expand(tt.args, aliases, testBuiltins, &bytes.Buffer{})
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestExpand_BuiltinShadowed(t *testing.T) {
+	var stderr bytes.Buffer
+	got, err := Expand([]string{"run", "list"}, map[string]string{"run": "pr list"}, testBuiltins, &stderr)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"run", "list"}, got.Args)
+	assert.Contains(t, stderr.String(), `alias "run" is ignored because it shadows the built-in run command`)
+}
+
+func TestSplitWords(t *testing.T) {
+	tests := []struct {
+		in   string
+		want []string
+	}{
+		{"run list  --status failed", []string{"run", "list", "--status", "failed"}},
+		{`pr create --title "Fix the thing"`, []string{"pr", "create", "--title", "Fix the thing"}},
+		{`pr comment --body 'it''s "quoted"'`, []string{"pr", "comment", "--body", `its "quoted"`}},
+		{`a\ b "c\"d" ''`, []string{"a b", `c"d`, ""}},
+		{"", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := SplitWords(tt.in)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
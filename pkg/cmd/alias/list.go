@@ -0,0 +1,58 @@
+package alias
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/carlosarraes/bt/pkg/config"
+	"github.com/carlosarraes/bt/pkg/output"
+)
+
+// ListCmd handles the alias list command
+type ListCmd struct {
+	Output  string
+	NoColor bool
+}
+
+// Alias is one alias as listed by alias list
+type Alias struct {
+	Name      string `json:"name" yaml:"name"`
+	Expansion string `json:"expansion" yaml:"expansion"`
+}
+
+// Run executes the alias list command. Aliases from the repository's
+// .bt.yml are listed along with the user's own.
+func (cmd *ListCmd) Run(ctx context.Context) error {
+	cfg, err := config.NewLoader().Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	aliases := make([]Alias, 0, len(cfg.Aliases))
+	for name, expansion := range cfg.Aliases {
+		aliases = append(aliases, Alias{Name: name, Expansion: expansion})
+	}
+	sort.Slice(aliases, func(i, j int) bool { return aliases[i].Name < aliases[j].Name })
+
+	switch cmd.Output {
+	case "table":
+		if len(aliases) == 0 {
+			fmt.Println("No aliases configured. Add one with: bt alias set <name> '<command>'")
+			return nil
+		}
+		rows := make([][]string, len(aliases))
+		for i, alias := range aliases {
+			rows[i] = []string{alias.Name, alias.Expansion}
+		}
+		return output.RenderSimpleTable([]string{"Alias", "Expansion"}, rows)
+	case "json", "yaml":
+		formatter, err := output.NewFormatter(output.Format(cmd.Output), &output.FormatterOptions{NoColor: cmd.NoColor})
+		if err != nil {
+			return err
+		}
+		return formatter.Format(aliases)
+	default:
+		return fmt.Errorf("unsupported output format: %s", cmd.Output)
+	}
+}
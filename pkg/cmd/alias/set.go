@@ -0,0 +1,68 @@
+package alias
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/carlosarraes/bt/pkg/config"
+)
+
+// SetCmd handles the alias set command
+type SetCmd struct {
+	Name      string
+	Expansion string
+	// Builtins are the top-level command names an alias may not take
+	Builtins []string
+}
+
+// Run executes the alias set command
+func (cmd *SetCmd) Run(ctx context.Context) error {
+	if !nameRe.MatchString(cmd.Name) {
+		return fmt.Errorf("invalid alias name %q: use letters, digits, - and _", cmd.Name)
+	}
+	if slices.Contains(cmd.Builtins, cmd.Name) {
+		return fmt.Errorf("%q is a built-in command; an alias of that name would never run", cmd.Name)
+	}
+
+	loader := config.NewLoader().WithoutRepoConfig()
+	cfg, err := loader.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	if script, isShell := strings.CutPrefix(cmd.Expansion, "!"); isShell {
+		if strings.TrimSpace(script) == "" {
+			return fmt.Errorf("the shell command of %s is empty", cmd.Name)
+		}
+	} else {
+		words, err := SplitWords(cmd.Expansion)
+		if err != nil {
+			return err
+		}
+		if len(words) == 0 {
+			return fmt.Errorf("the expansion of %s is empty", cmd.Name)
+		}
+		if _, isAlias := cfg.Aliases[words[0]]; !isAlias && !slices.Contains(cmd.Builtins, words[0]) {
+			return fmt.Errorf("the expansion must start with a bt command or another alias, not %q", words[0])
+		}
+	}
+
+	previous, existed := cfg.Aliases[cmd.Name]
+	if cfg.Aliases == nil {
+		cfg.Aliases = make(map[string]string)
+	}
+	cfg.Aliases[cmd.Name] = cmd.Expansion
+
+	if err := loader.Save(cfg); err != nil {
+		return fmt.Errorf("failed to save configuration: %w", err)
+	}
+
+	if existed {
+		fmt.Printf("✓ Changed alias %s from %q to %q\n", cmd.Name, previous, cmd.Expansion)
+	} else {
+		fmt.Printf("✓ Added alias %s for %q\n", cmd.Name, cmd.Expansion)
+	}
+	return nil
+}
@@ -0,0 +1,64 @@
+package alias
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/carlosarraes/bt/pkg/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetAndDeleteCmd(t *testing.T) {
+	t.Setenv("BT_CONFIG_PATH", filepath.Join(t.TempDir(), "config.yml"))
+	ctx := context.Background()
+
+	set := &SetCmd{Name: "failed", Expansion: "run list --status failed", Builtins: testBuiltins}
+	require.NoError(t, set.Run(ctx))
+	// Aliases may build on each other
+	require.NoError(t, (&SetCmd{Name: "ff", Expansion: "failed --branch main", Builtins: testBuiltins}).Run(ctx))
+	// Shell aliases aren't checked against the commands
+	require.NoError(t, (&SetCmd{Name: "cleanup", Expansion: "!git branch --merged | xargs git branch -d", Builtins: testBuiltins}).Run(ctx))
+
+	cfg, err := config.NewLoader().WithoutRepoConfig().Load()
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		"failed":  "run list --status failed",
+		"ff":      "failed --branch main",
+		"cleanup": "!git branch --merged | xargs git branch -d",
+	}, cfg.Aliases)
+
+	require.NoError(t, (&DeleteCmd{Name: "failed"}).Run(ctx))
+	require.NoError(t, (&DeleteCmd{Name: "cleanup"}).Run(ctx))
+	cfg, err = config.NewLoader().WithoutRepoConfig().Load()
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"ff": "failed --branch main"}, cfg.Aliases)
+
+	assert.ErrorContains(t, (&DeleteCmd{Name: "failed"}).Run(ctx), "no alias named failed")
+}
+
+func TestSetCmd_Rejects(t *testing.T) {
+	t.Setenv("BT_CONFIG_PATH", filepath.Join(t.TempDir(), "config.yml"))
+
+	tests := []struct {
+		name      string
+		alias     string
+		expansion string
+		wantErr   string
+	}{
+		{"built-in name", "pr", "run list", `"pr" is a built-in command`},
+		{"invalid name", "a.b", "run list", "invalid alias name"},
+		{"empty expansion", "x", "  ", "the expansion of x is empty"},
+		{"unknown command", "x", "deploy now", `must start with a bt command or another alias, not "deploy"`},
+		{"bad quoting", "x", `pr list --title "open`, "unterminated"},
+		{"empty shell command", "x", "!  ", "the shell command of x is empty"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := &SetCmd{Name: tt.alias, Expansion: tt.expansion, Builtins: testBuiltins}
+			assert.ErrorContains(t, cmd.Run(context.Background()), tt.wantErr)
+		})
+	}
+}
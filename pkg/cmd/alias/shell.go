@@ -0,0 +1,55 @@
+package alias
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"slices"
+)
+
+// posixShells are the shells a shell alias runs in when $SHELL names one;
+// they all take the alias arguments as "$@"
+var posixShells = []string{"sh", "bash", "zsh", "ksh", "dash"}
+
+// shellArgsRe matches a script that refers to its arguments itself
+var shellArgsRe = regexp.MustCompile(`\$(\{)?[1-9@*#]`)
+
+// RunShell runs a shell alias in the user's shell and returns its exit
+// code. The arguments are handed to the shell as positional parameters
+// rather than pasted into the script, so they reach it quoted exactly as
+// given; a script that doesn't use $1, $@ and the like gets them appended
+// as "$@".
+func RunShell(ctx context.Context, expansion Expansion, stdin io.Reader, stdout, stderr io.Writer) (int, error) {
+	script := expansion.Shell
+	if len(expansion.ShellArgs) > 0 && !shellArgsRe.MatchString(script) {
+		script += ` "$@"`
+	}
+
+	// With -c, the argument after the script becomes $0
+	args := append([]string{"-c", script, expansion.Name}, expansion.ShellArgs...)
+	cmd := exec.CommandContext(ctx, shellPath(), args...)
+	cmd.Stdin, cmd.Stdout, cmd.Stderr = stdin, stdout, stderr
+
+	err := cmd.Run()
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		return exitErr.ExitCode(), nil
+	}
+	if err != nil {
+		return 1, fmt.Errorf("failed to run alias %s: %w", expansion.Name, err)
+	}
+	return 0, nil
+}
+
+// shellPath returns $SHELL when it is a POSIX shell, and sh otherwise
+func shellPath() string {
+	if shell := os.Getenv("SHELL"); shell != "" && slices.Contains(posixShells, filepath.Base(shell)) {
+		return shell
+	}
+	return "sh"
+}
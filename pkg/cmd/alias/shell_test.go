@@ -0,0 +1,54 @@
+package alias
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunShell(t *testing.T) {
+	t.Setenv("SHELL", "/bin/sh")
+
+	tests := []struct {
+		name     string
+		script   string
+		args     []string
+		want     string
+		wantCode int
+	}{
+		{"arguments appended quoted", "printf '[%s]'", []string{"a b", "$(touch pwned)", "*"}, "[a b][$(touch pwned)][*]", 0},
+		{"positional parameters", `printf '%s-%s' "$2" "$1"`, []string{"one", "two"}, "two-one", 0},
+		{"all arguments", `for a in "$@"; do printf '<%s>' "$a"; done; printf '%s' "$#"`, []string{"x", "y z"}, "<x><y z>2", 0},
+		{"alias name as $0", `printf '%s' "$0"`, nil, "cleanup", 0},
+		{"pipeline", "printf 'b\\na\\n' | sort | tr -d '\\n'", nil, "ab", 0},
+		{"exit code", "exit 3", nil, "", 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var stdout bytes.Buffer
+			code, err := RunShell(context.Background(), Expansion{Shell: tt.script, Name: "cleanup", ShellArgs: tt.args}, nil, &stdout, &bytes.Buffer{})
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantCode, code)
+			assert.Equal(t, tt.want, stdout.String())
+		})
+	}
+}
+
+func TestExpand_ShellAlias(t *testing.T) {
+	aliases := map[string]string{
+		"cleanup": "!git branch --merged | xargs git branch -d",
+		"c":       "cleanup --dry",
+	}
+
+	got, err := Expand([]string{"c", "x y"}, aliases, testBuiltins, &bytes.Buffer{})
+	require.NoError(t, err)
+	assert.Equal(t, Expansion{
+		Shell:     "git branch --merged | xargs git branch -d",
+		Name:      "cleanup",
+		ShellArgs: []string{"--dry", "x y"},
+	}, got)
+}
@@ -0,0 +1,83 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/carlosarraes/bt/pkg/api"
+	"github.com/carlosarraes/bt/pkg/cmd/shared"
+)
+
+// RateLimitCmd handles the api rate-limit command. Bitbucket counts requests
+// per credential, so the quota belongs to whoever bt is authenticated as.
+type RateLimitCmd struct {
+	Output  string
+	NoColor bool
+}
+
+// rateLimitStatus is the structured output of api rate-limit
+type rateLimitStatus struct {
+	Reported  bool           `json:"reported" yaml:"reported"`
+	RateLimit *api.RateLimit `json:"rate_limit,omitempty" yaml:"rate_limit,omitempty"`
+	Low       bool           `json:"low" yaml:"low"`
+}
+
+// Run executes the api rate-limit command
+func (cmd *RateLimitCmd) Run(ctx context.Context) error {
+	cmdCtx, err := shared.NewMinimalContext(ctx, shared.MinimalContextOptions{
+		OutputFormat: cmd.Output,
+		NoColor:      cmd.NoColor,
+	})
+	if err != nil {
+		return err
+	}
+
+	limit, err := cmdCtx.Client.ProbeRateLimit(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to probe the rate limit: %w", err)
+	}
+
+	status := rateLimitStatus{Reported: limit != nil, RateLimit: limit}
+	if limit != nil {
+		status.Low = limit.Low()
+	}
+
+	if cmd.Output != "table" {
+		return cmdCtx.Formatter.Format(status)
+	}
+
+	printRateLimit(status, time.Now())
+	return nil
+}
+
+func printRateLimit(status rateLimitStatus, now time.Time) {
+	if !status.Reported {
+		fmt.Println("Bitbucket sent no rate limit headers for this request; no quota information is available")
+		return
+	}
+
+	limit := status.RateLimit
+	fmt.Println("Bitbucket API rate limit")
+	if limit.Resource != "" {
+		fmt.Printf("  Resource:   %s\n", limit.Resource)
+	}
+	if limit.Limit > 0 {
+		fmt.Printf("  Limit:      %d requests\n", limit.Limit)
+	}
+	if limit.Remaining != nil {
+		fmt.Printf("  Remaining:  %d\n", *limit.Remaining)
+	}
+	if limit.Reset != nil {
+		fmt.Printf("  Resets:     %s (in %s)\n", limit.Reset.Local().Format("15:04:05"), limit.Reset.Sub(now).Round(time.Second))
+	}
+
+	switch {
+	case limit.Throttled:
+		fmt.Println("⛔ Requests are being throttled; wait for the quota to reset")
+	case status.Low:
+		fmt.Println("⚠️  Quota nearly used up; commands that fan out requests may be throttled")
+	default:
+		fmt.Println("✅ Quota available")
+	}
+}
@@ -0,0 +1,87 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/carlosarraes/bt/pkg/api"
+	"github.com/carlosarraes/bt/pkg/cmd/shared"
+)
+
+// CreateTokenCmd handles the auth create-token command
+type CreateTokenCmd struct {
+	Repo    string
+	Scopes  []string
+	Name    string
+	Output  string
+	NoColor bool
+}
+
+// Run executes the auth create-token command
+func (cmd *CreateTokenCmd) Run(ctx context.Context) error {
+	workspace, repo, err := parseRepoFlag(cmd.Repo)
+	if err != nil {
+		return err
+	}
+
+	scopes := splitScopes(cmd.Scopes)
+	if err := api.ValidateTokenScopes(scopes); err != nil {
+		return err
+	}
+
+	name := cmd.Name
+	if name == "" {
+		name = "bt"
+	}
+
+	cmdCtx, err := shared.NewMinimalContext(ctx, shared.MinimalContextOptions{
+		OutputFormat: cmd.Output,
+		Workspace:    workspace,
+		Repository:   repo,
+		NoColor:      cmd.NoColor,
+	})
+	if err != nil {
+		return err
+	}
+
+	token, err := cmdCtx.Client.AccessTokens.CreateRepositoryAccessToken(ctx, workspace, repo, &api.CreateAccessTokenRequest{
+		Name:   name,
+		Scopes: scopes,
+	})
+	if err != nil {
+		return shared.HandleAPIError(err, shared.DomainRepository)
+	}
+
+	if cmd.Output != "table" {
+		return cmdCtx.Formatter.Format(token)
+	}
+
+	fmt.Printf("✅ Created access token %q for %s/%s\n", token.Name, workspace, repo)
+	fmt.Printf("🔐 Scopes: %s\n", strings.Join(token.Scopes, ", "))
+	fmt.Printf("🔑 Token: %s\n", token.Token)
+	fmt.Println("⚠️  Copy the token now; Bitbucket will not show it again")
+	return nil
+}
+
+// parseRepoFlag splits a --repo value of the form workspace/repo
+func parseRepoFlag(value string) (string, string, error) {
+	parts := strings.Split(value, "/")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", "", fmt.Errorf("--repo must be in the form workspace/repo, got %q", value)
+	}
+	return parts[0], parts[1], nil
+}
+
+// splitScopes accepts both repeated --scopes flags and comma-separated lists
+func splitScopes(values []string) []string {
+	var scopes []string
+	for _, value := range values {
+		for _, scope := range strings.Split(value, ",") {
+			if scope = strings.TrimSpace(scope); scope != "" {
+				scopes = append(scopes, scope)
+			}
+		}
+	}
+	return scopes
+}
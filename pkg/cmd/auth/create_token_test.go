@@ -0,0 +1,32 @@
+package auth
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseRepoFlag(t *testing.T) {
+	workspace, repo, err := parseRepoFlag("ws/repo")
+	require.NoError(t, err)
+	assert.Equal(t, "ws", workspace)
+	assert.Equal(t, "repo", repo)
+
+	for _, value := range []string{"", "repo", "ws/", "/repo", "ws/repo/extra"} {
+		_, _, err := parseRepoFlag(value)
+		assert.Error(t, err, value)
+	}
+}
+
+func TestSplitScopes(t *testing.T) {
+	scopes := splitScopes([]string{"repository, pipeline:write", "pullrequest", ""})
+	assert.Equal(t, []string{"repository", "pipeline:write", "pullrequest"}, scopes)
+}
+
+func TestCreateTokenCmd_RejectsUnknownScope(t *testing.T) {
+	cmd := &CreateTokenCmd{Repo: "ws/repo", Scopes: []string{"repository,account:write"}, Output: "table"}
+	err := cmd.Run(t.Context())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "account:write")
+}
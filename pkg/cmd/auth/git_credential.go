@@ -0,0 +1,127 @@
+package auth
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/carlosarraes/bt/pkg/auth"
+)
+
+// gitCredentialHost is the only host bt answers credential requests for
+const gitCredentialHost = "bitbucket.org"
+
+// gitTokenUsername is the fixed username Bitbucket expects when an API token
+// is used as the password for git over HTTPS
+const gitTokenUsername = "x-bitbucket-api-token-auth"
+
+// gitCredentialUsername returns the username git should send with the
+// password from auth.GetCredentials. API tokens use gitTokenUsername; an app
+// password from BITBUCKET_PASSWORD goes with the account's own username.
+func gitCredentialUsername(username string) string {
+	if os.Getenv("BITBUCKET_API_TOKEN") != "" {
+		return gitTokenUsername
+	}
+	if name := os.Getenv("BITBUCKET_USERNAME"); name != "" {
+		return name
+	}
+	return username
+}
+
+// GitCredentialCmd implements the git credential helper protocol so git can
+// reuse bt's API token for HTTPS remotes
+type GitCredentialCmd struct {
+	Operation string
+}
+
+// Run executes the auth git-credential command. Only "get" answers; git's
+// "store" and "erase" are accepted and ignored since bt owns the token.
+func (cmd *GitCredentialCmd) Run() error {
+	switch cmd.Operation {
+	case "get":
+		return writeGitCredential(os.Stdin, os.Stdout)
+	case "store", "erase":
+		_, err := io.Copy(io.Discard, os.Stdin)
+		return err
+	default:
+		return fmt.Errorf("unsupported git credential operation %q: expected get, store or erase", cmd.Operation)
+	}
+}
+
+// writeGitCredential reads a credential request and answers it with the API
+// token or app password when it targets Bitbucket over HTTPS. Anything else gets no answer,
+// letting git fall back to its other helpers.
+func writeGitCredential(r io.Reader, w io.Writer) error {
+	request, err := readGitCredentialRequest(r)
+	if err != nil {
+		return err
+	}
+
+	if request["protocol"] != "https" || request["host"] != gitCredentialHost {
+		return nil
+	}
+
+	username, token := auth.GetCredentials()
+	if token == "" {
+		return nil
+	}
+
+	_, err = fmt.Fprintf(w, "protocol=https\nhost=%s\nusername=%s\npassword=%s\n", gitCredentialHost, gitCredentialUsername(username), token)
+	return err
+}
+
+// readGitCredentialRequest parses key=value lines up to the first blank line
+func readGitCredentialRequest(r io.Reader) (map[string]string, error) {
+	request := make(map[string]string)
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if line == "" {
+			break
+		}
+		if key, value, ok := strings.Cut(line, "="); ok {
+			request[key] = value
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read git credential request: %w", err)
+	}
+	return request, nil
+}
+
+// setupGitCredentialHelper registers bt as the global credential helper for
+// https://bitbucket.org. The empty entry first clears helpers inherited from
+// other config files so git doesn't prompt before asking bt.
+func setupGitCredentialHelper() error {
+	if _, err := exec.LookPath("git"); err != nil {
+		return fmt.Errorf("git is not installed or not in PATH")
+	}
+
+	executable, err := os.Executable()
+	if err != nil {
+		return fmt.Errorf("failed to locate the bt executable: %w", err)
+	}
+
+	key := fmt.Sprintf("credential.https://%s.helper", gitCredentialHost)
+	for _, args := range gitCredentialConfigArgs(key, executable) {
+		if output, err := exec.Command("git", args...).CombinedOutput(); err != nil {
+			return fmt.Errorf("git %s failed: %s", strings.Join(args, " "), strings.TrimSpace(string(output)))
+		}
+	}
+	return nil
+}
+
+func gitCredentialConfigArgs(key, executable string) [][]string {
+	return [][]string{
+		{"config", "--global", "--replace-all", key, ""},
+		{"config", "--global", "--add", key, fmt.Sprintf("!%s auth git-credential", shellQuote(executable))},
+	}
+}
+
+// shellQuote single-quotes s for the shell git runs "!" helpers with
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
@@ -0,0 +1,79 @@
+package auth
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteGitCredential(t *testing.T) {
+	t.Setenv("BITBUCKET_EMAIL", "dev@example.com")
+	t.Setenv("BITBUCKET_API_TOKEN", "secret-token")
+
+	tests := []struct {
+		name    string
+		request string
+		want    string
+	}{
+		{
+			name:    "bitbucket over https",
+			request: "protocol=https\nhost=bitbucket.org\npath=acme/api.git\n\n",
+			want:    "protocol=https\nhost=bitbucket.org\nusername=x-bitbucket-api-token-auth\npassword=secret-token\n",
+		},
+		{
+			name:    "other host is left to other helpers",
+			request: "protocol=https\nhost=github.com\n\n",
+		},
+		{
+			name:    "plain http is refused",
+			request: "protocol=http\nhost=bitbucket.org\n\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out bytes.Buffer
+			require.NoError(t, writeGitCredential(strings.NewReader(tt.request), &out))
+			assert.Equal(t, tt.want, out.String())
+		})
+	}
+}
+
+func TestWriteGitCredential_AppPassword(t *testing.T) {
+	t.Setenv("BITBUCKET_EMAIL", "")
+	t.Setenv("BITBUCKET_API_TOKEN", "")
+	t.Setenv("BITBUCKET_USERNAME", "jdoe")
+	t.Setenv("BITBUCKET_PASSWORD", "app-password")
+
+	var out bytes.Buffer
+	require.NoError(t, writeGitCredential(strings.NewReader("protocol=https\nhost=bitbucket.org\n\n"), &out))
+	assert.Equal(t, "protocol=https\nhost=bitbucket.org\nusername=jdoe\npassword=app-password\n", out.String())
+}
+
+func TestWriteGitCredential_NoToken(t *testing.T) {
+	t.Setenv("BITBUCKET_API_TOKEN", "")
+	t.Setenv("BITBUCKET_PASSWORD", "")
+
+	var out bytes.Buffer
+	require.NoError(t, writeGitCredential(strings.NewReader("protocol=https\nhost=bitbucket.org\n"), &out))
+	assert.Empty(t, out.String())
+}
+
+func TestGitCredentialCmd_UnsupportedOperation(t *testing.T) {
+	err := (&GitCredentialCmd{Operation: "approve"}).Run()
+	assert.Error(t, err)
+}
+
+func TestGitCredentialConfigArgs(t *testing.T) {
+	args := gitCredentialConfigArgs("credential.https://bitbucket.org.helper", "/usr/local/bin/bt")
+	assert.Equal(t, [][]string{
+		{"config", "--global", "--replace-all", "credential.https://bitbucket.org.helper", ""},
+		{"config", "--global", "--add", "credential.https://bitbucket.org.helper", "!'/usr/local/bin/bt' auth git-credential"},
+	}, args)
+
+	args = gitCredentialConfigArgs("credential.https://bitbucket.org.helper", "/home/o'brien/bin/bt")
+	assert.Equal(t, `!'/home/o'\''brien/bin/bt' auth git-credential`, args[1][4])
+}
@@ -16,11 +16,26 @@ import (
 
 // LoginCmd handles auth login command
 type LoginCmd struct {
-	WithToken string `help:"Authenticate with a token (format: email:token)"`
+	WithToken   string `help:"Authenticate with a token (format: email:token)"`
+	GitProtocol string `help:"Protocol git uses with Bitbucket (https installs bt as git credential helper)"`
 }
 
 // Run executes the auth login command
 func (cmd *LoginCmd) Run(ctx context.Context) error {
+	switch cmd.GitProtocol {
+	case "", "https", "ssh":
+	default:
+		return fmt.Errorf("invalid --git-protocol %q: expected https or ssh", cmd.GitProtocol)
+	}
+
+	if err := cmd.login(ctx); err != nil {
+		return err
+	}
+
+	return cmd.setupGit()
+}
+
+func (cmd *LoginCmd) login(ctx context.Context) error {
 	if email := os.Getenv("BITBUCKET_EMAIL"); email != "" {
 		if token := os.Getenv("BITBUCKET_API_TOKEN"); token != "" {
 			return cmd.authenticateAndSave(ctx, email, token, "environment variables")
@@ -73,6 +88,20 @@ func (cmd *LoginCmd) authenticateAndSave(ctx context.Context, email, token, sour
 	return cmd.saveToProfile(email, token)
 }
 
+// setupGit configures git for the chosen --git-protocol after a successful login
+func (cmd *LoginCmd) setupGit() error {
+	switch cmd.GitProtocol {
+	case "https":
+		if err := setupGitCredentialHelper(); err != nil {
+			return fmt.Errorf("failed to configure git credential helper: %w", err)
+		}
+		fmt.Printf("🔧 Configured git to use bt for https://%s credentials\n", gitCredentialHost)
+	case "ssh":
+		fmt.Println("🔧 Using SSH for git; make sure your SSH key is added to Bitbucket")
+	}
+	return nil
+}
+
 func (cmd *LoginCmd) interactiveLogin(ctx context.Context) error {
 	fmt.Println("🚀 Welcome to Bitbucket CLI Authentication")
 	fmt.Println("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
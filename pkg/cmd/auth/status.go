@@ -6,12 +6,15 @@ import (
 	"os"
 
 	"github.com/carlosarraes/bt/pkg/auth"
-	"github.com/carlosarraes/bt/pkg/cmd/shared"
+	"github.com/carlosarraes/bt/pkg/config"
 	"github.com/carlosarraes/bt/pkg/output"
 )
 
 // StatusCmd handles auth status command
-type StatusCmd struct{}
+type StatusCmd struct {
+	Output  string
+	NoColor bool
+}
 
 // Run executes the auth status command
 func (cmd *StatusCmd) Run(ctx context.Context) error {
@@ -20,14 +23,14 @@ func (cmd *StatusCmd) Run(ctx context.Context) error {
 		return err
 	}
 
-	outputFormat := ctx.Value("output-format")
-	if outputFormat == nil {
+	outputFormat := cmd.Output
+	if outputFormat == "" {
 		outputFormat = "table"
 	}
 
-	formatter, err := output.NewFormatter(output.Format(outputFormat.(string)), &output.FormatterOptions{
+	formatter, err := output.NewFormatter(output.Format(outputFormat), &output.FormatterOptions{
 		Writer:  os.Stdout,
-		NoColor: ctx.Value("no-color") == true,
+		NoColor: cmd.NoColor,
 	})
 	if err != nil {
 		return fmt.Errorf("failed to create formatter: %w", err)
@@ -36,21 +39,32 @@ func (cmd *StatusCmd) Run(ctx context.Context) error {
 	return formatter.Format(status)
 }
 
-// AuthStatus represents the authentication status information
+// AuthStatus represents the authentication status information. It never
+// carries the token itself. Bitbucket doesn't report when API tokens expire,
+// so there is no expiry to show.
 type AuthStatus struct {
 	Authenticated bool            `json:"authenticated" yaml:"authenticated"`
 	Method        auth.AuthMethod `json:"method,omitempty" yaml:"method,omitempty"`
 	User          *auth.User      `json:"user,omitempty" yaml:"user,omitempty"`
 	Host          string          `json:"host" yaml:"host"`
+	BaseURL       string          `json:"base_url" yaml:"base_url"`
 	TokenSource   string          `json:"token_source,omitempty" yaml:"token_source,omitempty"`
+	TokenValid    bool            `json:"token_valid" yaml:"token_valid"`
 	Error         string          `json:"error,omitempty" yaml:"error,omitempty"`
 	Scopes        []string        `json:"scopes,omitempty" yaml:"scopes,omitempty"`
 }
 
 func (cmd *StatusCmd) getAuthStatus(ctx context.Context) (*AuthStatus, error) {
+	authConfig := auth.DefaultConfig()
+	// The credentials are checked against the API bt will actually talk to
+	if cfg, err := config.NewLoader().Load(); err == nil && cfg.API.BaseURL != "" {
+		authConfig.BaseURL = cfg.API.BaseURL
+	}
+
 	status := &AuthStatus{
 		Authenticated: false,
 		Host:          "bitbucket.org",
+		BaseURL:       authConfig.BaseURL,
 	}
 
 	tokenSource := cmd.detectAuthMethod()
@@ -62,7 +76,7 @@ func (cmd *StatusCmd) getAuthStatus(ctx context.Context) (*AuthStatus, error) {
 	status.Method = auth.AuthMethodAPIToken
 	status.TokenSource = tokenSource
 
-	manager, err := shared.CreateAuthManagerWithMethod(auth.AuthMethodAPIToken)
+	manager, err := auth.NewAuthManager(authConfig)
 	if err != nil {
 		status.Error = fmt.Sprintf("Failed to create auth manager: %v", err)
 		return status, nil
@@ -75,6 +89,7 @@ func (cmd *StatusCmd) getAuthStatus(ctx context.Context) (*AuthStatus, error) {
 	}
 
 	status.Authenticated = true
+	status.TokenValid = true
 	status.User = user
 	status.Scopes = []string{"repository", "pullrequest", "pipeline", "account"}
 
@@ -100,7 +115,11 @@ func (cmd *StatusCmd) detectAuthMethod() string {
 // String implements fmt.Stringer for table output
 func (s *AuthStatus) String() string {
 	if !s.Authenticated {
-		return fmt.Sprintf("❌ Not authenticated to bitbucket.org\n💡 Run 'bt auth login' to authenticate with your API token")
+		result := "❌ Not authenticated to bitbucket.org\n"
+		if s.TokenSource != "" && s.Error != "" {
+			result += fmt.Sprintf("⚠️  %s\n", s.Error)
+		}
+		return result + "💡 Run 'bt auth login' to authenticate with your API token"
 	}
 
 	result := fmt.Sprintf("✅ Authenticated to %s\n", s.Host)
@@ -110,6 +129,7 @@ func (s *AuthStatus) String() string {
 	}
 	result += fmt.Sprintf("🔐 Method: API Token\n")
 	result += fmt.Sprintf("📍 Source: %s\n", s.TokenSource)
+	result += fmt.Sprintf("🌐 API: %s\n", s.BaseURL)
 
 	if len(s.Scopes) > 0 {
 		result += fmt.Sprintf("🔓 Scopes: %v\n", s.Scopes)
@@ -0,0 +1,56 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/carlosarraes/bt/pkg/api/apitest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStatusCmd_getAuthStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, token, _ := r.BasicAuth(); token != "test-token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Write([]byte(`{"username": "jdoe", "display_name": "J Doe", "account_id": "557058:abc", "uuid": "{u-1}"}`))
+	}))
+	defer server.Close()
+
+	t.Run("valid token", func(t *testing.T) {
+		apitest.CommandEnv(t, "ws", "repo")
+		t.Setenv("BT_API_BASE_URL", server.URL)
+
+		status, err := (&StatusCmd{Output: "json"}).getAuthStatus(context.Background())
+		require.NoError(t, err)
+
+		assert.True(t, status.Authenticated)
+		assert.True(t, status.TokenValid)
+		assert.Equal(t, server.URL, status.BaseURL)
+		assert.Equal(t, "jdoe", status.User.Username)
+		assert.Equal(t, "557058:abc", status.User.AccountID)
+
+		data, err := json.Marshal(status)
+		require.NoError(t, err)
+		assert.NotContains(t, string(data), "test-token")
+	})
+
+	t.Run("rejected token", func(t *testing.T) {
+		apitest.CommandEnv(t, "ws", "repo")
+		t.Setenv("BT_API_BASE_URL", server.URL)
+		t.Setenv("BITBUCKET_API_TOKEN", "revoked")
+
+		status, err := (&StatusCmd{Output: "json"}).getAuthStatus(context.Background())
+		require.NoError(t, err)
+
+		assert.False(t, status.Authenticated)
+		assert.False(t, status.TokenValid)
+		assert.NotEmpty(t, status.TokenSource)
+		assert.Contains(t, status.Error, "Authentication invalid")
+	})
+}
@@ -3,11 +3,17 @@ package cmd
 import (
 	"context"
 	"fmt"
+	"time"
 
+	"github.com/alecthomas/kong"
+	"github.com/carlosarraes/bt/pkg/cmd/alias"
+	"github.com/carlosarraes/bt/pkg/cmd/api"
 	"github.com/carlosarraes/bt/pkg/cmd/auth"
 	"github.com/carlosarraes/bt/pkg/cmd/config"
+	"github.com/carlosarraes/bt/pkg/cmd/issue"
 	"github.com/carlosarraes/bt/pkg/cmd/pick"
 	"github.com/carlosarraes/bt/pkg/cmd/pr"
+	"github.com/carlosarraes/bt/pkg/cmd/repo"
 	"github.com/carlosarraes/bt/pkg/cmd/run"
 	"github.com/carlosarraes/bt/pkg/cmd/shared"
 	"github.com/carlosarraes/bt/pkg/cmd/skill"
@@ -23,19 +29,54 @@ func (v *VersionCmd) Run(ctx context.Context) error {
 }
 
 type AuthCmd struct {
-	Login   AuthLoginCmd   `cmd:""`
-	Logout  AuthLogoutCmd  `cmd:""`
-	Status  AuthStatusCmd  `cmd:""`
-	Refresh AuthRefreshCmd `cmd:""`
+	Login         AuthLoginCmd         `cmd:"" help:"Authenticate with Bitbucket"`
+	Logout        AuthLogoutCmd        `cmd:"" help:"Log out of Bitbucket"`
+	Status        AuthStatusCmd        `cmd:"" help:"View authentication status"`
+	Refresh       AuthRefreshCmd       `cmd:"" help:"Refresh stored authentication credentials"`
+	CreateToken   AuthCreateTokenCmd   `cmd:"create-token" help:"Create a repository access token"`
+	GitCredential AuthGitCredentialCmd `cmd:"git-credential" hidden:"" help:"Git credential helper (invoked by git)"`
 }
 
 type AuthLoginCmd struct {
-	WithToken string `help:"Authenticate with a token instead of interactive flow"`
+	WithToken   string `help:"Authenticate with a token instead of interactive flow"`
+	GitProtocol string `name:"git-protocol" help:"Protocol git uses with Bitbucket (https, ssh); https installs bt as git credential helper"`
 }
 
 func (a *AuthLoginCmd) Run(ctx context.Context) error {
 	cmd := &auth.LoginCmd{
-		WithToken: a.WithToken,
+		WithToken:   a.WithToken,
+		GitProtocol: a.GitProtocol,
+	}
+	return cmd.Run(ctx)
+}
+
+type AuthGitCredentialCmd struct {
+	Operation string `arg:"" help:"Credential operation (get, store, erase)"`
+}
+
+func (a *AuthGitCredentialCmd) Run(ctx context.Context) error {
+	cmd := &auth.GitCredentialCmd{
+		Operation: a.Operation,
+	}
+	return cmd.Run()
+}
+
+type AuthCreateTokenCmd struct {
+	Repo   string   `required:"" help:"Repository the token is scoped to (workspace/repo)"`
+	Scopes []string `required:"" help:"Token scopes, comma-separated or repeated (e.g. repository,pipeline:write)"`
+	Name   string   `help:"Token name shown in Bitbucket" default:"bt"`
+	Output string   `short:"o" help:"Output format (table, json, yaml)" enum:"table,json,yaml" default:"${output_format}"`
+}
+
+func (a *AuthCreateTokenCmd) Run(ctx context.Context) error {
+	noColor := shared.GetNoColor(ctx)
+
+	cmd := &auth.CreateTokenCmd{
+		Repo:    a.Repo,
+		Scopes:  a.Scopes,
+		Name:    a.Name,
+		Output:  a.Output,
+		NoColor: noColor,
 	}
 	return cmd.Run(ctx)
 }
@@ -51,10 +92,17 @@ func (a *AuthLogoutCmd) Run(ctx context.Context) error {
 	return cmd.Run(ctx)
 }
 
-type AuthStatusCmd struct{}
+type AuthStatusCmd struct {
+	Output string `short:"o" help:"Output format (table, json, yaml)" enum:"table,json,yaml" default:"${output_format}"`
+}
 
 func (a *AuthStatusCmd) Run(ctx context.Context) error {
-	cmd := &auth.StatusCmd{}
+	noColor := shared.GetNoColor(ctx)
+
+	cmd := &auth.StatusCmd{
+		Output:  a.Output,
+		NoColor: noColor,
+	}
 	return cmd.Run(ctx)
 }
 
@@ -66,21 +114,30 @@ func (a *AuthRefreshCmd) Run(ctx context.Context) error {
 }
 
 type RunCmd struct {
-	List   RunListCmd   `cmd:""`
-	View   RunViewCmd   `cmd:""`
-	Watch  RunWatchCmd  `cmd:""`
-	Logs   RunLogsCmd   `cmd:""`
-	Cancel RunCancelCmd `cmd:""`
-	Rerun  RunRerunCmd  `cmd:""`
-	Report RunReportCmd `cmd:""`
+	List        RunListCmd        `cmd:"" help:"List pipeline runs"`
+	View        RunViewCmd        `cmd:"" help:"View details about a specific pipeline run"`
+	Watch       RunWatchCmd       `cmd:"" help:"Watch a pipeline run in real-time"`
+	Logs        RunLogsCmd        `cmd:"" help:"View logs for a pipeline run"`
+	Cancel      RunCancelCmd      `cmd:"" help:"Cancel a running pipeline"`
+	Rerun       RunRerunCmd       `cmd:"" help:"Rerun a pipeline (optionally failed steps only)"`
+	Report      RunReportCmd      `cmd:"" help:"SonarCloud coverage/issues report for a pipeline"`
+	Compare     RunCompareCmd     `cmd:"" help:"Compare step statuses, durations and tests of two pipeline runs"`
+	Status      RunStatusCmd      `cmd:"" help:"Show pipeline health across a workspace, or publish build statuses on commits"`
+	Deployments RunDeploymentsCmd `cmd:"" help:"List deployment environments and their latest deployment"`
+	Deploy      RunDeployCmd      `cmd:"" help:"Deploy a pipeline to an environment through its deployment step"`
+	Grep        RunGrepCmd        `cmd:"" help:"Search step logs of recent pipelines for a pattern"`
+	ForCommit   RunForCommitCmd   `cmd:"" name:"for-commit" help:"List pipelines that ran on a commit"`
+	Latest      RunLatestCmd      `cmd:"" help:"Show the latest pipeline of the current or given branch"`
+	Download    RunDownloadCmd    `cmd:"" help:"Download a build artifact from the repository's Downloads, verifying its size"`
 }
 
 type RunListCmd struct {
 	Status     string `help:"Filter by status (PENDING, IN_PROGRESS, SUCCESSFUL, FAILED, ERROR, STOPPED)"`
 	Branch     string `help:"Filter by branch name"`
 	Creator    string `help:"Filter by pipeline creator (display name)"`
+	Commit     string `help:"Only list pipelines that ran on this commit (full or short SHA)"`
 	Limit      int    `help:"Maximum number of runs to show" default:"10"`
-	Output     string `short:"o" help:"Output format (table, json, yaml)" enum:"table,json,yaml" default:"table"`
+	Output     string `short:"o" help:"Output format (table, json, yaml, compact)" enum:"table,json,yaml,compact" default:"${output_format}"`
 	Workspace  string `help:"Bitbucket workspace (defaults to git remote or config)"`
 	Repository string `help:"Repository name (defaults to git remote)"`
 }
@@ -92,6 +149,7 @@ func (r *RunListCmd) Run(ctx context.Context) error {
 		Status:     r.Status,
 		Branch:     r.Branch,
 		Creator:    r.Creator,
+		Commit:     r.Commit,
 		Limit:      r.Limit,
 		Output:     r.Output,
 		NoColor:    noColor,
@@ -102,95 +160,161 @@ func (r *RunListCmd) Run(ctx context.Context) error {
 }
 
 type RunViewCmd struct {
-	PipelineID string `arg:"" help:"Pipeline ID (build number or UUID)"`
-	Output     string `short:"o" help:"Output format (table, json, yaml)" enum:"table,json,yaml" default:"table"`
-	Watch      bool   `short:"w" help:"Watch for live updates (running pipelines only)"`
-	Log        bool   `help:"View full logs for all steps"`
-	LogFailed  bool   `name:"log-failed" help:"View logs only for failed steps (last 100 lines)"`
-	FullOutput bool   `name:"full-output" help:"Show complete logs (use with --log-failed for full failure logs)"`
-	Tests      bool   `short:"t" help:"Show test results and failures"`
-	Step       string `help:"View specific step only"`
-	Web        bool   `help:"Open pipeline in browser"`
-	URL        bool   `help:"Print pipeline URL instead of opening in browser (use with --web)"`
-	Workspace  string `help:"Bitbucket workspace (defaults to git remote or config)"`
-	Repository string `help:"Repository name (defaults to git remote)"`
+	PipelineID       string `arg:"" optional:"" help:"Pipeline ID (build number or UUID); omit to pick from recent pipelines"`
+	Output           string `short:"o" help:"Output format (table, json, yaml)" enum:"table,json,yaml" default:"${output_format}"`
+	Watch            bool   `short:"w" help:"Watch for live updates (running pipelines only)"`
+	Append           bool   `help:"With --watch, print each update below the last instead of redrawing in place"`
+	Log              bool   `help:"View full logs for all steps"`
+	LogFailed        bool   `name:"log-failed" help:"View logs only for failed steps (last 100 lines)"`
+	FullOutput       bool   `name:"full-output" help:"Show complete logs (use with --log-failed for full failure logs)"`
+	IncludeLogs      bool   `name:"include-logs" help:"Embed step log text in json/yaml output (steps carry metadata only without it)"`
+	Tests            bool   `short:"t" help:"Show test results and failures"`
+	TestOutput       string `name:"test-output" help:"With --tests, which test cases to list: failed, or full to include passing tests" enum:"failed,full" default:"failed"`
+	History          bool   `help:"With --tests, flag flaky and consistently failing tests across recent pipelines of the same branch"`
+	HistoryLimit     int    `name:"history-limit" help:"Number of pipelines to inspect with --history" default:"10"`
+	Step             string `help:"View specific step only (part of its name or an alias from run.step_aliases)"`
+	StepTiming       bool   `name:"step-timing" help:"Show step start/end times with a timeline of parallel steps and the critical path"`
+	RetryOnTransient bool   `name:"retry-on-transient" help:"With --watch, keep polling through transient API errors"`
+	MaxPollFailures  int    `name:"max-poll-failures" help:"Consecutive transient failures tolerated by --retry-on-transient" default:"5"`
+	NoRedact         bool   `name:"no-redact" help:"With --log or --log-failed, show secrets in logs instead of masking them"`
+	Web              bool   `help:"Open pipeline in browser"`
+	URL              bool   `help:"Print pipeline URL instead of opening in browser (use with --web)"`
+	PR               bool   `name:"pr" help:"Show the pull request the pipeline ran for; with --web open it in the browser"`
+	Status           string `help:"Without a pipeline ID, only offer pipelines with this status (PENDING, IN_PROGRESS, SUCCESSFUL, FAILED, ERROR, STOPPED)"`
+	Branch           string `help:"Without a pipeline ID, only offer pipelines on this branch"`
+	Workspace        string `help:"Bitbucket workspace (defaults to git remote or config)"`
+	Repository       string `help:"Repository name (defaults to git remote)"`
 }
 
 func (r *RunViewCmd) Run(ctx context.Context) error {
 	noColor := shared.GetNoColor(ctx)
 
 	cmd := &run.ViewCmd{
-		PipelineID: r.PipelineID,
-		Output:     r.Output,
-		NoColor:    noColor,
-		Watch:      r.Watch,
-		Log:        r.Log,
-		LogFailed:  r.LogFailed,
-		FullOutput: r.FullOutput,
-		Tests:      r.Tests,
-		Step:       r.Step,
-		Web:        r.Web,
-		URL:        r.URL,
-		Workspace:  r.Workspace,
-		Repository: r.Repository,
+		PipelineID:       r.PipelineID,
+		Output:           r.Output,
+		NoColor:          noColor,
+		Watch:            r.Watch,
+		Append:           r.Append,
+		Log:              r.Log,
+		LogFailed:        r.LogFailed,
+		FullOutput:       r.FullOutput,
+		IncludeLogs:      r.IncludeLogs,
+		Tests:            r.Tests,
+		TestOutput:       r.TestOutput,
+		History:          r.History,
+		HistoryLimit:     r.HistoryLimit,
+		Step:             r.Step,
+		StepTiming:       r.StepTiming,
+		RetryOnTransient: r.RetryOnTransient,
+		MaxPollFailures:  r.MaxPollFailures,
+		NoRedact:         r.NoRedact,
+		Web:              r.Web,
+		URL:              r.URL,
+		PR:               r.PR,
+		Status:           r.Status,
+		Branch:           r.Branch,
+		Workspace:        r.Workspace,
+		Repository:       r.Repository,
 	}
 	return cmd.Run(ctx)
 }
 
 type RunWatchCmd struct {
-	PipelineID string `arg:"" help:"Pipeline ID (build number or UUID)"`
-	Output     string `short:"o" help:"Output format (table, json)" enum:"table,json" default:"table"`
-	Workspace  string `help:"Bitbucket workspace (defaults to git remote or config)"`
-	Repository string `help:"Repository name (defaults to git remote)"`
+	PipelineID       string `arg:"" help:"Pipeline ID (build number or UUID)"`
+	Output           string `short:"o" help:"Output format (table, json, yaml)" enum:"table,json,yaml" default:"${output_format}"`
+	RetryOnTransient bool   `name:"retry-on-transient" help:"Keep polling through transient API errors"`
+	MaxPollFailures  int    `name:"max-poll-failures" help:"Consecutive transient failures tolerated by --retry-on-transient" default:"5"`
+	Events           string `help:"Print JSON progress events instead of text: jsonl (one event per line, for scripts and agents)"`
+	NoRedact         bool   `name:"no-redact" help:"Show secrets in logs instead of masking them"`
+	Workspace        string `help:"Bitbucket workspace (defaults to git remote or config)"`
+	Repository       string `help:"Repository name (defaults to git remote)"`
 }
 
 func (r *RunWatchCmd) Run(ctx context.Context) error {
 	noColor := shared.GetNoColor(ctx)
 
 	cmd := &run.WatchCmd{
-		PipelineID: r.PipelineID,
-		Output:     r.Output,
-		NoColor:    noColor,
-		Workspace:  r.Workspace,
-		Repository: r.Repository,
+		PipelineID:       r.PipelineID,
+		Output:           r.Output,
+		RetryOnTransient: r.RetryOnTransient,
+		MaxPollFailures:  r.MaxPollFailures,
+		Events:           r.Events,
+		NoRedact:         r.NoRedact,
+		NoColor:          noColor,
+		Workspace:        r.Workspace,
+		Repository:       r.Repository,
 	}
 	return cmd.Run(ctx)
 }
 
 type RunLogsCmd struct {
-	PipelineID string `arg:"" help:"Pipeline ID (build number or UUID)"`
-	Step       string `help:"Show logs for specific step only"`
-	ErrorsOnly bool   `help:"Extract and show errors only"`
-	Follow     bool   `short:"f" help:"Follow live logs for running pipelines"`
-	Output     string `short:"o" help:"Output format (text, json, yaml)" enum:"text,json,yaml" default:"text"`
-	Context    int    `help:"Number of context lines around errors" default:"3"`
-	Workspace  string `help:"Bitbucket workspace (defaults to git remote or config)"`
-	Repository string `help:"Repository name (defaults to git remote)"`
+	PipelineID       string `arg:"" optional:"" help:"Pipeline ID (build number or UUID); omit to pick from recent pipelines"`
+	Step             string `help:"Show logs for specific step only (part of its name or an alias from run.step_aliases)"`
+	OnlyFailed       bool   `name:"only-failed" help:"Show logs for failed steps only"`
+	OnlySuccessful   bool   `name:"only-successful" help:"Show logs for successful steps only"`
+	OnlyRunning      bool   `name:"only-running" help:"Show logs for running steps only"`
+	ErrorsOnly       bool   `help:"Extract and show errors only"`
+	Follow           bool   `short:"f" help:"Follow live logs for running pipelines"`
+	Output           string `short:"o" help:"Output format (text, json, yaml)" enum:"text,json,yaml" default:"${text_output_format}"`
+	Context          int    `help:"Number of context lines around errors" default:"3"`
+	ContextBefore    *int   `name:"context-before" help:"Number of context lines before errors (overrides --context)"`
+	ContextAfter     *int   `name:"context-after" help:"Number of context lines after errors (overrides --context)"`
+	MergeSteps       bool   `name:"merge-steps" help:"Show the raw logs of all steps as one stream, prefixed with the step name and ordered by step start time"`
+	NoRedact         bool   `name:"no-redact" help:"Show secrets in logs instead of masking them"`
+	RetryOnTransient bool   `name:"retry-on-transient" help:"With --follow, retry transient API errors up to --max-poll-failures times in a row and stop on any other error"`
+	MaxPollFailures  int    `name:"max-poll-failures" help:"Consecutive transient failures tolerated by --retry-on-transient" default:"5"`
+	Status           string `help:"Without a pipeline ID, only offer pipelines with this status (PENDING, IN_PROGRESS, SUCCESSFUL, FAILED, ERROR, STOPPED)"`
+	Branch           string `help:"Without a pipeline ID, only offer pipelines on this branch"`
+	Events           string `help:"With --follow, print JSON progress events instead of text: jsonl (one event per line, for scripts and agents)"`
+	Workspace        string `help:"Bitbucket workspace (defaults to git remote or config)"`
+	Repository       string `help:"Repository name (defaults to git remote)"`
 }
 
 func (r *RunLogsCmd) Run(ctx context.Context) error {
 	noColor := shared.GetNoColor(ctx)
 
+	contextBefore, contextAfter := -1, -1
+	if r.ContextBefore != nil {
+		contextBefore = *r.ContextBefore
+	}
+	if r.ContextAfter != nil {
+		contextAfter = *r.ContextAfter
+	}
+
 	cmd := &run.LogsCmd{
-		PipelineID: r.PipelineID,
-		Step:       r.Step,
-		ErrorsOnly: r.ErrorsOnly,
-		Follow:     r.Follow,
-		Output:     r.Output,
-		NoColor:    noColor,
-		Context:    r.Context,
-		Workspace:  r.Workspace,
-		Repository: r.Repository,
+		PipelineID:       r.PipelineID,
+		Step:             r.Step,
+		OnlyFailed:       r.OnlyFailed,
+		OnlySuccessful:   r.OnlySuccessful,
+		OnlyRunning:      r.OnlyRunning,
+		ErrorsOnly:       r.ErrorsOnly,
+		Follow:           r.Follow,
+		Output:           r.Output,
+		NoColor:          noColor,
+		Context:          r.Context,
+		ContextBefore:    contextBefore,
+		ContextAfter:     contextAfter,
+		MergeSteps:       r.MergeSteps,
+		NoRedact:         r.NoRedact,
+		RetryOnTransient: r.RetryOnTransient,
+		MaxPollFailures:  r.MaxPollFailures,
+		Status:           r.Status,
+		Branch:           r.Branch,
+		Events:           r.Events,
+		Workspace:        r.Workspace,
+		Repository:       r.Repository,
 	}
 	return cmd.Run(ctx)
 }
 
 type RunCancelCmd struct {
-	PipelineID string `arg:"" help:"Pipeline ID (build number or UUID)"`
-	Force      bool   `short:"f" help:"Force cancellation without confirmation"`
-	Output     string `short:"o" help:"Output format (table, json, yaml)" enum:"table,json,yaml" default:"table"`
-	Workspace  string `help:"Bitbucket workspace (defaults to git remote or config)"`
-	Repository string `help:"Repository name (defaults to git remote)"`
+	PipelineID string        `arg:"" help:"Pipeline ID (build number or UUID)"`
+	Force      bool          `short:"f" help:"Force cancellation without confirmation"`
+	Wait       bool          `help:"Wait until the pipeline has stopped and report its final state"`
+	Timeout    time.Duration `help:"With --wait, how long to wait for the pipeline to stop" default:"10m"`
+	Output     string        `short:"o" help:"Output format (table, json, yaml)" enum:"table,json,yaml" default:"${output_format}"`
+	Workspace  string        `help:"Bitbucket workspace (defaults to git remote or config)"`
+	Repository string        `help:"Repository name (defaults to git remote)"`
 }
 
 func (r *RunCancelCmd) Run(ctx context.Context) error {
@@ -199,6 +323,8 @@ func (r *RunCancelCmd) Run(ctx context.Context) error {
 	cmd := &run.CancelCmd{
 		PipelineID: r.PipelineID,
 		Force:      r.Force,
+		Wait:       r.Wait,
+		Timeout:    r.Timeout,
 		Output:     r.Output,
 		NoColor:    noColor,
 		Workspace:  r.Workspace,
@@ -212,8 +338,10 @@ type RunRerunCmd struct {
 	Failed     bool   `help:"Rerun only failed steps"`
 	Step       string `help:"Rerun specific step"`
 	Force      bool   `short:"f" help:"Force rerun without confirmation"`
+	Watch      bool   `short:"w" help:"Watch the new run after triggering it"`
+	Follow     bool   `help:"Stream the new run's logs after triggering it"`
 	Debug      bool   `help:"Show debug information"`
-	Output     string `short:"o" help:"Output format (table, json, yaml)" enum:"table,json,yaml" default:"table"`
+	Output     string `short:"o" help:"Output format (table, json, yaml)" enum:"table,json,yaml" default:"${output_format}"`
 	Workspace  string `help:"Bitbucket workspace (defaults to git remote or config)"`
 	Repository string `help:"Repository name (defaults to git remote)"`
 }
@@ -226,6 +354,8 @@ func (r *RunRerunCmd) Run(ctx context.Context) error {
 		Failed:     r.Failed,
 		Step:       r.Step,
 		Force:      r.Force,
+		Watch:      r.Watch,
+		Follow:     r.Follow,
 		Debug:      r.Debug,
 		Output:     r.Output,
 		NoColor:    noColor,
@@ -235,9 +365,242 @@ func (r *RunRerunCmd) Run(ctx context.Context) error {
 	return cmd.Run(ctx)
 }
 
+type RunDeploymentsCmd struct {
+	Output     string `short:"o" help:"Output format (table, json, yaml)" enum:"table,json,yaml" default:"${output_format}"`
+	Workspace  string `help:"Bitbucket workspace (defaults to git remote or config)"`
+	Repository string `help:"Repository name (defaults to git remote)"`
+}
+
+func (r *RunDeploymentsCmd) Run(ctx context.Context) error {
+	noColor := shared.GetNoColor(ctx)
+
+	cmd := &run.DeploymentsCmd{
+		Output:     r.Output,
+		NoColor:    noColor,
+		Workspace:  r.Workspace,
+		Repository: r.Repository,
+	}
+	return cmd.Run(ctx)
+}
+
+type RunDeployCmd struct {
+	PipelineID string `arg:"" help:"Pipeline ID (build number or UUID)"`
+	Env        string `name:"env" required:"" help:"Deployment environment (name, slug or UUID)"`
+	Web        bool   `help:"Open the pipeline in the browser after starting the deployment step"`
+	Output     string `short:"o" help:"Output format (table, json, yaml)" enum:"table,json,yaml" default:"${output_format}"`
+	Workspace  string `help:"Bitbucket workspace (defaults to git remote or config)"`
+	Repository string `help:"Repository name (defaults to git remote)"`
+}
+
+func (r *RunDeployCmd) Run(ctx context.Context) error {
+	noColor := shared.GetNoColor(ctx)
+
+	cmd := &run.DeployCmd{
+		PipelineID: r.PipelineID,
+		Env:        r.Env,
+		Web:        r.Web,
+		Output:     r.Output,
+		NoColor:    noColor,
+		Workspace:  r.Workspace,
+		Repository: r.Repository,
+	}
+	return cmd.Run(ctx)
+}
+
+type RunGrepCmd struct {
+	Pattern    string `arg:"" help:"Regular expression to search the logs for"`
+	Limit      int    `help:"Number of recent pipelines to search (max 100)" default:"10"`
+	Branch     string `help:"Only search pipelines on this branch"`
+	Status     string `help:"Only search pipelines with this status (PENDING, IN_PROGRESS, SUCCESSFUL, FAILED, ERROR, STOPPED)"`
+	Step       string `help:"Only search steps with this name (or an alias from run.step_aliases)"`
+	IgnoreCase bool   `short:"i" name:"ignore-case" help:"Match the pattern case-insensitively"`
+	Context    int    `short:"C" help:"Number of context lines around each match"`
+	Output     string `short:"o" help:"Output format (table, json, yaml)" enum:"table,json,yaml" default:"${output_format}"`
+	NoRedact   bool   `name:"no-redact" help:"Search and show secrets in logs instead of masking them"`
+	Workspace  string `help:"Bitbucket workspace (defaults to git remote or config)"`
+	Repository string `help:"Repository name (defaults to git remote)"`
+}
+
+func (r *RunGrepCmd) Run(ctx context.Context) error {
+	noColor := shared.GetNoColor(ctx)
+
+	cmd := &run.GrepCmd{
+		Pattern:    r.Pattern,
+		Limit:      r.Limit,
+		Branch:     r.Branch,
+		Status:     r.Status,
+		Step:       r.Step,
+		IgnoreCase: r.IgnoreCase,
+		Context:    r.Context,
+		Output:     r.Output,
+		NoRedact:   r.NoRedact,
+		NoColor:    noColor,
+		Workspace:  r.Workspace,
+		Repository: r.Repository,
+	}
+	return cmd.Run(ctx)
+}
+
+type RunForCommitCmd struct {
+	SHA        string `arg:"" help:"Commit SHA, full or short (short SHAs are resolved in the local repository)"`
+	Status     string `help:"Filter by status (PENDING, IN_PROGRESS, SUCCESSFUL, FAILED, ERROR, STOPPED)"`
+	Limit      int    `help:"Maximum number of runs to show" default:"10"`
+	Output     string `short:"o" help:"Output format (table, json, yaml, compact)" enum:"table,json,yaml,compact" default:"${output_format}"`
+	Workspace  string `help:"Bitbucket workspace (defaults to git remote or config)"`
+	Repository string `help:"Repository name (defaults to git remote)"`
+}
+
+func (r *RunForCommitCmd) Run(ctx context.Context) error {
+	noColor := shared.GetNoColor(ctx)
+
+	cmd := &run.ListCmd{
+		Commit:     r.SHA,
+		Status:     r.Status,
+		Limit:      r.Limit,
+		Output:     r.Output,
+		NoColor:    noColor,
+		Workspace:  r.Workspace,
+		Repository: r.Repository,
+	}
+	return cmd.Run(ctx)
+}
+
+type RunDownloadCmd struct {
+	Name       string `arg:"" help:"Name of the download (as listed on the repository's Downloads page)"`
+	Dir        string `help:"Directory to save the file in" default:"."`
+	Verify     bool   `help:"Check the file against the size Bitbucket reports and retry on a mismatch" default:"true" negatable:""`
+	Output     string `short:"o" help:"Output format (table, json, yaml)" enum:"table,json,yaml" default:"${output_format}"`
+	Workspace  string `help:"Bitbucket workspace (defaults to git remote or config)"`
+	Repository string `help:"Repository name (defaults to git remote)"`
+}
+
+func (r *RunDownloadCmd) Run(ctx context.Context) error {
+	noColor := shared.GetNoColor(ctx)
+
+	cmd := &run.DownloadCmd{
+		Name:       r.Name,
+		Dir:        r.Dir,
+		Verify:     r.Verify,
+		Output:     r.Output,
+		NoColor:    noColor,
+		Workspace:  r.Workspace,
+		Repository: r.Repository,
+	}
+	return cmd.Run(ctx)
+}
+
+type RunLatestCmd struct {
+	Branch     string `help:"Branch to look up (defaults to the current git branch)"`
+	Watch      bool   `short:"w" help:"Watch the pipeline like run watch"`
+	LogFailed  bool   `name:"log-failed" help:"View logs only for failed steps (last 100 lines)"`
+	FullOutput bool   `name:"full-output" help:"Show complete logs (use with --log-failed for full failure logs)"`
+	Output     string `short:"o" help:"Output format (table, json, yaml)" enum:"table,json,yaml" default:"${output_format}"`
+	Workspace  string `help:"Bitbucket workspace (defaults to git remote or config)"`
+	Repository string `help:"Repository name (defaults to git remote)"`
+}
+
+func (r *RunLatestCmd) Run(ctx context.Context) error {
+	noColor := shared.GetNoColor(ctx)
+
+	cmd := &run.LatestCmd{
+		Branch:     r.Branch,
+		Watch:      r.Watch,
+		LogFailed:  r.LogFailed,
+		FullOutput: r.FullOutput,
+		Output:     r.Output,
+		NoColor:    noColor,
+		Workspace:  r.Workspace,
+		Repository: r.Repository,
+	}
+	return cmd.Run(ctx)
+}
+
+type RunCompareCmd struct {
+	Base       string `arg:"" help:"Pipeline to compare from, e.g. the last green build (build number or UUID)"`
+	Head       string `arg:"" help:"Pipeline to compare to (build number or UUID)"`
+	Threshold  int    `help:"Percent duration change (and at least 30s) flagged as slower/faster" default:"20"`
+	Output     string `short:"o" help:"Output format (table, json, yaml)" enum:"table,json,yaml" default:"${output_format}"`
+	Workspace  string `help:"Bitbucket workspace (defaults to git remote or config)"`
+	Repository string `help:"Repository name (defaults to git remote)"`
+}
+
+func (r *RunCompareCmd) Run(ctx context.Context) error {
+	noColor := shared.GetNoColor(ctx)
+
+	cmd := &run.CompareCmd{
+		Base:       r.Base,
+		Head:       r.Head,
+		Threshold:  r.Threshold,
+		Output:     r.Output,
+		NoColor:    noColor,
+		Workspace:  r.Workspace,
+		Repository: r.Repository,
+	}
+	return cmd.Run(ctx)
+}
+
+type RunStatusCmd struct {
+	Repos RunStatusReposCmd `cmd:"" default:"withargs" help:"Show the latest default-branch pipeline of every repository in a workspace (the default)"`
+	Set   RunStatusSetCmd   `cmd:"" help:"Create or update a build status on a commit"`
+}
+
+type RunStatusReposCmd struct {
+	Repos     []string `help:"Comma-separated repositories to check (defaults to every repository in the workspace)"`
+	Branch    string   `help:"Branch to check in every repository (defaults to each repository's main branch)"`
+	Failed    bool     `help:"Only show repositories whose latest pipeline failed or that could not be checked"`
+	Output    string   `short:"o" help:"Output format (table, json, yaml)" enum:"table,json,yaml" default:"${output_format}"`
+	Workspace string   `help:"Bitbucket workspace (defaults to git remote or config)"`
+}
+
+func (r *RunStatusReposCmd) Run(ctx context.Context) error {
+	noColor := shared.GetNoColor(ctx)
+
+	cmd := &run.WorkspaceStatusCmd{
+		Repos:     r.Repos,
+		Branch:    r.Branch,
+		Failed:    r.Failed,
+		Output:    r.Output,
+		NoColor:   noColor,
+		Workspace: r.Workspace,
+	}
+	return cmd.Run(ctx)
+}
+
+type RunStatusSetCmd struct {
+	Commit      string `required:"" help:"Commit SHA the status is attached to"`
+	State       string `required:"" help:"Status state (SUCCESSFUL, FAILED, INPROGRESS, STOPPED)"`
+	Key         string `required:"" help:"Unique key of the status; reusing it updates the existing status"`
+	Name        string `help:"Display name of the status"`
+	URL         string `required:"" help:"Link to the build or report behind the status"`
+	Description string `help:"Short description of the result"`
+	Refname     string `help:"Branch or tag the status applies to"`
+	Output      string `short:"o" help:"Output format (table, json, yaml)" enum:"table,json,yaml" default:"${output_format}"`
+	Workspace   string `help:"Bitbucket workspace (defaults to git remote or config)"`
+	Repository  string `help:"Repository name (defaults to git remote)"`
+}
+
+func (r *RunStatusSetCmd) Run(ctx context.Context) error {
+	noColor := shared.GetNoColor(ctx)
+
+	cmd := &run.StatusSetCmd{
+		Commit:      r.Commit,
+		State:       r.State,
+		Key:         r.Key,
+		Name:        r.Name,
+		URL:         r.URL,
+		Description: r.Description,
+		Refname:     r.Refname,
+		Output:      r.Output,
+		NoColor:     noColor,
+		Workspace:   r.Workspace,
+		Repository:  r.Repository,
+	}
+	return cmd.Run(ctx)
+}
+
 type RunReportCmd struct {
 	PipelineID        string   `arg:"" help:"Pipeline ID (build number or UUID)"`
-	Output            string   `short:"o" help:"Output format (table, json, yaml)" enum:"table,json,yaml" default:"table"`
+	Output            string   `short:"o" help:"Output format (table, json, yaml)" enum:"table,json,yaml" default:"${output_format}"`
 	Coverage          bool     `help:"Show only coverage-related information"`
 	Issues            bool     `help:"Show only code quality issues"`
 	Duplications      bool     `help:"Show duplicated code analysis"`
@@ -291,52 +654,264 @@ func (r *RunReportCmd) Run(ctx context.Context) error {
 	return cmd.Run(ctx)
 }
 
-type RepoCmd struct{}
+type RepoCmd struct {
+	Clone      RepoCloneCmd      `cmd:"" help:"Clone a repository, optionally checking out a pull request"`
+	Commits    RepoCommitsCmd    `cmd:"" help:"List commits with their signature verification status"`
+	CherryPick RepoCherryPickCmd `cmd:"" name:"cherry-pick" help:"Backport commits onto another branch, optionally opening a pull request"`
+	Variables  RepoVariablesCmd  `cmd:"" help:"Manage pipeline variables of the repository or a deployment environment"`
+	Changelog  RepoChangelogCmd  `cmd:"" help:"Build a changelog from the pull requests merged since a tag or date"`
+}
+
+type RepoCloneCmd struct {
+	Repository string `arg:"" help:"Repository to clone (workspace/repo or Bitbucket URL)"`
+	Directory  string `arg:"" optional:"" help:"Directory to clone into (defaults to the repository name)"`
+	PR         string `name:"pr" help:"Check out this pull request's source branch after cloning"`
+	SSH        bool   `name:"ssh" help:"Clone over SSH instead of HTTPS"`
+}
+
+func (r *RepoCloneCmd) Run(ctx context.Context) error {
+	noColor := shared.GetNoColor(ctx)
+
+	cmd := &repo.CloneCmd{
+		Repository: r.Repository,
+		Directory:  r.Directory,
+		PR:         r.PR,
+		SSH:        r.SSH,
+		NoColor:    noColor,
+	}
+	return cmd.Run(ctx)
+}
+
+type RepoCherryPickCmd struct {
+	Commits    []string `arg:"" help:"Commits to cherry-pick, applied in the order given"`
+	Onto       string   `required:"" help:"Branch to backport the commits onto"`
+	Branch     string   `help:"Name of the new branch (defaults to backport/<onto>/<short sha>)"`
+	PR         bool     `name:"pr" help:"Push the branch and open a pull request into --onto"`
+	Draft      bool     `help:"Open the pull request as a draft (with --pr)"`
+	NoVerify   bool     `name:"no-verify" help:"Skip git pre-push hooks when pushing the branch"`
+	Output     string   `short:"o" help:"Output format (table, json, yaml)" enum:"table,json,yaml" default:"${output_format}"`
+	Workspace  string   `help:"Bitbucket workspace (defaults to git remote or config)"`
+	Repository string   `help:"Repository name (defaults to git remote)"`
+}
+
+func (r *RepoCherryPickCmd) Run(ctx context.Context) error {
+	noColor := shared.GetNoColor(ctx)
 
-func (r *RepoCmd) Run(ctx context.Context) error {
-	return fmt.Errorf("repo commands not yet implemented")
+	cmd := &repo.CherryPickCmd{
+		Commits:    r.Commits,
+		Onto:       r.Onto,
+		Branch:     r.Branch,
+		PR:         r.PR,
+		Draft:      r.Draft,
+		NoVerify:   r.NoVerify,
+		Output:     r.Output,
+		NoColor:    noColor,
+		Workspace:  r.Workspace,
+		Repository: r.Repository,
+	}
+	return cmd.Run(ctx)
+}
+
+type RepoCommitsCmd struct {
+	Revision    string `arg:"" optional:"" help:"Branch, tag or commit to list history from (defaults to the main branch)"`
+	Limit       int    `help:"Maximum number of commits to show" default:"30"`
+	AuthorEmail string `name:"author-email" help:"Only show commits whose author has this email or Bitbucket username"`
+	Committer   string `help:"Only show commits committed by this name or email"`
+	Output      string `short:"o" help:"Output format (table, json, yaml)" enum:"table,json,yaml" default:"${output_format}"`
+	Workspace   string `help:"Bitbucket workspace (defaults to git remote or config)"`
+	Repository  string `help:"Repository name (defaults to git remote)"`
+}
+
+func (r *RepoCommitsCmd) Run(ctx context.Context) error {
+	noColor := shared.GetNoColor(ctx)
+
+	cmd := &repo.CommitsCmd{
+		Revision:    r.Revision,
+		Limit:       r.Limit,
+		AuthorEmail: r.AuthorEmail,
+		Committer:   r.Committer,
+		Output:      r.Output,
+		NoColor:     noColor,
+		Workspace:   r.Workspace,
+		Repository:  r.Repository,
+	}
+	return cmd.Run(ctx)
+}
+
+type RepoChangelogCmd struct {
+	Since      string `required:"" help:"Start of the range: a tag, commit or date (YYYY-MM-DD)"`
+	Until      string `help:"End of the range: a tag, commit or date (defaults to now)"`
+	Output     string `short:"o" help:"Output format (markdown, json, yaml)" enum:"markdown,json,yaml" default:"markdown"`
+	Workspace  string `help:"Bitbucket workspace (defaults to git remote or config)"`
+	Repository string `help:"Repository name (defaults to git remote)"`
+}
+
+func (r *RepoChangelogCmd) Run(ctx context.Context) error {
+	noColor := shared.GetNoColor(ctx)
+
+	cmd := &repo.ChangelogCmd{
+		Since:      r.Since,
+		Until:      r.Until,
+		Output:     r.Output,
+		NoColor:    noColor,
+		Workspace:  r.Workspace,
+		Repository: r.Repository,
+	}
+	return cmd.Run(ctx)
+}
+
+type RepoVariablesCmd struct {
+	List   RepoVariablesListCmd   `cmd:"" help:"List variables; secured values are masked"`
+	Get    RepoVariablesGetCmd    `cmd:"" help:"Print the value of a variable"`
+	Set    RepoVariablesSetCmd    `cmd:"" help:"Create or update a variable"`
+	Delete RepoVariablesDeleteCmd `cmd:"" help:"Delete a variable"`
+}
+
+type RepoVariablesListCmd struct {
+	Environment string `short:"e" help:"Deployment environment (name, slug or UUID); omit for repository variables"`
+	Output      string `short:"o" help:"Output format (table, json, yaml)" enum:"table,json,yaml" default:"${output_format}"`
+	Workspace   string `help:"Bitbucket workspace (defaults to git remote or config)"`
+	Repository  string `help:"Repository name (defaults to git remote)"`
+}
+
+func (r *RepoVariablesListCmd) Run(ctx context.Context) error {
+	noColor := shared.GetNoColor(ctx)
+
+	cmd := &repo.VariablesListCmd{
+		Environment: r.Environment,
+		Output:      r.Output,
+		NoColor:     noColor,
+		Workspace:   r.Workspace,
+		Repository:  r.Repository,
+	}
+	return cmd.Run(ctx)
+}
+
+type RepoVariablesGetCmd struct {
+	Key         string `arg:"" help:"Variable name"`
+	Environment string `short:"e" help:"Deployment environment (name, slug or UUID); omit for repository variables"`
+	Output      string `short:"o" help:"Output format (table, json, yaml)" enum:"table,json,yaml" default:"${output_format}"`
+	Workspace   string `help:"Bitbucket workspace (defaults to git remote or config)"`
+	Repository  string `help:"Repository name (defaults to git remote)"`
+}
+
+func (r *RepoVariablesGetCmd) Run(ctx context.Context) error {
+	noColor := shared.GetNoColor(ctx)
+
+	cmd := &repo.VariablesGetCmd{
+		Key:         r.Key,
+		Environment: r.Environment,
+		Output:      r.Output,
+		NoColor:     noColor,
+		Workspace:   r.Workspace,
+		Repository:  r.Repository,
+	}
+	return cmd.Run(ctx)
+}
+
+type RepoVariablesSetCmd struct {
+	Key         string `arg:"" help:"Variable name"`
+	Value       string `arg:"" optional:"" help:"Variable value; omit or pass - to read it from stdin"`
+	Secured     bool   `help:"Store as a secured variable (write-only, masked in logs); secured variables stay secured"`
+	Environment string `short:"e" help:"Deployment environment (name, slug or UUID); omit for repository variables"`
+	Output      string `short:"o" help:"Output format (table, json, yaml)" enum:"table,json,yaml" default:"${output_format}"`
+	Workspace   string `help:"Bitbucket workspace (defaults to git remote or config)"`
+	Repository  string `help:"Repository name (defaults to git remote)"`
+}
+
+func (r *RepoVariablesSetCmd) Run(ctx context.Context) error {
+	noColor := shared.GetNoColor(ctx)
+
+	cmd := &repo.VariablesSetCmd{
+		Key:         r.Key,
+		Value:       r.Value,
+		Secured:     r.Secured,
+		Environment: r.Environment,
+		Output:      r.Output,
+		NoColor:     noColor,
+		Workspace:   r.Workspace,
+		Repository:  r.Repository,
+	}
+	return cmd.Run(ctx)
+}
+
+type RepoVariablesDeleteCmd struct {
+	Key         string `arg:"" help:"Variable name"`
+	Force       bool   `short:"f" help:"Skip confirmation prompt"`
+	Environment string `short:"e" help:"Deployment environment (name, slug or UUID); omit for repository variables"`
+	Output      string `short:"o" help:"Output format (table, json, yaml)" enum:"table,json,yaml" default:"${output_format}"`
+	Workspace   string `help:"Bitbucket workspace (defaults to git remote or config)"`
+	Repository  string `help:"Repository name (defaults to git remote)"`
+}
+
+func (r *RepoVariablesDeleteCmd) Run(ctx context.Context) error {
+	noColor := shared.GetNoColor(ctx)
+
+	cmd := &repo.VariablesDeleteCmd{
+		Key:         r.Key,
+		Force:       r.Force,
+		Environment: r.Environment,
+		Output:      r.Output,
+		NoColor:     noColor,
+		Workspace:   r.Workspace,
+		Repository:  r.Repository,
+	}
+	return cmd.Run(ctx)
 }
 
 type PRCmd struct {
-	Create        PRCreateCmd        `cmd:""`
-	List          PRListCmd          `cmd:""`
-	ListAll       PRListAllCmd       `cmd:"list-all"`
-	View          PRViewCmd          `cmd:""`
-	Open          PROpenCmd          `cmd:""`
-	Edit          PREditCmd          `cmd:""`
-	Diff          PRDiffCmd          `cmd:""`
-	Review        PRReviewCmd        `cmd:""`
-	Files         PRFilesCmd         `cmd:""`
-	Comment       PRCommentCmd       `cmd:""`
-	Comments      PRCommentsCmd      `cmd:""`
+	Create        PRCreateCmd        `cmd:"" help:"Create a pull request"`
+	List          PRListCmd          `cmd:"" help:"List pull requests in a repository"`
+	ListAll       PRListAllCmd       `cmd:"list-all" help:"List pull requests across repositories in a workspace"`
+	View          PRViewCmd          `cmd:"" help:"View a pull request"`
+	Open          PROpenCmd          `cmd:"" help:"Open pull requests in a browser"`
+	Edit          PREditCmd          `cmd:"" help:"Edit a pull request"`
+	Diff          PRDiffCmd          `cmd:"" help:"View changes in a pull request"`
+	Review        PRReviewCmd        `cmd:"" help:"Add a review to a pull request"`
+	Files         PRFilesCmd         `cmd:"" help:"List files changed in a pull request"`
+	Comment       PRCommentCmd       `cmd:"" help:"Add a comment to a pull request"`
+	Comments      PRCommentsCmd      `cmd:"" help:"List comments on a pull request"`
 	ReviewHistory PRReviewHistoryCmd `cmd:"review-history" help:"Collect an author's comments across all PRs in the repo"`
-	Merge         PRMergeCmd         `cmd:""`
-	Checkout      PRCheckoutCmd      `cmd:""`
-	Ready         PRReadyCmd         `cmd:""`
-	Checks        PRChecksCmd        `cmd:""`
-	Close         PRCloseCmd         `cmd:""`
-	Reopen        PRReopenCmd        `cmd:""`
-	Status        PRStatusCmd        `cmd:""`
-	UpdateBranch  PRUpdateBranchCmd  `cmd:"update-branch"`
-	Lock          PRLockCmd          `cmd:""`
-	Unlock        PRUnlockCmd        `cmd:""`
-	Report        PRReportCmd        `cmd:""`
+	Merge         PRMergeCmd         `cmd:"" help:"Merge a pull request"`
+	Checkout      PRCheckoutCmd      `cmd:"" help:"Check out a pull request in git"`
+	Ready         PRReadyCmd         `cmd:"" help:"Mark a pull request as ready for review"`
+	Checks        PRChecksCmd        `cmd:"" help:"Show CI status for a single pull request"`
+	Close         PRCloseCmd         `cmd:"" help:"Close a pull request"`
+	Reopen        PRReopenCmd        `cmd:"" help:"Reopen a pull request"`
+	Status        PRStatusCmd        `cmd:"" help:"Show status of relevant pull requests"`
+	UpdateBranch  PRUpdateBranchCmd  `cmd:"update-branch" help:"Update a pull request branch"`
+	Lock          PRLockCmd          `cmd:"" help:"Lock pull request conversation"`
+	Unlock        PRUnlockCmd        `cmd:"" help:"Unlock pull request conversation"`
+	Report        PRReportCmd        `cmd:"" help:"Pull request report"`
 }
 
 type PRCreateCmd struct {
 	Title             string   `help:"Title of the pull request"`
 	Body              string   `help:"Body of the pull request"`
 	Base              string   `help:"Base branch for the pull request"`
+	Head              string   `help:"Branch to open the pull request from (defaults to the current git branch)"`
+	BaseAuto          bool     `name:"base-auto" help:"Use the branch the current branch was created from as the base, inferred from history"`
 	Draft             bool     `help:"Create a draft pull request"`
-	Reviewer          []string `help:"Reviewers for the pull request"`
+	NoDraft           bool     `name:"no-draft" help:"Create the pull request ready for review, even when pr.create_as_draft is set"`
+	Reviewer          []string `help:"Reviewers for the pull request (username, account_id, or {uuid}); the author is skipped"`
 	Fill              bool     `help:"Fill title and body from commit messages"`
+	FillFirstCommit   bool     `name:"fill-first-commit" help:"Use the first commit's subject as the title and its body as the description"`
 	AI                bool     `help:"Generate PR description using AI analysis"`
-	Jira              string   `help:"Path to JIRA context file (markdown format)"`
+	Jira              string   `help:"JIRA ticket key to fetch from jira.base_url (token in JIRA_API_TOKEN), or path to a JIRA context file (markdown format)"`
 	Debug             bool     `help:"Enable debug output for AI generation"`
 	NoPush            bool     `name:"no-push" help:"Skip pushing branch to remote"`
+	NoVerify          bool     `name:"no-verify" help:"Skip git pre-push hooks when pushing the branch"`
 	NoEmoji           bool     `name:"no-emoji" help:"Skip emojis in auto-generated titles"`
 	CloseSourceBranch bool     `name:"close-source-branch" help:"Close source branch when pull request is merged"`
-	Output            string   `short:"o" help:"Output format (table, json, yaml)" enum:"table,json,yaml" default:"table"`
+	Recover           bool     `help:"Reuse the title and description saved when a previous create failed"`
+	RequireChecklist  bool     `name:"require-checklist" help:"Refuse to create the pull request unless the description satisfies pr.checklist from the config"`
+	LintCommits       bool     `name:"lint-commits" help:"Check the pull request's commit messages against pr.commit_lint (conventional commits by default)"`
+	NoLint            bool     `name:"no-lint" help:"Skip commit message linting, even when pr.commit_lint.enabled is set"`
+	Milestone         string   `help:"Issue tracker milestone to set (repositories with the issue tracker only)"`
+	Version           string   `help:"Issue tracker version to set (repositories with the issue tracker only)"`
+	Component         string   `help:"Issue tracker component to set (repositories with the issue tracker only)"`
+	MaxSize           string   `name:"max-size" help:"Warn when the pull request changes more lines than this (a line count, or XS, S, M or L from pr.size_thresholds); defaults to pr.max_size"`
+	Output            string   `short:"o" help:"Output format (table, json, yaml)" enum:"table,json,yaml" default:"${output_format}"`
 	Workspace         string   `help:"Bitbucket workspace (defaults to git remote or config)"`
 	Repository        string   `help:"Repository name (defaults to git remote)"`
 }
@@ -348,15 +923,28 @@ func (p *PRCreateCmd) Run(ctx context.Context) error {
 		Title:             p.Title,
 		Body:              p.Body,
 		Base:              p.Base,
+		Head:              p.Head,
+		BaseAuto:          p.BaseAuto,
 		Draft:             p.Draft,
+		NoDraft:           p.NoDraft,
 		Reviewer:          p.Reviewer,
 		Fill:              p.Fill,
+		FillFirstCommit:   p.FillFirstCommit,
 		AI:                p.AI,
 		Jira:              p.Jira,
 		Debug:             p.Debug,
 		NoPush:            p.NoPush,
+		NoVerify:          p.NoVerify,
 		NoEmoji:           p.NoEmoji,
 		CloseSourceBranch: p.CloseSourceBranch,
+		Recover:           p.Recover,
+		RequireChecklist:  p.RequireChecklist,
+		LintCommits:       p.LintCommits,
+		NoLint:            p.NoLint,
+		Milestone:         p.Milestone,
+		Version:           p.Version,
+		Component:         p.Component,
+		MaxSize:           p.MaxSize,
 		Output:            p.Output,
 		NoColor:           noColor,
 		Workspace:         p.Workspace,
@@ -367,12 +955,17 @@ func (p *PRCreateCmd) Run(ctx context.Context) error {
 
 type PRListCmd struct {
 	State      string `help:"Filter by state (open, merged, declined, all)" default:"open"`
-	Author     string `help:"Filter by pull request author"`
-	Reviewer   string `help:"Filter by pull request reviewer"`
+	Author     string `help:"Filter by pull request author (username or @me)"`
+	Reviewer   string `help:"Filter by pull request reviewer (username or @me)"`
+	Mine       bool   `help:"Show only your pull requests (same as --author @me)"`
+	Base       string `help:"Filter by base (destination) branch; @default for the configured or default base"`
 	Limit      int    `help:"Maximum number of pull requests to show" default:"30"`
 	Sort       string `help:"Sort by field (created, updated, priority)" default:"updated"`
-	Output     string `short:"o" help:"Output format (table, json, yaml)" enum:"table,json,yaml" default:"table"`
+	Output     string `short:"o" help:"Output format (table, json, yaml, compact)" enum:"table,json,yaml,compact" default:"${output_format}"`
 	All        bool   `help:"Show all pull requests regardless of author"`
+	Detailed   bool   `help:"Fetch approvals, mergeability, check status and size for each pull request (extra API calls)"`
+	Stale      string `help:"Show only pull requests not updated for this long (e.g. 14d, 2w, 36h)"`
+	Draft      bool   `help:"Show only draft pull requests"`
 	Debug      bool   `help:"Show debug output"`
 	Workspace  string `help:"Bitbucket workspace (defaults to git remote or config)"`
 	Repository string `help:"Repository name (defaults to git remote)"`
@@ -385,10 +978,15 @@ func (p *PRListCmd) Run(ctx context.Context) error {
 		State:      p.State,
 		Author:     p.Author,
 		Reviewer:   p.Reviewer,
+		Mine:       p.Mine,
+		Base:       p.Base,
 		Limit:      p.Limit,
 		Sort:       p.Sort,
 		Output:     p.Output,
 		All:        p.All,
+		Detailed:   p.Detailed,
+		Stale:      p.Stale,
+		Draft:      p.Draft,
 		Debug:      p.Debug,
 		NoColor:    noColor,
 		Workspace:  p.Workspace,
@@ -401,10 +999,12 @@ type PRListAllCmd struct {
 	State     string `help:"Filter by state (open, merged, declined, all)" default:"open"`
 	Limit     int    `help:"Maximum number of pull requests per repository" default:"10"`
 	Sort      string `help:"Sort by field (created, updated, priority)" default:"updated"`
-	Output    string `short:"o" help:"Output format (table, json, yaml)" enum:"table,json,yaml" default:"table"`
+	Output    string `short:"o" help:"Output format (table, json, yaml)" enum:"table,json,yaml" default:"${output_format}"`
 	URL       bool   `help:"Output URLs in format: <repo:source-branch> <target-branch> <url>"`
 	Approved  bool   `help:"Filter to show only approved PRs"`
 	Debug     bool   `help:"Show debug output"`
+	Refresh   bool   `help:"Ignore the cache and rescan every repository"`
+	NoCache   bool   `help:"Neither read nor write the per-repository cache"`
 	Workspace string `help:"Bitbucket workspace (defaults to git remote or config)"`
 }
 
@@ -419,6 +1019,8 @@ func (p *PRListAllCmd) Run(ctx context.Context) error {
 		URL:       p.URL,
 		Approved:  p.Approved,
 		Debug:     p.Debug,
+		Refresh:   p.Refresh,
+		NoCache:   p.NoCache,
 		NoColor:   noColor,
 		Workspace: p.Workspace,
 	}
@@ -426,25 +1028,37 @@ func (p *PRListAllCmd) Run(ctx context.Context) error {
 }
 
 type PRViewCmd struct {
-	PRID       string `arg:"" help:"Pull request ID (number)"`
-	Web        bool   `help:"Open pull request in browser"`
-	Comments   bool   `help:"Show comments with the pull request"`
-	Output     string `short:"o" help:"Output format (table, json, yaml)" enum:"table,json,yaml" default:"table"`
-	Workspace  string `help:"Bitbucket workspace (defaults to git remote or config)"`
-	Repository string `help:"Repository name (defaults to git remote)"`
+	PRID        string `arg:"" optional:"" help:"Pull request ID (number); omit to pick from open pull requests"`
+	Web         bool   `help:"Open pull request in browser"`
+	Comments    bool   `help:"Show comments with the pull request"`
+	Tree        bool   `help:"With --comments, nest replies under their parent and group inline comments by file and line"`
+	Commits     bool   `help:"Show commits with their signature verification status"`
+	AuthorEmail string `name:"author-email" help:"With --commits, only show commits whose author has this email or Bitbucket username"`
+	Committer   string `help:"With --commits, only show commits committed by this name or email"`
+	Checks      bool   `help:"Append a summary of the CI checks: pass/fail counts and failing check names"`
+	Patch       bool   `help:"Print the commits as a mailbox patch series that git am --patch-format=mboxrd can apply"`
+	Output      string `short:"o" help:"Output format (table, json, yaml)" enum:"table,json,yaml" default:"${output_format}"`
+	Workspace   string `help:"Bitbucket workspace (defaults to git remote or config)"`
+	Repository  string `help:"Repository name (defaults to git remote)"`
 }
 
 func (p *PRViewCmd) Run(ctx context.Context) error {
 	noColor := shared.GetNoColor(ctx)
 
 	cmd := &pr.ViewCmd{
-		PRID:       p.PRID,
-		Web:        p.Web,
-		Comments:   p.Comments,
-		Output:     p.Output,
-		NoColor:    noColor,
-		Workspace:  p.Workspace,
-		Repository: p.Repository,
+		PRID:        p.PRID,
+		Web:         p.Web,
+		Comments:    p.Comments,
+		Tree:        p.Tree,
+		Commits:     p.Commits,
+		AuthorEmail: p.AuthorEmail,
+		Committer:   p.Committer,
+		Checks:      p.Checks,
+		Patch:       p.Patch,
+		Output:      p.Output,
+		NoColor:     noColor,
+		Workspace:   p.Workspace,
+		Repository:  p.Repository,
 	}
 	return cmd.Run(ctx)
 }
@@ -452,6 +1066,9 @@ func (p *PRViewCmd) Run(ctx context.Context) error {
 type PROpenCmd struct {
 	PRIDs      []string `arg:"" help:"Pull request IDs (numbers)"`
 	Show       bool     `help:"Print URLs instead of opening in browser"`
+	Commits    bool     `help:"Open the commits tab"`
+	Diff       bool     `help:"Open the diff tab"`
+	Pipeline   bool     `help:"Open the results of the latest pipeline run on the pull request (also --checks)" aliases:"checks"`
 	Workspace  string   `help:"Bitbucket workspace (defaults to git remote or config)"`
 	Repository string   `help:"Repository name (defaults to git remote)"`
 	Debug      bool     `help:"Show debug output"`
@@ -463,6 +1080,9 @@ func (p *PROpenCmd) Run(ctx context.Context) error {
 	cmd := &pr.OpenCmd{
 		PRIDs:      p.PRIDs,
 		Show:       p.Show,
+		Commits:    p.Commits,
+		Diff:       p.Diff,
+		Pipeline:   p.Pipeline,
 		Workspace:  p.Workspace,
 		Repository: p.Repository,
 		Debug:      p.Debug,
@@ -481,9 +1101,9 @@ type PREditCmd struct {
 	Ready          bool     `help:"Mark pull request as ready for review (if draft)"`
 	Draft          bool     `help:"Convert pull request to draft"`
 	AI             bool     `help:"Generate PR description using AI analysis"`
-	Jira           string   `help:"Path to JIRA context file (markdown format)"`
+	Jira           string   `help:"JIRA ticket key to fetch from jira.base_url (token in JIRA_API_TOKEN), or path to a JIRA context file (markdown format)"`
 	Debug          bool     `help:"Print debug information including git diff and AI inputs"`
-	Output         string   `short:"o" help:"Output format (table, json, yaml)" enum:"table,json,yaml" default:"table"`
+	Output         string   `short:"o" help:"Output format (table, json, yaml)" enum:"table,json,yaml" default:"${output_format}"`
 	Workspace      string   `help:"Bitbucket workspace (defaults to git remote or config)"`
 	Repository     string   `help:"Repository name (defaults to git remote)"`
 }
@@ -517,9 +1137,15 @@ type PRDiffCmd struct {
 	Patch        bool   `help:"Output in patch format suitable for git apply"`
 	File         string `help:"Show diff for specific file only"`
 	Color        string `help:"When to use color (always, never, auto)" enum:"always,never,auto" default:"auto"`
-	Output       string `short:"o" help:"Output format (diff, json, yaml)" enum:"diff,json,yaml" default:"diff"`
+	Output       string `short:"o" help:"Output format (diff, json, yaml)" enum:"diff,json,yaml" default:"${diff_output_format}"`
 	Page         bool   `help:"Page output through diff-so-fancy and less for enhanced viewing"`
 	IncludeTests bool   `name:"include-tests" help:"Include test files in diff (excluded by default)"`
+	OnlyAdded    bool   `name:"only-added" help:"Show only added lines, keeping file and hunk headers"`
+	OnlyRemoved  bool   `name:"only-removed" help:"Show only removed lines, keeping file and hunk headers"`
+	WordDiff     bool   `name:"word-diff" help:"Highlight the words that changed within modified lines"`
+	Apply        bool   `help:"Apply the patch to the working tree with git apply (test files included)"`
+	Check        bool   `help:"With --apply, only check whether the patch applies cleanly"`
+	ThreeWay     bool   `name:"3way" help:"With --apply, fall back to a 3-way merge when the patch doesn't apply cleanly"`
 	Workspace    string `help:"Bitbucket workspace (defaults to git remote or config)"`
 	Repository   string `help:"Repository name (defaults to git remote)"`
 }
@@ -536,6 +1162,12 @@ func (p *PRDiffCmd) Run(ctx context.Context) error {
 		Output:       p.Output,
 		Page:         p.Page,
 		IncludeTests: p.IncludeTests,
+		OnlyAdded:    p.OnlyAdded,
+		OnlyRemoved:  p.OnlyRemoved,
+		WordDiff:     p.WordDiff,
+		Apply:        p.Apply,
+		Check:        p.Check,
+		ThreeWay:     p.ThreeWay,
 		NoColor:      noColor,
 		Workspace:    p.Workspace,
 		Repository:   p.Repository,
@@ -544,34 +1176,42 @@ func (p *PRDiffCmd) Run(ctx context.Context) error {
 }
 
 type PRReviewCmd struct {
-	PRID           string `arg:"" help:"Pull request ID (number)"`
-	Approve        bool   `help:"Approve the pull request"`
-	RequestChanges bool   `name:"request-changes" help:"Request changes on the pull request"`
-	Comment        bool   `help:"Add a comment to the pull request"`
-	Body           string `short:"b" help:"Comment body text"`
-	BodyFile       string `short:"F" name:"body-file" help:"Read comment body from file"`
-	Force          bool   `short:"f" help:"Skip confirmation prompts"`
-	Output         string `short:"o" help:"Output format (table, json, yaml)" enum:"table,json,yaml" default:"table"`
-	Workspace      string `help:"Bitbucket workspace (defaults to git remote or config)"`
-	Repository     string `help:"Repository name (defaults to git remote)"`
+	PRIDs          []string `arg:"" name:"pr-id" optional:"" help:"Pull request ID(s) (number); pass several with --approve --force to approve in batch"`
+	Query          string   `help:"Approve every open pull request matching a query, e.g. author=renovate-bot (keys: author, reviewer; requires --approve --force)"`
+	Approve        bool     `help:"Approve the pull request"`
+	RequestChanges bool     `name:"request-changes" help:"Request changes on the pull request"`
+	Comment        bool     `help:"Add a comment to the pull request"`
+	Body           string   `short:"b" help:"Comment body text"`
+	BodyFile       string   `short:"F" name:"body-file" help:"Read comment body from file"`
+	FromPipeline   string   `name:"from-pipeline" aliases:"body-from-pipeline" help:"Add the errors of the failed steps of this pipeline (build number or UUID) to the comment (also --body-from-pipeline)"`
+	Force          bool     `short:"f" help:"Skip confirmation prompts"`
+	Output         string   `short:"o" help:"Output format (table, json, yaml)" enum:"table,json,yaml" default:"${output_format}"`
+	Workspace      string   `help:"Bitbucket workspace (defaults to git remote or config)"`
+	Repository     string   `help:"Repository name (defaults to git remote)"`
 }
 
 func (p *PRReviewCmd) Run(ctx context.Context) error {
 	noColor := shared.GetNoColor(ctx)
 
 	cmd := &pr.ReviewCmd{
-		PRID:           p.PRID,
+		Query:          p.Query,
 		Approve:        p.Approve,
 		RequestChanges: p.RequestChanges,
 		Comment:        p.Comment,
 		Body:           p.Body,
 		BodyFile:       p.BodyFile,
+		FromPipeline:   p.FromPipeline,
 		Force:          p.Force,
 		Output:         p.Output,
 		NoColor:        noColor,
 		Workspace:      p.Workspace,
 		Repository:     p.Repository,
 	}
+	if len(p.PRIDs) == 1 && p.Query == "" {
+		cmd.PRID = p.PRIDs[0]
+	} else {
+		cmd.BatchIDs = p.PRIDs
+	}
 	return cmd.Run(ctx)
 }
 
@@ -579,7 +1219,8 @@ type PRFilesCmd struct {
 	PRID       string `arg:"" name:"pr-id" help:"Pull request ID or number (e.g., 123 or #123)"`
 	NameOnly   bool   `help:"Show only file names"`
 	Filter     string `help:"Filter files by pattern (e.g., '*.go', 'src/**/*.js')"`
-	Output     string `short:"o" help:"Output format (table, json, yaml)" enum:"table,json,yaml" default:"table"`
+	Sort       string `help:"Sort files by name, additions, deletions or changes (added plus removed lines); counts sort largest first" enum:"name,additions,deletions,changes" default:"changes"`
+	Output     string `short:"o" help:"Output format (table, json, yaml)" enum:"table,json,yaml" default:"${output_format}"`
 	Workspace  string `help:"Bitbucket workspace (defaults to git remote or config)"`
 	Repository string `help:"Repository name (defaults to git remote)"`
 }
@@ -589,6 +1230,7 @@ func (p *PRFilesCmd) Run(ctx context.Context) error {
 		PRID:       p.PRID,
 		NameOnly:   p.NameOnly,
 		Filter:     p.Filter,
+		Sort:       p.Sort,
 		Output:     p.Output,
 		Workspace:  p.Workspace,
 		Repository: p.Repository,
@@ -604,7 +1246,9 @@ type PRCommentCmd struct {
 	File       string `name:"file" help:"File path for an inline comment (requires --line)"`
 	Line       int    `name:"line" help:"Line number for an inline comment"`
 	LineType   string `name:"line-type" help:"Which diff side --line refers to" enum:"new,old" default:"new"`
-	Output     string `short:"o" help:"Output format (table, json, yaml)" enum:"table,json,yaml" default:"table"`
+	FromDiff   string `name:"from-diff" help:"Comment inline on every added line matching this regex"`
+	Force      bool   `short:"f" help:"With --from-diff, post the comments instead of previewing them"`
+	Output     string `short:"o" help:"Output format (table, json, yaml)" enum:"table,json,yaml" default:"${output_format}"`
 	Workspace  string `help:"Bitbucket workspace (defaults to git remote or config)"`
 	Repository string `help:"Repository name (defaults to git remote)"`
 }
@@ -620,6 +1264,8 @@ func (p *PRCommentCmd) Run(ctx context.Context) error {
 		File:       p.File,
 		Line:       p.Line,
 		LineType:   p.LineType,
+		FromDiff:   p.FromDiff,
+		Force:      p.Force,
 		Output:     p.Output,
 		NoColor:    noColor,
 		Workspace:  p.Workspace,
@@ -630,7 +1276,7 @@ func (p *PRCommentCmd) Run(ctx context.Context) error {
 
 type PRCommentsCmd struct {
 	PRID       string `arg:"" help:"Pull request ID (number)"`
-	Output     string `short:"o" help:"Output format (table, json, yaml)" enum:"table,json,yaml" default:"table"`
+	Output     string `short:"o" help:"Output format (table, json, yaml)" enum:"table,json,yaml" default:"${output_format}"`
 	Author     string `help:"Only show comments by this author (username, nickname, display name, account_id, or @me)"`
 	Workspace  string `help:"Bitbucket workspace (defaults to git remote or config)"`
 	Repository string `help:"Repository name (defaults to git remote)"`
@@ -653,7 +1299,7 @@ func (p *PRCommentsCmd) Run(ctx context.Context) error {
 type PRReviewHistoryCmd struct {
 	Author      string `help:"Author whose comments to collect (username, nickname, display name, account_id, or @me)" default:"@me"`
 	State       string `help:"PR state to scan (open, merged, declined, all)" default:"merged"`
-	Output      string `short:"o" help:"Output format (table, json, yaml)" enum:"table,json,yaml" default:"table"`
+	Output      string `short:"o" help:"Output format (table, json, yaml)" enum:"table,json,yaml" default:"${output_format}"`
 	Concurrency int    `help:"Parallel PRs to fetch comments for" default:"8"`
 	Workspace   string `help:"Bitbucket workspace (defaults to git remote or config)"`
 	Repository  string `help:"Repository name (defaults to git remote)"`
@@ -675,13 +1321,13 @@ func (p *PRReviewHistoryCmd) Run(ctx context.Context) error {
 }
 
 type PRMergeCmd struct {
-	PRID         string `arg:"" help:"Pull request ID (number)"`
+	PRID         string `arg:"" optional:"" help:"Pull request ID (number); omit to pick from open pull requests"`
 	Squash       bool   `help:"Squash commits when merging"`
-	DeleteBranch bool   `help:"Delete source branch after merge"`
+	DeleteBranch bool   `help:"Delete the source branch after merge, on Bitbucket and locally (unless it has unmerged commits)"`
 	Auto         bool   `help:"Automatically merge when checks pass"`
 	Force        bool   `short:"f" help:"Skip confirmation prompt"`
 	Message      string `short:"m" help:"Custom merge commit message"`
-	Output       string `short:"o" help:"Output format (table, json, yaml)" enum:"table,json,yaml" default:"table"`
+	Output       string `short:"o" help:"Output format (table, json, yaml)" enum:"table,json,yaml" default:"${output_format}"`
 	Workspace    string `help:"Bitbucket workspace (defaults to git remote or config)"`
 	Repository   string `help:"Repository name (defaults to git remote)"`
 }
@@ -705,10 +1351,11 @@ func (p *PRMergeCmd) Run(ctx context.Context) error {
 }
 
 type PRCheckoutCmd struct {
-	PRID       string `arg:"" help:"Pull request ID (number)"`
+	PRID       string `arg:"" optional:"" help:"Pull request ID (number); omit to pick from open pull requests"`
 	Detach     bool   `help:"Checkout in detached HEAD mode"`
 	Force      bool   `short:"f" help:"Force checkout, discarding local changes"`
-	Output     string `short:"o" help:"Output format (table, json, yaml)" enum:"table,json,yaml" default:"table"`
+	Cleanup    bool   `help:"After the PR is merged, switch to the default branch and delete its local and remote branches"`
+	Output     string `short:"o" help:"Output format (table, json, yaml)" enum:"table,json,yaml" default:"${output_format}"`
 	Workspace  string `help:"Bitbucket workspace (defaults to git remote or config)"`
 	Repository string `help:"Repository name (defaults to git remote)"`
 }
@@ -720,6 +1367,7 @@ func (p *PRCheckoutCmd) Run(ctx context.Context) error {
 		PRID:       p.PRID,
 		Detach:     p.Detach,
 		Force:      p.Force,
+		Cleanup:    p.Cleanup,
 		Output:     p.Output,
 		NoColor:    noColor,
 		Workspace:  p.Workspace,
@@ -729,25 +1377,29 @@ func (p *PRCheckoutCmd) Run(ctx context.Context) error {
 }
 
 type PRReadyCmd struct {
-	PRID       string `arg:"" help:"Pull request ID (number)"`
-	Comment    string `help:"Add a comment when marking as ready"`
-	Force      bool   `short:"f" help:"Force mark as ready without confirmation"`
-	Output     string `short:"o" help:"Output format (table, json, yaml)" enum:"table,json,yaml" default:"table"`
-	Workspace  string `help:"Bitbucket workspace (defaults to git remote or config)"`
-	Repository string `help:"Repository name (defaults to git remote)"`
+	PRID           string        `arg:"" help:"Pull request ID (number)"`
+	Comment        string        `help:"Add a comment when marking as ready"`
+	Force          bool          `short:"f" help:"Force mark as ready without confirmation"`
+	WhenChecksPass bool          `name:"when-checks-pass" help:"Wait for the pull request's checks to pass, then mark it ready; it stays a draft if any fails"`
+	Timeout        time.Duration `help:"With --when-checks-pass, how long to wait for the checks" default:"1h"`
+	Output         string        `short:"o" help:"Output format (table, json, yaml)" enum:"table,json,yaml" default:"${output_format}"`
+	Workspace      string        `help:"Bitbucket workspace (defaults to git remote or config)"`
+	Repository     string        `help:"Repository name (defaults to git remote)"`
 }
 
 func (p *PRReadyCmd) Run(ctx context.Context) error {
 	noColor := shared.GetNoColor(ctx)
 
 	cmd := &pr.ReadyCmd{
-		PRID:       p.PRID,
-		Comment:    p.Comment,
-		Force:      p.Force,
-		Output:     p.Output,
-		NoColor:    noColor,
-		Workspace:  p.Workspace,
-		Repository: p.Repository,
+		PRID:           p.PRID,
+		Comment:        p.Comment,
+		Force:          p.Force,
+		WhenChecksPass: p.WhenChecksPass,
+		Timeout:        p.Timeout,
+		Output:         p.Output,
+		NoColor:        noColor,
+		Workspace:      p.Workspace,
+		Repository:     p.Repository,
 	}
 	return cmd.Run(ctx)
 }
@@ -755,7 +1407,9 @@ func (p *PRReadyCmd) Run(ctx context.Context) error {
 type PRChecksCmd struct {
 	PRID       string `arg:"" help:"Pull request ID (number)"`
 	Watch      bool   `short:"w" help:"Watch for live updates"`
-	Output     string `short:"o" help:"Output format (table, json, yaml)" enum:"table,json,yaml" default:"table"`
+	Required   bool   `help:"Mark checks required for merge by the destination branch's restrictions"`
+	Events     string `help:"With --watch, print JSON progress events instead of text: jsonl (one event per line, for scripts and agents)"`
+	Output     string `short:"o" help:"Output format (table, json, yaml)" enum:"table,json,yaml" default:"${output_format}"`
 	Workspace  string `help:"Bitbucket workspace (defaults to git remote or config)"`
 	Repository string `help:"Repository name (defaults to git remote)"`
 }
@@ -766,6 +1420,8 @@ func (p *PRChecksCmd) Run(ctx context.Context) error {
 	cmd := &pr.ChecksCmd{
 		PRID:       p.PRID,
 		Watch:      p.Watch,
+		Required:   p.Required,
+		Events:     p.Events,
 		Output:     p.Output,
 		NoColor:    noColor,
 		Workspace:  p.Workspace,
@@ -777,9 +1433,10 @@ func (p *PRChecksCmd) Run(ctx context.Context) error {
 type PRCloseCmd struct {
 	PRID         string `arg:"" help:"Pull request ID (number)"`
 	Comment      string `short:"c" help:"Comment to add when closing the PR"`
+	Reason       string `help:"Why the PR is declined; saved as the decline reason and posted as the closing comment"`
 	DeleteBranch bool   `name:"delete-branch" help:"Delete the source branch after closing"`
 	Force        bool   `short:"f" help:"Skip confirmation prompt"`
-	Output       string `short:"o" help:"Output format (table, json, yaml)" enum:"table,json,yaml" default:"table"`
+	Output       string `short:"o" help:"Output format (table, json, yaml)" enum:"table,json,yaml" default:"${output_format}"`
 	Workspace    string `help:"Bitbucket workspace (defaults to git remote or config)"`
 	Repository   string `help:"Repository name (defaults to git remote)"`
 }
@@ -790,6 +1447,7 @@ func (p *PRCloseCmd) Run(ctx context.Context) error {
 	cmd := &pr.CloseCmd{
 		PRID:         p.PRID,
 		Comment:      p.Comment,
+		Reason:       p.Reason,
 		DeleteBranch: p.DeleteBranch,
 		Force:        p.Force,
 		Output:       p.Output,
@@ -804,7 +1462,7 @@ type PRReopenCmd struct {
 	PRID       string `arg:"" help:"Pull request ID (number)"`
 	Comment    string `short:"c" help:"Comment to add when reopening the PR"`
 	Force      bool   `short:"f" help:"Skip confirmation prompt"`
-	Output     string `short:"o" help:"Output format (table, json, yaml)" enum:"table,json,yaml" default:"table"`
+	Output     string `short:"o" help:"Output format (table, json, yaml)" enum:"table,json,yaml" default:"${output_format}"`
 	Workspace  string `help:"Bitbucket workspace (defaults to git remote or config)"`
 	Repository string `help:"Repository name (defaults to git remote)"`
 }
@@ -825,7 +1483,7 @@ func (p *PRReopenCmd) Run(ctx context.Context) error {
 }
 
 type PRStatusCmd struct {
-	Output     string `short:"o" help:"Output format (table, json, yaml)" enum:"table,json,yaml" default:"table"`
+	Output     string `short:"o" help:"Output format (table, json, yaml)" enum:"table,json,yaml" default:"${output_format}"`
 	Workspace  string `help:"Bitbucket workspace (defaults to git remote or config)"`
 	Repository string `help:"Repository name (defaults to git remote)"`
 }
@@ -845,7 +1503,7 @@ func (p *PRStatusCmd) Run(ctx context.Context) error {
 type PRUpdateBranchCmd struct {
 	PRID       string `arg:"" help:"Pull request ID (number)"`
 	Force      bool   `short:"f" help:"Force update, overriding safety checks"`
-	Output     string `short:"o" help:"Output format (table, json, yaml)" enum:"table,json,yaml" default:"table"`
+	Output     string `short:"o" help:"Output format (table, json, yaml)" enum:"table,json,yaml" default:"${output_format}"`
 	Workspace  string `help:"Bitbucket workspace (defaults to git remote or config)"`
 	Repository string `help:"Repository name (defaults to git remote)"`
 }
@@ -868,7 +1526,7 @@ type PRLockCmd struct {
 	PRID       string `arg:"" help:"Pull request ID (number)"`
 	Reason     string `help:"Reason for locking conversation (off_topic, resolved, spam, too_heated)"`
 	Force      bool   `short:"f" help:"Skip confirmation prompt"`
-	Output     string `short:"o" help:"Output format (table, json, yaml)" enum:"table,json,yaml" default:"table"`
+	Output     string `short:"o" help:"Output format (table, json, yaml)" enum:"table,json,yaml" default:"${output_format}"`
 	Workspace  string `help:"Bitbucket workspace (defaults to git remote or config)"`
 	Repository string `help:"Repository name (defaults to git remote)"`
 }
@@ -891,7 +1549,7 @@ func (p *PRLockCmd) Run(ctx context.Context) error {
 type PRUnlockCmd struct {
 	PRID       string `arg:"" help:"Pull request ID (number)"`
 	Force      bool   `short:"f" help:"Skip confirmation prompt"`
-	Output     string `short:"o" help:"Output format (table, json, yaml)" enum:"table,json,yaml" default:"table"`
+	Output     string `short:"o" help:"Output format (table, json, yaml)" enum:"table,json,yaml" default:"${output_format}"`
 	Workspace  string `help:"Bitbucket workspace (defaults to git remote or config)"`
 	Repository string `help:"Repository name (defaults to git remote)"`
 }
@@ -912,7 +1570,7 @@ func (p *PRUnlockCmd) Run(ctx context.Context) error {
 
 type PRReportCmd struct {
 	PRID              string   `arg:"" help:"Pull request ID (number)"`
-	Output            string   `short:"o" help:"Output format (table, json, yaml)" enum:"table,json,yaml" default:"table"`
+	Output            string   `short:"o" help:"Output format (table, json, yaml)" enum:"table,json,yaml" default:"${output_format}"`
 	Coverage          bool     `help:"Show only coverage-related information"`
 	Issues            bool     `help:"Show only code quality issues"`
 	Duplications      bool     `help:"Show duplicated code analysis"`
@@ -967,56 +1625,153 @@ func (p *PRReportCmd) Run(ctx context.Context) error {
 	return cmd.Run(ctx)
 }
 
-type APICmd struct{}
+type IssueCmd struct {
+	List   IssueListCmd   `cmd:"" help:"List issues of the repository's issue tracker"`
+	View   IssueViewCmd   `cmd:"" help:"Show the details of an issue"`
+	Create IssueCreateCmd `cmd:"" help:"Open a new issue"`
+}
 
-func (a *APICmd) Run(ctx context.Context) error {
-	return fmt.Errorf("api command not yet implemented")
+type IssueListCmd struct {
+	State      string `help:"Filter by state (unresolved, all, new, open, submitted, resolved, on hold, invalid, duplicate, wontfix, closed)" default:"unresolved"`
+	Assignee   string `help:"Filter by assignee (username, account_id, {uuid} or @me)"`
+	Limit      int    `help:"Maximum number of issues to show" default:"30"`
+	Output     string `short:"o" help:"Output format (table, json, yaml)" enum:"table,json,yaml" default:"${output_format}"`
+	Workspace  string `help:"Bitbucket workspace (defaults to git remote or config)"`
+	Repository string `help:"Repository name (defaults to git remote)"`
 }
 
-type BrowseCmd struct{}
+func (i *IssueListCmd) Run(ctx context.Context) error {
+	noColor := shared.GetNoColor(ctx)
 
-func (b *BrowseCmd) Run(ctx context.Context) error {
-	return fmt.Errorf("browse command not yet implemented")
+	cmd := &issue.ListCmd{
+		State:      i.State,
+		Assignee:   i.Assignee,
+		Limit:      i.Limit,
+		Output:     i.Output,
+		NoColor:    noColor,
+		Workspace:  i.Workspace,
+		Repository: i.Repository,
+	}
+	return cmd.Run(ctx)
+}
+
+type IssueViewCmd struct {
+	IssueID    string `arg:"" help:"Issue ID (number)"`
+	Web        bool   `help:"Open the issue in the browser"`
+	Output     string `short:"o" help:"Output format (table, json, yaml)" enum:"table,json,yaml" default:"${output_format}"`
+	Workspace  string `help:"Bitbucket workspace (defaults to git remote or config)"`
+	Repository string `help:"Repository name (defaults to git remote)"`
+}
+
+func (i *IssueViewCmd) Run(ctx context.Context) error {
+	noColor := shared.GetNoColor(ctx)
+
+	cmd := &issue.ViewCmd{
+		IssueID:    i.IssueID,
+		Web:        i.Web,
+		Output:     i.Output,
+		NoColor:    noColor,
+		Workspace:  i.Workspace,
+		Repository: i.Repository,
+	}
+	return cmd.Run(ctx)
+}
+
+type IssueCreateCmd struct {
+	Title      string `short:"t" required:"" help:"Title of the issue"`
+	Body       string `short:"b" help:"Body of the issue"`
+	Kind       string `help:"Kind of issue (bug, enhancement, proposal, task); defaults to bug"`
+	Priority   string `help:"Priority (trivial, minor, major, critical, blocker); defaults to major"`
+	Assignee   string `help:"Assign the issue (username, account_id, {uuid} or @me)"`
+	Output     string `short:"o" help:"Output format (table, json, yaml)" enum:"table,json,yaml" default:"${output_format}"`
+	Workspace  string `help:"Bitbucket workspace (defaults to git remote or config)"`
+	Repository string `help:"Repository name (defaults to git remote)"`
+}
+
+func (i *IssueCreateCmd) Run(ctx context.Context) error {
+	noColor := shared.GetNoColor(ctx)
+
+	cmd := &issue.CreateCmd{
+		Title:      i.Title,
+		Body:       i.Body,
+		Kind:       i.Kind,
+		Priority:   i.Priority,
+		Assignee:   i.Assignee,
+		Output:     i.Output,
+		NoColor:    noColor,
+		Workspace:  i.Workspace,
+		Repository: i.Repository,
+	}
+	return cmd.Run(ctx)
+}
+
+type APICmd struct {
+	RateLimit APIRateLimitCmd `cmd:"" name:"rate-limit" help:"Show the Bitbucket API rate limit quota of your credentials"`
+}
+
+type APIRateLimitCmd struct {
+	Output string `short:"o" help:"Output format (table, json, yaml)" enum:"table,json,yaml" default:"${output_format}"`
+}
+
+func (a *APIRateLimitCmd) Run(ctx context.Context) error {
+	noColor := shared.GetNoColor(ctx)
+
+	cmd := &api.RateLimitCmd{
+		Output:  a.Output,
+		NoColor: noColor,
+	}
+	return cmd.Run(ctx)
 }
 
 type ConfigCmd struct {
-	Get   ConfigGetCmd   `cmd:""`
-	Set   ConfigSetCmd   `cmd:""`
-	List  ConfigListCmd  `cmd:""`
-	Unset ConfigUnsetCmd `cmd:""`
+	Get    ConfigGetCmd    `cmd:"" help:"Get configuration values"`
+	Set    ConfigSetCmd    `cmd:"" help:"Set configuration values"`
+	List   ConfigListCmd   `cmd:"" help:"List configuration settings"`
+	Unset  ConfigUnsetCmd  `cmd:"" help:"Remove configuration values"`
+	Export ConfigExportCmd `cmd:"" help:"Export the settings from your config file (credentials excluded)"`
+	Import ConfigImportCmd `cmd:"" help:"Merge an exported configuration into yours"`
 }
 
 type ConfigGetCmd struct {
-	Key    string `arg:"" help:"Configuration key to retrieve (e.g., auth.default_workspace)"`
-	Output string `short:"o" help:"Output format (table, json, yaml)" enum:"table,json,yaml" default:"table"`
+	Key        string `arg:"" help:"Configuration key to retrieve (e.g., auth.default_workspace)"`
+	Output     string `short:"o" help:"Output format (table, json, yaml)" enum:"table,json,yaml" default:"${output_format}"`
+	Resolved   bool   `help:"Show the effective value and where it came from"`
+	AllSources bool   `help:"Show the value from every source and which one wins"`
 }
 
 func (c *ConfigGetCmd) Run(ctx context.Context) error {
 	noColor := shared.GetNoColor(ctx)
 
 	cmd := &config.GetCmd{
-		Key:     c.Key,
-		Output:  c.Output,
-		NoColor: noColor,
+		Key:        c.Key,
+		Output:     c.Output,
+		Resolved:   c.Resolved,
+		AllSources: c.AllSources,
+		NoColor:    noColor,
 	}
 	return cmd.Run(ctx)
 }
 
 type ConfigSetCmd struct {
-	Key   string `arg:"" help:"Configuration key to set (e.g., auth.default_workspace)"`
-	Value string `arg:"" help:"Configuration value to set"`
+	Key    string `arg:"" help:"Configuration key to set (e.g., auth.default_workspace)"`
+	Value  string `arg:"" help:"Configuration value to set"`
+	Output string `short:"o" help:"Output format (table, json, yaml)" enum:"table,json,yaml" default:"${output_format}"`
 }
 
 func (c *ConfigSetCmd) Run(ctx context.Context) error {
+	noColor := shared.GetNoColor(ctx)
+
 	cmd := &config.SetCmd{
-		Key:   c.Key,
-		Value: c.Value,
+		Key:     c.Key,
+		Value:   c.Value,
+		Output:  c.Output,
+		NoColor: noColor,
 	}
 	return cmd.Run(ctx)
 }
 
 type ConfigListCmd struct {
-	Output string `short:"o" help:"Output format (table, json, yaml)" enum:"table,json,yaml" default:"table"`
+	Output string `short:"o" help:"Output format (table, json, yaml)" enum:"table,json,yaml" default:"${output_format}"`
 }
 
 func (c *ConfigListCmd) Run(ctx context.Context) error {
@@ -1030,27 +1785,97 @@ func (c *ConfigListCmd) Run(ctx context.Context) error {
 }
 
 type ConfigUnsetCmd struct {
-	Key string `arg:"" help:"Configuration key to remove (e.g., auth.default_workspace)"`
+	Key    string `arg:"" help:"Configuration key to remove (e.g., auth.default_workspace)"`
+	Output string `short:"o" help:"Output format (table, json, yaml)" enum:"table,json,yaml" default:"${output_format}"`
 }
 
 func (c *ConfigUnsetCmd) Run(ctx context.Context) error {
+	noColor := shared.GetNoColor(ctx)
+
 	cmd := &config.UnsetCmd{
-		Key: c.Key,
+		Key:     c.Key,
+		Output:  c.Output,
+		NoColor: noColor,
+	}
+	return cmd.Run(ctx)
+}
+
+type ConfigExportCmd struct {
+	File string `short:"f" help:"Write to this file instead of stdout"`
+}
+
+func (c *ConfigExportCmd) Run(ctx context.Context) error {
+	cmd := &config.ExportCmd{
+		File: c.File,
 	}
 	return cmd.Run(ctx)
 }
 
-type StatusCmd struct{}
+// ConfigImportCmd previews its changes with the global --dry-run flag
+type ConfigImportCmd struct {
+	File string `arg:"" help:"Configuration file written by 'bt config export'"`
+	Yes  bool   `short:"y" help:"Apply without asking for confirmation"`
+}
+
+func (c *ConfigImportCmd) Run(ctx context.Context) error {
+	cmd := &config.ImportCmd{
+		File:   c.File,
+		DryRun: shared.GetDryRun(ctx),
+		Yes:    c.Yes,
+	}
+	return cmd.Run(ctx)
+}
+
+type AliasCmd struct {
+	Set    AliasSetCmd    `cmd:"" help:"Create or change a command alias"`
+	List   AliasListCmd   `cmd:"" help:"List command aliases"`
+	Delete AliasDeleteCmd `cmd:"" help:"Delete a command alias"`
+}
+
+type AliasSetCmd struct {
+	Name      string `arg:"" help:"Alias name, used as 'bt <name>'"`
+	Expansion string `arg:"" help:"Command the alias runs, without 'bt'; $1..$9 and $@ take the arguments given to the alias"`
+}
+
+func (a *AliasSetCmd) Run(ctx context.Context, kctx *kong.Context) error {
+	cmd := &alias.SetCmd{
+		Name:      a.Name,
+		Expansion: a.Expansion,
+		Builtins:  alias.CommandNames(kctx.Model.Node),
+	}
+	return cmd.Run(ctx)
+}
 
-func (s *StatusCmd) Run(ctx context.Context) error {
-	return fmt.Errorf("status command not yet implemented")
+type AliasListCmd struct {
+	Output string `short:"o" help:"Output format (table, json, yaml)" enum:"table,json,yaml" default:"${output_format}"`
+}
+
+func (a *AliasListCmd) Run(ctx context.Context) error {
+	noColor := shared.GetNoColor(ctx)
+
+	cmd := &alias.ListCmd{
+		Output:  a.Output,
+		NoColor: noColor,
+	}
+	return cmd.Run(ctx)
+}
+
+type AliasDeleteCmd struct {
+	Name string `arg:"" help:"Alias to delete"`
+}
+
+func (a *AliasDeleteCmd) Run(ctx context.Context) error {
+	cmd := &alias.DeleteCmd{
+		Name: a.Name,
+	}
+	return cmd.Run(ctx)
 }
 
 type SkillCmd struct {
-	Add    SkillAddCmd    `cmd:""`
-	Update SkillUpdateCmd `cmd:""`
-	Remove SkillRemoveCmd `cmd:""`
-	Status SkillStatusCmd `cmd:""`
+	Add    SkillAddCmd    `cmd:"" help:"Install the bt skill and link to detected AI agents"`
+	Update SkillUpdateCmd `cmd:"" help:"Update the bt skill to the latest version"`
+	Remove SkillRemoveCmd `cmd:"" help:"Remove the bt skill and unlink from all agents"`
+	Status SkillStatusCmd `cmd:"" help:"Show skill installation and version info"`
 }
 
 type SkillAddCmd struct {
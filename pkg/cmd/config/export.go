@@ -0,0 +1,45 @@
+package config
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	yamlv3 "gopkg.in/yaml.v3"
+)
+
+// ExportCmd handles the config export command
+type ExportCmd struct {
+	File string
+}
+
+// Run executes the config export command
+func (cmd *ExportCmd) Run(ctx context.Context) error {
+	cm, err := newExportConfigManager()
+	if err != nil {
+		return err
+	}
+
+	values, err := sanitizedConfig(cm.config)
+	if err != nil {
+		return err
+	}
+	keepKeys(values, "", cm.loader.InConfigFile)
+
+	data, err := yamlv3.Marshal(values)
+	if err != nil {
+		return fmt.Errorf("failed to encode configuration: %w", err)
+	}
+
+	if cmd.File == "" {
+		_, err := os.Stdout.Write(data)
+		return err
+	}
+
+	if err := os.WriteFile(cmd.File, data, 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", cmd.File, err)
+	}
+
+	fmt.Printf("✓ Exported configuration to %s\n", cmd.File)
+	return nil
+}
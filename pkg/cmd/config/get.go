@@ -3,13 +3,19 @@ package config
 import (
 	"context"
 	"fmt"
+
+	"github.com/carlosarraes/bt/pkg/cmd/shared"
+	"github.com/carlosarraes/bt/pkg/config"
+	"github.com/carlosarraes/bt/pkg/git"
 )
 
 // GetCmd handles the config get command
 type GetCmd struct {
-	Key     string `arg:"" help:"Configuration key to retrieve (e.g., auth.default_workspace)"`
-	Output  string `short:"o" help:"Output format (table, json, yaml)" enum:"table,json,yaml" default:"table"`
-	NoColor bool   // Passed from global flag
+	Key        string `arg:"" help:"Configuration key to retrieve (e.g., auth.default_workspace)"`
+	Output     string `short:"o" help:"Output format (table, json, yaml)" enum:"table,json,yaml" default:"table"`
+	Resolved   bool   `help:"Show the effective value and where it came from"`
+	AllSources bool   `help:"Show the value from every source and which one wins"`
+	NoColor    bool   // Passed from global flag
 }
 
 // Run executes the config get command
@@ -20,6 +26,16 @@ func (cmd *GetCmd) Run(ctx context.Context) error {
 		return err
 	}
 
+	if cmd.Resolved && cmd.AllSources {
+		return fmt.Errorf("--resolved and --all-sources cannot be used together")
+	}
+	if cmd.Resolved {
+		return cmd.runResolved(cm)
+	}
+	if cmd.AllSources {
+		return cmd.runAllSources(cm)
+	}
+
 	// Get the value
 	value, err := cm.GetValue(cmd.Key)
 	if err != nil {
@@ -77,3 +93,133 @@ func (cmd *GetCmd) formatYAML(value interface{}) error {
 
 	return formatter.Format(result)
 }
+
+// runResolved shows the effective value of a key after environment and git
+// context have been applied, together with its source.
+func (cmd *GetCmd) runResolved(cm *ConfigManager) error {
+	if cmd.Key != "auth.default_workspace" {
+		return fmt.Errorf("--resolved is only supported for auth.default_workspace")
+	}
+
+	gitRepo, err := git.NewRepository("")
+	if err != nil {
+		gitRepo = nil
+	}
+
+	resolved := shared.NewRepoResolver(cm.config, gitRepo).Workspace("")
+
+	switch cmd.Output {
+	case "json", "yaml":
+		formatter, err := createFormatter(cmd.Output, cmd.NoColor)
+		if err != nil {
+			return err
+		}
+		return formatter.Format(map[string]interface{}{
+			"key":    cmd.Key,
+			"value":  resolved.Value,
+			"source": resolved.Source,
+		})
+	default:
+		if resolved.Source == shared.SourceUnset {
+			fmt.Printf("%s: %s\n", cmd.Key, formatValue(resolved.Value))
+			return nil
+		}
+		fmt.Printf("%s: %s (from %s)\n", cmd.Key, resolved.Value, resolved.Source)
+		return nil
+	}
+}
+
+// runAllSources shows the value every source gives the key, highest
+// precedence first, and which one wins. For auth.default_workspace the
+// environment and git remote come first, as they do for --resolved.
+func (cmd *GetCmd) runAllSources(cm *ConfigManager) error {
+	if _, err := cm.GetValue(cmd.Key); err != nil {
+		return err
+	}
+
+	origins, err := cm.loader.Provenance(cmd.Key)
+	if err != nil {
+		return err
+	}
+	if cmd.Key == "auth.default_workspace" {
+		origins = append(workspaceOrigins(cm), origins...)
+		config.MarkWinner(origins)
+	}
+
+	var winner *config.Origin
+	for i := range origins {
+		if origins[i].Wins {
+			winner = &origins[i]
+			break
+		}
+	}
+
+	switch cmd.Output {
+	case "json", "yaml":
+		formatter, err := createFormatter(cmd.Output, cmd.NoColor)
+		if err != nil {
+			return err
+		}
+		result := map[string]interface{}{
+			"key":     cmd.Key,
+			"value":   nil,
+			"source":  shared.SourceUnset,
+			"sources": origins,
+		}
+		if winner != nil {
+			result["value"] = winner.Value
+			result["source"] = winner.Source
+		}
+		return formatter.Format(result)
+	default:
+		if winner == nil {
+			fmt.Printf("%s: %s\n", cmd.Key, formatValue(""))
+		} else {
+			fmt.Printf("%s: %s (from %s)\n", cmd.Key, formatValue(winner.Value), winner.Source)
+		}
+		fmt.Println()
+		printOrigins(origins)
+		return nil
+	}
+}
+
+// workspaceOrigins are the sources the workspace resolver checks ahead of
+// auth.default_workspace; --workspace doesn't apply to config get
+func workspaceOrigins(cm *ConfigManager) []config.Origin {
+	gitRepo, err := git.NewRepository("")
+	if err != nil {
+		gitRepo = nil
+	}
+
+	var origins []config.Origin
+	for _, source := range shared.NewRepoResolver(cm.config, gitRepo).WorkspaceSources("") {
+		switch source.Source {
+		case shared.SourceEnv:
+			origins = append(origins, config.Origin{Source: config.SourceEnv, Name: shared.EnvWorkspace, Value: source.Value, Set: source.Value != ""})
+		case shared.SourceGitRemote:
+			origins = append(origins, config.Origin{Source: config.SourceGitRemote, Value: source.Value, Set: source.Value != ""})
+		}
+	}
+	return origins
+}
+
+// printOrigins lists the sources in aligned columns, marking the winner
+func printOrigins(origins []config.Origin) {
+	sourceWidth, nameWidth := 0, 0
+	for _, origin := range origins {
+		sourceWidth = max(sourceWidth, len(origin.Source))
+		nameWidth = max(nameWidth, len(origin.Name))
+	}
+
+	for _, origin := range origins {
+		marker := " "
+		if origin.Wins {
+			marker = "✓"
+		}
+		value := "(not set)"
+		if origin.Set {
+			value = formatValue(origin.Value)
+		}
+		fmt.Printf("%s %-*s  %-*s  %s\n", marker, sourceWidth, origin.Source, nameWidth, origin.Name, value)
+	}
+}
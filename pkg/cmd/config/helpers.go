@@ -19,7 +19,23 @@ type ConfigManager struct {
 
 // NewConfigManager creates a new config manager
 func NewConfigManager() (*ConfigManager, error) {
-	loader := config.NewLoader()
+	return newConfigManager(config.NewLoader())
+}
+
+// NewGlobalConfigManager creates a config manager for the global config file
+// alone, leaving out the repository's .bt.yml. Commands that save the
+// configuration use it.
+func NewGlobalConfigManager() (*ConfigManager, error) {
+	return newConfigManager(config.NewLoader().WithoutRepoConfig())
+}
+
+// newExportConfigManager creates a config manager for what the user's own
+// config file says, without the repository's .bt.yml or BT_ variables
+func newExportConfigManager() (*ConfigManager, error) {
+	return newConfigManager(config.NewLoader().WithoutRepoConfig().WithoutEnv())
+}
+
+func newConfigManager(loader *config.Loader) (*ConfigManager, error) {
 	cfg, err := loader.Load()
 	if err != nil {
 		return nil, fmt.Errorf("failed to load config: %w", err)
@@ -33,6 +49,16 @@ func NewConfigManager() (*ConfigManager, error) {
 
 // GetValue retrieves a configuration value by key (supports nested keys like "auth.default_workspace")
 func (cm *ConfigManager) GetValue(key string) (interface{}, error) {
+	if repository, ok, err := repoSettingKey(key); ok || err != nil {
+		if err != nil {
+			return nil, err
+		}
+		return cm.config.Repos[repository].Base, nil
+	}
+	if alias, ok := stepAliasKey(key); ok {
+		return cm.config.Run.StepAliases[alias], nil
+	}
+
 	parts := strings.Split(key, ".")
 	value := reflect.ValueOf(cm.config).Elem()
 
@@ -59,8 +85,37 @@ func (cm *ConfigManager) GetValue(key string) (interface{}, error) {
 	return value.Interface(), nil
 }
 
-// SetValue sets a configuration value by key with validation
+// SetValue sets a configuration value by key with validation. Save writes
+// the key even when the value is its default.
 func (cm *ConfigManager) SetValue(key, valueStr string) error {
+	if err := cm.setValue(key, valueStr); err != nil {
+		return err
+	}
+	if cm.loader != nil {
+		cm.loader.MarkSet(key)
+	}
+	return nil
+}
+
+func (cm *ConfigManager) setValue(key, valueStr string) error {
+	if repository, ok, err := repoSettingKey(key); ok || err != nil {
+		if err != nil {
+			return err
+		}
+		if cm.config.Repos == nil {
+			cm.config.Repos = make(map[string]config.RepoSettings)
+		}
+		cm.config.Repos[repository] = config.RepoSettings{Base: valueStr}
+		return nil
+	}
+	if alias, ok := stepAliasKey(key); ok {
+		if cm.config.Run.StepAliases == nil {
+			cm.config.Run.StepAliases = make(map[string]string)
+		}
+		cm.config.Run.StepAliases[alias] = valueStr
+		return nil
+	}
+
 	parts := strings.Split(key, ".")
 	value := reflect.ValueOf(cm.config).Elem()
 
@@ -101,10 +156,34 @@ func (cm *ConfigManager) SetValue(key, valueStr string) error {
 	return nil
 }
 
-// UnsetValue removes a configuration value by setting it to its zero value
+// UnsetValue removes a configuration value, setting it back to its default.
+// Save leaves the key out of the file, so its default applies again.
 func (cm *ConfigManager) UnsetValue(key string) error {
+	if err := cm.unsetValue(key); err != nil {
+		return err
+	}
+	if cm.loader != nil {
+		cm.loader.MarkUnset(key)
+	}
+	return nil
+}
+
+func (cm *ConfigManager) unsetValue(key string) error {
+	if repository, ok, err := repoSettingKey(key); ok || err != nil {
+		if err != nil {
+			return err
+		}
+		delete(cm.config.Repos, repository)
+		return nil
+	}
+	if alias, ok := stepAliasKey(key); ok {
+		delete(cm.config.Run.StepAliases, alias)
+		return nil
+	}
+
 	parts := strings.Split(key, ".")
 	value := reflect.ValueOf(cm.config).Elem()
+	defaultValue := reflect.ValueOf(config.NewDefaultConfig()).Elem()
 
 	// Navigate to the parent of the target field
 	for i, part := range parts[:len(parts)-1] {
@@ -116,6 +195,7 @@ func (cm *ConfigManager) UnsetValue(key string) error {
 		}
 
 		value = field
+		defaultValue = defaultValue.FieldByName(fieldName)
 	}
 
 	// Unset the final field
@@ -130,8 +210,9 @@ func (cm *ConfigManager) UnsetValue(key string) error {
 		return fmt.Errorf("configuration key is read-only: %s", key)
 	}
 
-	// Set to zero value
-	field.Set(reflect.Zero(field.Type()))
+	// Back to the default value, which applies once the key is gone from the
+	// config file
+	field.Set(defaultValue.FieldByName(finalFieldName))
 
 	// Validate the updated configuration
 	if err := cm.config.Validate(); err != nil {
@@ -141,6 +222,11 @@ func (cm *ConfigManager) UnsetValue(key string) error {
 	return nil
 }
 
+// InConfigFile reports whether the config file sets key
+func (cm *ConfigManager) InConfigFile(key string) bool {
+	return cm.loader != nil && cm.loader.InConfigFile(key)
+}
+
 // Save saves the current configuration to file
 func (cm *ConfigManager) Save() error {
 	return cm.loader.Save(cm.config)
@@ -157,22 +243,75 @@ func (cm *ConfigManager) GetAllValues() map[string]interface{} {
 	// API section
 	result["api.base_url"] = cm.config.API.BaseURL
 	result["api.timeout"] = cm.config.API.Timeout.String()
+	result["api.max_idle_conns_per_host"] = cm.config.API.MaxIdleConnsPerHost
+	result["api.idle_conn_timeout"] = cm.config.API.IdleConnTimeout.String()
 
 	// Defaults section
 	result["defaults.output_format"] = cm.config.Defaults.OutputFormat
 
 	result["llm.model"] = cm.config.LLM.Model
 
+	result["pr.base"] = cm.config.PR.Base
+	result["pr.checklist.section"] = cm.config.PR.Checklist.Section
+	result["pr.checklist.items"] = strings.Join(cm.config.PR.Checklist.Items, ",")
+	result["pr.commit_lint.enabled"] = cm.config.PR.CommitLint.Enabled
+	result["pr.commit_lint.mode"] = cm.config.PR.CommitLint.Mode
+	result["pr.commit_lint.pattern"] = cm.config.PR.CommitLint.Pattern
+	result["pr.commit_lint.types"] = strings.Join(cm.config.PR.CommitLint.Types, ",")
+	result["pr.commit_lint.max_subject_length"] = cm.config.PR.CommitLint.MaxSubjectLength
+	result["pr.size_thresholds.xs"] = cm.config.PR.SizeThresholds.XS
+	result["pr.size_thresholds.s"] = cm.config.PR.SizeThresholds.S
+	result["pr.size_thresholds.m"] = cm.config.PR.SizeThresholds.M
+	result["pr.size_thresholds.l"] = cm.config.PR.SizeThresholds.L
+	result["pr.max_size"] = cm.config.PR.MaxSize
+	result["pr.create_as_draft"] = cm.config.PR.CreateAsDraft
+
 	result["pick.prefix"] = strings.Join(cm.config.Pick.Prefix, ",")
 	result["pick.suffix_prd"] = cm.config.Pick.SuffixPrd
 	result["pick.suffix_hml"] = cm.config.Pick.SuffixHml
 
+	result["ui.max_width"] = cm.config.UI.MaxWidth
+
+	result["jira.base_url"] = cm.config.Jira.BaseURL
+
+	result["run.redact_patterns"] = strings.Join(cm.config.Run.RedactPatterns, ",")
+
+	for alias, pattern := range cm.config.Run.StepAliases {
+		result["run.step_aliases."+alias] = pattern
+	}
+
+	for repository, settings := range cm.config.Repos {
+		result["repo."+repository+".base"] = settings.Base
+	}
+
 	// Version
 	result["version"] = cm.config.Version
 
 	return result
 }
 
+// repoSettingKey recognizes the keys of per-repository settings,
+// repo.<name>.base, returning the repository name. The name is everything
+// between the prefix and the setting, so it may contain dots.
+func repoSettingKey(key string) (string, bool, error) {
+	rest, ok := strings.CutPrefix(key, "repo.")
+	if !ok {
+		return "", false, nil
+	}
+	repository, ok := strings.CutSuffix(rest, ".base")
+	if !ok || repository == "" {
+		return "", false, fmt.Errorf("configuration key not found: %s (per-repository keys look like repo.<name>.base)", key)
+	}
+	return repository, true, nil
+}
+
+// stepAliasKey recognizes the keys of step aliases, run.step_aliases.<alias>,
+// returning the alias
+func stepAliasKey(key string) (string, bool) {
+	alias, ok := strings.CutPrefix(key, "run.step_aliases.")
+	return alias, ok && alias != ""
+}
+
 // setFieldValue sets a reflect.Value field from a string
 func setFieldValue(field reflect.Value, valueStr string) error {
 	switch field.Kind() {
@@ -202,6 +341,8 @@ func setFieldValue(field reflect.Value, valueStr string) error {
 	case reflect.Slice:
 		if field.Type() == reflect.TypeOf(config.Prefixes{}) {
 			field.Set(reflect.ValueOf(config.ParsePrefixes(valueStr)))
+		} else if field.Type() == reflect.TypeOf([]string{}) {
+			field.Set(reflect.ValueOf([]string(config.ParsePrefixes(valueStr))))
 		} else {
 			return fmt.Errorf("unsupported slice type: %s", field.Type())
 		}
@@ -235,10 +376,16 @@ func toCamelCase(s string) string {
 		return "OutputFormat"
 	case "llm":
 		return "LLM"
+	case "pr":
+		return "PR"
 	case "model":
 		return "Model"
 	case "pick":
 		return "Pick"
+	case "ui":
+		return "UI"
+	case "xs":
+		return "XS"
 	case "prefix":
 		return "Prefix"
 	case "suffix_prd":
@@ -273,6 +420,60 @@ func formatValue(v interface{}) string {
 	}
 }
 
+// configChangeResult is what config set and unset print with -o json or
+// yaml: the key with its value before and after, so that scripts can check
+// the change and roll it back
+type configChangeResult struct {
+	Key      string `json:"key" yaml:"key"`
+	OldValue string `json:"old_value" yaml:"old_value"`
+	NewValue string `json:"new_value" yaml:"new_value"`
+	// Status is updated, or unchanged when the value was already the same;
+	// config unset reports removed when the config file held the key
+	Status string `json:"status" yaml:"status"`
+}
+
+func newConfigChangeResult(key string, oldValue, newValue interface{}) configChangeResult {
+	result := configChangeResult{
+		Key:      key,
+		OldValue: rawValue(oldValue),
+		NewValue: rawValue(newValue),
+		Status:   "updated",
+	}
+	if result.OldValue == result.NewValue {
+		result.Status = "unchanged"
+	}
+	return result
+}
+
+// rawValue formats a value as config set takes it: durations like 30s and
+// lists comma-separated, with unset values empty
+func rawValue(v interface{}) string {
+	if v == nil {
+		return ""
+	}
+	if d, ok := v.(time.Duration); ok {
+		return d.String()
+	}
+	value := reflect.ValueOf(v)
+	if value.Kind() == reflect.Slice && value.Type().Elem().Kind() == reflect.String {
+		items := make([]string, value.Len())
+		for i := range items {
+			items[i] = value.Index(i).String()
+		}
+		return strings.Join(items, ",")
+	}
+	return fmt.Sprintf("%v", v)
+}
+
+// formatChange prints the result of config set or unset in json or yaml
+func formatChange(format string, noColor bool, result configChangeResult) error {
+	formatter, err := createFormatter(format, noColor)
+	if err != nil {
+		return err
+	}
+	return formatter.Format(result)
+}
+
 // createFormatter creates an output formatter
 func createFormatter(format string, noColor bool) (output.Formatter, error) {
 	opts := &output.FormatterOptions{
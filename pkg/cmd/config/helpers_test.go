@@ -0,0 +1,73 @@
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/carlosarraes/bt/pkg/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfigManager_RepositoryBase(t *testing.T) {
+	cm := &ConfigManager{config: config.NewDefaultConfig()}
+
+	value, err := cm.GetValue("repo.api.base")
+	require.NoError(t, err)
+	assert.Equal(t, "", value)
+
+	require.NoError(t, cm.SetValue("repo.api.base", "develop"))
+	value, err = cm.GetValue("repo.api.base")
+	require.NoError(t, err)
+	assert.Equal(t, "develop", value)
+	assert.Equal(t, "develop", cm.GetAllValues()["repo.api.base"])
+
+	require.NoError(t, cm.UnsetValue("repo.api.base"))
+	assert.NotContains(t, cm.config.Repos, "api")
+	assert.NotContains(t, cm.GetAllValues(), "repo.api.base")
+
+	require.NoError(t, cm.SetValue("repo.my.repo.base", "develop"))
+	assert.Equal(t, "develop", cm.config.Repos["my.repo"].Base, "repository names may contain dots")
+
+	for _, key := range []string{"repo.api", "repo.api.merge", "repo..base"} {
+		assert.Error(t, cm.SetValue(key, "develop"), key)
+	}
+}
+
+func TestConfigManager_StepAliases(t *testing.T) {
+	cm := &ConfigManager{config: config.NewDefaultConfig()}
+
+	require.NoError(t, cm.SetValue("run.step_aliases.ut", "Build and Run Unit Tests*"))
+	value, err := cm.GetValue("run.step_aliases.ut")
+	require.NoError(t, err)
+	assert.Equal(t, "Build and Run Unit Tests*", value)
+	assert.Equal(t, "Build and Run Unit Tests*", cm.GetAllValues()["run.step_aliases.ut"])
+
+	require.NoError(t, cm.UnsetValue("run.step_aliases.ut"))
+	assert.NotContains(t, cm.config.Run.StepAliases, "ut")
+	assert.NotContains(t, cm.GetAllValues(), "run.step_aliases.ut")
+}
+
+func TestNewConfigChangeResult(t *testing.T) {
+	result := newConfigChangeResult("api.timeout", 30*time.Second, 60*time.Second)
+	assert.Equal(t, configChangeResult{Key: "api.timeout", OldValue: "30s", NewValue: "1m0s", Status: "updated"}, result)
+
+	result = newConfigChangeResult("pick.prefix", config.Prefixes{"feat", "fix"}, config.Prefixes{"feat", "fix"})
+	assert.Equal(t, "feat,fix", result.OldValue)
+	assert.Equal(t, "unchanged", result.Status)
+
+	result = newConfigChangeResult("pr.max_size", 0, 400)
+	assert.Equal(t, "0", result.OldValue)
+	assert.Equal(t, "400", result.NewValue)
+
+	result = newConfigChangeResult("pr.base", nil, "develop")
+	assert.Equal(t, "", result.OldValue)
+}
+
+func TestConfigManager_UnsetRestoresDefault(t *testing.T) {
+	cm := &ConfigManager{config: config.NewDefaultConfig()}
+
+	require.NoError(t, cm.SetValue("api.timeout", "60s"))
+	require.NoError(t, cm.UnsetValue("api.timeout"))
+	assert.Equal(t, 30*time.Second, cm.config.API.Timeout)
+}
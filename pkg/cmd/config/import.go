@@ -0,0 +1,108 @@
+package config
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// ImportCmd handles the config import command
+type ImportCmd struct {
+	File   string
+	DryRun bool
+	Yes    bool
+}
+
+// Run executes the config import command
+func (cmd *ImportCmd) Run(ctx context.Context) error {
+	data, err := os.ReadFile(cmd.File)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", cmd.File, err)
+	}
+
+	cm, err := NewGlobalConfigManager()
+	if err != nil {
+		return err
+	}
+
+	merged, skipped, err := mergeConfig(cm.config, data)
+	if err != nil {
+		return err
+	}
+
+	for _, key := range skipped {
+		fmt.Printf("⚠️  Skipping %s: auth settings can't be imported\n", key)
+	}
+
+	changes, err := diffConfigs(cm.config, merged)
+	if err != nil {
+		return err
+	}
+
+	if len(changes) == 0 {
+		fmt.Println("✓ Configuration already up to date")
+		return nil
+	}
+
+	fmt.Printf("Changes from %s:\n", cmd.File)
+	printChanges(changes)
+	warnShellAliases(changes)
+
+	if cmd.DryRun {
+		return nil
+	}
+
+	if !cmd.Yes && !confirmImport() {
+		fmt.Println("❌ Import cancelled")
+		return nil
+	}
+
+	cm.config = merged
+	if err := cm.Save(); err != nil {
+		return fmt.Errorf("failed to save configuration: %w", err)
+	}
+
+	fmt.Printf("✓ Imported %d setting(s) from %s\n", len(changes), cmd.File)
+	return nil
+}
+
+func printChanges(changes []configChange) {
+	for _, change := range changes {
+		switch {
+		case change.Old == "":
+			fmt.Printf("  + %s: %s\n", change.Key, change.New)
+		case change.New == "":
+			fmt.Printf("  - %s: %s\n", change.Key, change.Old)
+		default:
+			fmt.Printf("  ~ %s: %s → %s\n", change.Key, change.Old, change.New)
+		}
+	}
+}
+
+// warnShellAliases points out imported aliases that run shell commands,
+// which can do anything the user can
+func warnShellAliases(changes []configChange) {
+	for _, change := range changes {
+		if script, ok := strings.CutPrefix(change.New, "!"); ok && strings.HasPrefix(change.Key, "aliases.") {
+			fmt.Printf("⚠️  %s runs a shell command with your privileges: %s\n", change.Key, script)
+		}
+	}
+}
+
+func confirmImport() bool {
+	fmt.Print("Apply these changes? [y/N]: ")
+
+	input, err := bufio.NewReader(os.Stdin).ReadString('\n')
+	if err != nil {
+		return false
+	}
+
+	switch strings.ToLower(strings.TrimSpace(input)) {
+	case "y", "yes":
+		return true
+	default:
+		return false
+	}
+}
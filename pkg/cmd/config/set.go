@@ -7,18 +7,24 @@ import (
 
 // SetCmd handles the config set command
 type SetCmd struct {
-	Key   string `arg:"" help:"Configuration key to set (e.g., auth.default_workspace)"`
-	Value string `arg:"" help:"Configuration value to set"`
+	Key     string `arg:"" help:"Configuration key to set (e.g., auth.default_workspace)"`
+	Value   string `arg:"" help:"Configuration value to set"`
+	Output  string `short:"o" help:"Output format (table, json, yaml)" enum:"table,json,yaml" default:"table"`
+	NoColor bool   // Passed from global flag
 }
 
 // Run executes the config set command
 func (cmd *SetCmd) Run(ctx context.Context) error {
 	// Create config manager
-	cm, err := NewConfigManager()
+	cm, err := NewGlobalConfigManager()
 	if err != nil {
 		return err
 	}
 
+	// Keep the prior value for the confirmation; an invalid key is
+	// reported by SetValue
+	oldValue, _ := cm.GetValue(cmd.Key)
+
 	// Set the value
 	if err := cm.SetValue(cmd.Key, cmd.Value); err != nil {
 		return err
@@ -29,7 +35,15 @@ func (cmd *SetCmd) Run(ctx context.Context) error {
 		return fmt.Errorf("failed to save configuration: %w", err)
 	}
 
+	newValue, err := cm.GetValue(cmd.Key)
+	if err != nil {
+		return err
+	}
+
 	// Confirm the change
-	fmt.Printf("✓ Set %s to %s\n", cmd.Key, cmd.Value)
-	return nil
+	if cmd.Output == "table" || cmd.Output == "" {
+		fmt.Printf("✓ Set %s to %s\n", cmd.Key, cmd.Value)
+		return nil
+	}
+	return formatChange(cmd.Output, cmd.NoColor, newConfigChangeResult(cmd.Key, oldValue, newValue))
 }
@@ -0,0 +1,209 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/carlosarraes/bt/pkg/config"
+	yamlv3 "gopkg.in/yaml.v3"
+)
+
+// configChange is one key an import would change
+type configChange struct {
+	Key string
+	Old string
+	New string
+}
+
+// sanitizedConfig returns cfg as a nested map without the protected keys
+func sanitizedConfig(cfg *config.Config) (map[string]interface{}, error) {
+	data, err := yamlv3.Marshal(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode configuration: %w", err)
+	}
+
+	var values map[string]interface{}
+	if err := yamlv3.Unmarshal(data, &values); err != nil {
+		return nil, fmt.Errorf("failed to encode configuration: %w", err)
+	}
+
+	for _, key := range config.RepoProtectedKeys {
+		deleteKey(values, strings.Split(key, "."))
+	}
+	return values, nil
+}
+
+// keepKeys prunes values down to the keys keep accepts, so an export holds
+// only what the user set rather than every default. The repo section is kept
+// or dropped whole, as repository names may contain dots.
+func keepKeys(values map[string]interface{}, prefix string, keep func(key string) bool) {
+	for name, value := range values {
+		key := name
+		if prefix != "" {
+			key = prefix + "." + name
+		}
+
+		child, ok := value.(map[string]interface{})
+		switch {
+		case ok && key != "repo":
+			keepKeys(child, key, keep)
+			if len(child) == 0 {
+				delete(values, name)
+			}
+		case !keep(key):
+			delete(values, name)
+		}
+	}
+}
+
+func deleteKey(values map[string]interface{}, path []string) {
+	if len(path) == 1 {
+		delete(values, path[0])
+		return
+	}
+	if child, ok := values[path[0]].(map[string]interface{}); ok {
+		deleteKey(child, path[1:])
+		if len(child) == 0 {
+			delete(values, path[0])
+		}
+	}
+}
+
+// mergeConfig applies an exported config on top of current and returns the
+// result, leaving current untouched. Unknown keys are rejected, protected
+// keys are skipped and reported, and the result must pass validation.
+func mergeConfig(current *config.Config, data []byte) (*config.Config, []string, error) {
+	var incoming map[string]interface{}
+	if err := yamlv3.Unmarshal(data, &incoming); err != nil {
+		return nil, nil, fmt.Errorf("invalid configuration file: %w", err)
+	}
+
+	var skipped []string
+	for _, key := range config.RepoProtectedKeys {
+		if hasKey(incoming, strings.Split(key, ".")) {
+			skipped = append(skipped, key)
+			deleteKey(incoming, strings.Split(key, "."))
+		}
+	}
+
+	merged, err := copyConfig(current)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	sanitized, err := yamlv3.Marshal(incoming)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid configuration file: %w", err)
+	}
+
+	decoder := yamlv3.NewDecoder(bytes.NewReader(sanitized))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(merged); err != nil && !errors.Is(err, io.EOF) {
+		return nil, nil, fmt.Errorf("invalid configuration file: %w", err)
+	}
+
+	if err := merged.Validate(); err != nil {
+		return nil, nil, fmt.Errorf("invalid configuration: %w", err)
+	}
+
+	return merged, skipped, nil
+}
+
+func hasKey(values map[string]interface{}, path []string) bool {
+	value, ok := values[path[0]]
+	if !ok || len(path) == 1 {
+		return ok
+	}
+	child, ok := value.(map[string]interface{})
+	return ok && hasKey(child, path[1:])
+}
+
+// copyConfig deep-copies a config so merging maps doesn't alias the original
+func copyConfig(cfg *config.Config) (*config.Config, error) {
+	data, err := yamlv3.Marshal(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to copy configuration: %w", err)
+	}
+
+	var copied config.Config
+	if err := yamlv3.Unmarshal(data, &copied); err != nil {
+		return nil, fmt.Errorf("failed to copy configuration: %w", err)
+	}
+	return &copied, nil
+}
+
+// diffConfigs lists every flattened key whose value differs, sorted by key
+func diffConfigs(before, after *config.Config) ([]configChange, error) {
+	oldValues, err := flattenConfig(before)
+	if err != nil {
+		return nil, err
+	}
+	newValues, err := flattenConfig(after)
+	if err != nil {
+		return nil, err
+	}
+
+	keys := make(map[string]bool)
+	for key := range oldValues {
+		keys[key] = true
+	}
+	for key := range newValues {
+		keys[key] = true
+	}
+
+	var changes []configChange
+	for key := range keys {
+		if oldValues[key] != newValues[key] {
+			changes = append(changes, configChange{Key: key, Old: oldValues[key], New: newValues[key]})
+		}
+	}
+
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].Key < changes[j].Key
+	})
+	return changes, nil
+}
+
+// flattenConfig maps dotted keys (pr.branch_suffix_mapping.hml) to values
+func flattenConfig(cfg *config.Config) (map[string]string, error) {
+	data, err := yamlv3.Marshal(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode configuration: %w", err)
+	}
+
+	var values map[string]interface{}
+	if err := yamlv3.Unmarshal(data, &values); err != nil {
+		return nil, fmt.Errorf("failed to encode configuration: %w", err)
+	}
+
+	result := make(map[string]string)
+	flattenInto(result, "", values)
+	return result, nil
+}
+
+func flattenInto(result map[string]string, prefix string, values map[string]interface{}) {
+	for key, value := range values {
+		if prefix != "" {
+			key = prefix + "." + key
+		}
+
+		switch v := value.(type) {
+		case map[string]interface{}:
+			flattenInto(result, key, v)
+		case []interface{}:
+			items := make([]string, len(v))
+			for i, item := range v {
+				items[i] = fmt.Sprintf("%v", item)
+			}
+			result[key] = strings.Join(items, ",")
+		case nil:
+			result[key] = ""
+		default:
+			result[key] = fmt.Sprintf("%v", v)
+		}
+	}
+}
@@ -0,0 +1,144 @@
+package config
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/carlosarraes/bt/pkg/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	yamlv3 "gopkg.in/yaml.v3"
+)
+
+func TestSanitizedConfig_ExcludesProtectedKeys(t *testing.T) {
+	cfg := config.NewDefaultConfig()
+	cfg.Auth.DefaultWorkspace = "team"
+
+	values, err := sanitizedConfig(cfg)
+	require.NoError(t, err)
+
+	auth := values["auth"].(map[string]interface{})
+	assert.Equal(t, "team", auth["default_workspace"])
+	assert.NotContains(t, auth, "method")
+
+	api := values["api"].(map[string]interface{})
+	assert.NotContains(t, api, "base_url")
+	assert.Contains(t, api, "timeout")
+}
+
+func TestMergeConfig(t *testing.T) {
+	current := config.NewDefaultConfig()
+
+	data := []byte(`
+auth:
+  method: oauth
+  default_workspace: team
+api:
+  base_url: https://evil.example.com
+  timeout: 45s
+defaults:
+  output_format: json
+pr:
+  branch_suffix_mapping:
+    qa: qa
+`)
+
+	merged, skipped, err := mergeConfig(current, data)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"auth.method", "api.base_url"}, skipped)
+	assert.Equal(t, current.Auth.Method, merged.Auth.Method)
+	assert.Equal(t, current.API.BaseURL, merged.API.BaseURL)
+	assert.Equal(t, "team", merged.Auth.DefaultWorkspace)
+	assert.Equal(t, "json", merged.Defaults.OutputFormat)
+	assert.Equal(t, "homolog", merged.PR.BranchSuffixMapping["hml"])
+	assert.Equal(t, "qa", merged.PR.BranchSuffixMapping["qa"])
+
+	// The current config is left untouched
+	assert.Equal(t, "table", current.Defaults.OutputFormat)
+	assert.NotContains(t, current.PR.BranchSuffixMapping, "qa")
+
+	changes, err := diffConfigs(current, merged)
+	require.NoError(t, err)
+	assert.Equal(t, []configChange{
+		{Key: "api.timeout", Old: "30s", New: "45s"},
+		{Key: "auth.default_workspace", Old: "", New: "team"},
+		{Key: "defaults.output_format", Old: "table", New: "json"},
+		{Key: "pr.branch_suffix_mapping.qa", Old: "", New: "qa"},
+	}, changes)
+}
+
+func TestMergeConfig_Rejects(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+	}{
+		{"unknown key", "defaults:\n  colour: blue\n"},
+		{"invalid value", "defaults:\n  output_format: xml\n"},
+		{"malformed yaml", "defaults: [\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := mergeConfig(config.NewDefaultConfig(), []byte(tt.data))
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestExportCmd_OnlyConfigFileKeys(t *testing.T) {
+	dir := t.TempDir()
+	configPath := filepath.Join(dir, "config.yml")
+	require.NoError(t, os.WriteFile(configPath, []byte(`version: 1
+auth:
+  method: oauth
+  default_workspace: team
+pr:
+  branch_suffix_mapping:
+    qa: qa
+repo:
+  my.repo:
+    base: develop
+`), 0644))
+	t.Setenv("BT_CONFIG_PATH", configPath)
+	t.Setenv("BT_UI_MAX_WIDTH", "100")
+
+	repoDir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(repoDir, ".bt.yml"), []byte("defaults:\n  output_format: json\n"), 0644))
+	t.Chdir(repoDir)
+
+	exportPath := filepath.Join(dir, "team.yml")
+	require.NoError(t, (&ExportCmd{File: exportPath}).Run(context.Background()))
+
+	data, err := os.ReadFile(exportPath)
+	require.NoError(t, err)
+	var exported map[string]interface{}
+	require.NoError(t, yamlv3.Unmarshal(data, &exported))
+
+	assert.Equal(t, map[string]interface{}{
+		"version": 1,
+		"auth":    map[string]interface{}{"default_workspace": "team"},
+		"pr":      map[string]interface{}{"branch_suffix_mapping": map[string]interface{}{"qa": "qa"}},
+		"repo":    map[string]interface{}{"my.repo": map[string]interface{}{"base": "develop"}},
+	}, exported)
+}
+
+func TestMergeConfig_KeepsSettingsMissingFromFile(t *testing.T) {
+	current := config.NewDefaultConfig()
+	current.Auth.DefaultWorkspace = "mine"
+	current.PR.Base = "develop"
+	current.Defaults.OutputFormat = "yaml"
+
+	merged, _, err := mergeConfig(current, []byte("auth:\n  default_workspace: team\n"))
+	require.NoError(t, err)
+
+	assert.Equal(t, "team", merged.Auth.DefaultWorkspace)
+	assert.Equal(t, "develop", merged.PR.Base)
+	assert.Equal(t, "yaml", merged.Defaults.OutputFormat)
+
+	changes, err := diffConfigs(current, merged)
+	require.NoError(t, err)
+	assert.Equal(t, []configChange{{Key: "auth.default_workspace", Old: "mine", New: "team"}}, changes)
+}
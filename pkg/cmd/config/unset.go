@@ -7,17 +7,24 @@ import (
 
 // UnsetCmd handles the config unset command
 type UnsetCmd struct {
-	Key string `arg:"" help:"Configuration key to remove (e.g., auth.default_workspace)"`
+	Key     string `arg:"" help:"Configuration key to remove (e.g., auth.default_workspace)"`
+	Output  string `short:"o" help:"Output format (table, json, yaml)" enum:"table,json,yaml" default:"table"`
+	NoColor bool   // Passed from global flag
 }
 
 // Run executes the config unset command
 func (cmd *UnsetCmd) Run(ctx context.Context) error {
 	// Create config manager
-	cm, err := NewConfigManager()
+	cm, err := NewGlobalConfigManager()
 	if err != nil {
 		return err
 	}
 
+	// Keep the prior value for the confirmation; an invalid key is
+	// reported by UnsetValue
+	oldValue, _ := cm.GetValue(cmd.Key)
+	inFile := cm.InConfigFile(cmd.Key)
+
 	// Unset the value
 	if err := cm.UnsetValue(cmd.Key); err != nil {
 		return err
@@ -28,7 +35,21 @@ func (cmd *UnsetCmd) Run(ctx context.Context) error {
 		return fmt.Errorf("failed to save configuration: %w", err)
 	}
 
+	newValue, err := cm.GetValue(cmd.Key)
+	if err != nil {
+		return err
+	}
+
 	// Confirm the change
-	fmt.Printf("✓ Unset %s\n", cmd.Key)
-	return nil
+	if cmd.Output == "table" || cmd.Output == "" {
+		fmt.Printf("✓ Unset %s\n", cmd.Key)
+		return nil
+	}
+	// Removing the key from the file changes the effective value even when
+	// it held the default, since .bt.yml may set another
+	result := newConfigChangeResult(cmd.Key, oldValue, newValue)
+	if inFile {
+		result.Status = "removed"
+	}
+	return formatChange(cmd.Output, cmd.NoColor, result)
 }
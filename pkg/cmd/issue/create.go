@@ -0,0 +1,96 @@
+package issue
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/carlosarraes/bt/pkg/api"
+	"github.com/carlosarraes/bt/pkg/cmd/shared"
+)
+
+// CreateCmd handles the issue create command
+type CreateCmd struct {
+	Title      string
+	Body       string
+	Kind       string
+	Priority   string
+	Assignee   string
+	Output     string
+	NoColor    bool
+	Workspace  string
+	Repository string
+}
+
+// Run executes the issue create command
+func (cmd *CreateCmd) Run(ctx context.Context) error {
+	request, err := cmd.createRequest()
+	if err != nil {
+		return err
+	}
+
+	issueCtx, err := newIssueContext(ctx, cmd.Output, cmd.NoColor, cmd.Workspace, cmd.Repository)
+	if err != nil {
+		return err
+	}
+
+	if cmd.Assignee != "" {
+		assignee, err := resolveAssignee(ctx, issueCtx.Client, cmd.Assignee)
+		if err != nil {
+			return err
+		}
+		request.Assignee = assigneeUser(assignee)
+	}
+
+	if issueCtx.DryRun {
+		plan := shared.NewDryRunPlan("Would create issue %q in %s/%s", request.Title, issueCtx.Workspace, issueCtx.Repository)
+		plan.Add("POST", fmt.Sprintf("repositories/%s/%s/issues", issueCtx.Workspace, issueCtx.Repository), request)
+		return issueCtx.PrintDryRun(plan, cmd.Output)
+	}
+
+	issue, err := issueCtx.Client.Issues.CreateIssue(ctx, issueCtx.Workspace, issueCtx.Repository, request)
+	if err != nil {
+		return handleIssueAPIError(err)
+	}
+
+	if cmd.Output != "table" {
+		return issueCtx.Formatter.Format(issue)
+	}
+
+	fmt.Printf("✓ Issue created successfully!\n\n")
+	fmt.Printf("Title: %s\n", issue.Title)
+	fmt.Printf("ID: #%d\n", issue.ID)
+	fmt.Printf("Kind: %s\n", issue.Kind)
+	fmt.Printf("Priority: %s\n", issue.Priority)
+	fmt.Printf("URL: %s\n", issueURL(issueCtx, issue))
+	return nil
+}
+
+// createRequest validates the flags and builds the new issue. Kind and
+// priority are left to Bitbucket's defaults when omitted.
+func (cmd *CreateCmd) createRequest() (*api.CreateIssueRequest, error) {
+	title := strings.TrimSpace(cmd.Title)
+	if title == "" {
+		return nil, fmt.Errorf("--title is required")
+	}
+
+	request := &api.CreateIssueRequest{Title: title}
+	if cmd.Body != "" {
+		request.Content = &api.IssueContent{Raw: cmd.Body}
+	}
+
+	if cmd.Kind != "" {
+		if err := shared.ValidateAllowedValue(cmd.Kind, api.IssueKinds, "kind"); err != nil {
+			return nil, err
+		}
+		request.Kind = strings.ToLower(cmd.Kind)
+	}
+	if cmd.Priority != "" {
+		if err := shared.ValidateAllowedValue(cmd.Priority, api.IssuePriorities, "priority"); err != nil {
+			return nil, err
+		}
+		request.Priority = strings.ToLower(cmd.Priority)
+	}
+
+	return request, nil
+}
@@ -0,0 +1,78 @@
+package issue
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/carlosarraes/bt/pkg/api"
+	"github.com/carlosarraes/bt/pkg/api/apitest"
+	"github.com/carlosarraes/bt/pkg/cmd/shared"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateCmd_createRequest(t *testing.T) {
+	request, err := (&CreateCmd{Title: " Crash on start ", Body: "Steps", Kind: "Task", Priority: "blocker"}).createRequest()
+	require.NoError(t, err)
+	assert.Equal(t, "Crash on start", request.Title)
+	assert.Equal(t, "Steps", request.Content.Raw)
+	assert.Equal(t, "task", request.Kind)
+	assert.Equal(t, "blocker", request.Priority)
+
+	request, err = (&CreateCmd{Title: "Crash"}).createRequest()
+	require.NoError(t, err)
+	assert.Nil(t, request.Content)
+	assert.Empty(t, request.Kind)
+
+	_, err = (&CreateCmd{}).createRequest()
+	assert.EqualError(t, err, "--title is required")
+
+	_, err = (&CreateCmd{Title: "Crash", Kind: "story"}).createRequest()
+	assert.ErrorContains(t, err, "invalid kind 'story'")
+
+	_, err = (&CreateCmd{Title: "Crash", Priority: "urgent"}).createRequest()
+	assert.ErrorContains(t, err, "invalid priority 'urgent'")
+}
+
+func TestCreateCmd_CreatesIssue(t *testing.T) {
+	apitest.CommandEnv(t, "ws", "repo")
+	transport := apitest.NewReplayTransport(apitest.Fixture{
+		Method: "POST",
+		Path:   "/repositories/ws/repo/issues",
+		Status: 201,
+		Body:   json.RawMessage(`{"id":8,"title":"Crash on start","state":"new","kind":"bug","priority":"major"}`),
+	})
+	t.Cleanup(shared.SetClientTransport(transport))
+
+	cmd := &CreateCmd{Title: "Crash on start", Assignee: "557058:abc", Output: "table", NoColor: true}
+	var runErr error
+	out := captureStdout(func() { runErr = cmd.Run(context.Background()) })
+	require.NoError(t, runErr)
+
+	assert.Contains(t, out, "ID: #8")
+	assert.Contains(t, out, "URL: https://bitbucket.org/ws/repo/issues/8")
+
+	requests := transport.Requests()
+	require.Len(t, requests, 1)
+	var body api.CreateIssueRequest
+	require.NoError(t, json.Unmarshal(requests[0].Body, &body))
+	assert.Equal(t, "Crash on start", body.Title)
+	assert.Equal(t, "557058:abc", body.Assignee.AccountID)
+}
+
+func TestCreateCmd_DryRun(t *testing.T) {
+	apitest.CommandEnv(t, "ws", "repo")
+	transport := apitest.NewReplayTransport()
+	t.Cleanup(shared.SetClientTransport(transport))
+
+	ctx := context.WithValue(context.Background(), "dry-run", true)
+	cmd := &CreateCmd{Title: "Crash on start", Kind: "bug", Output: "table", NoColor: true}
+	var runErr error
+	out := captureStdout(func() { runErr = cmd.Run(ctx) })
+	require.NoError(t, runErr)
+
+	assert.Contains(t, out, `[dry-run] Would create issue "Crash on start" in ws/repo`)
+	assert.Contains(t, out, "POST repositories/ws/repo/issues")
+	assert.Empty(t, transport.Requests())
+}
@@ -0,0 +1,111 @@
+package issue
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/carlosarraes/bt/pkg/api"
+	"github.com/carlosarraes/bt/pkg/cmd/shared"
+)
+
+type IssueContext = shared.CommandContext
+
+// unresolvedStates are the issue states bt issue list shows by default
+var unresolvedStates = []string{"new", "open", "on hold"}
+
+func handleIssueAPIError(err error) error {
+	return shared.HandleAPIError(err, shared.DomainIssue)
+}
+
+// newIssueContext builds the command context and resolves the workspace and
+// repository with the --workspace and --repository flags
+func newIssueContext(ctx context.Context, outputFormat string, noColor bool, workspace, repository string) (*IssueContext, error) {
+	issueCtx, err := shared.NewCommandContext(ctx, outputFormat, noColor)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := issueCtx.ResolveRepository(workspace, repository); err != nil {
+		return nil, err
+	}
+	return issueCtx, nil
+}
+
+// ParseIssueID parses an issue ID argument such as "42" or "#42"
+func ParseIssueID(issueIDStr string) (int, error) {
+	if issueIDStr == "" {
+		return 0, fmt.Errorf("issue ID is required")
+	}
+
+	issueIDStr = strings.TrimPrefix(issueIDStr, "#")
+
+	issueID, err := strconv.Atoi(issueIDStr)
+	if err != nil {
+		return 0, fmt.Errorf("invalid issue ID '%s': must be a number", issueIDStr)
+	}
+
+	if issueID <= 0 {
+		return 0, fmt.Errorf("invalid issue ID '%d': must be positive", issueID)
+	}
+
+	return issueID, nil
+}
+
+// issueURL is the web page of an issue
+func issueURL(issueCtx *IssueContext, issue *api.Issue) string {
+	if issue.Links != nil && issue.Links.HTML != nil && issue.Links.HTML.Href != "" {
+		return issue.Links.HTML.Href
+	}
+	return fmt.Sprintf("https://bitbucket.org/%s/%s/issues/%d", issueCtx.Workspace, issueCtx.Repository, issue.ID)
+}
+
+// userName is the display name of user, falling back to the username
+func userName(user *api.User) string {
+	if user == nil {
+		return ""
+	}
+	if user.DisplayName != "" {
+		return user.DisplayName
+	}
+	return user.Username
+}
+
+// resolveAssignee turns "@me" into the authenticated user's account_id (or
+// UUID, for accounts without one); other selectors are returned unchanged
+func resolveAssignee(ctx context.Context, client *api.Client, selector string) (string, error) {
+	if selector != "@me" {
+		return selector, nil
+	}
+	if client == nil {
+		return "", fmt.Errorf("could not resolve @me: not authenticated")
+	}
+	user, err := client.GetAuthManager().GetAuthenticatedUser(ctx)
+	if err != nil {
+		return "", fmt.Errorf("could not resolve @me to the authenticated user: %w", err)
+	}
+	switch {
+	case user.AccountID != "":
+		return user.AccountID, nil
+	case user.UUID != "":
+		return user.UUID, nil
+	case user.Username != "":
+		return user.Username, nil
+	default:
+		return "", fmt.Errorf("could not resolve @me: the authenticated account has no identifier")
+	}
+}
+
+// assigneeUser builds the user reference for an assignee selector, the way
+// pr create does for reviewers
+func assigneeUser(selector string) *api.User {
+	switch {
+	case strings.HasPrefix(selector, "{") && strings.HasSuffix(selector, "}"):
+		return &api.User{UUID: selector}
+	case strings.Contains(selector, ":"):
+		return &api.User{AccountID: selector}
+	default:
+		return &api.User{Username: selector}
+	}
+}
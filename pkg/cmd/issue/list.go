@@ -0,0 +1,109 @@
+package issue
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/carlosarraes/bt/pkg/api"
+	"github.com/carlosarraes/bt/pkg/cmd/shared"
+	"github.com/carlosarraes/bt/pkg/output"
+)
+
+// ListCmd handles the issue list command
+type ListCmd struct {
+	State      string
+	Assignee   string
+	Limit      int
+	Output     string
+	NoColor    bool
+	Workspace  string
+	Repository string
+}
+
+// Run executes the issue list command
+func (cmd *ListCmd) Run(ctx context.Context) error {
+	states, err := listStates(cmd.State)
+	if err != nil {
+		return err
+	}
+
+	if cmd.Limit <= 0 {
+		return fmt.Errorf("limit must be greater than 0")
+	}
+	if cmd.Limit > 100 {
+		return fmt.Errorf("limit cannot exceed 100")
+	}
+
+	issueCtx, err := newIssueContext(ctx, cmd.Output, cmd.NoColor, cmd.Workspace, cmd.Repository)
+	if err != nil {
+		return err
+	}
+
+	assignee, err := resolveAssignee(ctx, issueCtx.Client, cmd.Assignee)
+	if err != nil {
+		return err
+	}
+
+	issues, err := issueCtx.Client.Issues.ListIssues(ctx, issueCtx.Workspace, issueCtx.Repository, &api.IssueListOptions{
+		States:   states,
+		Assignee: assignee,
+		Limit:    cmd.Limit,
+	})
+	if err != nil {
+		return handleIssueAPIError(err)
+	}
+
+	return cmd.formatOutput(issueCtx, issues)
+}
+
+// listStates maps --state to the issue states to list: "unresolved" covers
+// new, open and on hold issues, "all" lists every state
+func listStates(state string) ([]string, error) {
+	switch strings.ToLower(state) {
+	case "", "unresolved":
+		return unresolvedStates, nil
+	case "all":
+		return nil, nil
+	}
+
+	if err := shared.ValidateAllowedValue(state, append([]string{"unresolved", "all"}, api.IssueStates...), "state"); err != nil {
+		return nil, err
+	}
+	return []string{strings.ToLower(state)}, nil
+}
+
+func (cmd *ListCmd) formatOutput(issueCtx *IssueContext, issues []*api.Issue) error {
+	switch cmd.Output {
+	case "table":
+		if len(issues) == 0 {
+			fmt.Println("No issues found")
+			return nil
+		}
+		headers := []string{"ID", "Title", "State", "Kind", "Priority", "Assignee", "Updated"}
+		rows := make([][]string, 0, len(issues))
+		for _, issue := range issues {
+			assignee := userName(issue.Assignee)
+			if assignee == "" {
+				assignee = "-"
+			}
+			rows = append(rows, []string{
+				fmt.Sprintf("#%d", issue.ID),
+				issue.Title,
+				issue.State,
+				issue.Kind,
+				issue.Priority,
+				assignee,
+				output.FormatRelativeTime(issue.UpdatedOn),
+			})
+		}
+		return output.RenderSimpleTable(headers, rows)
+	case "json", "yaml":
+		return issueCtx.Formatter.Format(map[string]interface{}{
+			"issues":      issues,
+			"total_count": len(issues),
+		})
+	default:
+		return fmt.Errorf("unsupported output format: %s", cmd.Output)
+	}
+}
@@ -0,0 +1,76 @@
+package issue
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"os"
+	"testing"
+
+	"github.com/carlosarraes/bt/pkg/api/apitest"
+	"github.com/carlosarraes/bt/pkg/cmd/shared"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func captureStdout(fn func()) string {
+	old := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+
+	fn()
+
+	w.Close()
+	os.Stdout = old
+	var buf bytes.Buffer
+	io.Copy(&buf, r)
+	return buf.String()
+}
+
+func TestListStates(t *testing.T) {
+	states, err := listStates("")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"new", "open", "on hold"}, states)
+
+	states, err = listStates("all")
+	require.NoError(t, err)
+	assert.Nil(t, states)
+
+	states, err = listStates("Resolved")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"resolved"}, states)
+
+	_, err = listStates("merged")
+	assert.ErrorContains(t, err, "invalid state 'merged'")
+}
+
+func TestListCmd_Run(t *testing.T) {
+	apitest.CommandEnv(t, "ws", "repo")
+	transport := apitest.NewReplayTransport(apitest.Fixture{
+		Method: "GET",
+		Path:   "/repositories/ws/repo/issues",
+		Query:  `q=(state="resolved") AND assignee.username="alice"`,
+		Status: 200,
+		Body: json.RawMessage(`{"values":[
+			{"id":7,"title":"Crash on start","state":"resolved","kind":"bug","priority":"critical","assignee":{"display_name":"Alice"}},
+			{"id":3,"title":"Dark mode","state":"resolved","kind":"enhancement","priority":"minor"}
+		]}`),
+	})
+	t.Cleanup(shared.SetClientTransport(transport))
+
+	cmd := &ListCmd{State: "resolved", Assignee: "alice", Limit: 30, Output: "table", NoColor: true}
+	var runErr error
+	out := captureStdout(func() { runErr = cmd.Run(context.Background()) })
+	require.NoError(t, runErr)
+
+	assert.Contains(t, out, "#7")
+	assert.Contains(t, out, "Crash on start")
+	assert.Contains(t, out, "Alice")
+	assert.Contains(t, out, "enhancement")
+}
+
+func TestListCmd_Run_InvalidLimit(t *testing.T) {
+	cmd := &ListCmd{Limit: 101, Output: "table"}
+	assert.EqualError(t, cmd.Run(context.Background()), "limit cannot exceed 100")
+}
@@ -0,0 +1,96 @@
+package issue
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/carlosarraes/bt/pkg/api"
+	"github.com/carlosarraes/bt/pkg/cmd/shared"
+	"github.com/carlosarraes/bt/pkg/output"
+)
+
+// ViewCmd handles the issue view command
+type ViewCmd struct {
+	IssueID    string
+	Web        bool
+	Output     string
+	NoColor    bool
+	Workspace  string
+	Repository string
+}
+
+// Run executes the issue view command
+func (cmd *ViewCmd) Run(ctx context.Context) error {
+	issueID, err := ParseIssueID(cmd.IssueID)
+	if err != nil {
+		return err
+	}
+
+	issueCtx, err := newIssueContext(ctx, cmd.Output, cmd.NoColor, cmd.Workspace, cmd.Repository)
+	if err != nil {
+		return err
+	}
+
+	if cmd.Web {
+		url := issueURL(issueCtx, &api.Issue{ID: issueID})
+		if err := shared.LaunchBrowser(url); err != nil {
+			return fmt.Errorf("failed to open browser: %w", err)
+		}
+		fmt.Printf("Opening %s in your browser.\n", url)
+		return nil
+	}
+
+	issue, err := issueCtx.Client.Issues.GetIssue(ctx, issueCtx.Workspace, issueCtx.Repository, issueID)
+	if err != nil {
+		return handleIssueAPIError(err)
+	}
+
+	switch cmd.Output {
+	case "table":
+		printIssue(issueCtx, issue)
+		return nil
+	case "json", "yaml":
+		return issueCtx.Formatter.Format(issue)
+	default:
+		return fmt.Errorf("unsupported output format: %s", cmd.Output)
+	}
+}
+
+func printIssue(issueCtx *IssueContext, issue *api.Issue) {
+	fmt.Printf("#%d • %s\n", issue.ID, issue.Title)
+	fmt.Printf("State: %s\n", issue.State)
+	fmt.Printf("Kind: %s\n", issue.Kind)
+	fmt.Printf("Priority: %s\n", issue.Priority)
+
+	if reporter := userName(issue.Reporter); reporter != "" {
+		fmt.Printf("Reporter: %s\n", reporter)
+	}
+	if assignee := userName(issue.Assignee); assignee != "" {
+		fmt.Printf("Assignee: %s\n", assignee)
+	}
+
+	for _, field := range []struct {
+		label string
+		value *api.TrackerField
+	}{
+		{"Milestone", issue.Milestone},
+		{"Version", issue.Version},
+		{"Component", issue.Component},
+	} {
+		if field.value != nil && field.value.Name != "" {
+			fmt.Printf("%s: %s\n", field.label, field.value.Name)
+		}
+	}
+
+	if issue.CreatedOn != nil {
+		fmt.Printf("Created: %s\n", output.FormatRelativeTime(issue.CreatedOn))
+	}
+	if issue.UpdatedOn != nil {
+		fmt.Printf("Updated: %s\n", output.FormatRelativeTime(issue.UpdatedOn))
+	}
+	fmt.Printf("URL: %s\n", issueURL(issueCtx, issue))
+
+	if issue.Content != nil && issue.Content.Raw != "" {
+		fmt.Printf("\nDescription:\n%s\n", issue.Content.Raw)
+	}
+}
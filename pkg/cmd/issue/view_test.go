@@ -0,0 +1,65 @@
+package issue
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/carlosarraes/bt/pkg/api/apitest"
+	"github.com/carlosarraes/bt/pkg/cmd/shared"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseIssueID(t *testing.T) {
+	id, err := ParseIssueID("#42")
+	require.NoError(t, err)
+	assert.Equal(t, 42, id)
+
+	for _, arg := range []string{"", "abc", "0", "-3"} {
+		_, err := ParseIssueID(arg)
+		assert.Error(t, err, arg)
+	}
+}
+
+func TestViewCmd_Run(t *testing.T) {
+	apitest.CommandEnv(t, "ws", "repo")
+	transport := apitest.NewReplayTransport(apitest.Fixture{
+		Method: "GET",
+		Path:   "/repositories/ws/repo/issues/7",
+		Status: 200,
+		Body: json.RawMessage(`{"id":7,"title":"Crash on start","state":"open","kind":"bug","priority":"critical",
+			"reporter":{"display_name":"Bob"},"milestone":{"name":"v2.0"},
+			"content":{"raw":"Steps to reproduce"},
+			"links":{"html":{"href":"https://bitbucket.org/ws/repo/issues/7/crash-on-start"}}}`),
+	})
+	t.Cleanup(shared.SetClientTransport(transport))
+
+	cmd := &ViewCmd{IssueID: "7", Output: "table", NoColor: true}
+	var runErr error
+	out := captureStdout(func() { runErr = cmd.Run(context.Background()) })
+	require.NoError(t, runErr)
+
+	assert.Contains(t, out, "#7 • Crash on start")
+	assert.Contains(t, out, "Priority: critical")
+	assert.Contains(t, out, "Reporter: Bob")
+	assert.Contains(t, out, "Milestone: v2.0")
+	assert.Contains(t, out, "URL: https://bitbucket.org/ws/repo/issues/7/crash-on-start")
+	assert.Contains(t, out, "Description:\nSteps to reproduce")
+	assert.NotContains(t, out, "Assignee:")
+}
+
+func TestViewCmd_Run_TrackerDisabled(t *testing.T) {
+	apitest.CommandEnv(t, "ws", "repo")
+	transport := apitest.NewReplayTransport(apitest.Fixture{
+		Method: "GET",
+		Path:   "/repositories/ws/repo/issues/7",
+		Status: 404,
+		Body:   json.RawMessage(`{"type":"error","error":{"message":"Repository has no issue tracker."}}`),
+	})
+	t.Cleanup(shared.SetClientTransport(transport))
+
+	cmd := &ViewCmd{IssueID: "7", Output: "table", NoColor: true}
+	err := cmd.Run(context.Background())
+	assert.ErrorContains(t, err, "issue tracker is not enabled")
+}
@@ -36,6 +36,7 @@ bt commands map directly to GitHub CLI equivalents:
 ` + "```" + `
 gh auth login    → bt auth login
 gh pr list       → bt pr list
+gh issue list    → bt issue list    # Bitbucket's built-in issue tracker
 gh run list      → bt run list      # Main differentiator: enhanced pipeline debugging
 gh run view      → bt run view      # Enhanced with log analysis
 gh config        → bt config        # Advanced configuration management
@@ -68,18 +69,17 @@ bt run view 3808 --step "Run Tests"
 # Get structured pipeline data for analysis
 bt run list --status failed --output json
 
-# Get detailed pipeline information with logs
-bt run view 3808 --log-failed --output json
+# Failed steps with their log text (last 100 lines; --full-output for all)
+bt run view 3808 --log-failed --include-logs --output json
 
-# Example JSON structure for failed pipeline:
+# Example JSON structure (without --include-logs, steps carry metadata only):
 {
-  "id": "3808",
-  "state": "FAILED", 
+  "pipeline": {"build_number": 3808, "state": {"name": "COMPLETED", "result": {"name": "FAILED"}}},
   "steps": [
     {
-      "name": "Run Tests",
-      "state": "FAILED",
-      "logs": "FAILED (failures=4, skipped=138)\nAssertionError: None != '001'"
+      "step": {"name": "Run Tests", "state": {"name": "COMPLETED", "result": {"name": "FAILED"}}},
+      "logs": "FAILED (failures=4, skipped=138)\nAssertionError: None != '001'",
+      "truncated": true
     }
   ]
 }
@@ -108,7 +108,11 @@ Requires ` + "`SONARCLOUD_TOKEN`" + ` in the environment.
 ### Authentication Setup
 ` + "```bash" + `
 bt auth login                    # Interactive setup (API token recommended)
+bt auth login --git-protocol https  # Also let git push/pull over HTTPS with the same token
 bt auth status                   # Check current authentication
+bt auth status -o json           # Method, user, account ID, token validity and API base URL (no secrets)
+bt api rate-limit                # Remaining API quota and reset time (before big fan-out commands)
+bt auth create-token --repo ws/repo --scopes repository,pipeline:write  # Repository access token for CI
 export BITBUCKET_EMAIL="user@company.com"      # Environment variable auth
 export BITBUCKET_API_TOKEN="your_token"        # Recommended method
 ` + "```" + `
@@ -119,13 +123,44 @@ bt pr list                       # List pull requests
 bt pr create --ai                # AI-generated description (Portuguese)
 bt pr create --ai --template english  # English AI description
 bt pr create --ai --jira context.md   # Include JIRA context
+bt pr create --ai --jira PROJ-123     # Fetch the ticket from jira.base_url (JIRA_API_TOKEN)
+bt pr create --fill-first-commit # Title/body from the branch's first commit
+bt pr create --recover           # Retry with the title/body saved by a failed create
+bt pr create --require-checklist # Refuse unless the body satisfies pr.checklist (config)
+bt pr create --lint-commits      # Check commit messages against pr.commit_lint (conventional commits)
+bt pr create --no-lint           # Skip commit linting enabled in the config
+bt pr create --no-verify         # Push the branch without running pre-push hooks
+bt pr create --milestone "Sprint 12" --version 1.4  # Issue tracker metadata (ignored without the tracker)
+bt pr create --max-size M        # Warn when the PR is bigger than pr.size_thresholds.m lines
+bt pr create --head feature/x    # Open the PR from another branch (needed on a detached HEAD)
+bt pr create --base-auto         # Target the branch this one was created from (stacked PRs)
+bt pr create --no-draft          # Ready for review even when pr.create_as_draft is set
 bt pr create --title "Fix" --body "Description"  # Traditional creation
 bt pr view 42                    # PR details
+bt pr view 42 --comments --tree  # Comment threads, inline comments grouped by file:line
+bt pr view 42 --checks           # PR details plus check counts and failing checks
+bt pr view 42 --patch | git am --patch-format=mboxrd  # One patch per commit, applied with authorship kept
 bt pr review 42 --approve        # Approve PR
+bt pr review 42 --request-changes --from-pipeline 3808  # Quote the run's failure errors in the review
+bt pr review --query author=renovate-bot --approve --force  # Batch-approve matching open PRs
 bt pr comment 42 -b "LGTM!"     # Add comment
+bt pr comment 42 --from-diff "console\.log" -b "Drop debug logging" --force  # Same inline nit on every matching added line
 bt pr merge 42                   # Merge PR
 bt pr checkout 42                # Switch to PR branch
+bt pr checkout 42 --cleanup      # After merge: back to default branch, delete local + remote PR branch
+bt pr view                       # Omit the ID to pick from open PRs (view, checkout, merge; TTY only)
+bt repo clone ws/repo --pr 42     # Clone and check out PR #42 in one step
+bt repo cherry-pick 1a2b3c4 --onto release/2.1 --pr  # Backport a commit and open the PR
+bt repo commits --committer bot@example.com  # Commits committed by someone, with author and committer when they differ
+bt repo changelog --since v1.4.0  # Markdown changelog of PRs merged since the tag (-o json for tooling)
+bt repo variables list -e production  # Pipeline variables (repo or deployment environment)
+bt repo variables set API_KEY --secured < key.txt  # Secured value read from stdin
 bt pr status                     # Your PR dashboard
+bt issue list                    # Unresolved issues (new, open, on hold)
+bt issue list --state resolved --assignee @me -o json
+bt issue view 7                  # Issue details and description
+bt issue create -t "Crash on start" -b "Steps..." --kind bug --priority critical
+bt alias set failed 'run list --status failed'  # Then 'bt failed --branch main'; $1..$9 and $@ take arguments
 ` + "```" + `
 
 ### Pipeline Monitoring & Debugging
@@ -133,13 +168,36 @@ bt pr status                     # Your PR dashboard
 bt run list                      # Recent pipeline runs
 bt run list --status failed     # Failed runs only
 bt run list --branch main       # Specific branch
-bt run view <id>                 # Pipeline overview
+bt run latest --log-failed      # Newest pipeline of the current branch, failures only
+bt run for-commit abc1234       # Runs of one commit (same as run list --commit)
+bt run view <id>                 # Pipeline overview (parallel steps grouped, with the group's status)
 bt run view <id> --log-failed   # Quick error analysis (⚡ FASTEST)
 bt run view <id> --log          # All step logs
 bt run view <id> --tests        # Test results focus
+bt run view <id> --tests --test-output full  # Also list passing tests with durations
+bt run view <id> --tests --history  # Flaky vs consistently failing tests (last 10 runs)
+bt run view <id> --tests -o json    # Suites, cases, counts and durations in a stable schema
+bt run view <id> --step-timing  # Step timeline and critical path
+bt run view <id> --pr           # The pull request a PR pipeline ran for (--web opens it)
 bt run view <id> --step "name"  # Specific step logs
+bt run view <id> --step ut      # Step by alias from run.step_aliases
+bt run logs <id> --only-failed  # Logs of every failed step, no step names needed
+bt run logs <id> --merge-steps  # All steps' raw logs as one stream, lines prefixed [step]
+bt run logs <id> --no-redact    # Logs mask secrets as [REDACTED] unless --no-redact
+bt run view --status failed     # No ID: pick from recent pipelines (TTY only; also run logs)
+bt run compare <green> <red>    # What changed between two runs
 bt run watch <id>               # Real-time monitoring ✅ AVAILABLE
+bt run watch <id> --retry-on-transient  # Survive brief network/API hiccups (--max-poll-failures, default 5)
 bt run cancel <id>              # Cancel running pipeline ✅ AVAILABLE
+bt run cancel <id> --wait -f    # Cancel and wait until it has stopped, reporting its final state
+bt run rerun <id> --failed --watch  # Rerun failed steps and watch the new run (--follow streams logs)
+bt run status --workspace <ws> --failed  # Which repositories have a red main build
+bt run status set --commit <sha> --state FAILED --key lint --url <link>  # Report an external check (shown by pr checks)
+bt run deployments              # What is deployed where (environment, status, release, commit)
+bt run grep "OOMKilled" --limit 50 --branch main  # When did this log line first appear?
+bt run grep -i "timeout" --status failed -C 2     # Case-insensitive, with context lines
+bt run deploy <id> --env production  # Start the pipeline's waiting deploy step
+bt run download app.tar.gz --dir dist  # Fetch a build artifact; size-checked, retried, resumable (--no-verify)
 ` + "```" + `
 
 ## Output Formats
@@ -147,13 +205,34 @@ Most commands support multiple output formats for different use cases:
 - **table** (default): Human-readable terminal output
 - **json**: Structured data for automation and LLM analysis
 - **yaml**: Alternative structured format
+- **compact** (` + "`run list`" + `, ` + "`pr list`" + `): One line per item, e.g. ` + "`#42 OPEN feature/x → main  \"Title\"`" + `
 
 ` + "```bash" + `
 bt run list --output json       # JSON for automation
 bt pr list --output yaml        # YAML for configuration
+bt pr list --output compact     # One line per PR for prompts and status bars
 bt run view 123 --output table  # Formatted terminal output (default)
 ` + "```" + `
 
+With ` + "`--output json`" + `, failures are also JSON (on stderr, exit code 1):
+` + "```json" + `
+{"error": {"type": "not_found", "message": "...", "status_code": 404, "suggestions": ["..."]}}
+` + "```" + `
+
+### Progress Events (--events jsonl)
+Long-running commands can stream one JSON event per line instead of text, so an
+agent can react while a pipeline runs:
+` + "```bash" + `
+bt run watch 123 --events jsonl            # step_started, step_completed, log_line, pipeline_completed
+bt run logs 123 --follow --events jsonl    # Same events; --errors-only limits log_line to error lines
+bt pr checks 42 --watch --events jsonl     # check_updated, checks_completed
+` + "```" + `
+` + "```json" + `
+{"event":"step_completed","ts":"2026-10-16T12:00:05Z","pipeline":42,"step":"Run Tests","status":"FAILED"}
+` + "```" + `
+Every event has ` + "`event`" + ` and ` + "`ts`" + `; ` + "`pipeline`, `pull_request`, `step`, `check`, `status`, `line`, `message`" + ` appear when they apply.
+The stream starts with ` + "`watch_started`" + ` and ends with ` + "`pipeline_completed`" + ` or ` + "`checks_completed`" + ` (` + "`interrupted`" + ` on Ctrl+C).
+
 ## Environment Variables for Automation
 ` + "```bash" + `
 # Authentication (recommended)
@@ -185,7 +264,7 @@ bt includes advanced error detection for common scenarios:
 Error patterns are automatically highlighted and extracted for faster diagnosis.
 
 ## Best Practices for LLM Integration
-1. **Use JSON output** for structured data analysis
+1. **Use JSON output** for structured data analysis, and ` + "`--events jsonl`" + ` to follow running pipelines
 2. **Focus on pipeline debugging workflow** for maximum time savings
 3. **Leverage environment variables** for seamless automation
 4. **Start with failed pipelines** using --status failed filter
@@ -207,8 +286,10 @@ Use ` + "`bt pick --llm`" + ` for detailed LLM guidance on pick commands.
 ## Command Categories by Priority
 1. **Critical**: run (pipeline view + SonarCloud report)
 2. **Important**: pr, auth (standard Git operations)
-3. **Useful**: pick (cherry-pick between PRD/HML branches)
-4. **Utility**: config (configuration management)
+3. **Useful**: pick (cherry-pick between PRD/HML branches), repo, issue (built-in issue tracker)
+4. **Utility**: config (configuration management), alias (command shortcuts), api (rate limit), skill (agent skill install)
+
+Every command has ` + "`bt <command> --llm`" + ` guidance and ` + "`bt <command> <subcommand> --help`" + ` for its flags.
 
 bt excels at pipeline debugging and provides 5x faster error diagnosis compared to web UI navigation.
 `
@@ -216,25 +297,34 @@ bt excels at pipeline debugging and provides 5x faster error diagnosis compared
 	fmt.Print(help)
 }
 
+// commandLLMHelp holds the guides of the commands with their own LLM help
+var commandLLMHelp = map[string]func(){
+	"run":    showRunLLMHelp,
+	"auth":   showAuthLLMHelp,
+	"pr":     showPRLLMHelp,
+	"repo":   showRepoLLMHelp,
+	"config": showConfigLLMHelp,
+	"pick":   showPickLLMHelp,
+	"issue":  showIssueLLMHelp,
+	"alias":  showAliasLLMHelp,
+	"api":    showAPILLMHelp,
+	"skill":  showSkillLLMHelp,
+}
+
+// HasLLMHelp reports whether command has its own LLM guide
+func HasLLMHelp(command string) bool {
+	_, ok := commandLLMHelp[command]
+	return ok
+}
+
 // Command-specific LLM help
 func showCommandLLMHelp(command string) {
-	switch command {
-	case "run":
-		showRunLLMHelp()
-	case "auth":
-		showAuthLLMHelp()
-	case "pr":
-		showPRLLMHelp()
-	case "repo":
-		showRepoLLMHelp()
-	case "config":
-		showConfigLLMHelp()
-	case "pick":
-		showPickLLMHelp()
-	default:
-		fmt.Printf("No specific LLM guidance available for command: %s\n", command)
-		fmt.Println("Use 'bt --llm' for general guidance.")
+	if show, ok := commandLLMHelp[command]; ok {
+		show()
+		return
 	}
+	fmt.Printf("No specific LLM guidance available for command: %s\n", command)
+	fmt.Printf("Use 'bt %s --help' for its flags, or 'bt --llm' for general guidance.\n", command)
 }
 
 func showRunLLMHelp() {
@@ -280,13 +370,14 @@ Pipeline analysis with integrated log viewing:
 ` + "```bash" + `
 bt run view <id>                 # Pipeline overview + step status
 bt run view <id> --log-failed    # Show failures (last 100 lines) ⚡ FASTEST
-bt run view <id> --log-failed --full-output  # Complete failure logs
+bt run view <id> --log-failed --full-output  # Complete failure logs (asks first above 1 MB on a terminal)
 bt run view <id> --log           # All step logs (verbose)
 bt run view <id> --tests         # Focus on test results
 bt run view <id> --step "Run Tests"  # Specific step only
 bt run view <id> --output json   # Structured data for analysis
 bt run watch <id>                # Real-time monitoring (dedicated command)
-bt run view <id> --watch         # Live updates (alternative method)
+bt run view <id> --watch         # Live status, redrawn in place on a terminal
+bt run view <id> --watch --append  # Append each update instead (logging, non-TTY default)
 ` + "```" + `
 
 ### bt run report (SonarCloud Coverage & Issues)
@@ -322,6 +413,8 @@ Dedicated command for monitoring running pipelines:
 ` + "```bash" + `
 bt run watch <id>                # Monitor pipeline in real-time
 bt run watch <id> --output json  # JSON output for automation
+bt run watch <id> --output yaml  # YAML output (same fields as JSON)
+bt run watch <id> --events jsonl # One JSON progress event per line while it runs
 bt run watch 123                 # Watch pipeline by build number
 bt run watch {uuid}              # Watch pipeline by UUID
 ` + "```" + `
@@ -338,27 +431,24 @@ bt run watch {uuid}              # Watch pipeline by UUID
 - ✅ Works only with running/pending pipelines
 
 ## JSON Output Structure
-Perfect for LLM analysis:
+Perfect for LLM analysis. ` + "`bt run view <id> --log-failed --include-logs -o json`" + ` returns:
 ` + "```json" + `
 {
-  "id": "3808",
-  "build_number": 123,
-  "state": "FAILED",
-  "result": "FAILED", 
-  "target": {
-    "branch": "main",
-    "commit": "abc123"
+  "pipeline": {
+    "build_number": 123,
+    "state": {"name": "COMPLETED", "result": {"name": "FAILED"}},
+    "target": {"ref_name": "main", "commit": {"hash": "abc123"}}
   },
   "steps": [
     {
-      "name": "Run Tests",
-      "state": "FAILED",
-      "duration": 120,
-      "logs": "FAILED (failures=4)\nAssertionError: None != '001'"
+      "step": {"name": "Run Tests", "state": {"name": "COMPLETED", "result": {"name": "FAILED"}}},
+      "logs": "FAILED (failures=4)\nAssertionError: None != '001'",
+      "truncated": true
     }
   ]
 }
 ` + "```" + `
+Without --include-logs, steps carry metadata only ("logs" is omitted).
 
 ## Common Error Patterns Detected
 - Test failures: "FAILED (failures=N)", "AssertionError", "Test failed"
@@ -450,6 +540,7 @@ Complete pull request workflow with AI-powered descriptions and GitHub CLI compa
 bt pr create --ai                          # Portuguese template (default)
 bt pr create --ai --template english      # English template
 bt pr create --ai --jira project.md       # Include JIRA context from file
+bt pr create --ai --jira PROJ-123         # Include a ticket fetched from jira.base_url
 
 # AI process (step-by-step visibility):
 # 🔍 Analyzing PR context...
@@ -496,27 +587,46 @@ Portuguese Template:
 bt pr list                                 # List pull requests
 bt pr list --state open                   # Filter by state
 bt pr list --author @me                   # Your PRs only
+bt pr list --mine                         # Same as --author @me
+bt pr list --reviewer @me                 # PRs awaiting your review
+bt pr list --all --stale 14d              # PRs untouched for two weeks
+bt pr list --all --draft --stale 30d      # Abandoned work-in-progress drafts
+bt pr list --all --base @default          # PRs into the configured (repo.<name>.base, pr.base) or default base
+bt pr list --detailed                     # Approval counts, checks and size per PR
+bt pr list-all                            # Your PRs across the workspace, cached per repository
+bt pr list-all --refresh                  # Rescan every repository instead of the changed ones
 bt pr create --ai                         # AI-generated description
 bt pr create --title "Fix" --body "Desc" # Traditional creation
 
 # Review and collaboration
 bt pr view 42                             # PR details
 bt pr diff 42                             # Show changes
-bt pr files 42                            # List changed files
+bt pr diff 42 --only-added                # Only the added lines (file/hunk headers kept)
+bt pr diff 42 --word-diff                 # Highlight changed words; [-old-]{+new+} markers without color
+bt pr diff 42 --apply --check             # Check that the PR patch applies locally
+bt pr diff 42 --apply --3way              # Apply the PR patch to the working tree
+bt pr files 42                            # List changed files, most changed lines first
+bt pr files 42 --sort additions           # Order by added lines (or name, deletions, changes)
+bt pr open 42 --pipeline --show           # URL of the latest pipeline results of the PR
 bt pr review 42 --approve                 # Approve PR
+bt pr review 42 --request-changes --from-pipeline 3808  # Request changes citing CI errors
 bt pr comment 42 -b "Great work!"         # Add comment
 bt pr checkout 42                         # Switch to PR branch
 
 # Management and status
 bt pr status                              # Your PR dashboard
 bt pr checks 42                           # CI/build status
+bt pr checks 42 --required                # Which checks block the merge (branch restrictions, needs admin)
 bt pr edit 42 --title "New title"        # Edit metadata
 bt pr ready 42                            # Mark draft as ready
+bt pr ready 42 --when-checks-pass --force # Wait for green checks, then mark ready
 
 # Lifecycle
 bt pr merge 42                            # Merge PR
-bt pr merge 42 --squash --delete-branch  # Squash merge with cleanup
+bt pr merge 42 --squash --delete-branch  # Squash merge, delete remote + local branch
+bt --dry-run pr merge 42 --squash         # Print the merge's API calls without merging
 bt pr close 42                            # Close PR
+bt pr close 42 --reason "superseded by #57"  # Record why it was declined
 bt pr reopen 42                           # Reopen PR
 
 # Advanced operations
@@ -586,15 +696,31 @@ bt config list --output json        # JSON for automation
 bt config get auth.method            # Get authentication method
 bt config get auth.default_workspace # Get default workspace
 bt config get api.timeout           # Get API timeout
+bt config get --all-sources auth.default_workspace  # Value from every source, and which wins
 
 # Set configuration values with validation
 bt config set auth.default_workspace mycompany  # Set workspace
 bt config set api.timeout 60s                   # Set timeout (validates duration)
 bt config set defaults.output_format json      # Set default output format
+bt config set api.timeout 60s -o json          # {"key", "old_value", "new_value", "status": "updated"|"unchanged"}
 
 # Remove configuration (reset to default)
 bt config unset auth.default_workspace
 bt config unset api.timeout
+
+# Share a team configuration (auth.method, api.base_url and jira.base_url are never exported or imported)
+bt config export --file team.yml     # Only the keys set in your config file
+bt config import team.yml --dry-run   # Preview the changes
+bt config import team.yml --yes       # Merge without prompting
+` + "```" + `
+
+## Repository Config (.bt.yml)
+A ` + "`.bt.yml`" + ` in the repository (found by walking up from the current directory to the
+git root) takes the same keys and sets per-repo defaults. Precedence, highest first:
+BT_* environment variables > ~/.config/bt/config.yml > .bt.yml > built-in defaults.
+auth.method, api.base_url and jira.base_url are ignored in .bt.yml.
+` + "```bash" + `
+bt config get --all-sources pr.max_size   # Shows the .bt.yml layer as "repo config"
 ` + "```" + `
 
 ## Available Configuration Keys
@@ -603,7 +729,16 @@ auth.method              # Authentication method (app_password, oauth, access_to
 auth.default_workspace   # Default workspace for operations
 api.base_url            # Bitbucket API base URL
 api.timeout             # API request timeout (duration format: 30s, 1m, etc.)
+api.max_idle_conns_per_host  # Keep-alive connections per host for concurrent commands (default 16)
+api.idle_conn_timeout   # How long idle keep-alive connections are kept (default 90s)
 defaults.output_format  # Default output format (table, json, yaml)
+pr.base                 # Base branch for pr create without --base when no suffix matches (default: repo default branch)
+pr.size_thresholds.xs   # Most changed lines for an XS pull request (then s, m, l; bigger is XL; defaults 10/100/500/1000)
+pr.max_size             # pr create warns above this many changed lines (default 0: off; --max-size overrides)
+ui.max_width            # Width tables are fitted to (default 0: terminal width; --no-truncate prints cells in full)
+jira.base_url           # JIRA site --jira PROJ-123 fetches tickets from (token in JIRA_API_TOKEN, plus JIRA_EMAIL for Cloud)
+run.redact_patterns     # Extra regexes masked in run logs/view/watch/grep output on top of built-in secret patterns (--no-redact disables)
+run.step_aliases.<name> # --step alias for a step name pattern, * matches any text (bt config set run.step_aliases.ut "Build and Run Unit Tests*")
 version                 # Configuration schema version
 ` + "```" + `
 
@@ -760,3 +895,92 @@ func GetLLMHelpContent() map[string]interface{} {
 		},
 	}
 }
+
+func showIssueLLMHelp() {
+	help := `# bt issue - Issue Tracker (LLM Guide)
+
+## Overview
+Works with the repository's built-in Bitbucket issue tracker. Repositories without the tracker enabled return a not-found error.
+
+## Commands
+` + "```bash" + `
+bt issue list                              # Unresolved issues (new, open, on hold)
+bt issue list --state all --limit 100 -o json
+bt issue list --assignee @me               # Issues assigned to you
+bt issue view 7                            # State, kind, priority, people and description
+bt issue view 7 --web                      # Open in the browser
+bt issue create -t "Crash on start" -b "Steps..." --kind bug --priority critical
+bt issue create -t "Docs" --kind task --assignee @me -o json
+` + "```" + `
+
+## Values
+- **--state**: unresolved (default), all, new, open, submitted, resolved, on hold, invalid, duplicate, wontfix, closed
+- **--kind**: bug (default), enhancement, proposal, task
+- **--priority**: trivial, minor, major (default), critical, blocker
+
+## Best Practices
+1. Use ` + "`-o json`" + ` to read issue fields reliably
+2. ` + "`bt --dry-run issue create ...`" + ` shows the request without creating the issue
+`
+
+	fmt.Print(help)
+}
+
+func showAliasLLMHelp() {
+	help := `# bt alias - Command Shortcuts (LLM Guide)
+
+## Overview
+Aliases expand to a bt command line before it is parsed, so they take the flags of the command they stand for. They are saved under ` + "`aliases`" + ` in the config file; a repository's .bt.yml can define them for the whole team.
+
+## Commands
+` + "```bash" + `
+bt alias set failed 'run list --status failed'   # Then: bt failed --branch main
+bt alias set co 'pr checkout $1'                 # $1..$9 take arguments, $@ all of them
+bt alias set cleanup '!git branch --merged | xargs git branch -d'  # ! runs in the shell
+bt alias list -o json
+bt alias delete failed
+` + "```" + `
+
+## Rules
+- Arguments no placeholder takes are appended
+- An alias can't take the name of a built-in command
+- Shell aliases (!) run with the user's privileges and can't come from .bt.yml
+- Run ` + "`bt alias list`" + ` before assuming an unknown command is a typo
+`
+
+	fmt.Print(help)
+}
+
+func showAPILLMHelp() {
+	help := `# bt api - API Quota (LLM Guide)
+
+## Commands
+` + "```bash" + `
+bt api rate-limit                # Remaining API quota and reset time
+bt api rate-limit -o json        # Same, for scripts
+` + "```" + `
+
+## When to Use
+Check the quota before commands that fan out over many requests, such as ` + "`bt pr list-all`" + `, ` + "`bt run status`" + ` across a workspace or ` + "`bt run grep`" + ` over many pipelines. Rate-limited (429) requests are retried with backoff, so a low quota shows up as slow commands before it shows up as errors.
+`
+
+	fmt.Print(help)
+}
+
+func showSkillLLMHelp() {
+	help := `# bt skill - Agent Skill (LLM Guide)
+
+## Overview
+Installs a skill that teaches AI agents (Claude Code, Cursor, Codex, Pi) how to use bt for pipeline debugging, log analysis and coverage review.
+
+## Commands
+` + "```bash" + `
+bt skill add                     # Install and link to every detected agent
+bt skill status                  # Installed version and linked agents
+bt skill update                  # Update to the latest published skill
+bt skill remove                  # Remove and unlink
+` + "```" + `
+`
+
+	fmt.Print(help)
+}
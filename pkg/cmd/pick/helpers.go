@@ -1,6 +1,7 @@
 package pick
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"strings"
@@ -54,6 +55,9 @@ func loadPickConfig(flagPrefix, flagSuffixPrd, flagSuffixHml string) (*config.Pi
 
 func resolveBranches(repoDir string, cfg *config.PickConfig, reverse, debug bool) (*branchResult, error) {
 	currentBranch, err := git.GetCurrentBranchExec(repoDir)
+	if errors.Is(err, git.ErrDetachedHEAD) {
+		return nil, fmt.Errorf("you are in detached HEAD; check out the branch ending in '%s' or '%s' to pick from", cfg.SuffixPrd, cfg.SuffixHml)
+	}
 	if err != nil {
 		return nil, fmt.Errorf("failed to get current branch: %w", err)
 	}
@@ -0,0 +1,120 @@
+package pr
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/carlosarraes/bt/pkg/config"
+)
+
+// checkChecklist enforces the configured checklist when --require-checklist is set
+func (cmd *CreateCmd) checkChecklist(prCtx *PRContext, body string) error {
+	if !cmd.RequireChecklist {
+		return nil
+	}
+	return validateChecklist(configuredChecklist(prCtx), body)
+}
+
+// checkChecklistConfigured fails early, before anything is pushed,
+// generated or prompted for, when --require-checklist is set without a
+// checklist in the config
+func (cmd *CreateCmd) checkChecklistConfigured(prCtx *PRContext) error {
+	if !cmd.RequireChecklist {
+		return nil
+	}
+	return checklistConfigured(configuredChecklist(prCtx))
+}
+
+func configuredChecklist(prCtx *PRContext) config.ChecklistConfig {
+	if prCtx.Config == nil {
+		return config.ChecklistConfig{}
+	}
+	return prCtx.Config.PR.Checklist
+}
+
+func checklistConfigured(checklist config.ChecklistConfig) error {
+	if strings.TrimSpace(checklist.Section) == "" && len(checklist.Items) == 0 {
+		return fmt.Errorf("--require-checklist needs pr.checklist.section or pr.checklist.items in the config file")
+	}
+	return nil
+}
+
+// checkboxPattern matches a markdown task list item such as "- [x] Tests added"
+var checkboxPattern = regexp.MustCompile(`^\s*[-*+]\s+\[([ xX])\]\s+(.+?)\s*$`)
+
+// checklistError lists everything a description is missing from the
+// configured checklist
+type checklistError struct {
+	problems []string
+}
+
+func (e *checklistError) Error() string {
+	return "pull request description does not satisfy the required checklist:\n  - " +
+		strings.Join(e.problems, "\n  - ") +
+		"\nUpdate the description (or the saved draft) and try again"
+}
+
+// validateChecklist checks body against the configured checklist: the
+// section heading must appear and every item must be present as a checked
+// task list entry. Matching is case-insensitive and an entry only needs to
+// contain the item text.
+func validateChecklist(checklist config.ChecklistConfig, body string) error {
+	if err := checklistConfigured(checklist); err != nil {
+		return err
+	}
+	section := strings.TrimSpace(checklist.Section)
+
+	var problems []string
+	lowerBody := strings.ToLower(body)
+	if section != "" && !strings.Contains(lowerBody, strings.ToLower(section)) {
+		problems = append(problems, fmt.Sprintf("missing section %q", section))
+	}
+
+	checked, unchecked := parseCheckboxes(body)
+	for _, item := range checklist.Items {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		switch {
+		case containsItem(checked, item):
+		case containsItem(unchecked, item):
+			problems = append(problems, fmt.Sprintf("unchecked item %q", item))
+		default:
+			problems = append(problems, fmt.Sprintf("missing item %q", item))
+		}
+	}
+
+	if len(problems) > 0 {
+		return &checklistError{problems: problems}
+	}
+	return nil
+}
+
+// parseCheckboxes returns the lowercased text of checked and unchecked task list items
+func parseCheckboxes(body string) (checked, unchecked []string) {
+	for _, line := range strings.Split(body, "\n") {
+		match := checkboxPattern.FindStringSubmatch(line)
+		if match == nil {
+			continue
+		}
+		text := strings.ToLower(match[2])
+		if match[1] == " " {
+			unchecked = append(unchecked, text)
+		} else {
+			checked = append(checked, text)
+		}
+	}
+	return checked, unchecked
+}
+
+func containsItem(entries []string, item string) bool {
+	item = strings.ToLower(item)
+	for _, entry := range entries {
+		if strings.Contains(entry, item) {
+			return true
+		}
+	}
+	return false
+}
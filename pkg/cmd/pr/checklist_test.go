@@ -0,0 +1,90 @@
+package pr
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/carlosarraes/bt/pkg/config"
+)
+
+func TestValidateChecklist(t *testing.T) {
+	checklist := config.ChecklistConfig{
+		Section: "## Checklist",
+		Items:   []string{"Tests", "Docs", "Rollback plan"},
+	}
+
+	tests := []struct {
+		name     string
+		body     string
+		problems []string
+	}{
+		{
+			name: "all items checked",
+			body: "Summary\n\n## Checklist\n- [x] Tests added\n* [X] docs updated\n- [x] Rollback plan: revert the migration\n",
+		},
+		{
+			name:     "unchecked and missing items",
+			body:     "## Checklist\n- [x] Tests\n- [ ] Docs\n",
+			problems: []string{`unchecked item "Docs"`, `missing item "Rollback plan"`},
+		},
+		{
+			name:     "missing section",
+			body:     "- [x] Tests\n- [x] Docs\n- [x] Rollback plan\n",
+			problems: []string{`missing section "## Checklist"`},
+		},
+		{
+			name:     "plain text mention does not count as checked",
+			body:     "## Checklist\nTests, Docs and Rollback plan are done\n",
+			problems: []string{`missing item "Tests"`, `missing item "Docs"`, `missing item "Rollback plan"`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateChecklist(checklist, tt.body)
+			if len(tt.problems) == 0 {
+				assert.NoError(t, err)
+				return
+			}
+
+			var checklistErr *checklistError
+			require.ErrorAs(t, err, &checklistErr)
+			assert.Equal(t, tt.problems, checklistErr.problems)
+		})
+	}
+}
+
+func TestValidateChecklist_SectionOnly(t *testing.T) {
+	checklist := config.ChecklistConfig{Section: "Rollback plan"}
+
+	assert.NoError(t, validateChecklist(checklist, "## Rollback plan\nRevert the deploy"))
+	assert.Error(t, validateChecklist(checklist, "Just a summary"))
+}
+
+func TestValidateChecklist_NotConfigured(t *testing.T) {
+	err := validateChecklist(config.ChecklistConfig{}, "- [x] Tests")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "pr.checklist")
+}
+
+func TestCreateCmd_checkChecklist(t *testing.T) {
+	prCtx := &PRContext{Config: &config.Config{PR: config.PRConfig{
+		Checklist: config.ChecklistConfig{Items: []string{"Tests"}},
+	}}}
+
+	assert.NoError(t, (&CreateCmd{}).checkChecklist(prCtx, "no checklist"), "not enforced without --require-checklist")
+	assert.Error(t, (&CreateCmd{RequireChecklist: true}).checkChecklist(prCtx, "no checklist"))
+	assert.NoError(t, (&CreateCmd{RequireChecklist: true}).checkChecklist(prCtx, "- [x] Tests"))
+}
+
+func TestCreateCmd_checkChecklistConfigured(t *testing.T) {
+	configured := &PRContext{Config: &config.Config{PR: config.PRConfig{
+		Checklist: config.ChecklistConfig{Items: []string{"Tests"}},
+	}}}
+
+	assert.NoError(t, (&CreateCmd{}).checkChecklistConfigured(&PRContext{}))
+	assert.NoError(t, (&CreateCmd{RequireChecklist: true}).checkChecklistConfigured(configured))
+	assert.ErrorContains(t, (&CreateCmd{RequireChecklist: true}).checkChecklistConfigured(&PRContext{}), "pr.checklist")
+}
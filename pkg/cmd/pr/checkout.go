@@ -5,14 +5,16 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/carlosarraes/bt/pkg/api"
 	"github.com/carlosarraes/bt/pkg/cmd/shared"
 	"github.com/carlosarraes/bt/pkg/git"
 )
 
 type CheckoutCmd struct {
-	PRID       string `arg:"" help:"Pull request ID (number)"`
+	PRID       string `arg:"" optional:"" help:"Pull request ID (number); omit to pick from open pull requests"`
 	Detach     bool   `help:"Checkout in detached HEAD mode"`
 	Force      bool   `short:"f" help:"Force checkout, discarding local changes"`
+	Cleanup    bool   `help:"After the PR is merged, switch to the default branch and delete its local and remote branches"`
 	Output     string `short:"o" help:"Output format (table, json, yaml)" enum:"table,json,yaml" default:"table"`
 	NoColor    bool
 	Workspace  string
@@ -20,37 +22,65 @@ type CheckoutCmd struct {
 }
 
 func (c *CheckoutCmd) Run(ctx context.Context) error {
+	if c.Cleanup && c.Detach {
+		return fmt.Errorf("--cleanup and --detach cannot be used together")
+	}
+
 	prCtx, err := shared.NewCommandContext(ctx, c.Output, c.NoColor)
 	if err != nil {
 		return err
 	}
 
-	if c.Workspace != "" {
-		prCtx.Workspace = c.Workspace
-	}
-	if c.Repository != "" {
-		prCtx.Repository = c.Repository
-	}
-
-	if err := prCtx.ValidateWorkspaceAndRepo(); err != nil {
+	if err := prCtx.ResolveRepository(c.Workspace, c.Repository); err != nil {
 		return err
 	}
 
-	prID, err := ParsePRID(c.PRID)
-	if err != nil {
-		return fmt.Errorf("invalid pull request ID: %s", c.PRID)
-	}
-
 	gitRepo, err := git.NewRepository("")
 	if err != nil {
 		return fmt.Errorf("not in a git repository: %w", err)
 	}
 
+	var prID int
+	switch {
+	case c.PRID == "" && c.Cleanup:
+		prID, err = findMergedPRForCurrentBranch(ctx, prCtx, gitRepo)
+		if err != nil {
+			return err
+		}
+	case c.PRID == "":
+		prID, err = selectPullRequest(ctx, prCtx, c.Output)
+		if err != nil {
+			return err
+		}
+	default:
+		if prID, err = ParsePRID(c.PRID); err != nil {
+			return fmt.Errorf("invalid pull request ID: %s", c.PRID)
+		}
+	}
+
 	pr, err := prCtx.Client.PullRequests.GetPullRequest(ctx, prCtx.Workspace, prCtx.Repository, prID)
 	if err != nil {
 		return fmt.Errorf("failed to get pull request: %w", err)
 	}
 
+	if c.Cleanup {
+		return c.cleanupPullRequest(ctx, prCtx, gitRepo, pr)
+	}
+
+	return CheckoutPullRequest(prCtx, gitRepo, pr, CheckoutOptions{Detach: c.Detach, Force: c.Force})
+}
+
+// CheckoutOptions controls how CheckoutPullRequest switches branches
+type CheckoutOptions struct {
+	Detach bool
+	Force  bool
+}
+
+// CheckoutPullRequest fetches the pull request's source branch into gitRepo
+// and switches to it, adding a pr-<id> remote when the source is a fork
+func CheckoutPullRequest(prCtx *PRContext, gitRepo *git.Repository, pr *api.PullRequest, opts CheckoutOptions) error {
+	prID := pr.ID
+
 	if pr.Source == nil || pr.Source.Branch == nil {
 		return fmt.Errorf("pull request source branch information not available")
 	}
@@ -80,7 +110,7 @@ func (c *CheckoutCmd) Run(ctx context.Context) error {
 		remoteName = "origin"
 	}
 
-	if !c.Force {
+	if !opts.Force {
 		hasChanges, err := gitRepo.HasUncommittedChanges()
 		if err != nil {
 			return fmt.Errorf("failed to check for uncommitted changes: %w", err)
@@ -123,17 +153,17 @@ func (c *CheckoutCmd) Run(ctx context.Context) error {
 	}
 
 	fmt.Printf("Switching to branch: %s\n", localBranch)
-	if c.Force {
-		if err := gitRepo.ForceCheckoutBranch(localBranch, c.Detach); err != nil {
+	if opts.Force {
+		if err := gitRepo.ForceCheckoutBranch(localBranch, opts.Detach); err != nil {
 			return fmt.Errorf("failed to checkout branch: %w", err)
 		}
 	} else {
-		if err := gitRepo.CheckoutBranch(localBranch, c.Detach); err != nil {
+		if err := gitRepo.CheckoutBranch(localBranch, opts.Detach); err != nil {
 			return fmt.Errorf("failed to checkout branch: %w", err)
 		}
 	}
 
-	if c.Detach {
+	if opts.Detach {
 		fmt.Printf("Checked out PR #%d in detached HEAD mode\n", prID)
 	} else {
 		fmt.Printf("Checked out PR #%d to branch '%s'\n", prID, localBranch)
@@ -3,20 +3,27 @@ package pr
 import (
 	"context"
 	"fmt"
+	"os"
 	"strings"
 	"time"
 
 	"github.com/carlosarraes/bt/pkg/api"
 	"github.com/carlosarraes/bt/pkg/cmd/shared"
+	"github.com/carlosarraes/bt/pkg/output"
 )
 
 type ChecksCmd struct {
 	PRID       string `arg:"" help:"Pull request ID (number)"`
 	Watch      bool   `short:"w" help:"Watch for live updates"`
+	Required   bool   `help:"Mark checks required for merge by the destination branch's restrictions"`
 	Output     string `short:"o" help:"Output format (table, json, yaml)" enum:"table,json,yaml" default:"table"`
+	Events     string `help:"With --watch, print JSON progress events (jsonl) instead of text"`
 	NoColor    bool
 	Workspace  string `help:"Bitbucket workspace (defaults to git remote or config)"`
 	Repository string `help:"Repository name (defaults to git remote)"`
+
+	statuses     []*api.CommitStatus
+	requirements *mergeRequirements
 }
 
 func (cmd *ChecksCmd) Run(ctx context.Context) error {
@@ -25,14 +32,7 @@ func (cmd *ChecksCmd) Run(ctx context.Context) error {
 		return err
 	}
 
-	if cmd.Workspace != "" {
-		prCtx.Workspace = cmd.Workspace
-	}
-	if cmd.Repository != "" {
-		prCtx.Repository = cmd.Repository
-	}
-
-	if err := prCtx.ValidateWorkspaceAndRepo(); err != nil {
+	if err := prCtx.ResolveRepository(cmd.Workspace, cmd.Repository); err != nil {
 		return err
 	}
 
@@ -41,6 +41,16 @@ func (cmd *ChecksCmd) Run(ctx context.Context) error {
 		return err
 	}
 
+	if cmd.Events != "" {
+		if !cmd.Watch {
+			return fmt.Errorf("--events requires --watch")
+		}
+		if err := output.ValidateEventsFormat(cmd.Events); err != nil {
+			return err
+		}
+		return cmd.streamCheckEvents(ctx, prCtx, prID, output.NewEventWriter(os.Stdout))
+	}
+
 	if cmd.Watch {
 		return cmd.watchChecks(ctx, prCtx, prID)
 	}
@@ -63,13 +73,24 @@ func (cmd *ChecksCmd) getChecks(ctx context.Context, prCtx *PRContext, prID int)
 		return nil, handlePullRequestAPIError(err)
 	}
 
+	return cmd.checksForPullRequest(ctx, prCtx, pr)
+}
+
+// checksForPullRequest lists the pipelines run on the source commit of pr and
+// keeps the commit statuses other tools reported in cmd.statuses
+func (cmd *ChecksCmd) checksForPullRequest(ctx context.Context, prCtx *PRContext, pr *api.PullRequest) ([]*api.Pipeline, error) {
 	commitSHA := ""
 	if pr.Source != nil && pr.Source.Commit != nil {
 		commitSHA = pr.Source.Commit.Hash
 	}
 
 	if commitSHA == "" {
-		return nil, fmt.Errorf("unable to find commit SHA for pull request #%d", prID)
+		return nil, fmt.Errorf("unable to find commit SHA for pull request #%d", pr.ID)
+	}
+
+	// Restrictions don't change while watching, so they are read once
+	if cmd.Required && cmd.requirements == nil && pr.Destination != nil && pr.Destination.Branch != nil {
+		cmd.requirements = loadMergeRequirements(ctx, prCtx, pr.Destination.Branch.Name)
 	}
 
 	pipelines, err := prCtx.Client.Pipelines.GetPipelinesByCommit(ctx, prCtx.Workspace, prCtx.Repository, commitSHA)
@@ -77,9 +98,29 @@ func (cmd *ChecksCmd) getChecks(ctx context.Context, prCtx *PRContext, prID int)
 		return nil, fmt.Errorf("failed to get pipelines for commit %s: %w", commitSHA, err)
 	}
 
+	// Statuses published by other tools are supplementary; a failure to
+	// list them shouldn't hide the pipeline checks.
+	statuses, err := prCtx.Client.CommitStatuses.ListCommitStatuses(ctx, prCtx.Workspace, prCtx.Repository, commitSHA)
+	if err == nil {
+		cmd.statuses = externalStatuses(statuses)
+	}
+
 	return pipelines, nil
 }
 
+// externalStatuses drops the statuses Bitbucket Pipelines reports for its own
+// runs, which are already listed as pipeline checks
+func externalStatuses(statuses []*api.CommitStatus) []*api.CommitStatus {
+	var external []*api.CommitStatus
+	for _, status := range statuses {
+		if strings.Contains(status.URL, "/addon/pipelines/") {
+			continue
+		}
+		external = append(external, status)
+	}
+	return external
+}
+
 func (cmd *ChecksCmd) watchChecks(ctx context.Context, prCtx *PRContext, prID int) error {
 	checks, err := cmd.getChecks(ctx, prCtx, prID)
 	if err != nil {
@@ -172,8 +213,15 @@ func (cmd *ChecksCmd) formatOutput(prCtx *PRContext, checks []*api.Pipeline) err
 }
 
 func (cmd *ChecksCmd) formatTable(prCtx *PRContext, checks []*api.Pipeline) error {
-	if len(checks) == 0 {
+	if len(checks) == 0 && len(cmd.statuses) == 0 {
 		fmt.Println("No CI checks found for this pull request.")
+		cmd.printMergeVerdict(checks)
+		return nil
+	}
+
+	if len(checks) == 0 {
+		cmd.printStatuses()
+		cmd.printMergeVerdict(checks)
 		return nil
 	}
 
@@ -191,6 +239,7 @@ func (cmd *ChecksCmd) formatTable(prCtx *PRContext, checks []*api.Pipeline) erro
 		if duration != "" {
 			fmt.Printf(" (%s)", duration)
 		}
+		fmt.Print(cmd.requirementLabel())
 		fmt.Println()
 
 		if pipeline.State != nil && pipeline.State.Name == "FAILED" {
@@ -200,9 +249,64 @@ func (cmd *ChecksCmd) formatTable(prCtx *PRContext, checks []*api.Pipeline) erro
 		}
 	}
 
+	if len(cmd.statuses) > 0 {
+		fmt.Println()
+		cmd.printStatuses()
+	}
+
+	cmd.printMergeVerdict(checks)
 	return nil
 }
 
+func (cmd *ChecksCmd) requirementLabel() string {
+	if cmd.requirements == nil {
+		return ""
+	}
+	return cmd.requirements.requirementLabel()
+}
+
+func (cmd *ChecksCmd) printMergeVerdict(checks []*api.Pipeline) {
+	if cmd.requirements == nil {
+		return
+	}
+	fmt.Println()
+	fmt.Println(cmd.requirements.mergeVerdict(cmd.countChecks(checks)))
+}
+
+func (cmd *ChecksCmd) printStatuses() {
+	fmt.Printf("Commit statuses for pull request #%s:\n\n", cmd.PRID)
+	for _, status := range cmd.statuses {
+		name := status.Name
+		if name == "" {
+			name = status.Key
+		}
+		fmt.Printf("%s %s", commitStatusIndicator(status.State), name)
+		if status.Description != "" {
+			fmt.Printf(" - %s", status.Description)
+		}
+		fmt.Print(cmd.requirementLabel())
+		fmt.Println()
+		if status.URL != "" {
+			fmt.Printf("  └─ %s\n", status.URL)
+		}
+	}
+}
+
+func commitStatusIndicator(state string) string {
+	switch state {
+	case "SUCCESSFUL":
+		return "✓ "
+	case "FAILED":
+		return "✗ "
+	case "INPROGRESS":
+		return "● "
+	case "STOPPED":
+		return "◐ "
+	default:
+		return "○ "
+	}
+}
+
 func (cmd *ChecksCmd) getStatusIndicator(pipeline *api.Pipeline) string {
 	if pipeline.State == nil {
 		return "○ "
@@ -384,6 +488,14 @@ func (cmd *ChecksCmd) formatJSON(prCtx *PRContext, checks []*api.Pipeline) error
 		},
 	}
 
+	if len(cmd.statuses) > 0 {
+		output["statuses"] = cmd.statuses
+	}
+
+	if cmd.requirements != nil {
+		output["merge_requirements"] = cmd.requirements
+	}
+
 	return prCtx.Formatter.Format(output)
 }
 
@@ -400,6 +512,14 @@ func (cmd *ChecksCmd) formatYAML(prCtx *PRContext, checks []*api.Pipeline) error
 		},
 	}
 
+	if len(cmd.statuses) > 0 {
+		output["statuses"] = cmd.statuses
+	}
+
+	if cmd.requirements != nil {
+		output["merge_requirements"] = cmd.requirements
+	}
+
 	return prCtx.Formatter.Format(output)
 }
 
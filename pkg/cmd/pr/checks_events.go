@@ -0,0 +1,132 @@
+package pr
+
+import (
+	"context"
+	"time"
+
+	"github.com/carlosarraes/bt/pkg/api"
+	"github.com/carlosarraes/bt/pkg/output"
+)
+
+// checkEventInterval is how often --watch --events polls the checks
+const checkEventInterval = 5 * time.Second
+
+// streamCheckEvents is pr checks --watch --events: it reports every change
+// of a check's status as a check_updated event until all checks finish
+func (cmd *ChecksCmd) streamCheckEvents(ctx context.Context, prCtx *PRContext, prID int, events *output.EventWriter) error {
+	if err := events.Emit(output.Event{Event: output.EventWatchStarted, PullRequest: prID}); err != nil {
+		return err
+	}
+
+	ticker := time.NewTicker(checkEventInterval)
+	defer ticker.Stop()
+
+	tracker := newCheckEvents(prID)
+	for {
+		checks, err := cmd.getChecks(ctx, prCtx, prID)
+		if err != nil {
+			return err
+		}
+
+		for _, event := range tracker.update(cmd, checks) {
+			if err := events.Emit(event); err != nil {
+				return err
+			}
+		}
+
+		counts := cmd.countChecks(checks)
+		if cmd.allChecksCompleted(checks) && counts.running == 0 {
+			return events.Emit(output.Event{
+				Event:       output.EventChecksCompleted,
+				PullRequest: prID,
+				Status:      checksOutcome(counts),
+				Message:     cmd.getChecksSummary(checks),
+			})
+		}
+
+		select {
+		case <-ctx.Done():
+			events.Emit(output.Event{Event: output.EventInterrupted, PullRequest: prID})
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// checkEvents remembers the last reported status of each check
+type checkEvents struct {
+	pullRequest int
+	statuses    map[string]string
+}
+
+func newCheckEvents(pullRequest int) *checkEvents {
+	return &checkEvents{pullRequest: pullRequest, statuses: make(map[string]string)}
+}
+
+// update returns a check_updated event for every pipeline and commit status
+// that is new or changed since the previous poll
+func (c *checkEvents) update(cmd *ChecksCmd, checks []*api.Pipeline) []output.Event {
+	var events []output.Event
+
+	for _, pipeline := range checks {
+		status := checkPipelineStatus(pipeline)
+		if c.changed("pipeline:"+pipeline.UUID, status) {
+			events = append(events, output.Event{
+				Event:       output.EventCheckUpdated,
+				PullRequest: c.pullRequest,
+				Pipeline:    pipeline.BuildNumber,
+				Check:       cmd.getPipelineName(pipeline),
+				Status:      status,
+			})
+		}
+	}
+
+	for _, status := range cmd.statuses {
+		name := status.Name
+		if name == "" {
+			name = status.Key
+		}
+		if c.changed("status:"+status.Key, status.State) {
+			events = append(events, output.Event{
+				Event:       output.EventCheckUpdated,
+				PullRequest: c.pullRequest,
+				Check:       name,
+				Status:      status.State,
+				Message:     status.URL,
+			})
+		}
+	}
+
+	return events
+}
+
+func (c *checkEvents) changed(key, status string) bool {
+	if c.statuses[key] == status {
+		return false
+	}
+	c.statuses[key] = status
+	return true
+}
+
+// checkPipelineStatus is the pipeline's result once it has one
+func checkPipelineStatus(pipeline *api.Pipeline) string {
+	if pipeline.State == nil {
+		return "UNKNOWN"
+	}
+	if pipeline.State.Result != nil && pipeline.State.Result.Name != "" {
+		return pipeline.State.Result.Name
+	}
+	return pipeline.State.Name
+}
+
+// checksOutcome is the overall status reported by checks_completed
+func checksOutcome(counts checkCounts) string {
+	switch {
+	case counts.failed > 0:
+		return "FAILED"
+	case counts.successful > 0:
+		return "SUCCESSFUL"
+	default:
+		return "NONE"
+	}
+}
@@ -0,0 +1,36 @@
+package pr
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/carlosarraes/bt/pkg/api"
+	"github.com/carlosarraes/bt/pkg/output"
+)
+
+func TestCheckEvents_update(t *testing.T) {
+	cmd := &ChecksCmd{statuses: []*api.CommitStatus{{Key: "lint", Name: "Lint", State: "INPROGRESS", URL: "https://ci.example.com/1"}}}
+	pipeline := &api.Pipeline{UUID: "{p1}", BuildNumber: 7, State: &api.PipelineState{Name: "IN_PROGRESS"}}
+
+	tracker := newCheckEvents(42)
+
+	events := tracker.update(cmd, []*api.Pipeline{pipeline})
+	require.Len(t, events, 2)
+	assert.Equal(t, output.Event{Event: output.EventCheckUpdated, PullRequest: 42, Pipeline: 7, Check: "Pipeline #7", Status: "IN_PROGRESS"}, events[0])
+	assert.Equal(t, output.Event{Event: output.EventCheckUpdated, PullRequest: 42, Check: "Lint", Status: "INPROGRESS", Message: "https://ci.example.com/1"}, events[1])
+
+	assert.Empty(t, tracker.update(cmd, []*api.Pipeline{pipeline}))
+
+	pipeline.State = &api.PipelineState{Name: "COMPLETED", Result: &api.PipelineResult{Name: "FAILED"}}
+	events = tracker.update(cmd, []*api.Pipeline{pipeline})
+	require.Len(t, events, 1)
+	assert.Equal(t, "FAILED", events[0].Status)
+}
+
+func TestChecksOutcome(t *testing.T) {
+	assert.Equal(t, "FAILED", checksOutcome(checkCounts{failed: 1, successful: 2}))
+	assert.Equal(t, "SUCCESSFUL", checksOutcome(checkCounts{successful: 2}))
+	assert.Equal(t, "NONE", checksOutcome(checkCounts{}))
+}
@@ -0,0 +1,197 @@
+package pr
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/carlosarraes/bt/pkg/api"
+)
+
+// mergeRequirements is what the destination branch's restrictions demand of
+// the checks before a pull request can be merged. Bitbucket applies
+// require_passing_builds_to_merge to every build of the source commit, so a
+// check is either required along with all the others or informational.
+type mergeRequirements struct {
+	Branch                string `json:"branch" yaml:"branch"`
+	Known                 bool   `json:"known" yaml:"known"`
+	Note                  string `json:"note,omitempty" yaml:"note,omitempty"`
+	PassingBuildsRequired bool   `json:"passing_builds_required" yaml:"passing_builds_required"`
+	MinSuccessfulBuilds   int    `json:"min_successful_builds,omitempty" yaml:"min_successful_builds,omitempty"`
+	// Enforced is set when merge checks block the merge rather than warn
+	Enforced bool `json:"enforced" yaml:"enforced"`
+}
+
+// loadMergeRequirements reads the branch restrictions applying to branch.
+// Restrictions need admin access, so failures are reported in Note instead
+// of failing the command.
+func loadMergeRequirements(ctx context.Context, prCtx *PRContext, branch string) *mergeRequirements {
+	restrictions, err := prCtx.Client.BranchRestrictions.ListBranchRestrictions(ctx, prCtx.Workspace, prCtx.Repository, "")
+	if err != nil {
+		return &mergeRequirements{Branch: branch, Note: restrictionErrorNote(err)}
+	}
+
+	var model *api.BranchingModel
+	for _, restriction := range restrictions {
+		if restriction.BranchMatchKind == "branching_model" {
+			// Without the model, branching model restrictions just don't match
+			model, _ = prCtx.Client.BranchRestrictions.GetBranchingModel(ctx, prCtx.Workspace, prCtx.Repository)
+			break
+		}
+	}
+
+	return newMergeRequirements(branch, restrictions, model)
+}
+
+func newMergeRequirements(branch string, restrictions []*api.BranchRestriction, model *api.BranchingModel) *mergeRequirements {
+	requirements := &mergeRequirements{Branch: branch, Known: true}
+
+	for _, restriction := range restrictions {
+		if !restrictionMatches(restriction, branch, model) {
+			continue
+		}
+
+		switch restriction.Kind {
+		case api.RestrictionRequirePassingBuilds:
+			requirements.PassingBuildsRequired = true
+			if restriction.Value != nil && *restriction.Value > requirements.MinSuccessfulBuilds {
+				requirements.MinSuccessfulBuilds = *restriction.Value
+			}
+		case api.RestrictionEnforceMergeChecks:
+			requirements.Enforced = true
+		}
+	}
+
+	return requirements
+}
+
+func restrictionErrorNote(err error) string {
+	var bbErr *api.BitbucketError
+	if errors.As(err, &bbErr) && (bbErr.Type == api.ErrorTypePermission || bbErr.Type == api.ErrorTypeAuthentication) {
+		return "reading branch restrictions requires admin access to the repository"
+	}
+	return fmt.Sprintf("could not read branch restrictions: %v", err)
+}
+
+// restrictionMatches reports whether a restriction applies to branch, by
+// glob pattern or by the branching model's branch types
+func restrictionMatches(restriction *api.BranchRestriction, branch string, model *api.BranchingModel) bool {
+	if restriction.BranchMatchKind != "branching_model" {
+		return branchGlobMatches(restriction.Pattern, branch)
+	}
+
+	if model == nil {
+		return false
+	}
+
+	switch restriction.BranchType {
+	case "development":
+		return modelBranchName(model.Development) == branch
+	case "production":
+		return modelBranchName(model.Production) == branch
+	default:
+		for _, branchType := range model.BranchTypes {
+			if branchType.Kind == restriction.BranchType && branchType.Prefix != "" {
+				return strings.HasPrefix(branch, branchType.Prefix)
+			}
+		}
+		return false
+	}
+}
+
+func modelBranchName(branch *api.BranchingModelBranch) string {
+	if branch == nil {
+		return ""
+	}
+	if branch.Branch != nil && branch.Branch.Name != "" {
+		return branch.Branch.Name
+	}
+	return branch.Name
+}
+
+// branchGlobMatches matches Bitbucket branch patterns, where * matches any
+// run of characters, slashes included
+func branchGlobMatches(pattern, branch string) bool {
+	if pattern == "" {
+		return false
+	}
+	expr := "^" + strings.ReplaceAll(regexp.QuoteMeta(pattern), `\*`, ".*") + "$"
+	return regexp.MustCompile(expr).MatchString(branch)
+}
+
+// requirementLabel marks a check as blocking the merge or informational
+func (r *mergeRequirements) requirementLabel() string {
+	switch {
+	case !r.Known:
+		return ""
+	case r.PassingBuildsRequired:
+		return " [required]"
+	default:
+		return " [informational]"
+	}
+}
+
+// checkCounts tallies failed, running and successful checks, pipelines and
+// commit statuses alike
+type checkCounts struct {
+	failed, running, successful int
+}
+
+func (cmd *ChecksCmd) countChecks(checks []*api.Pipeline) checkCounts {
+	var counts checkCounts
+	for _, pipeline := range checks {
+		switch cmd.getPipelinePriority(pipeline) {
+		case 0:
+			counts.failed++
+		case 1, 2:
+			counts.running++
+		case 3:
+			counts.successful++
+		}
+	}
+	for _, status := range cmd.statuses {
+		switch status.State {
+		case "FAILED":
+			counts.failed++
+		case "INPROGRESS":
+			counts.running++
+		case "SUCCESSFUL":
+			counts.successful++
+		}
+	}
+	return counts
+}
+
+// mergeVerdict sums up whether the checks allow merging into the branch
+func (r *mergeRequirements) mergeVerdict(counts checkCounts) string {
+	if !r.Known {
+		return fmt.Sprintf("❔ Required checks for %s are unknown: %s", r.Branch, r.Note)
+	}
+	if !r.PassingBuildsRequired {
+		return fmt.Sprintf("ℹ️  No passing builds are required to merge into %s; all checks are informational", r.Branch)
+	}
+
+	minimum := r.MinSuccessfulBuilds
+	if minimum < 1 {
+		minimum = 1
+	}
+
+	var problem string
+	switch {
+	case counts.failed > 0:
+		problem = fmt.Sprintf("%d required check(s) failed", counts.failed)
+	case counts.running > 0:
+		problem = fmt.Sprintf("%d required check(s) still running", counts.running)
+	case counts.successful < minimum:
+		problem = fmt.Sprintf("%d successful check(s), %d required", counts.successful, minimum)
+	default:
+		return fmt.Sprintf("✅ Required checks pass for %s", r.Branch)
+	}
+
+	if !r.Enforced {
+		return fmt.Sprintf("⚠️  %s; merge checks aren't enforced on %s, so merging only shows a warning", problem, r.Branch)
+	}
+	return fmt.Sprintf("⛔ Merge into %s blocked: %s", r.Branch, problem)
+}
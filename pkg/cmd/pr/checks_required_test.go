@@ -0,0 +1,102 @@
+package pr
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/carlosarraes/bt/pkg/api"
+)
+
+func intPtr(v int) *int { return &v }
+
+func TestRestrictionMatches(t *testing.T) {
+	model := &api.BranchingModel{
+		Development: &api.BranchingModelBranch{Branch: &api.Branch{Name: "develop"}},
+		Production:  &api.BranchingModelBranch{Name: "main"},
+		BranchTypes: []*api.BranchType{{Kind: "release", Prefix: "release/"}},
+	}
+
+	tests := []struct {
+		name        string
+		restriction api.BranchRestriction
+		branch      string
+		want        bool
+	}{
+		{name: "exact glob", restriction: api.BranchRestriction{BranchMatchKind: "glob", Pattern: "main"}, branch: "main", want: true},
+		{name: "glob mismatch", restriction: api.BranchRestriction{BranchMatchKind: "glob", Pattern: "main"}, branch: "maintenance", want: false},
+		{name: "wildcard spans slashes", restriction: api.BranchRestriction{BranchMatchKind: "glob", Pattern: "release/*"}, branch: "release/1.2/rc", want: true},
+		{name: "dots are literal", restriction: api.BranchRestriction{BranchMatchKind: "glob", Pattern: "v1.*"}, branch: "v12", want: false},
+		{name: "development branch", restriction: api.BranchRestriction{BranchMatchKind: "branching_model", BranchType: "development"}, branch: "develop", want: true},
+		{name: "production branch", restriction: api.BranchRestriction{BranchMatchKind: "branching_model", BranchType: "production"}, branch: "main", want: true},
+		{name: "branch type prefix", restriction: api.BranchRestriction{BranchMatchKind: "branching_model", BranchType: "release"}, branch: "release/2.0", want: true},
+		{name: "unknown branch type", restriction: api.BranchRestriction{BranchMatchKind: "branching_model", BranchType: "hotfix"}, branch: "hotfix/x", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, restrictionMatches(&tt.restriction, tt.branch, model))
+		})
+	}
+
+	assert.False(t, restrictionMatches(&api.BranchRestriction{BranchMatchKind: "branching_model", BranchType: "development"}, "develop", nil))
+}
+
+func TestNewMergeRequirements(t *testing.T) {
+	restrictions := []*api.BranchRestriction{
+		{Kind: api.RestrictionRequirePassingBuilds, BranchMatchKind: "glob", Pattern: "main", Value: intPtr(1)},
+		{Kind: api.RestrictionRequirePassingBuilds, BranchMatchKind: "glob", Pattern: "*", Value: intPtr(2)},
+		{Kind: api.RestrictionEnforceMergeChecks, BranchMatchKind: "glob", Pattern: "main"},
+		{Kind: "require_approvals_to_merge", BranchMatchKind: "glob", Pattern: "main", Value: intPtr(5)},
+	}
+
+	main := newMergeRequirements("main", restrictions, nil)
+	assert.True(t, main.Known)
+	assert.True(t, main.PassingBuildsRequired)
+	assert.Equal(t, 2, main.MinSuccessfulBuilds)
+	assert.True(t, main.Enforced)
+	assert.Equal(t, " [required]", main.requirementLabel())
+
+	none := newMergeRequirements("develop", restrictions[2:], nil)
+	assert.False(t, none.PassingBuildsRequired)
+	assert.Equal(t, " [informational]", none.requirementLabel())
+}
+
+func TestRestrictionErrorNote(t *testing.T) {
+	forbidden := fmt.Errorf("failed to fetch branch restrictions: %w", &api.BitbucketError{Type: api.ErrorTypePermission, StatusCode: 403})
+	assert.Contains(t, restrictionErrorNote(forbidden), "admin access")
+
+	assert.Contains(t, restrictionErrorNote(fmt.Errorf("boom")), "could not read branch restrictions: boom")
+}
+
+func TestMergeRequirements_mergeVerdict(t *testing.T) {
+	enforced := &mergeRequirements{Branch: "main", Known: true, PassingBuildsRequired: true, Enforced: true}
+	warnOnly := &mergeRequirements{Branch: "main", Known: true, PassingBuildsRequired: true, MinSuccessfulBuilds: 2}
+
+	assert.Contains(t, enforced.mergeVerdict(checkCounts{failed: 1, successful: 3}), "blocked: 1 required check(s) failed")
+	assert.Contains(t, enforced.mergeVerdict(checkCounts{running: 2}), "2 required check(s) still running")
+	assert.Contains(t, enforced.mergeVerdict(checkCounts{}), "0 successful check(s), 1 required")
+	assert.Contains(t, enforced.mergeVerdict(checkCounts{successful: 1}), "Required checks pass")
+
+	assert.Contains(t, warnOnly.mergeVerdict(checkCounts{successful: 1}), "1 successful check(s), 2 required; merge checks aren't enforced")
+
+	informational := &mergeRequirements{Branch: "develop", Known: true}
+	assert.Contains(t, informational.mergeVerdict(checkCounts{failed: 1}), "all checks are informational")
+
+	unknown := &mergeRequirements{Branch: "main", Note: "reading branch restrictions requires admin access to the repository"}
+	assert.Contains(t, unknown.mergeVerdict(checkCounts{}), "unknown: reading branch restrictions")
+	assert.Empty(t, unknown.requirementLabel())
+}
+
+func TestChecksCmd_countChecks(t *testing.T) {
+	cmd := &ChecksCmd{statuses: []*api.CommitStatus{{State: "FAILED"}, {State: "INPROGRESS"}, {State: "SUCCESSFUL"}}}
+	checks := []*api.Pipeline{
+		{State: &api.PipelineState{Name: "FAILED"}},
+		{State: &api.PipelineState{Name: "SUCCESSFUL"}},
+		{State: &api.PipelineState{Name: "PENDING"}},
+		{State: &api.PipelineState{Name: "STOPPED"}},
+	}
+
+	assert.Equal(t, checkCounts{failed: 2, running: 2, successful: 2}, cmd.countChecks(checks))
+}
@@ -639,6 +639,37 @@ func TestChecksCmd_formatOutput_UnsupportedFormat(t *testing.T) {
 	}
 }
 
+func TestExternalStatuses(t *testing.T) {
+	statuses := []*api.CommitStatus{
+		{Key: "pipeline", State: "SUCCESSFUL", URL: "https://bitbucket.org/ws/repo/addon/pipelines/home#!/results/12"},
+		{Key: "lint", State: "FAILED", URL: "https://ci.example.com/lint/1"},
+	}
+
+	external := externalStatuses(statuses)
+	if len(external) != 1 {
+		t.Fatalf("externalStatuses() returned %d statuses, expected 1", len(external))
+	}
+	if external[0].Key != "lint" {
+		t.Errorf("externalStatuses() kept %q, expected %q", external[0].Key, "lint")
+	}
+}
+
+func TestCommitStatusIndicator(t *testing.T) {
+	tests := map[string]string{
+		"SUCCESSFUL": "✓ ",
+		"FAILED":     "✗ ",
+		"INPROGRESS": "● ",
+		"STOPPED":    "◐ ",
+		"UNKNOWN":    "○ ",
+	}
+
+	for state, expected := range tests {
+		if got := commitStatusIndicator(state); got != expected {
+			t.Errorf("commitStatusIndicator(%q) = %q, expected %q", state, got, expected)
+		}
+	}
+}
+
 func TestChecksCmd_Run_InvalidPRID(t *testing.T) {
 	cmd := &ChecksCmd{
 		PRID: "invalid",
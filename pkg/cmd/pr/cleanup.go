@@ -0,0 +1,242 @@
+package pr
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/carlosarraes/bt/pkg/api"
+	"github.com/carlosarraes/bt/pkg/cmd/shared"
+	"github.com/carlosarraes/bt/pkg/git"
+)
+
+// cleanupPullRequest tidies up after a merged pull request: it switches to
+// the default branch, deletes the local PR branch and deletes the source
+// branch on Bitbucket when merging didn't already. Branches holding commits
+// that weren't part of the merged pull request are kept unless --force.
+func (c *CheckoutCmd) cleanupPullRequest(ctx context.Context, prCtx *PRContext, gitRepo *git.Repository, pr *api.PullRequest) error {
+	if pr.State != "MERGED" {
+		return fmt.Errorf("pull request #%d is %s; --cleanup only removes the branches of merged pull requests",
+			pr.ID, strings.ToLower(pr.State))
+	}
+	if pr.Source == nil || pr.Source.Branch == nil || pr.Source.Commit == nil {
+		return fmt.Errorf("pull request source branch information not available")
+	}
+
+	sourceBranch := pr.Source.Branch.Name
+	mergedHash := pr.Source.Commit.Hash
+
+	defaultBranch, err := gitRepo.GetDefaultBranch()
+	if err != nil {
+		return fmt.Errorf("failed to determine the default branch: %w", err)
+	}
+	if sourceBranch == defaultBranch || sourceBranch == getBranchName(pr.Destination) {
+		return fmt.Errorf("pull request #%d was opened from %s, which --cleanup won't delete", pr.ID, sourceBranch)
+	}
+	if !gitRepo.BranchExists(defaultBranch) {
+		return fmt.Errorf("default branch %s doesn't exist locally", defaultBranch)
+	}
+
+	if prCtx.DryRun {
+		return prCtx.PrintDryRun(c.cleanupDryRunPlan(prCtx, gitRepo, pr, defaultBranch), c.Output)
+	}
+
+	if err := deleteLocalPRBranches(os.Stdout, gitRepo, pr, defaultBranch, c.Force, "--force"); err != nil {
+		return err
+	}
+
+	if isForkPullRequest(prCtx, pr) {
+		fmt.Printf("Source branch %s lives in a fork; leaving it alone\n", sourceBranch)
+		return nil
+	}
+
+	return c.cleanupRemoteBranch(ctx, prCtx, sourceBranch, mergedHash)
+}
+
+// cleanupDryRunPlan lists what cleanupPullRequest would do without touching
+// the local branches. The remote DELETE is skipped at run time when the
+// branch has moved past the merged commit and --force isn't given.
+func (c *CheckoutCmd) cleanupDryRunPlan(prCtx *PRContext, gitRepo *git.Repository, pr *api.PullRequest, defaultBranch string) *shared.DryRunPlan {
+	sourceBranch := pr.Source.Branch.Name
+	plan := shared.NewDryRunPlan("Would clean up merged pull request #%d", pr.ID)
+
+	deletable := deletablePRBranches(io.Discard, gitRepo, pr, c.Force, "")
+	if current, err := gitRepo.GetCurrentBranch(); err == nil && containsString(deletable, current.ShortName) {
+		plan.Summary += fmt.Sprintf(", switching to %s", defaultBranch)
+	}
+	if len(deletable) > 0 {
+		plan.Summary += fmt.Sprintf(", deleting local branches %s", strings.Join(deletable, ", "))
+	}
+
+	if isForkPullRequest(prCtx, pr) {
+		plan.Summary += fmt.Sprintf(" and leaving source branch %s in its fork", sourceBranch)
+		return plan
+	}
+	plan.Summary += fmt.Sprintf(" and deleting remote branch %s", sourceBranch)
+	plan.Add("DELETE", fmt.Sprintf("repositories/%s/%s/refs/branches/%s", prCtx.Workspace, prCtx.Repository, sourceBranch), nil)
+	return plan
+}
+
+// findMergedPRForCurrentBranch finds the most recently merged pull request
+// opened from the checked out branch
+func findMergedPRForCurrentBranch(ctx context.Context, prCtx *PRContext, gitRepo *git.Repository) (int, error) {
+	current, err := gitRepo.GetCurrentBranch()
+	if errors.Is(err, git.ErrDetachedHEAD) {
+		return 0, fmt.Errorf("you are in detached HEAD; specify the pull request ID explicitly")
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to get current branch: %w", err)
+	}
+
+	options := &api.PullRequestListOptions{
+		State:   "MERGED",
+		Sort:    "-updated_on",
+		PageLen: 50,
+		Page:    1,
+	}
+
+	result, err := prCtx.Client.PullRequests.ListPullRequests(ctx, prCtx.Workspace, prCtx.Repository, options)
+	if err != nil {
+		return 0, handlePullRequestAPIError(err)
+	}
+
+	pullRequests, err := parsePullRequestResults(result)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, pr := range pullRequests {
+		if getBranchName(pr.Source) == current.ShortName {
+			return pr.ID, nil
+		}
+	}
+
+	return 0, fmt.Errorf("no merged pull request found for branch %s; pass the pull request ID", current.ShortName)
+}
+
+// cleanupRemoteBranch deletes the merged source branch on Bitbucket, unless
+// it is already gone or has moved past the merged commit
+func (c *CheckoutCmd) cleanupRemoteBranch(ctx context.Context, prCtx *PRContext, branch, mergedHash string) error {
+	ref, err := prCtx.Client.Repositories.GetBranch(ctx, prCtx.Workspace, prCtx.Repository, branch)
+	if err != nil {
+		var bbErr *api.BitbucketError
+		if errors.As(err, &bbErr) && bbErr.Type == api.ErrorTypeNotFound {
+			fmt.Printf("Remote branch %s is already deleted\n", branch)
+			return nil
+		}
+		return handlePullRequestAPIError(err)
+	}
+
+	if !c.Force && (ref.Target == nil || !sameCommit(ref.Target.Hash, mergedHash)) {
+		fmt.Printf("Keeping remote branch %s: it has commits pushed after the merge (use --force to delete it)\n", branch)
+		return nil
+	}
+
+	if err := prCtx.Client.Repositories.DeleteBranch(ctx, prCtx.Workspace, prCtx.Repository, branch); err != nil {
+		return fmt.Errorf("failed to delete remote branch %s: %w", branch, handlePullRequestAPIError(err))
+	}
+	fmt.Printf("Deleted remote branch: %s\n", branch)
+
+	return nil
+}
+
+// deleteLocalPRBranches deletes the local branches of the merged pull request
+// pr, first switching to defaultBranch when the one it deletes is checked out.
+// Branches holding commits that weren't part of the pull request, such as
+// unpushed work, are kept unless force. forceFlag names the flag that sets
+// force, for the hints, or is "" when the command has none.
+func deleteLocalPRBranches(w io.Writer, gitRepo *git.Repository, pr *api.PullRequest, defaultBranch string, force bool, forceFlag string) error {
+	deletable := deletablePRBranches(w, gitRepo, pr, force, forceFlag)
+
+	if current, err := gitRepo.GetCurrentBranch(); err == nil && containsString(deletable, current.ShortName) {
+		if !force {
+			hasChanges, err := gitRepo.HasUncommittedChanges()
+			if err != nil {
+				return fmt.Errorf("failed to check for uncommitted changes: %w", err)
+			}
+			if hasChanges && forceFlag != "" {
+				return fmt.Errorf("you have uncommitted changes on %s. Use %s to discard them or commit/stash your changes", current.ShortName, forceFlag)
+			}
+			if hasChanges {
+				return fmt.Errorf("you have uncommitted changes on %s; commit or stash them, then delete the branch", current.ShortName)
+			}
+		}
+
+		fmt.Fprintf(w, "Switching to branch: %s\n", defaultBranch)
+		checkout := gitRepo.CheckoutBranch
+		if force {
+			checkout = gitRepo.ForceCheckoutBranch
+		}
+		if err := checkout(defaultBranch, false); err != nil {
+			return fmt.Errorf("failed to checkout branch: %w", err)
+		}
+	}
+
+	for _, branch := range deletable {
+		if err := git.DeleteBranchExec(gitRepo.GetPath(), branch, "origin"); err != nil {
+			return err
+		}
+		fmt.Fprintf(w, "Deleted local branch: %s\n", branch)
+	}
+	return nil
+}
+
+// deletablePRBranches returns the local branches of pr that
+// deleteLocalPRBranches would delete, telling w about the ones it keeps
+func deletablePRBranches(w io.Writer, gitRepo *git.Repository, pr *api.PullRequest, force bool, forceFlag string) []string {
+	mergedHash := pr.Source.Commit.Hash
+
+	var deletable []string
+	for _, branch := range prLocalBranches(gitRepo, pr) {
+		if !force && !git.IsAncestorExec(gitRepo.GetPath(), branch, mergedHash) {
+			fmt.Fprintf(w, "Keeping local branch %s: it has commits that weren't merged with PR #%d", branch, pr.ID)
+			if forceFlag != "" {
+				fmt.Fprintf(w, " (use %s to delete it)", forceFlag)
+			}
+			fmt.Fprintln(w)
+			continue
+		}
+		deletable = append(deletable, branch)
+	}
+	return deletable
+}
+
+// prLocalBranches returns the local branches checkout may have created for
+// pr: its source branch name, or pr-<id> when that name was taken
+func prLocalBranches(gitRepo *git.Repository, pr *api.PullRequest) []string {
+	var branches []string
+	for _, name := range []string{pr.Source.Branch.Name, fmt.Sprintf("pr-%d", pr.ID)} {
+		if gitRepo.BranchExists(name) {
+			branches = append(branches, name)
+		}
+	}
+	return branches
+}
+
+func isForkPullRequest(prCtx *PRContext, pr *api.PullRequest) bool {
+	if pr.Source == nil || pr.Source.Repository == nil {
+		return false
+	}
+	return pr.Source.Repository.FullName != fmt.Sprintf("%s/%s", prCtx.Workspace, prCtx.Repository)
+}
+
+// sameCommit compares commit hashes that may be abbreviated, as Bitbucket
+// abbreviates the commits of pull request branches
+func sameCommit(a, b string) bool {
+	if a == "" || b == "" {
+		return false
+	}
+	return strings.HasPrefix(a, b) || strings.HasPrefix(b, a)
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
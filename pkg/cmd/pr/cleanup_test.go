@@ -0,0 +1,105 @@
+package pr
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/carlosarraes/bt/pkg/api"
+	gitpkg "github.com/carlosarraes/bt/pkg/git"
+)
+
+func TestCheckoutCmd_Cleanup_RequiresMerged(t *testing.T) {
+	for _, state := range []string{"OPEN", "DECLINED", "SUPERSEDED"} {
+		cmd := &CheckoutCmd{Cleanup: true}
+		pr := &api.PullRequest{ID: 9, State: state}
+
+		err := cmd.cleanupPullRequest(context.Background(), &PRContext{}, nil, pr)
+		if err == nil || !strings.Contains(err.Error(), "only removes the branches of merged pull requests") {
+			t.Errorf("cleanupPullRequest() with %s PR error = %v", state, err)
+		}
+	}
+}
+
+func TestCheckoutCmd_Cleanup_DetachConflict(t *testing.T) {
+	cmd := &CheckoutCmd{PRID: "9", Cleanup: true, Detach: true}
+
+	err := cmd.Run(context.Background())
+	if err == nil || err.Error() != "--cleanup and --detach cannot be used together" {
+		t.Errorf("Run() error = %v", err)
+	}
+}
+
+func TestCheckoutCmd_Cleanup_DryRun(t *testing.T) {
+	repo, git := newCreateTestRepo(t, "main")
+	dir := repo.GetPath()
+
+	git("checkout", "-q", "-b", "feature")
+	git("commit", "-q", "--allow-empty", "-m", "feature work")
+	merged, err := gitpkg.ResolveCommitExec(dir, "HEAD")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	prCtx := &PRContext{Workspace: "ws", Repository: "repo", DryRun: true}
+	pr := &api.PullRequest{
+		ID:          7,
+		State:       "MERGED",
+		Source:      &api.PullRequestBranch{Branch: &api.Branch{Name: "feature"}, Commit: &api.Commit{Hash: merged[:12]}},
+		Destination: &api.PullRequestBranch{Branch: &api.Branch{Name: "main"}},
+	}
+	cmd := &CheckoutCmd{Cleanup: true, Output: "table"}
+
+	if err := cmd.cleanupPullRequest(context.Background(), prCtx, repo, pr); err != nil {
+		t.Fatalf("cleanupPullRequest() error = %v", err)
+	}
+	if current, _ := gitpkg.GetCurrentBranchExec(dir); current != "feature" {
+		t.Errorf("dry run switched to %q", current)
+	}
+	if exists, _ := gitpkg.BranchExistsExec(dir, "refs/heads/feature"); !exists {
+		t.Error("dry run deleted the local branch")
+	}
+
+	plan := cmd.cleanupDryRunPlan(prCtx, repo, pr, "main")
+	for _, want := range []string{"#7", "switching to main", "local branches feature", "remote branch feature"} {
+		if !strings.Contains(plan.Summary, want) {
+			t.Errorf("summary %q does not mention %q", plan.Summary, want)
+		}
+	}
+	if len(plan.Calls) != 1 || plan.Calls[0].Method != "DELETE" || plan.Calls[0].Endpoint != "repositories/ws/repo/refs/branches/feature" {
+		t.Errorf("unexpected calls %+v", plan.Calls)
+	}
+}
+
+func TestSameCommit(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want bool
+	}{
+		{"abc123def456", "abc123def456", true},
+		{"abc123def4567890", "abc123def456", true},
+		{"abc123def456", "abc123def4567890", true},
+		{"abc123def456", "fff123def456", false},
+		{"", "abc123", false},
+	}
+
+	for _, tt := range tests {
+		if got := sameCommit(tt.a, tt.b); got != tt.want {
+			t.Errorf("sameCommit(%q, %q) = %v, want %v", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestIsForkPullRequest(t *testing.T) {
+	prCtx := &PRContext{Workspace: "ws", Repository: "repo"}
+
+	pr := &api.PullRequest{Source: &api.PullRequestBranch{Repository: &api.Repository{FullName: "ws/repo"}}}
+	if isForkPullRequest(prCtx, pr) {
+		t.Error("isForkPullRequest() = true for a same-repository PR")
+	}
+
+	pr.Source.Repository.FullName = "someone/repo"
+	if !isForkPullRequest(prCtx, pr) {
+		t.Error("isForkPullRequest() = false for a fork PR")
+	}
+}
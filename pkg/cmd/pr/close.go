@@ -15,6 +15,7 @@ import (
 type CloseCmd struct {
 	PRID         string `arg:"" help:"Pull request ID (number)"`
 	Comment      string `short:"c" help:"Comment to add when closing the PR"`
+	Reason       string `help:"Why the PR is declined; saved as the decline reason and posted as the closing comment"`
 	DeleteBranch bool   `name:"delete-branch" help:"Delete the source branch after closing"`
 	Force        bool   `short:"f" help:"Skip confirmation prompt"`
 	Output       string `short:"o" help:"Output format (table, json, yaml)" enum:"table,json,yaml" default:"table"`
@@ -29,14 +30,7 @@ func (cmd *CloseCmd) Run(ctx context.Context) error {
 		return err
 	}
 
-	if cmd.Workspace != "" {
-		prCtx.Workspace = cmd.Workspace
-	}
-	if cmd.Repository != "" {
-		prCtx.Repository = cmd.Repository
-	}
-
-	if err := prCtx.ValidateWorkspaceAndRepo(); err != nil {
+	if err := prCtx.ResolveRepository(cmd.Workspace, cmd.Repository); err != nil {
 		return err
 	}
 
@@ -54,20 +48,24 @@ func (cmd *CloseCmd) Run(ctx context.Context) error {
 		return err
 	}
 
+	if prCtx.DryRun {
+		return prCtx.PrintDryRun(cmd.dryRunPlan(prCtx, pr), cmd.Output)
+	}
+
 	if !cmd.Force {
 		if err := cmd.confirmClose(pr); err != nil {
 			return err
 		}
 	}
 
-	if cmd.Comment != "" {
-		_, err := prCtx.Client.PullRequests.AddComment(ctx, prCtx.Workspace, prCtx.Repository, prID, cmd.Comment, nil)
+	if comment := cmd.closingComment(); comment != "" {
+		_, err := prCtx.Client.PullRequests.AddComment(ctx, prCtx.Workspace, prCtx.Repository, prID, comment, nil)
 		if err != nil {
 			return fmt.Errorf("failed to add comment: %w", err)
 		}
 	}
 
-	closedPR, err := prCtx.Client.PullRequests.DeclinePullRequest(ctx, prCtx.Workspace, prCtx.Repository, prID, cmd.Comment)
+	closedPR, err := prCtx.Client.PullRequests.DeclinePullRequest(ctx, prCtx.Workspace, prCtx.Repository, prID, cmd.declineReason())
 	if err != nil {
 		return handlePullRequestAPIError(err)
 	}
@@ -81,6 +79,52 @@ func (cmd *CloseCmd) Run(ctx context.Context) error {
 	return cmd.formatOutput(prCtx, closedPR)
 }
 
+// closingComment is the comment posted before declining: the reason, so it
+// shows up in the PR's activity, followed by any --comment
+func (cmd *CloseCmd) closingComment() string {
+	reason := strings.TrimSpace(cmd.Reason)
+	if reason == "" {
+		return cmd.Comment
+	}
+
+	comment := "**Declined:** " + reason
+	if cmd.Comment != "" {
+		comment += "\n\n" + cmd.Comment
+	}
+	return comment
+}
+
+// declineReason is saved with the decline: --reason, or else --comment
+func (cmd *CloseCmd) declineReason() string {
+	if reason := strings.TrimSpace(cmd.Reason); reason != "" {
+		return reason
+	}
+	return cmd.Comment
+}
+
+// dryRunPlan lists the requests close would send, in order
+func (cmd *CloseCmd) dryRunPlan(prCtx *PRContext, pr *api.PullRequest) *shared.DryRunPlan {
+	plan := shared.NewDryRunPlan("Would close pull request #%d %q", pr.ID, pr.Title)
+	prEndpoint := fmt.Sprintf("repositories/%s/%s/pullrequests/%d", prCtx.Workspace, prCtx.Repository, pr.ID)
+
+	if comment := cmd.closingComment(); comment != "" {
+		plan.Add("POST", prEndpoint+"/comments", map[string]interface{}{"content": map[string]string{"raw": comment}})
+	}
+
+	decline := map[string]interface{}{"type": "pullrequest"}
+	if reason := cmd.declineReason(); reason != "" {
+		decline["reason"] = reason
+	}
+	plan.Add("POST", prEndpoint+"/decline", decline)
+
+	if cmd.DeleteBranch && pr.Source != nil && pr.Source.Branch != nil {
+		plan.Summary += fmt.Sprintf(" and delete branch %s", pr.Source.Branch.Name)
+		plan.Add("DELETE", branchEndpoint(prCtx, pr.Source.Branch.Name), nil)
+	}
+
+	return plan
+}
+
 func (cmd *CloseCmd) parsePRID() (int, error) {
 	if cmd.PRID == "" {
 		return 0, fmt.Errorf("pull request ID is required")
@@ -139,9 +183,7 @@ func (cmd *CloseCmd) deleteBranch(ctx context.Context, prCtx *PRContext, pr *api
 
 	branchName := pr.Source.Branch.Name
 
-	endpoint := fmt.Sprintf("repositories/%s/%s/refs/branches/%s", prCtx.Workspace, prCtx.Repository, branchName)
-
-	resp, err := prCtx.Client.Delete(ctx, endpoint)
+	resp, err := prCtx.Client.Delete(ctx, branchEndpoint(prCtx, branchName))
 	if err != nil {
 		return fmt.Errorf("failed to delete branch '%s': %w", branchName, err)
 	}
@@ -168,6 +210,9 @@ func (cmd *CloseCmd) formatTable(pr *api.PullRequest) error {
 	fmt.Printf("✓ Closed pull request #%d\n", pr.ID)
 	fmt.Printf("Title: %s\n", pr.Title)
 	fmt.Printf("State: %s\n", pr.State)
+	if reason := declineReason(pr); reason != "" {
+		fmt.Printf("Reason: %s\n", reason)
+	}
 
 	if pr.Author != nil {
 		authorName := pr.Author.DisplayName
@@ -194,6 +239,15 @@ func (cmd *CloseCmd) formatTable(pr *api.PullRequest) error {
 	return nil
 }
 
+// declineReason returns why a declined pull request was declined, or "" for
+// pull requests in any other state
+func declineReason(pr *api.PullRequest) string {
+	if pr.State != "DECLINED" {
+		return ""
+	}
+	return strings.TrimSpace(pr.Reason)
+}
+
 func (cmd *CloseCmd) ParsePRID() (int, error) {
 	return ParsePRID(cmd.PRID)
 }
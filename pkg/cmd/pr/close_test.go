@@ -124,3 +124,73 @@ func TestCloseCmd_validatePRState(t *testing.T) {
 		})
 	}
 }
+
+func TestCloseCmd_closingComment(t *testing.T) {
+	tests := []struct {
+		name    string
+		reason  string
+		comment string
+		want    string
+	}{
+		{"nothing", "", "", ""},
+		{"comment only", "", "thanks", "thanks"},
+		{"reason only", "superseded by #12", "", "**Declined:** superseded by #12"},
+		{"reason and comment", " out of scope ", "see the roadmap", "**Declined:** out of scope\n\nsee the roadmap"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := &CloseCmd{Reason: tt.reason, Comment: tt.comment}
+			if got := cmd.closingComment(); got != tt.want {
+				t.Errorf("closingComment() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDeclineReason(t *testing.T) {
+	declined := &api.PullRequest{State: "DECLINED", Reason: " duplicate "}
+	if got := declineReason(declined); got != "duplicate" {
+		t.Errorf("declineReason() = %q, want %q", got, "duplicate")
+	}
+
+	open := &api.PullRequest{State: "OPEN", Reason: "stale"}
+	if got := declineReason(open); got != "" {
+		t.Errorf("declineReason() = %q for an open PR, want empty", got)
+	}
+}
+
+func TestCloseCmd_dryRunPlan(t *testing.T) {
+	prCtx := &PRContext{Workspace: "ws", Repository: "repo"}
+	pr := &api.PullRequest{
+		ID:     7,
+		Title:  "Old work",
+		Source: &api.PullRequestBranch{Branch: &api.Branch{Name: "feature/old"}},
+	}
+
+	cmd := &CloseCmd{Reason: "superseded by #8", DeleteBranch: true}
+	plan := cmd.dryRunPlan(prCtx, pr)
+
+	want := []string{
+		"POST repositories/ws/repo/pullrequests/7/comments",
+		"POST repositories/ws/repo/pullrequests/7/decline",
+		"DELETE repositories/ws/repo/refs/branches/feature/old",
+	}
+	if len(plan.Calls) != len(want) {
+		t.Fatalf("dryRunPlan() has %d calls, want %d", len(plan.Calls), len(want))
+	}
+	for i, call := range plan.Calls {
+		if got := call.Method + " " + call.Endpoint; got != want[i] {
+			t.Errorf("call %d = %q, want %q", i, got, want[i])
+		}
+	}
+
+	if plan.Summary != `Would close pull request #7 "Old work" and delete branch feature/old` {
+		t.Errorf("unexpected summary %q", plan.Summary)
+	}
+
+	plan = (&CloseCmd{}).dryRunPlan(prCtx, pr)
+	if len(plan.Calls) != 1 || plan.Calls[0].Endpoint != "repositories/ws/repo/pullrequests/7/decline" {
+		t.Errorf("plan without comment or branch deletion should only decline, got %+v", plan.Calls)
+	}
+}
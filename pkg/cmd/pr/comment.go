@@ -22,6 +22,8 @@ type CommentCmd struct {
 	File       string `name:"file" help:"File path for an inline comment (requires --line)"`
 	Line       int    `name:"line" help:"Line number for an inline comment"`
 	LineType   string `name:"line-type" help:"Which diff side --line refers to" enum:"new,old" default:"new"`
+	FromDiff   string `name:"from-diff" help:"Comment inline on every added line matching this regex"`
+	Force      bool   `short:"f" help:"With --from-diff, post the comments instead of previewing them"`
 	Output     string `short:"o" help:"Output format (table, json, yaml)" enum:"table,json,yaml" default:"table"`
 	NoColor    bool
 	Workspace  string `help:"Bitbucket workspace (defaults to git remote or config)"`
@@ -34,14 +36,7 @@ func (cmd *CommentCmd) Run(ctx context.Context) error {
 		return err
 	}
 
-	if cmd.Workspace != "" {
-		prCtx.Workspace = cmd.Workspace
-	}
-	if cmd.Repository != "" {
-		prCtx.Repository = cmd.Repository
-	}
-
-	if err := prCtx.ValidateWorkspaceAndRepo(); err != nil {
+	if err := prCtx.ResolveRepository(cmd.Workspace, cmd.Repository); err != nil {
 		return err
 	}
 
@@ -50,6 +45,10 @@ func (cmd *CommentCmd) Run(ctx context.Context) error {
 		return err
 	}
 
+	if cmd.FromDiff != "" {
+		return cmd.commentFromDiff(ctx, prCtx, prID)
+	}
+
 	body, err := cmd.getCommentBody()
 	if err != nil {
 		return err
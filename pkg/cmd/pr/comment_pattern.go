@@ -0,0 +1,127 @@
+package pr
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+
+	"github.com/carlosarraes/bt/pkg/api"
+)
+
+// patternPreviewLimit caps the locations listed in the --from-diff preview
+const patternPreviewLimit = 20
+
+// patternComment is an inline comment posted by --from-diff
+type patternComment struct {
+	File      string `json:"file" yaml:"file"`
+	Line      int    `json:"line" yaml:"line"`
+	CommentID int    `json:"comment_id,omitempty" yaml:"comment_id,omitempty"`
+	Error     string `json:"error,omitempty" yaml:"error,omitempty"`
+}
+
+func (cmd *CommentCmd) validateFromDiff() (*regexp.Regexp, error) {
+	if cmd.File != "" || cmd.Line != 0 || cmd.ReplyTo != "" {
+		return nil, fmt.Errorf("--from-diff cannot be combined with --file, --line or --reply-to")
+	}
+
+	pattern, err := regexp.Compile(cmd.FromDiff)
+	if err != nil {
+		return nil, fmt.Errorf("invalid --from-diff pattern %q: %w", cmd.FromDiff, err)
+	}
+	return pattern, nil
+}
+
+// commentFromDiff posts the comment body inline on every added line of the
+// pull request matching the --from-diff pattern. Without --force it only
+// previews where the comments would go.
+func (cmd *CommentCmd) commentFromDiff(ctx context.Context, prCtx *PRContext, prID int) error {
+	pattern, err := cmd.validateFromDiff()
+	if err != nil {
+		return err
+	}
+
+	body, err := cmd.getCommentBody()
+	if err != nil {
+		return err
+	}
+
+	diff, err := prCtx.Client.PullRequests.GetPullRequestDiff(ctx, prCtx.Workspace, prCtx.Repository, prID)
+	if err != nil {
+		return handlePullRequestAPIError(err)
+	}
+
+	matches := matchAddedLines(diff, pattern)
+	if len(matches) == 0 {
+		fmt.Fprintf(os.Stderr, "No added lines in pull request #%d match %q; nothing to post\n", prID, cmd.FromDiff)
+		return nil
+	}
+
+	previewPatternComments(os.Stderr, prID, matches)
+	if !cmd.Force {
+		return fmt.Errorf("run again with --force to post %d comment(s)", len(matches))
+	}
+
+	var posted []patternComment
+	failed := 0
+	for _, match := range matches {
+		result := patternComment{File: match.File, Line: match.Line}
+
+		inline := &api.PullRequestCommentInline{Path: match.File, To: match.Line}
+		comment, err := cmd.addComment(ctx, prCtx, prID, body, nil, inline)
+		if err != nil {
+			failed++
+			result.Error = err.Error()
+			fmt.Fprintf(os.Stderr, "✗ %s:%d: %v\n", match.File, match.Line, err)
+		} else {
+			result.CommentID = comment.ID
+			if cmd.Output == "table" {
+				fmt.Printf("✓ %s:%d\n", match.File, match.Line)
+			}
+		}
+		posted = append(posted, result)
+	}
+
+	if cmd.Output != "table" {
+		if err := prCtx.Formatter.Format(map[string]interface{}{
+			"pull_request": prID,
+			"pattern":      cmd.FromDiff,
+			"comments":     posted,
+			"failed":       failed,
+		}); err != nil {
+			return err
+		}
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("posted %d of %d comment(s) on pull request #%d", len(matches)-failed, len(matches), prID)
+	}
+	if cmd.Output == "table" {
+		fmt.Printf("Posted %d comment(s) on pull request #%d\n", len(matches), prID)
+	}
+	return nil
+}
+
+// matchAddedLines returns the added lines of diff matching pattern. Only
+// added lines are matched: they're the ones the pull request introduces.
+func matchAddedLines(diff string, pattern *regexp.Regexp) []addedLine {
+	var matches []addedLine
+	for _, line := range parseAddedLines(diff) {
+		if pattern.MatchString(line.Text) {
+			matches = append(matches, line)
+		}
+	}
+	return matches
+}
+
+func previewPatternComments(w io.Writer, prID int, matches []addedLine) {
+	fmt.Fprintf(w, "%d comment(s) will be posted on pull request #%d:\n", len(matches), prID)
+	for i, match := range matches {
+		if i == patternPreviewLimit {
+			fmt.Fprintf(w, "  ... and %d more\n", len(matches)-patternPreviewLimit)
+			break
+		}
+		fmt.Fprintf(w, "  %s:%d  %s\n", match.File, match.Line, match.Text)
+	}
+}
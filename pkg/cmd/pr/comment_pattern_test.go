@@ -0,0 +1,110 @@
+package pr
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/carlosarraes/bt/pkg/api/apitest"
+	"github.com/carlosarraes/bt/pkg/cmd/shared"
+)
+
+const patternDiff = `diff --git a/main.go b/main.go
+--- a/main.go
++++ b/main.go
+@@ -1,3 +1,4 @@
+ package main
+-	fmt.Println("old")
++	fmt.Println("debug")
++	log.Info("ok")
+ 	fmt.Println("context")
+diff --git a/util.go b/util.go
+--- a/util.go
++++ b/util.go
+@@ -8,2 +8,3 @@
+ func x() {
++	fmt.Println("again")
+ }
+`
+
+func TestMatchAddedLines(t *testing.T) {
+	matches := matchAddedLines(patternDiff, regexp.MustCompile(`fmt\.Println`))
+
+	assert.Equal(t, []addedLine{
+		{File: "main.go", Line: 2, Text: "\tfmt.Println(\"debug\")"},
+		{File: "util.go", Line: 9, Text: "\tfmt.Println(\"again\")"},
+	}, matches)
+	assert.Empty(t, matchAddedLines(patternDiff, regexp.MustCompile("nothing")))
+}
+
+func TestCommentCmd_validateFromDiff(t *testing.T) {
+	_, err := (&CommentCmd{FromDiff: "("}).validateFromDiff()
+	assert.ErrorContains(t, err, "invalid --from-diff pattern")
+
+	_, err = (&CommentCmd{FromDiff: "x", File: "a.go", Line: 1}).validateFromDiff()
+	assert.ErrorContains(t, err, "cannot be combined")
+
+	_, err = (&CommentCmd{FromDiff: "x", ReplyTo: "3"}).validateFromDiff()
+	assert.ErrorContains(t, err, "cannot be combined")
+}
+
+func TestPreviewPatternComments(t *testing.T) {
+	var matches []addedLine
+	for i := 1; i <= patternPreviewLimit+3; i++ {
+		matches = append(matches, addedLine{File: "a.go", Line: i, Text: "TODO"})
+	}
+
+	var buf bytes.Buffer
+	previewPatternComments(&buf, 7, matches)
+
+	assert.Contains(t, buf.String(), fmt.Sprintf("%d comment(s) will be posted on pull request #7", len(matches)))
+	assert.Contains(t, buf.String(), "a.go:1  TODO")
+	assert.NotContains(t, buf.String(), fmt.Sprintf("a.go:%d ", patternPreviewLimit+1))
+	assert.Contains(t, buf.String(), "... and 3 more")
+}
+
+func commentPatternTransport(t *testing.T) *apitest.ReplayTransport {
+	apitest.CommandEnv(t, "ws", "repo")
+	transport := apitest.NewReplayTransport(
+		apitest.Fixture{Method: "GET", Path: "/repositories/ws/repo/pullrequests/7/diff", Status: 200, RawBody: patternDiff},
+		apitest.Fixture{Method: "POST", Path: "/repositories/ws/repo/pullrequests/7/comments", Status: 201, Body: []byte(`{"id": 100}`)},
+	)
+	t.Cleanup(shared.SetClientTransport(transport))
+	return transport
+}
+
+func TestCommentCmd_FromDiff_PreviewWithoutForce(t *testing.T) {
+	transport := commentPatternTransport(t)
+
+	cmd := &CommentCmd{PRID: "7", Body: "Use the logger", FromDiff: `fmt\.Println`, Output: "table", NoColor: true}
+	err := cmd.Run(context.Background())
+	assert.ErrorContains(t, err, "--force to post 2 comment(s)")
+
+	for _, req := range transport.Requests() {
+		assert.NotEqual(t, "POST", req.Method)
+	}
+}
+
+func TestCommentCmd_FromDiff_Force(t *testing.T) {
+	transport := commentPatternTransport(t)
+
+	cmd := &CommentCmd{PRID: "7", Body: "Use the logger", FromDiff: `fmt\.Println`, Force: true, Output: "json", NoColor: true}
+	require.NoError(t, cmd.Run(context.Background()))
+
+	var posts []string
+	for _, req := range transport.Requests() {
+		if req.Method == "POST" {
+			posts = append(posts, string(req.Body))
+		}
+	}
+	require.Len(t, posts, 2)
+	assert.Contains(t, posts[0], `"path":"main.go"`)
+	assert.Contains(t, posts[0], `"to":2`)
+	assert.Contains(t, posts[1], `"path":"util.go"`)
+	assert.Contains(t, posts[1], `"to":9`)
+}
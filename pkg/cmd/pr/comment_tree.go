@@ -0,0 +1,107 @@
+package pr
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/carlosarraes/bt/pkg/api"
+	"github.com/carlosarraes/bt/pkg/output"
+)
+
+// inlineLine is the line an inline comment points at; comments on removed
+// lines only carry the old line number.
+func inlineLine(inline *api.PullRequestCommentInline) int {
+	if inline.To != 0 {
+		return inline.To
+	}
+	return inline.From
+}
+
+// renderCommentTree writes general threads first, then inline threads
+// grouped by file and line, with replies indented under their parents.
+func renderCommentTree(w io.Writer, threads []*api.CommentThread) {
+	if len(threads) == 0 {
+		return
+	}
+
+	var general, inline []*api.CommentThread
+	for _, thread := range threads {
+		if thread.Comment.Inline != nil {
+			inline = append(inline, thread)
+		} else {
+			general = append(general, thread)
+		}
+	}
+
+	sort.SliceStable(inline, func(i, j int) bool {
+		a, b := inline[i].Comment.Inline, inline[j].Comment.Inline
+		if a.Path != b.Path {
+			return a.Path < b.Path
+		}
+		return inlineLine(a) < inlineLine(b)
+	})
+
+	fmt.Fprintf(w, "\nComments (%d threads):\n", len(threads))
+
+	if len(general) > 0 {
+		fmt.Fprintf(w, "\nGeneral\n")
+		for _, thread := range general {
+			renderThread(w, thread, 1)
+		}
+	}
+
+	location := ""
+	for _, thread := range inline {
+		current := fmt.Sprintf("%s:%d", thread.Comment.Inline.Path, inlineLine(thread.Comment.Inline))
+		if current != location {
+			location = current
+			fmt.Fprintf(w, "\n%s\n", location)
+		}
+		renderThread(w, thread, 1)
+	}
+}
+
+func renderThread(w io.Writer, thread *api.CommentThread, depth int) {
+	comment := thread.Comment
+	indent := strings.Repeat("  ", depth)
+
+	marker := ""
+	if depth > 1 {
+		marker = "↳ "
+	}
+
+	timeStr := ""
+	if comment.CreatedOn != nil {
+		timeStr = " (" + output.FormatRelativeTime(comment.CreatedOn) + ")"
+	}
+	fmt.Fprintf(w, "%s%s%s%s:\n", indent, marker, commentAuthor(comment), timeStr)
+
+	body := ""
+	if comment.Content != nil {
+		body = comment.Content.Raw
+	}
+	if comment.Deleted {
+		body = "[deleted]"
+	}
+	for _, line := range strings.Split(body, "\n") {
+		fmt.Fprintf(w, "%s    %s\n", indent, line)
+	}
+
+	for _, reply := range thread.Replies {
+		renderThread(w, reply, depth+1)
+	}
+}
+
+func commentAuthor(comment *api.PullRequestComment) string {
+	if comment.User != nil {
+		if comment.User.DisplayName != "" {
+			return comment.User.DisplayName
+		}
+		if comment.User.Username != "" {
+			return comment.User.Username
+		}
+	}
+	return "Unknown"
+}
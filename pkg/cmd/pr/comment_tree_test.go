@@ -0,0 +1,66 @@
+package pr
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/carlosarraes/bt/pkg/api"
+	"github.com/stretchr/testify/assert"
+)
+
+func treeComment(id, parent int, author, body string, inline *api.PullRequestCommentInline) api.PullRequestComment {
+	comment := api.PullRequestComment{
+		ID:      id,
+		User:    &api.User{DisplayName: author},
+		Content: &api.PullRequestCommentContent{Raw: body},
+		Inline:  inline,
+	}
+	if parent != 0 {
+		comment.Parent = &api.PullRequestComment{ID: parent}
+	}
+	return comment
+}
+
+func TestRenderCommentTree(t *testing.T) {
+	comments := []api.PullRequestComment{
+		treeComment(1, 0, "Alice", "Looks good overall", nil),
+		treeComment(2, 0, "Bob", "Off by one?", &api.PullRequestCommentInline{Path: "main.go", To: 42}),
+		treeComment(3, 2, "Alice", "Fixed", nil),
+		treeComment(4, 0, "Carol", "Typo", &api.PullRequestCommentInline{Path: "README.md", From: 7}),
+		treeComment(5, 1, "Bob", "Thanks!\nMerging soon", nil),
+		treeComment(6, 5, "Alice", "👍", nil),
+	}
+
+	var buf bytes.Buffer
+	renderCommentTree(&buf, api.ThreadComments(comments))
+
+	want := `
+Comments (3 threads):
+
+General
+  Alice:
+      Looks good overall
+    ↳ Bob:
+        Thanks!
+        Merging soon
+      ↳ Alice:
+          👍
+
+README.md:7
+  Carol:
+      Typo
+
+main.go:42
+  Bob:
+      Off by one?
+    ↳ Alice:
+        Fixed
+`
+	assert.Equal(t, want, buf.String())
+}
+
+func TestRenderCommentTree_Empty(t *testing.T) {
+	var buf bytes.Buffer
+	renderCommentTree(&buf, nil)
+	assert.Empty(t, buf.String())
+}
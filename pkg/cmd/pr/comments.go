@@ -26,14 +26,7 @@ func (cmd *CommentsCmd) Run(ctx context.Context) error {
 		return err
 	}
 
-	if cmd.Workspace != "" {
-		prCtx.Workspace = cmd.Workspace
-	}
-	if cmd.Repository != "" {
-		prCtx.Repository = cmd.Repository
-	}
-
-	if err := prCtx.ValidateWorkspaceAndRepo(); err != nil {
+	if err := prCtx.ResolveRepository(cmd.Workspace, cmd.Repository); err != nil {
 		return err
 	}
 
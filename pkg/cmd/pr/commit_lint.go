@@ -0,0 +1,167 @@
+package pr
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/carlosarraes/bt/pkg/config"
+	"github.com/carlosarraes/bt/pkg/git"
+)
+
+// defaultCommitTypes are the conventional commit types accepted when
+// pr.commit_lint.types is not set
+var defaultCommitTypes = []string{"feat", "fix", "docs", "style", "refactor", "perf", "test", "build", "ci", "chore", "revert"}
+
+// conventionalSubjectPattern matches "type(scope)!: description"
+var conventionalSubjectPattern = regexp.MustCompile(`^([a-zA-Z]+)(\([^()]+\))?!?: \S`)
+
+// commitViolation is a commit that breaks one or more lint rules
+type commitViolation struct {
+	Commit   git.CommitMessage
+	Problems []string
+}
+
+// commitLintError lists the commits that block pull request creation
+type commitLintError struct {
+	total      int
+	violations []commitViolation
+}
+
+func (e *commitLintError) Error() string {
+	return formatCommitViolations(e.total, e.violations) +
+		"Reword them (e.g. git rebase -i) and push again, or pass --no-lint to skip the check"
+}
+
+// commitLinter checks commit messages against the pr.commit_lint rules
+type commitLinter struct {
+	pattern          *regexp.Regexp
+	types            []string
+	maxSubjectLength int
+}
+
+func newCommitLinter(cfg config.CommitLintConfig) (*commitLinter, error) {
+	linter := &commitLinter{
+		types:            cfg.Types,
+		maxSubjectLength: cfg.MaxSubjectLength,
+	}
+	if len(linter.types) == 0 {
+		linter.types = defaultCommitTypes
+	}
+
+	if cfg.Pattern != "" {
+		pattern, err := regexp.Compile(cfg.Pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid pr.commit_lint.pattern: %w", err)
+		}
+		linter.pattern = pattern
+	}
+
+	return linter, nil
+}
+
+// lint returns why subject breaks the rules, if it does
+func (l *commitLinter) lint(subject string) []string {
+	var problems []string
+
+	if l.pattern != nil {
+		if !l.pattern.MatchString(subject) {
+			problems = append(problems, fmt.Sprintf("subject does not match pattern %s", l.pattern))
+		}
+	} else if match := conventionalSubjectPattern.FindStringSubmatch(subject); match == nil {
+		problems = append(problems, `subject is not a conventional commit ("type(scope): description")`)
+	} else if !containsString(l.types, strings.ToLower(match[1])) {
+		problems = append(problems, fmt.Sprintf("unknown type %q (allowed: %s)", match[1], strings.Join(l.types, ", ")))
+	}
+
+	if l.maxSubjectLength > 0 && len([]rune(subject)) > l.maxSubjectLength {
+		problems = append(problems, fmt.Sprintf("subject is %d characters long (max %d)", len([]rune(subject)), l.maxSubjectLength))
+	}
+
+	return problems
+}
+
+func (l *commitLinter) lintCommits(commits []git.CommitMessage) []commitViolation {
+	var violations []commitViolation
+	for _, commit := range commits {
+		if problems := l.lint(commit.Subject); len(problems) > 0 {
+			violations = append(violations, commitViolation{Commit: commit, Problems: problems})
+		}
+	}
+	return violations
+}
+
+// formatCommitViolations lists each failing commit with its problems
+func formatCommitViolations(total int, violations []commitViolation) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%d of %d commit(s) do not follow the commit conventions:\n", len(violations), total)
+	for _, v := range violations {
+		fmt.Fprintf(&b, "  %s %s\n", shortHash(v.Commit.Hash), v.Commit.Subject)
+		for _, problem := range v.Problems {
+			fmt.Fprintf(&b, "      - %s\n", problem)
+		}
+	}
+	return b.String()
+}
+
+func shortHash(hash string) string {
+	if len(hash) > 7 {
+		return hash[:7]
+	}
+	return hash
+}
+
+// shouldLintCommits reports whether commits are checked on this create:
+// --lint-commits or pr.commit_lint.enabled turn it on, --no-lint off
+func (cmd *CreateCmd) shouldLintCommits(prCtx *PRContext) (bool, error) {
+	if cmd.LintCommits && cmd.NoLint {
+		return false, fmt.Errorf("--lint-commits and --no-lint cannot be used together")
+	}
+	if cmd.NoLint {
+		return false, nil
+	}
+	return cmd.LintCommits || (prCtx.Config != nil && prCtx.Config.PR.CommitLint.Enabled), nil
+}
+
+// lintCommits checks the commits the pull request would contain. In block
+// mode violations stop the create; otherwise they are reported on stderr.
+func (cmd *CreateCmd) lintCommits(prCtx *PRContext, repo *git.Repository, sourceBranch, baseBranch string) error {
+	cfg := config.CommitLintConfig{}
+	if prCtx.Config != nil {
+		cfg = prCtx.Config.PR.CommitLint
+	}
+
+	linter, err := newCommitLinter(cfg)
+	if err != nil {
+		return err
+	}
+
+	// Compare against the remote base when it exists: the local one may be stale
+	base := baseBranch
+	if repo.RemoteBranchExists("origin", baseBranch) {
+		base = "origin/" + baseBranch
+	}
+
+	commits, err := git.ListCommitsExec(repo.GetPath(), base, sourceBranch)
+	if err != nil {
+		return fmt.Errorf("failed to lint commits: %w", err)
+	}
+
+	return reportCommitLint(os.Stderr, cfg.Mode, len(commits), linter.lintCommits(commits))
+}
+
+func reportCommitLint(w io.Writer, mode string, total int, violations []commitViolation) error {
+	if len(violations) == 0 {
+		fmt.Fprintf(w, "✅ All %d commit(s) follow the commit conventions\n", total)
+		return nil
+	}
+
+	if mode == config.CommitLintModeBlock {
+		return &commitLintError{total: total, violations: violations}
+	}
+
+	fmt.Fprintf(w, "⚠️  %s", formatCommitViolations(total, violations))
+	return nil
+}
@@ -0,0 +1,143 @@
+package pr
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/carlosarraes/bt/pkg/config"
+	"github.com/carlosarraes/bt/pkg/git"
+)
+
+func TestCommitLinter_Conventional(t *testing.T) {
+	linter, err := newCommitLinter(config.CommitLintConfig{MaxSubjectLength: 40})
+	require.NoError(t, err)
+
+	tests := []struct {
+		subject  string
+		problems int
+	}{
+		{subject: "feat: add compact output", problems: 0},
+		{subject: "fix(api)!: drop legacy endpoint", problems: 0},
+		{subject: "Fix: uppercase type is accepted", problems: 0},
+		{subject: "add compact output", problems: 1},
+		{subject: "feat:missing space", problems: 1},
+		{subject: "wip: unknown type", problems: 1},
+		{subject: "feat: a subject that is far too long for the limit", problems: 1},
+		{subject: "update things in a subject that is also too long", problems: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.subject, func(t *testing.T) {
+			assert.Len(t, linter.lint(tt.subject), tt.problems)
+		})
+	}
+}
+
+func TestCommitLinter_Types(t *testing.T) {
+	linter, err := newCommitLinter(config.CommitLintConfig{Types: []string{"feat", "fix"}})
+	require.NoError(t, err)
+
+	assert.Empty(t, linter.lint("fix: typo"))
+
+	problems := linter.lint("chore: bump deps")
+	require.Len(t, problems, 1)
+	assert.Contains(t, problems[0], `unknown type "chore" (allowed: feat, fix)`)
+}
+
+func TestCommitLinter_Pattern(t *testing.T) {
+	linter, err := newCommitLinter(config.CommitLintConfig{Pattern: `^[A-Z]+-[0-9]+ `})
+	require.NoError(t, err)
+
+	assert.Empty(t, linter.lint("ZUP-123 add compact output"))
+	assert.Empty(t, linter.lint("ZUP-9 not a conventional commit, but matches"))
+
+	problems := linter.lint("feat: add compact output")
+	require.Len(t, problems, 1)
+	assert.Contains(t, problems[0], "does not match pattern")
+
+	_, err = newCommitLinter(config.CommitLintConfig{Pattern: "("})
+	assert.Error(t, err)
+}
+
+func TestCommitLinter_lintCommits(t *testing.T) {
+	linter, err := newCommitLinter(config.CommitLintConfig{})
+	require.NoError(t, err)
+
+	violations := linter.lintCommits([]git.CommitMessage{
+		{Hash: "1111111111", Subject: "feat: ok"},
+		{Hash: "2222222222", Subject: "oops"},
+		{Hash: "3333333333", Subject: "fix: ok too"},
+	})
+
+	require.Len(t, violations, 1)
+	assert.Equal(t, "oops", violations[0].Commit.Subject)
+}
+
+func TestCreateCmd_shouldLintCommits(t *testing.T) {
+	enabled := &PRContext{Config: &config.Config{PR: config.PRConfig{
+		CommitLint: config.CommitLintConfig{Enabled: true},
+	}}}
+	disabled := &PRContext{Config: &config.Config{}}
+
+	tests := []struct {
+		name    string
+		cmd     CreateCmd
+		prCtx   *PRContext
+		want    bool
+		wantErr bool
+	}{
+		{name: "off by default", prCtx: disabled, want: false},
+		{name: "enabled in config", prCtx: enabled, want: true},
+		{name: "flag", cmd: CreateCmd{LintCommits: true}, prCtx: disabled, want: true},
+		{name: "no-lint overrides config", cmd: CreateCmd{NoLint: true}, prCtx: enabled, want: false},
+		{name: "conflicting flags", cmd: CreateCmd{LintCommits: true, NoLint: true}, prCtx: disabled, wantErr: true},
+		{name: "no config", cmd: CreateCmd{}, prCtx: &PRContext{}, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.cmd.shouldLintCommits(tt.prCtx)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestReportCommitLint(t *testing.T) {
+	violations := []commitViolation{{
+		Commit:   git.CommitMessage{Hash: "abcdef1234567890", Subject: "oops"},
+		Problems: []string{"subject is not a conventional commit"},
+	}}
+
+	t.Run("clean", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, reportCommitLint(&buf, config.CommitLintModeBlock, 3, nil))
+		assert.Contains(t, buf.String(), "All 3 commit(s)")
+	})
+
+	t.Run("warn", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, reportCommitLint(&buf, config.CommitLintModeWarn, 3, violations))
+		assert.Contains(t, buf.String(), "1 of 3 commit(s)")
+		assert.Contains(t, buf.String(), "abcdef1 oops")
+		assert.Contains(t, buf.String(), "- subject is not a conventional commit")
+	})
+
+	t.Run("block", func(t *testing.T) {
+		var buf bytes.Buffer
+		err := reportCommitLint(&buf, config.CommitLintModeBlock, 3, violations)
+
+		var lintErr *commitLintError
+		require.ErrorAs(t, err, &lintErr)
+		assert.Contains(t, err.Error(), "abcdef1 oops")
+		assert.Contains(t, err.Error(), "--no-lint")
+		assert.Empty(t, buf.String())
+	})
+}
@@ -3,15 +3,19 @@ package pr
 import (
 	"bufio"
 	"context"
+	"errors"
 	"fmt"
 	"os"
 	"strings"
 	"syscall"
+	"time"
 
 	"github.com/carlosarraes/bt/pkg/ai"
 	"github.com/carlosarraes/bt/pkg/api"
+	"github.com/carlosarraes/bt/pkg/auth"
 	"github.com/carlosarraes/bt/pkg/cmd/shared"
 	"github.com/carlosarraes/bt/pkg/git"
+	"github.com/carlosarraes/bt/pkg/output"
 	"golang.org/x/term"
 )
 
@@ -19,15 +23,28 @@ type CreateCmd struct {
 	Title             string   `help:"Title of the pull request"`
 	Body              string   `help:"Body of the pull request"`
 	Base              string   `help:"Base branch for the pull request"`
+	Head              string   `help:"Branch to open the pull request from (defaults to the current git branch)"`
+	BaseAuto          bool     `name:"base-auto" help:"Use the branch the current branch was created from as the base, inferred from history"`
 	Draft             bool     `help:"Create a draft pull request"`
-	Reviewer          []string `help:"Reviewers for the pull request"`
+	NoDraft           bool     `name:"no-draft" help:"Create the pull request ready for review, even when pr.create_as_draft is set"`
+	Reviewer          []string `help:"Reviewers for the pull request (username, account_id, or {uuid}); the author is skipped"`
 	Fill              bool     `help:"Fill title and body from commit messages"`
+	FillFirstCommit   bool     `name:"fill-first-commit" help:"Use the first commit's subject as the title and its body as the description"`
 	AI                bool     `help:"Generate PR description using AI analysis"`
-	Jira              string   `help:"Path to JIRA context file (markdown format)"`
+	Jira              string   `help:"JIRA ticket key to fetch from jira.base_url (token in JIRA_API_TOKEN), or path to a JIRA context file (markdown format)"`
 	Debug             bool     `help:"Enable debug output for AI generation"`
 	NoPush            bool     `name:"no-push" help:"Skip pushing branch to remote"`
+	NoVerify          bool     `name:"no-verify" help:"Skip git pre-push hooks when pushing the branch"`
 	NoEmoji           bool     `name:"no-emoji" help:"Skip emojis in auto-generated titles"`
 	CloseSourceBranch bool     `name:"close-source-branch" help:"Close source branch when pull request is merged"`
+	Recover           bool     `help:"Reuse the title and description saved when a previous create failed"`
+	RequireChecklist  bool     `name:"require-checklist" help:"Refuse to create the pull request unless the description satisfies pr.checklist from the config"`
+	LintCommits       bool     `name:"lint-commits" help:"Check the pull request's commit messages against pr.commit_lint (conventional commits by default)"`
+	NoLint            bool     `name:"no-lint" help:"Skip commit message linting, even when pr.commit_lint.enabled is set"`
+	Milestone         string   `help:"Issue tracker milestone to set (repositories with the issue tracker only)"`
+	Version           string   `help:"Issue tracker version to set (repositories with the issue tracker only)"`
+	Component         string   `help:"Issue tracker component to set (repositories with the issue tracker only)"`
+	MaxSize           string   `name:"max-size" help:"Warn when the pull request changes more lines than this (a line count, or XS, S, M or L from pr.size_thresholds); defaults to pr.max_size"`
 	Output            string   `short:"o" help:"Output format (table, json, yaml)" enum:"table,json,yaml" default:"table"`
 	NoColor           bool
 	Workspace         string `help:"Bitbucket workspace (defaults to git remote or config)"`
@@ -38,22 +55,40 @@ type PRCreateResult struct {
 	PullRequest *api.PullRequest `json:"pull_request"`
 	URL         string           `json:"url"`
 	Created     bool             `json:"created"`
+	Size        *prSize          `json:"size,omitempty"`
 }
 
 func (cmd *CreateCmd) Run(ctx context.Context) error {
+	if cmd.Base != "" && cmd.BaseAuto {
+		return fmt.Errorf("--base and --base-auto cannot be used together")
+	}
+	if cmd.FillFirstCommit && (cmd.Fill || cmd.AI) {
+		return fmt.Errorf("--fill-first-commit cannot be combined with --fill or --ai")
+	}
+	if cmd.Draft && cmd.NoDraft {
+		return fmt.Errorf("--draft and --no-draft cannot be used together")
+	}
+
 	prCtx, err := shared.NewCommandContext(ctx, cmd.Output, cmd.NoColor)
 	if err != nil {
 		return err
 	}
 
-	if cmd.Workspace != "" {
-		prCtx.Workspace = cmd.Workspace
+	if err := prCtx.ResolveRepository(cmd.Workspace, cmd.Repository); err != nil {
+		return err
 	}
-	if cmd.Repository != "" {
-		prCtx.Repository = cmd.Repository
+
+	if err := cmd.checkChecklistConfigured(prCtx); err != nil {
+		return err
+	}
+
+	lint, err := cmd.shouldLintCommits(prCtx)
+	if err != nil {
+		return err
 	}
 
-	if err := prCtx.ValidateWorkspaceAndRepo(); err != nil {
+	maxSize, err := cmd.maxSizeLimit(prCtx)
+	if err != nil {
 		return err
 	}
 
@@ -62,59 +97,88 @@ func (cmd *CreateCmd) Run(ctx context.Context) error {
 		return fmt.Errorf("failed to get git repository: %w", err)
 	}
 
-	currentBranch, err := repo.GetCurrentBranch()
+	headBranch, err := cmd.headBranch(repo)
 	if err != nil {
-		return fmt.Errorf("failed to get current branch: %w", err)
+		return err
 	}
 
 	if !cmd.NoPush {
 		if err := repo.FetchRemote("origin"); err != nil {
 		}
 
-		branchStatus, err := repo.GetBranchStatus(currentBranch.ShortName)
+		branchStatus, err := repo.GetBranchStatus(headBranch)
 		if err != nil {
 			fmt.Printf("Warning: Could not determine branch status: %v\n", err)
 		} else if !branchStatus.HasRemote {
-			if err := cmd.handleBranchPush(currentBranch.ShortName); err != nil {
+			if err := cmd.handleBranchPush(prCtx, headBranch); err != nil {
 				return err
 			}
 		} else if branchStatus.Ahead > 0 {
-			if err := cmd.handleBranchPush(currentBranch.ShortName); err != nil {
+			if err := cmd.handleBranchPush(prCtx, headBranch); err != nil {
 				return err
 			}
 		}
 	}
 
-	baseBranch := cmd.Base
-	var autoDetectedBase bool
-	if baseBranch == "" {
-		if detectedBase := cmd.detectBaseBranchFromSuffix(prCtx, currentBranch.ShortName); detectedBase != "" {
-			baseBranch = detectedBase
-			autoDetectedBase = true
-			fmt.Printf("🎯 Auto-detected base branch from suffix: %s\n", detectedBase)
-		} else {
-			baseBranch, err = repo.GetDefaultBranch()
-			if err != nil {
-				baseBranch = "main"
-			}
-			fmt.Printf("📍 Using default base branch: %s\n", baseBranch)
+	draft, err := cmd.resolveDraft(prCtx, headBranch)
+	if err != nil {
+		return err
+	}
+
+	baseBranch, autoDetectedBase := cmd.resolveBase(prCtx, repo, headBranch, draft)
+
+	if lint {
+		if err := cmd.lintCommits(prCtx, repo, headBranch, baseBranch); err != nil {
+			return err
 		}
 	}
 
 	title := cmd.Title
-	if title == "" {
-		title = cmd.generateTitleFromBranch(currentBranch.ShortName, baseBranch, autoDetectedBase, cmd.NoEmoji)
-		fmt.Printf("🔤 Auto-generated title from branch '%s': %s\n", currentBranch.ShortName, title)
+	body := cmd.Body
+	if draft != nil {
+		if title == "" {
+			title = draft.Title
+		}
+		if body == "" {
+			body = draft.Body
+		}
 	}
 
-	body := cmd.Body
+	// A description given with --body or recovered from a draft is checked
+	// before any AI generation or prompt
+	if body != "" {
+		if err := cmd.checkChecklist(prCtx, body); err != nil {
+			return err
+		}
+	}
+
+	// Runs before the branch-name fallback so the commit subject wins
+	if cmd.FillFirstCommit && draft == nil {
+		commitTitle, commitBody, err := cmd.firstCommitMessage(repo, baseBranch, headBranch)
+		if err != nil {
+			return err
+		}
+
+		if title == "" {
+			title = commitTitle
+		}
+		if body == "" {
+			body = commitBody
+		}
+	}
+
+	if title == "" {
+		title = cmd.generateTitleFromBranch(headBranch, baseBranch, autoDetectedBase, cmd.NoEmoji)
+		fmt.Printf("🔤 Auto-generated title from branch '%s': %s\n", headBranch, title)
+	}
 
-	if cmd.AI {
-		if err := cmd.validateAIOptions(); err != nil {
+	if cmd.AI && draft == nil {
+		jira, err := jiraSource(prCtx, cmd.Jira)
+		if err != nil {
 			return err
 		}
 
-		aiResult, err := cmd.generateAIDescription(ctx, prCtx, repo, currentBranch.ShortName, baseBranch)
+		aiResult, err := cmd.generateAIDescription(ctx, prCtx, repo, headBranch, baseBranch, jira)
 		if err != nil {
 			fmt.Printf("⚠️  AI generation failed: %v\n", err)
 			fmt.Println("Falling back to manual input...")
@@ -127,7 +191,7 @@ func (cmd *CreateCmd) Run(ctx context.Context) error {
 			}
 		}
 	} else if cmd.Fill {
-		commitTitle, commitBody, err := cmd.getCommitMessages(repo, baseBranch, currentBranch.ShortName)
+		commitTitle, commitBody, err := cmd.getCommitMessages(repo, baseBranch, headBranch)
 		if err != nil {
 			return fmt.Errorf("failed to get commit messages: %w", err)
 		}
@@ -161,21 +225,95 @@ func (cmd *CreateCmd) Run(ctx context.Context) error {
 		}
 	}
 
-	pr, err := cmd.createPullRequest(ctx, prCtx, title, body, currentBranch.ShortName, baseBranch)
+	draftFile, draftErr := saveDraft(&PRDraft{
+		Workspace:  prCtx.Workspace,
+		Repository: prCtx.Repository,
+		Branch:     headBranch,
+		Base:       baseBranch,
+		Title:      title,
+		Body:       body,
+		SavedAt:    time.Now(),
+	})
+	if draftErr != nil && cmd.Debug {
+		fmt.Fprintf(os.Stderr, "DEBUG: could not save draft: %v\n", draftErr)
+	}
+
+	var pr *api.PullRequest
+	err = cmd.checkChecklist(prCtx, body)
+	if err == nil {
+		pr, err = cmd.createPullRequest(ctx, prCtx, title, body, headBranch, baseBranch)
+	}
 	if err != nil {
+		if draftErr == nil {
+			fmt.Fprintf(os.Stderr, "💾 Title and description saved to %s\n   Retry with: bt pr create --recover\n", draftFile)
+		}
 		return err
 	}
 
+	if err := removeDraft(prCtx.Workspace, prCtx.Repository, headBranch); err != nil && cmd.Debug {
+		fmt.Fprintf(os.Stderr, "DEBUG: could not remove draft: %v\n", err)
+	}
+
 	result := &PRCreateResult{
 		PullRequest: pr,
 		URL:         pr.Links.HTML.Href,
 		Created:     true,
 	}
+	if maxSize > 0 {
+		result.Size = cmd.checkSize(ctx, prCtx, pr.ID, maxSize)
+	}
 
 	return cmd.formatOutput(prCtx, result)
 }
 
-func (cmd *CreateCmd) handleBranchPush(branchName string) error {
+// createsDraft reports whether the pull request is opened as a draft: with
+// --draft, or when pr.create_as_draft is set and --no-draft isn't given
+func (cmd *CreateCmd) createsDraft(prCtx *PRContext) bool {
+	if cmd.NoDraft {
+		return false
+	}
+	return cmd.Draft || (prCtx.Config != nil && prCtx.Config.PR.CreateAsDraft)
+}
+
+// resolveDraft returns the saved draft to reuse for this branch, if any.
+// --recover requires one; otherwise the user is offered a draft left by a
+// failed create when no title or body was given on the command line.
+func (cmd *CreateCmd) resolveDraft(prCtx *PRContext, branch string) (*PRDraft, error) {
+	draft, err := loadDraft(prCtx.Workspace, prCtx.Repository, branch)
+	if err != nil {
+		if cmd.Recover {
+			return nil, err
+		}
+		fmt.Fprintf(os.Stderr, "Warning: ignoring saved draft: %v\n", err)
+		return nil, nil
+	}
+
+	if cmd.Recover {
+		if draft == nil {
+			return nil, fmt.Errorf("no saved draft for branch '%s'", branch)
+		}
+		fmt.Printf("♻️  Recovered draft from %s: %s\n", output.FormatRelativeTime(&draft.SavedAt), draft.Title)
+		return draft, nil
+	}
+
+	if draft == nil || cmd.Title != "" || cmd.Body != "" || !isTerminal() {
+		return nil, nil
+	}
+
+	prompt := fmt.Sprintf("Found a draft saved %s (%s). Reuse it?", output.FormatRelativeTime(&draft.SavedAt), draft.Title)
+	if confirmAction(prompt) {
+		return draft, nil
+	}
+	return nil, nil
+}
+
+func (cmd *CreateCmd) handleBranchPush(prCtx *PRContext, branchName string) error {
+	// --dry-run leaves the remote alone, so the push is only reported
+	if prCtx.DryRun {
+		fmt.Fprintf(os.Stderr, "[dry-run] Would push branch '%s' to origin\n", branchName)
+		return nil
+	}
+
 	fmt.Printf("Branch '%s' is not pushed to remote. Push now? (Y/n) ", branchName)
 
 	reader := bufio.NewReader(os.Stdin)
@@ -191,21 +329,67 @@ func (cmd *CreateCmd) handleBranchPush(branchName string) error {
 
 	fmt.Printf("Pushing branch '%s' to remote...\n", branchName)
 	if err := cmd.executePush(branchName); err != nil {
-		return fmt.Errorf("failed to push branch: %w", err)
+		return err
 	}
 
 	fmt.Println("Branch pushed successfully!")
 	return nil
 }
 
+// executePush pushes the branch, streaming git's output (hook messages and
+// the remote's hints) to stderr so stdout stays clean for --output json.
 func (cmd *CreateCmd) executePush(branchName string) error {
-	return nil
+	err := git.PushBranchExec("", branchName, git.PushOptions{
+		NoVerify: cmd.NoVerify,
+		Output:   os.Stderr,
+	})
+	if err == nil {
+		return nil
+	}
+
+	var pushErr *git.PushError
+	if errors.As(err, &pushErr) && pushErr.HookRejected {
+		return fmt.Errorf("failed to push branch: %w (see the hook output above; fix the issues or rerun with --no-verify)", err)
+	}
+	return fmt.Errorf("failed to push branch: %w", err)
+}
+
+// headBranch returns --head, or the checked out branch without it
+func (cmd *CreateCmd) headBranch(repo *git.Repository) (string, error) {
+	if head := strings.TrimSpace(cmd.Head); head != "" {
+		return head, nil
+	}
+
+	current, err := repo.GetCurrentBranch()
+	if errors.Is(err, git.ErrDetachedHEAD) {
+		return "", fmt.Errorf("you are in detached HEAD; specify --head explicitly to choose the branch to open the pull request from")
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to get current branch: %w", err)
+	}
+	return current.ShortName, nil
 }
 
 func (cmd *CreateCmd) getCommitMessages(repo *git.Repository, baseBranch, currentBranch string) (string, string, error) {
 	return fmt.Sprintf("PR: %s", currentBranch), "Auto-generated from commit messages", nil
 }
 
+// firstCommitMessage returns the subject and body of the branch's first
+// commit after its merge base with the base branch
+func (cmd *CreateCmd) firstCommitMessage(repo *git.Repository, baseBranch, currentBranch string) (string, string, error) {
+	// Compare against the remote base when it exists: the local one may be stale
+	base := baseBranch
+	if repo.RemoteBranchExists("origin", baseBranch) {
+		base = "origin/" + baseBranch
+	}
+
+	commit, err := git.FirstCommitExec(repo.GetPath(), base, currentBranch)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to read the first commit: %w", err)
+	}
+	return commit.Subject, commit.Body, nil
+}
+
 func (cmd *CreateCmd) getPRTemplate() (string, error) {
 	templatePaths := []string{
 		".github/pull_request_template.md",
@@ -251,17 +435,32 @@ func (cmd *CreateCmd) promptForBody() (string, error) {
 }
 
 func (cmd *CreateCmd) createPullRequest(ctx context.Context, prCtx *PRContext, title, body, sourceBranch, baseBranch string) (*api.PullRequest, error) {
+	var author *auth.User
+	if len(cmd.Reviewer) > 0 {
+		if user, err := prCtx.Client.GetAuthManager().GetAuthenticatedUser(ctx); err == nil {
+			author = user
+		}
+	}
+
+	selectors, skipped := normalizeReviewers(cmd.Reviewer, author)
+	for _, s := range skipped {
+		fmt.Fprintf(os.Stderr, "⚠️  Skipping reviewer '%s': the author cannot review their own pull request\n", s)
+	}
+
 	var reviewers []*api.PullRequestParticipant
-	for _, reviewer := range cmd.Reviewer {
+	for _, reviewer := range selectors {
 		reviewers = append(reviewers, &api.PullRequestParticipant{
 			Type: "participant",
-			User: &api.User{
-				Username: reviewer,
-			},
+			User: reviewerUser(reviewer),
 			Role: string(api.ParticipantRoleReviewer),
 		})
 	}
 
+	tracker, err := cmd.resolveTrackerFields(ctx, prCtx)
+	if err != nil {
+		return nil, err
+	}
+
 	request := &api.CreatePullRequestRequest{
 		Type:        "pullrequest",
 		Title:       title,
@@ -278,17 +477,71 @@ func (cmd *CreateCmd) createPullRequest(ctx context.Context, prCtx *PRContext, t
 		},
 		Reviewers:         reviewers,
 		CloseSourceBranch: cmd.CloseSourceBranch,
-		Draft:             cmd.Draft,
+		Draft:             cmd.createsDraft(prCtx),
 	}
+	tracker.apply(request)
 
 	pr, err := prCtx.Client.PullRequests.CreatePullRequest(ctx, prCtx.Workspace, prCtx.Repository, request)
 	if err != nil {
 		return nil, handlePullRequestAPIError(err)
 	}
+	verifyTrackerFields(ctx, prCtx, tracker, pr.ID)
 
 	return pr, nil
 }
 
+// normalizeReviewers trims and dedupes reviewer selectors case-insensitively
+// and drops any that refer to the author (including "@me"), since Bitbucket
+// rejects the author as a reviewer with an opaque error. The dropped
+// selectors are returned so the caller can warn about them.
+func normalizeReviewers(reviewers []string, author *auth.User) (kept, skipped []string) {
+	seen := make(map[string]bool)
+	for _, r := range reviewers {
+		r = strings.TrimSpace(r)
+		key := strings.ToLower(r)
+		if r == "" || seen[key] {
+			continue
+		}
+		seen[key] = true
+
+		if isAuthorSelector(r, author) {
+			skipped = append(skipped, r)
+			continue
+		}
+		kept = append(kept, r)
+	}
+	return kept, skipped
+}
+
+func isAuthorSelector(selector string, author *auth.User) bool {
+	if selector == "@me" {
+		return true
+	}
+	if author == nil {
+		return false
+	}
+	for _, field := range []string{author.Username, author.AccountID, author.UUID} {
+		if field != "" && strings.EqualFold(field, selector) {
+			return true
+		}
+	}
+	return false
+}
+
+// reviewerUser builds the user reference for a reviewer selector. Bitbucket
+// identifies users by UUID ("{...}") or account_id ("557058:..."); anything
+// else is sent as a username.
+func reviewerUser(selector string) *api.User {
+	switch {
+	case strings.HasPrefix(selector, "{") && strings.HasSuffix(selector, "}"):
+		return &api.User{UUID: selector}
+	case strings.Contains(selector, ":"):
+		return &api.User{AccountID: selector}
+	default:
+		return &api.User{Username: selector}
+	}
+}
+
 func (cmd *CreateCmd) formatOutput(prCtx *PRContext, result *PRCreateResult) error {
 	switch cmd.Output {
 	case "table":
@@ -312,6 +565,9 @@ func (cmd *CreateCmd) formatTable(prCtx *PRContext, result *PRCreateResult) erro
 	fmt.Printf("Destination: %s\n", pr.Destination.Branch.Name)
 	fmt.Printf("State: %s\n", pr.State)
 	fmt.Printf("URL: %s\n", result.URL)
+	if result.Size != nil {
+		fmt.Printf("Size: %s\n", result.Size)
+	}
 
 	if len(pr.Reviewers) > 0 {
 		fmt.Printf("Reviewers: ")
@@ -389,23 +645,13 @@ func confirmAction(prompt string) bool {
 	return input == "y" || input == "yes"
 }
 
-func (cmd *CreateCmd) validateAIOptions() error {
-	if cmd.Jira != "" {
-		if _, err := os.Stat(cmd.Jira); os.IsNotExist(err) {
-			return fmt.Errorf("JIRA context file not found: %s", cmd.Jira)
-		}
-	}
-
-	return nil
-}
-
-func (cmd *CreateCmd) generateAIDescription(ctx context.Context, prCtx *PRContext, repo *git.Repository, sourceBranch, targetBranch string) (*ai.PRDescriptionResult, error) {
+func (cmd *CreateCmd) generateAIDescription(ctx context.Context, prCtx *PRContext, repo *git.Repository, sourceBranch, targetBranch string, jira ai.JiraSource) (*ai.PRDescriptionResult, error) {
 	generator := ai.NewDescriptionGenerator(prCtx.Client, repo, prCtx.Workspace, prCtx.Repository, cmd.NoColor, prCtx.Config)
 
 	opts := &ai.GenerateOptions{
 		SourceBranch: sourceBranch,
 		TargetBranch: targetBranch,
-		JiraFile:     cmd.Jira,
+		Jira:         jira,
 		Verbose:      true,
 		Debug:        cmd.Debug,
 	}
@@ -468,6 +714,58 @@ func (cmd *CreateCmd) getBaseBranchEmoji(baseBranch string) string {
 	}
 }
 
+// resolveBase picks the base branch: --base, the branch --base-auto infers
+// from history, the recovered draft's base, the branch suffix mapping,
+// repo.<name>.base or pr.base, and finally the repository's default branch. It reports whether
+// the base came from the suffix mapping, which the generated title mentions.
+func (cmd *CreateCmd) resolveBase(prCtx *PRContext, repo *git.Repository, headBranch string, draft *PRDraft) (string, bool) {
+	if cmd.Base != "" {
+		return cmd.Base, false
+	}
+
+	defaultBranch, err := repo.GetDefaultBranch()
+	if err != nil {
+		defaultBranch = "main"
+	}
+	configuredBase, configuredKey := prCtx.ConfiguredBase()
+
+	if cmd.BaseAuto {
+		preferred := defaultBranch
+		if configuredBase != "" {
+			preferred = configuredBase
+		}
+		inferred, err := git.InferBaseBranchExec(repo.GetPath(), headBranch, preferred)
+		if err == nil && inferred != "" {
+			fmt.Printf("🎯 Inferred base branch from history: %s\n", inferred)
+			return inferred, false
+		}
+		fmt.Println("⚠️  Could not infer a base branch from history")
+	}
+
+	if draft != nil && draft.Base != "" {
+		return draft.Base, false
+	}
+
+	if detectedBase := cmd.detectBaseBranchFromSuffix(prCtx, headBranch); detectedBase != "" {
+		fmt.Printf("🎯 Auto-detected base branch from suffix: %s\n", detectedBase)
+		return detectedBase, true
+	}
+
+	if configuredBase != "" {
+		fmt.Printf("📍 Using configured base branch (%s): %s\n", configuredKey, configuredBase)
+		return configuredBase, false
+	}
+
+	fmt.Printf("📍 Using default base branch: %s\n", defaultBranch)
+	if !cmd.BaseAuto {
+		// Stacked branches shouldn't target the default branch; point that out
+		if inferred, err := git.InferBaseBranchExec(repo.GetPath(), headBranch, defaultBranch); err == nil && inferred != "" && inferred != defaultBranch {
+			fmt.Printf("💡 '%s' looks like it was created from '%s'; use --base-auto to target it\n", headBranch, inferred)
+		}
+	}
+	return defaultBranch, false
+}
+
 func (cmd *CreateCmd) detectBaseBranchFromSuffix(prCtx *PRContext, branchName string) string {
 	suffixMapping := prCtx.Config.PR.BranchSuffixMapping
 
@@ -2,10 +2,16 @@ package pr
 
 import (
 	"context"
+	"os/exec"
 	"strings"
 	"testing"
 
 	"github.com/carlosarraes/bt/pkg/api"
+	"github.com/carlosarraes/bt/pkg/auth"
+	"github.com/carlosarraes/bt/pkg/config"
+	gitpkg "github.com/carlosarraes/bt/pkg/git"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestCreateCmd_Run(t *testing.T) {
@@ -315,3 +321,180 @@ func TestCreateCmd_validation(t *testing.T) {
 		})
 	}
 }
+
+func TestNormalizeReviewers(t *testing.T) {
+	author := &auth.User{Username: "alice", AccountID: "557058:alice", UUID: "{alice-uuid}"}
+
+	tests := []struct {
+		name        string
+		reviewers   []string
+		author      *auth.User
+		wantKept    []string
+		wantSkipped []string
+	}{
+		{
+			name:      "dedupes case-insensitively",
+			reviewers: []string{"bob", "Bob", " bob ", "carol"},
+			author:    author,
+			wantKept:  []string{"bob", "carol"},
+		},
+		{
+			name:        "drops author by username, account_id and uuid",
+			reviewers:   []string{"ALICE", "557058:alice", "{alice-uuid}", "bob"},
+			author:      author,
+			wantKept:    []string{"bob"},
+			wantSkipped: []string{"ALICE", "557058:alice", "{alice-uuid}"},
+		},
+		{
+			name:        "drops @me without a known author",
+			reviewers:   []string{"@me", "bob"},
+			author:      nil,
+			wantKept:    []string{"bob"},
+			wantSkipped: []string{"@me"},
+		},
+		{
+			name:      "ignores empty entries",
+			reviewers: []string{"", "  "},
+			author:    author,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			kept, skipped := normalizeReviewers(tt.reviewers, tt.author)
+			if strings.Join(kept, ",") != strings.Join(tt.wantKept, ",") {
+				t.Errorf("kept = %v, want %v", kept, tt.wantKept)
+			}
+			if strings.Join(skipped, ",") != strings.Join(tt.wantSkipped, ",") {
+				t.Errorf("skipped = %v, want %v", skipped, tt.wantSkipped)
+			}
+		})
+	}
+}
+
+func TestReviewerUser(t *testing.T) {
+	if u := reviewerUser("{abc}"); u.UUID != "{abc}" {
+		t.Errorf("expected UUID reference, got %+v", u)
+	}
+	if u := reviewerUser("557058:abc"); u.AccountID != "557058:abc" {
+		t.Errorf("expected account_id reference, got %+v", u)
+	}
+	if u := reviewerUser("bob"); u.Username != "bob" {
+		t.Errorf("expected username reference, got %+v", u)
+	}
+}
+
+// newCreateTestRepo creates a git repository with a Bitbucket remote whose
+// first branch is initialBranch; git runs further git commands in it
+func newCreateTestRepo(t *testing.T, initialBranch string) (repo *gitpkg.Repository, git func(args ...string)) {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+
+	dir := t.TempDir()
+	git = func(args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", append([]string{"-C", dir, "-c", "user.name=Test", "-c", "user.email=test@example.com"}, args...)...)
+		out, err := cmd.CombinedOutput()
+		require.NoError(t, err, string(out))
+	}
+	git("init", "-q", "-b", initialBranch)
+	git("commit", "-q", "--allow-empty", "-m", "initial")
+	git("remote", "add", "origin", "git@bitbucket.org:ws/repo.git")
+
+	repo, err := gitpkg.NewRepository(dir)
+	require.NoError(t, err)
+	return repo, git
+}
+
+func TestCreateCmd_headBranch(t *testing.T) {
+	repo, git := newCreateTestRepo(t, "feature/x")
+
+	head, err := (&CreateCmd{}).headBranch(repo)
+	require.NoError(t, err)
+	assert.Equal(t, "feature/x", head)
+
+	git("checkout", "-q", "--detach")
+	_, err = (&CreateCmd{}).headBranch(repo)
+	assert.ErrorContains(t, err, "you are in detached HEAD; specify --head explicitly")
+
+	head, err = (&CreateCmd{Head: " feature/x "}).headBranch(repo)
+	require.NoError(t, err)
+	assert.Equal(t, "feature/x", head, "--head works on a detached HEAD")
+}
+
+func TestCreateCmd_resolveBase(t *testing.T) {
+	repo, git := newCreateTestRepo(t, "main")
+	git("checkout", "-q", "-b", "feature-a")
+	git("commit", "-q", "--allow-empty", "-m", "feat: a")
+	git("checkout", "-q", "-b", "feature-b")
+	git("commit", "-q", "--allow-empty", "-m", "feat: b")
+
+	cfg := config.NewDefaultConfig()
+	prCtx := &PRContext{Config: cfg}
+
+	base, fromSuffix := (&CreateCmd{Base: "develop"}).resolveBase(prCtx, repo, "feature-b", nil)
+	assert.Equal(t, "develop", base)
+	assert.False(t, fromSuffix)
+
+	base, _ = (&CreateCmd{BaseAuto: true}).resolveBase(prCtx, repo, "feature-b", nil)
+	assert.Equal(t, "feature-a", base, "--base-auto uses the branch feature-b was created from")
+
+	base, _ = (&CreateCmd{BaseAuto: true}).resolveBase(prCtx, repo, "feature-a", nil)
+	assert.Equal(t, "main", base)
+
+	base, _ = (&CreateCmd{}).resolveBase(prCtx, repo, "feature-b", &PRDraft{Base: "release"})
+	assert.Equal(t, "release", base, "the recovered draft's base")
+
+	base, fromSuffix = (&CreateCmd{}).resolveBase(prCtx, repo, "ZUP-1-hml", nil)
+	assert.Equal(t, "homolog", base)
+	assert.True(t, fromSuffix)
+
+	base, _ = (&CreateCmd{}).resolveBase(prCtx, repo, "feature-b", nil)
+	assert.Equal(t, "main", base, "without --base-auto the default branch is only suggested against")
+
+	cfg.PR.Base = "develop"
+	base, _ = (&CreateCmd{}).resolveBase(prCtx, repo, "feature-b", nil)
+	assert.Equal(t, "develop", base, "pr.base beats the default branch")
+
+	prCtx.Repository = "repo"
+	cfg.Repos = map[string]config.RepoSettings{"repo": {Base: "integration"}}
+	base, _ = (&CreateCmd{}).resolveBase(prCtx, repo, "feature-b", nil)
+	assert.Equal(t, "integration", base, "repo.<name>.base beats pr.base")
+}
+
+func TestCreateCmd_BaseAndBaseAuto(t *testing.T) {
+	err := (&CreateCmd{Base: "main", BaseAuto: true}).Run(context.Background())
+	assert.EqualError(t, err, "--base and --base-auto cannot be used together")
+}
+
+func TestCreateCmd_createsDraft(t *testing.T) {
+	asDraft := &PRContext{Config: &config.Config{PR: config.PRConfig{CreateAsDraft: true}}}
+	ready := &PRContext{Config: &config.Config{}}
+
+	assert.False(t, (&CreateCmd{}).createsDraft(ready))
+	assert.True(t, (&CreateCmd{Draft: true}).createsDraft(ready))
+	assert.True(t, (&CreateCmd{}).createsDraft(asDraft), "pr.create_as_draft makes drafts the default")
+	assert.False(t, (&CreateCmd{NoDraft: true}).createsDraft(asDraft), "--no-draft overrides pr.create_as_draft")
+	assert.False(t, (&CreateCmd{}).createsDraft(&PRContext{}))
+
+	err := (&CreateCmd{Draft: true, NoDraft: true}).Run(context.Background())
+	assert.EqualError(t, err, "--draft and --no-draft cannot be used together")
+}
+
+func TestCreateCmd_handleBranchPush_DryRun(t *testing.T) {
+	// Without --dry-run this would prompt on stdin and run git push
+	cmd := &CreateCmd{}
+	assert.NoError(t, cmd.handleBranchPush(&PRContext{DryRun: true}, "feature/login"))
+}
+
+func TestIsAuthorSelector(t *testing.T) {
+	author := &auth.User{Username: "ana", AccountID: "557058:ana"}
+
+	assert.True(t, isAuthorSelector("@me", nil))
+	assert.True(t, isAuthorSelector("ANA", author))
+	assert.True(t, isAuthorSelector("557058:ana", author))
+	assert.False(t, isAuthorSelector("me", author), "only @me stands for the author")
+	assert.False(t, isAuthorSelector("bob", author))
+}
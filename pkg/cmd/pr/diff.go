@@ -4,7 +4,6 @@ import (
 	"context"
 	"fmt"
 	"os"
-	"os/exec"
 	"strconv"
 	"strings"
 
@@ -22,6 +21,12 @@ type DiffCmd struct {
 	Output       string `short:"o" help:"Output format (diff, json, yaml)" enum:"diff,json,yaml" default:"diff"`
 	Page         bool   `help:"Page output through diff-so-fancy and less for enhanced viewing"`
 	IncludeTests bool   `name:"include-tests" help:"Include test files in diff (excluded by default)"`
+	OnlyAdded    bool   `name:"only-added" help:"Show only added lines, keeping file and hunk headers"`
+	OnlyRemoved  bool   `name:"only-removed" help:"Show only removed lines, keeping file and hunk headers"`
+	WordDiff     bool   `name:"word-diff" help:"Highlight the words that changed within modified lines"`
+	Apply        bool   `help:"Apply the patch to the working tree with git apply (test files included)"`
+	Check        bool   `help:"With --apply, only check whether the patch applies cleanly"`
+	ThreeWay     bool   `name:"3way" help:"With --apply, fall back to a 3-way merge when the patch doesn't apply cleanly"`
 	NoColor      bool
 	Workspace    string `help:"Bitbucket workspace (defaults to git remote or config)"`
 	Repository   string `help:"Repository name (defaults to git remote)"`
@@ -34,22 +39,58 @@ func (cmd *DiffCmd) Run(ctx context.Context) error {
 		return err
 	}
 
-	if cmd.Workspace != "" {
-		prCtx.Workspace = cmd.Workspace
+	if err := prCtx.ResolveRepository(cmd.Workspace, cmd.Repository); err != nil {
+		return err
 	}
-	if cmd.Repository != "" {
-		prCtx.Repository = cmd.Repository
+
+	prID, err := cmd.ParsePRID()
+	if err != nil {
+		return err
 	}
 
-	if err := prCtx.ValidateWorkspaceAndRepo(); err != nil {
+	if err := cmd.validateApplyFlags(); err != nil {
 		return err
 	}
 
-	prID, err := cmd.ParsePRID()
+	if err := cmd.validateLineFilterFlags(); err != nil {
+		return err
+	}
+
+	if err := cmd.validateWordDiffFlags(); err != nil {
+		return err
+	}
+
+	// JSON and YAML hold the whole diff, and git apply is given it whole
+	if cmd.Apply || (!cmd.NameOnly && (cmd.Output == "json" || cmd.Output == "yaml")) {
+		return cmd.runWholeDiff(ctx, prCtx, prID)
+	}
+
+	stream, err := prCtx.Client.PullRequests.StreamPullRequestDiff(ctx, prCtx.Workspace, prCtx.Repository, prID)
+	if err != nil {
+		return handlePullRequestAPIError(err)
+	}
+	defer stream.Close()
+
+	// --page has its own diff-so-fancy pipeline
+	if cmd.Page && !cmd.NameOnly && !cmd.Patch {
+		return cmd.pageDiff(stream)
+	}
+
+	stopPager := shared.StartPager(ctx)
+	defer stopPager()
+
+	counts, err := cmd.writeDiff(stream, os.Stdout, cmd.renderSection)
 	if err != nil {
 		return err
 	}
+	if message := cmd.emptyDiffMessage(counts); message != "" {
+		fmt.Println(message)
+	}
+	return nil
+}
 
+// runWholeDiff handles the outputs that need the whole diff at once
+func (cmd *DiffCmd) runWholeDiff(ctx context.Context, prCtx *PRContext, prID int) error {
 	diff, err := prCtx.Client.PullRequests.GetPullRequestDiff(ctx, prCtx.Workspace, prCtx.Repository, prID)
 	if err != nil {
 		return handlePullRequestAPIError(err)
@@ -60,7 +101,8 @@ func (cmd *DiffCmd) Run(ctx context.Context) error {
 		return nil
 	}
 
-	if !cmd.IncludeTests {
+	// Tests are part of the change being applied, so --apply keeps them
+	if !cmd.IncludeTests && !cmd.Apply {
 		diff = cmd.filterTestFiles(diff)
 		if diff == "" {
 			fmt.Println("No non-test changes found in this pull request.")
@@ -68,19 +110,25 @@ func (cmd *DiffCmd) Run(ctx context.Context) error {
 		}
 	}
 
+	if cmd.OnlyAdded || cmd.OnlyRemoved {
+		marker := byte('+')
+		if cmd.OnlyRemoved {
+			marker = '-'
+		}
+		diff = filterDiffLines(diff, marker)
+		if diff == "" {
+			fmt.Printf("No %s lines found in this pull request.\n", cmd.lineFilterName())
+			return nil
+		}
+	}
+
 	switch {
-	case cmd.NameOnly:
-		return cmd.outputNameOnly(diff)
+	case cmd.Apply:
+		return cmd.applyPatch(prID, diff)
 	case cmd.Output == "json":
 		return cmd.outputJSON(prCtx, diff, prID)
-	case cmd.Output == "yaml":
-		return cmd.outputYAML(prCtx, diff, prID)
-	case cmd.Patch:
-		return cmd.outputPatch(diff)
-	case cmd.Page:
-		return cmd.outputWithPager(diff)
 	default:
-		return cmd.outputColoredDiff(diff)
+		return cmd.outputYAML(prCtx, diff, prID)
 	}
 }
 
@@ -103,73 +151,17 @@ func (cmd *DiffCmd) ParsePRID() (int, error) {
 	return prID, nil
 }
 
-func (cmd *DiffCmd) outputNameOnly(diff string) error {
-	files := utils.ExtractChangedFiles(diff)
-
-	if cmd.File != "" {
-		filteredFiles := make([]string, 0)
-		for _, file := range files {
-			if strings.Contains(file, cmd.File) {
-				filteredFiles = append(filteredFiles, file)
-			}
-		}
-		files = filteredFiles
-	}
-
-	for _, file := range files {
-		fmt.Println(file)
-	}
-	return nil
-}
-
-func (cmd *DiffCmd) outputPatch(diff string) error {
-
+// buildPatch returns the diff in the form printed by --patch and fed to git apply
+func (cmd *DiffCmd) buildPatch(diff string) string {
 	if cmd.File != "" {
 		diff = utils.FilterDiffByFile(diff, cmd.File)
 	}
 
-	cleanDiff := utils.CleanDiffForPatch(diff)
-	fmt.Print(cleanDiff)
-	return nil
-}
-
-func (cmd *DiffCmd) outputColoredDiff(diff string) error {
-
-	if cmd.File != "" {
-		diff = utils.FilterDiffByFile(diff, cmd.File)
-		if diff == "" {
-			fmt.Printf("No differences found for file: %s\n", cmd.File)
-			return nil
-		}
-	}
-
-	useColors := cmd.shouldUseColors()
-
-	formattedDiff := utils.FormatDiff(diff, useColors)
-	fmt.Print(formattedDiff)
-	return nil
+	return utils.CleanDiffForPatch(diff)
 }
 
 func (cmd *DiffCmd) outputJSON(prCtx *PRContext, diff string, prID int) error {
-
-	if cmd.File != "" {
-		diff = utils.FilterDiffByFile(diff, cmd.File)
-	}
-
-	diffData := map[string]interface{}{
-		"pull_request_id": prID,
-		"workspace":       prCtx.Workspace,
-		"repository":      prCtx.Repository,
-		"diff":            diff,
-		"files_changed":   utils.ExtractChangedFiles(diff),
-		"stats":           utils.CalculateDiffStats(diff),
-	}
-
-	if cmd.File != "" {
-		diffData["filtered_file"] = cmd.File
-	}
-
-	return prCtx.Formatter.Format(diffData)
+	return prCtx.Formatter.Format(cmd.diffData(prCtx, diff, prID))
 }
 
 func (cmd *DiffCmd) outputYAML(prCtx *PRContext, diff string, prID int) error {
@@ -181,24 +173,31 @@ func (cmd *DiffCmd) outputYAML(prCtx *PRContext, diff string, prID int) error {
 		return fmt.Errorf("failed to create YAML formatter: %w", err)
 	}
 
+	return yamlFormatter.Format(cmd.diffData(prCtx, diff, prID))
+}
+
+// diffData is the structured diff output; files and stats share the shape
+// of pr files --output json
+func (cmd *DiffCmd) diffData(prCtx *PRContext, diff string, prID int) map[string]interface{} {
 	if cmd.File != "" {
 		diff = utils.FilterDiffByFile(diff, cmd.File)
 	}
 
+	stat := newDiffStatOutput(utils.ParseDiffFiles(diff))
 	diffData := map[string]interface{}{
 		"pull_request_id": prID,
 		"workspace":       prCtx.Workspace,
 		"repository":      prCtx.Repository,
 		"diff":            diff,
-		"files_changed":   utils.ExtractChangedFiles(diff),
-		"stats":           utils.CalculateDiffStats(diff),
+		"files":           stat.Files,
+		"stats":           stat.Stats,
 	}
 
 	if cmd.File != "" {
 		diffData["filtered_file"] = cmd.File
 	}
 
-	return yamlFormatter.Format(diffData)
+	return diffData
 }
 
 func (cmd *DiffCmd) shouldUseColors() bool {
@@ -209,89 +208,12 @@ func (cmd *DiffCmd) shouldUseColors() bool {
 		return false
 	case "auto":
 
-		return !cmd.NoColor && utils.IsTerminal(os.Stdout)
+		return !cmd.NoColor && shared.StdoutIsTerminal()
 	default:
 		return false
 	}
 }
 
-func (cmd *DiffCmd) outputWithPager(diff string) error {
-	if cmd.File != "" {
-		diff = utils.FilterDiffByFile(diff, cmd.File)
-		if diff == "" {
-			fmt.Printf("No differences found for file: %s\n", cmd.File)
-			return nil
-		}
-	}
-
-	if _, err := exec.LookPath("less"); err != nil {
-		return fmt.Errorf("less is not installed or not in PATH")
-	}
-
-	coloredDiff := utils.FormatDiff(diff, true)
-
-	hasDiffSoFancy := false
-	if _, err := exec.LookPath("diff-so-fancy"); err == nil {
-		hasDiffSoFancy = true
-	}
-
-	if hasDiffSoFancy {
-		diffSoFancyCmd := exec.Command("diff-so-fancy")
-		diffSoFancyCmd.Stdin = strings.NewReader(coloredDiff)
-		diffSoFancyCmd.Env = append(os.Environ(), "FORCE_COLOR=1")
-
-		lessCmd := exec.Command("less", "--tabs=2", "-RFX")
-
-		pipe, err := diffSoFancyCmd.StdoutPipe()
-		if err != nil {
-			return fmt.Errorf("failed to create pipe: %w", err)
-		}
-		lessCmd.Stdin = pipe
-
-		lessCmd.Stdout = os.Stdout
-		lessCmd.Stderr = os.Stderr
-
-		if err := lessCmd.Start(); err != nil {
-			return fmt.Errorf("failed to start less: %w", err)
-		}
-
-		if err := diffSoFancyCmd.Start(); err != nil {
-			return fmt.Errorf("failed to start diff-so-fancy: %w", err)
-		}
-
-		if err := diffSoFancyCmd.Wait(); err != nil {
-			return fmt.Errorf("diff-so-fancy failed: %w", err)
-		}
-
-		pipe.Close()
-
-		if err := lessCmd.Wait(); err != nil {
-			if exitError, ok := err.(*exec.ExitError); ok {
-				if exitError.ExitCode() == 1 {
-					return nil
-				}
-			}
-			return fmt.Errorf("less failed: %w", err)
-		}
-	} else {
-		lessCmd := exec.Command("less", "--tabs=2", "-RFX")
-		lessCmd.Stdin = strings.NewReader(coloredDiff)
-		lessCmd.Stdout = os.Stdout
-		lessCmd.Stderr = os.Stderr
-
-		if err := lessCmd.Run(); err != nil {
-			if exitError, ok := err.(*exec.ExitError); ok {
-				if exitError.ExitCode() == 1 {
-					return nil
-				}
-			}
-			return fmt.Errorf("less failed: %w", err)
-		}
-	}
-
-	return nil
-}
-
 func (cmd *DiffCmd) filterTestFiles(diff string) string {
 	var result strings.Builder
 	lines := strings.Split(diff, "\n")
@@ -0,0 +1,71 @@
+package pr
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+func (cmd *DiffCmd) validateApplyFlags() error {
+	if cmd.Apply {
+		if cmd.NameOnly || cmd.Page {
+			return fmt.Errorf("--apply cannot be combined with --name-only or --page")
+		}
+		return nil
+	}
+	if cmd.Check {
+		return fmt.Errorf("--check requires --apply")
+	}
+	if cmd.ThreeWay {
+		return fmt.Errorf("--3way requires --apply")
+	}
+	return nil
+}
+
+// gitApplyArgs builds the git apply invocation; the patch is read from stdin
+func (cmd *DiffCmd) gitApplyArgs() []string {
+	args := []string{"apply"}
+	if cmd.Check {
+		args = append(args, "--check")
+	}
+	if cmd.ThreeWay {
+		args = append(args, "--3way")
+	}
+	return append(args, "-")
+}
+
+// applyPatch pipes the pull request's patch into git apply in the current
+// working tree, leaving conflicts and rejects to git to report
+func (cmd *DiffCmd) applyPatch(prID int, diff string) error {
+	patch := cmd.buildPatch(diff)
+	if strings.TrimSpace(patch) == "" {
+		if cmd.File != "" {
+			return fmt.Errorf("no differences found for file: %s", cmd.File)
+		}
+		return fmt.Errorf("pull request #%d has nothing to apply", prID)
+	}
+
+	if _, err := exec.LookPath("git"); err != nil {
+		return fmt.Errorf("git is not installed or not in PATH")
+	}
+
+	gitCmd := exec.Command("git", cmd.gitApplyArgs()...)
+	gitCmd.Stdin = strings.NewReader(patch)
+	gitCmd.Stdout = os.Stdout
+	gitCmd.Stderr = os.Stderr
+
+	if err := gitCmd.Run(); err != nil {
+		if cmd.Check {
+			return fmt.Errorf("patch for pull request #%d does not apply cleanly", prID)
+		}
+		return fmt.Errorf("failed to apply patch for pull request #%d: %w", prID, err)
+	}
+
+	if cmd.Check {
+		fmt.Printf("✓ Patch for pull request #%d applies cleanly\n", prID)
+	} else {
+		fmt.Printf("✓ Applied pull request #%d to the working tree\n", prID)
+	}
+	return nil
+}
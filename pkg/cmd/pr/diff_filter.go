@@ -0,0 +1,76 @@
+package pr
+
+import (
+	"fmt"
+	"strings"
+)
+
+func (cmd *DiffCmd) validateLineFilterFlags() error {
+	if !cmd.OnlyAdded && !cmd.OnlyRemoved {
+		return nil
+	}
+	if cmd.OnlyAdded && cmd.OnlyRemoved {
+		return fmt.Errorf("--only-added and --only-removed cannot be used together")
+	}
+	// The filtered hunks no longer match their line counts, so git can't apply them
+	if cmd.Apply || cmd.Patch {
+		return fmt.Errorf("--only-added and --only-removed cannot be combined with --apply or --patch")
+	}
+	return nil
+}
+
+// lineFilterName describes the active line filter for empty-result messages
+func (cmd *DiffCmd) lineFilterName() string {
+	if cmd.OnlyRemoved {
+		return "removed"
+	}
+	return "added"
+}
+
+// filterDiffLines keeps only the hunk lines starting with marker ('+' or
+// '-'), dropping context and the complementary changes. File and hunk
+// headers are kept for the files and hunks that still have lines.
+func filterDiffLines(diff string, marker byte) string {
+	var result, fileHeader, hunk strings.Builder
+	hunkHasLines, keptPrevious := false, false
+
+	flushHunk := func() {
+		if hunkHasLines {
+			result.WriteString(fileHeader.String())
+			fileHeader.Reset()
+			result.WriteString(hunk.String())
+		}
+		hunk.Reset()
+		hunkHasLines = false
+	}
+
+	inHunk := false
+	for _, line := range strings.Split(diff, "\n") {
+		switch {
+		case strings.HasPrefix(line, "diff --git"):
+			flushHunk()
+			fileHeader.Reset()
+			inHunk = false
+			fileHeader.WriteString(line + "\n")
+		case strings.HasPrefix(line, "@@"):
+			flushHunk()
+			inHunk = true
+			hunk.WriteString(line + "\n")
+		case !inHunk:
+			fileHeader.WriteString(line + "\n")
+		case len(line) > 0 && line[0] == marker:
+			hunk.WriteString(line + "\n")
+			hunkHasLines, keptPrevious = true, true
+			continue
+		case strings.HasPrefix(line, `\ `):
+			// "\ No newline at end of file" belongs to the line before it
+			if keptPrevious {
+				hunk.WriteString(line + "\n")
+			}
+		}
+		keptPrevious = false
+	}
+	flushHunk()
+
+	return strings.TrimSuffix(result.String(), "\n")
+}
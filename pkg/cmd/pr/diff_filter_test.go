@@ -0,0 +1,81 @@
+package pr
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const mixedDiff = `diff --git a/main.go b/main.go
+index 1234567..abcdefg 100644
+--- a/main.go
++++ b/main.go
+@@ -1,4 +1,4 @@
+ package main
+-import "fmt"
++import "log"
+ 
+@@ -10,3 +10,4 @@ func main() {
+ 	run()
++	log.Println("done")
+ }
+diff --git a/old.txt b/old.txt
+deleted file mode 100644
+index 1111111..0000000
+--- a/old.txt
++++ /dev/null
+@@ -1,2 +0,0 @@
+-first
+-last
+\ No newline at end of file`
+
+func TestFilterDiffLines_Added(t *testing.T) {
+	expected := `diff --git a/main.go b/main.go
+index 1234567..abcdefg 100644
+--- a/main.go
++++ b/main.go
+@@ -1,4 +1,4 @@
++import "log"
+@@ -10,3 +10,4 @@ func main() {
++	log.Println("done")`
+
+	assert.Equal(t, expected, filterDiffLines(mixedDiff, '+'))
+}
+
+func TestFilterDiffLines_Removed(t *testing.T) {
+	expected := `diff --git a/main.go b/main.go
+index 1234567..abcdefg 100644
+--- a/main.go
++++ b/main.go
+@@ -1,4 +1,4 @@
+-import "fmt"
+diff --git a/old.txt b/old.txt
+deleted file mode 100644
+index 1111111..0000000
+--- a/old.txt
++++ /dev/null
+@@ -1,2 +0,0 @@
+-first
+-last
+\ No newline at end of file`
+
+	assert.Equal(t, expected, filterDiffLines(mixedDiff, '-'))
+}
+
+func TestFilterDiffLines_NothingLeft(t *testing.T) {
+	diff := `diff --git a/old.txt b/old.txt
+--- a/old.txt
++++ /dev/null
+@@ -1 +0,0 @@
+-gone`
+
+	assert.Empty(t, filterDiffLines(diff, '+'))
+}
+
+func TestDiffCmd_validateLineFilterFlags(t *testing.T) {
+	assert.NoError(t, (&DiffCmd{}).validateLineFilterFlags())
+	assert.NoError(t, (&DiffCmd{OnlyAdded: true, NameOnly: true}).validateLineFilterFlags())
+	assert.Error(t, (&DiffCmd{OnlyAdded: true, OnlyRemoved: true}).validateLineFilterFlags())
+	assert.Error(t, (&DiffCmd{OnlyRemoved: true, Apply: true}).validateLineFilterFlags())
+	assert.Error(t, (&DiffCmd{OnlyAdded: true, Patch: true}).validateLineFilterFlags())
+}
@@ -8,15 +8,35 @@ import (
 
 var hunkHeaderRe = regexp.MustCompile(`^@@ -\d+(?:,\d+)? \+(\d+)(?:,\d+)? @@`)
 
+// addedLine is a line added by a diff, numbered in the post-image file
+type addedLine struct {
+	File string
+	Line int
+	Text string
+}
+
 // ParseAddedLinesByFile parses a unified diff and returns added line numbers
 // per file. The map key is the post-image path (b/...) without the b/ prefix.
 // Removed and context lines are not included.
 func ParseAddedLinesByFile(diff string) map[string]map[int]bool {
 	result := make(map[string]map[int]bool)
+	for _, added := range parseAddedLines(diff) {
+		if _, ok := result[added.File]; !ok {
+			result[added.File] = make(map[int]bool)
+		}
+		result[added.File][added.Line] = true
+	}
+	return result
+}
+
+// parseAddedLines returns the lines added by a unified diff in diff order,
+// without their leading +
+func parseAddedLines(diff string) []addedLine {
 	if diff == "" {
-		return result
+		return nil
 	}
 
+	var lines []addedLine
 	var currentFile string
 	var newLine int
 	for _, line := range strings.Split(diff, "\n") {
@@ -25,9 +45,6 @@ func ParseAddedLinesByFile(diff string) map[string]map[int]bool {
 			currentFile = ""
 		case strings.HasPrefix(line, "+++ b/"):
 			currentFile = strings.TrimPrefix(line, "+++ b/")
-			if _, ok := result[currentFile]; !ok {
-				result[currentFile] = make(map[int]bool)
-			}
 		case strings.HasPrefix(line, "+++ "):
 			currentFile = ""
 		case strings.HasPrefix(line, "@@"):
@@ -38,7 +55,7 @@ func ParseAddedLinesByFile(diff string) map[string]map[int]bool {
 				}
 			}
 		case currentFile != "" && strings.HasPrefix(line, "+") && !strings.HasPrefix(line, "+++"):
-			result[currentFile][newLine] = true
+			lines = append(lines, addedLine{File: currentFile, Line: newLine, Text: line[1:]})
 			newLine++
 		case currentFile != "" && strings.HasPrefix(line, "-") && !strings.HasPrefix(line, "---"):
 			// removed line: do not advance newLine
@@ -46,5 +63,5 @@ func ParseAddedLinesByFile(diff string) map[string]map[int]bool {
 			newLine++
 		}
 	}
-	return result
+	return lines
 }
@@ -0,0 +1,221 @@
+package pr
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+	"syscall"
+
+	"github.com/carlosarraes/bt/pkg/utils"
+)
+
+// diffCounts tells how many file sections of a streamed diff each filter
+// let through, to explain an empty result
+type diffCounts struct {
+	files     int
+	nonTest   int
+	withLines int
+	matched   int
+}
+
+// eachDiffFile reads a unified diff and calls fn with the section of each
+// file: its diff --git line and the lines up to the next one. Text before
+// the first file comes as a section of its own. Only one section is held in
+// memory, and the sections add up to the diff byte for byte.
+func eachDiffFile(r io.Reader, fn func(section string) error) error {
+	reader := bufio.NewReaderSize(r, 64*1024)
+	var section strings.Builder
+	for {
+		line, err := reader.ReadString('\n')
+		if strings.HasPrefix(line, utils.DiffHeaderPrefix) && section.Len() > 0 {
+			if err := fn(section.String()); err != nil {
+				return err
+			}
+			section.Reset()
+		}
+		section.WriteString(line)
+
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read diff: %w", err)
+		}
+	}
+
+	if section.Len() > 0 {
+		return fn(section.String())
+	}
+	return nil
+}
+
+// isTestSection reports whether a file section changes a test file
+func isTestSection(section string) bool {
+	header, _, _ := strings.Cut(section, "\n")
+	if !strings.HasPrefix(header, utils.DiffHeaderPrefix) {
+		return false
+	}
+	parts := strings.Fields(header)
+	return len(parts) >= 4 && isTestFile(strings.TrimPrefix(parts[3], "b/"))
+}
+
+// writeDiff streams diff to w one file at a time, through the same filters
+// as the whole diff gets: test files, --only-added/--only-removed and
+// --file. render turns each section that is left into its output.
+func (cmd *DiffCmd) writeDiff(diff io.Reader, w io.Writer, render func(section string) string) (diffCounts, error) {
+	var counts diffCounts
+	err := eachDiffFile(diff, func(section string) error {
+		if strings.TrimSpace(section) == "" {
+			return nil
+		}
+		counts.files++
+
+		if !cmd.IncludeTests && isTestSection(section) {
+			return nil
+		}
+		counts.nonTest++
+
+		if cmd.OnlyAdded || cmd.OnlyRemoved {
+			marker := byte('+')
+			if cmd.OnlyRemoved {
+				marker = '-'
+			}
+			section = filterDiffLines(section, marker)
+			if section == "" {
+				return nil
+			}
+			section += "\n"
+		}
+		counts.withLines++
+
+		// --name-only matches --file against the file names instead
+		if cmd.File != "" && !cmd.NameOnly {
+			section = utils.FilterDiffByFile(section, cmd.File)
+			if section == "" {
+				return nil
+			}
+		}
+		counts.matched++
+
+		_, err := io.WriteString(w, render(section))
+		return err
+	})
+	return counts, err
+}
+
+// emptyDiffMessage explains why nothing was written, or returns "" when
+// something was
+func (cmd *DiffCmd) emptyDiffMessage(counts diffCounts) string {
+	switch {
+	case counts.files == 0:
+		return "No differences found in this pull request."
+	case counts.nonTest == 0:
+		return "No non-test changes found in this pull request."
+	case counts.withLines == 0:
+		return fmt.Sprintf("No %s lines found in this pull request.", cmd.lineFilterName())
+	case counts.matched == 0 && cmd.File != "" && !cmd.NameOnly && !cmd.Patch:
+		return fmt.Sprintf("No differences found for file: %s", cmd.File)
+	}
+	return ""
+}
+
+// renderSection is the output of one file section in the plain diff view,
+// with --name-only or with --patch
+func (cmd *DiffCmd) renderSection(section string) string {
+	switch {
+	case cmd.NameOnly:
+		var names strings.Builder
+		for _, file := range utils.ExtractChangedFiles(section) {
+			if cmd.File == "" || strings.Contains(file, cmd.File) {
+				names.WriteString(file + "\n")
+			}
+		}
+		return names.String()
+	case cmd.Patch:
+		return utils.CleanDiffForPatch(section)
+	}
+
+	useColors := cmd.shouldUseColors()
+	section = utils.AnnotateRenames(section)
+	if cmd.WordDiff {
+		return utils.FormatWordDiff(section, useColors)
+	}
+	return utils.FormatDiff(section, useColors)
+}
+
+// pageDiff streams the diff through diff-so-fancy, when installed, into
+// less. Quitting less before the end stops reading the diff.
+func (cmd *DiffCmd) pageDiff(diff io.Reader) error {
+	if _, err := exec.LookPath("less"); err != nil {
+		return fmt.Errorf("less is not installed or not in PATH")
+	}
+
+	hasDiffSoFancy := false
+	if _, err := exec.LookPath("diff-so-fancy"); err == nil {
+		hasDiffSoFancy = true
+	}
+
+	lessCmd := exec.Command("less", "--tabs=2", "-RFX")
+	lessCmd.Stdout = os.Stdout
+	lessCmd.Stderr = os.Stderr
+
+	var diffSoFancyCmd *exec.Cmd
+	var stdin io.WriteCloser
+	var err error
+	if hasDiffSoFancy {
+		diffSoFancyCmd = exec.Command("diff-so-fancy")
+		diffSoFancyCmd.Env = append(os.Environ(), "FORCE_COLOR=1")
+		if stdin, err = diffSoFancyCmd.StdinPipe(); err != nil {
+			return fmt.Errorf("failed to create pipe: %w", err)
+		}
+		if lessCmd.Stdin, err = diffSoFancyCmd.StdoutPipe(); err != nil {
+			return fmt.Errorf("failed to create pipe: %w", err)
+		}
+	} else if stdin, err = lessCmd.StdinPipe(); err != nil {
+		return fmt.Errorf("failed to create pipe: %w", err)
+	}
+
+	if err := lessCmd.Start(); err != nil {
+		return fmt.Errorf("failed to start less: %w", err)
+	}
+	if diffSoFancyCmd != nil {
+		if err := diffSoFancyCmd.Start(); err != nil {
+			return fmt.Errorf("failed to start diff-so-fancy: %w", err)
+		}
+	}
+
+	counts, writeErr := cmd.writeDiff(diff, stdin, func(section string) string {
+		// diff-so-fancy renders renames itself; plain less gets the annotation
+		if !hasDiffSoFancy {
+			section = utils.AnnotateRenames(section)
+		}
+		return utils.FormatDiff(section, true)
+	})
+	stdin.Close()
+	// A broken pipe means less was quit before the end
+	quit := errors.Is(writeErr, syscall.EPIPE)
+
+	if diffSoFancyCmd != nil {
+		if err := diffSoFancyCmd.Wait(); err != nil && !quit {
+			return fmt.Errorf("diff-so-fancy failed: %w", err)
+		}
+	}
+	if err := lessCmd.Wait(); err != nil {
+		var exitError *exec.ExitError
+		if !errors.As(err, &exitError) || exitError.ExitCode() != 1 {
+			return fmt.Errorf("less failed: %w", err)
+		}
+	}
+
+	if writeErr != nil && !quit {
+		return writeErr
+	}
+	if message := cmd.emptyDiffMessage(counts); message != "" {
+		fmt.Println(message)
+	}
+	return nil
+}
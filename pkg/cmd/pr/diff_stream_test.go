@@ -0,0 +1,101 @@
+package pr
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const streamTestDiff = sampleDiff + `diff --git a/src/main_test.go b/src/main_test.go
+--- a/src/main_test.go
++++ b/src/main_test.go
+@@ -1,2 +1,2 @@
+-func TestOld(t *testing.T) {}
++func TestNew(t *testing.T) {}
+`
+
+func TestEachDiffFile(t *testing.T) {
+	var sections []string
+	err := eachDiffFile(strings.NewReader("preamble\n"+streamTestDiff), func(section string) error {
+		sections = append(sections, section)
+		return nil
+	})
+	require.NoError(t, err)
+
+	require.Len(t, sections, 4)
+	assert.Equal(t, "preamble\n", sections[0])
+	assert.True(t, strings.HasPrefix(sections[1], "diff --git a/src/main.go b/src/main.go\n"))
+	assert.True(t, strings.HasPrefix(sections[2], "diff --git a/README.md b/README.md\n"))
+	assert.Equal(t, "preamble\n"+streamTestDiff, strings.Join(sections, ""))
+}
+
+func TestEachDiffFile_NoTrailingNewline(t *testing.T) {
+	diff := strings.TrimSuffix(sampleDiff, "\n")
+	var joined strings.Builder
+	require.NoError(t, eachDiffFile(strings.NewReader(diff), func(section string) error {
+		joined.WriteString(section)
+		return nil
+	}))
+	assert.Equal(t, diff, joined.String())
+}
+
+func streamDiff(t *testing.T, cmd *DiffCmd, diff string) (string, string) {
+	t.Helper()
+
+	var out strings.Builder
+	counts, err := cmd.writeDiff(strings.NewReader(diff), &out, cmd.renderSection)
+	require.NoError(t, err)
+	return out.String(), cmd.emptyDiffMessage(counts)
+}
+
+func TestDiffCmd_writeDiff_MatchesWholeDiff(t *testing.T) {
+	cmd := &DiffCmd{Color: "never"}
+	out, message := streamDiff(t, cmd, streamTestDiff)
+	assert.Equal(t, cmd.filterTestFiles(streamTestDiff)+"\n", out)
+	assert.Empty(t, message)
+
+	cmd = &DiffCmd{Color: "never", IncludeTests: true}
+	out, _ = streamDiff(t, cmd, streamTestDiff)
+	assert.Equal(t, streamTestDiff, out)
+
+	cmd = &DiffCmd{Color: "never", OnlyAdded: true}
+	out, _ = streamDiff(t, cmd, streamTestDiff)
+	assert.Equal(t, filterDiffLines(cmd.filterTestFiles(streamTestDiff), '+')+"\n", out)
+
+	cmd = &DiffCmd{Patch: true, File: "README"}
+	out, _ = streamDiff(t, cmd, streamTestDiff)
+	assert.Equal(t, cmd.buildPatch(streamTestDiff), out)
+}
+
+func TestDiffCmd_writeDiff_NameOnly(t *testing.T) {
+	out, _ := streamDiff(t, &DiffCmd{NameOnly: true}, streamTestDiff)
+	assert.Equal(t, "src/main.go\nREADME.md\n", out)
+
+	out, _ = streamDiff(t, &DiffCmd{NameOnly: true, IncludeTests: true, File: "main"}, streamTestDiff)
+	assert.Equal(t, "src/main.go\nsrc/main_test.go\n", out)
+}
+
+func TestDiffCmd_emptyDiffMessage(t *testing.T) {
+	tests := []struct {
+		name string
+		cmd  *DiffCmd
+		diff string
+		want string
+	}{
+		{name: "empty diff", cmd: &DiffCmd{}, diff: "", want: "No differences found in this pull request."},
+		{name: "only tests", cmd: &DiffCmd{}, diff: strings.TrimPrefix(streamTestDiff, sampleDiff), want: "No non-test changes found in this pull request."},
+		{name: "no removed lines", cmd: &DiffCmd{OnlyRemoved: true}, diff: sampleDiff[strings.Index(sampleDiff, "diff --git a/README.md"):], want: "No removed lines found in this pull request."},
+		{name: "file not in diff", cmd: &DiffCmd{File: "missing.go"}, diff: sampleDiff, want: "No differences found for file: missing.go"},
+		{name: "file not in patch", cmd: &DiffCmd{File: "missing.go", Patch: true}, diff: sampleDiff, want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.cmd.Color = "never"
+			_, message := streamDiff(t, tt.cmd, tt.diff)
+			assert.Equal(t, tt.want, message)
+		})
+	}
+}
@@ -1,6 +1,9 @@
 package pr
 
 import (
+	"os"
+	"os/exec"
+	"path/filepath"
 	"strings"
 	"testing"
 
@@ -426,3 +429,75 @@ func TestDiffCmd_OutputModeSelection(t *testing.T) {
 		})
 	}
 }
+
+func TestDiffCmd_validateApplyFlags(t *testing.T) {
+	tests := []struct {
+		name     string
+		cmd      *DiffCmd
+		errorMsg string
+	}{
+		{name: "no apply flags", cmd: &DiffCmd{}},
+		{name: "apply", cmd: &DiffCmd{Apply: true, Output: "diff"}},
+		{name: "apply with check and 3way", cmd: &DiffCmd{Apply: true, Check: true, ThreeWay: true}},
+		{name: "apply ignores configured json output", cmd: &DiffCmd{Apply: true, Output: "json"}},
+		{name: "check without apply", cmd: &DiffCmd{Check: true}, errorMsg: "--check requires --apply"},
+		{name: "3way without apply", cmd: &DiffCmd{ThreeWay: true}, errorMsg: "--3way requires --apply"},
+		{name: "apply with name-only", cmd: &DiffCmd{Apply: true, NameOnly: true}, errorMsg: "cannot be combined"},
+		{name: "apply with page", cmd: &DiffCmd{Apply: true, Page: true}, errorMsg: "cannot be combined"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.cmd.validateApplyFlags()
+			if tt.errorMsg == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.Error(t, err)
+			assert.Contains(t, err.Error(), tt.errorMsg)
+		})
+	}
+}
+
+func TestDiffCmd_gitApplyArgs(t *testing.T) {
+	assert.Equal(t, []string{"apply", "-"}, (&DiffCmd{Apply: true}).gitApplyArgs())
+	assert.Equal(t, []string{"apply", "--check", "-"}, (&DiffCmd{Apply: true, Check: true}).gitApplyArgs())
+	assert.Equal(t, []string{"apply", "--check", "--3way", "-"}, (&DiffCmd{Apply: true, Check: true, ThreeWay: true}).gitApplyArgs())
+}
+
+func TestDiffCmd_applyPatch(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+
+	dir := t.TempDir()
+	require.NoError(t, exec.Command("git", "-C", dir, "init", "-q").Run())
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "hello.txt"), []byte("hello\n"), 0644))
+
+	wd, err := os.Getwd()
+	require.NoError(t, err)
+	require.NoError(t, os.Chdir(dir))
+	t.Cleanup(func() { os.Chdir(wd) })
+
+	diff := "diff --git a/hello.txt b/hello.txt\n" +
+		"index ce01362..94954ab 100644\n" +
+		"--- a/hello.txt\n" +
+		"+++ b/hello.txt\n" +
+		"@@ -1 +1,2 @@\n" +
+		" hello\n" +
+		"+world\n"
+
+	require.NoError(t, (&DiffCmd{Apply: true, Check: true}).applyPatch(7, diff))
+	content, err := os.ReadFile(filepath.Join(dir, "hello.txt"))
+	require.NoError(t, err)
+	assert.Equal(t, "hello\n", string(content), "--check must not modify the working tree")
+
+	require.NoError(t, (&DiffCmd{Apply: true}).applyPatch(7, diff))
+	content, err = os.ReadFile(filepath.Join(dir, "hello.txt"))
+	require.NoError(t, err)
+	assert.Equal(t, "hello\nworld\n", string(content))
+
+	err = (&DiffCmd{Apply: true, Check: true}).applyPatch(7, diff)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "does not apply cleanly")
+}
@@ -0,0 +1,24 @@
+package pr
+
+import "fmt"
+
+// validateWordDiffFlags rejects --word-diff outside the plain diff view;
+// the merged lines are for reading, not for git or other programs
+func (cmd *DiffCmd) validateWordDiffFlags() error {
+	if !cmd.WordDiff {
+		return nil
+	}
+	switch {
+	case cmd.Apply || cmd.Patch:
+		return fmt.Errorf("--word-diff cannot be combined with --apply or --patch")
+	case cmd.NameOnly:
+		return fmt.Errorf("--word-diff cannot be combined with --name-only")
+	case cmd.Page:
+		return fmt.Errorf("--word-diff cannot be combined with --page")
+	case cmd.OnlyAdded || cmd.OnlyRemoved:
+		return fmt.Errorf("--word-diff needs both sides of a change; drop --only-added/--only-removed")
+	case cmd.Output != "" && cmd.Output != "diff":
+		return fmt.Errorf("--word-diff only applies to diff output, not %s", cmd.Output)
+	}
+	return nil
+}
@@ -0,0 +1,15 @@
+package pr
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiffCmd_validateWordDiffFlags(t *testing.T) {
+	assert.NoError(t, (&DiffCmd{}).validateWordDiffFlags())
+	assert.NoError(t, (&DiffCmd{WordDiff: true, Output: "diff", File: "main.go"}).validateWordDiffFlags())
+	assert.Error(t, (&DiffCmd{WordDiff: true, Patch: true}).validateWordDiffFlags())
+	assert.Error(t, (&DiffCmd{WordDiff: true, OnlyAdded: true}).validateWordDiffFlags())
+	assert.Error(t, (&DiffCmd{WordDiff: true, Output: "json"}).validateWordDiffFlags())
+}
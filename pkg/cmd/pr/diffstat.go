@@ -0,0 +1,65 @@
+package pr
+
+import (
+	"strings"
+
+	"github.com/carlosarraes/bt/pkg/api"
+	"github.com/carlosarraes/bt/pkg/utils"
+)
+
+// diffStatOutput is the structured form of a pull request's changed files.
+// pr diff and pr files both emit it so tooling sees the same shape from either.
+type diffStatOutput struct {
+	Files []utils.DiffFile `json:"files"`
+	Stats utils.DiffStats  `json:"stats"`
+}
+
+func newDiffStatOutput(files []utils.DiffFile) diffStatOutput {
+	return diffStatOutput{
+		Files: files,
+		Stats: utils.SummarizeDiffFiles(files),
+	}
+}
+
+// diffFilesFromAPI converts Bitbucket diffstat entries to the canonical form
+func diffFilesFromAPI(files []*api.PullRequestFile) []utils.DiffFile {
+	result := make([]utils.DiffFile, 0, len(files))
+	for _, file := range files {
+		entry := utils.DiffFile{
+			OldPath:      file.OldPath,
+			NewPath:      file.NewPath,
+			Status:       diffFileStatus(file),
+			LinesAdded:   file.LinesAdded,
+			LinesRemoved: file.LinesRemoved,
+			Binary:       file.Binary,
+		}
+		entry.Path = entry.NewPath
+		if entry.Path == "" {
+			entry.Path = entry.OldPath
+		}
+		result = append(result, entry)
+	}
+	return result
+}
+
+func diffFileStatus(file *api.PullRequestFile) string {
+	switch strings.ToLower(file.Status) {
+	case "added":
+		return utils.DiffStatusAdded
+	case "removed", "deleted":
+		return utils.DiffStatusRemoved
+	case "renamed":
+		return utils.DiffStatusRenamed
+	}
+
+	switch {
+	case isRenamed(file):
+		return utils.DiffStatusRenamed
+	case file.OldPath == "" && file.NewPath != "":
+		return utils.DiffStatusAdded
+	case file.NewPath == "" && file.OldPath != "":
+		return utils.DiffStatusRemoved
+	default:
+		return utils.DiffStatusModified
+	}
+}
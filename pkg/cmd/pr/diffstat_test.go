@@ -0,0 +1,62 @@
+package pr
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/carlosarraes/bt/pkg/api"
+	"github.com/carlosarraes/bt/pkg/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiffFilesFromAPI(t *testing.T) {
+	files := diffFilesFromAPI([]*api.PullRequestFile{
+		{Status: "modified", OldPath: "main.go", NewPath: "main.go", LinesAdded: 2, LinesRemoved: 1},
+		{Status: "removed", OldPath: "old.txt", LinesRemoved: 1},
+		{OldPath: "a.go", NewPath: "b.go", Status: "renamed"},
+		{NewPath: "docs/new.md", LinesAdded: 2},
+		{Status: "modified", OldPath: "logo.png", NewPath: "logo.png", Binary: true},
+	})
+
+	expected := []utils.DiffFile{
+		{Path: "main.go", OldPath: "main.go", NewPath: "main.go", Status: utils.DiffStatusModified, LinesAdded: 2, LinesRemoved: 1},
+		{Path: "old.txt", OldPath: "old.txt", Status: utils.DiffStatusRemoved, LinesRemoved: 1},
+		{Path: "b.go", OldPath: "a.go", NewPath: "b.go", Status: utils.DiffStatusRenamed},
+		{Path: "docs/new.md", NewPath: "docs/new.md", Status: utils.DiffStatusAdded, LinesAdded: 2},
+		{Path: "logo.png", OldPath: "logo.png", NewPath: "logo.png", Status: utils.DiffStatusModified, Binary: true},
+	}
+	assert.Equal(t, expected, files)
+}
+
+// pr diff derives files from the patch and pr files from the diffstat API;
+// both must serialize to the same schema
+func TestDiffStatOutput_SharedSchema(t *testing.T) {
+	diff := `diff --git a/main.go b/main.go
+index 1111111..2222222 100644
+--- a/main.go
++++ b/main.go
+@@ -1 +1,2 @@
+-package old
++package main
++func main() {}
+`
+	fromDiff := newDiffStatOutput(utils.ParseDiffFiles(diff))
+	fromAPI := newDiffStatOutput(diffFilesFromAPI([]*api.PullRequestFile{
+		{Status: "modified", OldPath: "main.go", NewPath: "main.go", LinesAdded: 2, LinesRemoved: 1},
+	}))
+
+	diffJSON, err := json.Marshal(fromDiff)
+	require.NoError(t, err)
+	apiJSON, err := json.Marshal(fromAPI)
+	require.NoError(t, err)
+	assert.JSONEq(t, string(diffJSON), string(apiJSON))
+
+	var decoded map[string]interface{}
+	require.NoError(t, json.Unmarshal(apiJSON, &decoded))
+	file := decoded["files"].([]interface{})[0].(map[string]interface{})
+	for _, key := range []string{"path", "old_path", "new_path", "status", "lines_added", "lines_removed", "binary"} {
+		assert.Contains(t, file, key)
+	}
+	assert.Equal(t, map[string]interface{}{"files_changed": 1.0, "lines_added": 2.0, "lines_removed": 1.0}, decoded["stats"])
+}
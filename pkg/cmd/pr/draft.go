@@ -0,0 +1,102 @@
+package pr
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"time"
+)
+
+// PRDraft is a composed pull request kept on disk until it is created, so a
+// failed create doesn't lose an expensive AI-generated description.
+type PRDraft struct {
+	Workspace  string    `json:"workspace"`
+	Repository string    `json:"repository"`
+	Branch     string    `json:"branch"`
+	Base       string    `json:"base"`
+	Title      string    `json:"title"`
+	Body       string    `json:"body"`
+	SavedAt    time.Time `json:"saved_at"`
+}
+
+var draftUnsafeChars = regexp.MustCompile(`[^A-Za-z0-9._-]+`)
+
+func draftDir() (string, error) {
+	configDir := os.Getenv("XDG_CONFIG_HOME")
+	if configDir == "" {
+		homeDir, err := os.UserHomeDir()
+		if err != nil {
+			return "", fmt.Errorf("failed to get home directory: %w", err)
+		}
+		configDir = filepath.Join(homeDir, ".config")
+	}
+	return filepath.Join(configDir, "bt", "drafts"), nil
+}
+
+// draftPath returns the draft file for a branch. Drafts are keyed by
+// repository as well so identically named branches don't collide.
+func draftPath(workspace, repository, branch string) (string, error) {
+	dir, err := draftDir()
+	if err != nil {
+		return "", err
+	}
+	name := draftUnsafeChars.ReplaceAllString(workspace+"_"+repository+"_"+branch, "-")
+	return filepath.Join(dir, name+".json"), nil
+}
+
+// saveDraft writes the draft, returning the file path.
+func saveDraft(draft *PRDraft) (string, error) {
+	path, err := draftPath(draft.Workspace, draft.Repository, draft.Branch)
+	if err != nil {
+		return "", err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return "", fmt.Errorf("failed to create drafts directory: %w", err)
+	}
+
+	data, err := json.MarshalIndent(draft, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to encode draft: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0600); err != nil {
+		return "", fmt.Errorf("failed to save draft: %w", err)
+	}
+	return path, nil
+}
+
+// loadDraft reads the saved draft for a branch; it returns nil without an
+// error when there is none.
+func loadDraft(workspace, repository, branch string) (*PRDraft, error) {
+	path, err := draftPath(workspace, repository, branch)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read draft: %w", err)
+	}
+
+	var draft PRDraft
+	if err := json.Unmarshal(data, &draft); err != nil {
+		return nil, fmt.Errorf("failed to parse draft %s: %w", path, err)
+	}
+	return &draft, nil
+}
+
+func removeDraft(workspace, repository, branch string) error {
+	path, err := draftPath(workspace, repository, branch)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return nil
+}
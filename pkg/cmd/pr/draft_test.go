@@ -0,0 +1,72 @@
+package pr
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDraftRoundTrip(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", dir)
+
+	draft := &PRDraft{
+		Workspace:  "acme",
+		Repository: "api",
+		Branch:     "feature/ZUP-12-login",
+		Base:       "main",
+		Title:      "Add login",
+		Body:       "## Summary\nAI generated",
+		SavedAt:    time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC),
+	}
+
+	path, err := saveDraft(draft)
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(dir, "bt", "drafts", "acme_api_feature-ZUP-12-login.json"), path)
+
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+
+	loaded, err := loadDraft("acme", "api", "feature/ZUP-12-login")
+	require.NoError(t, err)
+	assert.Equal(t, draft, loaded)
+
+	other, err := loadDraft("acme", "web", "feature/ZUP-12-login")
+	require.NoError(t, err)
+	assert.Nil(t, other, "drafts are keyed by repository")
+
+	require.NoError(t, removeDraft("acme", "api", "feature/ZUP-12-login"))
+	loaded, err = loadDraft("acme", "api", "feature/ZUP-12-login")
+	require.NoError(t, err)
+	assert.Nil(t, loaded)
+
+	assert.NoError(t, removeDraft("acme", "api", "feature/ZUP-12-login"), "removing a missing draft is not an error")
+}
+
+func TestLoadDraft_Corrupt(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", dir)
+
+	path, err := draftPath("acme", "api", "main")
+	require.NoError(t, err)
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0700))
+	require.NoError(t, os.WriteFile(path, []byte("{not json"), 0600))
+
+	_, err = loadDraft("acme", "api", "main")
+	assert.Error(t, err)
+}
+
+func TestResolveDraft_RecoverWithoutDraft(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	cmd := &CreateCmd{Recover: true}
+	_, err := cmd.resolveDraft(&PRContext{Workspace: "acme", Repository: "api"}, "main")
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "no saved draft")
+}
@@ -25,7 +25,7 @@ type EditCmd struct {
 	Ready          bool     `help:"Mark pull request as ready for review (if draft)"`
 	Draft          bool     `help:"Convert pull request to draft"`
 	AI             bool     `help:"Generate PR description using AI analysis"`
-	Jira           string   `help:"Path to JIRA context file (markdown format)"`
+	Jira           string   `help:"JIRA ticket key to fetch from jira.base_url (token in JIRA_API_TOKEN), or path to a JIRA context file (markdown format)"`
 	Debug          bool     `help:"Print debug information including git diff and AI inputs"`
 	Output         string   `short:"o" help:"Output format (table, json, yaml)" enum:"table,json,yaml" default:"table"`
 	NoColor        bool
@@ -39,14 +39,7 @@ func (cmd *EditCmd) Run(ctx context.Context) error {
 		return err
 	}
 
-	if cmd.Workspace != "" {
-		prCtx.Workspace = cmd.Workspace
-	}
-	if cmd.Repository != "" {
-		prCtx.Repository = cmd.Repository
-	}
-
-	if err := prCtx.ValidateWorkspaceAndRepo(); err != nil {
+	if err := prCtx.ResolveRepository(cmd.Workspace, cmd.Repository); err != nil {
 		return err
 	}
 
@@ -69,11 +62,12 @@ func (cmd *EditCmd) Run(ctx context.Context) error {
 	}
 
 	if cmd.AI {
-		if err := cmd.validateAIOptions(); err != nil {
+		jira, err := jiraSource(prCtx, cmd.Jira)
+		if err != nil {
 			return err
 		}
 
-		aiResult, err := cmd.generateAIDescription(ctx, prCtx, pr)
+		aiResult, err := cmd.generateAIDescription(ctx, prCtx, pr, jira)
 		if err != nil {
 			fmt.Printf("⚠️  AI generation failed: %v\n", err)
 			fmt.Println("Falling back to current description...")
@@ -373,17 +367,7 @@ func (cmd *EditCmd) formatTable(prCtx *PRContext, pr *api.PullRequest) error {
 	return nil
 }
 
-func (cmd *EditCmd) validateAIOptions() error {
-	if cmd.Jira != "" {
-		if _, err := os.Stat(cmd.Jira); os.IsNotExist(err) {
-			return fmt.Errorf("JIRA context file not found: %s", cmd.Jira)
-		}
-	}
-
-	return nil
-}
-
-func (cmd *EditCmd) generateAIDescription(ctx context.Context, prCtx *PRContext, pr *api.PullRequest) (*ai.PRDescriptionResult, error) {
+func (cmd *EditCmd) generateAIDescription(ctx context.Context, prCtx *PRContext, pr *api.PullRequest, jira ai.JiraSource) (*ai.PRDescriptionResult, error) {
 	repo, err := git.NewRepository("")
 	if err != nil {
 		return nil, fmt.Errorf("failed to get git repository: %w", err)
@@ -404,7 +388,7 @@ func (cmd *EditCmd) generateAIDescription(ctx context.Context, prCtx *PRContext,
 	opts := &ai.GenerateOptions{
 		SourceBranch: sourceBranch,
 		TargetBranch: targetBranch,
-		JiraFile:     cmd.Jira,
+		Jira:         jira,
 		Verbose:      true,
 		Debug:        cmd.Debug,
 	}
@@ -4,11 +4,13 @@ import (
 	"context"
 	"fmt"
 	"path/filepath"
+	"sort"
 	"strconv"
 	"strings"
 
 	"github.com/carlosarraes/bt/pkg/api"
 	"github.com/carlosarraes/bt/pkg/cmd/shared"
+	"github.com/carlosarraes/bt/pkg/utils"
 )
 
 type FilesCmd struct {
@@ -16,6 +18,7 @@ type FilesCmd struct {
 
 	NameOnly bool   `help:"Show only file names"`
 	Filter   string `help:"Filter files by pattern (e.g., '*.go', 'src/**/*.js')"`
+	Sort     string `help:"Sort files by name, additions, deletions or changes (added plus removed lines); counts sort largest first" enum:"name,additions,deletions,changes" default:"changes"`
 
 	Output     string `short:"o" help:"Output format (table, json, yaml)" default:"table"`
 	Workspace  string `help:"Bitbucket workspace (defaults to current repository)"`
@@ -58,17 +61,15 @@ func (c *FilesCmd) Run(ctx context.Context) error {
 	if c.Filter != "" {
 		filtered := make([]*api.PullRequestFile, 0)
 		for _, file := range files {
-			path := file.NewPath
-			if path == "" {
-				path = file.OldPath
-			}
-			if c.matchesFilter(path, c.Filter) {
+			if c.matchesFilter(changedFilePath(file), c.Filter) {
 				filtered = append(filtered, file)
 			}
 		}
 		files = filtered
 	}
 
+	sortFiles(files, c.Sort)
+
 	return c.formatOutput(prCtx, files)
 }
 
@@ -85,33 +86,47 @@ func (c *FilesCmd) matchesFilter(path, pattern string) bool {
 	return matched
 }
 
-func (c *FilesCmd) formatOutput(prCtx *PRContext, files []*api.PullRequestFile) error {
-	if c.Output == "json" || c.Output == "yaml" {
-		output := struct {
-			Files        []*api.PullRequestFile `json:"files" yaml:"files"`
-			TotalFiles   int                    `json:"total_files" yaml:"total_files"`
-			TotalAdded   int                    `json:"total_added" yaml:"total_added"`
-			TotalRemoved int                    `json:"total_removed" yaml:"total_removed"`
-		}{
-			Files:      files,
-			TotalFiles: len(files),
+// sortFiles orders files by name, or by additions, deletions or changes
+// with the biggest first, so that review can start where most changed.
+// Files with the same count are sorted by name.
+func sortFiles(files []*api.PullRequestFile, by string) {
+	count := func(file *api.PullRequestFile) int {
+		switch by {
+		case "additions":
+			return file.LinesAdded
+		case "deletions":
+			return file.LinesRemoved
+		case "changes":
+			return file.LinesAdded + file.LinesRemoved
 		}
+		return 0
+	}
 
-		for _, file := range files {
-			output.TotalAdded += file.LinesAdded
-			output.TotalRemoved += file.LinesRemoved
+	sort.SliceStable(files, func(i, j int) bool {
+		if a, b := count(files[i]), count(files[j]); a != b {
+			return a > b
 		}
+		return changedFilePath(files[i]) < changedFilePath(files[j])
+	})
+}
 
-		return prCtx.Formatter.Format(output)
+// changedFilePath is the path of a file after the change, or before it for
+// deleted files
+func changedFilePath(file *api.PullRequestFile) string {
+	if file.NewPath == "" {
+		return file.OldPath
+	}
+	return file.NewPath
+}
+
+func (c *FilesCmd) formatOutput(prCtx *PRContext, files []*api.PullRequestFile) error {
+	if c.Output == "json" || c.Output == "yaml" {
+		return prCtx.Formatter.Format(newDiffStatOutput(diffFilesFromAPI(files)))
 	}
 
 	if c.NameOnly {
 		for _, file := range files {
-			path := file.NewPath
-			if path == "" {
-				path = file.OldPath
-			}
-			fmt.Println(path)
+			fmt.Println(changedFilePath(file))
 		}
 		return nil
 	}
@@ -139,14 +154,9 @@ func (c *FilesCmd) formatTable(prCtx *PRContext, files []*api.PullRequestFile) e
 		totalAdded += file.LinesAdded
 		totalRemoved += file.LinesRemoved
 
-		path := file.NewPath
-		if path == "" {
-			path = file.OldPath
-		}
-
 		rows = append(rows, []string{
 			status,
-			path,
+			c.displayPath(file),
 			added,
 			removed,
 		})
@@ -206,6 +216,8 @@ func (c *FilesCmd) renderTable(headers []string, rows [][]string) error {
 					cell = "\033[33m" + cell + "\033[0m"
 				case "D":
 					cell = "\033[31m" + cell + "\033[0m"
+				case "R":
+					cell = "\033[36m" + cell + "\033[0m"
 				}
 			}
 			if (i == 2 || i == 3) && cell != "0" && cell != "" {
@@ -227,10 +239,28 @@ func (c *FilesCmd) renderTable(headers []string, rows [][]string) error {
 	return nil
 }
 
+// displayPath shows renamed or moved files as "old → new"
+func (c *FilesCmd) displayPath(file *api.PullRequestFile) string {
+	if isRenamed(file) {
+		return utils.FormatFilePath(file.OldPath, file.NewPath)
+	}
+	return changedFilePath(file)
+}
+
+// isRenamed reports whether the diffstat entry moved the file, whether or
+// not Bitbucket labelled it "renamed"
+func isRenamed(file *api.PullRequestFile) bool {
+	return file.OldPath != "" && file.NewPath != "" && file.OldPath != file.NewPath
+}
+
 func (c *FilesCmd) getFileStatus(file *api.PullRequestFile) string {
 	hasAdditions := file.LinesAdded > 0
 	hasRemovals := file.LinesRemoved > 0
 
+	if isRenamed(file) {
+		return "R"
+	}
+
 	if file.Status != "" {
 		switch strings.ToLower(file.Status) {
 		case "added":
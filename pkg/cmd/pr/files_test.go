@@ -131,6 +131,15 @@ func TestFilesCmd_getFileStatus(t *testing.T) {
 			},
 			expected: "M",
 		},
+		{
+			name: "moved file without renamed status",
+			file: &api.PullRequestFile{
+				Status:  "modified",
+				OldPath: "pkg/old.go",
+				NewPath: "pkg/new/old.go",
+			},
+			expected: "R",
+		},
 		{
 			name: "unknown status defaults to modified",
 			file: &api.PullRequestFile{
@@ -151,6 +160,15 @@ func TestFilesCmd_getFileStatus(t *testing.T) {
 	}
 }
 
+func TestFilesCmd_displayPath(t *testing.T) {
+	cmd := &FilesCmd{}
+
+	assert.Equal(t, "old.go → new.go", cmd.displayPath(&api.PullRequestFile{OldPath: "old.go", NewPath: "new.go"}))
+	assert.Equal(t, "same.go", cmd.displayPath(&api.PullRequestFile{OldPath: "same.go", NewPath: "same.go"}))
+	assert.Equal(t, "gone.go", cmd.displayPath(&api.PullRequestFile{OldPath: "gone.go"}))
+	assert.Equal(t, "added.go", cmd.displayPath(&api.PullRequestFile{NewPath: "added.go"}))
+}
+
 func TestFilesCmd_validatePRID(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -206,3 +224,39 @@ func TestFilesCmd_validatePRID(t *testing.T) {
 		})
 	}
 }
+
+func TestSortFiles(t *testing.T) {
+	files := func() []*api.PullRequestFile {
+		return []*api.PullRequestFile{
+			{NewPath: "b.go", LinesAdded: 5, LinesRemoved: 1},
+			{OldPath: "gone.go", LinesRemoved: 40},
+			{NewPath: "a.go", LinesAdded: 3, LinesRemoved: 3},
+			{NewPath: "big.go", LinesAdded: 20, LinesRemoved: 2},
+		}
+	}
+	paths := func(files []*api.PullRequestFile) []string {
+		var result []string
+		for _, file := range files {
+			result = append(result, changedFilePath(file))
+		}
+		return result
+	}
+
+	tests := []struct {
+		by   string
+		want []string
+	}{
+		{by: "changes", want: []string{"gone.go", "big.go", "a.go", "b.go"}},
+		{by: "additions", want: []string{"big.go", "b.go", "a.go", "gone.go"}},
+		{by: "deletions", want: []string{"gone.go", "a.go", "big.go", "b.go"}},
+		{by: "name", want: []string{"a.go", "b.go", "big.go", "gone.go"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.by, func(t *testing.T) {
+			sorted := files()
+			sortFiles(sorted, tt.by)
+			assert.Equal(t, tt.want, paths(sorted))
+		})
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"strconv"
 	"strings"
 
+	"github.com/carlosarraes/bt/pkg/ai"
 	"github.com/carlosarraes/bt/pkg/cmd/shared"
 )
 
@@ -47,3 +48,21 @@ func ParsePRID(prIDStr string) (int, error) {
 
 	return prID, nil
 }
+
+// branchEndpoint is the API endpoint of a branch in the current repository
+func branchEndpoint(prCtx *PRContext, branch string) string {
+	return fmt.Sprintf("repositories/%s/%s/refs/branches/%s", prCtx.Workspace, prCtx.Repository, branch)
+}
+
+// jiraSource resolves --jira to a context file or to a ticket fetched from
+// jira.base_url; it is nil without --jira
+func jiraSource(prCtx *PRContext, value string) (ai.JiraSource, error) {
+	if value == "" {
+		return nil, nil
+	}
+	baseURL := ""
+	if prCtx.Config != nil {
+		baseURL = prCtx.Config.Jira.BaseURL
+	}
+	return ai.NewJiraSource(value, baseURL)
+}
@@ -6,6 +6,7 @@ import (
 	"os"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/carlosarraes/bt/pkg/api"
 	"github.com/carlosarraes/bt/pkg/cmd/shared"
@@ -14,12 +15,17 @@ import (
 
 type ListCmd struct {
 	State      string `help:"Filter by state (open, merged, declined, all)" default:"open"`
-	Author     string `help:"Filter by pull request author"`
-	Reviewer   string `help:"Filter by pull request reviewer"`
+	Author     string `help:"Filter by pull request author (username or @me)"`
+	Reviewer   string `help:"Filter by pull request reviewer (username or @me)"`
+	Mine       bool   `help:"Show only your pull requests (same as --author @me)"`
+	Base       string `help:"Filter by base (destination) branch; @default for the configured or default base"`
 	Limit      int    `help:"Maximum number of pull requests to show" default:"30"`
 	Sort       string `help:"Sort by field (created, updated, priority)" default:"updated"`
-	Output     string `short:"o" help:"Output format (table, json, yaml)" enum:"table,json,yaml" default:"table"`
+	Output     string `short:"o" help:"Output format (table, json, yaml, compact)" enum:"table,json,yaml,compact" default:"table"`
 	All        bool   `help:"Show all pull requests regardless of author"`
+	Detailed   bool   `help:"Fetch approvals, mergeability, check status and size for each pull request (extra API calls)"`
+	Stale      string `help:"Show only pull requests not updated for this long (e.g. 14d, 2w, 36h)"`
+	Draft      bool   `help:"Show only draft pull requests"`
 	Debug      bool   `help:"Show debug output"`
 	NoColor    bool
 	Workspace  string `help:"Bitbucket workspace (defaults to git remote or config)"`
@@ -39,12 +45,7 @@ func (cmd *ListCmd) Run(ctx context.Context) error {
 		}
 	}
 
-	if cmd.Workspace != "" {
-		prCtx.Workspace = cmd.Workspace
-	}
-	if cmd.Repository != "" {
-		prCtx.Repository = cmd.Repository
-	}
+	prCtx.ApplyRepoFlags(cmd.Workspace, cmd.Repository)
 
 	if cmd.Debug {
 		fmt.Fprintf(os.Stderr, "DEBUG: Using workspace: %s\n", prCtx.Workspace)
@@ -78,10 +79,31 @@ func (cmd *ListCmd) Run(ctx context.Context) error {
 		options.State = strings.ToUpper(cmd.State)
 	}
 
+	if cmd.Stale != "" {
+		staleFor, err := shared.ParseAge(cmd.Stale)
+		if err != nil {
+			return fmt.Errorf("invalid --stale: %w", err)
+		}
+		updatedBefore := time.Now().Add(-staleFor)
+		options.UpdatedBefore = &updatedBefore
+	}
+
+	if cmd.Mine && (cmd.All || cmd.Author != "") {
+		return fmt.Errorf("--mine cannot be combined with --all or --author")
+	}
+
 	if !cmd.All {
-		if cmd.Author != "" {
+		if cmd.Mine || isMeSelector(cmd.Author) {
+			username, err := currentUsername(ctx, prCtx.Client)
+			if err != nil {
+				return err
+			}
+			options.Author = username
+		} else if cmd.Author != "" {
 			options.Author = cmd.Author
-		} else {
+		} else if cmd.Reviewer == "" {
+			// Default to the current user's PRs, unless a reviewer filter
+			// already narrows the list to someone else's review queue
 			if prCtx.Client != nil {
 				currentUser, err := prCtx.Client.GetAuthManager().GetAuthenticatedUser(ctx)
 				if err == nil && currentUser != nil {
@@ -99,10 +121,26 @@ func (cmd *ListCmd) Run(ctx context.Context) error {
 		}
 	}
 
-	if cmd.Reviewer != "" {
+	if isMeSelector(cmd.Reviewer) {
+		username, err := currentUsername(ctx, prCtx.Client)
+		if err != nil {
+			return err
+		}
+		options.Reviewer = username
+	} else if cmd.Reviewer != "" {
 		options.Reviewer = cmd.Reviewer
 	}
 
+	if cmd.Base == defaultBaseSelector {
+		base, err := prCtx.DefaultBase(ctx)
+		if err != nil {
+			return err
+		}
+		options.Destination = base
+	} else if cmd.Base != "" {
+		options.Destination = cmd.Base
+	}
+
 	if cmd.Sort != "" {
 		switch strings.ToLower(cmd.Sort) {
 		case "created":
@@ -154,39 +192,63 @@ func (cmd *ListCmd) Run(ctx context.Context) error {
 		}
 	}
 
-	return cmd.formatOutput(prCtx, pullRequests)
+	// Bitbucket can't filter drafts server-side, so this narrows the fetched page
+	if cmd.Draft {
+		pullRequests = filterDrafts(pullRequests)
+	}
+
+	var details []*PRListDetail
+	if cmd.Detailed {
+		details = cmd.fetchDetails(ctx, prCtx, pullRequests)
+	}
+
+	return cmd.formatOutput(prCtx, pullRequests, details)
 }
 
-func (cmd *ListCmd) formatOutput(prCtx *PRContext, pullRequests []*api.PullRequest) error {
+func (cmd *ListCmd) formatOutput(prCtx *PRContext, pullRequests []*api.PullRequest, details []*PRListDetail) error {
 	switch cmd.Output {
 	case "table":
-		return cmd.formatTable(prCtx, pullRequests)
+		return cmd.formatTable(prCtx, pullRequests, details)
 	case "json":
-		return cmd.formatJSON(prCtx, pullRequests)
+		return cmd.formatJSON(prCtx, pullRequests, details)
 	case "yaml":
-		return cmd.formatYAML(prCtx, pullRequests)
+		return cmd.formatYAML(prCtx, pullRequests, details)
+	case "compact":
+		return cmd.formatCompact(prCtx, pullRequests)
 	default:
 		return fmt.Errorf("unsupported output format: %s", cmd.Output)
 	}
 }
 
-func (cmd *ListCmd) formatTable(prCtx *PRContext, pullRequests []*api.PullRequest) error {
+// formatTable renders the list; details, when fetched with --detailed,
+// replace the Approved column with approval counts, checks and size
+func (cmd *ListCmd) formatTable(prCtx *PRContext, pullRequests []*api.PullRequest, details []*PRListDetail) error {
 	if len(pullRequests) == 0 {
 		fmt.Println("No pull requests found")
 		return nil
 	}
 
-	headers := []string{"ID", "Title", "Branch", "Author", "State", "Approved", "Mergeable", "Updated"}
+	headers := []string{"ID", "Title", "Branch", "Author", "State", "Approved", "Mergeable", "Updated", "Age"}
+	if details != nil {
+		headers = []string{"ID", "Title", "Branch", "Author", "State", "Approvals", "Mergeable", "Checks", "Size", "Updated", "Age"}
+	}
+	showReason := strings.EqualFold(cmd.State, "declined")
+	if showReason {
+		headers = append(headers, "Reason")
+	}
 	rows := make([][]string, len(pullRequests))
 
-	mergeableResults := cmd.checkMergeableStatusConcurrently(prCtx, pullRequests)
+	var mergeableResults []bool
+	if details == nil {
+		mergeableResults = cmd.checkMergeableStatusConcurrently(prCtx, pullRequests)
+	}
 
 	for i, pr := range pullRequests {
-		title := shared.Truncate(pr.Title, 50)
+		title := pr.Title
 
 		sourceBranch := "-"
 		if pr.Source != nil && pr.Source.Branch != nil {
-			sourceBranch = shared.Truncate(pr.Source.Branch.Name, 20)
+			sourceBranch = pr.Source.Branch.Name
 		}
 
 		author := "-"
@@ -196,61 +258,126 @@ func (cmd *ListCmd) formatTable(prCtx *PRContext, pullRequests []*api.PullReques
 			} else if pr.Author.Username != "" {
 				author = pr.Author.Username
 			}
-			author = shared.Truncate(author, 15)
 		}
 
 		state := pr.State
 		if state == "" {
 			state = "UNKNOWN"
 		}
+		if pr.Draft {
+			state += " (draft)"
+		}
 
 		updatedTime := output.FormatRelativeTime(pr.UpdatedOn)
 
-		approved := cmd.isPRApproved(pr)
-		approvedStatus := "✗"
-		if approved {
-			approvedStatus = "✓"
-		}
+		row := []string{fmt.Sprintf("#%d", pr.ID), title, sourceBranch, author, state}
 
-		mergeable := mergeableResults[i]
-		mergeableStatus := "✓"
-		if !mergeable {
-			mergeableStatus = "✗"
+		if details != nil {
+			detail := details[i]
+			row = append(row, formatApprovals(detail), mergeableMark(detail.Mergeable), formatChecks(detail.Checks), formatSize(detail.Size))
+		} else {
+			approvedStatus := "✗"
+			if cmd.isPRApproved(pr) {
+				approvedStatus = "✓"
+			}
+			row = append(row, approvedStatus, mergeableMark(mergeableResults[i]))
 		}
 
-		rows[i] = []string{
-			fmt.Sprintf("#%d", pr.ID),
-			title,
-			sourceBranch,
-			author,
-			state,
-			approvedStatus,
-			mergeableStatus,
-			updatedTime,
+		row = append(row, updatedTime, output.FormatAge(pr.UpdatedOn))
+		if showReason {
+			reason := declineReason(pr)
+			if reason == "" {
+				reason = "-"
+			}
+			row = append(row, strings.ReplaceAll(reason, "\n", " "))
 		}
+		rows[i] = row
 	}
 
 	return output.RenderSimpleTable(headers, rows)
 }
 
-func (cmd *ListCmd) formatJSON(prCtx *PRContext, pullRequests []*api.PullRequest) error {
+// formatCompact prints one line per pull request:
+// #42 OPEN feature/x → main  "Title"
+func (cmd *ListCmd) formatCompact(prCtx *PRContext, pullRequests []*api.PullRequest) error {
+	lines := make([]string, len(pullRequests))
+	for i, pr := range pullRequests {
+		state := pr.State
+		if state == "" {
+			state = "UNKNOWN"
+		}
+		if pr.Draft {
+			state += " (draft)"
+		}
+
+		source, destination := "-", "-"
+		if pr.Source != nil && pr.Source.Branch != nil {
+			source = pr.Source.Branch.Name
+		}
+		if pr.Destination != nil && pr.Destination.Branch != nil {
+			destination = pr.Destination.Branch.Name
+		}
+
+		lines[i] = fmt.Sprintf("#%d %s %s → %s  %q", pr.ID, state, source, destination, pr.Title)
+	}
+	return prCtx.Formatter.Format(lines)
+}
+
+func (cmd *ListCmd) formatJSON(prCtx *PRContext, pullRequests []*api.PullRequest, details []*PRListDetail) error {
 	output := map[string]interface{}{
 		"total_count":   len(pullRequests),
 		"pull_requests": pullRequests,
+		"ages":          prAges(pullRequests, time.Now()),
+	}
+	if details != nil {
+		output["details"] = details
 	}
 
 	return prCtx.Formatter.Format(output)
 }
 
-func (cmd *ListCmd) formatYAML(prCtx *PRContext, pullRequests []*api.PullRequest) error {
+func (cmd *ListCmd) formatYAML(prCtx *PRContext, pullRequests []*api.PullRequest, details []*PRListDetail) error {
 	output := map[string]interface{}{
 		"total_count":   len(pullRequests),
 		"pull_requests": pullRequests,
+		"ages":          prAges(pullRequests, time.Now()),
+	}
+	if details != nil {
+		output["details"] = details
 	}
 
 	return prCtx.Formatter.Format(output)
 }
 
+// PRAge is how long a listed pull request has gone without an update
+type PRAge struct {
+	ID          int    `json:"id"`
+	Age         string `json:"age"`
+	IdleSeconds int64  `json:"idle_seconds"`
+}
+
+func prAges(pullRequests []*api.PullRequest, now time.Time) []PRAge {
+	ages := make([]PRAge, 0, len(pullRequests))
+	for _, pr := range pullRequests {
+		age := PRAge{ID: pr.ID, Age: output.FormatAge(pr.UpdatedOn)}
+		if pr.UpdatedOn != nil {
+			age.IdleSeconds = int64(now.Sub(*pr.UpdatedOn).Seconds())
+		}
+		ages = append(ages, age)
+	}
+	return ages
+}
+
+func filterDrafts(pullRequests []*api.PullRequest) []*api.PullRequest {
+	var drafts []*api.PullRequest
+	for _, pr := range pullRequests {
+		if pr.Draft {
+			drafts = append(drafts, pr)
+		}
+	}
+	return drafts
+}
+
 func parsePullRequestResults(result *api.PaginatedResponse) ([]*api.PullRequest, error) {
 	return shared.ParsePaginatedResults[api.PullRequest](result)
 }
@@ -296,6 +423,14 @@ func (cmd *ListCmd) checkMergeableStatusConcurrently(prCtx *PRContext, pullReque
 	return results
 }
 
+// mergeableMark is the Mergeable column's cell
+func mergeableMark(mergeable bool) string {
+	if mergeable {
+		return "✓"
+	}
+	return "✗"
+}
+
 func (cmd *ListCmd) isPRApproved(pr *api.PullRequest) bool {
 	if pr.Reviewers != nil {
 		for _, reviewer := range pr.Reviewers {
@@ -343,3 +478,27 @@ func (cmd *ListCmd) isPRMergeable(prCtx *PRContext, pr *api.PullRequest) bool {
 
 	return true
 }
+
+// defaultBaseSelector is the --base value that stands for the repository's
+// configured or default base branch.
+const defaultBaseSelector = "@default"
+
+// isMeSelector reports whether a user filter refers to the authenticated user.
+func isMeSelector(selector string) bool {
+	return selector == "@me"
+}
+
+// currentUsername resolves "@me" for the username-based pull request filters.
+func currentUsername(ctx context.Context, client *api.Client) (string, error) {
+	if client == nil {
+		return "", fmt.Errorf("could not resolve @me: not authenticated")
+	}
+	user, err := client.GetAuthManager().GetAuthenticatedUser(ctx)
+	if err != nil {
+		return "", fmt.Errorf("could not resolve @me to the authenticated user: %w", err)
+	}
+	if user.Username == "" {
+		return "", fmt.Errorf("could not resolve @me: the authenticated account has no Bitbucket username")
+	}
+	return user.Username, nil
+}
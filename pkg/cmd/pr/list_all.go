@@ -2,12 +2,13 @@ package pr
 
 import (
 	"context"
-	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 	"sort"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/carlosarraes/bt/pkg/api"
 	"github.com/carlosarraes/bt/pkg/auth"
@@ -23,6 +24,8 @@ type ListAllCmd struct {
 	URL       bool   `help:"Output URLs in format: <repo:source-branch> <target-branch> <url>"`
 	Approved  bool   `help:"Filter to show only approved PRs"`
 	Debug     bool   `help:"Show debug output"`
+	Refresh   bool   `help:"Ignore the cache and rescan every repository"`
+	NoCache   bool   `help:"Neither read nor write the per-repository cache"`
 	NoColor   bool
 	Workspace string `help:"Bitbucket workspace (defaults to git remote or config)"`
 }
@@ -42,10 +45,8 @@ func (cmd *ListAllCmd) Run(ctx context.Context) error {
 		}
 	}
 
-	workspace := cmd.Workspace
-	if workspace == "" {
-		workspace = prCtx.Workspace
-	}
+	prCtx.ApplyRepoFlags(cmd.Workspace, "")
+	workspace := prCtx.Workspace
 	if workspace == "" {
 		return fmt.Errorf("workspace is required. Provide it via --workspace flag or configure it")
 	}
@@ -82,6 +83,105 @@ func (cmd *ListAllCmd) Run(ctx context.Context) error {
 		}
 	}
 
+	author := ""
+	if currentUser != nil {
+		author = currentUser.Username
+	}
+
+	allPRs, err := cmd.collectPullRequests(ctx, prCtx, workspace, author)
+	if err != nil {
+		return err
+	}
+
+	sort.Slice(allPRs, func(i, j int) bool {
+		prI := allPRs[i].PullRequest
+		prJ := allPRs[j].PullRequest
+
+		targetI := ""
+		if prI.Destination != nil && prI.Destination.Branch != nil {
+			targetI = prI.Destination.Branch.Name
+		}
+		targetJ := ""
+		if prJ.Destination != nil && prJ.Destination.Branch != nil {
+			targetJ = prJ.Destination.Branch.Name
+		}
+
+		isHomologI := strings.Contains(strings.ToLower(targetI), "homolog")
+		isHomologJ := strings.Contains(strings.ToLower(targetJ), "homolog")
+
+		if isHomologI && !isHomologJ {
+			return true
+		}
+		if !isHomologI && isHomologJ {
+			return false
+		}
+
+		if prI.UpdatedOn == nil && prJ.UpdatedOn == nil {
+			return false
+		}
+		if prI.UpdatedOn == nil {
+			return false
+		}
+		if prJ.UpdatedOn == nil {
+			return true
+		}
+
+		return prI.UpdatedOn.After(*prJ.UpdatedOn)
+	})
+
+	if cmd.Debug {
+		fmt.Fprintf(os.Stderr, "DEBUG: Total PRs found across all repositories: %d\n", len(allPRs))
+	}
+
+	if cmd.Approved {
+		var approvedPRs []*PRWithRepo
+		for _, prWithRepo := range allPRs {
+			if cmd.isPRApproved(prWithRepo.PullRequest) {
+				approvedPRs = append(approvedPRs, prWithRepo)
+			}
+		}
+		allPRs = approvedPRs
+
+		if cmd.Debug {
+			fmt.Fprintf(os.Stderr, "DEBUG: Filtered to %d approved PRs\n", len(allPRs))
+		}
+	}
+
+	return cmd.formatOutput(prCtx, allPRs)
+}
+
+// collectPullRequests lists the author's pull requests in every repository
+// of the workspace. A scan younger than listAllCacheTTL is reused as it is;
+// an older one is updated by fetching only the repositories whose pull
+// requests changed since their high-water mark.
+func (cmd *ListAllCmd) collectPullRequests(ctx context.Context, prCtx *PRContext, workspace, author string) ([]*PRWithRepo, error) {
+	var cache *listAllCache
+	cachePath := ""
+	if !cmd.NoCache {
+		path, err := cmd.listAllCachePath(workspace, author)
+		if err != nil && cmd.Debug {
+			fmt.Fprintf(os.Stderr, "DEBUG: Cache disabled: %v\n", err)
+		}
+		cachePath = path
+	}
+	if cachePath != "" && !cmd.Refresh {
+		loaded, err := loadListAllCache(cachePath)
+		if err != nil && cmd.Debug {
+			fmt.Fprintf(os.Stderr, "DEBUG: Ignoring cache: %v\n", err)
+		}
+		if loaded != nil && loaded.matches(cmd, workspace, author) {
+			cache = loaded
+		}
+	}
+
+	scannedAt := time.Now()
+	if cache != nil && scannedAt.Sub(cache.ScannedAt) < listAllCacheTTL {
+		if cmd.Debug {
+			fmt.Fprintf(os.Stderr, "DEBUG: Using the scan cached at %s\n", cache.ScannedAt.Format(time.RFC3339))
+		}
+		return listAllPullRequests(workspace, cache.Repositories), nil
+	}
+
 	repoOptions := &api.RepositoryListOptions{
 		Role:    "member",
 		PageLen: 100,
@@ -92,33 +192,39 @@ func (cmd *ListAllCmd) Run(ctx context.Context) error {
 		fmt.Fprintf(os.Stderr, "DEBUG: Fetching repositories in workspace %s\n", workspace)
 	}
 
-	repoResult, err := prCtx.Client.Repositories.ListRepositories(ctx, workspace, repoOptions)
+	repositories, err := prCtx.Client.Repositories.ListAllRepositories(ctx, workspace, repoOptions)
 	if err != nil {
-		return fmt.Errorf("failed to fetch repositories: %w", err)
+		var pageErr *api.PageError
+		if !errors.As(err, &pageErr) || len(repositories) == 0 {
+			return nil, fmt.Errorf("failed to fetch repositories: %w", err)
+		}
+		fmt.Fprintf(os.Stderr, "⚠️  Repository listing stopped early (%v); showing results for the first %d repositories\n", err, len(repositories))
 	}
 
-	var repositories []*api.Repository
-	if repoResult.Values != nil {
-		var values []json.RawMessage
-		if err := json.Unmarshal(repoResult.Values, &values); err != nil {
-			return fmt.Errorf("failed to unmarshal repository values: %w", err)
-		}
+	if cmd.Debug {
+		fmt.Fprintf(os.Stderr, "DEBUG: Found %d repositories\n", len(repositories))
+	}
 
-		repositories = make([]*api.Repository, len(values))
-		for i, rawRepo := range values {
-			var repo api.Repository
-			if err := json.Unmarshal(rawRepo, &repo); err != nil {
-				return fmt.Errorf("failed to unmarshal repository %d: %w", i, err)
+	cached := make(map[string]*listAllCacheEntry)
+	if cache != nil {
+		for _, entry := range cache.Repositories {
+			if entry.Repository != nil {
+				cached[entry.Repository.FullName] = entry
 			}
-			repositories[i] = &repo
 		}
 	}
 
-	if cmd.Debug {
-		fmt.Fprintf(os.Stderr, "DEBUG: Found %d repositories\n", len(repositories))
+	// One workspace-wide query tells which cached repositories need fetching
+	// again; when it fails, they all are
+	var changed map[string]bool
+	if len(cached) > 0 {
+		changed, err = changedRepositories(ctx, prCtx, workspace, author, oldestFetch(cached))
+		if err != nil && cmd.Debug {
+			fmt.Fprintf(os.Stderr, "DEBUG: Could not check for pull request changes: %v\n", err)
+		}
 	}
 
-	var allPRs []*PRWithRepo
+	var entries []*listAllCacheEntry
 	var mu sync.Mutex
 	var wg sync.WaitGroup
 	errChan := make(chan error, len(repositories))
@@ -128,141 +234,142 @@ func (cmd *ListAllCmd) Run(ctx context.Context) error {
 		go func(repo *api.Repository) {
 			defer wg.Done()
 
-			options := &api.PullRequestListOptions{
-				PageLen: cmd.Limit,
-				Page:    1,
-				Sort:    "-updated_on",
-				Author:  currentUser.Username,
-			}
-
-			if cmd.State != "" && cmd.State != "all" {
-				options.State = strings.ToUpper(cmd.State)
-			}
-
-			if cmd.Sort != "" {
-				switch strings.ToLower(cmd.Sort) {
-				case "created":
-					options.Sort = "-created_on"
-				case "updated":
-					options.Sort = "-updated_on"
-				case "priority":
-					options.Sort = "-priority"
-				}
-			}
-
-			if cmd.Debug {
-				fmt.Fprintf(os.Stderr, "DEBUG: Fetching PRs from repository %s\n", repo.FullName)
-			}
-
-			repoSlug := repo.Name
-			if repo.FullName != "" {
-				parts := strings.Split(repo.FullName, "/")
-				if len(parts) == 2 {
-					repoSlug = parts[1]
-				}
-			}
-
-			result, err := prCtx.Client.PullRequests.ListPullRequests(ctx, workspace, repoSlug, options)
-			if err != nil {
-				if cmd.Debug {
-					fmt.Fprintf(os.Stderr, "DEBUG: Error fetching PRs from %s: %v\n", repo.FullName, err)
-				}
-				errChan <- fmt.Errorf("failed to fetch PRs from %s: %w", repo.FullName, err)
-				return
-			}
-
-			pullRequests, err := parsePullRequestResults(result)
+			entry, err := cmd.fetchRepositoryPullRequests(ctx, prCtx, workspace, author, repo, cached[repo.FullName], changed)
 			if err != nil {
-				errChan <- fmt.Errorf("failed to parse PR results from %s: %w", repo.FullName, err)
+				errChan <- err
 				return
 			}
 
 			mu.Lock()
-			for _, pr := range pullRequests {
-				allPRs = append(allPRs, &PRWithRepo{
-					PullRequest: pr,
-					Repository:  repo,
-					Workspace:   workspace,
-				})
-			}
+			entries = append(entries, entry)
 			mu.Unlock()
-
-			if cmd.Debug {
-				fmt.Fprintf(os.Stderr, "DEBUG: Found %d PRs in repository %s\n", len(pullRequests), repo.FullName)
-			}
 		}(repo)
 	}
 
 	wg.Wait()
 	close(errChan)
 
-	var errors []error
+	var repoErrors []error
 	for err := range errChan {
-		errors = append(errors, err)
+		repoErrors = append(repoErrors, err)
 	}
 
-	if len(errors) > 0 && cmd.Debug {
-		fmt.Fprintf(os.Stderr, "DEBUG: %d repositories had errors:\n", len(errors))
-		for _, err := range errors {
-			fmt.Fprintf(os.Stderr, "DEBUG: - %v\n", err)
+	if len(repoErrors) > 0 {
+		fmt.Fprintf(os.Stderr, "⚠️  Could not fetch pull requests from %d of %d repositories:\n", len(repoErrors), len(repositories))
+		for _, err := range repoErrors {
+			fmt.Fprintf(os.Stderr, "  - %v\n", err)
 		}
 	}
 
-	sort.Slice(allPRs, func(i, j int) bool {
-		prI := allPRs[i].PullRequest
-		prJ := allPRs[j].PullRequest
-
-		targetI := ""
-		if prI.Destination != nil && prI.Destination.Branch != nil {
-			targetI = prI.Destination.Branch.Name
+	if cachePath != "" {
+		sort.Slice(entries, func(i, j int) bool {
+			return entries[i].Repository.FullName < entries[j].Repository.FullName
+		})
+		scan := &listAllCache{
+			Workspace:    workspace,
+			Author:       author,
+			State:        cmd.State,
+			Sort:         cmd.Sort,
+			Limit:        cmd.Limit,
+			ScannedAt:    scannedAt,
+			Repositories: entries,
 		}
-		targetJ := ""
-		if prJ.Destination != nil && prJ.Destination.Branch != nil {
-			targetJ = prJ.Destination.Branch.Name
+		// Repositories that failed are missing from the scan, which mustn't
+		// be reused as it is then
+		if len(repoErrors) > 0 {
+			scan.ScannedAt = time.Time{}
+		}
+		if err := saveListAllCache(cachePath, scan); err != nil && cmd.Debug {
+			fmt.Fprintf(os.Stderr, "DEBUG: %v\n", err)
 		}
+	}
 
-		isHomologI := strings.Contains(strings.ToLower(targetI), "homolog")
-		isHomologJ := strings.Contains(strings.ToLower(targetJ), "homolog")
+	return listAllPullRequests(workspace, entries), nil
+}
 
-		if isHomologI && !isHomologJ {
-			return true
-		}
-		if !isHomologI && isHomologJ {
-			return false
-		}
+// fetchRepositoryPullRequests lists the author's pull requests in repo. A
+// cached entry is reused when changed, the repositories with updated pull
+// requests, is known and doesn't include repo.
+func (cmd *ListAllCmd) fetchRepositoryPullRequests(ctx context.Context, prCtx *PRContext, workspace, author string, repo *api.Repository, cached *listAllCacheEntry, changed map[string]bool) (*listAllCacheEntry, error) {
+	fetchedAt := time.Now()
+	repoSlug := listAllRepoSlug(repo)
 
-		if prI.UpdatedOn == nil && prJ.UpdatedOn == nil {
-			return false
+	if cached != nil && changed != nil && !changed[repo.FullName] {
+		if cmd.Debug {
+			fmt.Fprintf(os.Stderr, "DEBUG: No pull request changes in repository %s\n", repo.FullName)
 		}
-		if prI.UpdatedOn == nil {
-			return false
+		return &listAllCacheEntry{Repository: repo, FetchedAt: fetchedAt, PullRequests: cached.PullRequests}, nil
+	}
+
+	options := &api.PullRequestListOptions{
+		PageLen: cmd.Limit,
+		Page:    1,
+		Sort:    "-updated_on",
+		Author:  author,
+	}
+
+	if cmd.State != "" && cmd.State != "all" {
+		options.State = strings.ToUpper(cmd.State)
+	}
+
+	if cmd.Sort != "" {
+		switch strings.ToLower(cmd.Sort) {
+		case "created":
+			options.Sort = "-created_on"
+		case "updated":
+			options.Sort = "-updated_on"
+		case "priority":
+			options.Sort = "-priority"
 		}
-		if prJ.UpdatedOn == nil {
-			return true
+	}
+
+	if cmd.Debug {
+		fmt.Fprintf(os.Stderr, "DEBUG: Fetching PRs from repository %s\n", repo.FullName)
+	}
+
+	result, err := prCtx.Client.PullRequests.ListPullRequests(ctx, workspace, repoSlug, options)
+	if err != nil {
+		if cmd.Debug {
+			fmt.Fprintf(os.Stderr, "DEBUG: Error fetching PRs from %s: %v\n", repo.FullName, err)
 		}
+		return nil, fmt.Errorf("failed to fetch PRs from %s: %w", repo.FullName, err)
+	}
 
-		return prI.UpdatedOn.After(*prJ.UpdatedOn)
-	})
+	pullRequests, err := parsePullRequestResults(result)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse PR results from %s: %w", repo.FullName, err)
+	}
 
 	if cmd.Debug {
-		fmt.Fprintf(os.Stderr, "DEBUG: Total PRs found across all repositories: %d\n", len(allPRs))
+		fmt.Fprintf(os.Stderr, "DEBUG: Found %d PRs in repository %s\n", len(pullRequests), repo.FullName)
 	}
 
-	if cmd.Approved {
-		var approvedPRs []*PRWithRepo
-		for _, prWithRepo := range allPRs {
-			if cmd.isPRApproved(prWithRepo.PullRequest) {
-				approvedPRs = append(approvedPRs, prWithRepo)
-			}
-		}
-		allPRs = approvedPRs
+	return &listAllCacheEntry{Repository: repo, FetchedAt: fetchedAt, PullRequests: pullRequests}, nil
+}
 
-		if cmd.Debug {
-			fmt.Fprintf(os.Stderr, "DEBUG: Filtered to %d approved PRs\n", len(allPRs))
+func listAllPullRequests(workspace string, entries []*listAllCacheEntry) []*PRWithRepo {
+	var prs []*PRWithRepo
+	for _, entry := range entries {
+		for _, pr := range entry.PullRequests {
+			prs = append(prs, &PRWithRepo{
+				PullRequest: pr,
+				Repository:  entry.Repository,
+				Workspace:   workspace,
+			})
 		}
 	}
+	return prs
+}
 
-	return cmd.formatOutput(prCtx, allPRs)
+// listAllRepoSlug is the slug of repo, taken from its full name
+func listAllRepoSlug(repo *api.Repository) string {
+	if repo.FullName != "" {
+		parts := strings.Split(repo.FullName, "/")
+		if len(parts) == 2 {
+			return parts[1]
+		}
+	}
+	return repo.Name
 }
 
 func (cmd *ListAllCmd) formatOutput(prCtx *PRContext, prs []*PRWithRepo) error {
@@ -297,18 +404,18 @@ func (cmd *ListAllCmd) formatTable(prCtx *PRContext, prs []*PRWithRepo) error {
 		pr := prWithRepo.PullRequest
 		repo := prWithRepo.Repository
 
-		repoName := shared.Truncate(repo.Name, 15)
+		repoName := repo.Name
 
-		title := shared.Truncate(pr.Title, 40)
+		title := pr.Title
 
 		sourceBranch := "-"
 		if pr.Source != nil && pr.Source.Branch != nil {
-			sourceBranch = shared.Truncate(pr.Source.Branch.Name, 15)
+			sourceBranch = pr.Source.Branch.Name
 		}
 
 		targetBranch := "-"
 		if pr.Destination != nil && pr.Destination.Branch != nil {
-			targetBranch = shared.Truncate(pr.Destination.Branch.Name, 15)
+			targetBranch = pr.Destination.Branch.Name
 		}
 
 		state := pr.State
@@ -0,0 +1,143 @@
+package pr
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/carlosarraes/bt/pkg/api"
+)
+
+// listAllCacheTTL is how long a scan is reused as it is; past it, only the
+// repositories with updated pull requests are fetched again
+const listAllCacheTTL = 2 * time.Minute
+
+// listAllClockSkew is taken off the high-water marks, which are local
+// times compared against Bitbucket's updated_on
+const listAllClockSkew = time.Minute
+
+// listAllStates are all the states a pull request can be in. Changes are
+// looked for in every state, so a pull request leaving the listed one is
+// noticed too.
+var listAllStates = []string{"OPEN", "MERGED", "DECLINED", "SUPERSEDED"}
+
+// listAllCache is the last pr list-all scan of a workspace, for one set of
+// filters
+type listAllCache struct {
+	Workspace    string               `json:"workspace"`
+	Author       string               `json:"author"`
+	State        string               `json:"state"`
+	Sort         string               `json:"sort"`
+	Limit        int                  `json:"limit"`
+	ScannedAt    time.Time            `json:"scanned_at"`
+	Repositories []*listAllCacheEntry `json:"repositories"`
+}
+
+// listAllCacheEntry holds the pull requests listed for a repository.
+// FetchedAt is its high-water mark: pull requests updated after it make the
+// repository be fetched again.
+type listAllCacheEntry struct {
+	Repository   *api.Repository    `json:"repository"`
+	FetchedAt    time.Time          `json:"fetched_at"`
+	PullRequests []*api.PullRequest `json:"pull_requests"`
+}
+
+func listAllCacheDir() (string, error) {
+	configDir := os.Getenv("XDG_CONFIG_HOME")
+	if configDir == "" {
+		homeDir, err := os.UserHomeDir()
+		if err != nil {
+			return "", fmt.Errorf("failed to get home directory: %w", err)
+		}
+		configDir = filepath.Join(homeDir, ".config")
+	}
+	return filepath.Join(configDir, "bt", "cache", "pr-list-all"), nil
+}
+
+// listAllCachePath returns the cache file of a scan. Each set of filters
+// gets its own file, so switching between them doesn't throw scans away.
+func (cmd *ListAllCmd) listAllCachePath(workspace, author string) (string, error) {
+	dir, err := listAllCacheDir()
+	if err != nil {
+		return "", err
+	}
+	name := workspace + "_" + author + "_" + cmd.State + "_" + cmd.Sort + "_" + strconv.Itoa(cmd.Limit)
+	return filepath.Join(dir, draftUnsafeChars.ReplaceAllString(name, "-")+".json"), nil
+}
+
+// loadListAllCache reads a scan; it returns nil without an error when there
+// is none
+func loadListAllCache(path string) (*listAllCache, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read cache: %w", err)
+	}
+
+	var cache listAllCache
+	if err := json.Unmarshal(data, &cache); err != nil {
+		return nil, fmt.Errorf("failed to parse cache %s: %w", path, err)
+	}
+	return &cache, nil
+}
+
+func saveListAllCache(path string, cache *listAllCache) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return fmt.Errorf("failed to create cache directory: %w", err)
+	}
+
+	data, err := json.Marshal(cache)
+	if err != nil {
+		return fmt.Errorf("failed to encode cache: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0600); err != nil {
+		return fmt.Errorf("failed to save cache: %w", err)
+	}
+	return nil
+}
+
+// matches reports whether the cache was written for the same filters
+func (cache *listAllCache) matches(cmd *ListAllCmd, workspace, author string) bool {
+	return cache.Workspace == workspace && cache.Author == author &&
+		cache.State == cmd.State && cache.Sort == cmd.Sort && cache.Limit == cmd.Limit
+}
+
+// changedRepositories returns the full names of the repositories where a
+// pull request of the author was updated after since, whatever its state.
+// It is one workspace-wide query however many repositories there are.
+func changedRepositories(ctx context.Context, prCtx *PRContext, workspace, author string, since time.Time) (map[string]bool, error) {
+	since = since.Add(-listAllClockSkew)
+	pullRequests, err := prCtx.Client.PullRequests.ListWorkspacePullRequests(ctx, workspace, author, &api.PullRequestListOptions{
+		States:       listAllStates,
+		UpdatedAfter: &since,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	changed := make(map[string]bool)
+	for _, pr := range pullRequests {
+		if pr.Destination != nil && pr.Destination.Repository != nil {
+			changed[pr.Destination.Repository.FullName] = true
+		}
+	}
+	return changed, nil
+}
+
+// oldestFetch returns the earliest high-water mark of the cached entries
+func oldestFetch(entries map[string]*listAllCacheEntry) time.Time {
+	var oldest time.Time
+	for _, entry := range entries {
+		if oldest.IsZero() || entry.FetchedAt.Before(oldest) {
+			oldest = entry.FetchedAt
+		}
+	}
+	return oldest
+}
@@ -0,0 +1,198 @@
+package pr
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/carlosarraes/bt/pkg/api"
+	"github.com/carlosarraes/bt/pkg/api/apitest"
+	"github.com/carlosarraes/bt/pkg/cmd/shared"
+)
+
+const listAllChangesQuery = "state=OPEN&state=MERGED&state=DECLINED&state=SUPERSEDED"
+
+func listAllFixtures(fixtures ...apitest.Fixture) []apitest.Fixture {
+	return append([]apitest.Fixture{{
+		Method: "GET",
+		Path:   "/repositories/ws",
+		Body:   json.RawMessage(`{"values": [{"name": "api", "full_name": "ws/api"}, {"name": "web", "full_name": "ws/web"}]}`),
+	}}, fixtures...)
+}
+
+func listAllPRs(repo string, titles ...string) apitest.Fixture {
+	var values []map[string]interface{}
+	for i, title := range titles {
+		values = append(values, map[string]interface{}{"id": i + 1, "title": title, "state": "OPEN"})
+	}
+	body, _ := json.Marshal(map[string]interface{}{"values": values})
+	return apitest.Fixture{Method: "GET", Path: "/repositories/ws/" + repo + "/pullrequests", Query: "pagelen=10", Body: body}
+}
+
+// listAllChanges answers the workspace-wide query for updated pull
+// requests with one updated pull request in each of repos
+func listAllChanges(repos ...string) apitest.Fixture {
+	values := []map[string]interface{}{}
+	for i, repo := range repos {
+		values = append(values, map[string]interface{}{
+			"id": i + 1, "title": "Updated", "state": "OPEN",
+			"destination": map[string]interface{}{"repository": map[string]interface{}{"full_name": "ws/" + repo}},
+		})
+	}
+	body, _ := json.Marshal(map[string]interface{}{"values": values})
+	return apitest.Fixture{Method: "GET", Path: "/workspaces/ws/pullrequests/alice", Query: listAllChangesQuery, Body: body}
+}
+
+func collectListAll(t *testing.T, cmd *ListAllCmd, fixtures ...apitest.Fixture) ([]string, []apitest.Request) {
+	t.Helper()
+
+	transport := apitest.NewReplayTransport(fixtures...)
+	restore := shared.SetClientTransport(transport)
+	defer restore()
+
+	prCtx, err := shared.NewCommandContext(context.Background(), "table", true)
+	require.NoError(t, err)
+
+	prs, err := cmd.collectPullRequests(context.Background(), prCtx, "ws", "alice")
+	require.NoError(t, err)
+
+	var titles []string
+	for _, pr := range prs {
+		titles = append(titles, pr.Repository.Name+": "+pr.Title)
+	}
+	sort.Strings(titles)
+	return titles, transport.Requests()
+}
+
+func writeListAllCache(t *testing.T, cmd *ListAllCmd, scannedAt time.Time, entries ...*listAllCacheEntry) {
+	t.Helper()
+
+	path, err := cmd.listAllCachePath("ws", "alice")
+	require.NoError(t, err)
+	require.NoError(t, saveListAllCache(path, &listAllCache{
+		Workspace: "ws", Author: "alice", State: cmd.State, Sort: cmd.Sort, Limit: cmd.Limit,
+		ScannedAt: scannedAt, Repositories: entries,
+	}))
+}
+
+func cachedRepository(name string, fetchedAt time.Time, titles ...string) *listAllCacheEntry {
+	entry := &listAllCacheEntry{Repository: &api.Repository{Name: name, FullName: "ws/" + name}, FetchedAt: fetchedAt}
+	for i, title := range titles {
+		entry.PullRequests = append(entry.PullRequests, &api.PullRequest{ID: i + 1, Title: title, State: "OPEN"})
+	}
+	return entry
+}
+
+func newListAllCmd(t *testing.T) *ListAllCmd {
+	apitest.CommandEnv(t, "ws", "repo")
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	return &ListAllCmd{State: "open", Sort: "updated", Limit: 10}
+}
+
+func TestListAllCmd_collectPullRequests_ReusesFreshScan(t *testing.T) {
+	cmd := newListAllCmd(t)
+
+	titles, requests := collectListAll(t, cmd, listAllFixtures(listAllPRs("api", "Add endpoint"), listAllPRs("web", "Fix layout", "Dark mode"))...)
+	assert.Equal(t, []string{"api: Add endpoint", "web: Dark mode", "web: Fix layout"}, titles)
+	assert.Len(t, requests, 3)
+
+	titles, requests = collectListAll(t, cmd)
+	assert.Equal(t, []string{"api: Add endpoint", "web: Dark mode", "web: Fix layout"}, titles)
+	assert.Empty(t, requests)
+}
+
+func TestListAllCmd_collectPullRequests_FetchesChangedRepositories(t *testing.T) {
+	cmd := newListAllCmd(t)
+	old := time.Now().Add(-time.Hour)
+	writeListAllCache(t, cmd, old, cachedRepository("api", old, "Add endpoint"), cachedRepository("web", old, "Fix layout"))
+
+	titles, requests := collectListAll(t, cmd, listAllFixtures(
+		listAllChanges("web"),
+		listAllPRs("web", "Fix layout", "Dark mode"),
+	)...)
+	assert.Equal(t, []string{"api: Add endpoint", "web: Dark mode", "web: Fix layout"}, titles)
+
+	var paths []string
+	for _, req := range requests {
+		paths = append(paths, req.Path)
+	}
+	sort.Strings(paths)
+	assert.Equal(t, []string{
+		"/repositories/ws",
+		"/repositories/ws/web/pullrequests",
+		"/workspaces/ws/pullrequests/alice",
+	}, paths)
+
+	// The rescan is cached in turn, with fresh high-water marks
+	titles, requests = collectListAll(t, cmd)
+	assert.Equal(t, []string{"api: Add endpoint", "web: Dark mode", "web: Fix layout"}, titles)
+	assert.Empty(t, requests)
+}
+
+func TestListAllCmd_collectPullRequests_NewRepositoryIsFetched(t *testing.T) {
+	cmd := newListAllCmd(t)
+	old := time.Now().Add(-time.Hour)
+	writeListAllCache(t, cmd, old, cachedRepository("api", old, "Add endpoint"), cachedRepository("legacy", old, "Old change"))
+
+	titles, _ := collectListAll(t, cmd, listAllFixtures(
+		listAllChanges(),
+		listAllPRs("web", "Fix layout"),
+	)...)
+	assert.Equal(t, []string{"api: Add endpoint", "web: Fix layout"}, titles)
+}
+
+func TestListAllCmd_collectPullRequests_ChangeCheckFails(t *testing.T) {
+	cmd := newListAllCmd(t)
+	old := time.Now().Add(-time.Hour)
+	writeListAllCache(t, cmd, old, cachedRepository("api", old, "Stale title"))
+
+	titles, _ := collectListAll(t, cmd, listAllFixtures(
+		apitest.Fixture{Method: "GET", Path: "/workspaces/ws/pullrequests/alice", Status: 403, Body: json.RawMessage(`{"error": {"message": "Forbidden"}}`)},
+		listAllPRs("api", "Add endpoint"),
+		listAllPRs("web"),
+	)...)
+	assert.Equal(t, []string{"api: Add endpoint"}, titles, "without the change check every repository is fetched")
+}
+
+func TestListAllCmd_collectPullRequests_Refresh(t *testing.T) {
+	cmd := newListAllCmd(t)
+	writeListAllCache(t, cmd, time.Now(), cachedRepository("api", time.Now(), "Stale title"))
+	cmd.Refresh = true
+
+	titles, requests := collectListAll(t, cmd, listAllFixtures(listAllPRs("api", "Add endpoint"), listAllPRs("web"))...)
+	assert.Equal(t, []string{"api: Add endpoint"}, titles)
+	for _, req := range requests {
+		assert.NotContains(t, req.Query, "state=MERGED")
+	}
+}
+
+func TestListAllCmd_collectPullRequests_FiltersHaveTheirOwnCache(t *testing.T) {
+	cmd := newListAllCmd(t)
+	writeListAllCache(t, cmd, time.Now(), cachedRepository("api", time.Now(), "Open change"))
+	cmd.State = "merged"
+
+	titles, requests := collectListAll(t, cmd, listAllFixtures(listAllPRs("api", "Merged change"), listAllPRs("web"))...)
+	assert.Equal(t, []string{"api: Merged change"}, titles)
+	assert.Len(t, requests, 3)
+}
+
+func TestListAllCmd_collectPullRequests_NoCache(t *testing.T) {
+	cmd := newListAllCmd(t)
+	cmd.NoCache = true
+
+	fixtures := listAllFixtures(listAllPRs("api", "Add endpoint"), listAllPRs("web"))
+	collectListAll(t, cmd, fixtures...)
+	_, requests := collectListAll(t, cmd, fixtures...)
+	assert.Len(t, requests, 3)
+
+	path, err := cmd.listAllCachePath("ws", "alice")
+	require.NoError(t, err)
+	cache, err := loadListAllCache(path)
+	require.NoError(t, err)
+	assert.Nil(t, cache)
+}
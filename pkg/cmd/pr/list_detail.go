@@ -0,0 +1,177 @@
+package pr
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sync"
+
+	"github.com/carlosarraes/bt/pkg/api"
+)
+
+// maxDetailFetches bounds the concurrent per-PR requests made by --detailed
+const maxDetailFetches = 10
+
+// Check states reported by pr list --detailed
+const (
+	checksPassing = "passing"
+	checksFailing = "failing"
+	checksRunning = "running"
+	checksNone    = "none"
+	checksUnknown = "unknown"
+)
+
+// PRListDetail is the merge readiness and size of a pull request, fetched
+// per PR by pr list --detailed
+type PRListDetail struct {
+	ID           int     `json:"id" yaml:"id"`
+	Approvals    int     `json:"approvals" yaml:"approvals"`
+	Reviewers    int     `json:"reviewers" yaml:"reviewers"`
+	Mergeable    bool    `json:"mergeable" yaml:"mergeable"`
+	Checks       string  `json:"checks" yaml:"checks"`
+	ReadyToMerge bool    `json:"ready_to_merge" yaml:"ready_to_merge"`
+	Size         *prSize `json:"size,omitempty" yaml:"size,omitempty"`
+}
+
+// fetchDetails enriches every pull request concurrently, at most
+// maxDetailFetches at a time. Results keep the order of pullRequests.
+func (cmd *ListCmd) fetchDetails(ctx context.Context, prCtx *PRContext, pullRequests []*api.PullRequest) []*PRListDetail {
+	details := make([]*PRListDetail, len(pullRequests))
+	var wg sync.WaitGroup
+
+	semaphore := make(chan struct{}, maxDetailFetches)
+
+	for i, pr := range pullRequests {
+		wg.Add(1)
+		go func(index int, pullRequest *api.PullRequest) {
+			defer wg.Done()
+
+			semaphore <- struct{}{}
+			defer func() { <-semaphore }()
+
+			details[index] = cmd.fetchDetail(ctx, prCtx, pullRequest)
+		}(i, pr)
+	}
+
+	wg.Wait()
+	return details
+}
+
+func (cmd *ListCmd) fetchDetail(ctx context.Context, prCtx *PRContext, pr *api.PullRequest) *PRListDetail {
+	// The list endpoint omits participants, so approvals need the full PR
+	full, err := prCtx.Client.PullRequests.GetPullRequest(ctx, prCtx.Workspace, prCtx.Repository, pr.ID)
+	if err != nil {
+		cmd.debugf("Failed to get pull request #%d: %v", pr.ID, err)
+		full = pr
+	}
+
+	detail := &PRListDetail{ID: pr.ID, Checks: checksUnknown}
+	detail.Approvals, detail.Reviewers = approvalCounts(full)
+	detail.Mergeable = cmd.isPRMergeable(prCtx, full)
+
+	if full.Source != nil && full.Source.Commit != nil && full.Source.Commit.Hash != "" {
+		pipelines, err := prCtx.Client.Pipelines.GetPipelinesByCommit(ctx, prCtx.Workspace, prCtx.Repository, full.Source.Commit.Hash)
+		if err != nil {
+			cmd.debugf("Failed to get pipelines for pull request #%d: %v", pr.ID, err)
+		} else {
+			detail.Checks = checksState(pipelines)
+		}
+	}
+
+	files, err := prCtx.Client.PullRequests.GetPullRequestFiles(ctx, prCtx.Workspace, prCtx.Repository, pr.ID)
+	if err != nil {
+		cmd.debugf("Failed to get the diffstat of pull request #%d: %v", pr.ID, err)
+	} else {
+		detail.Size = sizeOf(files, sizeThresholds(prCtx))
+	}
+
+	detail.ReadyToMerge = full.State == "OPEN" && detail.Approvals > 0 && detail.Mergeable &&
+		(detail.Checks == checksPassing || detail.Checks == checksNone)
+	return detail
+}
+
+func (cmd *ListCmd) debugf(format string, args ...interface{}) {
+	if cmd.Debug {
+		fmt.Fprintf(os.Stderr, "DEBUG: "+format+"\n", args...)
+	}
+}
+
+// approvalCounts returns how many participants approved and how many
+// reviewers were requested. Approvals from non-reviewers still count, so the
+// total never drops below the approvals.
+func approvalCounts(pr *api.PullRequest) (approvals, reviewers int) {
+	for _, participant := range pr.Participants {
+		if participant.Approved {
+			approvals++
+		}
+	}
+	reviewers = len(pr.Reviewers)
+	if reviewers < approvals {
+		reviewers = approvals
+	}
+	return approvals, reviewers
+}
+
+// checksState summarizes the newest pipeline run for the PR's head commit
+func checksState(pipelines []*api.Pipeline) string {
+	var latest *api.Pipeline
+	for _, p := range pipelines {
+		if latest == nil || p.BuildNumber > latest.BuildNumber {
+			latest = p
+		}
+	}
+	if latest == nil {
+		return checksNone
+	}
+	if latest.State == nil {
+		return checksUnknown
+	}
+
+	switch latest.State.Name {
+	case "PENDING", "IN_PROGRESS":
+		return checksRunning
+	case "SUCCESSFUL":
+		return checksPassing
+	case "FAILED", "ERROR", "STOPPED":
+		return checksFailing
+	}
+
+	if latest.State.Result != nil {
+		switch latest.State.Result.Name {
+		case "SUCCESSFUL":
+			return checksPassing
+		case "FAILED", "ERROR", "STOPPED", "EXPIRED":
+			return checksFailing
+		}
+	}
+	return checksUnknown
+}
+
+func formatApprovals(detail *PRListDetail) string {
+	if detail.Reviewers == 0 {
+		return "-"
+	}
+	return fmt.Sprintf("%d/%d", detail.Approvals, detail.Reviewers)
+}
+
+func formatSize(size *prSize) string {
+	if size == nil {
+		return "?"
+	}
+	return size.Label
+}
+
+func formatChecks(checks string) string {
+	switch checks {
+	case checksPassing:
+		return "✓ passing"
+	case checksFailing:
+		return "✗ failing"
+	case checksRunning:
+		return "● running"
+	case checksNone:
+		return "-"
+	default:
+		return "?"
+	}
+}
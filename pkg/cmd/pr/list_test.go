@@ -1,13 +1,18 @@
 package pr
 
 import (
+	"context"
+	"encoding/json"
 	"strings"
 	"testing"
 	"time"
 
 	"github.com/carlosarraes/bt/pkg/api"
+	"github.com/carlosarraes/bt/pkg/api/apitest"
+	"github.com/carlosarraes/bt/pkg/cmd/shared"
 	"github.com/carlosarraes/bt/pkg/output"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestValidateState(t *testing.T) {
@@ -510,7 +515,7 @@ func TestFormatTable(t *testing.T) {
 
 			// This test just ensures the function doesn't panic
 			// In a real environment, we'd capture stdout for verification
-			err := cmd.formatTable(&PRContext{}, tt.prs)
+			err := cmd.formatTable(&PRContext{}, tt.prs, nil)
 			assert.NoError(t, err)
 		})
 	}
@@ -545,3 +550,153 @@ func TestSortFieldValidation(t *testing.T) {
 		})
 	}
 }
+
+func TestIsMeSelector(t *testing.T) {
+	assert.True(t, isMeSelector("@me"))
+	assert.False(t, isMeSelector("me"), "a user called me is matched by name")
+	assert.False(t, isMeSelector(""))
+	assert.False(t, isMeSelector("@meh"))
+	assert.False(t, isMeSelector("alice"))
+}
+
+func TestCurrentUsername_NoClient(t *testing.T) {
+	_, err := currentUsername(context.Background(), nil)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "@me")
+}
+
+func TestFormatTable_Detailed(t *testing.T) {
+	prs := []*api.PullRequest{{ID: 7, Title: "Ready", State: "OPEN"}}
+	details := []*PRListDetail{{ID: 7, Approvals: 2, Reviewers: 2, Mergeable: true, Checks: checksPassing}}
+
+	cmd := &ListCmd{Output: "table"}
+	assert.NoError(t, cmd.formatTable(&PRContext{}, prs, details))
+}
+
+func TestApprovalCounts(t *testing.T) {
+	reviewer := func(name string) *api.PullRequestParticipant {
+		return &api.PullRequestParticipant{User: &api.User{Username: name}, Role: "REVIEWER"}
+	}
+	approval := func(name string) *api.PullRequestParticipant {
+		return &api.PullRequestParticipant{User: &api.User{Username: name}, Approved: true}
+	}
+
+	tests := []struct {
+		name          string
+		pr            *api.PullRequest
+		wantApprovals int
+		wantReviewers int
+	}{
+		{name: "no reviewers", pr: &api.PullRequest{}},
+		{
+			name:          "partially approved",
+			pr:            &api.PullRequest{Reviewers: []*api.PullRequestParticipant{reviewer("a"), reviewer("b")}, Participants: []*api.PullRequestParticipant{approval("a")}},
+			wantApprovals: 1,
+			wantReviewers: 2,
+		},
+		{
+			name:          "approval from a non-reviewer",
+			pr:            &api.PullRequest{Participants: []*api.PullRequestParticipant{approval("c")}},
+			wantApprovals: 1,
+			wantReviewers: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			approvals, reviewers := approvalCounts(tt.pr)
+			assert.Equal(t, tt.wantApprovals, approvals)
+			assert.Equal(t, tt.wantReviewers, reviewers)
+		})
+	}
+}
+
+func TestChecksState(t *testing.T) {
+	pipeline := func(build int, state, result string) *api.Pipeline {
+		p := &api.Pipeline{BuildNumber: build, State: &api.PipelineState{Name: state}}
+		if result != "" {
+			p.State.Result = &api.PipelineResult{Name: result}
+		}
+		return p
+	}
+
+	assert.Equal(t, checksNone, checksState(nil))
+	assert.Equal(t, checksPassing, checksState([]*api.Pipeline{pipeline(1, "COMPLETED", "SUCCESSFUL")}))
+	assert.Equal(t, checksFailing, checksState([]*api.Pipeline{pipeline(1, "COMPLETED", "FAILED")}))
+	assert.Equal(t, checksRunning, checksState([]*api.Pipeline{pipeline(1, "IN_PROGRESS", "")}))
+	// The newest run wins over an older failure
+	assert.Equal(t, checksPassing, checksState([]*api.Pipeline{
+		pipeline(3, "COMPLETED", "SUCCESSFUL"),
+		pipeline(2, "COMPLETED", "FAILED"),
+	}))
+}
+
+func TestFormatApprovals(t *testing.T) {
+	assert.Equal(t, "-", formatApprovals(&PRListDetail{}))
+	assert.Equal(t, "1/2", formatApprovals(&PRListDetail{Approvals: 1, Reviewers: 2}))
+}
+
+func TestPRAges(t *testing.T) {
+	now := time.Now()
+	updated := now.Add(-15 * 24 * time.Hour)
+
+	ages := prAges([]*api.PullRequest{
+		{ID: 1, UpdatedOn: &updated},
+		{ID: 2},
+	}, now)
+
+	assert.Equal(t, []PRAge{
+		{ID: 1, Age: "15d", IdleSeconds: int64(15 * 24 * 60 * 60)},
+		{ID: 2, Age: "-"},
+	}, ages)
+}
+
+func TestFilterDrafts(t *testing.T) {
+	prs := []*api.PullRequest{{ID: 1, Draft: true}, {ID: 2}, {ID: 3, Draft: true}}
+
+	drafts := filterDrafts(prs)
+	assert.Len(t, drafts, 2)
+	assert.Equal(t, 1, drafts[0].ID)
+	assert.Equal(t, 3, drafts[1].ID)
+	assert.Empty(t, filterDrafts([]*api.PullRequest{{ID: 2}}))
+}
+
+func TestFormatCompact(t *testing.T) {
+	var buf strings.Builder
+	formatter, err := output.NewFormatter(output.FormatCompact, &output.FormatterOptions{Writer: &buf})
+	assert.NoError(t, err)
+
+	prs := []*api.PullRequest{
+		{
+			ID:          42,
+			Title:       "Add login",
+			State:       "OPEN",
+			Source:      &api.PullRequestBranch{Branch: &api.Branch{Name: "feature/x"}},
+			Destination: &api.PullRequestBranch{Branch: &api.Branch{Name: "main"}},
+		},
+		{ID: 43, Title: "WIP", State: "OPEN", Draft: true},
+	}
+
+	cmd := &ListCmd{Output: "compact"}
+	assert.NoError(t, cmd.formatOutput(&PRContext{Formatter: formatter}, prs, nil))
+	assert.Equal(t, "#42 OPEN feature/x → main  \"Add login\"\n#43 OPEN (draft) - → -  \"WIP\"\n", buf.String())
+}
+
+func TestListCmd_DefaultBase(t *testing.T) {
+	apitest.CommandEnv(t, "ws", "repo")
+	transport := apitest.NewReplayTransport(apitest.Fixture{
+		Method: "GET",
+		Path:   "/repositories/ws/repo",
+		Body:   json.RawMessage(`{"slug": "repo", "mainbranch": {"name": "develop"}}`),
+	}, apitest.Fixture{
+		Method: "GET",
+		Path:   "/repositories/ws/repo/pullrequests",
+		Query:  `q=destination.branch.name="develop"`,
+		Body:   json.RawMessage(`{"values": []}`),
+	})
+	t.Cleanup(shared.SetClientTransport(transport))
+
+	cmd := &ListCmd{All: true, Base: "@default", Limit: 30, Output: "json", NoColor: true}
+	require.NoError(t, cmd.Run(context.Background()))
+	assert.Len(t, transport.Requests(), 2)
+}
@@ -3,16 +3,19 @@ package pr
 import (
 	"context"
 	"fmt"
+	"io"
+	"os"
 	"strings"
 
 	"github.com/carlosarraes/bt/pkg/api"
 	"github.com/carlosarraes/bt/pkg/cmd/shared"
+	"github.com/carlosarraes/bt/pkg/git"
 )
 
 type MergeCmd struct {
-	PRID         string `arg:"" help:"Pull request ID (number)"`
+	PRID         string `arg:"" optional:"" help:"Pull request ID (number); omit to pick from open pull requests"`
 	Squash       bool   `help:"Squash commits when merging"`
-	DeleteBranch bool   `help:"Delete source branch after merge"`
+	DeleteBranch bool   `help:"Delete the source branch after merge, on Bitbucket and locally (unless it has unmerged commits)"`
 	Auto         bool   `help:"Automatically merge when checks pass"`
 	Force        bool   `short:"f" help:"Skip confirmation prompt"`
 	Message      string `short:"m" help:"Custom merge commit message"`
@@ -28,19 +31,17 @@ func (cmd *MergeCmd) Run(ctx context.Context) error {
 		return err
 	}
 
-	if cmd.Workspace != "" {
-		prCtx.Workspace = cmd.Workspace
-	}
-	if cmd.Repository != "" {
-		prCtx.Repository = cmd.Repository
-	}
-
-	if err := prCtx.ValidateWorkspaceAndRepo(); err != nil {
+	if err := prCtx.ResolveRepository(cmd.Workspace, cmd.Repository); err != nil {
 		return err
 	}
 
-	prID, err := cmd.ParsePRID()
-	if err != nil {
+	var prID int
+	if cmd.PRID == "" {
+		prID, err = selectPullRequest(ctx, prCtx, cmd.Output)
+		if err != nil {
+			return err
+		}
+	} else if prID, err = cmd.ParsePRID(); err != nil {
 		return fmt.Errorf("invalid pull request ID '%s': %w", cmd.PRID, err)
 	}
 
@@ -53,12 +54,40 @@ func (cmd *MergeCmd) Run(ctx context.Context) error {
 		return err
 	}
 
+	mergeRequest := cmd.mergeRequest()
+
+	if prCtx.DryRun {
+		return prCtx.PrintDryRun(cmd.dryRunPlan(prCtx, pr, mergeRequest), cmd.Output)
+	}
+
 	if !cmd.Force {
 		if err := cmd.showConfirmationPrompt(pr); err != nil {
 			return err
 		}
 	}
 
+	mergedPR, err := prCtx.Client.PullRequests.MergePullRequest(ctx, prCtx.Workspace, prCtx.Repository, prID, mergeRequest)
+	if err != nil {
+		return handleMergeAPIError(err)
+	}
+
+	if cmd.DeleteBranch && !mergedPR.CloseSourceBranch {
+		if err := cmd.deleteBranch(ctx, prCtx, pr); err != nil {
+			fmt.Printf("Warning: Failed to delete source branch: %v\n", err)
+		}
+	}
+
+	if err := cmd.formatOutput(prCtx, mergedPR); err != nil {
+		return err
+	}
+
+	if cmd.DeleteBranch {
+		cmd.cleanupLocalBranch(prCtx, pr)
+	}
+	return nil
+}
+
+func (cmd *MergeCmd) mergeRequest() *api.PullRequestMerge {
 	mergeRequest := &api.PullRequestMerge{
 		Type:              "pullrequest_merge",
 		CloseSourceBranch: cmd.DeleteBranch,
@@ -72,18 +101,25 @@ func (cmd *MergeCmd) Run(ctx context.Context) error {
 		mergeRequest.Message = cmd.Message
 	}
 
-	mergedPR, err := prCtx.Client.PullRequests.MergePullRequest(ctx, prCtx.Workspace, prCtx.Repository, prID, mergeRequest)
-	if err != nil {
-		return handleMergeAPIError(err)
+	return mergeRequest
+}
+
+// dryRunPlan lists the merge request. Branch deletion rides on
+// close_source_branch; the fallback DELETE only runs if Bitbucket ignores it.
+func (cmd *MergeCmd) dryRunPlan(prCtx *PRContext, pr *api.PullRequest, mergeRequest *api.PullRequestMerge) *shared.DryRunPlan {
+	strategy := "merge commit"
+	if cmd.Squash {
+		strategy = "squash"
 	}
 
-	if cmd.DeleteBranch && !mergedPR.CloseSourceBranch {
-		if err := cmd.deleteBranch(ctx, prCtx, pr); err != nil {
-			fmt.Printf("Warning: Failed to delete source branch: %v\n", err)
-		}
+	plan := shared.NewDryRunPlan("Would merge pull request #%d %q (%s → %s) using %s",
+		pr.ID, pr.Title, getBranchName(pr.Source), getBranchName(pr.Destination), strategy)
+	if cmd.DeleteBranch {
+		plan.Summary += fmt.Sprintf(" and delete branch %s, remotely and locally", getBranchName(pr.Source))
 	}
 
-	return cmd.formatOutput(prCtx, mergedPR)
+	plan.Add("POST", fmt.Sprintf("repositories/%s/%s/pullrequests/%d/merge", prCtx.Workspace, prCtx.Repository, pr.ID), mergeRequest)
+	return plan
 }
 
 func (cmd *MergeCmd) validateMergeability(pr *api.PullRequest) error {
@@ -110,7 +146,7 @@ func (cmd *MergeCmd) showConfirmationPrompt(pr *api.PullRequest) error {
 	}
 
 	if cmd.DeleteBranch {
-		fmt.Printf("Source branch will be deleted after merge\n")
+		fmt.Printf("Source branch will be deleted after merge, on Bitbucket and locally\n")
 	}
 
 	fmt.Print("\nContinue? (y/N): ")
@@ -134,9 +170,7 @@ func (cmd *MergeCmd) deleteBranch(ctx context.Context, prCtx *PRContext, pr *api
 	}
 
 	branchName := pr.Source.Branch.Name
-	endpoint := fmt.Sprintf("repositories/%s/%s/refs/branches/%s", prCtx.Workspace, prCtx.Repository, branchName)
-
-	resp, err := prCtx.Client.Delete(ctx, endpoint)
+	resp, err := prCtx.Client.Delete(ctx, branchEndpoint(prCtx, branchName))
 	if err != nil {
 		return fmt.Errorf("failed to delete branch '%s': %w", branchName, err)
 	}
@@ -145,6 +179,47 @@ func (cmd *MergeCmd) deleteBranch(ctx context.Context, prCtx *PRContext, pr *api
 	return nil
 }
 
+// cleanupLocalBranch finishes --delete-branch in the local clone: it deletes
+// the merged source branch, switching to the default branch first when it is
+// checked out. Branches with commits that weren't merged are kept. The merge
+// has already happened, so problems are only reported.
+func (cmd *MergeCmd) cleanupLocalBranch(prCtx *PRContext, pr *api.PullRequest) {
+	if pr.Source == nil || pr.Source.Branch == nil || pr.Source.Commit == nil {
+		return
+	}
+
+	gitRepo, err := git.NewRepository("")
+	if err != nil {
+		return // not run from a clone
+	}
+	if !strings.EqualFold(gitRepo.GetWorkspace(), prCtx.Workspace) || !strings.EqualFold(gitRepo.GetName(), prCtx.Repository) {
+		return // a clone of another repository
+	}
+
+	w := io.Writer(os.Stdout)
+	if cmd.Output != "table" {
+		w = os.Stderr
+	}
+
+	sourceBranch := pr.Source.Branch.Name
+	defaultBranch, err := gitRepo.GetDefaultBranch()
+	if err != nil {
+		fmt.Fprintf(w, "Warning: Keeping local branch %s: failed to determine the default branch: %v\n", sourceBranch, err)
+		return
+	}
+	if sourceBranch == defaultBranch || sourceBranch == getBranchName(pr.Destination) {
+		return
+	}
+	if !gitRepo.BranchExists(defaultBranch) {
+		fmt.Fprintf(w, "Warning: Keeping local branch %s: default branch %s doesn't exist locally\n", sourceBranch, defaultBranch)
+		return
+	}
+
+	if err := deleteLocalPRBranches(w, gitRepo, pr, defaultBranch, false, ""); err != nil {
+		fmt.Fprintf(w, "Warning: Failed to delete local branch: %v\n", err)
+	}
+}
+
 func (cmd *MergeCmd) formatOutput(prCtx *PRContext, pr *api.PullRequest) error {
 	switch cmd.Output {
 	case "table":
@@ -6,6 +6,7 @@ import (
 	"testing"
 
 	"github.com/carlosarraes/bt/pkg/api"
+	gitpkg "github.com/carlosarraes/bt/pkg/git"
 )
 
 func TestMergeCmd_validateMergeability(t *testing.T) {
@@ -207,3 +208,80 @@ func TestMergeCmd_handleMergeAPIError(t *testing.T) {
 		})
 	}
 }
+
+func TestMergeCmd_dryRunPlan(t *testing.T) {
+	prCtx := &PRContext{Workspace: "ws", Repository: "repo"}
+	pr := &api.PullRequest{
+		ID:          42,
+		Title:       "Add feature",
+		Source:      &api.PullRequestBranch{Branch: &api.Branch{Name: "feature"}},
+		Destination: &api.PullRequestBranch{Branch: &api.Branch{Name: "main"}},
+	}
+
+	cmd := &MergeCmd{Squash: true, DeleteBranch: true}
+	plan := cmd.dryRunPlan(prCtx, pr, cmd.mergeRequest())
+
+	if len(plan.Calls) != 1 {
+		t.Fatalf("dryRunPlan() has %d calls, want 1", len(plan.Calls))
+	}
+	call := plan.Calls[0]
+	if call.Method != "POST" || call.Endpoint != "repositories/ws/repo/pullrequests/42/merge" {
+		t.Errorf("unexpected call %s %s", call.Method, call.Endpoint)
+	}
+
+	request, ok := call.Body.(*api.PullRequestMerge)
+	if !ok || request.MergeStrategy != "squash" || !request.CloseSourceBranch {
+		t.Errorf("unexpected merge request %+v", call.Body)
+	}
+
+	for _, want := range []string{"#42", "feature → main", "squash", "delete branch feature"} {
+		if !strings.Contains(plan.Summary, want) {
+			t.Errorf("summary %q does not mention %q", plan.Summary, want)
+		}
+	}
+}
+
+func TestMergeCmd_cleanupLocalBranch(t *testing.T) {
+	repo, git := newCreateTestRepo(t, "main")
+	dir := repo.GetPath()
+	t.Chdir(dir)
+
+	git("checkout", "-q", "-b", "feature")
+	git("commit", "-q", "--allow-empty", "-m", "feature work")
+	merged, err := gitpkg.ResolveCommitExec(dir, "HEAD")
+	if err != nil {
+		t.Fatal(err)
+	}
+	git("checkout", "-q", "-b", "unpushed")
+	git("commit", "-q", "--allow-empty", "-m", "local only")
+
+	prCtx := &PRContext{Workspace: "ws", Repository: "repo"}
+	prFrom := func(branch string) *api.PullRequest {
+		return &api.PullRequest{
+			ID:          7,
+			Source:      &api.PullRequestBranch{Branch: &api.Branch{Name: branch}, Commit: &api.Commit{Hash: merged[:12]}},
+			Destination: &api.PullRequestBranch{Branch: &api.Branch{Name: "main"}},
+		}
+	}
+	cmd := &MergeCmd{DeleteBranch: true, Output: "table"}
+
+	cmd.cleanupLocalBranch(prCtx, prFrom("unpushed"))
+	if current, _ := gitpkg.GetCurrentBranchExec(dir); current != "unpushed" {
+		t.Errorf("cleanupLocalBranch() switched to %q off a branch with unmerged commits", current)
+	}
+
+	git("checkout", "-q", "feature")
+	cmd.cleanupLocalBranch(prCtx, prFrom("feature"))
+	if current, err := gitpkg.GetCurrentBranchExec(dir); err != nil || current != "main" {
+		t.Errorf("current branch = %q (%v), want main", current, err)
+	}
+	if exists, _ := gitpkg.BranchExistsExec(dir, "refs/heads/feature"); exists {
+		t.Error("cleanupLocalBranch() kept the merged branch")
+	}
+
+	git("checkout", "-q", "-b", "feature")
+	cmd.cleanupLocalBranch(&PRContext{Workspace: "ws", Repository: "other"}, prFrom("feature"))
+	if exists, _ := gitpkg.BranchExistsExec(dir, "refs/heads/feature"); !exists {
+		t.Error("cleanupLocalBranch() touched a clone of another repository")
+	}
+}
@@ -16,6 +16,9 @@ import (
 type OpenCmd struct {
 	PRIDs      []string `arg:"" help:"Pull request IDs (numbers)"`
 	Show       bool     `help:"Print URLs instead of opening in browser"`
+	Commits    bool     `help:"Open the commits tab"`
+	Diff       bool     `help:"Open the diff tab"`
+	Pipeline   bool     `help:"Open the results of the latest pipeline run on the pull request (also --checks)" aliases:"checks"`
 	Workspace  string   `help:"Bitbucket workspace (defaults to git remote or config)"`
 	Repository string   `help:"Repository name (defaults to git remote)"`
 	Debug      bool     `help:"Show debug output"`
@@ -26,6 +29,9 @@ func (cmd *OpenCmd) Run(ctx context.Context) error {
 	if len(cmd.PRIDs) == 0 {
 		return fmt.Errorf("at least one pull request ID is required")
 	}
+	if err := cmd.validateTab(); err != nil {
+		return err
+	}
 
 	var wg sync.WaitGroup
 	for _, prid := range cmd.PRIDs {
@@ -52,9 +58,9 @@ func (cmd *OpenCmd) processPR(ctx context.Context, prid string) error {
 		return fmt.Errorf("invalid PR ID '%s': %w", prid, err)
 	}
 
-	if cmd.Workspace != "" && cmd.Repository != "" {
+	if cmd.Workspace != "" && cmd.Repository != "" && !cmd.Pipeline {
 		url := fmt.Sprintf("https://bitbucket.org/%s/%s/pull-requests/%d", cmd.Workspace, cmd.Repository, prID)
-		return cmd.handleURL(url)
+		return cmd.handleURL(cmd.tabURL(url))
 	}
 
 	prCtx, err := shared.NewCommandContext(ctx, "table", cmd.NoColor, cmd.Debug)
@@ -65,11 +71,31 @@ func (cmd *OpenCmd) processPR(ctx context.Context, prid string) error {
 		}
 	}
 
-	if prCtx.Workspace == "" {
-		return fmt.Errorf("could not determine workspace. Use --workspace flag")
+	prCtx.ApplyRepoFlags(cmd.Workspace, cmd.Repository)
+
+	var match *PRMatch
+	if cmd.Workspace != "" && cmd.Repository != "" {
+		match = &PRMatch{
+			URL:        fmt.Sprintf("https://bitbucket.org/%s/%s/pull-requests/%d", cmd.Workspace, cmd.Repository, prID),
+			Repository: cmd.Repository,
+			FullName:   cmd.Workspace + "/" + cmd.Repository,
+		}
+	} else {
+		if prCtx.Workspace == "" {
+			return fmt.Errorf("could not determine workspace. Use --workspace flag")
+		}
+
+		match, err = cmd.findPR(ctx, prCtx, prID)
+		if err != nil {
+			return err
+		}
+	}
+
+	if !cmd.Pipeline {
+		return cmd.handleURL(cmd.tabURL(match.URL))
 	}
 
-	url, err := cmd.findPRURL(ctx, prCtx, prID)
+	url, err := latestPipelineURL(ctx, prCtx, match.Repository, prID)
 	if err != nil {
 		return err
 	}
@@ -83,7 +109,7 @@ type PRMatch struct {
 	FullName   string
 }
 
-func (cmd *OpenCmd) findPRURL(ctx context.Context, prCtx *PRContext, prID int) (string, error) {
+func (cmd *OpenCmd) findPR(ctx context.Context, prCtx *PRContext, prID int) (*PRMatch, error) {
 	repoOptions := &api.RepositoryListOptions{
 		Role:    "member",
 		PageLen: 100,
@@ -92,21 +118,21 @@ func (cmd *OpenCmd) findPRURL(ctx context.Context, prCtx *PRContext, prID int) (
 
 	repoResult, err := prCtx.Client.Repositories.ListRepositories(ctx, prCtx.Workspace, repoOptions)
 	if err != nil {
-		return "", fmt.Errorf("failed to fetch repositories: %w", err)
+		return nil, fmt.Errorf("failed to fetch repositories: %w", err)
 	}
 
 	var repositories []*api.Repository
 	if repoResult.Values != nil {
 		var values []json.RawMessage
 		if err := json.Unmarshal(repoResult.Values, &values); err != nil {
-			return "", fmt.Errorf("failed to unmarshal repository values: %w", err)
+			return nil, fmt.Errorf("failed to unmarshal repository values: %w", err)
 		}
 
 		repositories = make([]*api.Repository, len(values))
 		for i, rawRepo := range values {
 			var repo api.Repository
 			if err := json.Unmarshal(rawRepo, &repo); err != nil {
-				return "", fmt.Errorf("failed to unmarshal repository %d: %w", i, err)
+				return nil, fmt.Errorf("failed to unmarshal repository %d: %w", i, err)
 			}
 			repositories[i] = &repo
 		}
@@ -189,11 +215,11 @@ func (cmd *OpenCmd) findPRURL(ctx context.Context, prCtx *PRContext, prID int) (
 	wg.Wait()
 
 	if len(matches) == 0 {
-		return "", fmt.Errorf("PR #%d not found in any repository in workspace %s", prID, prCtx.Workspace)
+		return nil, fmt.Errorf("PR #%d not found in any repository in workspace %s", prID, prCtx.Workspace)
 	}
 
 	if len(matches) == 1 {
-		return matches[0].URL, nil
+		return &matches[0], nil
 	}
 
 	fmt.Fprintf(os.Stderr, "Multiple PRs found with ID #%d:\n", prID)
@@ -202,7 +228,7 @@ func (cmd *OpenCmd) findPRURL(ctx context.Context, prCtx *PRContext, prID int) (
 	}
 	fmt.Fprintf(os.Stderr, "\nPlease be more specific by using: bt pr open --workspace %s --repository <repo_name> %d\n", prCtx.Workspace, prID)
 
-	return "", fmt.Errorf("multiple PRs found - please specify repository")
+	return nil, fmt.Errorf("multiple PRs found - please specify repository")
 }
 
 func (cmd *OpenCmd) handleURL(url string) error {
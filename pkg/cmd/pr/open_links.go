@@ -0,0 +1,56 @@
+package pr
+
+import (
+	"context"
+	"fmt"
+)
+
+// validateTab rejects asking for more than one tab of the pull request
+func (cmd *OpenCmd) validateTab() error {
+	tabs := 0
+	for _, set := range []bool{cmd.Commits, cmd.Diff, cmd.Pipeline} {
+		if set {
+			tabs++
+		}
+	}
+	if tabs > 1 {
+		return fmt.Errorf("only one of --commits, --diff and --pipeline can be given")
+	}
+	return nil
+}
+
+// tabURL deep-links prURL to the commits or diff tab when asked to
+func (cmd *OpenCmd) tabURL(prURL string) string {
+	switch {
+	case cmd.Commits:
+		return prURL + "/commits"
+	case cmd.Diff:
+		return prURL + "/diff"
+	default:
+		return prURL
+	}
+}
+
+// latestPipelineURL links to the results of the latest pipeline run on the
+// source commit of the pull request
+func latestPipelineURL(ctx context.Context, prCtx *PRContext, repoSlug string, prID int) (string, error) {
+	pr, err := prCtx.Client.PullRequests.GetPullRequest(ctx, prCtx.Workspace, repoSlug, prID)
+	if err != nil {
+		return "", handlePullRequestAPIError(err)
+	}
+	if pr.Source == nil || pr.Source.Commit == nil || pr.Source.Commit.Hash == "" {
+		return "", fmt.Errorf("unable to find commit SHA for pull request #%d", prID)
+	}
+
+	// Pipelines come newest first
+	pipelines, err := prCtx.Client.Pipelines.GetPipelinesByCommit(ctx, prCtx.Workspace, repoSlug, pr.Source.Commit.Hash)
+	if err != nil {
+		return "", fmt.Errorf("failed to get pipelines for commit %s: %w", pr.Source.Commit.Hash, err)
+	}
+	if len(pipelines) == 0 {
+		return "", fmt.Errorf("no pipeline has run on the source commit of pull request #%d", prID)
+	}
+
+	return fmt.Sprintf("https://bitbucket.org/%s/%s/addon/pipelines/home#!/results/%d",
+		prCtx.Workspace, repoSlug, pipelines[0].BuildNumber), nil
+}
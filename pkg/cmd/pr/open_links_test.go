@@ -0,0 +1,58 @@
+package pr
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/carlosarraes/bt/pkg/api/apitest"
+	"github.com/carlosarraes/bt/pkg/cmd/shared"
+)
+
+func TestOpenCmd_tabURL(t *testing.T) {
+	prURL := "https://bitbucket.org/ws/repo/pull-requests/7"
+
+	assert.Equal(t, prURL, (&OpenCmd{}).tabURL(prURL))
+	assert.Equal(t, prURL+"/commits", (&OpenCmd{Commits: true}).tabURL(prURL))
+	assert.Equal(t, prURL+"/diff", (&OpenCmd{Diff: true}).tabURL(prURL))
+}
+
+func TestOpenCmd_validateTab(t *testing.T) {
+	assert.NoError(t, (&OpenCmd{Pipeline: true}).validateTab())
+	assert.EqualError(t, (&OpenCmd{Diff: true, Pipeline: true}).validateTab(), "only one of --commits, --diff and --pipeline can be given")
+}
+
+func latestPipelineURLFor(t *testing.T, pipelines string) (string, error) {
+	t.Helper()
+
+	apitest.CommandEnv(t, "ws", "repo")
+	transport := apitest.NewReplayTransport(apitest.Fixture{
+		Method: "GET",
+		Path:   "/repositories/ws/repo/pullrequests/7",
+		Body:   json.RawMessage(`{"id": 7, "state": "OPEN", "source": {"branch": {"name": "fix/login"}, "commit": {"hash": "abc123"}}}`),
+	}, apitest.Fixture{
+		Method: "GET",
+		Path:   "/repositories/ws/repo/pipelines",
+		Query:  "target.commit.hash=abc123",
+		Body:   json.RawMessage(pipelines),
+	})
+	t.Cleanup(shared.SetClientTransport(transport))
+
+	prCtx, err := shared.NewCommandContext(context.Background(), "table", true)
+	require.NoError(t, err)
+	return latestPipelineURL(context.Background(), prCtx, "repo", 7)
+}
+
+func TestLatestPipelineURL(t *testing.T) {
+	url, err := latestPipelineURLFor(t, `{"values": [{"uuid": "{p-2}", "build_number": 42}, {"uuid": "{p-1}", "build_number": 41}]}`)
+	require.NoError(t, err)
+	assert.Equal(t, "https://bitbucket.org/ws/repo/addon/pipelines/home#!/results/42", url)
+}
+
+func TestLatestPipelineURL_NoPipeline(t *testing.T) {
+	_, err := latestPipelineURLFor(t, `{"values": []}`)
+	assert.EqualError(t, err, "no pipeline has run on the source commit of pull request #7")
+}
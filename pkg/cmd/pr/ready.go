@@ -3,20 +3,24 @@ package pr
 import (
 	"context"
 	"fmt"
+	"os"
 	"strings"
+	"time"
 
 	"github.com/carlosarraes/bt/pkg/api"
 	"github.com/carlosarraes/bt/pkg/cmd/shared"
 )
 
 type ReadyCmd struct {
-	PRID       string `arg:"" help:"Pull request ID (number)"`
-	Comment    string `help:"Add a comment when marking as ready"`
-	Force      bool   `short:"f" help:"Force mark as ready without confirmation"`
-	Output     string `short:"o" help:"Output format (table, json, yaml)" enum:"table,json,yaml" default:"table"`
-	NoColor    bool
-	Workspace  string `help:"Bitbucket workspace (defaults to git remote or config)"`
-	Repository string `help:"Repository name (defaults to git remote)"`
+	PRID           string        `arg:"" help:"Pull request ID (number)"`
+	Comment        string        `help:"Add a comment when marking as ready"`
+	Force          bool          `short:"f" help:"Force mark as ready without confirmation"`
+	WhenChecksPass bool          `name:"when-checks-pass" help:"Wait for the pull request's checks to pass, then mark it ready; it stays a draft if any fails"`
+	Timeout        time.Duration `help:"With --when-checks-pass, how long to wait for the checks" default:"1h"`
+	Output         string        `short:"o" help:"Output format (table, json, yaml)" enum:"table,json,yaml" default:"table"`
+	NoColor        bool
+	Workspace      string `help:"Bitbucket workspace (defaults to git remote or config)"`
+	Repository     string `help:"Repository name (defaults to git remote)"`
 }
 
 type PRReadyResult struct {
@@ -31,14 +35,7 @@ func (cmd *ReadyCmd) Run(ctx context.Context) error {
 		return err
 	}
 
-	if cmd.Workspace != "" {
-		prCtx.Workspace = cmd.Workspace
-	}
-	if cmd.Repository != "" {
-		prCtx.Repository = cmd.Repository
-	}
-
-	if err := prCtx.ValidateWorkspaceAndRepo(); err != nil {
+	if err := prCtx.ResolveRepository(cmd.Workspace, cmd.Repository); err != nil {
 		return err
 	}
 
@@ -62,9 +59,17 @@ func (cmd *ReadyCmd) Run(ctx context.Context) error {
 		}
 	}
 
+	if cmd.WhenChecksPass {
+		if err := cmd.waitForChecks(ctx, prCtx, prID, os.Stderr); err != nil {
+			return err
+		}
+	}
+
+	notDraft := false
 	updateRequest := &api.UpdatePullRequestRequest{
 		Type:  "pullrequest",
 		State: "OPEN",
+		Draft: &notDraft,
 	}
 
 	updatedPR, err := prCtx.Client.PullRequests.UpdatePullRequest(ctx, prCtx.Workspace, prCtx.Repository, prID, updateRequest)
@@ -90,7 +95,7 @@ func (cmd *ReadyCmd) Run(ctx context.Context) error {
 }
 
 func isPRDraft(pr *api.PullRequest) bool {
-	if strings.EqualFold(pr.State, "DRAFT") {
+	if pr.Draft || strings.EqualFold(pr.State, "DRAFT") {
 		return true
 	}
 
@@ -108,6 +113,9 @@ func (cmd *ReadyCmd) confirmReady(pr *api.PullRequest) bool {
 	fmt.Printf("Author: %s\n", pr.Author.Username)
 	fmt.Printf("Source: %s -> %s\n", pr.Source.Branch.Name, pr.Destination.Branch.Name)
 	fmt.Printf("\nThis will:\n")
+	if cmd.WhenChecksPass {
+		fmt.Printf("- Wait for its checks to pass, leaving it a draft if any fails\n")
+	}
 	fmt.Printf("- Mark the PR as ready for review\n")
 	fmt.Printf("- Notify reviewers\n")
 	fmt.Printf("- Allow the PR to be merged\n")
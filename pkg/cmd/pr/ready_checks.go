@@ -0,0 +1,88 @@
+package pr
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// readyCheckInterval is how often ready --when-checks-pass polls the checks
+var readyCheckInterval = 15 * time.Second
+
+// waitForChecks is ready --when-checks-pass: it polls the checks of the pull
+// request's latest commit until they all pass, and fails as soon as one
+// does. Progress is written to w whenever the counts change. The pull
+// request is read again on every poll, so pushes made while waiting are
+// picked up.
+func (cmd *ReadyCmd) waitForChecks(ctx context.Context, prCtx *PRContext, prID int, w io.Writer) error {
+	waitCtx := ctx
+	if cmd.Timeout > 0 {
+		var cancel context.CancelFunc
+		waitCtx, cancel = context.WithTimeout(ctx, cmd.Timeout)
+		defer cancel()
+	}
+	timedOut := func(err error) error {
+		if ctx.Err() == nil && errors.Is(waitCtx.Err(), context.DeadlineExceeded) {
+			return fmt.Errorf("checks on pull request #%d did not pass within %s; it stays a draft", prID, cmd.Timeout)
+		}
+		return err
+	}
+
+	checks := &ChecksCmd{}
+	lastProgress := ""
+	for {
+		pr, err := prCtx.Client.PullRequests.GetPullRequest(waitCtx, prCtx.Workspace, prCtx.Repository, prID)
+		if err != nil {
+			return timedOut(handlePullRequestAPIError(err))
+		}
+		if !isPRDraft(pr) {
+			return fmt.Errorf("pull request #%d is no longer a draft (current state: %s)", prID, pr.State)
+		}
+
+		pipelines, err := checks.checksForPullRequest(waitCtx, prCtx, pr)
+		if err != nil {
+			return timedOut(err)
+		}
+
+		counts := checks.countChecks(pipelines)
+		progress := describeCheckCounts(counts)
+		if counts.failed > 0 {
+			return fmt.Errorf("checks failed on pull request #%d (%s); it stays a draft", prID, progress)
+		}
+		if counts.running == 0 && counts.successful > 0 {
+			fmt.Fprintf(w, "✓ Checks passed on #%d: %s\n", prID, progress)
+			return nil
+		}
+		if progress != lastProgress {
+			fmt.Fprintf(w, "⏳ Waiting for checks on #%d: %s\n", prID, progress)
+			lastProgress = progress
+		}
+
+		select {
+		case <-waitCtx.Done():
+			return timedOut(waitCtx.Err())
+		case <-time.After(readyCheckInterval):
+		}
+	}
+}
+
+// describeCheckCounts sums up checks as "2 passed, 1 running"
+func describeCheckCounts(counts checkCounts) string {
+	var parts []string
+	if counts.successful > 0 {
+		parts = append(parts, fmt.Sprintf("%d passed", counts.successful))
+	}
+	if counts.failed > 0 {
+		parts = append(parts, fmt.Sprintf("%d failed", counts.failed))
+	}
+	if counts.running > 0 {
+		parts = append(parts, fmt.Sprintf("%d running", counts.running))
+	}
+	if len(parts) == 0 {
+		return "no checks reported yet"
+	}
+	return strings.Join(parts, ", ")
+}
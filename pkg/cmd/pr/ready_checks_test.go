@@ -0,0 +1,88 @@
+package pr
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/carlosarraes/bt/pkg/api/apitest"
+	"github.com/carlosarraes/bt/pkg/cmd/shared"
+)
+
+func readyFixtures(pipelineStates ...string) []apitest.Fixture {
+	fixtures := []apitest.Fixture{{
+		Method: "GET",
+		Path:   "/repositories/ws/repo/pullrequests/7",
+		Body:   json.RawMessage(`{"id": 7, "title": "Fix login", "state": "OPEN", "draft": true, "source": {"branch": {"name": "fix/login"}, "commit": {"hash": "abc123"}}}`),
+	}, {
+		Method: "GET",
+		Path:   "/repositories/ws/repo/commit/abc123/statuses",
+		Body:   json.RawMessage(`{"values": []}`),
+	}}
+	for _, state := range pipelineStates {
+		fixtures = append(fixtures, apitest.Fixture{
+			Method: "GET",
+			Path:   "/repositories/ws/repo/pipelines",
+			Body:   json.RawMessage(`{"values": [{"uuid": "{p-1}", "build_number": 1, "state": {"name": "` + state + `"}}]}`),
+		})
+	}
+	return fixtures
+}
+
+func waitForReadyChecks(t *testing.T, fixtures ...apitest.Fixture) (*apitest.ReplayTransport, string, error) {
+	t.Helper()
+
+	apitest.CommandEnv(t, "ws", "repo")
+	transport := apitest.NewReplayTransport(fixtures...)
+	t.Cleanup(shared.SetClientTransport(transport))
+
+	interval := readyCheckInterval
+	readyCheckInterval = time.Millisecond
+	t.Cleanup(func() { readyCheckInterval = interval })
+
+	prCtx, err := shared.NewCommandContext(context.Background(), "table", true)
+	require.NoError(t, err)
+
+	var progress bytes.Buffer
+	cmd := &ReadyCmd{WhenChecksPass: true, Timeout: time.Minute}
+	err = cmd.waitForChecks(context.Background(), prCtx, 7, &progress)
+	return transport, progress.String(), err
+}
+
+func TestReadyCmd_waitForChecks_Pass(t *testing.T) {
+	transport, progress, err := waitForReadyChecks(t, readyFixtures("IN_PROGRESS", "IN_PROGRESS", "SUCCESSFUL")...)
+	require.NoError(t, err)
+
+	assert.Equal(t, "⏳ Waiting for checks on #7: 1 running\n✓ Checks passed on #7: 1 passed\n", progress)
+
+	polls := 0
+	for _, req := range transport.Requests() {
+		if req.Path == "/repositories/ws/repo/pipelines" {
+			polls++
+		}
+	}
+	assert.Equal(t, 3, polls)
+}
+
+func TestReadyCmd_waitForChecks_Fail(t *testing.T) {
+	_, _, err := waitForReadyChecks(t, readyFixtures("IN_PROGRESS", "FAILED")...)
+	assert.EqualError(t, err, "checks failed on pull request #7 (1 failed); it stays a draft")
+}
+
+func TestReadyCmd_waitForChecks_NoLongerDraft(t *testing.T) {
+	fixtures := readyFixtures("IN_PROGRESS")
+	fixtures[0].Body = json.RawMessage(`{"id": 7, "title": "Fix login", "state": "MERGED", "source": {"commit": {"hash": "abc123"}}}`)
+
+	_, _, err := waitForReadyChecks(t, fixtures...)
+	assert.EqualError(t, err, "pull request #7 is no longer a draft (current state: MERGED)")
+}
+
+func TestDescribeCheckCounts(t *testing.T) {
+	assert.Equal(t, "no checks reported yet", describeCheckCounts(checkCounts{}))
+	assert.Equal(t, "2 passed, 1 failed, 1 running", describeCheckCounts(checkCounts{successful: 2, failed: 1, running: 1}))
+}
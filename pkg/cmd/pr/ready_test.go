@@ -88,6 +88,15 @@ func TestReadyCmd_isPRDraft(t *testing.T) {
 			},
 			expected: false,
 		},
+		{
+			name: "open with draft flag",
+			pr: &api.PullRequest{
+				State: "OPEN",
+				Title: "Normal title",
+				Draft: true,
+			},
+			expected: true,
+		},
 		{
 			name: "draft prefix in title",
 			pr: &api.PullRequest{
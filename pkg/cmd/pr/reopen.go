@@ -28,14 +28,7 @@ func (cmd *ReopenCmd) Run(ctx context.Context) error {
 		return err
 	}
 
-	if cmd.Workspace != "" {
-		prCtx.Workspace = cmd.Workspace
-	}
-	if cmd.Repository != "" {
-		prCtx.Repository = cmd.Repository
-	}
-
-	if err := prCtx.ValidateWorkspaceAndRepo(); err != nil {
+	if err := prCtx.ResolveRepository(cmd.Workspace, cmd.Repository); err != nil {
 		return err
 	}
 
@@ -93,14 +86,33 @@ func (cmd *ReopenCmd) validatePRState(pr *api.PullRequest) error {
 	case "OPEN":
 		return fmt.Errorf("pull request #%d is already open", pr.ID)
 	case "MERGED":
-		return fmt.Errorf("pull request #%d is already merged and cannot be reopened", pr.ID)
+		return fmt.Errorf("pull request #%d is already merged and cannot be reopened; Bitbucket only reopens declined pull requests. %s",
+			pr.ID, newPullRequestHint(pr))
 	case "SUPERSEDED":
-		return fmt.Errorf("pull request #%d is superseded and cannot be reopened", pr.ID)
+		return fmt.Errorf("pull request #%d is superseded and cannot be reopened. %s", pr.ID, newPullRequestHint(pr))
 	default:
 		return fmt.Errorf("pull request #%d is in an unknown state '%s'", pr.ID, pr.State)
 	}
 }
 
+// newPullRequestHint suggests opening a new pull request for the same
+// branches when pr can't be reopened
+func newPullRequestHint(pr *api.PullRequest) string {
+	source, destination := "", ""
+	if pr.Source != nil && pr.Source.Branch != nil {
+		source = pr.Source.Branch.Name
+	}
+	if pr.Destination != nil && pr.Destination.Branch != nil {
+		destination = pr.Destination.Branch.Name
+	}
+
+	if source == "" || destination == "" {
+		return "Create a new pull request instead with 'bt pr create'"
+	}
+	return fmt.Sprintf("To continue the work, push new commits to %s and run 'git checkout %s && bt pr create --base %s'",
+		source, source, destination)
+}
+
 func (cmd *ReopenCmd) confirmReopen(pr *api.PullRequest) error {
 	fmt.Printf("Are you sure you want to reopen pull request #%d (%s)? [y/N] ", pr.ID, pr.Title)
 
@@ -1,6 +1,7 @@
 package pr
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/carlosarraes/bt/pkg/api"
@@ -124,3 +125,29 @@ func TestReopenCmd_validatePRState(t *testing.T) {
 		})
 	}
 }
+
+func TestReopenCmd_validatePRState_MergedHint(t *testing.T) {
+	cmd := &ReopenCmd{}
+	pr := &api.PullRequest{
+		ID:          42,
+		State:       "MERGED",
+		Source:      &api.PullRequestBranch{Branch: &api.Branch{Name: "feature/login"}},
+		Destination: &api.PullRequestBranch{Branch: &api.Branch{Name: "main"}},
+	}
+
+	err := cmd.validatePRState(pr)
+	if err == nil {
+		t.Fatal("validatePRState() expected error, got nil")
+	}
+	for _, want := range []string{"#42 is already merged", "only reopens declined", "bt pr create --base main", "feature/login"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("validatePRState() error %q missing %q", err, want)
+		}
+	}
+
+	pr.Source, pr.Destination = nil, nil
+	err = cmd.validatePRState(pr)
+	if err == nil || !strings.Contains(err.Error(), "'bt pr create'") {
+		t.Errorf("validatePRState() without branches = %v, want generic bt pr create hint", err)
+	}
+}
@@ -51,14 +51,7 @@ func (cmd *ReportCmd) Run(ctx context.Context) error {
 		return err
 	}
 
-	if cmd.Workspace != "" {
-		prCtx.Workspace = cmd.Workspace
-	}
-	if cmd.Repository != "" {
-		prCtx.Repository = cmd.Repository
-	}
-
-	if err := prCtx.ValidateWorkspaceAndRepo(); err != nil {
+	if err := prCtx.ResolveRepository(cmd.Workspace, cmd.Repository); err != nil {
 		return err
 	}
 
@@ -94,6 +87,11 @@ func (cmd *ReportCmd) Run(ctx context.Context) error {
 		}
 	}
 
+	if cmd.Output == "table" {
+		stopPager := shared.StartPager(ctx)
+		defer stopPager()
+	}
+
 	return cmd.generateReport(ctx, prCtx, sonarCloudService, prID)
 }
 
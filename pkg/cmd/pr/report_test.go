@@ -2,6 +2,7 @@ package pr
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 
@@ -34,3 +35,22 @@ func TestFilterActionable_NilInput(t *testing.T) {
 	assert.Empty(t, out.Issues)
 	assert.True(t, out.Available)
 }
+
+func TestReportCmd_formatOutput_YAMLRoundTrip(t *testing.T) {
+	prID := 7
+	report := &sonarcloud.Report{
+		ProjectKey:    "acme_api",
+		PullRequestID: &prID,
+		Timestamp:     time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC),
+		QualityGate:   &sonarcloud.QualityGateInfo{Status: "OK", Passed: true},
+		Issues: &sonarcloud.IssuesData{
+			Available: true,
+			Issues:    []sonarcloud.ProcessedIssue{{Key: "AX-1", IsNew: true, Status: "OPEN"}},
+		},
+	}
+
+	cmd := &ReportCmd{Output: "yaml"}
+	assertYAMLMatchesJSON(t, func(prCtx *PRContext) error {
+		return cmd.formatOutput(prCtx, report, prID, sonarcloud.FilterOptions{}, nil, nil)
+	})
+}
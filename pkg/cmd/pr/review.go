@@ -13,14 +13,17 @@ import (
 )
 
 type ReviewCmd struct {
-	PRID           string `arg:"" help:"Pull request ID (number)"`
-	Approve        bool   `help:"Approve the pull request"`
-	RequestChanges bool   `name:"request-changes" help:"Request changes on the pull request"`
-	Comment        bool   `help:"Add a comment to the pull request"`
-	Body           string `short:"b" help:"Comment body text"`
-	BodyFile       string `short:"F" name:"body-file" help:"Read comment body from file"`
-	Force          bool   `short:"f" help:"Skip confirmation prompts"`
-	Output         string `short:"o" help:"Output format (table, json, yaml)" enum:"table,json,yaml" default:"table"`
+	PRID           string   `arg:"" help:"Pull request ID (number)"`
+	BatchIDs       []string // BatchIDs are approved together with --approve --force
+	Query          string   `help:"Approve every open pull request matching author=USER and/or reviewer=USER"`
+	Approve        bool     `help:"Approve the pull request"`
+	RequestChanges bool     `name:"request-changes" help:"Request changes on the pull request"`
+	Comment        bool     `help:"Add a comment to the pull request"`
+	Body           string   `short:"b" help:"Comment body text"`
+	BodyFile       string   `short:"F" name:"body-file" help:"Read comment body from file"`
+	FromPipeline   string   `name:"from-pipeline" help:"Add the errors of the failed steps of this pipeline (build number or UUID) to the comment"`
+	Force          bool     `short:"f" help:"Skip confirmation prompts"`
+	Output         string   `short:"o" help:"Output format (table, json, yaml)" enum:"table,json,yaml" default:"table"`
 	NoColor        bool
 	Workspace      string `help:"Bitbucket workspace (defaults to git remote or config)"`
 	Repository     string `help:"Repository name (defaults to git remote)"`
@@ -40,15 +43,12 @@ func (cmd *ReviewCmd) Run(ctx context.Context) error {
 		return err
 	}
 
-	if cmd.Workspace != "" {
-		prCtx.Workspace = cmd.Workspace
-	}
-	if cmd.Repository != "" {
-		prCtx.Repository = cmd.Repository
+	if err := prCtx.ResolveRepository(cmd.Workspace, cmd.Repository); err != nil {
+		return err
 	}
 
-	if err := prCtx.ValidateWorkspaceAndRepo(); err != nil {
-		return err
+	if cmd.isBatch() {
+		return cmd.runBatchApproval(ctx, prCtx)
 	}
 
 	prID, err := cmd.ParsePRID()
@@ -61,7 +61,12 @@ func (cmd *ReviewCmd) Run(ctx context.Context) error {
 		return err
 	}
 
-	body, err := cmd.getCommentBody(action)
+	var body string
+	if cmd.FromPipeline != "" {
+		body, err = cmd.pipelineCommentBody(ctx, prCtx, action)
+	} else {
+		body, err = cmd.getCommentBody(action)
+	}
 	if err != nil {
 		return err
 	}
@@ -71,6 +76,12 @@ func (cmd *ReviewCmd) Run(ctx context.Context) error {
 		return handlePullRequestAPIError(err)
 	}
 
+	if prCtx.DryRun {
+		plan := shared.NewDryRunPlan("Would %s pull request #%d %q", reviewActionVerb(action), pr.ID, pr.Title)
+		addReviewCalls(plan, prCtx, action, prID, body)
+		return prCtx.PrintDryRun(plan, cmd.Output)
+	}
+
 	if !cmd.Force {
 		if err := cmd.confirmReviewAction(action, pr, body); err != nil {
 			return err
@@ -127,22 +138,9 @@ func (cmd *ReviewCmd) validateReviewAction() (reviewAction, error) {
 }
 
 func (cmd *ReviewCmd) getCommentBody(action reviewAction) (string, error) {
-	var body string
-
-	if cmd.Body != "" {
-		body = cmd.Body
-	}
-
-	if cmd.BodyFile != "" {
-		if cmd.Body != "" {
-			return "", fmt.Errorf("cannot specify both --body and --body-file")
-		}
-
-		fileContent, err := os.ReadFile(cmd.BodyFile)
-		if err != nil {
-			return "", fmt.Errorf("failed to read body file '%s': %w", cmd.BodyFile, err)
-		}
-		body = string(fileContent)
+	body, err := cmd.readBodyFlags()
+	if err != nil {
+		return "", err
 	}
 
 	if action == actionRequestChanges && strings.TrimSpace(body) == "" {
@@ -183,19 +181,48 @@ func (cmd *ReviewCmd) getCommentBody(action reviewAction) (string, error) {
 	return strings.TrimSpace(body), nil
 }
 
-func (cmd *ReviewCmd) confirmReviewAction(action reviewAction, pr *api.PullRequest, body string) error {
-	actionStr := ""
-	switch action {
-	case actionApprove:
-		actionStr = "approve"
-	case actionRequestChanges:
-		actionStr = "request changes on"
-	case actionComment:
-		actionStr = "comment on"
+// readBodyFlags returns the comment given with --body or --body-file
+func (cmd *ReviewCmd) readBodyFlags() (string, error) {
+	if cmd.BodyFile == "" {
+		return cmd.Body, nil
+	}
+	if cmd.Body != "" {
+		return "", fmt.Errorf("cannot specify both --body and --body-file")
+	}
+
+	fileContent, err := os.ReadFile(cmd.BodyFile)
+	if err != nil {
+		return "", fmt.Errorf("failed to read body file '%s': %w", cmd.BodyFile, err)
+	}
+	return string(fileContent), nil
+}
+
+// pipelineCommentBody is the comment of --from-pipeline: the text of --body
+// or --body-file, if any, followed by the pipeline's failure summary
+func (cmd *ReviewCmd) pipelineCommentBody(ctx context.Context, prCtx *PRContext, action reviewAction) (string, error) {
+	if action == actionApprove {
+		return "", fmt.Errorf("--from-pipeline requires --request-changes or --comment")
+	}
+
+	body, err := cmd.readBodyFlags()
+	if err != nil {
+		return "", err
 	}
 
+	summary, err := pipelineFailureSummary(ctx, prCtx, cmd.FromPipeline)
+	if err != nil {
+		return "", err
+	}
+
+	if body = strings.TrimSpace(body); body != "" {
+		return body + "\n\n" + summary, nil
+	}
+	return summary, nil
+}
+
+func (cmd *ReviewCmd) confirmReviewAction(action reviewAction, pr *api.PullRequest, body string) error {
 	fmt.Printf("Review #%d (%s):\n", pr.ID, pr.Title)
-	fmt.Printf("Action: %s\n", actionStr)
+	fmt.Printf("Action: %s\n", reviewActionVerb(action))
 	if body != "" {
 		fmt.Printf("Comment: %s\n", body)
 	}
@@ -215,6 +242,38 @@ func (cmd *ReviewCmd) confirmReviewAction(action reviewAction, pr *api.PullReque
 	return nil
 }
 
+func reviewActionVerb(action reviewAction) string {
+	switch action {
+	case actionApprove:
+		return "approve"
+	case actionRequestChanges:
+		return "request changes on"
+	case actionComment:
+		return "comment on"
+	default:
+		return ""
+	}
+}
+
+// addReviewCalls adds the requests a review action sends to plan, matching
+// the execute* functions below
+func addReviewCalls(plan *shared.DryRunPlan, prCtx *PRContext, action reviewAction, prID int, body string) {
+	prEndpoint := fmt.Sprintf("repositories/%s/%s/pullrequests/%d", prCtx.Workspace, prCtx.Repository, prID)
+	comment := map[string]interface{}{"content": map[string]string{"raw": body}}
+
+	switch action {
+	case actionApprove:
+		plan.Add("POST", prEndpoint+"/approve", nil)
+		if body != "" {
+			plan.Add("POST", prEndpoint+"/comments", comment)
+		}
+	case actionRequestChanges:
+		plan.Add("POST", prEndpoint+"/request-changes", comment)
+	case actionComment:
+		plan.Add("POST", prEndpoint+"/comments", comment)
+	}
+}
+
 func (cmd *ReviewCmd) executeReviewAction(ctx context.Context, prCtx *PRContext, action reviewAction, prID int, body string, pr *api.PullRequest) error {
 	switch action {
 	case actionApprove:
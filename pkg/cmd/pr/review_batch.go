@@ -0,0 +1,295 @@
+package pr
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/carlosarraes/bt/pkg/api"
+	"github.com/carlosarraes/bt/pkg/cmd/shared"
+	"github.com/carlosarraes/bt/pkg/output"
+)
+
+// maxBatchApprovals caps how many pull requests a single --query may approve
+const maxBatchApprovals = 100
+
+// Outcomes of a batch approval
+const (
+	batchApproved = "approved"
+	batchSkipped  = "skipped"
+	batchFailed   = "failed"
+)
+
+// BatchApprovalResult is the outcome of approving one pull request in a batch
+type BatchApprovalResult struct {
+	ID     int    `json:"id" yaml:"id"`
+	Title  string `json:"title" yaml:"title"`
+	Author string `json:"author" yaml:"author"`
+	Result string `json:"result" yaml:"result"`
+	Reason string `json:"reason,omitempty" yaml:"reason,omitempty"`
+}
+
+// isBatch reports whether the review targets several pull requests at once
+func (cmd *ReviewCmd) isBatch() bool {
+	return len(cmd.BatchIDs) > 0 || cmd.Query != ""
+}
+
+// runBatchApproval approves every pull request given by ID or matched by
+// --query. Batches are approval-only and always require --force.
+func (cmd *ReviewCmd) runBatchApproval(ctx context.Context, prCtx *PRContext) error {
+	if cmd.RequestChanges || cmd.Comment || !cmd.Approve {
+		return fmt.Errorf("multiple pull requests or --query can only be used with --approve")
+	}
+	if cmd.FromPipeline != "" {
+		return fmt.Errorf("--from-pipeline requires --request-changes or --comment")
+	}
+	if !cmd.Force && !prCtx.DryRun {
+		return fmt.Errorf("approving multiple pull requests requires --force")
+	}
+	if len(cmd.BatchIDs) > 0 && cmd.Query != "" {
+		return fmt.Errorf("cannot combine pull request IDs with --query")
+	}
+
+	body, err := cmd.getCommentBody(actionApprove)
+	if err != nil {
+		return err
+	}
+
+	// Under --dry-run approveOne records its requests here instead
+	var plan *shared.DryRunPlan
+	if prCtx.DryRun {
+		plan = shared.NewDryRunPlan("")
+	}
+
+	var results []BatchApprovalResult
+	if cmd.Query != "" {
+		options, err := parseApprovalQuery(ctx, prCtx.Client, cmd.Query)
+		if err != nil {
+			return err
+		}
+		pullRequests, err := cmd.findPullRequests(ctx, prCtx, options)
+		if err != nil {
+			return err
+		}
+		for _, pr := range pullRequests {
+			results = append(results, cmd.approveOne(ctx, prCtx, pr, body, plan))
+		}
+	} else {
+		ids, err := parseBatchIDs(cmd.BatchIDs)
+		if err != nil {
+			return err
+		}
+		for _, id := range ids {
+			pr, err := prCtx.Client.PullRequests.GetPullRequest(ctx, prCtx.Workspace, prCtx.Repository, id)
+			if err != nil {
+				results = append(results, BatchApprovalResult{ID: id, Result: batchFailed, Reason: handlePullRequestAPIError(err).Error()})
+				continue
+			}
+			results = append(results, cmd.approveOne(ctx, prCtx, pr, body, plan))
+		}
+	}
+
+	if plan != nil {
+		plan.Summary = fmt.Sprintf("Would approve %d of %d pull request(s)", countBatchResults(results, batchApproved), len(results))
+		for _, r := range results {
+			if r.Result != batchApproved {
+				plan.Summary += fmt.Sprintf("; #%d %s: %s", r.ID, r.Result, r.Reason)
+			}
+		}
+		return prCtx.PrintDryRun(plan, cmd.Output)
+	}
+
+	if err := cmd.formatBatchOutput(prCtx, results); err != nil {
+		return err
+	}
+
+	failed := countBatchResults(results, batchFailed)
+	if failed > 0 {
+		return fmt.Errorf("failed to approve %d of %d pull request(s)", failed, len(results))
+	}
+	return nil
+}
+
+// approveOne approves a single pull request, skipping ones that are no longer
+// open. With a dry-run plan it only adds the requests it would send.
+func (cmd *ReviewCmd) approveOne(ctx context.Context, prCtx *PRContext, pr *api.PullRequest, body string, plan *shared.DryRunPlan) BatchApprovalResult {
+	result := BatchApprovalResult{ID: pr.ID, Title: pr.Title, Author: pullRequestAuthorName(pr)}
+
+	if pr.State != "OPEN" {
+		result.Result = batchSkipped
+		result.Reason = fmt.Sprintf("pull request is %s", strings.ToLower(pr.State))
+		return result
+	}
+
+	if plan != nil {
+		addReviewCalls(plan, prCtx, actionApprove, pr.ID, body)
+		result.Result = batchApproved
+		return result
+	}
+
+	if _, err := prCtx.Client.PullRequests.ApprovePullRequest(ctx, prCtx.Workspace, prCtx.Repository, pr.ID); err != nil {
+		result.Result = batchFailed
+		result.Reason = handlePullRequestAPIError(err).Error()
+		return result
+	}
+
+	if body != "" {
+		if _, err := prCtx.Client.PullRequests.AddComment(ctx, prCtx.Workspace, prCtx.Repository, pr.ID, body, nil); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: Failed to add comment to #%d: %v\n", pr.ID, err)
+		}
+	}
+
+	result.Result = batchApproved
+	return result
+}
+
+// findPullRequests lists open pull requests matching the query, following pages
+func (cmd *ReviewCmd) findPullRequests(ctx context.Context, prCtx *PRContext, options *api.PullRequestListOptions) ([]*api.PullRequest, error) {
+	var pullRequests []*api.PullRequest
+	for {
+		result, err := prCtx.Client.PullRequests.ListPullRequests(ctx, prCtx.Workspace, prCtx.Repository, options)
+		if err != nil {
+			return nil, handlePullRequestAPIError(err)
+		}
+
+		page, err := parsePullRequestResults(result)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse pull request results: %w", err)
+		}
+		pullRequests = append(pullRequests, page...)
+
+		if len(pullRequests) > maxBatchApprovals {
+			return nil, fmt.Errorf("--query matches more than %d pull requests; narrow it down", maxBatchApprovals)
+		}
+		if result.Next == "" || len(page) == 0 {
+			break
+		}
+		options.Page++
+	}
+	return pullRequests, nil
+}
+
+// parseApprovalQuery turns "author=x reviewer=y" (space or comma separated)
+// into pull request list options limited to open pull requests. "@me" is
+// resolved to the authenticated user like in pr list.
+func parseApprovalQuery(ctx context.Context, client *api.Client, query string) (*api.PullRequestListOptions, error) {
+	options := &api.PullRequestListOptions{
+		State:   "OPEN",
+		Sort:    "-updated_on",
+		Page:    1,
+		PageLen: 50,
+	}
+
+	fields := strings.FieldsFunc(query, func(r rune) bool { return r == ',' || r == ' ' })
+	if len(fields) == 0 {
+		return nil, fmt.Errorf("--query cannot be empty")
+	}
+
+	for _, field := range fields {
+		key, value, ok := strings.Cut(field, "=")
+		value = strings.TrimSpace(value)
+		if !ok || value == "" {
+			return nil, fmt.Errorf("invalid --query term %q: expected key=value", field)
+		}
+
+		if isMeSelector(value) {
+			username, err := currentUsername(ctx, client)
+			if err != nil {
+				return nil, err
+			}
+			value = username
+		}
+
+		switch strings.ToLower(strings.TrimSpace(key)) {
+		case "author":
+			options.Author = value
+		case "reviewer":
+			options.Reviewer = value
+		default:
+			return nil, fmt.Errorf("unsupported --query key %q: use author or reviewer", key)
+		}
+	}
+
+	return options, nil
+}
+
+func parseBatchIDs(values []string) ([]int, error) {
+	seen := make(map[int]bool)
+	ids := make([]int, 0, len(values))
+	for _, value := range values {
+		id, err := strconv.Atoi(strings.TrimPrefix(value, "#"))
+		if err != nil || id <= 0 {
+			return nil, fmt.Errorf("invalid pull request ID '%s': must be a positive integer", value)
+		}
+		if !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+	return ids, nil
+}
+
+func pullRequestAuthorName(pr *api.PullRequest) string {
+	if pr.Author == nil {
+		return ""
+	}
+	if pr.Author.DisplayName != "" {
+		return pr.Author.DisplayName
+	}
+	return pr.Author.Username
+}
+
+func countBatchResults(results []BatchApprovalResult, outcome string) int {
+	count := 0
+	for _, r := range results {
+		if r.Result == outcome {
+			count++
+		}
+	}
+	return count
+}
+
+func (cmd *ReviewCmd) formatBatchOutput(prCtx *PRContext, results []BatchApprovalResult) error {
+	switch cmd.Output {
+	case "table":
+		if len(results) == 0 {
+			fmt.Println("No open pull requests matched")
+			return nil
+		}
+
+		headers := []string{"ID", "TITLE", "AUTHOR", "RESULT"}
+		rows := make([][]string, 0, len(results))
+		for _, r := range results {
+			status := r.Result
+			if r.Reason != "" {
+				status += ": " + r.Reason
+			}
+			rows = append(rows, []string{fmt.Sprintf("#%d", r.ID), r.Title, r.Author, status})
+		}
+		if err := output.RenderSimpleTable(headers, rows); err != nil {
+			return err
+		}
+
+		fmt.Printf("\n✓ Approved %d of %d pull request(s)", countBatchResults(results, batchApproved), len(results))
+		if skipped := countBatchResults(results, batchSkipped); skipped > 0 {
+			fmt.Printf(", %d skipped", skipped)
+		}
+		if failed := countBatchResults(results, batchFailed); failed > 0 {
+			fmt.Printf(", %d failed", failed)
+		}
+		fmt.Println()
+		return nil
+	case "json", "yaml":
+		return prCtx.Formatter.Format(map[string]interface{}{
+			"action":   "approved",
+			"results":  results,
+			"approved": countBatchResults(results, batchApproved),
+			"skipped":  countBatchResults(results, batchSkipped),
+			"failed":   countBatchResults(results, batchFailed),
+		})
+	default:
+		return fmt.Errorf("unsupported output format: %s", cmd.Output)
+	}
+}
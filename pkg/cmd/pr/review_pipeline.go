@@ -0,0 +1,199 @@
+package pr
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/carlosarraes/bt/pkg/api"
+	"github.com/carlosarraes/bt/pkg/cmd/shared"
+	"github.com/carlosarraes/bt/pkg/utils"
+)
+
+const (
+	// maxSummaryErrors caps the errors quoted per failed step
+	maxSummaryErrors = 5
+	// summaryTailLines are quoted from a failed step whose log has no
+	// recognizable error
+	summaryTailLines = 10
+	// maxSummaryLineLength cuts long log lines in the summary
+	maxSummaryLineLength = 300
+)
+
+// stepFailure is what the review comment quotes of a failed step: the
+// errors found in its log, or its last lines when none were recognized
+type stepFailure struct {
+	name   string
+	errors []utils.ExtractedError
+	tail   []string
+	// logErr is set when the step's log could not be read
+	logErr error
+}
+
+// pipelineFailureSummary composes the failure summary --from-pipeline adds
+// to a review comment. The errors come from the log parser, as in run logs
+// --errors-only, and logs pass through the same redaction as run logs, since
+// the comment is visible to everyone with access to the pull request.
+func pipelineFailureSummary(ctx context.Context, prCtx *PRContext, pipelineID string) (string, error) {
+	pipelineUUID, err := shared.ResolvePipelineUUID(ctx, prCtx, pipelineID)
+	if err != nil {
+		return "", err
+	}
+
+	pipeline, err := prCtx.Client.Pipelines.GetPipeline(ctx, prCtx.Workspace, prCtx.Repository, pipelineUUID)
+	if err != nil {
+		return "", shared.HandleAPIError(err, shared.DomainPipeline)
+	}
+
+	steps, err := prCtx.Client.Pipelines.GetPipelineSteps(ctx, prCtx.Workspace, prCtx.Repository, pipelineUUID)
+	if err != nil {
+		return "", shared.HandleAPIError(err, shared.DomainPipeline)
+	}
+
+	var failedSteps []*api.PipelineStep
+	for _, step := range steps {
+		if isFailedStep(step) {
+			failedSteps = append(failedSteps, step)
+		}
+	}
+	if len(failedSteps) == 0 {
+		return "", fmt.Errorf("pipeline #%d has no failed steps to summarize", pipeline.BuildNumber)
+	}
+
+	redactor, err := shared.NewLogRedactor(ctx, prCtx)
+	if err != nil {
+		return "", err
+	}
+
+	failures := make([]stepFailure, 0, len(failedSteps))
+	for _, step := range failedSteps {
+		failures = append(failures, analyzeFailedStep(ctx, prCtx, redactor, pipelineUUID, step))
+	}
+
+	url := fmt.Sprintf("https://bitbucket.org/%s/%s/addon/pipelines/home#!/results/%d",
+		prCtx.Workspace, prCtx.Repository, pipeline.BuildNumber)
+	return formatFailureSummary(pipeline.BuildNumber, url, failures), nil
+}
+
+// isFailedStep reports whether a step ended in failure
+func isFailedStep(step *api.PipelineStep) bool {
+	if step.State == nil || step.State.Result == nil {
+		return false
+	}
+	return step.State.Result.Name == "FAILED" || step.State.Result.Name == "ERROR"
+}
+
+// analyzeFailedStep reads the log of a failed step and extracts its errors
+func analyzeFailedStep(ctx context.Context, prCtx *PRContext, redactor *utils.Redactor, pipelineUUID string, step *api.PipelineStep) stepFailure {
+	failure := stepFailure{name: step.Name}
+
+	logReader, err := prCtx.Client.Pipelines.GetStepLogs(ctx, prCtx.Workspace, prCtx.Repository, pipelineUUID, step.UUID)
+	if err != nil {
+		failure.logErr = err
+		return failure
+	}
+	defer logReader.Close()
+
+	content, err := io.ReadAll(redactor.Reader(logReader))
+	if err != nil {
+		failure.logErr = err
+		return failure
+	}
+
+	parser := utils.NewLogParser()
+	result, err := parser.AnalyzeLog(bytes.NewReader(content), step.Name)
+	if err != nil {
+		failure.logErr = err
+		return failure
+	}
+
+	failure.errors = parser.FilterErrorsOnly(result).Errors
+	if len(failure.errors) == 0 && strings.TrimSpace(string(content)) != "" {
+		// Without a recognizable error, the end of the log is the best clue
+		lines := strings.Split(strings.TrimRight(string(content), "\n"), "\n")
+		failure.tail = lastLines(lines, summaryTailLines)
+	}
+	return failure
+}
+
+// formatFailureSummary renders the failures of a pipeline as Markdown
+func formatFailureSummary(buildNumber int, url string, failures []stepFailure) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "CI failed in [pipeline #%d](%s):\n", buildNumber, url)
+
+	for _, failure := range failures {
+		fmt.Fprintf(&b, "\n**%s**\n\n", failure.name)
+
+		switch {
+		case failure.logErr != nil:
+			fmt.Fprintf(&b, "_Log not available: %v_\n", failure.logErr)
+			continue
+		case len(failure.errors) == 0 && len(failure.tail) == 0:
+			b.WriteString("_No errors recognized in the log._\n")
+			continue
+		}
+
+		var lines []string
+		if len(failure.errors) == 0 {
+			for _, line := range failure.tail {
+				lines = append(lines, truncateSummaryLine(line))
+			}
+		}
+		for i, e := range failure.errors {
+			if i == maxSummaryErrors {
+				lines = append(lines, fmt.Sprintf("... and %d more", len(failure.errors)-maxSummaryErrors))
+				break
+			}
+			line := truncateSummaryLine(e.Content)
+			if e.Count > 1 {
+				line += fmt.Sprintf(" (×%d)", e.Count)
+			}
+			lines = append(lines, line)
+		}
+
+		fence := codeFence(lines)
+		b.WriteString(fence + "\n")
+		for _, line := range lines {
+			b.WriteString(line + "\n")
+		}
+		b.WriteString(fence + "\n")
+	}
+
+	return strings.TrimRight(b.String(), "\n")
+}
+
+// codeFence returns a fence for a Markdown code block holding lines: three
+// backticks, or one more than the longest run of them in lines, so that log
+// output can't close the block early
+func codeFence(lines []string) string {
+	longest := 0
+	for _, line := range lines {
+		run := 0
+		for _, r := range line {
+			if r != '`' {
+				run = 0
+				continue
+			}
+			run++
+			longest = max(longest, run)
+		}
+	}
+	return strings.Repeat("`", max(3, longest+1))
+}
+
+func truncateSummaryLine(line string) string {
+	runes := []rune(line)
+	if len(runes) <= maxSummaryLineLength {
+		return line
+	}
+	return string(runes[:maxSummaryLineLength]) + "..."
+}
+
+func lastLines(lines []string, n int) []string {
+	if len(lines) <= n {
+		return lines
+	}
+	return lines[len(lines)-n:]
+}
@@ -0,0 +1,112 @@
+package pr
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/carlosarraes/bt/pkg/api/apitest"
+	"github.com/carlosarraes/bt/pkg/cmd/shared"
+	"github.com/carlosarraes/bt/pkg/utils"
+)
+
+func reviewPipelineContext(t *testing.T, steps, log string) *PRContext {
+	t.Helper()
+
+	apitest.CommandEnv(t, "ws", "repo")
+	transport := apitest.NewReplayTransport(
+		apitest.Fixture{Method: "GET", Path: "/repositories/ws/repo/pipelines/{p-1}",
+			Body: []byte(`{"uuid": "{p-1}", "build_number": 42, "state": {"name": "COMPLETED", "result": {"name": "FAILED"}}}`)},
+		apitest.Fixture{Method: "GET", Path: "/repositories/ws/repo/pipelines/{p-1}/steps", Body: []byte(steps)},
+		apitest.Fixture{Method: "GET", Path: "/repositories/ws/repo/pipelines/{p-1}/steps/{s-1}/log", RawBody: log},
+		apitest.Fixture{Method: "GET", Path: "/repositories/ws/repo/pipelines_config/variables",
+			Body: []byte(`{"values": [{"key": "DEPLOY_KEY", "secured": true}]}`)},
+	)
+	t.Cleanup(shared.SetClientTransport(transport))
+
+	prCtx, err := shared.NewCommandContext(context.Background(), "table", true)
+	require.NoError(t, err)
+	return prCtx
+}
+
+func TestPipelineFailureSummary(t *testing.T) {
+	prCtx := reviewPipelineContext(t,
+		`{"values": [{"uuid": "{s-1}", "name": "Run Tests", "state": {"name": "COMPLETED", "result": {"name": "FAILED"}}}, {"uuid": "{s-2}", "name": "Lint", "state": {"name": "COMPLETED", "result": {"name": "SUCCESSFUL"}}}]}`,
+		"go test ./...\nexport DEPLOY_KEY=hunter2hunter2\n--- FAIL: TestLogin (0.01s)\n--- FAIL: TestLogin (0.01s)\nFAIL\n",
+	)
+
+	summary, err := pipelineFailureSummary(context.Background(), prCtx, "{p-1}")
+	require.NoError(t, err)
+
+	assert.True(t, strings.HasPrefix(summary, "CI failed in [pipeline #42](https://bitbucket.org/ws/repo/addon/pipelines/home#!/results/42):\n"), summary)
+	assert.Contains(t, summary, "**Run Tests**")
+	assert.Contains(t, summary, "--- FAIL: TestLogin (0.01s) (×2)")
+	assert.NotContains(t, summary, "Lint")
+	assert.NotContains(t, summary, "hunter2")
+}
+
+func TestPipelineFailureSummary_NoFailedSteps(t *testing.T) {
+	prCtx := reviewPipelineContext(t, `{"values": [{"uuid": "{s-1}", "name": "Lint", "state": {"name": "COMPLETED", "result": {"name": "SUCCESSFUL"}}}]}`, "")
+
+	_, err := pipelineFailureSummary(context.Background(), prCtx, "{p-1}")
+	assert.EqualError(t, err, "pipeline #42 has no failed steps to summarize")
+}
+
+func TestFormatFailureSummary(t *testing.T) {
+	errors := make([]utils.ExtractedError, maxSummaryErrors+2)
+	for i := range errors {
+		errors[i] = utils.ExtractedError{Content: "error: boom", Count: 1}
+	}
+
+	summary := formatFailureSummary(7, "https://example.com/7", []stepFailure{
+		{name: "Build", errors: errors},
+		{name: "Deploy", tail: []string{"uploading", "exit status 3"}},
+		{name: "Smoke", logErr: assert.AnError},
+		{name: "Empty"},
+	})
+
+	assert.Equal(t, `CI failed in [pipeline #7](https://example.com/7):
+
+**Build**
+
+`+"```"+`
+error: boom
+error: boom
+error: boom
+error: boom
+error: boom
+... and 2 more
+`+"```"+`
+
+**Deploy**
+
+`+"```"+`
+uploading
+exit status 3
+`+"```"+`
+
+**Smoke**
+
+_Log not available: `+assert.AnError.Error()+`_
+
+**Empty**
+
+_No errors recognized in the log._`, summary)
+}
+
+func TestReviewCmd_pipelineCommentBody_Approve(t *testing.T) {
+	_, err := (&ReviewCmd{FromPipeline: "42"}).pipelineCommentBody(context.Background(), nil, actionApprove)
+	assert.EqualError(t, err, "--from-pipeline requires --request-changes or --comment")
+}
+
+func TestFormatFailureSummary_FenceOutlastsBackticks(t *testing.T) {
+	summary := formatFailureSummary(7, "https://example.com/7", []stepFailure{
+		{name: "Docs", tail: []string{"```go", "``` unterminated"}},
+	})
+
+	assert.Contains(t, summary, "\n````\n```go\n``` unterminated\n````")
+	assert.Equal(t, "```", codeFence([]string{"plain `code`"}))
+}
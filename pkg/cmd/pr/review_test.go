@@ -1,11 +1,15 @@
 package pr
 
 import (
+	"context"
 	"os"
 	"testing"
 
 	"github.com/carlosarraes/bt/pkg/api"
+	"github.com/carlosarraes/bt/pkg/api/apitest"
+	"github.com/carlosarraes/bt/pkg/cmd/shared"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestReviewCmd_ParsePRID(t *testing.T) {
@@ -397,3 +401,104 @@ func BenchmarkReviewCmd_ValidateReviewAction(b *testing.B) {
 		_, _ = cmd.validateReviewAction()
 	}
 }
+
+func TestReviewCmd_BatchValidation(t *testing.T) {
+	tests := []struct {
+		name    string
+		cmd     ReviewCmd
+		wantErr string
+	}{
+		{"requires approve", ReviewCmd{BatchIDs: []string{"1", "2"}, Comment: true, Force: true}, "can only be used with --approve"},
+		{"requires force", ReviewCmd{BatchIDs: []string{"1", "2"}, Approve: true}, "requires --force"},
+		{"ids and query", ReviewCmd{BatchIDs: []string{"1", "2"}, Query: "author=bot", Approve: true, Force: true}, "cannot combine"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.True(t, tt.cmd.isBatch())
+			err := tt.cmd.runBatchApproval(context.Background(), &PRContext{})
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+
+	assert.False(t, (&ReviewCmd{PRID: "1"}).isBatch())
+}
+
+func TestParseApprovalQuery(t *testing.T) {
+	options, err := parseApprovalQuery(context.Background(), nil, "author=renovate-bot, reviewer=alice")
+	require.NoError(t, err)
+	assert.Equal(t, "OPEN", options.State)
+	assert.Equal(t, "renovate-bot", options.Author)
+	assert.Equal(t, "alice", options.Reviewer)
+
+	for _, query := range []string{"", "author", "author=", "title=bump"} {
+		_, err := parseApprovalQuery(context.Background(), nil, query)
+		assert.Error(t, err, query)
+	}
+
+	_, err = parseApprovalQuery(context.Background(), nil, "author=@me")
+	assert.Error(t, err, "@me needs an authenticated client")
+}
+
+func TestParseBatchIDs(t *testing.T) {
+	ids, err := parseBatchIDs([]string{"12", "#13", "12"})
+	require.NoError(t, err)
+	assert.Equal(t, []int{12, 13}, ids)
+
+	_, err = parseBatchIDs([]string{"12", "abc"})
+	assert.Error(t, err)
+	_, err = parseBatchIDs([]string{"0"})
+	assert.Error(t, err)
+}
+
+func TestCountBatchResults(t *testing.T) {
+	results := []BatchApprovalResult{
+		{ID: 1, Result: batchApproved},
+		{ID: 2, Result: batchSkipped},
+		{ID: 3, Result: batchApproved},
+		{ID: 4, Result: batchFailed},
+	}
+	assert.Equal(t, 2, countBatchResults(results, batchApproved))
+	assert.Equal(t, 1, countBatchResults(results, batchSkipped))
+	assert.Equal(t, 1, countBatchResults(results, batchFailed))
+}
+
+func TestReviewCmd_Run_BatchDryRun(t *testing.T) {
+	apitest.CommandEnv(t, "ws", "repo")
+	transport := apitest.NewReplayTransport(
+		apitest.Fixture{Method: "GET", Path: "/repositories/ws/repo/pullrequests/12", Status: 200,
+			Body: []byte(`{"id": 12, "title": "Open one", "state": "OPEN"}`)},
+		apitest.Fixture{Method: "GET", Path: "/repositories/ws/repo/pullrequests/13", Status: 200,
+			Body: []byte(`{"id": 13, "title": "Merged one", "state": "MERGED"}`)},
+	)
+	t.Cleanup(shared.SetClientTransport(transport))
+
+	// No --force needed: nothing is approved
+	ctx := context.WithValue(context.Background(), "dry-run", true)
+	cmd := &ReviewCmd{BatchIDs: []string{"12", "13"}, Approve: true, Body: "LGTM", Output: "json", NoColor: true}
+	require.NoError(t, cmd.Run(ctx))
+
+	for _, request := range transport.Requests() {
+		assert.Equal(t, "GET", request.Method)
+	}
+}
+
+func TestAddReviewCalls(t *testing.T) {
+	prCtx := &PRContext{Workspace: "ws", Repository: "repo"}
+
+	plan := shared.NewDryRunPlan("approve")
+	addReviewCalls(plan, prCtx, actionApprove, 5, "")
+	require.Len(t, plan.Calls, 1)
+	assert.Equal(t, "repositories/ws/repo/pullrequests/5/approve", plan.Calls[0].Endpoint)
+
+	plan = shared.NewDryRunPlan("approve with comment")
+	addReviewCalls(plan, prCtx, actionApprove, 5, "LGTM")
+	require.Len(t, plan.Calls, 2)
+	assert.Equal(t, "repositories/ws/repo/pullrequests/5/comments", plan.Calls[1].Endpoint)
+
+	plan = shared.NewDryRunPlan("request changes")
+	addReviewCalls(plan, prCtx, actionRequestChanges, 5, "Fix it")
+	require.Len(t, plan.Calls, 1)
+	assert.Equal(t, "repositories/ws/repo/pullrequests/5/request-changes", plan.Calls[0].Endpoint)
+}
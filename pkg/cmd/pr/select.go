@@ -0,0 +1,71 @@
+package pr
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/carlosarraes/bt/pkg/api"
+	"github.com/carlosarraes/bt/pkg/cmd/shared"
+)
+
+// selectPullRequest lets the user pick one of the repository's open pull
+// requests when the PR ID argument was omitted
+func selectPullRequest(ctx context.Context, prCtx *PRContext, output string) (int, error) {
+	if !shared.CanSelect(output) {
+		return 0, fmt.Errorf("pull request ID is required when not running interactively")
+	}
+
+	options := &api.PullRequestListOptions{
+		State:   "OPEN",
+		Sort:    "-updated_on",
+		PageLen: 50,
+		Page:    1,
+	}
+
+	result, err := prCtx.Client.PullRequests.ListPullRequests(ctx, prCtx.Workspace, prCtx.Repository, options)
+	if err != nil {
+		return 0, handlePullRequestAPIError(err)
+	}
+
+	pullRequests, err := parsePullRequestResults(result)
+	if err != nil {
+		return 0, err
+	}
+	if len(pullRequests) == 0 {
+		return 0, fmt.Errorf("no open pull requests in %s/%s", prCtx.Workspace, prCtx.Repository)
+	}
+
+	labels := make([]string, len(pullRequests))
+	for i, pr := range pullRequests {
+		labels[i] = pullRequestOptionLabel(pr)
+	}
+
+	index, err := shared.Select("Select a pull request", "pull request", labels)
+	if err != nil {
+		return 0, err
+	}
+
+	return pullRequests[index].ID, nil
+}
+
+// pullRequestOptionLabel describes pr in the selector with its title, author
+// and branches so any of them can be typed to filter
+func pullRequestOptionLabel(pr *api.PullRequest) string {
+	author := "unknown"
+	if pr.Author != nil {
+		author = pr.Author.DisplayName
+		if author == "" {
+			author = pr.Author.Username
+		}
+	}
+
+	source, destination := "?", "?"
+	if pr.Source != nil && pr.Source.Branch != nil {
+		source = pr.Source.Branch.Name
+	}
+	if pr.Destination != nil && pr.Destination.Branch != nil {
+		destination = pr.Destination.Branch.Name
+	}
+
+	return fmt.Sprintf("#%d  %s  (%s, %s → %s)", pr.ID, pr.Title, author, source, destination)
+}
@@ -0,0 +1,39 @@
+package pr
+
+import (
+	"context"
+	"testing"
+
+	"github.com/carlosarraes/bt/pkg/api"
+)
+
+func TestPullRequestOptionLabel(t *testing.T) {
+	pr := &api.PullRequest{
+		ID:          7,
+		Title:       "Fix login redirect",
+		Author:      &api.User{DisplayName: "Alice Doe", Username: "alice"},
+		Source:      &api.PullRequestBranch{Branch: &api.Branch{Name: "fix/login"}},
+		Destination: &api.PullRequestBranch{Branch: &api.Branch{Name: "main"}},
+	}
+
+	want := "#7  Fix login redirect  (Alice Doe, fix/login → main)"
+	if got := pullRequestOptionLabel(pr); got != want {
+		t.Errorf("pullRequestOptionLabel() = %q, want %q", got, want)
+	}
+
+	pr.Author = &api.User{Username: "alice"}
+	pr.Source, pr.Destination = nil, nil
+	want = "#7  Fix login redirect  (alice, ? → ?)"
+	if got := pullRequestOptionLabel(pr); got != want {
+		t.Errorf("pullRequestOptionLabel() = %q, want %q", got, want)
+	}
+}
+
+func TestSelectPullRequest_NonInteractive(t *testing.T) {
+	for _, output := range []string{"table", "json", "yaml"} {
+		_, err := selectPullRequest(context.Background(), &PRContext{}, output)
+		if err == nil || err.Error() != "pull request ID is required when not running interactively" {
+			t.Errorf("selectPullRequest(%q) error = %v", output, err)
+		}
+	}
+}
@@ -0,0 +1,116 @@
+package pr
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/carlosarraes/bt/pkg/api"
+	"github.com/carlosarraes/bt/pkg/config"
+)
+
+// Pull request size labels, smallest first
+var sizeLabels = []string{"XS", "S", "M", "L", "XL"}
+
+// prSize classifies a pull request by the lines its diff changes
+type prSize struct {
+	Label        string `json:"label" yaml:"label"`
+	LinesChanged int    `json:"lines_changed" yaml:"lines_changed"`
+	LinesAdded   int    `json:"lines_added" yaml:"lines_added"`
+	LinesRemoved int    `json:"lines_removed" yaml:"lines_removed"`
+}
+
+func (s *prSize) String() string {
+	return fmt.Sprintf("%s (+%d -%d)", s.Label, s.LinesAdded, s.LinesRemoved)
+}
+
+// sizeThresholds returns pr.size_thresholds, or the defaults without a config
+func sizeThresholds(prCtx *PRContext) config.SizeThresholds {
+	if prCtx != nil && prCtx.Config != nil {
+		return prCtx.Config.PR.SizeThresholds
+	}
+	return config.DefaultSizeThresholds()
+}
+
+// sizeOf classifies the diffstat of a pull request, or returns nil without one
+func sizeOf(stat *api.PullRequestDiffStat, thresholds config.SizeThresholds) *prSize {
+	if stat == nil {
+		return nil
+	}
+	lines := stat.LinesAdded + stat.LinesRemoved
+	return &prSize{
+		Label:        sizeLabel(lines, thresholds),
+		LinesChanged: lines,
+		LinesAdded:   stat.LinesAdded,
+		LinesRemoved: stat.LinesRemoved,
+	}
+}
+
+// sizeLabel is the size label of a diff changing lines lines
+func sizeLabel(lines int, thresholds config.SizeThresholds) string {
+	for i, limit := range []int{thresholds.XS, thresholds.S, thresholds.M, thresholds.L} {
+		if lines <= limit {
+			return sizeLabels[i]
+		}
+	}
+	return "XL"
+}
+
+// parseMaxSize reads --max-size: a number of changed lines, or a size label
+// standing for the most lines that label allows. XL has no upper bound, so
+// it can't be a limit.
+func parseMaxSize(value string, thresholds config.SizeThresholds) (int, error) {
+	if lines, err := strconv.Atoi(value); err == nil {
+		if lines <= 0 {
+			return 0, fmt.Errorf("invalid --max-size %d: must be positive", lines)
+		}
+		return lines, nil
+	}
+
+	switch strings.ToUpper(value) {
+	case "XS":
+		return thresholds.XS, nil
+	case "S":
+		return thresholds.S, nil
+	case "M":
+		return thresholds.M, nil
+	case "L":
+		return thresholds.L, nil
+	default:
+		return 0, fmt.Errorf("invalid --max-size %q: use a number of changed lines or one of XS, S, M, L", value)
+	}
+}
+
+// maxSizeLimit resolves the line limit pr create warns above: --max-size,
+// else pr.max_size; 0 means no limit
+func (cmd *CreateCmd) maxSizeLimit(prCtx *PRContext) (int, error) {
+	if cmd.MaxSize != "" {
+		return parseMaxSize(cmd.MaxSize, sizeThresholds(prCtx))
+	}
+	if prCtx.Config != nil {
+		return prCtx.Config.PR.MaxSize, nil
+	}
+	return 0, nil
+}
+
+// checkSize sizes the new pull request and warns on stderr when it changes
+// more than limit lines. The pull request already exists, so a size that
+// can't be fetched is only reported with --debug.
+func (cmd *CreateCmd) checkSize(ctx context.Context, prCtx *PRContext, prID, limit int) *prSize {
+	files, err := prCtx.Client.PullRequests.GetPullRequestFiles(ctx, prCtx.Workspace, prCtx.Repository, prID)
+	if err != nil {
+		if cmd.Debug {
+			fmt.Fprintf(os.Stderr, "DEBUG: could not size pull request #%d: %v\n", prID, err)
+		}
+		return nil
+	}
+
+	size := sizeOf(files, sizeThresholds(prCtx))
+	if size.LinesChanged > limit {
+		fmt.Fprintf(os.Stderr, "⚠️  Pull request #%d is size %s: %d lines changed, over the limit of %d. Smaller pull requests are reviewed faster; consider splitting it.\n",
+			prID, size.Label, size.LinesChanged, limit)
+	}
+	return size
+}
@@ -0,0 +1,99 @@
+package pr
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/carlosarraes/bt/pkg/api"
+	"github.com/carlosarraes/bt/pkg/api/apitest"
+	"github.com/carlosarraes/bt/pkg/cmd/shared"
+	"github.com/carlosarraes/bt/pkg/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSizeLabel(t *testing.T) {
+	thresholds := config.DefaultSizeThresholds()
+
+	tests := []struct {
+		lines int
+		want  string
+	}{
+		{0, "XS"},
+		{10, "XS"},
+		{11, "S"},
+		{100, "S"},
+		{500, "M"},
+		{1000, "L"},
+		{1001, "XL"},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, sizeLabel(tt.lines, thresholds), "%d lines", tt.lines)
+	}
+
+	custom := config.SizeThresholds{XS: 5, S: 20, M: 50, L: 200}
+	assert.Equal(t, "M", sizeLabel(30, custom))
+	assert.Equal(t, "XL", sizeLabel(201, custom))
+}
+
+func TestSizeOf(t *testing.T) {
+	assert.Nil(t, sizeOf(nil, config.DefaultSizeThresholds()))
+
+	size := sizeOf(&api.PullRequestDiffStat{LinesAdded: 80, LinesRemoved: 40}, config.DefaultSizeThresholds())
+	assert.Equal(t, &prSize{Label: "M", LinesChanged: 120, LinesAdded: 80, LinesRemoved: 40}, size)
+	assert.Equal(t, "M (+80 -40)", size.String())
+}
+
+func TestParseMaxSize(t *testing.T) {
+	thresholds := config.DefaultSizeThresholds()
+
+	lines, err := parseMaxSize("400", thresholds)
+	require.NoError(t, err)
+	assert.Equal(t, 400, lines)
+
+	lines, err = parseMaxSize("m", thresholds)
+	require.NoError(t, err)
+	assert.Equal(t, thresholds.M, lines)
+
+	for _, value := range []string{"0", "-5", "XL", "huge"} {
+		_, err := parseMaxSize(value, thresholds)
+		assert.Error(t, err, value)
+	}
+}
+
+func TestCreateCmd_maxSizeLimit(t *testing.T) {
+	cfg := config.NewDefaultConfig()
+	cfg.PR.MaxSize = 300
+	prCtx := &PRContext{Config: cfg}
+
+	limit, err := (&CreateCmd{}).maxSizeLimit(prCtx)
+	require.NoError(t, err)
+	assert.Equal(t, 300, limit, "pr.max_size is the default")
+
+	limit, err = (&CreateCmd{MaxSize: "S"}).maxSizeLimit(prCtx)
+	require.NoError(t, err)
+	assert.Equal(t, 100, limit, "--max-size overrides pr.max_size")
+
+	limit, err = (&CreateCmd{}).maxSizeLimit(&PRContext{})
+	require.NoError(t, err)
+	assert.Zero(t, limit)
+}
+
+func TestCreateCmd_checkSize(t *testing.T) {
+	apitest.CommandEnv(t, "ws", "repo")
+	t.Cleanup(shared.SetClientTransport(apitest.NewReplayTransport(apitest.Fixture{
+		Method: "GET",
+		Path:   "/repositories/ws/repo/pullrequests/9/diffstat",
+		Status: 200,
+		Body:   json.RawMessage(`{"values":[{"status":"modified","lines_added":700,"lines_removed":50,"old":{"path":"a.go"},"new":{"path":"a.go"}}]}`),
+	})))
+
+	prCtx, err := shared.NewCommandContext(context.Background(), "table", true)
+	require.NoError(t, err)
+
+	size := (&CreateCmd{}).checkSize(context.Background(), prCtx, 9, 500)
+	require.NotNil(t, size)
+	assert.Equal(t, "L", size.Label)
+	assert.Equal(t, 750, size.LinesChanged)
+}
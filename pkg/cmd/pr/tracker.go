@@ -0,0 +1,132 @@
+package pr
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/carlosarraes/bt/pkg/api"
+	"github.com/carlosarraes/bt/pkg/cmd/shared"
+)
+
+// trackerFields holds the issue tracker metadata requested for a new pull
+// request
+type trackerFields struct {
+	Milestone *api.TrackerField
+	Version   *api.TrackerField
+	Component *api.TrackerField
+}
+
+func (f *trackerFields) apply(request *api.CreatePullRequestRequest) {
+	request.Milestone = f.Milestone
+	request.Version = f.Version
+	request.Component = f.Component
+}
+
+// missing returns the flags of the requested fields that pr doesn't carry
+func (f *trackerFields) missing(pr *api.PullRequest) []string {
+	var flags []string
+	for _, field := range []struct {
+		flag      string
+		requested *api.TrackerField
+		got       *api.TrackerField
+	}{
+		{"--milestone", f.Milestone, pr.Milestone},
+		{"--version", f.Version, pr.Version},
+		{"--component", f.Component, pr.Component},
+	} {
+		if field.requested == nil {
+			continue
+		}
+		if field.got == nil || !strings.EqualFold(field.got.Name, field.requested.Name) {
+			flags = append(flags, fmt.Sprintf("%s %q", field.flag, field.requested.Name))
+		}
+	}
+	return flags
+}
+
+// verifyTrackerFields reads the new pull request back and warns about the
+// requested fields Bitbucket didn't keep; it accepts unknown fields without
+// an error
+func verifyTrackerFields(ctx context.Context, prCtx *PRContext, fields *trackerFields, id int) {
+	if fields.Milestone == nil && fields.Version == nil && fields.Component == nil {
+		return
+	}
+
+	pr, err := prCtx.Client.PullRequests.GetPullRequest(ctx, prCtx.Workspace, prCtx.Repository, id)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "⚠️  Could not check the issue tracker fields of pull request #%d: %v\n", id, err)
+		return
+	}
+
+	if missing := fields.missing(pr); len(missing) > 0 {
+		fmt.Fprintf(os.Stderr, "⚠️  Bitbucket did not set %s on pull request #%d\n", strings.Join(missing, ", "), id)
+	}
+}
+
+// resolveTrackerFields checks --milestone, --version and --component against
+// the values defined in the repository's issue tracker. Repositories without
+// the tracker get no metadata; that is only reported with --verbose.
+func (cmd *CreateCmd) resolveTrackerFields(ctx context.Context, prCtx *PRContext) (*trackerFields, error) {
+	fields := &trackerFields{}
+	if cmd.Milestone == "" && cmd.Version == "" && cmd.Component == "" {
+		return fields, nil
+	}
+
+	repository, err := prCtx.Client.Repositories.GetRepository(ctx, prCtx.Workspace, prCtx.Repository)
+	if err != nil {
+		return nil, shared.HandleAPIError(err, shared.DomainRepository)
+	}
+
+	if !repository.HasIssues {
+		if shared.GetVerbose(ctx) {
+			fmt.Fprintf(os.Stderr, "ℹ️  %s/%s has no issue tracker; ignoring --milestone, --version and --component\n",
+				prCtx.Workspace, prCtx.Repository)
+		}
+		return fields, nil
+	}
+
+	for _, f := range []struct {
+		kind  string
+		flag  string
+		value string
+		field **api.TrackerField
+	}{
+		{"milestones", "--milestone", cmd.Milestone, &fields.Milestone},
+		{"versions", "--version", cmd.Version, &fields.Version},
+		{"components", "--component", cmd.Component, &fields.Component},
+	} {
+		if f.value == "" {
+			continue
+		}
+
+		names, err := prCtx.Client.Repositories.ListTrackerFields(ctx, prCtx.Workspace, prCtx.Repository, f.kind)
+		if err != nil {
+			return nil, err
+		}
+
+		name, err := matchTrackerField(f.flag, f.value, names)
+		if err != nil {
+			return nil, err
+		}
+		*f.field = &api.TrackerField{Name: name}
+	}
+
+	return fields, nil
+}
+
+// matchTrackerField finds value among names case-insensitively and returns
+// the name as the tracker spells it
+func matchTrackerField(flag, value string, names []string) (string, error) {
+	for _, name := range names {
+		if strings.EqualFold(name, value) {
+			return name, nil
+		}
+	}
+
+	if len(names) == 0 {
+		return "", fmt.Errorf("%s %q: the issue tracker defines none", flag, value)
+	}
+	return "", fmt.Errorf("%s %q not found in the issue tracker. Available: %s", flag, value, strings.Join(names, ", "))
+}
@@ -0,0 +1,54 @@
+package pr
+
+import (
+	"context"
+	"testing"
+
+	"github.com/carlosarraes/bt/pkg/api"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMatchTrackerField(t *testing.T) {
+	names := []string{"1.0", "Sprint 12"}
+
+	name, err := matchTrackerField("--milestone", "sprint 12", names)
+	assert.NoError(t, err)
+	assert.Equal(t, "Sprint 12", name)
+
+	_, err = matchTrackerField("--version", "2.0", names)
+	assert.EqualError(t, err, `--version "2.0" not found in the issue tracker. Available: 1.0, Sprint 12`)
+
+	_, err = matchTrackerField("--component", "api", nil)
+	assert.EqualError(t, err, `--component "api": the issue tracker defines none`)
+}
+
+func TestTrackerFields_apply(t *testing.T) {
+	request := &api.CreatePullRequestRequest{Title: "t"}
+	fields := &trackerFields{Version: &api.TrackerField{Name: "1.0"}}
+	fields.apply(request)
+
+	assert.Nil(t, request.Milestone)
+	assert.Equal(t, "1.0", request.Version.Name)
+	assert.Nil(t, request.Component)
+}
+
+func TestCreateCmd_resolveTrackerFields_NoFlags(t *testing.T) {
+	cmd := &CreateCmd{}
+
+	fields, err := cmd.resolveTrackerFields(context.Background(), nil)
+	assert.NoError(t, err)
+	assert.Equal(t, &trackerFields{}, fields)
+}
+
+func TestTrackerFields_missing(t *testing.T) {
+	fields := &trackerFields{
+		Milestone: &api.TrackerField{Name: "Sprint 12"},
+		Version:   &api.TrackerField{Name: "1.0"},
+	}
+
+	pr := &api.PullRequest{Milestone: &api.TrackerField{Name: "Sprint 12"}}
+	assert.Equal(t, []string{`--version "1.0"`}, fields.missing(pr))
+
+	pr.Version = &api.TrackerField{Name: "1.0"}
+	assert.Empty(t, fields.missing(pr))
+}
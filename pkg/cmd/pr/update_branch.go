@@ -2,6 +2,7 @@ package pr
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	"github.com/carlosarraes/bt/pkg/api"
@@ -37,14 +38,7 @@ func (cmd *UpdateBranchCmd) Run(ctx context.Context) error {
 		return err
 	}
 
-	if cmd.Workspace != "" {
-		prCtx.Workspace = cmd.Workspace
-	}
-	if cmd.Repository != "" {
-		prCtx.Repository = cmd.Repository
-	}
-
-	if err := prCtx.ValidateWorkspaceAndRepo(); err != nil {
+	if err := prCtx.ResolveRepository(cmd.Workspace, cmd.Repository); err != nil {
 		return err
 	}
 
@@ -146,7 +140,13 @@ func (cmd *UpdateBranchCmd) updateBranch(ctx context.Context, gitRepo *git.Repos
 		return nil, fmt.Errorf("target branch '%s' does not exist locally", targetBranch)
 	}
 
+	// On a detached HEAD, remember the commit so it can be checked out again
+	var detachedAt string
 	currentBranch, err := gitRepo.GetCurrentBranch()
+	if errors.Is(err, git.ErrDetachedHEAD) {
+		currentBranch = &git.BranchInfo{}
+		detachedAt, err = gitRepo.HeadCommit()
+	}
 	if err != nil {
 		return nil, fmt.Errorf("failed to get current branch: %w", err)
 	}
@@ -198,7 +198,11 @@ func (cmd *UpdateBranchCmd) updateBranch(ctx context.Context, gitRepo *git.Repos
 		result.Message = fmt.Sprintf("Failed to update PR #%d branch '%s' from '%s': %s", prID, sourceBranch, targetBranch, mergeResult.Message)
 	}
 
-	if currentBranch.ShortName != sourceBranch && currentBranch.ShortName != "" {
+	if detachedAt != "" {
+		if restoreErr := gitRepo.CheckoutCommit(detachedAt); restoreErr != nil {
+			result.Message += fmt.Sprintf(" (Warning: failed to restore detached HEAD at %s)", detachedAt[:8])
+		}
+	} else if currentBranch.ShortName != sourceBranch && currentBranch.ShortName != "" {
 		if restoreErr := gitRepo.CheckoutBranch(currentBranch.ShortName, false); restoreErr != nil {
 			result.Message += fmt.Sprintf(" (Warning: failed to restore original branch '%s')", currentBranch.ShortName)
 		}
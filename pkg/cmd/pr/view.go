@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"os"
 	"strconv"
 	"strings"
 
@@ -14,13 +15,22 @@ import (
 
 // ViewCmd handles the pr view command
 type ViewCmd struct {
-	PRID       string `arg:"" help:"Pull request ID (number)"`
-	Web        bool   `help:"Open pull request in browser"`
-	Comments   bool   `help:"Show comments with the pull request"`
-	Output     string `short:"o" help:"Output format (table, json, yaml)" enum:"table,json,yaml" default:"table"`
-	NoColor    bool   // NoColor is passed from global flag
-	Workspace  string `help:"Bitbucket workspace (defaults to git remote or config)"`
-	Repository string `help:"Repository name (defaults to git remote)"`
+	PRID        string `arg:"" optional:"" help:"Pull request ID (number); omit to pick from open pull requests"`
+	Web         bool   `help:"Open pull request in browser"`
+	Comments    bool   `help:"Show comments with the pull request"`
+	Tree        bool   `help:"With --comments, nest replies under their parent and group inline comments by file and line"`
+	Commits     bool   `help:"Show commits with their signature verification status"`
+	AuthorEmail string `name:"author-email" help:"With --commits, only show commits whose author has this email or Bitbucket username"`
+	Committer   string `help:"With --commits, only show commits committed by this name or email"`
+	Checks      bool   `help:"Append a summary of the CI checks: pass/fail counts and failing check names"`
+	Patch       bool   `help:"Print the commits as a mailbox patch series that git am can apply"`
+	Output      string `short:"o" help:"Output format (table, json, yaml)" enum:"table,json,yaml" default:"table"`
+	NoColor     bool   // NoColor is passed from global flag
+	Workspace   string `help:"Bitbucket workspace (defaults to git remote or config)"`
+	Repository  string `help:"Repository name (defaults to git remote)"`
+
+	threads []*api.CommentThread
+	checks  *checksOverview
 }
 
 // Run executes the pr view command
@@ -32,24 +42,38 @@ func (cmd *ViewCmd) Run(ctx context.Context) error {
 	}
 
 	// Override workspace and repository if provided via flags
-	if cmd.Workspace != "" {
-		prCtx.Workspace = cmd.Workspace
-	}
-	if cmd.Repository != "" {
-		prCtx.Repository = cmd.Repository
-	}
+	prCtx.ApplyRepoFlags(cmd.Workspace, cmd.Repository)
 
 	// Validate workspace and repository are available
 	if err := prCtx.ValidateWorkspaceAndRepo(); err != nil {
 		return err
 	}
 
-	// Parse PR ID
-	prID, err := cmd.ParsePRID()
+	// Parse PR ID, or pick one when it was omitted
+	var prID int
+	if cmd.PRID == "" {
+		prID, err = selectPullRequest(ctx, prCtx, cmd.Output)
+	} else {
+		prID, err = cmd.ParsePRID()
+	}
 	if err != nil {
 		return err
 	}
 
+	if cmd.Tree && !cmd.Comments {
+		return fmt.Errorf("--tree requires --comments")
+	}
+	if (cmd.AuthorEmail != "" || cmd.Committer != "") && !cmd.Commits {
+		return fmt.Errorf("--author-email and --committer require --commits")
+	}
+
+	if err := cmd.validatePatchFlags(); err != nil {
+		return err
+	}
+	if cmd.Patch {
+		return cmd.writePatchSeries(ctx, prCtx, prID, os.Stdout)
+	}
+
 	// Handle web flag first - open in browser and exit
 	if cmd.Web {
 		return cmd.openInBrowser(prCtx, prID)
@@ -61,6 +85,16 @@ func (cmd *ViewCmd) Run(ctx context.Context) error {
 		return handlePullRequestAPIError(err)
 	}
 
+	// Checks hang off the source commit, so they can only start once the
+	// PR is known; they load while the remaining details are fetched
+	var checksCh chan *checksOverview
+	if cmd.Checks {
+		checksCh = make(chan *checksOverview, 1)
+		go func() {
+			checksCh <- loadChecksOverview(ctx, prCtx, pr)
+		}()
+	}
+
 	// Fetch additional data if needed
 	var files *api.PullRequestDiffStat
 	var comments *api.PaginatedResponse
@@ -81,8 +115,33 @@ func (cmd *ViewCmd) Run(ctx context.Context) error {
 		}
 	}
 
+	// Threads need every comment, since a reply may be on a later page than its parent
+	if cmd.Tree {
+		allComments, err := prCtx.Client.PullRequests.GetAllComments(ctx, prCtx.Workspace, prCtx.Repository, prID)
+		if err != nil {
+			return handlePullRequestAPIError(err)
+		}
+		cmd.threads = api.ThreadComments(allComments)
+	}
+
+	var commits []shared.CommitSummary
+	if cmd.Commits {
+		apiCommits, err := prCtx.Client.PullRequests.GetPullRequestCommits(ctx, prCtx.Workspace, prCtx.Repository, prID)
+		if err != nil {
+			return handlePullRequestAPIError(err)
+		}
+		commits = shared.FilterCommits(shared.SummarizeCommits(apiCommits, prCtx.Workspace, prCtx.Repository), shared.CommitFilter{
+			AuthorEmail: cmd.AuthorEmail,
+			Committer:   cmd.Committer,
+		})
+	}
+
+	if checksCh != nil {
+		cmd.checks = <-checksCh
+	}
+
 	// Format and display output
-	return cmd.formatOutput(prCtx, pr, files, comments)
+	return cmd.formatOutput(prCtx, pr, files, comments, commits)
 }
 
 // parsePRID parses the PR ID argument
@@ -120,24 +179,38 @@ func (cmd *ViewCmd) openInBrowser(prCtx *PRContext, prID int) error {
 }
 
 // formatOutput formats and displays the PR details
-func (cmd *ViewCmd) formatOutput(prCtx *PRContext, pr *api.PullRequest, files *api.PullRequestDiffStat, comments *api.PaginatedResponse) error {
+func (cmd *ViewCmd) formatOutput(prCtx *PRContext, pr *api.PullRequest, files *api.PullRequestDiffStat, comments *api.PaginatedResponse, commits []shared.CommitSummary) error {
 	switch cmd.Output {
 	case "table":
-		return cmd.formatTable(prCtx, pr, files, comments)
+		return cmd.formatTable(prCtx, pr, files, comments, commits)
 	case "json":
-		return cmd.formatJSON(prCtx, pr, files, comments)
+		return cmd.formatJSON(prCtx, pr, files, comments, commits)
 	case "yaml":
-		return cmd.formatYAML(prCtx, pr, files, comments)
+		return cmd.formatYAML(prCtx, pr, files, comments, commits)
 	default:
 		return fmt.Errorf("unsupported output format: %s", cmd.Output)
 	}
 }
 
 // formatTable formats PR details as a human-readable table
-func (cmd *ViewCmd) formatTable(prCtx *PRContext, pr *api.PullRequest, files *api.PullRequestDiffStat, comments *api.PaginatedResponse) error {
+func (cmd *ViewCmd) formatTable(prCtx *PRContext, pr *api.PullRequest, files *api.PullRequestDiffStat, comments *api.PaginatedResponse, commits []shared.CommitSummary) error {
 	// PR Header
 	fmt.Printf("#%d • %s\n", pr.ID, pr.Title)
 	fmt.Printf("State: %s\n", pr.State)
+	if reason := declineReason(pr); reason != "" {
+		declinedBy := ""
+		if pr.ClosedBy != nil {
+			declinedBy = pr.ClosedBy.DisplayName
+			if declinedBy == "" {
+				declinedBy = pr.ClosedBy.Username
+			}
+		}
+		if declinedBy != "" {
+			fmt.Printf("Declined by %s: %s\n", declinedBy, reason)
+		} else {
+			fmt.Printf("Declined: %s\n", reason)
+		}
+	}
 
 	// Author information
 	if pr.Author != nil {
@@ -204,6 +277,7 @@ func (cmd *ViewCmd) formatTable(prCtx *PRContext, pr *api.PullRequest, files *ap
 		if files.LinesAdded > 0 || files.LinesRemoved > 0 {
 			fmt.Printf("Lines: +%d -%d\n", files.LinesAdded, files.LinesRemoved)
 		}
+		fmt.Printf("Size: %s\n", sizeOf(files, sizeThresholds(prCtx)).Label)
 	}
 
 	// Comments count
@@ -212,8 +286,22 @@ func (cmd *ViewCmd) formatTable(prCtx *PRContext, pr *api.PullRequest, files *ap
 		fmt.Printf("\nComments: %d\n", commentCount)
 	}
 
+	if cmd.checks != nil {
+		cmd.checks.write(os.Stdout)
+	}
+
+	// Show commits if requested
+	if cmd.Commits {
+		fmt.Printf("\nCommits: %d\n", len(commits))
+		if err := shared.RenderCommitTable(commits); err != nil {
+			return fmt.Errorf("failed to display commits: %w", err)
+		}
+	}
+
 	// Show comments if requested
-	if cmd.Comments && comments != nil {
+	if cmd.Tree {
+		renderCommentTree(os.Stdout, cmd.threads)
+	} else if cmd.Comments && comments != nil {
 		if err := cmd.displayComments(comments); err != nil {
 			return fmt.Errorf("failed to display comments: %w", err)
 		}
@@ -283,7 +371,7 @@ func (cmd *ViewCmd) displayComments(comments *api.PaginatedResponse) error {
 }
 
 // formatJSON formats PR details as JSON
-func (cmd *ViewCmd) formatJSON(prCtx *PRContext, pr *api.PullRequest, files *api.PullRequestDiffStat, comments *api.PaginatedResponse) error {
+func (cmd *ViewCmd) formatJSON(prCtx *PRContext, pr *api.PullRequest, files *api.PullRequestDiffStat, comments *api.PaginatedResponse, commits []shared.CommitSummary) error {
 	// Create a comprehensive output structure
 	output := map[string]interface{}{
 		"pull_request": pr,
@@ -291,6 +379,11 @@ func (cmd *ViewCmd) formatJSON(prCtx *PRContext, pr *api.PullRequest, files *api
 
 	if files != nil {
 		output["files"] = files
+		output["size"] = sizeOf(files, sizeThresholds(prCtx))
+	}
+
+	if commits != nil {
+		output["commits"] = commits
 	}
 
 	if comments != nil {
@@ -310,11 +403,19 @@ func (cmd *ViewCmd) formatJSON(prCtx *PRContext, pr *api.PullRequest, files *api
 		}
 	}
 
+	if cmd.Tree {
+		output["comment_threads"] = cmd.threads
+	}
+
+	if cmd.checks != nil {
+		output["checks"] = cmd.checks
+	}
+
 	return prCtx.Formatter.Format(output)
 }
 
 // formatYAML formats PR details as YAML
-func (cmd *ViewCmd) formatYAML(prCtx *PRContext, pr *api.PullRequest, files *api.PullRequestDiffStat, comments *api.PaginatedResponse) error {
+func (cmd *ViewCmd) formatYAML(prCtx *PRContext, pr *api.PullRequest, files *api.PullRequestDiffStat, comments *api.PaginatedResponse, commits []shared.CommitSummary) error {
 	// Create a comprehensive output structure (same as JSON)
 	output := map[string]interface{}{
 		"pull_request": pr,
@@ -322,6 +423,11 @@ func (cmd *ViewCmd) formatYAML(prCtx *PRContext, pr *api.PullRequest, files *api
 
 	if files != nil {
 		output["files"] = files
+		output["size"] = sizeOf(files, sizeThresholds(prCtx))
+	}
+
+	if commits != nil {
+		output["commits"] = commits
 	}
 
 	if comments != nil {
@@ -341,5 +447,13 @@ func (cmd *ViewCmd) formatYAML(prCtx *PRContext, pr *api.PullRequest, files *api
 		}
 	}
 
+	if cmd.Tree {
+		output["comment_threads"] = cmd.threads
+	}
+
+	if cmd.checks != nil {
+		output["checks"] = cmd.checks
+	}
+
 	return prCtx.Formatter.Format(output)
 }
@@ -0,0 +1,88 @@
+package pr
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/carlosarraes/bt/pkg/api"
+)
+
+// checksOverview is the compact checks section of pr view --checks
+type checksOverview struct {
+	Successful int      `json:"successful" yaml:"successful"`
+	Failed     int      `json:"failed" yaml:"failed"`
+	Running    int      `json:"running" yaml:"running"`
+	Failing    []string `json:"failing,omitempty" yaml:"failing,omitempty"`
+	Error      string   `json:"error,omitempty" yaml:"error,omitempty"`
+}
+
+// loadChecksOverview fetches the checks of pr the way pr checks does and sums
+// them up. A failure is reported in the overview rather than failing the view.
+func loadChecksOverview(ctx context.Context, prCtx *PRContext, pr *api.PullRequest) *checksOverview {
+	checker := &ChecksCmd{PRID: strconv.Itoa(pr.ID)}
+	pipelines, err := checker.checksForPullRequest(ctx, prCtx, pr)
+	if err != nil {
+		return &checksOverview{Error: err.Error()}
+	}
+	return summarizeChecks(checker, pipelines)
+}
+
+func summarizeChecks(checker *ChecksCmd, pipelines []*api.Pipeline) *checksOverview {
+	counts := checker.countChecks(pipelines)
+	overview := &checksOverview{
+		Successful: counts.successful,
+		Failed:     counts.failed,
+		Running:    counts.running,
+	}
+
+	for _, pipeline := range pipelines {
+		if checker.getPipelinePriority(pipeline) == 0 {
+			overview.Failing = append(overview.Failing, checker.getPipelineName(pipeline))
+		}
+	}
+	for _, status := range checker.statuses {
+		if status.State != "FAILED" {
+			continue
+		}
+		name := status.Name
+		if name == "" {
+			name = status.Key
+		}
+		overview.Failing = append(overview.Failing, name)
+	}
+
+	return overview
+}
+
+// summary is the one-line tally, e.g. "3 successful, 1 failed"
+func (o *checksOverview) summary() string {
+	var parts []string
+	if o.Successful > 0 {
+		parts = append(parts, fmt.Sprintf("%d successful", o.Successful))
+	}
+	if o.Failed > 0 {
+		parts = append(parts, fmt.Sprintf("%d failed", o.Failed))
+	}
+	if o.Running > 0 {
+		parts = append(parts, fmt.Sprintf("%d running", o.Running))
+	}
+	if len(parts) == 0 {
+		return "none reported"
+	}
+	return strings.Join(parts, ", ")
+}
+
+func (o *checksOverview) write(w io.Writer) {
+	if o.Error != "" {
+		fmt.Fprintf(w, "\nChecks: unavailable (%s)\n", o.Error)
+		return
+	}
+
+	fmt.Fprintf(w, "\nChecks: %s\n", o.summary())
+	for _, name := range o.Failing {
+		fmt.Fprintf(w, "  ✗ %s\n", name)
+	}
+}
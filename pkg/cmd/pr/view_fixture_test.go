@@ -0,0 +1,84 @@
+package pr
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/carlosarraes/bt/pkg/api/apitest"
+	"github.com/carlosarraes/bt/pkg/cmd/shared"
+)
+
+// runWithFixtures runs fn with the command layer talking to the recorded
+// responses in testdata/fixtures/<name> and returns what it printed
+func runWithFixtures(t *testing.T, name string, fn func() error) string {
+	t.Helper()
+
+	apitest.CommandEnv(t, "ws", "repo")
+	t.Cleanup(shared.SetClientTransport(apitest.Transport(t, "testdata/fixtures/"+name)))
+
+	stdout := os.Stdout
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	os.Stdout = w
+
+	runErr := fn()
+
+	w.Close()
+	os.Stdout = stdout
+	var out bytes.Buffer
+	io.Copy(&out, r)
+
+	if runErr != nil {
+		t.Fatalf("command failed: %v\n%s", runErr, out.String())
+	}
+	return out.String()
+}
+
+func TestViewCmd_Run_Fixtures(t *testing.T) {
+	out := runWithFixtures(t, "view", func() error {
+		cmd := &ViewCmd{PRID: "7", Output: "table", NoColor: true}
+		return cmd.Run(context.Background())
+	})
+
+	for _, want := range []string{
+		"#7 • Fix login redirect",
+		"State: OPEN",
+		"Author: Alice Doe",
+		"fix/login → main",
+		"Send users back to the page they came from.",
+		"Lines: +12 -3",
+		"Size: S",
+		"Comments: 1",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestViewCmd_Run_Checks(t *testing.T) {
+	out := runWithFixtures(t, "view_checks", func() error {
+		cmd := &ViewCmd{PRID: "7", Checks: true, Output: "table", NoColor: true}
+		return cmd.Run(context.Background())
+	})
+
+	for _, want := range []string{
+		"#7 • Fix login redirect",
+		"Checks: 1 successful, 2 failed",
+		"✗ Pipeline #12 (fix/login)",
+		"✗ SonarCloud",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "Pipeline #11") {
+		t.Errorf("passing pipelines should not be listed:\n%s", out)
+	}
+}
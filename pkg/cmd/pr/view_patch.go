@@ -0,0 +1,140 @@
+package pr
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/carlosarraes/bt/pkg/api"
+	"github.com/carlosarraes/bt/pkg/git"
+)
+
+// mboxFromLine starts every message of a mailbox; git format-patch uses the
+// same fixed date
+const mboxFromLine = "From %s Mon Sep 17 00:00:00 2001\n"
+
+// validatePatchFlags rejects --patch with flags that add to the normal view
+func (cmd *ViewCmd) validatePatchFlags() error {
+	if !cmd.Patch {
+		return nil
+	}
+	if cmd.Web || cmd.Comments || cmd.Commits || cmd.Checks {
+		return fmt.Errorf("--patch cannot be combined with --web, --comments, --commits or --checks")
+	}
+	if cmd.Output != "" && cmd.Output != "table" {
+		return fmt.Errorf("--patch writes a mailbox and cannot be combined with --output %s", cmd.Output)
+	}
+	return nil
+}
+
+// writePatchSeries prints the pull request's commits as a mailbox of
+// patches, oldest first, that git am can apply. Merge commits are skipped,
+// as git format-patch does.
+func (cmd *ViewCmd) writePatchSeries(ctx context.Context, prCtx *PRContext, prID int, w io.Writer) error {
+	commits, err := prCtx.Client.PullRequests.GetPullRequestCommits(ctx, prCtx.Workspace, prCtx.Repository, prID)
+	if err != nil {
+		return handlePullRequestAPIError(err)
+	}
+
+	// The API lists the newest commit first
+	var series []api.Commit
+	for i := len(commits) - 1; i >= 0; i-- {
+		if len(commits[i].Parents) > 1 {
+			continue
+		}
+		series = append(series, commits[i])
+	}
+	if len(series) == 0 {
+		return fmt.Errorf("pull request #%d has no commits to export", prID)
+	}
+
+	for i, commit := range series {
+		diff, err := prCtx.Client.Repositories.GetCommitDiff(ctx, prCtx.Workspace, prCtx.Repository, commit.Hash)
+		if err != nil {
+			return fmt.Errorf("failed to get the diff of commit %s: %w", shortHash(commit.Hash), err)
+		}
+		writeMailboxPatch(w, &commit, diff, i+1, len(series))
+	}
+	return nil
+}
+
+// writeMailboxPatch writes one commit in the format of git format-patch
+func writeMailboxPatch(w io.Writer, commit *api.Commit, diff string, n, total int) {
+	subject, body, _ := strings.Cut(strings.TrimSpace(commit.Message), "\n")
+
+	fmt.Fprintf(w, mboxFromLine, commit.Hash)
+	fmt.Fprintf(w, "From: %s\n", patchAuthor(commit))
+	if commit.Date != nil {
+		fmt.Fprintf(w, "Date: %s\n", commit.Date.Format(time.RFC1123Z))
+	}
+	if total > 1 {
+		fmt.Fprintf(w, "Subject: [PATCH %d/%d] %s\n", n, total, strings.TrimSpace(subject))
+	} else {
+		fmt.Fprintf(w, "Subject: [PATCH] %s\n", strings.TrimSpace(subject))
+	}
+	fmt.Fprintln(w)
+
+	if body = strings.TrimSpace(body); body != "" {
+		for _, line := range strings.Split(body, "\n") {
+			fmt.Fprintln(w, escapeMboxLine(escapePatchBreak(line)))
+		}
+		fmt.Fprintln(w)
+	}
+	fmt.Fprintln(w, "---")
+	for _, line := range strings.SplitAfter(diff, "\n") {
+		fmt.Fprint(w, escapeMboxLine(line))
+	}
+	if !strings.HasSuffix(diff, "\n") {
+		fmt.Fprintln(w)
+	}
+	fmt.Fprint(w, "-- \nbt\n\n")
+}
+
+// mboxFromPattern matches the lines mboxrd escapes: "From " behind any
+// number of ">"
+var mboxFromPattern = regexp.MustCompile(`^>*From `)
+
+// escapeMboxLine quotes a "From " line with ">" as git format-patch
+// --pretty=mboxrd does, so that it isn't read as the start of the next
+// message; git am --patch-format=mboxrd takes the ">" off again
+func escapeMboxLine(line string) string {
+	if mboxFromPattern.MatchString(line) {
+		return ">" + line
+	}
+	return line
+}
+
+// escapePatchBreak indents a commit message line git am would take for the
+// start of the diff ("---", "--- file", "diff -", "Index: "), which would
+// cut the message short there
+func escapePatchBreak(line string) string {
+	if strings.HasPrefix(line, "diff -") || strings.HasPrefix(line, "Index: ") {
+		return " " + line
+	}
+	if rest, ok := strings.CutPrefix(line, "---"); ok && (strings.TrimSpace(rest) == "" || strings.HasPrefix(rest, " ")) {
+		return " " + line
+	}
+	return line
+}
+
+// patchAuthor is the From header of a commit: its raw git identity, with
+// the mapped account's name standing in for a missing one
+func patchAuthor(commit *api.Commit) string {
+	var author git.Identity
+	if commit.Author != nil {
+		author = git.ParseIdentity(commit.Author.Raw)
+		if author.Name == "" && commit.Author.User != nil {
+			author.Name = commit.Author.User.DisplayName
+		}
+	}
+	if author.Name == "" {
+		author.Name = "Unknown"
+	}
+	if author.Email == "" {
+		author.Email = "unknown"
+	}
+	return author.String()
+}
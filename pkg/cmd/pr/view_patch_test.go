@@ -0,0 +1,127 @@
+package pr
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/carlosarraes/bt/pkg/api"
+	"github.com/carlosarraes/bt/pkg/api/apitest"
+	"github.com/carlosarraes/bt/pkg/cmd/shared"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	patchDiffA = "diff --git a/greeting.txt b/greeting.txt\nnew file mode 100644\nindex 0000000..ce01362\n--- /dev/null\n+++ b/greeting.txt\n@@ -0,0 +1 @@\n+hello\n"
+	patchDiffB = "diff --git a/greeting.txt b/greeting.txt\nindex ce01362..94954ab 100644\n--- a/greeting.txt\n+++ b/greeting.txt\n@@ -1 +1,2 @@\n hello\n+world\n"
+)
+
+func TestViewCmd_writePatchSeries(t *testing.T) {
+	apitest.CommandEnv(t, "ws", "repo")
+	transport := apitest.NewReplayTransport(
+		apitest.Fixture{Method: "GET", Path: "/repositories/ws/repo/pullrequests/7/commits", Status: 200,
+			Body: []byte(`{"values": [
+				{"hash": "ccc", "message": "Merge branch 'main'", "parents": [{"hash": "bbb"}, {"hash": "zzz"}]},
+				{"hash": "bbb", "message": "Say world\n\nGreet everyone.", "date": "2024-03-02T10:00:00+00:00",
+				 "author": {"raw": "Alice Doe <alice@example.com>"}, "parents": [{"hash": "aaa"}]},
+				{"hash": "aaa", "message": "Add greeting", "date": "2024-03-01T10:00:00+00:00",
+				 "author": {"raw": "Alice Doe <alice@example.com>"}, "parents": [{"hash": "000"}]}
+			]}`)},
+		apitest.Fixture{Method: "GET", Path: "/repositories/ws/repo/diff/aaa", Status: 200, RawBody: patchDiffA},
+		apitest.Fixture{Method: "GET", Path: "/repositories/ws/repo/diff/bbb", Status: 200, RawBody: patchDiffB},
+	)
+	t.Cleanup(shared.SetClientTransport(transport))
+
+	prCtx, err := shared.NewCommandContext(context.Background(), "table", true)
+	require.NoError(t, err)
+
+	var mbox bytes.Buffer
+	require.NoError(t, (&ViewCmd{Patch: true}).writePatchSeries(context.Background(), prCtx, 7, &mbox))
+
+	out := mbox.String()
+	assert.True(t, strings.HasPrefix(out, "From aaa Mon Sep 17 00:00:00 2001\nFrom: Alice Doe <alice@example.com>\nDate: Fri, 01 Mar 2024 10:00:00 +0000\nSubject: [PATCH 1/2] Add greeting\n"), out)
+	assert.Contains(t, out, "Subject: [PATCH 2/2] Say world\n\nGreet everyone.\n\n---\n")
+	assert.NotContains(t, out, "Merge branch")
+
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+	repoDir := t.TempDir()
+	for _, args := range [][]string{
+		{"init", "-q"},
+		{"config", "user.email", "test@example.com"},
+		{"config", "user.name", "Test"},
+		{"commit", "-q", "--allow-empty", "-m", "root"},
+	} {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = repoDir
+		require.NoError(t, cmd.Run(), "git %v", args)
+	}
+
+	am := exec.Command("git", "am", "-q")
+	am.Dir = repoDir
+	am.Stdin = strings.NewReader(out)
+	output, err := am.CombinedOutput()
+	require.NoError(t, err, string(output))
+
+	content, err := os.ReadFile(filepath.Join(repoDir, "greeting.txt"))
+	require.NoError(t, err)
+	assert.Equal(t, "hello\nworld\n", string(content))
+
+	log := exec.Command("git", "log", "--format=%an|%s|%b", "-1")
+	log.Dir = repoDir
+	last, err := log.Output()
+	require.NoError(t, err)
+	assert.Equal(t, "Alice Doe|Say world|Greet everyone.", strings.TrimSpace(string(last)))
+}
+
+func TestWriteMailboxPatch_EscapesBody(t *testing.T) {
+	commit := &api.Commit{
+		Hash:    "aaa",
+		Message: "Add greeting\n\nFrom the docs: say hello.\n>From a quote\n---\nStill the message.",
+		Author:  &api.CommitAuthor{Raw: "Alice Doe <alice@example.com>"},
+	}
+
+	var mbox bytes.Buffer
+	writeMailboxPatch(&mbox, commit, patchDiffA, 1, 1)
+	out := mbox.String()
+	assert.Contains(t, out, "\n>From the docs: say hello.\n>>From a quote\n ---\nStill the message.\n\n---\n")
+
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+	repoDir := t.TempDir()
+	for _, args := range [][]string{
+		{"init", "-q"},
+		{"config", "user.email", "test@example.com"},
+		{"config", "user.name", "Test"},
+		{"commit", "-q", "--allow-empty", "-m", "root"},
+	} {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = repoDir
+		require.NoError(t, cmd.Run(), "git %v", args)
+	}
+
+	am := exec.Command("git", "am", "-q", "--patch-format=mboxrd")
+	am.Dir = repoDir
+	am.Stdin = strings.NewReader(out)
+	output, err := am.CombinedOutput()
+	require.NoError(t, err, string(output))
+
+	log := exec.Command("git", "log", "--format=%b", "-1")
+	log.Dir = repoDir
+	body, err := log.Output()
+	require.NoError(t, err)
+	assert.Equal(t, "From the docs: say hello.\n>From a quote\n ---\nStill the message.", strings.TrimSpace(string(body)))
+}
+
+func TestViewCmd_validatePatchFlags(t *testing.T) {
+	assert.NoError(t, (&ViewCmd{Patch: true, Output: "table"}).validatePatchFlags())
+	assert.Error(t, (&ViewCmd{Patch: true, Comments: true}).validatePatchFlags())
+	assert.Error(t, (&ViewCmd{Patch: true, Output: "json"}).validatePatchFlags())
+}
@@ -1,11 +1,17 @@
 package pr
 
 import (
+	"bytes"
+	"encoding/json"
 	"testing"
 	"time"
 
 	"github.com/carlosarraes/bt/pkg/api"
+	"github.com/carlosarraes/bt/pkg/cmd/shared"
+	"github.com/carlosarraes/bt/pkg/output"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
 )
 
 func TestViewCmd_ParsePRID(t *testing.T) {
@@ -244,7 +250,7 @@ func TestViewCmd_FormatOutput(t *testing.T) {
 			prCtx := &PRContext{}
 
 			// Test table format directly since it doesn't use formatter
-			err := cmd.formatTable(prCtx, tt.pr, tt.files, nil)
+			err := cmd.formatTable(prCtx, tt.pr, tt.files, nil, nil)
 			assert.NoError(t, err)
 		})
 	}
@@ -318,7 +324,7 @@ func TestViewCmd_FormatTable_EdgeCases(t *testing.T) {
 
 			// This test ensures the table formatting handles edge cases gracefully
 			// In a real test, we'd capture and validate the output
-			err := cmd.formatTable(&PRContext{}, tt.pr, nil, nil)
+			err := cmd.formatTable(&PRContext{}, tt.pr, nil, nil, nil)
 
 			// We expect no panics or crashes, even with minimal data
 			// The function should handle nil values gracefully
@@ -460,7 +466,7 @@ func TestViewCmd_UnsupportedOutputFormat(t *testing.T) {
 	cmd := &ViewCmd{Output: "unsupported"}
 	pr := &api.PullRequest{ID: 123, Title: "Test", State: "OPEN"}
 
-	err := cmd.formatOutput(&PRContext{}, pr, nil, nil)
+	err := cmd.formatOutput(&PRContext{}, pr, nil, nil, nil)
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "unsupported output format: unsupported")
 }
@@ -560,3 +566,93 @@ func TestViewCmd_ErrorHandling(t *testing.T) {
 		})
 	}
 }
+
+// renderAs runs render against a context whose formatter writes format to a buffer
+func renderAs(t *testing.T, format string, render func(*PRContext) error) []byte {
+	t.Helper()
+	buf := &bytes.Buffer{}
+	formatter, err := output.NewFormatter(output.Format(format), &output.FormatterOptions{Writer: buf})
+	require.NoError(t, err)
+	require.NoError(t, render(&PRContext{Formatter: formatter}))
+	return buf.Bytes()
+}
+
+// assertYAMLMatchesJSON checks that the YAML output decodes to the same
+// document as the JSON output
+func assertYAMLMatchesJSON(t *testing.T, render func(*PRContext) error) {
+	t.Helper()
+
+	var fromJSON, fromYAML interface{}
+	require.NoError(t, json.Unmarshal(renderAs(t, "json", render), &fromJSON))
+	require.NoError(t, yaml.Unmarshal(renderAs(t, "yaml", render), &fromYAML))
+
+	// Compare through JSON so YAML ints and JSON float64s line up
+	wantJSON, err := json.Marshal(fromJSON)
+	require.NoError(t, err)
+	gotJSON, err := json.Marshal(fromYAML)
+	require.NoError(t, err)
+	assert.JSONEq(t, string(wantJSON), string(gotJSON))
+}
+
+func TestViewCmd_formatYAML_RoundTrip(t *testing.T) {
+	created := time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC)
+	pr := &api.PullRequest{
+		ID:          12,
+		Title:       "Fix: handle null",
+		Description: "Line one\nLine two  \n- not a list",
+		State:       "OPEN",
+		Author:      &api.User{DisplayName: "Alice", Username: "alice"},
+		Source:      &api.PullRequestBranch{Branch: &api.Branch{Name: "feature"}},
+		CreatedOn:   &created,
+		UpdatedOn:   &created,
+	}
+	files := &api.PullRequestDiffStat{LinesAdded: 10, LinesRemoved: 2, FilesChanged: 1}
+	comments := &api.PaginatedResponse{
+		Size:   1,
+		Values: json.RawMessage(`[{"id": 1, "content": {"raw": "on"}, "created_on": "2024-03-01T11:00:00Z"}]`),
+	}
+	commits := []shared.CommitSummary{{Hash: "abc123", Message: "fix", Author: "Alice", Date: &created}}
+
+	cmd := &ViewCmd{}
+	assertYAMLMatchesJSON(t, func(prCtx *PRContext) error {
+		return cmd.formatYAML(prCtx, pr, files, comments, commits)
+	})
+
+	out := string(renderAs(t, "yaml", func(prCtx *PRContext) error {
+		return cmd.formatYAML(prCtx, pr, files, comments, commits)
+	}))
+	assert.Contains(t, out, `created_on: "2024-03-01T10:00:00Z"`)
+	assert.Contains(t, out, "lines_added: 10")
+	assert.NotContains(t, out, "createdon")
+}
+
+func TestChecksOverview_write(t *testing.T) {
+	var buf bytes.Buffer
+	(&checksOverview{}).write(&buf)
+	assert.Equal(t, "\nChecks: none reported\n", buf.String())
+
+	buf.Reset()
+	(&checksOverview{Successful: 2, Running: 1}).write(&buf)
+	assert.Equal(t, "\nChecks: 2 successful, 1 running\n", buf.String())
+
+	buf.Reset()
+	(&checksOverview{Error: "unable to find commit SHA for pull request #7"}).write(&buf)
+	assert.Contains(t, buf.String(), "Checks: unavailable (unable to find commit SHA")
+}
+
+func TestSummarizeChecks(t *testing.T) {
+	checker := &ChecksCmd{statuses: []*api.CommitStatus{
+		{Key: "lint", State: "FAILED"},
+		{Name: "SonarCloud", State: "SUCCESSFUL"},
+	}}
+	pipelines := []*api.Pipeline{
+		{BuildNumber: 3, State: &api.PipelineState{Name: "FAILED"}},
+		{BuildNumber: 4, State: &api.PipelineState{Name: "IN_PROGRESS"}},
+	}
+
+	overview := summarizeChecks(checker, pipelines)
+	assert.Equal(t, 1, overview.Successful)
+	assert.Equal(t, 2, overview.Failed)
+	assert.Equal(t, 1, overview.Running)
+	assert.Equal(t, []string{"Pipeline #3", "lint"}, overview.Failing)
+}
@@ -0,0 +1,352 @@
+package repo
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/carlosarraes/bt/pkg/api"
+	"github.com/carlosarraes/bt/pkg/cmd/shared"
+	"github.com/carlosarraes/bt/pkg/git"
+)
+
+// changelogMaxPages bounds how many pages of merged pull requests are read
+const changelogMaxPages = 20
+
+// ChangelogCmd handles the repo changelog command
+type ChangelogCmd struct {
+	Since      string
+	Until      string
+	Output     string
+	NoColor    bool
+	Workspace  string
+	Repository string
+}
+
+// Changelog is the structured output of repo changelog
+type Changelog struct {
+	Since    string             `json:"since" yaml:"since"`
+	From     time.Time          `json:"from" yaml:"from"`
+	Until    string             `json:"until,omitempty" yaml:"until,omitempty"`
+	To       *time.Time         `json:"to,omitempty" yaml:"to,omitempty"`
+	Total    int                `json:"total" yaml:"total"`
+	Sections []ChangelogSection `json:"sections" yaml:"sections"`
+}
+
+// ChangelogSection holds the pull requests of one change type
+type ChangelogSection struct {
+	Type    string           `json:"type" yaml:"type"`
+	Title   string           `json:"title" yaml:"title"`
+	Entries []ChangelogEntry `json:"entries" yaml:"entries"`
+}
+
+// ChangelogEntry is a merged pull request as listed in the changelog
+type ChangelogEntry struct {
+	ID       int        `json:"id" yaml:"id"`
+	Title    string     `json:"title" yaml:"title"`
+	Summary  string     `json:"summary" yaml:"summary"`
+	Scope    string     `json:"scope,omitempty" yaml:"scope,omitempty"`
+	Breaking bool       `json:"breaking,omitempty" yaml:"breaking,omitempty"`
+	Author   string     `json:"author,omitempty" yaml:"author,omitempty"`
+	URL      string     `json:"url,omitempty" yaml:"url,omitempty"`
+	MergedOn *time.Time `json:"merged_on,omitempty" yaml:"merged_on,omitempty"`
+}
+
+// changelogSections lists the sections in the order they are printed
+var changelogSections = []struct{ Type, Title string }{
+	{"feature", "Features"},
+	{"fix", "Bug Fixes"},
+	{"chore", "Maintenance"},
+	{"other", "Other Changes"},
+}
+
+// conventionalTitleRe matches conventional commit titles such as
+// "feat(api)!: add endpoint", capturing the type, scope, breaking mark and
+// summary
+var conventionalTitleRe = regexp.MustCompile(`^(\w+)(?:\(([^)]*)\))?(!)?:\s*(.+)$`)
+
+// conventionalTypes maps conventional commit types to changelog sections
+var conventionalTypes = map[string]string{
+	"feat":     "feature",
+	"feature":  "feature",
+	"perf":     "feature",
+	"fix":      "fix",
+	"bugfix":   "fix",
+	"hotfix":   "fix",
+	"revert":   "fix",
+	"chore":    "chore",
+	"build":    "chore",
+	"ci":       "chore",
+	"docs":     "chore",
+	"refactor": "chore",
+	"style":    "chore",
+	"test":     "chore",
+	"deps":     "chore",
+}
+
+// Run executes the repo changelog command
+func (cmd *ChangelogCmd) Run(ctx context.Context) error {
+	if cmd.Since == "" {
+		return fmt.Errorf("--since is required: a tag, commit or date (YYYY-MM-DD)")
+	}
+
+	formatterOutput := cmd.Output
+	if formatterOutput == "markdown" {
+		formatterOutput = "table"
+	}
+	repoCtx, err := shared.NewCommandContext(ctx, formatterOutput, cmd.NoColor)
+	if err != nil {
+		return err
+	}
+
+	if err := repoCtx.ResolveRepository(cmd.Workspace, cmd.Repository); err != nil {
+		return err
+	}
+
+	repoDir := ""
+	if repo, err := git.NewRepository(""); err == nil {
+		repoDir = repo.GetPath()
+	}
+
+	from, sinceRef, err := resolveChangelogBound(repoDir, cmd.Since)
+	if err != nil {
+		return err
+	}
+	var to *time.Time
+	untilRef := ""
+	if cmd.Until != "" {
+		until, ref, err := resolveChangelogBound(repoDir, cmd.Until)
+		if err != nil {
+			return err
+		}
+		to, untilRef = &until, ref
+	}
+
+	pullRequests, err := listMergedPullRequests(ctx, repoCtx, from, to)
+	if err != nil {
+		return err
+	}
+	// A pull request edited after the release it shipped in is still updated
+	// after the tag; its merge commit being in the tag gives it away
+	if repoDir != "" {
+		pullRequests = excludeReleased(repoDir, sinceRef, untilRef, pullRequests)
+	}
+
+	changelog := buildChangelog(pullRequests)
+	changelog.Since, changelog.From = cmd.Since, from
+	changelog.Until, changelog.To = cmd.Until, to
+
+	switch cmd.Output {
+	case "markdown":
+		writeChangelogMarkdown(os.Stdout, changelog)
+		return nil
+	case "json", "yaml":
+		return repoCtx.Formatter.Format(changelog)
+	default:
+		return fmt.Errorf("unsupported output format: %s", cmd.Output)
+	}
+}
+
+// resolveChangelogBound turns --since or --until into a time. Dates are
+// taken as they are; anything else must name a tag or commit of the local
+// repository, which is returned as the ref for release correlation.
+func resolveChangelogBound(repoDir, value string) (time.Time, string, error) {
+	for _, layout := range []string{time.RFC3339, "2006-01-02"} {
+		if date, err := time.Parse(layout, value); err == nil {
+			return date, "", nil
+		}
+	}
+
+	if repoDir == "" {
+		return time.Time{}, "", fmt.Errorf("%q is not a date (YYYY-MM-DD), and tags can only be resolved inside a git repository", value)
+	}
+	date, err := git.CommitDateExec(repoDir, value)
+	if err != nil {
+		return time.Time{}, "", err
+	}
+	return date, value, nil
+}
+
+// listMergedPullRequests reads the pull requests merged after from, and
+// before to when set, oldest first. Bitbucket can only filter on the last
+// update, so pull requests merged earlier but edited since come along too;
+// the merge date isn't exposed, so updated_on stands in for it.
+func listMergedPullRequests(ctx context.Context, repoCtx *RepoContext, from time.Time, to *time.Time) ([]*api.PullRequest, error) {
+	options := &api.PullRequestListOptions{
+		State:         "MERGED",
+		UpdatedAfter:  &from,
+		UpdatedBefore: to,
+		Sort:          "updated_on",
+		PageLen:       50,
+	}
+
+	var pullRequests []*api.PullRequest
+	for page := 1; page <= changelogMaxPages; page++ {
+		options.Page = page
+		result, err := repoCtx.Client.PullRequests.ListPullRequests(ctx, repoCtx.Workspace, repoCtx.Repository, options)
+		if err != nil {
+			return nil, handleRepositoryAPIError(err)
+		}
+
+		values, err := shared.ParsePaginatedResults[api.PullRequest](result)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse pull requests: %w", err)
+		}
+		pullRequests = append(pullRequests, values...)
+
+		if result.Next == "" {
+			return pullRequests, nil
+		}
+	}
+
+	fmt.Fprintf(os.Stderr, "Warning: only the first %d merged pull requests were read, so the latest are missing; narrow the range with --since/--until\n", len(pullRequests))
+	return pullRequests, nil
+}
+
+// excludeReleased drops pull requests whose merge commit the since ref
+// already contains, and those the until ref doesn't contain yet. Merge
+// commits missing from the local clone are kept.
+func excludeReleased(repoDir, sinceRef, untilRef string, pullRequests []*api.PullRequest) []*api.PullRequest {
+	if sinceRef == "" && untilRef == "" {
+		return pullRequests
+	}
+
+	kept := pullRequests[:0]
+	for _, pr := range pullRequests {
+		if pr.MergeCommit == nil || pr.MergeCommit.Hash == "" {
+			kept = append(kept, pr)
+			continue
+		}
+		hash, err := git.ResolveCommitExec(repoDir, pr.MergeCommit.Hash)
+		if err != nil {
+			kept = append(kept, pr)
+			continue
+		}
+		if sinceRef != "" && git.IsAncestorExec(repoDir, hash, sinceRef) {
+			continue
+		}
+		if untilRef != "" && !git.IsAncestorExec(repoDir, hash, untilRef) {
+			continue
+		}
+		kept = append(kept, pr)
+	}
+	return kept
+}
+
+// buildChangelog sorts pull requests into sections, oldest first within
+// each. Empty sections are left out.
+func buildChangelog(pullRequests []*api.PullRequest) *Changelog {
+	byType := make(map[string][]ChangelogEntry)
+	for _, pr := range pullRequests {
+		changeType, entry := changelogEntry(pr)
+		byType[changeType] = append(byType[changeType], entry)
+	}
+
+	changelog := &Changelog{Total: len(pullRequests), Sections: []ChangelogSection{}}
+	for _, section := range changelogSections {
+		entries := byType[section.Type]
+		if len(entries) == 0 {
+			continue
+		}
+		sort.SliceStable(entries, func(i, j int) bool {
+			a, b := entries[i].MergedOn, entries[j].MergedOn
+			return a != nil && (b == nil || a.Before(*b))
+		})
+		changelog.Sections = append(changelog.Sections, ChangelogSection{Type: section.Type, Title: section.Title, Entries: entries})
+	}
+	return changelog
+}
+
+// changelogEntry classifies a pull request by its title, falling back to
+// the prefix of its source branch (feature/, bugfix/, ...)
+func changelogEntry(pr *api.PullRequest) (string, ChangelogEntry) {
+	entry := ChangelogEntry{
+		ID:       pr.ID,
+		Title:    pr.Title,
+		Summary:  strings.TrimSpace(pr.Title),
+		MergedOn: pr.UpdatedOn,
+	}
+	if pr.Author != nil {
+		entry.Author = pr.Author.DisplayName
+		if pr.Author.Nickname != "" {
+			entry.Author = pr.Author.Nickname
+		}
+	}
+	if pr.Links != nil && pr.Links.HTML != nil {
+		entry.URL = pr.Links.HTML.Href
+	}
+
+	if m := conventionalTitleRe.FindStringSubmatch(entry.Summary); m != nil {
+		if changeType, ok := conventionalTypes[strings.ToLower(m[1])]; ok {
+			entry.Scope, entry.Breaking, entry.Summary = m[2], m[3] == "!", m[4]
+			return changeType, entry
+		}
+	}
+
+	if changeType := changeTypeFromWords(entry.Summary); changeType != "" {
+		return changeType, entry
+	}
+	if pr.Source != nil && pr.Source.Branch != nil {
+		prefix, _, found := strings.Cut(pr.Source.Branch.Name, "/")
+		if changeType, ok := conventionalTypes[strings.ToLower(prefix)]; ok && found {
+			return changeType, entry
+		}
+	}
+	return "other", entry
+}
+
+// changeTypeFromWords recognizes titles that start like "Fix ..." or
+// "Add ..."
+func changeTypeFromWords(title string) string {
+	first, _, _ := strings.Cut(strings.ToLower(title), " ")
+	switch strings.Trim(first, "[]:") {
+	case "fix", "fixes", "fixed", "bugfix", "hotfix", "revert":
+		return "fix"
+	case "add", "adds", "added", "feat", "feature", "implement", "introduce", "support":
+		return "feature"
+	case "chore", "bump", "update", "upgrade", "refactor", "docs", "ci", "test", "tests":
+		return "chore"
+	}
+	return ""
+}
+
+func writeChangelogMarkdown(w io.Writer, changelog *Changelog) {
+	heading := "Changes since " + changelog.Since
+	if changelog.Until != "" {
+		heading += " until " + changelog.Until
+	}
+	fmt.Fprintf(w, "## %s\n", heading)
+
+	if changelog.Total == 0 {
+		fmt.Fprintln(w, "\nNo merged pull requests in this range.")
+		return
+	}
+
+	for _, section := range changelog.Sections {
+		fmt.Fprintf(w, "\n### %s\n\n", section.Title)
+		for _, entry := range section.Entries {
+			line := "- "
+			if entry.Breaking {
+				line += "**BREAKING** "
+			}
+			if entry.Scope != "" {
+				line += "**" + entry.Scope + ":** "
+			}
+			line += entry.Summary
+			if entry.URL != "" {
+				line += fmt.Sprintf(" ([#%d](%s))", entry.ID, entry.URL)
+			} else {
+				line += fmt.Sprintf(" (#%d)", entry.ID)
+			}
+			if entry.Author != "" {
+				line += " by " + entry.Author
+			}
+			fmt.Fprintln(w, line)
+		}
+	}
+}
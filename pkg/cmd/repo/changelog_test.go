@@ -0,0 +1,121 @@
+package repo
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/url"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/carlosarraes/bt/pkg/api"
+	"github.com/carlosarraes/bt/pkg/api/apitest"
+	"github.com/carlosarraes/bt/pkg/cmd/shared"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChangelogEntry_Classifies(t *testing.T) {
+	tests := []struct {
+		title, branch string
+		wantType      string
+		wantSummary   string
+	}{
+		{"feat(api)!: paginate repositories", "", "feature", "paginate repositories"},
+		{"fix: handle empty diffs", "", "fix", "handle empty diffs"},
+		{"docs: explain redaction", "", "chore", "explain redaction"},
+		{"Fix crash on startup", "", "fix", "Fix crash on startup"},
+		{"Add dark mode", "", "feature", "Add dark mode"},
+		{"Login page polish", "feature/login", "feature", "Login page polish"},
+		{"Quarterly cleanup", "main", "other", "Quarterly cleanup"},
+		{"WIP: not a type", "", "other", "WIP: not a type"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.title, func(t *testing.T) {
+			pr := &api.PullRequest{ID: 1, Title: tt.title}
+			if tt.branch != "" {
+				pr.Source = &api.PullRequestBranch{Branch: &api.Branch{Name: tt.branch}}
+			}
+			changeType, entry := changelogEntry(pr)
+			assert.Equal(t, tt.wantType, changeType)
+			assert.Equal(t, tt.wantSummary, entry.Summary)
+		})
+	}
+
+	_, entry := changelogEntry(&api.PullRequest{Title: "feat(api)!: paginate repositories"})
+	assert.Equal(t, "api", entry.Scope)
+	assert.True(t, entry.Breaking)
+}
+
+func TestListMergedPullRequests_BuildsChangelog(t *testing.T) {
+	apitest.CommandEnv(t, "ws", "repo")
+	transport := apitest.NewReplayTransport(
+		apitest.Fixture{Method: "GET", Path: "/repositories/ws/repo/pullrequests", Query: "page=1", Status: 200,
+			Body: []byte(`{"values": [
+				{"id": 7, "title": "fix: retry uploads", "state": "MERGED", "updated_on": "2024-03-02T10:00:00Z",
+				 "author": {"display_name": "Ana"}, "links": {"html": {"href": "https://bitbucket.org/ws/repo/pull-requests/7"}}}
+			], "next": "https://api.bitbucket.org/2.0/repositories/ws/repo/pullrequests?page=2"}`)},
+		apitest.Fixture{Method: "GET", Path: "/repositories/ws/repo/pullrequests", Query: "page=2", Status: 200,
+			Body: []byte(`{"values": [
+				{"id": 9, "title": "feat: export to CSV", "state": "MERGED", "updated_on": "2024-03-05T10:00:00Z"},
+				{"id": 8, "title": "feat: import from CSV", "state": "MERGED", "updated_on": "2024-03-04T10:00:00Z"}
+			]}`)},
+	)
+	t.Cleanup(shared.SetClientTransport(transport))
+
+	repoCtx, err := shared.NewCommandContext(context.Background(), "json", true)
+	require.NoError(t, err)
+
+	from := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	pullRequests, err := listMergedPullRequests(context.Background(), repoCtx, from, nil)
+	require.NoError(t, err)
+	require.Len(t, pullRequests, 3)
+
+	values, err := url.ParseQuery(transport.Requests()[0].Query)
+	require.NoError(t, err)
+	query := values.Get("q")
+	assert.Contains(t, query, `state="MERGED"`)
+	assert.Contains(t, query, "updated_on>2024-03-01T00:00:00Z")
+
+	changelog := buildChangelog(pullRequests)
+	changelog.Since = "v1.2.0"
+	require.Len(t, changelog.Sections, 2)
+	assert.Equal(t, "feature", changelog.Sections[0].Type)
+	assert.Equal(t, 8, changelog.Sections[0].Entries[0].ID, "entries are oldest first")
+
+	var buf bytes.Buffer
+	writeChangelogMarkdown(&buf, changelog)
+	out := buf.String()
+	assert.True(t, strings.HasPrefix(out, "## Changes since v1.2.0\n"))
+	assert.Contains(t, out, "### Features\n\n- import from CSV (#8)\n- export to CSV (#9)\n")
+	assert.Contains(t, out, "### Bug Fixes\n\n- retry uploads ([#7](https://bitbucket.org/ws/repo/pull-requests/7)) by Ana\n")
+}
+
+func TestListMergedPullRequests_WarnsWhenTruncated(t *testing.T) {
+	apitest.CommandEnv(t, "ws", "repo")
+	transport := apitest.NewReplayTransport(apitest.Fixture{
+		Method: "GET", Path: "/repositories/ws/repo/pullrequests", Status: 200,
+		Body: []byte(`{"values": [{"id": 1, "title": "fix: one", "state": "MERGED"}],
+			"next": "https://api.bitbucket.org/2.0/repositories/ws/repo/pullrequests?page=2"}`),
+	})
+	t.Cleanup(shared.SetClientTransport(transport))
+
+	repoCtx, err := shared.NewCommandContext(context.Background(), "json", true)
+	require.NoError(t, err)
+
+	r, w, err := os.Pipe()
+	require.NoError(t, err)
+	stderr := os.Stderr
+	os.Stderr = w
+	pullRequests, err := listMergedPullRequests(context.Background(), repoCtx, time.Now().AddDate(0, -1, 0), nil)
+	os.Stderr = stderr
+	w.Close()
+	warning, _ := io.ReadAll(r)
+
+	require.NoError(t, err)
+	assert.Len(t, pullRequests, changelogMaxPages)
+	assert.Contains(t, string(warning), "only the first 20 merged pull requests were read")
+}
@@ -0,0 +1,275 @@
+package repo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/carlosarraes/bt/pkg/cmd/pr"
+	"github.com/carlosarraes/bt/pkg/cmd/shared"
+	"github.com/carlosarraes/bt/pkg/git"
+)
+
+// CherryPickCmd handles the repo cherry-pick command
+type CherryPickCmd struct {
+	Commits    []string
+	Onto       string
+	Branch     string
+	PR         bool
+	Draft      bool
+	NoVerify   bool
+	Output     string
+	NoColor    bool
+	Workspace  string
+	Repository string
+}
+
+// pickedCommit is a commit applied to the backport branch
+type pickedCommit struct {
+	Hash    string `json:"hash" yaml:"hash"`
+	Subject string `json:"subject" yaml:"subject"`
+}
+
+// CherryPickResult is the outcome of a backport without --pr
+type CherryPickResult struct {
+	Branch  string         `json:"branch" yaml:"branch"`
+	Onto    string         `json:"onto" yaml:"onto"`
+	Commits []pickedCommit `json:"commits" yaml:"commits"`
+}
+
+// Run executes the repo cherry-pick command
+func (cmd *CherryPickCmd) Run(ctx context.Context) error {
+	if len(cmd.Commits) == 0 {
+		return fmt.Errorf("at least one commit is required")
+	}
+	if cmd.Onto == "" {
+		return fmt.Errorf("--onto is required: name the branch to backport onto")
+	}
+	if cmd.Draft && !cmd.PR {
+		return fmt.Errorf("--draft requires --pr")
+	}
+
+	repoCtx, err := shared.NewCommandContext(ctx, cmd.Output, cmd.NoColor)
+	if err != nil {
+		return err
+	}
+	if cmd.PR {
+		if err := repoCtx.ResolveRepository(cmd.Workspace, cmd.Repository); err != nil {
+			return err
+		}
+	}
+
+	if git.IsCherryPickInProgress("") {
+		return fmt.Errorf("a cherry-pick is already in progress; finish it with 'git cherry-pick --continue' or abort it with 'git cherry-pick --abort'")
+	}
+	dirty, err := git.HasUncommittedChangesExec("")
+	if err != nil {
+		return err
+	}
+	if dirty {
+		return fmt.Errorf("you have uncommitted changes; commit or stash them before cherry-picking")
+	}
+
+	commits, err := resolvePickedCommits(cmd.Commits)
+	if err != nil {
+		return err
+	}
+
+	startPoint, err := backportStartPoint(cmd.Onto)
+	if err != nil {
+		return err
+	}
+
+	branch := cmd.Branch
+	if branch == "" {
+		branch = backportBranchName(cmd.Onto, commits)
+	}
+	if exists, _ := git.BranchExistsExec("", "refs/heads/"+branch); exists {
+		return fmt.Errorf("branch '%s' already exists; choose another name with --branch", branch)
+	}
+
+	if repoCtx.DryRun {
+		return cmd.printDryRun(repoCtx, branch, startPoint, commits)
+	}
+
+	progress := io.Writer(os.Stdout)
+	if cmd.Output != "table" {
+		progress = os.Stderr
+	}
+
+	if err := git.CreateBranchExec("", branch, startPoint); err != nil {
+		return err
+	}
+	fmt.Fprintf(progress, "🌿 Created branch '%s' from '%s'\n", branch, startPoint)
+
+	hashes := make([]string, len(commits))
+	for i, commit := range commits {
+		hashes[i] = commit.Hash
+	}
+	if err := git.CherryPickExec("", hashes); err != nil {
+		var conflict *git.CherryPickConflictError
+		if errors.As(err, &conflict) {
+			cmd.reportConflict(progress, conflict, hashes)
+		}
+		return err
+	}
+	fmt.Fprintf(progress, "🍒 Cherry-picked %d commit(s) onto '%s'\n", len(commits), branch)
+
+	if !cmd.PR {
+		return cmd.formatOutput(repoCtx, &CherryPickResult{Branch: branch, Onto: cmd.Onto, Commits: commits})
+	}
+
+	fmt.Fprintf(progress, "Pushing branch '%s' to remote...\n", branch)
+	if err := git.PushBranchExec("", branch, git.PushOptions{NoVerify: cmd.NoVerify, Output: os.Stderr}); err != nil {
+		return fmt.Errorf("backport branch '%s' is ready locally but could not be pushed: %w", branch, err)
+	}
+
+	create := &pr.CreateCmd{
+		Title:      backportTitle(cmd.Onto, commits),
+		Body:       backportBody(cmd.Onto, commits),
+		Base:       cmd.Onto,
+		Head:       branch,
+		Draft:      cmd.Draft,
+		NoPush:     true,
+		Output:     cmd.Output,
+		NoColor:    cmd.NoColor,
+		Workspace:  repoCtx.Workspace,
+		Repository: repoCtx.Repository,
+	}
+	return create.Run(ctx)
+}
+
+// resolvePickedCommits expands the commit arguments to full hashes, keeping
+// the order they were given in
+func resolvePickedCommits(revs []string) ([]pickedCommit, error) {
+	commits := make([]pickedCommit, 0, len(revs))
+	for _, rev := range revs {
+		hash, err := git.ResolveCommitExec("", rev)
+		if err != nil {
+			return nil, err
+		}
+		subject, err := git.CommitSubjectExec("", hash)
+		if err != nil {
+			return nil, err
+		}
+		commits = append(commits, pickedCommit{Hash: hash, Subject: subject})
+	}
+	return commits, nil
+}
+
+// backportStartPoint is the ref the backport branch starts from: the remote
+// copy of onto after a fetch, so the branch is up to date, else the local
+// branch
+func backportStartPoint(onto string) (string, error) {
+	if err := git.FetchBranchesExec("", false); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v; using local branches\n", err)
+	}
+
+	for _, ref := range []string{"origin/" + onto, onto} {
+		if exists, _ := git.BranchExistsExec("", ref); exists {
+			return ref, nil
+		}
+	}
+	return "", fmt.Errorf("branch '%s' not found locally or on origin", onto)
+}
+
+// backportBranchName is the default name of the backport branch, such as
+// backport/release-1.2/1a2b3c4
+func backportBranchName(onto string, commits []pickedCommit) string {
+	return fmt.Sprintf("backport/%s/%s", onto, shortCommit(commits[0].Hash))
+}
+
+func backportTitle(onto string, commits []pickedCommit) string {
+	if len(commits) == 1 {
+		return fmt.Sprintf("Backport to %s: %s", onto, commits[0].Subject)
+	}
+	return fmt.Sprintf("Backport %d commits to %s", len(commits), onto)
+}
+
+func backportBody(onto string, commits []pickedCommit) string {
+	var body strings.Builder
+	fmt.Fprintf(&body, "Backport to `%s` of:\n\n", onto)
+	for _, commit := range commits {
+		fmt.Fprintf(&body, "- %s %s\n", shortCommit(commit.Hash), commit.Subject)
+	}
+	return strings.TrimSuffix(body.String(), "\n")
+}
+
+// reportConflict lists the conflicting files and how to finish the backport.
+// The cherry-pick is left in progress so the conflicts can be resolved in place.
+func (cmd *CherryPickCmd) reportConflict(w io.Writer, conflict *git.CherryPickConflictError, hashes []string) {
+	fmt.Fprintf(w, "\n❌ Conflicts cherry-picking %s\n", shortCommit(conflict.Commit))
+	if len(conflict.Files) > 0 {
+		fmt.Fprintln(w, "\nFiles with conflicts:")
+		for _, file := range conflict.Files {
+			fmt.Fprintf(w, "  %s\n", file)
+		}
+	}
+
+	var remaining []string
+	for i, hash := range hashes {
+		if hash == conflict.Commit {
+			for _, rest := range hashes[i+1:] {
+				remaining = append(remaining, shortCommit(rest))
+			}
+			break
+		}
+	}
+
+	fmt.Fprintln(w, "\nWhat to do:")
+	fmt.Fprintln(w, "1. Resolve the conflicts in the files listed above")
+	fmt.Fprintln(w, "2. Add the resolved files: git add <file>")
+	fmt.Fprintln(w, "3. Continue: git cherry-pick --continue")
+	step := 4
+	if len(remaining) > 0 {
+		fmt.Fprintf(w, "%d. Pick the remaining commits: git cherry-pick -x %s\n", step, strings.Join(remaining, " "))
+		step++
+	}
+	if cmd.PR {
+		fmt.Fprintf(w, "%d. Open the pull request: bt pr create --base %s\n", step, cmd.Onto)
+	}
+	fmt.Fprintln(w, "Or abort: git cherry-pick --abort")
+}
+
+func (cmd *CherryPickCmd) printDryRun(repoCtx *RepoContext, branch, startPoint string, commits []pickedCommit) error {
+	short := make([]string, len(commits))
+	for i, commit := range commits {
+		short[i] = shortCommit(commit.Hash)
+	}
+
+	plan := shared.NewDryRunPlan("Would create branch '%s' from '%s' and cherry-pick %s onto it", branch, startPoint, strings.Join(short, ", "))
+	if cmd.PR {
+		plan.Summary += fmt.Sprintf(", push it and open a pull request into '%s'", cmd.Onto)
+		plan.Add("POST", fmt.Sprintf("repositories/%s/%s/pullrequests", repoCtx.Workspace, repoCtx.Repository), map[string]interface{}{
+			"title":       backportTitle(cmd.Onto, commits),
+			"source":      map[string]interface{}{"branch": map[string]string{"name": branch}},
+			"destination": map[string]interface{}{"branch": map[string]string{"name": cmd.Onto}},
+		})
+	}
+	return repoCtx.PrintDryRun(plan, cmd.Output)
+}
+
+func (cmd *CherryPickCmd) formatOutput(repoCtx *RepoContext, result *CherryPickResult) error {
+	switch cmd.Output {
+	case "table":
+		for _, commit := range result.Commits {
+			fmt.Printf("  %s %s\n", shortCommit(commit.Hash), commit.Subject)
+		}
+		fmt.Printf("\nPush it and open a pull request with: bt pr create --head %s --base %s\n", result.Branch, result.Onto)
+		return nil
+	case "json", "yaml":
+		return repoCtx.Formatter.Format(result)
+	default:
+		return fmt.Errorf("unsupported output format: %s", cmd.Output)
+	}
+}
+
+func shortCommit(hash string) string {
+	if len(hash) > 7 {
+		return hash[:7]
+	}
+	return hash
+}
@@ -0,0 +1,104 @@
+package repo
+
+import (
+	"context"
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/carlosarraes/bt/pkg/api/apitest"
+	"github.com/carlosarraes/bt/pkg/git"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newCherryPickRepo creates a repository with a release branch and a main
+// branch one fix ahead of it, and makes it the working directory. It returns
+// the hash of the fix.
+func newCherryPickRepo(t *testing.T) (dir string, fix string, run func(args ...string) string) {
+	t.Helper()
+	dir = t.TempDir()
+	run = func(args ...string) string {
+		t.Helper()
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		out, err := cmd.CombinedOutput()
+		require.NoError(t, err, "git %v: %s", args, out)
+		return strings.TrimSpace(string(out))
+	}
+	write := func(name, content string) {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0644))
+	}
+
+	run("init", "-b", "main")
+	run("config", "user.name", "Test User")
+	run("config", "user.email", "test@example.com")
+	write("app.txt", "v1\n")
+	run("add", ".")
+	run("commit", "-m", "initial")
+	run("branch", "release")
+	write("app.txt", "v2\n")
+	run("commit", "-am", "fix the crash")
+	fix = run("rev-parse", "HEAD")
+
+	t.Chdir(dir)
+	return dir, fix, run
+}
+
+func TestCherryPickCmd_Run(t *testing.T) {
+	apitest.CommandEnv(t, "ws", "repo")
+	_, fix, run := newCherryPickRepo(t)
+
+	cmd := &CherryPickCmd{Commits: []string{fix[:7]}, Onto: "release", Output: "json", NoColor: true}
+	require.NoError(t, cmd.Run(context.Background()))
+
+	branch := "backport/release/" + fix[:7]
+	assert.Equal(t, branch, run("branch", "--show-current"))
+	assert.Equal(t, "initial", run("log", "-1", "--format=%s", "HEAD~1"), "the branch starts from release")
+	assert.Contains(t, run("log", "-1", "--format=%B"), "(cherry picked from commit "+fix+")")
+
+	err := cmd.Run(context.Background())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "already exists")
+}
+
+func TestCherryPickCmd_RunConflict(t *testing.T) {
+	apitest.CommandEnv(t, "ws", "repo")
+	dir, fix, run := newCherryPickRepo(t)
+	run("checkout", "release")
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "app.txt"), []byte("v1-hotfix\n"), 0644))
+	run("commit", "-am", "release hotfix")
+	run("checkout", "main")
+
+	cmd := &CherryPickCmd{Commits: []string{fix}, Onto: "release", Branch: "backport/crash", Output: "table", NoColor: true}
+	err := cmd.Run(context.Background())
+
+	var conflict *git.CherryPickConflictError
+	require.True(t, errors.As(err, &conflict), "got %v", err)
+	assert.Equal(t, []string{"app.txt"}, conflict.Files)
+	assert.Equal(t, "backport/crash", run("branch", "--show-current"))
+	assert.True(t, git.IsCherryPickInProgress(dir), "the conflict is left to resolve")
+}
+
+func TestCherryPickCmd_RunRefusesDirtyTree(t *testing.T) {
+	apitest.CommandEnv(t, "ws", "repo")
+	dir, fix, _ := newCherryPickRepo(t)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "app.txt"), []byte("wip\n"), 0644))
+
+	err := (&CherryPickCmd{Commits: []string{fix}, Onto: "release", Output: "table"}).Run(context.Background())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "uncommitted changes")
+}
+
+func TestBackportTitleAndBody(t *testing.T) {
+	one := []pickedCommit{{Hash: "1a2b3c4d5e6f", Subject: "Fix the crash"}}
+	assert.Equal(t, "Backport to release: Fix the crash", backportTitle("release", one))
+	assert.Equal(t, "backport/release/1a2b3c4", backportBranchName("release", one))
+
+	two := append(one, pickedCommit{Hash: "9f8e7d6c5b4a", Subject: "Add a test"})
+	assert.Equal(t, "Backport 2 commits to release", backportTitle("release", two))
+	assert.Equal(t, "Backport to `release` of:\n\n- 1a2b3c4 Fix the crash\n- 9f8e7d6 Add a test", backportBody("release", two))
+}
@@ -0,0 +1,110 @@
+package repo
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/carlosarraes/bt/pkg/api"
+	"github.com/carlosarraes/bt/pkg/cmd/pr"
+	"github.com/carlosarraes/bt/pkg/cmd/shared"
+	"github.com/carlosarraes/bt/pkg/git"
+)
+
+// CloneCmd handles the repo clone command
+type CloneCmd struct {
+	Repository string
+	Directory  string
+	PR         string
+	SSH        bool
+	NoColor    bool
+}
+
+// cloneTarget is the resolved repository, clone URL and destination
+type cloneTarget struct {
+	workspace string
+	repo      string
+	url       string
+	directory string
+}
+
+// Run executes the repo clone command
+func (cmd *CloneCmd) Run(ctx context.Context) error {
+	target, err := cmd.resolveTarget()
+	if err != nil {
+		return err
+	}
+
+	// Resolve the pull request before cloning so a bad ID fails fast
+	var repoCtx *RepoContext
+	var pullRequest *api.PullRequest
+	if cmd.PR != "" {
+		prID, err := pr.ParsePRID(cmd.PR)
+		if err != nil {
+			return err
+		}
+
+		repoCtx, err = shared.NewCommandContext(ctx, "table", cmd.NoColor)
+		if err != nil {
+			return err
+		}
+		repoCtx.Workspace = target.workspace
+		repoCtx.Repository = target.repo
+
+		pullRequest, err = repoCtx.Client.PullRequests.GetPullRequest(ctx, target.workspace, target.repo, prID)
+		if err != nil {
+			return shared.HandleAPIError(err, shared.DomainPullRequest)
+		}
+	}
+
+	if _, err := exec.LookPath("git"); err != nil {
+		return fmt.Errorf("git is not installed or not in PATH")
+	}
+
+	fmt.Printf("Cloning %s/%s into %s...\n", target.workspace, target.repo, target.directory)
+	gitCmd := exec.Command("git", "clone", "--", target.url, target.directory)
+	gitCmd.Stdout = os.Stdout
+	gitCmd.Stderr = os.Stderr
+	if err := gitCmd.Run(); err != nil {
+		return fmt.Errorf("failed to clone %s: %w", target.url, err)
+	}
+
+	if pullRequest == nil {
+		return nil
+	}
+
+	gitRepo, err := git.NewRepository(target.directory)
+	if err != nil {
+		return fmt.Errorf("failed to open cloned repository: %w", err)
+	}
+
+	return pr.CheckoutPullRequest(repoCtx, gitRepo, pullRequest, pr.CheckoutOptions{})
+}
+
+// resolveTarget accepts workspace/repo or a Bitbucket URL. A URL is cloned
+// as given; workspace/repo is cloned over HTTPS, or SSH with --ssh.
+func (cmd *CloneCmd) resolveTarget() (*cloneTarget, error) {
+	workspace, repo, err := git.ExtractRepositoryInfo(cmd.Repository)
+	if err != nil {
+		return nil, fmt.Errorf("invalid repository %q: %w", cmd.Repository, err)
+	}
+	repo = strings.TrimSuffix(repo, ".git")
+
+	url := strings.TrimSpace(cmd.Repository)
+	if !strings.Contains(url, "://") && !strings.Contains(url, "@") {
+		protocol := "https"
+		if cmd.SSH {
+			protocol = "ssh"
+		}
+		url = git.BuildBitbucketURL(workspace, repo, protocol)
+	}
+
+	directory := cmd.Directory
+	if directory == "" {
+		directory = repo
+	}
+
+	return &cloneTarget{workspace: workspace, repo: repo, url: url, directory: directory}, nil
+}
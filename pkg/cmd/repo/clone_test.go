@@ -0,0 +1,56 @@
+package repo
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCloneCmd_resolveTarget(t *testing.T) {
+	tests := []struct {
+		name string
+		cmd  CloneCmd
+		want cloneTarget
+	}{
+		{
+			name: "workspace/repo over https",
+			cmd:  CloneCmd{Repository: "acme/api"},
+			want: cloneTarget{workspace: "acme", repo: "api", url: "https://bitbucket.org/acme/api.git", directory: "api"},
+		},
+		{
+			name: "workspace/repo over ssh into a directory",
+			cmd:  CloneCmd{Repository: "acme/api", Directory: "work/api", SSH: true},
+			want: cloneTarget{workspace: "acme", repo: "api", url: "git@bitbucket.org:acme/api.git", directory: "work/api"},
+		},
+		{
+			name: "https URL is cloned as given",
+			cmd:  CloneCmd{Repository: "https://bitbucket.org/acme/api.git"},
+			want: cloneTarget{workspace: "acme", repo: "api", url: "https://bitbucket.org/acme/api.git", directory: "api"},
+		},
+		{
+			name: "ssh URL is cloned as given",
+			cmd:  CloneCmd{Repository: "git@bitbucket.org:acme/api.git", SSH: false},
+			want: cloneTarget{workspace: "acme", repo: "api", url: "git@bitbucket.org:acme/api.git", directory: "api"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.cmd.resolveTarget()
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, *got)
+		})
+	}
+}
+
+func TestCloneCmd_resolveTarget_Invalid(t *testing.T) {
+	_, err := (&CloneCmd{Repository: "just-a-name"}).resolveTarget()
+	assert.Error(t, err)
+}
+
+func TestCloneCmd_Run_InvalidPR(t *testing.T) {
+	err := (&CloneCmd{Repository: "acme/api", PR: "abc"}).Run(context.Background())
+	assert.Error(t, err)
+}
@@ -0,0 +1,79 @@
+package repo
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/carlosarraes/bt/pkg/api"
+	"github.com/carlosarraes/bt/pkg/cmd/shared"
+)
+
+// commitFilterScanLimit is how many commits are read when filtering by
+// author or committer, which Bitbucket can't do itself
+const commitFilterScanLimit = 300
+
+// CommitsCmd handles the repo commits command
+type CommitsCmd struct {
+	Revision    string
+	Limit       int
+	AuthorEmail string
+	Committer   string
+	Output      string
+	NoColor     bool
+	Workspace   string
+	Repository  string
+}
+
+// Run executes the repo commits command
+func (cmd *CommitsCmd) Run(ctx context.Context) error {
+	repoCtx, err := shared.NewCommandContext(ctx, cmd.Output, cmd.NoColor)
+	if err != nil {
+		return err
+	}
+
+	if err := repoCtx.ResolveRepository(cmd.Workspace, cmd.Repository); err != nil {
+		return err
+	}
+
+	if cmd.Limit <= 0 {
+		return fmt.Errorf("--limit must be positive, got %d", cmd.Limit)
+	}
+
+	filter := shared.CommitFilter{AuthorEmail: cmd.AuthorEmail, Committer: cmd.Committer}
+	options := &api.CommitListOptions{
+		Revision: cmd.Revision,
+		Limit:    cmd.Limit,
+	}
+	if filter.Active() {
+		options.Limit = max(cmd.Limit, commitFilterScanLimit)
+	}
+
+	commits, err := repoCtx.Client.Repositories.ListCommits(ctx, repoCtx.Workspace, repoCtx.Repository, options)
+	if err != nil {
+		return handleRepositoryAPIError(err)
+	}
+
+	summaries := shared.FilterCommits(shared.SummarizeCommits(commits, repoCtx.Workspace, repoCtx.Repository), filter)
+	if len(summaries) > cmd.Limit {
+		summaries = summaries[:cmd.Limit]
+	}
+	return cmd.formatOutput(repoCtx, summaries)
+}
+
+func (cmd *CommitsCmd) formatOutput(repoCtx *RepoContext, commits []shared.CommitSummary) error {
+	switch cmd.Output {
+	case "table":
+		if len(commits) == 0 {
+			fmt.Println("No commits found")
+			return nil
+		}
+		return shared.RenderCommitTable(commits)
+	case "json", "yaml":
+		return repoCtx.Formatter.Format(map[string]interface{}{
+			"commits":     commits,
+			"total_count": len(commits),
+		})
+	default:
+		return fmt.Errorf("unsupported output format: %s", cmd.Output)
+	}
+}
@@ -0,0 +1,15 @@
+package repo
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCommitsCmd_formatOutput_UnsupportedFormat(t *testing.T) {
+	cmd := &CommitsCmd{Output: "xml"}
+
+	err := cmd.formatOutput(nil, nil)
+	if err == nil || !strings.Contains(err.Error(), "unsupported output format: xml") {
+		t.Errorf("formatOutput() error = %v, want unsupported output format", err)
+	}
+}
@@ -0,0 +1,11 @@
+package repo
+
+import (
+	"github.com/carlosarraes/bt/pkg/cmd/shared"
+)
+
+type RepoContext = shared.CommandContext
+
+func handleRepositoryAPIError(err error) error {
+	return shared.HandleAPIError(err, shared.DomainRepository)
+}
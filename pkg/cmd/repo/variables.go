@@ -0,0 +1,350 @@
+package repo
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/carlosarraes/bt/pkg/api"
+	"github.com/carlosarraes/bt/pkg/cmd/shared"
+	"github.com/carlosarraes/bt/pkg/output"
+	"github.com/carlosarraes/bt/pkg/utils"
+)
+
+// variableKeyPattern is the form Bitbucket accepts for variable names
+var variableKeyPattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// variableScope is where a variables command reads and writes: the
+// repository, or one of its deployment environments
+type variableScope struct {
+	repoCtx     *RepoContext
+	environment *api.DeploymentEnvironment
+}
+
+func (s *variableScope) environmentUUID() string {
+	if s.environment == nil {
+		return ""
+	}
+	return s.environment.UUID
+}
+
+func (s *variableScope) String() string {
+	if s.environment == nil {
+		return fmt.Sprintf("%s/%s", s.repoCtx.Workspace, s.repoCtx.Repository)
+	}
+	return fmt.Sprintf("%s/%s (%s)", s.repoCtx.Workspace, s.repoCtx.Repository, s.environment.Name)
+}
+
+func (s *variableScope) listVariables(ctx context.Context) ([]*api.PipelineVariable, error) {
+	variables, err := s.repoCtx.Client.Variables.ListVariables(ctx, s.repoCtx.Workspace, s.repoCtx.Repository, s.environmentUUID())
+	if err != nil {
+		return nil, handleRepositoryAPIError(err)
+	}
+	return variables, nil
+}
+
+// findVariable looks a variable up by key, returning an error naming the
+// scope when it does not exist
+func (s *variableScope) findVariable(ctx context.Context, key string) (*api.PipelineVariable, error) {
+	variables, err := s.listVariables(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if variable := findVariableByKey(variables, key); variable != nil {
+		return variable, nil
+	}
+	return nil, fmt.Errorf("variable %s not found in %s", key, s)
+}
+
+// newVariableScope sets up the command context and resolves the
+// --environment flag, if given, to one of the repository's environments
+func newVariableScope(ctx context.Context, environment, outputFormat string, noColor bool, workspace, repository string) (*variableScope, error) {
+	repoCtx, err := shared.NewCommandContext(ctx, outputFormat, noColor)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := repoCtx.ResolveRepository(workspace, repository); err != nil {
+		return nil, err
+	}
+
+	scope := &variableScope{repoCtx: repoCtx}
+	if environment == "" {
+		return scope, nil
+	}
+
+	environments, err := repoCtx.Client.Deployments.ListEnvironments(ctx, repoCtx.Workspace, repoCtx.Repository)
+	if err != nil {
+		return nil, handleRepositoryAPIError(err)
+	}
+
+	scope.environment, err = shared.FindEnvironment(environments, environment)
+	if err != nil {
+		return nil, err
+	}
+	return scope, nil
+}
+
+func findVariableByKey(variables []*api.PipelineVariable, key string) *api.PipelineVariable {
+	for _, variable := range variables {
+		if variable.Key == key {
+			return variable
+		}
+	}
+	return nil
+}
+
+func validateVariableKey(key string) error {
+	if !variableKeyPattern.MatchString(key) {
+		return fmt.Errorf("invalid variable name %q: use letters, digits and underscores, not starting with a digit", key)
+	}
+	return nil
+}
+
+// displayVariableValue is how a variable's value is shown in tables;
+// Bitbucket never returns the value of a secured variable
+func displayVariableValue(variable *api.PipelineVariable) string {
+	if variable.Secured {
+		return "••••••"
+	}
+	return variable.Value
+}
+
+// VariablesListCmd handles the repo variables list command
+type VariablesListCmd struct {
+	Environment string
+	Output      string
+	NoColor     bool
+	Workspace   string
+	Repository  string
+}
+
+// Run executes the repo variables list command
+func (cmd *VariablesListCmd) Run(ctx context.Context) error {
+	scope, err := newVariableScope(ctx, cmd.Environment, cmd.Output, cmd.NoColor, cmd.Workspace, cmd.Repository)
+	if err != nil {
+		return err
+	}
+
+	variables, err := scope.listVariables(ctx)
+	if err != nil {
+		return err
+	}
+
+	switch cmd.Output {
+	case "table":
+		if len(variables) == 0 {
+			fmt.Printf("No variables found in %s\n", scope)
+			return nil
+		}
+		headers := []string{"NAME", "VALUE", "SECURED"}
+		rows := make([][]string, 0, len(variables))
+		for _, variable := range variables {
+			secured := "no"
+			if variable.Secured {
+				secured = "yes"
+			}
+			rows = append(rows, []string{variable.Key, displayVariableValue(variable), secured})
+		}
+		return output.RenderSimpleTable(headers, rows)
+	case "json", "yaml":
+		return scope.repoCtx.Formatter.Format(map[string]interface{}{
+			"environment": scope.environment,
+			"variables":   variables,
+			"total_count": len(variables),
+		})
+	default:
+		return fmt.Errorf("unsupported output format: %s", cmd.Output)
+	}
+}
+
+// VariablesGetCmd handles the repo variables get command
+type VariablesGetCmd struct {
+	Key         string
+	Environment string
+	Output      string
+	NoColor     bool
+	Workspace   string
+	Repository  string
+}
+
+// Run executes the repo variables get command
+func (cmd *VariablesGetCmd) Run(ctx context.Context) error {
+	scope, err := newVariableScope(ctx, cmd.Environment, cmd.Output, cmd.NoColor, cmd.Workspace, cmd.Repository)
+	if err != nil {
+		return err
+	}
+
+	variable, err := scope.findVariable(ctx, cmd.Key)
+	if err != nil {
+		return err
+	}
+
+	if cmd.Output != "table" {
+		return scope.repoCtx.Formatter.Format(variable)
+	}
+
+	if variable.Secured {
+		return fmt.Errorf("variable %s is secured; Bitbucket does not return its value", variable.Key)
+	}
+	fmt.Println(variable.Value)
+	return nil
+}
+
+// VariablesSetCmd handles the repo variables set command
+type VariablesSetCmd struct {
+	Key         string
+	Value       string
+	Secured     bool
+	Environment string
+	Output      string
+	NoColor     bool
+	Workspace   string
+	Repository  string
+}
+
+// Run executes the repo variables set command
+func (cmd *VariablesSetCmd) Run(ctx context.Context) error {
+	if err := validateVariableKey(cmd.Key); err != nil {
+		return err
+	}
+
+	value, err := cmd.resolveValue(os.Stdin)
+	if err != nil {
+		return err
+	}
+
+	scope, err := newVariableScope(ctx, cmd.Environment, cmd.Output, cmd.NoColor, cmd.Workspace, cmd.Repository)
+	if err != nil {
+		return err
+	}
+
+	variables, err := scope.listVariables(ctx)
+	if err != nil {
+		return err
+	}
+
+	repoCtx := scope.repoCtx
+	variable := &api.PipelineVariable{Key: cmd.Key, Value: value, Secured: cmd.Secured}
+
+	action := "Created"
+	existing := findVariableByKey(variables, cmd.Key)
+	if existing != nil {
+		// A secured variable cannot be turned back into a plain one
+		variable.Secured = variable.Secured || existing.Secured
+		variable, err = repoCtx.Client.Variables.UpdateVariable(ctx, repoCtx.Workspace, repoCtx.Repository, scope.environmentUUID(), existing.UUID, variable)
+		action = "Updated"
+	} else {
+		variable, err = repoCtx.Client.Variables.CreateVariable(ctx, repoCtx.Workspace, repoCtx.Repository, scope.environmentUUID(), variable)
+	}
+	if err != nil {
+		return handleRepositoryAPIError(err)
+	}
+
+	if cmd.Output != "table" {
+		return repoCtx.Formatter.Format(variable)
+	}
+
+	kind := "variable"
+	if variable.Secured {
+		kind = "secured variable"
+	}
+	fmt.Printf("✅ %s %s %s in %s\n", action, kind, variable.Key, scope)
+	return nil
+}
+
+// resolveValue returns the value argument, or reads it from in when the
+// argument is "-" or omitted with piped input, so secrets stay out of shell
+// history
+func (cmd *VariablesSetCmd) resolveValue(in *os.File) (string, error) {
+	if cmd.Value != "" && cmd.Value != "-" {
+		return cmd.Value, nil
+	}
+
+	if cmd.Value == "" && utils.IsTerminal(in) {
+		return "", fmt.Errorf("a value is required: pass it as an argument or pipe it on stdin")
+	}
+
+	return readVariableValue(in)
+}
+
+func readVariableValue(in io.Reader) (string, error) {
+	data, err := io.ReadAll(in)
+	if err != nil {
+		return "", fmt.Errorf("failed to read value from stdin: %w", err)
+	}
+
+	value := strings.TrimRight(string(data), "\r\n")
+	if value == "" {
+		return "", fmt.Errorf("value read from stdin is empty")
+	}
+	return value, nil
+}
+
+// VariablesDeleteCmd handles the repo variables delete command
+type VariablesDeleteCmd struct {
+	Key         string
+	Force       bool
+	Environment string
+	Output      string
+	NoColor     bool
+	Workspace   string
+	Repository  string
+}
+
+// Run executes the repo variables delete command
+func (cmd *VariablesDeleteCmd) Run(ctx context.Context) error {
+	scope, err := newVariableScope(ctx, cmd.Environment, cmd.Output, cmd.NoColor, cmd.Workspace, cmd.Repository)
+	if err != nil {
+		return err
+	}
+
+	variable, err := scope.findVariable(ctx, cmd.Key)
+	if err != nil {
+		return err
+	}
+
+	if !cmd.Force {
+		if err := confirmDeleteVariable(os.Stdin, variable.Key, scope); err != nil {
+			return err
+		}
+	}
+
+	repoCtx := scope.repoCtx
+	if err := repoCtx.Client.Variables.DeleteVariable(ctx, repoCtx.Workspace, repoCtx.Repository, scope.environmentUUID(), variable.UUID); err != nil {
+		return handleRepositoryAPIError(err)
+	}
+
+	if cmd.Output != "table" {
+		return repoCtx.Formatter.Format(map[string]interface{}{
+			"deleted":     true,
+			"variable":    variable,
+			"environment": scope.environment,
+		})
+	}
+
+	fmt.Printf("🗑️  Deleted variable %s from %s\n", variable.Key, scope)
+	return nil
+}
+
+func confirmDeleteVariable(in io.Reader, key string, scope *variableScope) error {
+	fmt.Printf("Are you sure you want to delete variable %s from %s? [y/N] ", key, scope)
+
+	reader := bufio.NewReader(in)
+	response, err := reader.ReadString('\n')
+	if err != nil {
+		return fmt.Errorf("failed to read confirmation (use --force to skip it): %w", err)
+	}
+
+	response = strings.TrimSpace(strings.ToLower(response))
+	if response != "y" && response != "yes" {
+		return fmt.Errorf("operation cancelled")
+	}
+
+	return nil
+}
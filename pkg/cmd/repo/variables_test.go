@@ -0,0 +1,80 @@
+package repo
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/carlosarraes/bt/pkg/api"
+	"github.com/carlosarraes/bt/pkg/api/apitest"
+	"github.com/carlosarraes/bt/pkg/cmd/shared"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateVariableKey(t *testing.T) {
+	for _, key := range []string{"AWS_REGION", "_private", "token2"} {
+		assert.NoError(t, validateVariableKey(key), key)
+	}
+	for _, key := range []string{"", "2FA", "MY-VAR", "A B"} {
+		assert.Error(t, validateVariableKey(key), key)
+	}
+}
+
+func TestReadVariableValue(t *testing.T) {
+	value, err := readVariableValue(strings.NewReader("s3cret\n"))
+	require.NoError(t, err)
+	assert.Equal(t, "s3cret", value)
+
+	value, err = readVariableValue(strings.NewReader("line one\nline two\r\n"))
+	require.NoError(t, err)
+	assert.Equal(t, "line one\nline two", value)
+
+	_, err = readVariableValue(strings.NewReader("\n"))
+	assert.Error(t, err)
+}
+
+func TestConfirmDeleteVariable(t *testing.T) {
+	scope := &variableScope{repoCtx: &RepoContext{Workspace: "ws", Repository: "repo"}}
+
+	assert.NoError(t, confirmDeleteVariable(strings.NewReader("y\n"), "TOKEN", scope))
+	assert.EqualError(t, confirmDeleteVariable(strings.NewReader("n\n"), "TOKEN", scope), "operation cancelled")
+	assert.ErrorContains(t, confirmDeleteVariable(strings.NewReader(""), "TOKEN", scope), "--force")
+}
+
+func TestVariablesSetCmd_UpdatesEnvironmentVariable(t *testing.T) {
+	apitest.CommandEnv(t, "ws", "repo")
+	transport := apitest.NewReplayTransport(
+		apitest.Fixture{
+			Method: "GET",
+			Path:   "/repositories/ws/repo/environments",
+			Status: 200,
+			Body:   json.RawMessage(`{"values":[{"uuid":"{env-1}","name":"Production","slug":"production"}]}`),
+		},
+		apitest.Fixture{
+			Method: "GET",
+			Path:   "/repositories/ws/repo/deployments_config/environments/{env-1}/variables",
+			Status: 200,
+			Body:   json.RawMessage(`{"values":[{"uuid":"{v1}","key":"TOKEN","secured":true}]}`),
+		},
+		apitest.Fixture{
+			Method: "PUT",
+			Path:   "/repositories/ws/repo/deployments_config/environments/{env-1}/variables/{v1}",
+			Status: 200,
+			Body:   json.RawMessage(`{"uuid":"{v1}","key":"TOKEN","secured":true}`),
+		},
+	)
+	t.Cleanup(shared.SetClientTransport(transport))
+
+	cmd := &VariablesSetCmd{Key: "TOKEN", Value: "n3w", Environment: "production", Output: "table", NoColor: true}
+	require.NoError(t, cmd.Run(context.Background()))
+
+	requests := transport.Requests()
+	require.Len(t, requests, 3)
+
+	var body api.PipelineVariable
+	require.NoError(t, json.Unmarshal(requests[2].Body, &body))
+	assert.Equal(t, "n3w", body.Value)
+	assert.True(t, body.Secured, "an existing secured variable stays secured")
+}
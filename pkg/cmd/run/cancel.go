@@ -6,15 +6,18 @@ import (
 	"fmt"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/carlosarraes/bt/pkg/api"
 	"github.com/carlosarraes/bt/pkg/cmd/shared"
 )
 
 type CancelCmd struct {
-	PipelineID string `arg:"" help:"Pipeline ID (build number or UUID)"`
-	Force      bool   `short:"f" help:"Force cancellation without confirmation"`
-	Output     string `short:"o" help:"Output format (table, json, yaml)" enum:"table,json,yaml" default:"table"`
+	PipelineID string        `arg:"" help:"Pipeline ID (build number or UUID)"`
+	Force      bool          `short:"f" help:"Force cancellation without confirmation"`
+	Wait       bool          `help:"Wait until the pipeline has stopped and report its final state"`
+	Timeout    time.Duration `help:"With --wait, how long to wait for the pipeline to stop" default:"10m"`
+	Output     string        `short:"o" help:"Output format (table, json, yaml)" enum:"table,json,yaml" default:"table"`
 	NoColor    bool
 	Workspace  string `help:"Bitbucket workspace (defaults to git remote or config)"`
 	Repository string `help:"Repository name (defaults to git remote)"`
@@ -26,14 +29,7 @@ func (cmd *CancelCmd) Run(ctx context.Context) error {
 		return err
 	}
 
-	if cmd.Workspace != "" {
-		runCtx.Workspace = cmd.Workspace
-	}
-	if cmd.Repository != "" {
-		runCtx.Repository = cmd.Repository
-	}
-
-	if err := runCtx.ValidateWorkspaceAndRepo(); err != nil {
+	if err := runCtx.ResolveRepository(cmd.Workspace, cmd.Repository); err != nil {
 		return err
 	}
 
@@ -51,6 +47,12 @@ func (cmd *CancelCmd) Run(ctx context.Context) error {
 		return err
 	}
 
+	if runCtx.DryRun {
+		plan := shared.NewDryRunPlan("Would cancel pipeline #%d (%s)", pipeline.BuildNumber, pipeline.State.Name)
+		plan.Add("POST", fmt.Sprintf("repositories/%s/%s/pipelines/%s/stopPipeline", runCtx.Workspace, runCtx.Repository, pipelineUUID), nil)
+		return runCtx.PrintDryRun(plan, cmd.Output)
+	}
+
 	if !cmd.Force {
 		if !cmd.confirmCancellation(pipeline) {
 			fmt.Println("Cancellation aborted.")
@@ -62,6 +64,12 @@ func (cmd *CancelCmd) Run(ctx context.Context) error {
 		return fmt.Errorf("failed to cancel pipeline: %w", err)
 	}
 
+	if cmd.Wait {
+		if pipeline, err = cmd.waitForStop(ctx, runCtx, pipeline); err != nil {
+			return err
+		}
+	}
+
 	return cmd.outputSuccess(runCtx, pipeline)
 }
 
@@ -105,7 +113,14 @@ func (cmd *CancelCmd) outputSuccess(runCtx *RunContext, pipeline *api.Pipeline)
 }
 
 func (cmd *CancelCmd) outputTable(pipeline *api.Pipeline) error {
-	fmt.Printf("✓ Pipeline #%d has been cancelled successfully.\n", pipeline.BuildNumber)
+	switch {
+	case !cmd.Wait:
+		fmt.Printf("✓ Pipeline #%d has been cancelled successfully.\n", pipeline.BuildNumber)
+	case cmd.stopped(pipeline):
+		fmt.Printf("✓ Pipeline #%d has stopped.\n", pipeline.BuildNumber)
+	default:
+		fmt.Printf("! Pipeline #%d completed as %s before the cancellation took effect.\n", pipeline.BuildNumber, pipelineStatus(pipeline))
+	}
 	if pipeline.Repository != nil {
 		fmt.Printf("  Repository: %s\n", pipeline.Repository.FullName)
 	}
@@ -127,6 +142,9 @@ func (cmd *CancelCmd) outputJSON(runCtx *RunContext, pipeline *api.Pipeline) err
 	if pipeline.State != nil {
 		pipelineData["state"] = pipeline.State.Name
 	}
+	if cmd.Wait {
+		pipelineData["status"] = pipelineStatus(pipeline)
+	}
 
 	if pipeline.Repository != nil {
 		pipelineData["repository"] = pipeline.Repository.FullName
@@ -140,8 +158,8 @@ func (cmd *CancelCmd) outputJSON(runCtx *RunContext, pipeline *api.Pipeline) err
 	}
 
 	result := map[string]interface{}{
-		"success":  true,
-		"message":  "Pipeline cancelled successfully",
+		"success":  cmd.stopped(pipeline),
+		"message":  cmd.resultMessage(pipeline),
 		"pipeline": pipelineData,
 	}
 
@@ -157,6 +175,9 @@ func (cmd *CancelCmd) outputYAML(runCtx *RunContext, pipeline *api.Pipeline) err
 	if pipeline.State != nil {
 		pipelineData["state"] = pipeline.State.Name
 	}
+	if cmd.Wait {
+		pipelineData["status"] = pipelineStatus(pipeline)
+	}
 
 	if pipeline.Repository != nil {
 		pipelineData["repository"] = pipeline.Repository.FullName
@@ -170,8 +191,8 @@ func (cmd *CancelCmd) outputYAML(runCtx *RunContext, pipeline *api.Pipeline) err
 	}
 
 	result := map[string]interface{}{
-		"success":  true,
-		"message":  "Pipeline cancelled successfully",
+		"success":  cmd.stopped(pipeline),
+		"message":  cmd.resultMessage(pipeline),
 		"pipeline": pipelineData,
 	}
 
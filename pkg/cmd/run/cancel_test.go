@@ -0,0 +1,104 @@
+package run
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/carlosarraes/bt/pkg/api/apitest"
+	"github.com/carlosarraes/bt/pkg/cmd/shared"
+)
+
+func TestCancelCmd_Run_DryRun(t *testing.T) {
+	apitest.CommandEnv(t, "ws", "repo")
+	transport := apitest.NewReplayTransport(apitest.Fixture{
+		Method: "GET",
+		Path:   "/repositories/ws/repo/pipelines/{c-1}",
+		Status: 200,
+		Body:   []byte(`{"uuid": "{c-1}", "build_number": 12, "state": {"name": "IN_PROGRESS"}}`),
+	})
+	t.Cleanup(shared.SetClientTransport(transport))
+
+	ctx := context.WithValue(context.Background(), "dry-run", true)
+	var runErr error
+	out := captureStdout(func() {
+		cmd := &CancelCmd{PipelineID: "{c-1}", Output: "table", NoColor: true}
+		runErr = cmd.Run(ctx)
+	})
+	require.NoError(t, runErr)
+
+	assert.Contains(t, out, "[dry-run] Would cancel pipeline #12 (IN_PROGRESS)")
+	assert.Contains(t, out, "POST repositories/ws/repo/pipelines/{c-1}/stopPipeline")
+	assert.Contains(t, out, "No changes were made.")
+
+	for _, request := range transport.Requests() {
+		assert.Equal(t, "GET", request.Method)
+	}
+}
+
+func cancelWaitFixtures(finalResult string) []apitest.Fixture {
+	return []apitest.Fixture{{
+		Method: "GET",
+		Path:   "/repositories/ws/repo/pipelines/{c-1}",
+		Body:   json.RawMessage(`{"uuid": "{c-1}", "build_number": 12, "state": {"name": "IN_PROGRESS"}}`),
+	}, {
+		Method: "POST",
+		Path:   "/repositories/ws/repo/pipelines/{c-1}/stopPipeline",
+		Status: 204,
+	}, {
+		Method: "GET",
+		Path:   "/repositories/ws/repo/pipelines/{c-1}",
+		Body:   json.RawMessage(`{"uuid": "{c-1}", "build_number": 12, "state": {"name": "COMPLETED", "result": {"name": "` + finalResult + `"}}}`),
+	}}
+}
+
+func runCancelWait(t *testing.T, output string, fixtures ...apitest.Fixture) (*apitest.ReplayTransport, string, error) {
+	t.Helper()
+
+	apitest.CommandEnv(t, "ws", "repo")
+	transport := apitest.NewReplayTransport(fixtures...)
+	t.Cleanup(shared.SetClientTransport(transport))
+
+	interval := cancelWaitInterval
+	cancelWaitInterval = 1
+	t.Cleanup(func() { cancelWaitInterval = interval })
+
+	var runErr error
+	out := captureStdout(func() {
+		cmd := &CancelCmd{PipelineID: "{c-1}", Force: true, Wait: true, Timeout: time.Minute, Output: output, NoColor: true}
+		runErr = cmd.Run(context.Background())
+	})
+	return transport, out, runErr
+}
+
+func TestCancelCmd_Run_WaitStopped(t *testing.T) {
+	transport, out, err := runCancelWait(t, "table", cancelWaitFixtures("STOPPED")...)
+	require.NoError(t, err)
+
+	assert.Contains(t, out, "⏳ Waiting for pipeline #12 to stop...")
+	assert.Contains(t, out, "✓ Pipeline #12 has stopped.")
+	assert.Len(t, transport.Requests(), 3)
+}
+
+func TestCancelCmd_Run_WaitCompletedFirst(t *testing.T) {
+	_, out, err := runCancelWait(t, "json", cancelWaitFixtures("SUCCESSFUL")...)
+	require.NoError(t, err)
+
+	var result struct {
+		Success  bool   `json:"success"`
+		Message  string `json:"message"`
+		Pipeline struct {
+			State  string `json:"state"`
+			Status string `json:"status"`
+		} `json:"pipeline"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(out), &result))
+	assert.False(t, result.Success)
+	assert.Equal(t, "Pipeline completed as SUCCESSFUL before the cancellation took effect", result.Message)
+	assert.Equal(t, "COMPLETED", result.Pipeline.State)
+	assert.Equal(t, "SUCCESSFUL", result.Pipeline.Status)
+}
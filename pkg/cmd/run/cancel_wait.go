@@ -0,0 +1,55 @@
+package run
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/carlosarraes/bt/pkg/api"
+)
+
+// cancelWaitInterval is how often, in seconds, run cancel --wait polls the
+// pipeline
+var cancelWaitInterval = 3
+
+// waitForStop is run cancel --wait: stopping a pipeline is asynchronous, so
+// it polls the pipeline until it reaches a final state and returns it. That
+// is usually STOPPED, but a pipeline about to finish may complete first.
+func (cmd *CancelCmd) waitForStop(ctx context.Context, runCtx *RunContext, pipeline *api.Pipeline) (*api.Pipeline, error) {
+	waitCtx := ctx
+	if cmd.Timeout > 0 {
+		var cancel context.CancelFunc
+		waitCtx, cancel = context.WithTimeout(ctx, cmd.Timeout)
+		defer cancel()
+	}
+
+	if cmd.Output == "table" {
+		fmt.Printf("⏳ Waiting for pipeline #%d to stop...\n", pipeline.BuildNumber)
+	}
+
+	final, err := runCtx.Client.Pipelines.WaitForPipelineCompletion(waitCtx, runCtx.Workspace, runCtx.Repository, pipeline.UUID, cancelWaitInterval)
+	if err != nil {
+		if ctx.Err() == nil && errors.Is(waitCtx.Err(), context.DeadlineExceeded) {
+			return nil, fmt.Errorf("pipeline #%d did not stop within %s; cancellation was requested", pipeline.BuildNumber, cmd.Timeout)
+		}
+		return nil, handlePipelineAPIError(err)
+	}
+	return final, nil
+}
+
+// stopped reports whether the cancellation succeeded: the stop request was
+// accepted, and with --wait the pipeline ended up STOPPED
+func (cmd *CancelCmd) stopped(pipeline *api.Pipeline) bool {
+	return !cmd.Wait || pipelineStatus(pipeline) == "STOPPED"
+}
+
+func (cmd *CancelCmd) resultMessage(pipeline *api.Pipeline) string {
+	switch {
+	case !cmd.Wait:
+		return "Pipeline cancelled successfully"
+	case cmd.stopped(pipeline):
+		return "Pipeline stopped"
+	default:
+		return fmt.Sprintf("Pipeline completed as %s before the cancellation took effect", pipelineStatus(pipeline))
+	}
+}
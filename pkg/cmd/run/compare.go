@@ -0,0 +1,362 @@
+package run
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/carlosarraes/bt/pkg/api"
+	"github.com/carlosarraes/bt/pkg/cmd/shared"
+	"github.com/carlosarraes/bt/pkg/output"
+)
+
+// Step changes reported by run compare
+const (
+	ChangeNewlyFailed = "newly_failed"
+	ChangeNewlyPassed = "newly_passed"
+	ChangeAdded       = "added"
+	ChangeRemoved     = "removed"
+	ChangeSlower      = "slower"
+	ChangeFaster      = "faster"
+)
+
+// minRegressionSeconds keeps short steps from being flagged for noise-level
+// changes that happen to be a large percentage
+const minRegressionSeconds = 30
+
+// CompareCmd handles the run compare command
+type CompareCmd struct {
+	Base       string
+	Head       string
+	Threshold  int
+	Output     string
+	NoColor    bool
+	Workspace  string
+	Repository string
+}
+
+// TestCounts aggregates the test reports of one step
+type TestCounts struct {
+	Total   int `json:"total" yaml:"total"`
+	Passed  int `json:"passed" yaml:"passed"`
+	Failed  int `json:"failed" yaml:"failed"`
+	Skipped int `json:"skipped" yaml:"skipped"`
+}
+
+// StepSide is one pipeline's view of a step
+type StepSide struct {
+	Status          string      `json:"status" yaml:"status"`
+	DurationSeconds int         `json:"duration_seconds" yaml:"duration_seconds"`
+	Tests           *TestCounts `json:"tests,omitempty" yaml:"tests,omitempty"`
+}
+
+// StepComparison compares a step, matched by name, across two pipelines
+type StepComparison struct {
+	Name         string    `json:"name" yaml:"name"`
+	Base         *StepSide `json:"base,omitempty" yaml:"base,omitempty"`
+	Head         *StepSide `json:"head,omitempty" yaml:"head,omitempty"`
+	DeltaSeconds int       `json:"delta_seconds" yaml:"delta_seconds"`
+	DeltaPercent float64   `json:"delta_percent" yaml:"delta_percent"`
+	Changes      []string  `json:"changes" yaml:"changes"`
+}
+
+// PipelineRef identifies one side of the comparison
+type PipelineRef struct {
+	BuildNumber     int    `json:"build_number" yaml:"build_number"`
+	UUID            string `json:"uuid" yaml:"uuid"`
+	Status          string `json:"status" yaml:"status"`
+	Commit          string `json:"commit,omitempty" yaml:"commit,omitempty"`
+	DurationSeconds int    `json:"duration_seconds" yaml:"duration_seconds"`
+}
+
+// PipelineComparison is the full result of run compare
+type PipelineComparison struct {
+	Base    PipelineRef      `json:"base" yaml:"base"`
+	Head    PipelineRef      `json:"head" yaml:"head"`
+	Steps   []StepComparison `json:"steps" yaml:"steps"`
+	Summary map[string]int   `json:"summary" yaml:"summary"`
+}
+
+// Run executes the run compare command
+func (cmd *CompareCmd) Run(ctx context.Context) error {
+	runCtx, err := shared.NewCommandContext(ctx, cmd.Output, cmd.NoColor)
+	if err != nil {
+		return err
+	}
+
+	if err := runCtx.ResolveRepository(cmd.Workspace, cmd.Repository); err != nil {
+		return err
+	}
+
+	if cmd.Threshold < 0 {
+		return fmt.Errorf("--threshold must not be negative, got %d", cmd.Threshold)
+	}
+
+	base, baseSteps, err := cmd.fetchPipeline(ctx, runCtx, cmd.Base)
+	if err != nil {
+		return err
+	}
+	head, headSteps, err := cmd.fetchPipeline(ctx, runCtx, cmd.Head)
+	if err != nil {
+		return err
+	}
+
+	comparison := comparePipelines(base, baseSteps, head, headSteps, cmd.Threshold)
+	return cmd.formatOutput(runCtx, comparison)
+}
+
+// stepWithTests pairs a step with its aggregated test reports
+type stepWithTests struct {
+	step  *api.PipelineStep
+	tests *TestCounts
+}
+
+// fetchPipeline loads a pipeline, its steps and each step's test counts
+func (cmd *CompareCmd) fetchPipeline(ctx context.Context, runCtx *RunContext, id string) (*api.Pipeline, []stepWithTests, error) {
+	uuid, err := resolvePipelineUUID(ctx, runCtx, id)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	pipeline, err := runCtx.Client.Pipelines.GetPipeline(ctx, runCtx.Workspace, runCtx.Repository, uuid)
+	if err != nil {
+		return nil, nil, handlePipelineAPIError(err)
+	}
+
+	steps, err := runCtx.Client.Pipelines.GetPipelineSteps(ctx, runCtx.Workspace, runCtx.Repository, pipeline.UUID)
+	if err != nil {
+		return nil, nil, handlePipelineAPIError(err)
+	}
+
+	withTests := make([]stepWithTests, 0, len(steps))
+	for _, step := range steps {
+		// Steps without test reports are common; treat errors as "no tests"
+		var tests *TestCounts
+		if reports, err := runCtx.Client.Pipelines.GetStepTestReports(ctx, runCtx.Workspace, runCtx.Repository, pipeline.UUID, step.UUID); err == nil {
+			tests = sumTestReports(reports)
+		}
+		withTests = append(withTests, stepWithTests{step: step, tests: tests})
+	}
+
+	return pipeline, withTests, nil
+}
+
+func sumTestReports(reports []*api.TestReport) *TestCounts {
+	if len(reports) == 0 {
+		return nil
+	}
+	counts := &TestCounts{}
+	for _, r := range reports {
+		counts.Total += r.Total
+		counts.Passed += r.Passed
+		counts.Failed += r.Failed
+		counts.Skipped += r.Skipped
+	}
+	return counts
+}
+
+// comparePipelines matches steps by name and classifies what changed.
+// Steps keep the base pipeline's order; steps only in head come last.
+func comparePipelines(base *api.Pipeline, baseSteps []stepWithTests, head *api.Pipeline, headSteps []stepWithTests, thresholdPercent int) *PipelineComparison {
+	comparison := &PipelineComparison{
+		Base:    pipelineRef(base),
+		Head:    pipelineRef(head),
+		Summary: map[string]int{},
+	}
+
+	headByName := make(map[string]*StepSide)
+	var headOrder []string
+	for _, st := range headSteps {
+		if _, dup := headByName[st.step.Name]; !dup {
+			headOrder = append(headOrder, st.step.Name)
+		}
+		headByName[st.step.Name] = stepSide(st)
+	}
+
+	seen := make(map[string]bool)
+	for _, st := range baseSteps {
+		if seen[st.step.Name] {
+			continue
+		}
+		seen[st.step.Name] = true
+		comparison.Steps = append(comparison.Steps, compareStep(st.step.Name, stepSide(st), headByName[st.step.Name], thresholdPercent))
+	}
+	for _, name := range headOrder {
+		if !seen[name] {
+			comparison.Steps = append(comparison.Steps, compareStep(name, nil, headByName[name], thresholdPercent))
+		}
+	}
+
+	for _, sc := range comparison.Steps {
+		for _, change := range sc.Changes {
+			comparison.Summary[change]++
+		}
+	}
+
+	return comparison
+}
+
+func compareStep(name string, base, head *StepSide, thresholdPercent int) StepComparison {
+	sc := StepComparison{Name: name, Base: base, Head: head, Changes: []string{}}
+
+	switch {
+	case base == nil:
+		sc.Changes = append(sc.Changes, ChangeAdded)
+		return sc
+	case head == nil:
+		sc.Changes = append(sc.Changes, ChangeRemoved)
+		return sc
+	}
+
+	if isFailureStatus(head.Status) && !isFailureStatus(base.Status) {
+		sc.Changes = append(sc.Changes, ChangeNewlyFailed)
+	} else if isFailureStatus(base.Status) && head.Status == "SUCCESSFUL" {
+		sc.Changes = append(sc.Changes, ChangeNewlyPassed)
+	}
+
+	sc.DeltaSeconds = head.DurationSeconds - base.DurationSeconds
+	if base.DurationSeconds > 0 {
+		sc.DeltaPercent = float64(sc.DeltaSeconds) * 100 / float64(base.DurationSeconds)
+	}
+
+	delta := sc.DeltaSeconds
+	if delta < 0 {
+		delta = -delta
+	}
+	if base.DurationSeconds > 0 && delta >= minRegressionSeconds && delta*100 >= base.DurationSeconds*thresholdPercent {
+		if sc.DeltaSeconds > 0 {
+			sc.Changes = append(sc.Changes, ChangeSlower)
+		} else {
+			sc.Changes = append(sc.Changes, ChangeFaster)
+		}
+	}
+
+	return sc
+}
+
+func stepSide(st stepWithTests) *StepSide {
+	return &StepSide{
+		Status:          stepResultName(st.step),
+		DurationSeconds: st.step.BuildSecondsUsed,
+		Tests:           st.tests,
+	}
+}
+
+func pipelineRef(p *api.Pipeline) PipelineRef {
+	ref := PipelineRef{
+		BuildNumber:     p.BuildNumber,
+		UUID:            p.UUID,
+		Status:          "UNKNOWN",
+		DurationSeconds: p.BuildSecondsUsed,
+	}
+	if p.State != nil {
+		ref.Status = p.State.Name
+		if p.State.Result != nil && p.State.Result.Name != "" {
+			ref.Status = p.State.Result.Name
+		}
+	}
+	if p.Target != nil && p.Target.Commit != nil {
+		ref.Commit = p.Target.Commit.Hash
+	}
+	return ref
+}
+
+func isFailureStatus(status string) bool {
+	return status == "FAILED" || status == "ERROR"
+}
+
+func (cmd *CompareCmd) formatOutput(runCtx *RunContext, comparison *PipelineComparison) error {
+	switch cmd.Output {
+	case "table":
+		return cmd.formatTable(comparison)
+	case "json", "yaml":
+		return runCtx.Formatter.Format(comparison)
+	default:
+		return fmt.Errorf("unsupported output format: %s", cmd.Output)
+	}
+}
+
+func (cmd *CompareCmd) formatTable(c *PipelineComparison) error {
+	fmt.Printf("Comparing pipeline #%d (%s) → #%d (%s)\n\n",
+		c.Base.BuildNumber, c.Base.Status, c.Head.BuildNumber, c.Head.Status)
+
+	baseLabel := fmt.Sprintf("#%d", c.Base.BuildNumber)
+	headLabel := fmt.Sprintf("#%d", c.Head.BuildNumber)
+	headers := []string{"STEP", baseLabel, headLabel, "DURATION", "TESTS", "CHANGE"}
+
+	rows := make([][]string, 0, len(c.Steps))
+	for _, sc := range c.Steps {
+		rows = append(rows, []string{
+			sc.Name,
+			sideStatus(sc.Base),
+			sideStatus(sc.Head),
+			durationChange(sc),
+			testsChange(sc.Base, sc.Head),
+			changeLabel(sc.Changes),
+		})
+	}
+
+	if err := output.RenderSimpleTable(headers, rows); err != nil {
+		return err
+	}
+
+	fmt.Printf("\n%d newly failed, %d newly passed, %d slower, %d faster, %d added, %d removed\n",
+		c.Summary[ChangeNewlyFailed], c.Summary[ChangeNewlyPassed], c.Summary[ChangeSlower],
+		c.Summary[ChangeFaster], c.Summary[ChangeAdded], c.Summary[ChangeRemoved])
+	return nil
+}
+
+func sideStatus(side *StepSide) string {
+	if side == nil {
+		return "-"
+	}
+	return side.Status
+}
+
+func durationChange(sc StepComparison) string {
+	switch {
+	case sc.Base == nil:
+		return output.FormatDuration(sc.Head.DurationSeconds)
+	case sc.Head == nil:
+		return output.FormatDuration(sc.Base.DurationSeconds)
+	}
+
+	s := fmt.Sprintf("%s → %s", output.FormatDuration(sc.Base.DurationSeconds), output.FormatDuration(sc.Head.DurationSeconds))
+	if sc.Base.DurationSeconds > 0 && sc.DeltaSeconds != 0 {
+		s += fmt.Sprintf(" (%+.0f%%)", sc.DeltaPercent)
+	}
+	return s
+}
+
+func testsChange(base, head *StepSide) string {
+	format := func(side *StepSide) string {
+		if side == nil || side.Tests == nil {
+			return "-"
+		}
+		return fmt.Sprintf("%d/%d", side.Tests.Passed, side.Tests.Failed)
+	}
+	b, h := format(base), format(head)
+	if b == "-" && h == "-" {
+		return ""
+	}
+	return fmt.Sprintf("%s → %s", b, h)
+}
+
+func changeLabel(changes []string) string {
+	labels := make([]string, 0, len(changes))
+	for _, change := range changes {
+		switch change {
+		case ChangeNewlyFailed:
+			labels = append(labels, "✗ newly failed")
+		case ChangeNewlyPassed:
+			labels = append(labels, "✓ newly passed")
+		case ChangeSlower:
+			labels = append(labels, "▲ slower")
+		case ChangeFaster:
+			labels = append(labels, "▼ faster")
+		default:
+			labels = append(labels, change)
+		}
+	}
+	return strings.Join(labels, ", ")
+}
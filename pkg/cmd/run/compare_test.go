@@ -0,0 +1,105 @@
+package run
+
+import (
+	"testing"
+
+	"github.com/carlosarraes/bt/pkg/api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func compareStepFixture(name, result string, seconds int, tests *TestCounts) stepWithTests {
+	return stepWithTests{
+		step: &api.PipelineStep{
+			Name:             name,
+			BuildSecondsUsed: seconds,
+			State:            &api.PipelineState{Name: "COMPLETED", Result: &api.PipelineResult{Name: result}},
+		},
+		tests: tests,
+	}
+}
+
+func TestCompareStep(t *testing.T) {
+	tests := []struct {
+		name    string
+		base    *StepSide
+		head    *StepSide
+		changes []string
+	}{
+		{"newly failed", &StepSide{Status: "SUCCESSFUL", DurationSeconds: 60}, &StepSide{Status: "FAILED", DurationSeconds: 60}, []string{ChangeNewlyFailed}},
+		{"newly passed", &StepSide{Status: "ERROR", DurationSeconds: 60}, &StepSide{Status: "SUCCESSFUL", DurationSeconds: 60}, []string{ChangeNewlyPassed}},
+		{"still failing", &StepSide{Status: "FAILED", DurationSeconds: 60}, &StepSide{Status: "FAILED", DurationSeconds: 60}, []string{}},
+		{"slower", &StepSide{Status: "SUCCESSFUL", DurationSeconds: 100}, &StepSide{Status: "SUCCESSFUL", DurationSeconds: 160}, []string{ChangeSlower}},
+		{"faster", &StepSide{Status: "SUCCESSFUL", DurationSeconds: 300}, &StepSide{Status: "SUCCESSFUL", DurationSeconds: 200}, []string{ChangeFaster}},
+		{"below threshold", &StepSide{Status: "SUCCESSFUL", DurationSeconds: 600}, &StepSide{Status: "SUCCESSFUL", DurationSeconds: 660}, []string{}},
+		{"short step noise", &StepSide{Status: "SUCCESSFUL", DurationSeconds: 10}, &StepSide{Status: "SUCCESSFUL", DurationSeconds: 30}, []string{}},
+		{"failed and slower", &StepSide{Status: "SUCCESSFUL", DurationSeconds: 100}, &StepSide{Status: "FAILED", DurationSeconds: 200}, []string{ChangeNewlyFailed, ChangeSlower}},
+		{"added", nil, &StepSide{Status: "SUCCESSFUL"}, []string{ChangeAdded}},
+		{"removed", &StepSide{Status: "SUCCESSFUL"}, nil, []string{ChangeRemoved}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sc := compareStep("step", tt.base, tt.head, 20)
+			assert.Equal(t, tt.changes, sc.Changes)
+		})
+	}
+}
+
+func TestCompareStep_Delta(t *testing.T) {
+	sc := compareStep("build", &StepSide{DurationSeconds: 200}, &StepSide{DurationSeconds: 250}, 20)
+
+	assert.Equal(t, 50, sc.DeltaSeconds)
+	assert.InDelta(t, 25.0, sc.DeltaPercent, 0.001)
+}
+
+func TestComparePipelines(t *testing.T) {
+	base := &api.Pipeline{BuildNumber: 120, State: &api.PipelineState{Name: "COMPLETED", Result: &api.PipelineResult{Name: "SUCCESSFUL"}}}
+	head := &api.Pipeline{BuildNumber: 123, State: &api.PipelineState{Name: "COMPLETED", Result: &api.PipelineResult{Name: "FAILED"}}}
+
+	baseSteps := []stepWithTests{
+		compareStepFixture("build", "SUCCESSFUL", 120, nil),
+		compareStepFixture("test", "SUCCESSFUL", 300, &TestCounts{Total: 50, Passed: 50}),
+		compareStepFixture("lint", "SUCCESSFUL", 40, nil),
+	}
+	headSteps := []stepWithTests{
+		compareStepFixture("build", "SUCCESSFUL", 125, nil),
+		compareStepFixture("security", "SUCCESSFUL", 60, nil),
+		compareStepFixture("test", "FAILED", 420, &TestCounts{Total: 50, Passed: 48, Failed: 2}),
+	}
+
+	c := comparePipelines(base, baseSteps, head, headSteps, 20)
+
+	assert.Equal(t, "SUCCESSFUL", c.Base.Status)
+	assert.Equal(t, "FAILED", c.Head.Status)
+
+	require.Len(t, c.Steps, 4)
+	names := []string{c.Steps[0].Name, c.Steps[1].Name, c.Steps[2].Name, c.Steps[3].Name}
+	assert.Equal(t, []string{"build", "test", "lint", "security"}, names)
+
+	assert.Empty(t, c.Steps[0].Changes)
+	assert.Equal(t, []string{ChangeNewlyFailed, ChangeSlower}, c.Steps[1].Changes)
+	assert.Equal(t, 2, c.Steps[1].Head.Tests.Failed)
+	assert.Equal(t, []string{ChangeRemoved}, c.Steps[2].Changes)
+	assert.Equal(t, []string{ChangeAdded}, c.Steps[3].Changes)
+
+	assert.Equal(t, map[string]int{ChangeNewlyFailed: 1, ChangeSlower: 1, ChangeRemoved: 1, ChangeAdded: 1}, c.Summary)
+}
+
+func TestSumTestReports(t *testing.T) {
+	assert.Nil(t, sumTestReports(nil))
+
+	counts := sumTestReports([]*api.TestReport{
+		{Total: 10, Passed: 8, Failed: 1, Skipped: 1},
+		{Total: 5, Passed: 5},
+	})
+	assert.Equal(t, &TestCounts{Total: 15, Passed: 13, Failed: 1, Skipped: 1}, counts)
+}
+
+func TestCompareFormatOutput_Unsupported(t *testing.T) {
+	cmd := &CompareCmd{Output: "xml"}
+	err := cmd.formatOutput(nil, &PipelineComparison{})
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unsupported output format")
+}
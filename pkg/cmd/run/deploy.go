@@ -0,0 +1,130 @@
+package run
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/carlosarraes/bt/pkg/api"
+	"github.com/carlosarraes/bt/pkg/cmd/shared"
+)
+
+// DeployCmd handles the run deploy command
+type DeployCmd struct {
+	PipelineID string
+	Env        string
+	Web        bool
+	Output     string
+	NoColor    bool
+	Workspace  string
+	Repository string
+}
+
+// Run executes the run deploy command
+func (cmd *DeployCmd) Run(ctx context.Context) error {
+	runCtx, err := shared.NewCommandContext(ctx, cmd.Output, cmd.NoColor)
+	if err != nil {
+		return err
+	}
+
+	if err := runCtx.ResolveRepository(cmd.Workspace, cmd.Repository); err != nil {
+		return err
+	}
+
+	pipelineUUID, err := resolvePipelineUUID(ctx, runCtx, cmd.PipelineID)
+	if err != nil {
+		return err
+	}
+
+	pipeline, err := runCtx.Client.Pipelines.GetPipeline(ctx, runCtx.Workspace, runCtx.Repository, pipelineUUID)
+	if err != nil {
+		return handlePipelineAPIError(err)
+	}
+
+	environments, err := runCtx.Client.Deployments.ListEnvironments(ctx, runCtx.Workspace, runCtx.Repository)
+	if err != nil {
+		return handlePipelineAPIError(err)
+	}
+
+	env, err := shared.FindEnvironment(environments, cmd.Env)
+	if err != nil {
+		return err
+	}
+
+	steps, err := runCtx.Client.Pipelines.GetPipelineSteps(ctx, runCtx.Workspace, runCtx.Repository, pipeline.UUID)
+	if err != nil {
+		return handlePipelineAPIError(err)
+	}
+
+	deployment, step, err := findPipelineDeployment(ctx, runCtx, steps, env)
+	if err != nil {
+		return handlePipelineAPIError(err)
+	}
+	if deployment == nil {
+		return fmt.Errorf("pipeline #%d has no deployment step for %s", pipeline.BuildNumber, env.Name)
+	}
+
+	status := deploymentStatus(deployment)
+	if status == "IN_PROGRESS" {
+		return fmt.Errorf("pipeline #%d is already deploying to %s", pipeline.BuildNumber, env.Name)
+	}
+	if status != "UNDEPLOYED" {
+		return fmt.Errorf("pipeline #%d was already deployed to %s (%s); rerun the pipeline with 'bt run rerun %d' to deploy it again",
+			pipeline.BuildNumber, env.Name, status, pipeline.BuildNumber)
+	}
+
+	url := pipelineWebURL(runCtx, pipeline)
+
+	if runCtx.DryRun {
+		plan := shared.NewDryRunPlan("Would start step '%s' of pipeline #%d to deploy to %s", step.Name, pipeline.BuildNumber, env.Name)
+		plan.Add("POST", api.StartStepEndpoint(runCtx.Workspace, runCtx.Repository, pipeline.UUID, step.UUID), nil)
+		return runCtx.PrintDryRun(plan, cmd.Output)
+	}
+
+	if err := runCtx.Client.Pipelines.StartStep(ctx, runCtx.Workspace, runCtx.Repository, pipeline.UUID, step.UUID); err != nil {
+		return fmt.Errorf("failed to start step '%s' of pipeline #%d, start it from %s: %w", step.Name, pipeline.BuildNumber, url, err)
+	}
+
+	if cmd.Output != "table" {
+		return runCtx.Formatter.Format(map[string]interface{}{
+			"pipeline":    pipeline.BuildNumber,
+			"environment": env,
+			"step":        step,
+			"status":      "STARTED",
+			"url":         url,
+		})
+	}
+
+	fmt.Printf("🚀 Started step '%s' of pipeline #%d, deploying to %s\n", step.Name, pipeline.BuildNumber, env.Name)
+	fmt.Printf("   %s\n", url)
+
+	if cmd.Web {
+		return shared.LaunchBrowser(url)
+	}
+	return nil
+}
+
+// findPipelineDeployment pages through the repository's deployments for the
+// one of the pipeline's steps to env, and returns it together with that step
+func findPipelineDeployment(ctx context.Context, runCtx *RunContext, steps []*api.PipelineStep, env *api.DeploymentEnvironment) (*api.Deployment, *api.PipelineStep, error) {
+	stepsByUUID := make(map[string]*api.PipelineStep, len(steps))
+	for _, step := range steps {
+		stepsByUUID[step.UUID] = step
+	}
+
+	deployment, err := runCtx.Client.Deployments.FindDeployment(ctx, runCtx.Workspace, runCtx.Repository, func(deployment *api.Deployment) bool {
+		if deployment.Environment == nil || deployment.Environment.UUID != env.UUID || deployment.Step == nil {
+			return false
+		}
+		_, ok := stepsByUUID[deployment.Step.UUID]
+		return ok
+	})
+	if err != nil || deployment == nil {
+		return nil, nil, err
+	}
+	return deployment, stepsByUUID[deployment.Step.UUID], nil
+}
+
+func pipelineWebURL(runCtx *RunContext, pipeline *api.Pipeline) string {
+	return fmt.Sprintf("https://bitbucket.org/%s/%s/addon/pipelines/home#!/results/%d",
+		runCtx.Workspace, runCtx.Repository, pipeline.BuildNumber)
+}
@@ -0,0 +1,135 @@
+package run
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/carlosarraes/bt/pkg/api"
+	"github.com/carlosarraes/bt/pkg/cmd/shared"
+	"github.com/carlosarraes/bt/pkg/output"
+)
+
+// deploymentHistoryLimit is how many recent deployments are scanned for the
+// latest deployment of each environment
+const deploymentHistoryLimit = 100
+
+// DeploymentsCmd handles the run deployments command
+type DeploymentsCmd struct {
+	Output     string
+	NoColor    bool
+	Workspace  string
+	Repository string
+}
+
+// environmentDeployment pairs an environment with its latest deployment,
+// which is nil when it has not been deployed to recently
+type environmentDeployment struct {
+	Environment *api.DeploymentEnvironment `json:"environment"`
+	Latest      *api.Deployment            `json:"latest_deployment,omitempty"`
+}
+
+// Run executes the run deployments command
+func (cmd *DeploymentsCmd) Run(ctx context.Context) error {
+	runCtx, err := shared.NewCommandContext(ctx, cmd.Output, cmd.NoColor)
+	if err != nil {
+		return err
+	}
+
+	if err := runCtx.ResolveRepository(cmd.Workspace, cmd.Repository); err != nil {
+		return err
+	}
+
+	environments, err := runCtx.Client.Deployments.ListEnvironments(ctx, runCtx.Workspace, runCtx.Repository)
+	if err != nil {
+		return handlePipelineAPIError(err)
+	}
+
+	deployments, err := runCtx.Client.Deployments.ListDeployments(ctx, runCtx.Workspace, runCtx.Repository, deploymentHistoryLimit)
+	if err != nil {
+		return handlePipelineAPIError(err)
+	}
+
+	return cmd.formatOutput(runCtx, latestDeployments(environments, deployments))
+}
+
+// latestDeployments finds, for each environment, the most recent deployment
+// that was actually started; deployments must be sorted newest first
+func latestDeployments(environments []*api.DeploymentEnvironment, deployments []*api.Deployment) []environmentDeployment {
+	result := make([]environmentDeployment, 0, len(environments))
+	for _, env := range environments {
+		entry := environmentDeployment{Environment: env}
+		for _, deployment := range deployments {
+			if deployment.Environment == nil || deployment.Environment.UUID != env.UUID {
+				continue
+			}
+			if deployment.State == nil || deployment.State.Name == "UNDEPLOYED" {
+				continue
+			}
+			entry.Latest = deployment
+			break
+		}
+		result = append(result, entry)
+	}
+	return result
+}
+
+// deploymentStatus returns the outcome of a completed deployment and the
+// state (IN_PROGRESS, UNDEPLOYED) of any other
+func deploymentStatus(deployment *api.Deployment) string {
+	if deployment.State == nil {
+		return "UNKNOWN"
+	}
+	if deployment.State.Status != nil && deployment.State.Status.Name != "" {
+		return deployment.State.Status.Name
+	}
+	return deployment.State.Name
+}
+
+func (cmd *DeploymentsCmd) formatOutput(runCtx *RunContext, entries []environmentDeployment) error {
+	switch cmd.Output {
+	case "table":
+		return cmd.formatTable(entries)
+	case "json", "yaml":
+		return runCtx.Formatter.Format(map[string]interface{}{
+			"environments": entries,
+			"total_count":  len(entries),
+		})
+	default:
+		return fmt.Errorf("unsupported output format: %s", cmd.Output)
+	}
+}
+
+func (cmd *DeploymentsCmd) formatTable(entries []environmentDeployment) error {
+	if len(entries) == 0 {
+		fmt.Println("No deployment environments found")
+		return nil
+	}
+
+	headers := []string{"Environment", "Type", "Status", "Release", "Commit", "Deployed"}
+	rows := make([][]string, len(entries))
+
+	for i, entry := range entries {
+		envType := "-"
+		if entry.Environment.EnvironmentType != nil {
+			envType = entry.Environment.EnvironmentType.Name
+		}
+
+		status, release, commit, deployed := "NOT DEPLOYED", "-", "-", "-"
+		if deployment := entry.Latest; deployment != nil {
+			status = deploymentStatus(deployment)
+			deployed = output.FormatRelativeTime(deployment.State.StartedOn)
+			if deployment.Release != nil {
+				if deployment.Release.Name != "" {
+					release = deployment.Release.Name
+				}
+				if deployment.Release.Commit != nil && deployment.Release.Commit.Hash != "" {
+					commit = shortSHA(deployment.Release.Commit.Hash)
+				}
+			}
+		}
+
+		rows[i] = []string{entry.Environment.Name, envType, status, release, commit, deployed}
+	}
+
+	return output.RenderSimpleTable(headers, rows)
+}
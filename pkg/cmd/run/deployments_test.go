@@ -0,0 +1,107 @@
+package run
+
+import (
+	"context"
+	"testing"
+
+	"github.com/carlosarraes/bt/pkg/api"
+	"github.com/carlosarraes/bt/pkg/api/apitest"
+	"github.com/carlosarraes/bt/pkg/cmd/shared"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testDeployment(uuid, envUUID, stepUUID, state, status string) *api.Deployment {
+	deployment := &api.Deployment{
+		UUID:        uuid,
+		Environment: &api.DeploymentEnvironment{UUID: envUUID},
+		Step:        &api.PipelineStep{UUID: stepUUID},
+		State:       &api.DeploymentState{Name: state},
+	}
+	if status != "" {
+		deployment.State.Status = &api.DeploymentStatus{Name: status}
+	}
+	return deployment
+}
+
+func TestLatestDeployments(t *testing.T) {
+	environments := []*api.DeploymentEnvironment{
+		{UUID: "{staging}", Name: "Staging"},
+		{UUID: "{production}", Name: "Production"},
+		{UUID: "{test}", Name: "Test"},
+	}
+	deployments := []*api.Deployment{
+		testDeployment("{d4}", "{production}", "{s4}", "UNDEPLOYED", ""),
+		testDeployment("{d3}", "{staging}", "{s3}", "IN_PROGRESS", ""),
+		testDeployment("{d2}", "{production}", "{s2}", "COMPLETED", "FAILED"),
+		testDeployment("{d1}", "{staging}", "{s1}", "COMPLETED", "SUCCESSFUL"),
+	}
+
+	entries := latestDeployments(environments, deployments)
+	require.Len(t, entries, 3)
+	assert.Equal(t, "{d3}", entries[0].Latest.UUID)
+	assert.Equal(t, "{d2}", entries[1].Latest.UUID, "undeployed manual steps are skipped")
+	assert.Nil(t, entries[2].Latest)
+
+	assert.Equal(t, "IN_PROGRESS", deploymentStatus(entries[0].Latest))
+	assert.Equal(t, "FAILED", deploymentStatus(entries[1].Latest))
+}
+
+// deployFixtures serves pipeline #12 with a deploy step whose deployment
+// to production is on the second page of deployments, in state
+func deployFixtures(state string) []apitest.Fixture {
+	return []apitest.Fixture{
+		{Method: "GET", Path: "/repositories/ws/repo/pipelines/{p-12}", Status: 200,
+			Body: []byte(`{"uuid": "{p-12}", "build_number": 12}`)},
+		{Method: "GET", Path: "/repositories/ws/repo/environments", Status: 200,
+			Body: []byte(`{"values": [{"uuid": "{production}", "name": "Production"}]}`)},
+		{Method: "GET", Path: "/repositories/ws/repo/pipelines/{p-12}/steps", Status: 200,
+			Body: []byte(`{"values": [{"uuid": "{build}", "name": "Build"}, {"uuid": "{deploy}", "name": "Deploy to production"}]}`)},
+		{Method: "GET", Path: "/repositories/ws/repo/deployments", Status: 200,
+			Body: []byte(`{"values": [
+				{"uuid": "{other}", "environment": {"uuid": "{production}"}, "step": {"uuid": "{older-step}"}, "state": {"name": "COMPLETED"}}
+			], "next": "https://api.bitbucket.org/2.0/repositories/ws/repo/deployments?page=2"}`)},
+		{Method: "GET", Path: "/repositories/ws/repo/deployments", Query: "page=2", Status: 200,
+			Body: []byte(`{"values": [
+				{"uuid": "{d1}", "environment": {"uuid": "{production}"}, "step": {"uuid": "{deploy}"}, "state": {"name": "` + state + `"}}
+			]}`)},
+	}
+}
+
+func TestDeployCmd_Run_StartsStep(t *testing.T) {
+	apitest.CommandEnv(t, "ws", "repo")
+	transport := apitest.NewReplayTransport(append(deployFixtures("UNDEPLOYED"), apitest.Fixture{
+		Method: "POST", Path: "/repositories/ws/repo/pipelines/{p-12}/steps/{deploy}/start", Status: 204,
+	})...)
+	t.Cleanup(shared.SetClientTransport(transport))
+
+	var runErr error
+	out := captureStdout(func() {
+		cmd := &DeployCmd{PipelineID: "{p-12}", Env: "production", Output: "table", NoColor: true}
+		runErr = cmd.Run(context.Background())
+	})
+	require.NoError(t, runErr)
+
+	assert.Contains(t, out, "Started step 'Deploy to production' of pipeline #12")
+	requests := transport.Requests()
+	last := requests[len(requests)-1]
+	assert.Equal(t, "POST", last.Method)
+	assert.Equal(t, "/repositories/ws/repo/pipelines/{p-12}/steps/{deploy}/start", last.Path)
+}
+
+func TestDeployCmd_Run_AlreadyDeployed(t *testing.T) {
+	for _, output := range []string{"table", "json"} {
+		t.Run(output, func(t *testing.T) {
+			apitest.CommandEnv(t, "ws", "repo")
+			transport := apitest.NewReplayTransport(deployFixtures("IN_PROGRESS")...)
+			t.Cleanup(shared.SetClientTransport(transport))
+
+			cmd := &DeployCmd{PipelineID: "{p-12}", Env: "production", Output: output, NoColor: true}
+			err := cmd.Run(context.Background())
+			assert.ErrorContains(t, err, "pipeline #12 is already deploying to Production")
+			for _, req := range transport.Requests() {
+				assert.NotEqual(t, "POST", req.Method)
+			}
+		})
+	}
+}
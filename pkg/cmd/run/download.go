@@ -0,0 +1,91 @@
+package run
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+
+	"github.com/carlosarraes/bt/pkg/api"
+	"github.com/carlosarraes/bt/pkg/cmd/shared"
+)
+
+// DownloadCmd handles the run download command
+type DownloadCmd struct {
+	Name       string
+	Dir        string
+	Verify     bool
+	Output     string
+	NoColor    bool
+	Workspace  string
+	Repository string
+}
+
+// downloadResult describes a finished artifact download
+type downloadResult struct {
+	Name     string `json:"name"`
+	Path     string `json:"path"`
+	Size     int64  `json:"size"`
+	Verified bool   `json:"verified"`
+}
+
+// Run executes the run download command
+func (cmd *DownloadCmd) Run(ctx context.Context) error {
+	runCtx, err := shared.NewCommandContext(ctx, cmd.Output, cmd.NoColor)
+	if err != nil {
+		return err
+	}
+
+	if err := runCtx.ResolveRepository(cmd.Workspace, cmd.Repository); err != nil {
+		return err
+	}
+
+	artifacts, err := runCtx.Client.Pipelines.ListArtifacts(ctx, runCtx.Workspace, runCtx.Repository)
+	if err != nil {
+		return handlePipelineAPIError(err)
+	}
+
+	artifact := findArtifact(artifacts, cmd.Name)
+	if artifact == nil {
+		return fmt.Errorf("no download named %q in %s/%s", cmd.Name, runCtx.Workspace, runCtx.Repository)
+	}
+
+	dir := cmd.Dir
+	if dir == "" {
+		dir = "."
+	}
+	path := filepath.Join(dir, filepath.Base(artifact.Name))
+
+	size, err := runCtx.Client.Pipelines.DownloadArtifactToFile(ctx, runCtx.Workspace, runCtx.Repository, artifact, path, &api.ArtifactDownloadOptions{
+		Verify: cmd.Verify,
+	})
+	if err != nil {
+		return handlePipelineAPIError(err)
+	}
+
+	result := downloadResult{
+		Name:     artifact.Name,
+		Path:     path,
+		Size:     size,
+		Verified: cmd.Verify && artifact.Size > 0,
+	}
+
+	if cmd.Output != "table" {
+		return runCtx.Formatter.Format(result)
+	}
+
+	fmt.Printf("✅ Downloaded %s (%d bytes) to %s\n", result.Name, result.Size, result.Path)
+	if cmd.Verify && !result.Verified {
+		fmt.Println("⚠️  Bitbucket reported no size for this download, so it was not verified")
+	}
+	return nil
+}
+
+// findArtifact returns the download called name, nil when there is none
+func findArtifact(artifacts []*api.Artifact, name string) *api.Artifact {
+	for _, artifact := range artifacts {
+		if artifact.Name == name {
+			return artifact
+		}
+	}
+	return nil
+}
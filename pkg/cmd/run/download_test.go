@@ -0,0 +1,60 @@
+package run
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/carlosarraes/bt/pkg/api/apitest"
+	"github.com/carlosarraes/bt/pkg/cmd/shared"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDownloadCmd_Run(t *testing.T) {
+	apitest.CommandEnv(t, "ws", "repo")
+	transport := apitest.NewReplayTransport(
+		apitest.Fixture{
+			Method: "GET",
+			Path:   "/repositories/ws/repo/downloads",
+			Status: 200,
+			Body:   []byte(`{"values": [{"name": "app.tar.gz", "size": 11}]}`),
+		},
+		apitest.Fixture{
+			Method:  "GET",
+			Path:    "/repositories/ws/repo/downloads/app.tar.gz",
+			Status:  200,
+			RawBody: "hello world",
+		},
+	)
+	t.Cleanup(shared.SetClientTransport(transport))
+
+	dir := t.TempDir()
+	var runErr error
+	out := captureStdout(func() {
+		cmd := &DownloadCmd{Name: "app.tar.gz", Dir: dir, Verify: true, Output: "table", NoColor: true}
+		runErr = cmd.Run(context.Background())
+	})
+	require.NoError(t, runErr)
+
+	assert.Contains(t, out, "Downloaded app.tar.gz (11 bytes)")
+	got, err := os.ReadFile(filepath.Join(dir, "app.tar.gz"))
+	require.NoError(t, err)
+	assert.Equal(t, "hello world", string(got))
+}
+
+func TestDownloadCmd_Run_UnknownName(t *testing.T) {
+	apitest.CommandEnv(t, "ws", "repo")
+	transport := apitest.NewReplayTransport(apitest.Fixture{
+		Method: "GET",
+		Path:   "/repositories/ws/repo/downloads",
+		Status: 200,
+		Body:   []byte(`{"values": [{"name": "app.tar.gz", "size": 11}]}`),
+	})
+	t.Cleanup(shared.SetClientTransport(transport))
+
+	cmd := &DownloadCmd{Name: "missing.zip", Dir: t.TempDir(), Verify: true, Output: "table", NoColor: true}
+	err := cmd.Run(context.Background())
+	assert.ErrorContains(t, err, `no download named "missing.zip"`)
+}
@@ -0,0 +1,71 @@
+package run
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/carlosarraes/bt/pkg/api"
+	"github.com/carlosarraes/bt/pkg/output"
+)
+
+// newEventWriter returns the stdout event writer for --events, or nil when
+// events are off
+func newEventWriter(format string) (*output.EventWriter, error) {
+	if err := output.ValidateEventsFormat(format); err != nil {
+		return nil, err
+	}
+	if format == "" {
+		return nil, nil
+	}
+	return output.NewEventWriter(os.Stdout), nil
+}
+
+// stepEvents turns successive polls of a pipeline's steps into
+// step_started and step_completed events, each sent once per step
+type stepEvents struct {
+	pipeline  int
+	started   map[string]bool
+	completed map[string]bool
+}
+
+func newStepEvents(pipeline int) *stepEvents {
+	return &stepEvents{
+		pipeline:  pipeline,
+		started:   make(map[string]bool),
+		completed: make(map[string]bool),
+	}
+}
+
+// update returns the events for the steps whose state changed since the
+// previous poll. A step that finished between two polls only completes.
+func (s *stepEvents) update(steps []*api.PipelineStep) []output.Event {
+	var events []output.Event
+	for _, step := range steps {
+		if step.State == nil || s.completed[step.UUID] {
+			continue
+		}
+
+		switch step.State.Name {
+		case "IN_PROGRESS":
+			if !s.started[step.UUID] {
+				s.started[step.UUID] = true
+				events = append(events, output.Event{Event: output.EventStepStarted, Pipeline: s.pipeline, Step: step.Name, Status: step.State.Name})
+			}
+		case "COMPLETED":
+			s.started[step.UUID] = true
+			s.completed[step.UUID] = true
+			events = append(events, output.Event{Event: output.EventStepCompleted, Pipeline: s.pipeline, Step: step.Name, Status: stepResultName(step)})
+		}
+	}
+	return events
+}
+
+// emitEvents writes events, stopping at the first write error
+func emitEvents(w *output.EventWriter, events []output.Event) error {
+	for _, event := range events {
+		if err := w.Emit(event); err != nil {
+			return fmt.Errorf("failed to write event: %w", err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,56 @@
+package run
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/carlosarraes/bt/pkg/api"
+	"github.com/carlosarraes/bt/pkg/output"
+)
+
+func TestStepEvents_update(t *testing.T) {
+	step := func(uuid, name, state, result string) *api.PipelineStep {
+		s := &api.PipelineStep{UUID: uuid, Name: name, State: &api.PipelineState{Name: state}}
+		if result != "" {
+			s.State.Result = &api.PipelineResult{Name: result}
+		}
+		return s
+	}
+
+	tracker := newStepEvents(42)
+
+	events := tracker.update([]*api.PipelineStep{
+		step("1", "Build", "IN_PROGRESS", ""),
+		step("2", "Test", "PENDING", ""),
+	})
+	require.Len(t, events, 1)
+	assert.Equal(t, output.Event{Event: output.EventStepStarted, Pipeline: 42, Step: "Build", Status: "IN_PROGRESS"}, events[0])
+
+	assert.Empty(t, tracker.update([]*api.PipelineStep{step("1", "Build", "IN_PROGRESS", "")}), "no change, no event")
+
+	// Test finished between polls: it only completes
+	events = tracker.update([]*api.PipelineStep{
+		step("1", "Build", "COMPLETED", "SUCCESSFUL"),
+		step("2", "Test", "COMPLETED", "FAILED"),
+	})
+	require.Len(t, events, 2)
+	assert.Equal(t, output.Event{Event: output.EventStepCompleted, Pipeline: 42, Step: "Build", Status: "SUCCESSFUL"}, events[0])
+	assert.Equal(t, output.Event{Event: output.EventStepCompleted, Pipeline: 42, Step: "Test", Status: "FAILED"}, events[1])
+
+	assert.Empty(t, tracker.update([]*api.PipelineStep{step("2", "Test", "COMPLETED", "FAILED")}), "completed once")
+}
+
+func TestNewEventWriter(t *testing.T) {
+	w, err := newEventWriter("")
+	require.NoError(t, err)
+	assert.Nil(t, w)
+
+	w, err = newEventWriter("jsonl")
+	require.NoError(t, err)
+	assert.NotNil(t, w)
+
+	_, err = newEventWriter("xml")
+	assert.Error(t, err)
+}
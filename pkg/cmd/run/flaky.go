@@ -0,0 +1,298 @@
+package run
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/carlosarraes/bt/pkg/api"
+	"github.com/carlosarraes/bt/pkg/output"
+)
+
+// Test classifications reported by run view --tests --history
+const (
+	TestFlaky   = "flaky"
+	TestFailing = "failing"
+	TestStable  = "stable"
+)
+
+// maxHistoryLimit caps how many pipelines --history inspects; each pipeline
+// costs one request per step
+const maxHistoryLimit = 50
+
+// TestHistory is a single test case tracked across recent pipelines
+type TestHistory struct {
+	Name           string   `json:"name" yaml:"name"`
+	Classification string   `json:"classification" yaml:"classification"`
+	Runs           int      `json:"runs" yaml:"runs"`
+	Passed         int      `json:"passed" yaml:"passed"`
+	Failed         int      `json:"failed" yaml:"failed"`
+	PassRate       float64  `json:"pass_rate" yaml:"pass_rate"`
+	Flips          int      `json:"flips" yaml:"flips"`
+	Outcomes       []string `json:"outcomes" yaml:"outcomes"` // Oldest first: passed, failed or "" when the test did not run
+}
+
+// TestHistoryReport summarizes test outcomes over recent pipelines of a branch
+type TestHistoryReport struct {
+	Branch    string        `json:"branch" yaml:"branch"`
+	Pipelines []int         `json:"pipelines" yaml:"pipelines"` // Build numbers, oldest first
+	Flaky     int           `json:"flaky" yaml:"flaky"`
+	Failing   int           `json:"failing" yaml:"failing"`
+	Stable    int           `json:"stable" yaml:"stable"`
+	Tests     []TestHistory `json:"tests" yaml:"tests"` // Flaky and failing tests only
+}
+
+// pipelineTestCases holds the test outcomes of one pipeline keyed by test name
+type pipelineTestCases struct {
+	buildNumber int
+	outcomes    map[string]string
+}
+
+// viewTestHistory correlates test cases over the last HistoryLimit pipelines
+// of the viewed pipeline's branch and reports flaky and failing tests
+func (cmd *ViewCmd) viewTestHistory(ctx context.Context, runCtx *RunContext, pipelineUUID string) error {
+	if cmd.HistoryLimit < 2 || cmd.HistoryLimit > maxHistoryLimit {
+		return fmt.Errorf("--history-limit must be between 2 and %d, got %d", maxHistoryLimit, cmd.HistoryLimit)
+	}
+
+	pipeline, err := runCtx.Client.Pipelines.GetPipeline(ctx, runCtx.Workspace, runCtx.Repository, pipelineUUID)
+	if err != nil {
+		return handlePipelineAPIError(err)
+	}
+
+	if pipeline.Target == nil || pipeline.Target.RefName == "" {
+		return fmt.Errorf("pipeline #%d has no branch to compare test history against", pipeline.BuildNumber)
+	}
+	branch := pipeline.Target.RefName
+
+	result, err := runCtx.Client.Pipelines.ListPipelines(ctx, runCtx.Workspace, runCtx.Repository, &api.PipelineListOptions{
+		Branch:  branch,
+		Sort:    "-created_on",
+		Page:    1,
+		PageLen: maxHistoryLimit,
+	})
+	if err != nil {
+		return handlePipelineAPIError(err)
+	}
+
+	recent, err := parsePipelineResults(result)
+	if err != nil {
+		return fmt.Errorf("failed to parse pipeline results: %w", err)
+	}
+
+	history := historyPipelines(pipeline, recent, cmd.HistoryLimit)
+
+	runs := make([]pipelineTestCases, 0, len(history))
+	for i := len(history) - 1; i >= 0; i-- {
+		runs = append(runs, cmd.fetchTestOutcomes(ctx, runCtx, history[i]))
+	}
+
+	report := computeTestHistory(runs)
+	report.Branch = branch
+
+	if cmd.Output != "table" {
+		return runCtx.Formatter.Format(report)
+	}
+	return formatTestHistory(report)
+}
+
+// historyPipelines picks up to limit completed pipelines, newest first, ending
+// at the viewed pipeline so later runs don't leak into the history
+func historyPipelines(current *api.Pipeline, recent []*api.Pipeline, limit int) []*api.Pipeline {
+	history := []*api.Pipeline{current}
+	for _, p := range recent {
+		if len(history) >= limit {
+			break
+		}
+		if p.UUID == current.UUID || p.BuildNumber > current.BuildNumber {
+			continue
+		}
+		if p.State == nil || p.State.Name != "COMPLETED" {
+			continue
+		}
+		history = append(history, p)
+	}
+	return history
+}
+
+// fetchTestOutcomes collects the outcome of every test case in a pipeline.
+// Steps without test reports are skipped.
+func (cmd *ViewCmd) fetchTestOutcomes(ctx context.Context, runCtx *RunContext, pipeline *api.Pipeline) pipelineTestCases {
+	run := pipelineTestCases{buildNumber: pipeline.BuildNumber, outcomes: make(map[string]string)}
+
+	steps, err := runCtx.Client.Pipelines.GetPipelineSteps(ctx, runCtx.Workspace, runCtx.Repository, pipeline.UUID)
+	if err != nil {
+		return run
+	}
+
+	for _, step := range steps {
+		testCases, err := runCtx.Client.Pipelines.GetStepTestCases(ctx, runCtx.Workspace, runCtx.Repository, pipeline.UUID, step.UUID)
+		if err != nil {
+			continue
+		}
+		for _, tc := range testCases {
+			outcome := testOutcome(tc)
+			if outcome == "" {
+				continue
+			}
+			// A failure anywhere wins over a pass elsewhere (e.g. retried steps)
+			key := testCaseKey(tc)
+			if run.outcomes[key] != "failed" {
+				run.outcomes[key] = outcome
+			}
+		}
+	}
+
+	return run
+}
+
+func testCaseKey(tc *api.TestCase) string {
+	if tc.ClassName != "" {
+		return tc.ClassName + "." + tc.Name
+	}
+	if tc.TestSuite != "" {
+		return tc.TestSuite + "." + tc.Name
+	}
+	return tc.Name
+}
+
+// testOutcome normalizes a test case result to passed, failed or "" (skipped)
+func testOutcome(tc *api.TestCase) string {
+	result := tc.Result
+	if result == "" {
+		result = tc.Status
+	}
+	switch strings.ToUpper(result) {
+	case "PASSED", "SUCCESSFUL", "SUCCESS":
+		return "passed"
+	case "FAILED", "ERROR":
+		return "failed"
+	default:
+		return ""
+	}
+}
+
+// computeTestHistory classifies each test over runs ordered oldest first.
+// A test that flipped between pass and fail more than once is flaky; one that
+// has failed in every run since it first broke is failing; anything else,
+// including a single fail-to-pass fix, is stable.
+func computeTestHistory(runs []pipelineTestCases) *TestHistoryReport {
+	report := &TestHistoryReport{
+		Pipelines: make([]int, 0, len(runs)),
+		Tests:     make([]TestHistory, 0),
+	}
+
+	names := make(map[string]bool)
+	for _, run := range runs {
+		report.Pipelines = append(report.Pipelines, run.buildNumber)
+		for name := range run.outcomes {
+			names[name] = true
+		}
+	}
+
+	for name := range names {
+		th := TestHistory{Name: name, Outcomes: make([]string, 0, len(runs))}
+		last := ""
+		for _, run := range runs {
+			outcome := run.outcomes[name]
+			th.Outcomes = append(th.Outcomes, outcome)
+			switch outcome {
+			case "passed":
+				th.Passed++
+			case "failed":
+				th.Failed++
+			default:
+				continue
+			}
+			if last != "" && last != outcome {
+				th.Flips++
+			}
+			last = outcome
+		}
+
+		th.Runs = th.Passed + th.Failed
+		if th.Runs > 0 {
+			th.PassRate = float64(th.Passed) * 100 / float64(th.Runs)
+		}
+
+		switch {
+		case th.Flips > 1:
+			th.Classification = TestFlaky
+			report.Flaky++
+		case last == "failed":
+			th.Classification = TestFailing
+			report.Failing++
+		default:
+			th.Classification = TestStable
+			report.Stable++
+			continue
+		}
+		report.Tests = append(report.Tests, th)
+	}
+
+	sort.Slice(report.Tests, func(i, j int) bool {
+		a, b := report.Tests[i], report.Tests[j]
+		if a.Classification != b.Classification {
+			return a.Classification == TestFlaky
+		}
+		if a.Flips != b.Flips {
+			return a.Flips > b.Flips
+		}
+		if a.PassRate != b.PassRate {
+			return a.PassRate < b.PassRate
+		}
+		return a.Name < b.Name
+	})
+
+	return report
+}
+
+func formatTestHistory(report *TestHistoryReport) error {
+	if len(report.Pipelines) == 0 {
+		fmt.Println("No pipelines found")
+		return nil
+	}
+
+	fmt.Printf("Test history for %s over %d pipeline(s) (#%d → #%d)\n\n", report.Branch,
+		len(report.Pipelines), report.Pipelines[0], report.Pipelines[len(report.Pipelines)-1])
+
+	if len(report.Tests) == 0 {
+		fmt.Printf("✅ No flaky or failing tests (%d stable)\n", report.Stable)
+		return nil
+	}
+
+	headers := []string{"TEST", "STATUS", "PASS RATE", "HISTORY"}
+	rows := make([][]string, 0, len(report.Tests))
+	for _, th := range report.Tests {
+		rows = append(rows, []string{
+			th.Name,
+			th.Classification,
+			fmt.Sprintf("%.0f%% (%d/%d)", th.PassRate, th.Passed, th.Runs),
+			historyBar(th.Outcomes),
+		})
+	}
+
+	if err := output.RenderSimpleTable(headers, rows); err != nil {
+		return err
+	}
+
+	fmt.Printf("\n%d flaky, %d consistently failing, %d stable (history oldest → newest: ✓ passed, ✗ failed, · not run)\n",
+		report.Flaky, report.Failing, report.Stable)
+	return nil
+}
+
+func historyBar(outcomes []string) string {
+	var b strings.Builder
+	for _, outcome := range outcomes {
+		switch outcome {
+		case "passed":
+			b.WriteString("✓")
+		case "failed":
+			b.WriteString("✗")
+		default:
+			b.WriteString("·")
+		}
+	}
+	return b.String()
+}
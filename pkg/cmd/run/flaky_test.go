@@ -0,0 +1,87 @@
+package run
+
+import (
+	"testing"
+
+	"github.com/carlosarraes/bt/pkg/api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestComputeTestHistory(t *testing.T) {
+	runs := []pipelineTestCases{
+		{buildNumber: 10, outcomes: map[string]string{"auth.TestLogin": "passed", "db.TestMigrate": "passed", "api.TestList": "passed", "ui.TestRender": "failed"}},
+		{buildNumber: 11, outcomes: map[string]string{"auth.TestLogin": "failed", "db.TestMigrate": "passed", "api.TestList": "passed", "ui.TestRender": "failed"}},
+		{buildNumber: 12, outcomes: map[string]string{"auth.TestLogin": "passed", "db.TestMigrate": "failed", "api.TestList": "passed", "ui.TestRender": "passed"}},
+		{buildNumber: 13, outcomes: map[string]string{"auth.TestLogin": "failed", "db.TestMigrate": "failed"}},
+	}
+
+	report := computeTestHistory(runs)
+
+	assert.Equal(t, []int{10, 11, 12, 13}, report.Pipelines)
+	assert.Equal(t, 1, report.Flaky)
+	assert.Equal(t, 1, report.Failing)
+	assert.Equal(t, 2, report.Stable, "always-passing and fixed tests are stable")
+
+	require.Len(t, report.Tests, 2)
+
+	flaky := report.Tests[0]
+	assert.Equal(t, "auth.TestLogin", flaky.Name)
+	assert.Equal(t, TestFlaky, flaky.Classification)
+	assert.Equal(t, 3, flaky.Flips)
+	assert.Equal(t, 4, flaky.Runs)
+	assert.InDelta(t, 50.0, flaky.PassRate, 0.001)
+
+	failing := report.Tests[1]
+	assert.Equal(t, "db.TestMigrate", failing.Name)
+	assert.Equal(t, TestFailing, failing.Classification)
+	assert.Equal(t, []string{"passed", "passed", "failed", "failed"}, failing.Outcomes)
+}
+
+func TestComputeTestHistory_MissingRuns(t *testing.T) {
+	runs := []pipelineTestCases{
+		{buildNumber: 1, outcomes: map[string]string{"TestA": "failed"}},
+		{buildNumber: 2, outcomes: map[string]string{}},
+		{buildNumber: 3, outcomes: map[string]string{"TestA": "failed"}},
+	}
+
+	report := computeTestHistory(runs)
+
+	require.Len(t, report.Tests, 1)
+	assert.Equal(t, TestFailing, report.Tests[0].Classification)
+	assert.Equal(t, 2, report.Tests[0].Runs)
+	assert.Equal(t, "✗·✗", historyBar(report.Tests[0].Outcomes))
+}
+
+func TestHistoryPipelines(t *testing.T) {
+	completed := &api.PipelineState{Name: "COMPLETED"}
+	current := &api.Pipeline{UUID: "{c}", BuildNumber: 20, State: completed}
+	recent := []*api.Pipeline{
+		{UUID: "{n}", BuildNumber: 21, State: completed},
+		current,
+		{UUID: "{r}", BuildNumber: 19, State: &api.PipelineState{Name: "IN_PROGRESS"}},
+		{UUID: "{a}", BuildNumber: 18, State: completed},
+		{UUID: "{b}", BuildNumber: 17, State: completed},
+		{UUID: "{d}", BuildNumber: 16, State: completed},
+	}
+
+	history := historyPipelines(current, recent, 3)
+
+	require.Len(t, history, 3)
+	assert.Equal(t, 20, history[0].BuildNumber)
+	assert.Equal(t, 18, history[1].BuildNumber)
+	assert.Equal(t, 17, history[2].BuildNumber)
+}
+
+func TestTestOutcome(t *testing.T) {
+	assert.Equal(t, "passed", testOutcome(&api.TestCase{Status: "PASSED"}))
+	assert.Equal(t, "passed", testOutcome(&api.TestCase{Status: "COMPLETED", Result: "SUCCESSFUL"}))
+	assert.Equal(t, "failed", testOutcome(&api.TestCase{Result: "ERROR"}))
+	assert.Equal(t, "", testOutcome(&api.TestCase{Status: "SKIPPED"}))
+}
+
+func TestTestCaseKey(t *testing.T) {
+	assert.Equal(t, "pkg.Suite.TestA", testCaseKey(&api.TestCase{ClassName: "pkg.Suite", Name: "TestA"}))
+	assert.Equal(t, "suite.TestA", testCaseKey(&api.TestCase{TestSuite: "suite", Name: "TestA"}))
+	assert.Equal(t, "TestA", testCaseKey(&api.TestCase{Name: "TestA"}))
+}
@@ -0,0 +1,108 @@
+package run
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"time"
+)
+
+// maxStepTagLength bounds the step name each followed log line starts with
+const maxStepTagLength = 20
+
+// followOutput serializes what run logs --follow prints. The steps of a
+// parallel block are streamed at the same time, so every write takes one
+// lock and log lines carry the tag of their step.
+type followOutput struct {
+	mu  sync.Mutex
+	w   io.Writer
+	now func() time.Time
+}
+
+func newFollowOutput(w io.Writer) *followOutput {
+	return &followOutput{w: w, now: time.Now}
+}
+
+// printf writes anything that isn't a log line, such as step headers and
+// error summaries, in one piece
+func (o *followOutput) printf(format string, args ...interface{}) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	fmt.Fprintf(o.w, format, args...)
+}
+
+// logLine writes a line of a step's log as "[15:04:05] [tag] marker text"
+func (o *followOutput) logLine(tag, marker, text string) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	fmt.Fprintf(o.w, "[%s] [%s] %s%s\n", o.now().Format("15:04:05"), tag, marker, text)
+}
+
+// stepTag shortens a step name to tag its log lines
+func stepTag(name string) string {
+	runes := []rune(strings.TrimSpace(name))
+	if len(runes) == 0 {
+		return "step"
+	}
+	if len(runes) > maxStepTagLength {
+		return string(runes[:maxStepTagLength-1]) + "…"
+	}
+	return string(runes)
+}
+
+// stepStreams runs the log streams of followed steps concurrently, at most
+// one per step. A step is streamed again when its state changes; shown
+// remembers how many of its lines were printed, so only new ones are.
+type stepStreams struct {
+	mu     sync.Mutex
+	active map[string]bool
+	shown  map[string]int
+	wg     sync.WaitGroup
+}
+
+func newStepStreams() *stepStreams {
+	return &stepStreams{active: make(map[string]bool), shown: make(map[string]int)}
+}
+
+// start runs stream for the step in the background, and reports false when
+// the step is still being streamed
+func (s *stepStreams) start(stepUUID string, stream func()) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.active[stepUUID] {
+		return false
+	}
+	s.active[stepUUID] = true
+
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		defer func() {
+			s.mu.Lock()
+			delete(s.active, stepUUID)
+			s.mu.Unlock()
+		}()
+		stream()
+	}()
+	return true
+}
+
+// linesShown returns how many lines of the step were already printed
+func (s *stepStreams) linesShown(stepUUID string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.shown[stepUUID]
+}
+
+// markShown records that the first n lines of the step were printed
+func (s *stepStreams) markShown(stepUUID string, n int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.shown[stepUUID] = max(s.shown[stepUUID], n)
+}
+
+// wait blocks until every stream has finished
+func (s *stepStreams) wait() {
+	s.wg.Wait()
+}
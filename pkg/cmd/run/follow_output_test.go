@@ -0,0 +1,90 @@
+package run
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFollowOutput_logLine(t *testing.T) {
+	var buf bytes.Buffer
+	out := newFollowOutput(&buf)
+	out.now = func() time.Time { return time.Date(2026, 1, 2, 15, 4, 5, 0, time.UTC) }
+
+	out.logLine("Unit tests", "", "ok  pkg/api")
+	out.logLine("Lint", "❌ ", "error: unused variable")
+
+	assert.Equal(t, "[15:04:05] [Unit tests] ok  pkg/api\n[15:04:05] [Lint] ❌ error: unused variable\n", buf.String())
+}
+
+func TestFollowOutput_ConcurrentStepsKeepWholeLines(t *testing.T) {
+	var buf bytes.Buffer
+	out := newFollowOutput(&buf)
+
+	var wg sync.WaitGroup
+	for _, step := range []string{"Unit tests", "Integration tests", "Lint"} {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 200; i++ {
+				out.logLine(stepTag(step), "", fmt.Sprintf("line %d of %s", i, step))
+			}
+		}()
+	}
+	wg.Wait()
+
+	lineRe := regexp.MustCompile(`^\[\d\d:\d\d:\d\d\] \[([^\]]+)\] line \d+ of (.+)$`)
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	require.Len(t, lines, 600)
+	for _, line := range lines {
+		m := lineRe.FindStringSubmatch(line)
+		require.NotNil(t, m, "mangled line %q", line)
+		assert.Equal(t, m[1], m[2], "line tagged with another step")
+	}
+}
+
+func TestStepTag(t *testing.T) {
+	assert.Equal(t, "Lint", stepTag("Lint"))
+	assert.Equal(t, "step", stepTag("  "))
+	assert.Equal(t, "Build and test the …", stepTag("Build and test the application"))
+	assert.Len(t, []rune(stepTag("Build and test the application")), maxStepTagLength)
+}
+
+func TestStepStreams(t *testing.T) {
+	streams := newStepStreams()
+
+	release := make(chan struct{})
+	var ran []string
+	var mu sync.Mutex
+	record := func(name string) func() {
+		return func() {
+			<-release
+			mu.Lock()
+			ran = append(ran, name)
+			mu.Unlock()
+		}
+	}
+
+	assert.True(t, streams.start("{a}", record("a")))
+	assert.True(t, streams.start("{b}", record("b")), "other steps stream at the same time")
+	assert.False(t, streams.start("{a}", record("a again")), "a step streams once at a time")
+
+	close(release)
+	streams.wait()
+	assert.ElementsMatch(t, []string{"a", "b"}, ran)
+
+	assert.True(t, streams.start("{a}", func() {}), "a finished step can stream again")
+	streams.wait()
+
+	assert.Equal(t, 0, streams.linesShown("{a}"))
+	streams.markShown("{a}", 12)
+	streams.markShown("{a}", 5)
+	assert.Equal(t, 12, streams.linesShown("{a}"))
+}
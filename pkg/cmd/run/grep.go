@@ -0,0 +1,282 @@
+package run
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/carlosarraes/bt/pkg/api"
+	"github.com/carlosarraes/bt/pkg/cmd/shared"
+	"github.com/carlosarraes/bt/pkg/output"
+	"github.com/carlosarraes/bt/pkg/utils"
+)
+
+// maxGrepLimit caps how many pipelines run grep searches; each pipeline
+// costs one request per step
+const maxGrepLimit = 100
+
+// grepConcurrency bounds the pipelines whose logs are fetched at once
+const grepConcurrency = 5
+
+// GrepCmd handles the run grep command
+type GrepCmd struct {
+	Pattern    string
+	Limit      int
+	Branch     string
+	Status     string
+	Step       string
+	IgnoreCase bool
+	Context    int
+	Output     string
+	NoRedact   bool
+	NoColor    bool
+	Workspace  string
+	Repository string
+
+	redactor *utils.Redactor
+}
+
+// logMatch is a log line matching the run grep pattern
+type logMatch struct {
+	Pipeline int      `json:"pipeline" yaml:"pipeline"`
+	Branch   string   `json:"branch,omitempty" yaml:"branch,omitempty"`
+	Step     string   `json:"step" yaml:"step"`
+	Line     int      `json:"line" yaml:"line"`
+	Text     string   `json:"text" yaml:"text"`
+	Context  []string `json:"context,omitempty" yaml:"context,omitempty"`
+}
+
+// Run executes the run grep command
+func (cmd *GrepCmd) Run(ctx context.Context) error {
+	pattern, err := cmd.compilePattern()
+	if err != nil {
+		return err
+	}
+
+	if cmd.Limit <= 0 || cmd.Limit > maxGrepLimit {
+		return fmt.Errorf("--limit must be between 1 and %d, got %d", maxGrepLimit, cmd.Limit)
+	}
+	if cmd.Status != "" {
+		if err := validateStatus(cmd.Status); err != nil {
+			return err
+		}
+	}
+
+	runCtx, err := shared.NewCommandContext(ctx, cmd.Output, cmd.NoColor)
+	if err != nil {
+		return err
+	}
+
+	if err := runCtx.ResolveRepository(cmd.Workspace, cmd.Repository); err != nil {
+		return err
+	}
+
+	cmd.redactor, err = newLogRedactor(ctx, runCtx, cmd.NoRedact)
+	if err != nil {
+		return err
+	}
+
+	pipelines, err := listRecentPipelines(ctx, runCtx, cmd.Status, cmd.Branch, "", cmd.Limit)
+	if err != nil {
+		return err
+	}
+	if len(pipelines) == 0 {
+		if cmd.Status == "" && cmd.Branch == "" {
+			return errors.New(noPipelinesMessage(ctx, runCtx))
+		}
+		return fmt.Errorf("no pipelines found in %s/%s", runCtx.Workspace, runCtx.Repository)
+	}
+
+	// Table output streams matches as each step is searched; structured
+	// output needs them all first
+	var mu sync.Mutex
+	var matches []logMatch
+	report := func(stepMatches []logMatch) {
+		mu.Lock()
+		defer mu.Unlock()
+		matches = append(matches, stepMatches...)
+		if cmd.Output == "table" {
+			printLogMatches(os.Stdout, stepMatches)
+		}
+	}
+
+	cmd.searchPipelines(ctx, runCtx, pipelines, pattern, report)
+	sortLogMatches(matches)
+
+	if cmd.Output != "table" {
+		return runCtx.Formatter.Format(map[string]interface{}{
+			"pattern":     pattern.String(),
+			"pipelines":   len(pipelines),
+			"matches":     matches,
+			"total_count": len(matches),
+		})
+	}
+
+	if len(matches) == 0 {
+		fmt.Printf("No matches for %q in the last %d pipeline(s)\n", cmd.Pattern, len(pipelines))
+		return nil
+	}
+
+	fmt.Printf("🔎 %d match(es) in %d of %d pipeline(s)\n", len(matches), countMatchedPipelines(matches), len(pipelines))
+	if first := firstMatchedPipeline(pipelines, matches); first != nil {
+		fmt.Printf("   First seen in pipeline #%d (%s, %s)\n", first.BuildNumber, pipelineRefName(first), output.FormatRelativeTime(first.CreatedOn))
+	}
+	return nil
+}
+
+func (cmd *GrepCmd) compilePattern() (*regexp.Regexp, error) {
+	if cmd.Pattern == "" {
+		return nil, fmt.Errorf("a pattern is required")
+	}
+
+	expr := cmd.Pattern
+	if cmd.IgnoreCase {
+		expr = "(?i)" + expr
+	}
+
+	pattern, err := regexp.Compile(expr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid pattern %q: %w", cmd.Pattern, err)
+	}
+	return pattern, nil
+}
+
+// searchPipelines searches the step logs of every pipeline, at most
+// grepConcurrency pipelines at a time, handing each step's matches to report
+func (cmd *GrepCmd) searchPipelines(ctx context.Context, runCtx *RunContext, pipelines []*api.Pipeline, pattern *regexp.Regexp, report func([]logMatch)) {
+	var wg sync.WaitGroup
+	semaphore := make(chan struct{}, grepConcurrency)
+
+	for _, pipeline := range pipelines {
+		wg.Add(1)
+		go func(pipeline *api.Pipeline) {
+			defer wg.Done()
+
+			semaphore <- struct{}{}
+			defer func() { <-semaphore }()
+
+			cmd.searchPipeline(ctx, runCtx, pipeline, pattern, report)
+		}(pipeline)
+	}
+
+	wg.Wait()
+}
+
+func (cmd *GrepCmd) searchPipeline(ctx context.Context, runCtx *RunContext, pipeline *api.Pipeline, pattern *regexp.Regexp, report func([]logMatch)) {
+	steps, err := runCtx.Client.Pipelines.GetPipelineSteps(ctx, runCtx.Workspace, runCtx.Repository, pipeline.UUID)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: could not get steps of pipeline #%d: %v\n", pipeline.BuildNumber, err)
+		return
+	}
+
+	if cmd.Step != "" {
+		steps = filterStepsByName(steps, cmd.Step, stepAliases(runCtx))
+	}
+
+	for _, step := range steps {
+		logReader, err := runCtx.Client.Pipelines.GetStepLogs(ctx, runCtx.Workspace, runCtx.Repository, pipeline.UUID, step.UUID)
+		if err != nil {
+			if !isMissingStepLog(err) {
+				fmt.Fprintf(os.Stderr, "Warning: could not get logs of step '%s' in pipeline #%d: %v\n", step.Name, pipeline.BuildNumber, err)
+			}
+			continue
+		}
+
+		content, err := io.ReadAll(logReader)
+		logReader.Close()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: could not read logs of step '%s' in pipeline #%d: %v\n", step.Name, pipeline.BuildNumber, err)
+			continue
+		}
+
+		// Secrets are masked before matching, so they can't be searched for
+		lines := cmd.redactor.RedactLines(strings.Split(string(content), "\n"))
+		stepMatches := grepLogLines(lines, pattern, cmd.Context)
+		if len(stepMatches) == 0 {
+			continue
+		}
+
+		for i := range stepMatches {
+			stepMatches[i].Pipeline = pipeline.BuildNumber
+			stepMatches[i].Branch = pipelineRefName(pipeline)
+			stepMatches[i].Step = step.Name
+		}
+		report(stepMatches)
+	}
+}
+
+// grepLogLines returns the lines matching pattern, numbered from 1, with
+// context lines around each one when context is positive
+func grepLogLines(lines []string, pattern *regexp.Regexp, context int) []logMatch {
+	var matches []logMatch
+	for i, line := range lines {
+		line = strings.TrimRight(line, "\r")
+		if !pattern.MatchString(line) {
+			continue
+		}
+
+		match := logMatch{Line: i + 1, Text: line}
+		if context > 0 {
+			match.Context = utils.ExtractContext(lines, i, context, context)
+		}
+		matches = append(matches, match)
+	}
+	return matches
+}
+
+func printLogMatches(w io.Writer, matches []logMatch) {
+	for _, match := range matches {
+		fmt.Fprintf(w, "#%d %s line %d: %s\n", match.Pipeline, match.Step, match.Line, match.Text)
+		if len(match.Context) > 0 {
+			fmt.Fprintf(w, "Context:\n")
+			for _, contextLine := range match.Context {
+				fmt.Fprintf(w, "  %s\n", contextLine)
+			}
+			fmt.Fprintln(w)
+		}
+	}
+}
+
+// sortLogMatches orders matches newest pipeline first, then by step and line
+func sortLogMatches(matches []logMatch) {
+	sort.SliceStable(matches, func(i, j int) bool {
+		if matches[i].Pipeline != matches[j].Pipeline {
+			return matches[i].Pipeline > matches[j].Pipeline
+		}
+		if matches[i].Step != matches[j].Step {
+			return matches[i].Step < matches[j].Step
+		}
+		return matches[i].Line < matches[j].Line
+	})
+}
+
+func countMatchedPipelines(matches []logMatch) int {
+	seen := make(map[int]bool)
+	for _, match := range matches {
+		seen[match.Pipeline] = true
+	}
+	return len(seen)
+}
+
+// firstMatchedPipeline returns the oldest searched pipeline with a match
+func firstMatchedPipeline(pipelines []*api.Pipeline, matches []logMatch) *api.Pipeline {
+	var first *api.Pipeline
+	for _, pipeline := range pipelines {
+		for _, match := range matches {
+			if match.Pipeline != pipeline.BuildNumber {
+				continue
+			}
+			if first == nil || pipeline.BuildNumber < first.BuildNumber {
+				first = pipeline
+			}
+			break
+		}
+	}
+	return first
+}
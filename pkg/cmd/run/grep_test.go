@@ -0,0 +1,78 @@
+package run
+
+import (
+	"bytes"
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/carlosarraes/bt/pkg/api"
+)
+
+func TestGrepLogLines(t *testing.T) {
+	lines := []string{"start", "dial tcp: connection refused\r", "retrying", "Connection Refused again", "done"}
+
+	matches := grepLogLines(lines, regexp.MustCompile("connection refused"), 0)
+	require.Len(t, matches, 1)
+	assert.Equal(t, 2, matches[0].Line)
+	assert.Equal(t, "dial tcp: connection refused", matches[0].Text)
+	assert.Empty(t, matches[0].Context)
+
+	matches = grepLogLines(lines, regexp.MustCompile("(?i)connection refused"), 1)
+	require.Len(t, matches, 2)
+	assert.Equal(t, 4, matches[1].Line)
+	assert.Equal(t, []string{"  retrying", "→ Connection Refused again", "  done"}, matches[1].Context)
+}
+
+func TestGrepCmd_compilePattern(t *testing.T) {
+	pattern, err := (&GrepCmd{Pattern: "error", IgnoreCase: true}).compilePattern()
+	require.NoError(t, err)
+	assert.True(t, pattern.MatchString("ERROR: boom"))
+
+	_, err = (&GrepCmd{Pattern: "("}).compilePattern()
+	assert.Error(t, err)
+
+	_, err = (&GrepCmd{}).compilePattern()
+	assert.Error(t, err)
+}
+
+func TestSortLogMatches(t *testing.T) {
+	matches := []logMatch{
+		{Pipeline: 40, Step: "Build", Line: 3},
+		{Pipeline: 42, Step: "Test", Line: 1},
+		{Pipeline: 42, Step: "Build", Line: 9},
+		{Pipeline: 42, Step: "Build", Line: 2},
+	}
+	sortLogMatches(matches)
+
+	assert.Equal(t, []logMatch{
+		{Pipeline: 42, Step: "Build", Line: 2},
+		{Pipeline: 42, Step: "Build", Line: 9},
+		{Pipeline: 42, Step: "Test", Line: 1},
+		{Pipeline: 40, Step: "Build", Line: 3},
+	}, matches)
+	assert.Equal(t, 2, countMatchedPipelines(matches))
+}
+
+func TestFirstMatchedPipeline(t *testing.T) {
+	pipelines := []*api.Pipeline{{BuildNumber: 42}, {BuildNumber: 41}, {BuildNumber: 40}, {BuildNumber: 39}}
+	matches := []logMatch{{Pipeline: 42}, {Pipeline: 40}}
+
+	first := firstMatchedPipeline(pipelines, matches)
+	require.NotNil(t, first)
+	assert.Equal(t, 40, first.BuildNumber)
+
+	assert.Nil(t, firstMatchedPipeline(pipelines, nil))
+}
+
+func TestPrintLogMatches(t *testing.T) {
+	var buf bytes.Buffer
+	printLogMatches(&buf, []logMatch{
+		{Pipeline: 42, Step: "Build", Line: 7, Text: "boom"},
+		{Pipeline: 42, Step: "Build", Line: 9, Text: "bang", Context: []string{"→ bang", "  after"}},
+	})
+
+	assert.Equal(t, "#42 Build line 7: boom\n#42 Build line 9: bang\nContext:\n  → bang\n    after\n\n", buf.String())
+}
@@ -1,11 +1,24 @@
 package run
 
 import (
+	"github.com/carlosarraes/bt/pkg/api"
 	"github.com/carlosarraes/bt/pkg/cmd/shared"
 )
 
 type RunContext = shared.CommandContext
 
+// pipelineStatus returns the pipeline's result (SUCCESSFUL, FAILED, ...) once
+// it has one, and its state (PENDING, IN_PROGRESS, ...) until then
+func pipelineStatus(pipeline *api.Pipeline) string {
+	if pipeline.State == nil {
+		return "UNKNOWN"
+	}
+	if pipeline.State.Result != nil && pipeline.State.Result.Name != "" {
+		return pipeline.State.Result.Name
+	}
+	return pipeline.State.Name
+}
+
 func PipelineStateColor(state string) string {
 	switch state {
 	case "SUCCESSFUL":
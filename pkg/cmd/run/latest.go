@@ -0,0 +1,129 @@
+package run
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/carlosarraes/bt/pkg/api"
+	"github.com/carlosarraes/bt/pkg/cmd/shared"
+	"github.com/carlosarraes/bt/pkg/git"
+)
+
+// LatestCmd handles the run latest command: it finds the newest pipeline of
+// a branch and hands it to run view, or run watch with --watch
+type LatestCmd struct {
+	Branch     string
+	Watch      bool
+	LogFailed  bool
+	FullOutput bool
+	Output     string
+	NoColor    bool
+	Workspace  string
+	Repository string
+}
+
+// Run executes the run latest command
+func (cmd *LatestCmd) Run(ctx context.Context) error {
+	if cmd.Watch && cmd.LogFailed {
+		return fmt.Errorf("--watch and --log-failed cannot be used together")
+	}
+
+	branch, commit, err := cmd.resolveBranch()
+	if err != nil {
+		return err
+	}
+
+	runCtx, err := shared.NewCommandContext(ctx, cmd.Output, cmd.NoColor)
+	if err != nil {
+		return err
+	}
+
+	if err := runCtx.ResolveRepository(cmd.Workspace, cmd.Repository); err != nil {
+		return err
+	}
+
+	var pipeline *api.Pipeline
+	target := branch
+	if commit != "" {
+		target = shortSHA(commit)
+		pipeline, err = latestCommitPipeline(ctx, runCtx, commit)
+	} else {
+		pipeline, err = latestPipeline(ctx, runCtx, branch)
+	}
+	if err != nil {
+		return err
+	}
+
+	if cmd.Output == "table" {
+		fmt.Printf("Latest pipeline on %s: #%d (%s)\n\n", target, pipeline.BuildNumber, pipelineStatus(pipeline))
+	}
+
+	if cmd.Watch {
+		watch := &WatchCmd{
+			PipelineID: pipeline.UUID,
+			Output:     cmd.Output,
+			NoColor:    cmd.NoColor,
+			Workspace:  runCtx.Workspace,
+			Repository: runCtx.Repository,
+		}
+		return watch.Run(ctx)
+	}
+
+	view := &ViewCmd{
+		PipelineID: pipeline.UUID,
+		Output:     cmd.Output,
+		NoColor:    cmd.NoColor,
+		LogFailed:  cmd.LogFailed,
+		FullOutput: cmd.FullOutput,
+		Workspace:  runCtx.Workspace,
+		Repository: runCtx.Repository,
+	}
+	return view.Run(ctx)
+}
+
+// resolveBranch returns --branch, or the checked out branch without it. On
+// a detached HEAD there is no branch, so it returns the commit HEAD points
+// to instead.
+func (cmd *LatestCmd) resolveBranch() (branch, commit string, err error) {
+	if branch := strings.TrimSpace(cmd.Branch); branch != "" {
+		return branch, "", nil
+	}
+
+	branch, err = git.GetCurrentBranchExec("")
+	if errors.Is(err, git.ErrDetachedHEAD) {
+		commit, err = git.ResolveCommitExec("", "HEAD")
+		if err != nil {
+			return "", "", fmt.Errorf("you are in detached HEAD; specify --branch explicitly")
+		}
+		return "", commit, nil
+	}
+	if err != nil {
+		return "", "", fmt.Errorf("could not determine the current branch, use --branch: %w", err)
+	}
+	return branch, "", nil
+}
+
+// latestCommitPipeline returns the newest pipeline that ran on a commit
+func latestCommitPipeline(ctx context.Context, runCtx *RunContext, commit string) (*api.Pipeline, error) {
+	pipelines, err := runCtx.Client.Pipelines.GetPipelinesByCommit(ctx, runCtx.Workspace, runCtx.Repository, commit)
+	if err != nil {
+		return nil, handlePipelineAPIError(err)
+	}
+	if len(pipelines) == 0 {
+		return nil, fmt.Errorf("no pipelines found for commit %s in %s/%s (HEAD is detached; use --branch to look up a branch)", shortSHA(commit), runCtx.Workspace, runCtx.Repository)
+	}
+	return pipelines[0], nil
+}
+
+func latestPipeline(ctx context.Context, runCtx *RunContext, branch string) (*api.Pipeline, error) {
+	pipelines, err := runCtx.Client.Pipelines.GetPipelinesByBranch(ctx, runCtx.Workspace, runCtx.Repository, branch, 1)
+	if err != nil {
+		return nil, handlePipelineAPIError(err)
+	}
+	if len(pipelines) == 0 {
+		return nil, fmt.Errorf("no pipelines found for branch %s in %s/%s", branch, runCtx.Workspace, runCtx.Repository)
+	}
+	return pipelines[0], nil
+}
@@ -0,0 +1,78 @@
+package run
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/carlosarraes/bt/pkg/api/apitest"
+	"github.com/carlosarraes/bt/pkg/cmd/shared"
+)
+
+func TestLatestPipeline(t *testing.T) {
+	apitest.CommandEnv(t, "ws", "repo")
+	transport := apitest.NewReplayTransport(apitest.Fixture{
+		Method: "GET",
+		Path:   "/repositories/ws/repo/pipelines",
+		Query:  "target.ref_name=feature/x",
+		Status: 200,
+		Body:   []byte(`{"values": [{"uuid": "{c}", "build_number": 12, "state": {"name": "IN_PROGRESS"}, "target": {"ref_name": "feature/x"}}]}`),
+	})
+	t.Cleanup(shared.SetClientTransport(transport))
+
+	runCtx, err := shared.NewCommandContext(context.Background(), "table", true)
+	require.NoError(t, err)
+
+	pipeline, err := latestPipeline(context.Background(), runCtx, "feature/x")
+	require.NoError(t, err)
+	assert.Equal(t, 12, pipeline.BuildNumber)
+	assert.Equal(t, "{c}", pipeline.UUID)
+}
+
+func TestLatestPipeline_NoPipelines(t *testing.T) {
+	apitest.CommandEnv(t, "ws", "repo")
+	transport := apitest.NewReplayTransport(apitest.Fixture{
+		Method: "GET",
+		Path:   "/repositories/ws/repo/pipelines",
+		Status: 200,
+		Body:   []byte(`{"values": []}`),
+	})
+	t.Cleanup(shared.SetClientTransport(transport))
+
+	runCtx, err := shared.NewCommandContext(context.Background(), "table", true)
+	require.NoError(t, err)
+
+	_, err = latestPipeline(context.Background(), runCtx, "feature/x")
+	assert.ErrorContains(t, err, "no pipelines found for branch feature/x")
+}
+
+func TestLatestCommitPipeline(t *testing.T) {
+	apitest.CommandEnv(t, "ws", "repo")
+	transport := apitest.NewReplayTransport(apitest.Fixture{
+		Method: "GET",
+		Path:   "/repositories/ws/repo/pipelines",
+		Query:  "target.commit.hash=0123456789abcdef",
+		Status: 200,
+		Body:   []byte(`{"values": [{"uuid": "{n}", "build_number": 7, "state": {"name": "COMPLETED"}}, {"uuid": "{o}", "build_number": 5}]}`),
+	})
+	t.Cleanup(shared.SetClientTransport(transport))
+
+	runCtx, err := shared.NewCommandContext(context.Background(), "table", true)
+	require.NoError(t, err)
+
+	pipeline, err := latestCommitPipeline(context.Background(), runCtx, "0123456789abcdef")
+	require.NoError(t, err)
+	assert.Equal(t, 7, pipeline.BuildNumber)
+}
+
+func TestLatestCmd_Validation(t *testing.T) {
+	err := (&LatestCmd{Branch: "main", Watch: true, LogFailed: true, Output: "table"}).Run(context.Background())
+	assert.ErrorContains(t, err, "cannot be used together")
+
+	branch, commit, err := (&LatestCmd{Branch: " main "}).resolveBranch()
+	require.NoError(t, err)
+	assert.Equal(t, "main", branch)
+	assert.Empty(t, commit)
+}
@@ -7,6 +7,7 @@ import (
 
 	"github.com/carlosarraes/bt/pkg/api"
 	"github.com/carlosarraes/bt/pkg/cmd/shared"
+	"github.com/carlosarraes/bt/pkg/git"
 	"github.com/carlosarraes/bt/pkg/output"
 )
 
@@ -14,8 +15,9 @@ type ListCmd struct {
 	Status     string `help:"Filter by status (PENDING, IN_PROGRESS, SUCCESSFUL, FAILED, ERROR, STOPPED)"`
 	Branch     string `help:"Filter by branch name"`
 	Creator    string `help:"Filter by pipeline creator (display name)"`
+	Commit     string `help:"Only list pipelines that ran on this commit (full or short SHA)"`
 	Limit      int    `help:"Maximum number of runs to show" default:"10"`
-	Output     string `short:"o" help:"Output format (table, json, yaml)" enum:"table,json,yaml" default:"table"`
+	Output     string `short:"o" help:"Output format (table, json, yaml, compact)" enum:"table,json,yaml,compact" default:"table"`
 	NoColor    bool
 	Workspace  string `help:"Bitbucket workspace (defaults to git remote or config)"`
 	Repository string `help:"Repository name (defaults to git remote)"`
@@ -30,12 +32,7 @@ func (cmd *ListCmd) Run(ctx context.Context) error {
 	}
 
 	// Override workspace and repository if provided via flags
-	if cmd.Workspace != "" {
-		runCtx.Workspace = cmd.Workspace
-	}
-	if cmd.Repository != "" {
-		runCtx.Repository = cmd.Repository
-	}
+	runCtx.ApplyRepoFlags(cmd.Workspace, cmd.Repository)
 
 	// Validate workspace and repository are available
 	if err := runCtx.ValidateWorkspaceAndRepo(); err != nil {
@@ -57,20 +54,115 @@ func (cmd *ListCmd) Run(ctx context.Context) error {
 		return fmt.Errorf("limit cannot exceed 100")
 	}
 
-	needsClientFilter := cmd.Creator != "" || isClientSideStatus(cmd.Status)
+	if cmd.Commit != "" {
+		pipelines, err := cmd.listCommitPipelines(ctx, runCtx)
+		if err != nil {
+			return err
+		}
+		return cmd.formatOutput(runCtx, pipelines)
+	}
+
+	pipelines, err := listRecentPipelines(ctx, runCtx, cmd.Status, cmd.Branch, cmd.Creator, cmd.Limit)
+	if err != nil {
+		return err
+	}
+	// Without filters, an empty list means the repository has no pipelines
+	if len(pipelines) == 0 && cmd.Output == "table" && cmd.Status == "" && cmd.Branch == "" && cmd.Creator == "" {
+		fmt.Printf("No pipeline runs found: %s\n", noPipelinesMessage(ctx, runCtx))
+		return nil
+	}
+
+	return cmd.formatOutput(runCtx, pipelines)
+}
+
+// listCommitPipelines returns the pipelines that ran on cmd.Commit, newest
+// first. The API only filters by commit, so the other filters apply here.
+func (cmd *ListCmd) listCommitPipelines(ctx context.Context, runCtx *RunContext) ([]*api.Pipeline, error) {
+	sha, err := resolveCommitSHA(cmd.Commit)
+	if err != nil {
+		return nil, err
+	}
+
+	pipelines, err := runCtx.Client.Pipelines.GetPipelinesByCommit(ctx, runCtx.Workspace, runCtx.Repository, sha)
+	if err != nil {
+		return nil, handlePipelineAPIError(err)
+	}
+
+	pipelines = filterCommitPipelines(pipelines, cmd.Status, cmd.Branch, cmd.Creator)
+	if len(pipelines) > cmd.Limit {
+		pipelines = pipelines[:cmd.Limit]
+	}
+
+	return pipelines, nil
+}
+
+// resolveCommitSHA expands a short SHA against the local repository, since
+// the API only matches full hashes
+func resolveCommitSHA(sha string) (string, error) {
+	sha = strings.ToLower(strings.TrimSpace(sha))
+	if !isHexSHA(sha) {
+		return "", fmt.Errorf("invalid commit SHA %q", sha)
+	}
+	if len(sha) == 40 {
+		return sha, nil
+	}
+
+	resolved, err := git.ResolveCommitExec("", sha)
+	if err != nil {
+		return "", fmt.Errorf("could not resolve short SHA %s: %w; pass the full 40-character SHA", sha, err)
+	}
+	return resolved, nil
+}
+
+func isHexSHA(sha string) bool {
+	if len(sha) < 4 || len(sha) > 40 {
+		return false
+	}
+	for _, c := range sha {
+		if (c < '0' || c > '9') && (c < 'a' || c > 'f') {
+			return false
+		}
+	}
+	return true
+}
+
+func filterCommitPipelines(pipelines []*api.Pipeline, status, branch, creator string) []*api.Pipeline {
+	var filtered []*api.Pipeline
+	creatorLower := strings.ToLower(creator)
+
+	for _, p := range pipelines {
+		if status != "" && !strings.EqualFold(pipelineStatus(p), status) && (p.State == nil || !strings.EqualFold(p.State.Name, status)) {
+			continue
+		}
+		if branch != "" && (p.Target == nil || p.Target.RefName != branch) {
+			continue
+		}
+		if creator != "" && !strings.Contains(strings.ToLower(pipelineCreator(p)), creatorLower) {
+			continue
+		}
+		filtered = append(filtered, p)
+	}
+	return filtered
+}
+
+// listRecentPipelines returns up to limit pipelines, newest first, matching
+// the optional status, branch and creator filters. Statuses and creators the
+// API can't filter on are matched client-side, fetching full pages.
+func listRecentPipelines(ctx context.Context, runCtx *RunContext, status, branch, creator string, limit int) ([]*api.Pipeline, error) {
+	needsClientFilter := creator != "" || isClientSideStatus(status)
 
 	options := &api.PipelineListOptions{
-		PageLen: cmd.Limit,
+		PageLen: limit,
 		Page:    1,
 		Sort:    "-created_on",
 	}
 
-	if cmd.Status != "" && !isClientSideStatus(cmd.Status) {
-		options.Status = strings.ToUpper(cmd.Status)
+	if status != "" && !isClientSideStatus(status) {
+		options.Status = strings.ToUpper(status)
 	}
 
-	if cmd.Branch != "" {
-		options.Branch = cmd.Branch
+	if branch != "" {
+		options.Branch = branch
 	}
 
 	if needsClientFilter {
@@ -82,32 +174,32 @@ func (cmd *ListCmd) Run(ctx context.Context) error {
 	for {
 		result, err := runCtx.Client.Pipelines.ListPipelines(ctx, runCtx.Workspace, runCtx.Repository, options)
 		if err != nil {
-			return handlePipelineAPIError(err)
+			return nil, handlePipelineAPIError(err)
 		}
 
 		page, err := parsePipelineResults(result)
 		if err != nil {
-			return fmt.Errorf("failed to parse pipeline results: %w", err)
+			return nil, fmt.Errorf("failed to parse pipeline results: %w", err)
 		}
 
 		if needsClientFilter {
-			page = filterPipelines(page, cmd.Status, cmd.Creator)
+			page = filterPipelines(page, status, creator)
 		}
 
 		pipelines = append(pipelines, page...)
 
-		if len(pipelines) >= cmd.Limit || result.Next == "" {
+		if len(pipelines) >= limit || result.Next == "" {
 			break
 		}
 
 		options.Page++
 	}
 
-	if len(pipelines) > cmd.Limit {
-		pipelines = pipelines[:cmd.Limit]
+	if len(pipelines) > limit {
+		pipelines = pipelines[:limit]
 	}
 
-	return cmd.formatOutput(runCtx, pipelines)
+	return pipelines, nil
 }
 
 // formatOutput formats and displays the pipeline results
@@ -119,6 +211,8 @@ func (cmd *ListCmd) formatOutput(runCtx *RunContext, pipelines []*api.Pipeline)
 		return cmd.formatJSON(runCtx, pipelines)
 	case "yaml":
 		return cmd.formatYAML(runCtx, pipelines)
+	case "compact":
+		return cmd.formatCompact(runCtx, pipelines)
 	default:
 		return fmt.Errorf("unsupported output format: %s", cmd.Output)
 	}
@@ -136,16 +230,7 @@ func (cmd *ListCmd) formatTable(runCtx *RunContext, pipelines []*api.Pipeline) e
 	rows := make([][]string, len(pipelines))
 
 	for i, pipeline := range pipelines {
-		status := "UNKNOWN"
-		if pipeline.State != nil {
-			// Use the result if available (SUCCESSFUL, FAILED, etc.)
-			if pipeline.State.Result != nil && pipeline.State.Result.Name != "" {
-				status = pipeline.State.Result.Name
-			} else {
-				// Fall back to state name (PENDING, IN_PROGRESS, COMPLETED, etc.)
-				status = pipeline.State.Name
-			}
-		}
+		status := pipelineStatus(pipeline)
 
 		startedTime := output.FormatRelativeTime(pipeline.CreatedOn)
 
@@ -154,36 +239,11 @@ func (cmd *ListCmd) formatTable(runCtx *RunContext, pipelines []*api.Pipeline) e
 			duration = output.FormatDuration(pipeline.BuildSecondsUsed)
 		}
 
-		ref := "-"
-		if pipeline.Target != nil {
-			// Check if this is a PR-triggered pipeline
-			if pipeline.Target.Type == "pipeline_pullrequest_target" {
-				ref = "PR"
-			} else if pipeline.Target.PullRequestId != nil {
-				ref = fmt.Sprintf("PR #%d", *pipeline.Target.PullRequestId)
-			} else if pipeline.Target.RefName != "" {
-				ref = shared.Truncate(pipeline.Target.RefName, 15)
-			} else if pipeline.Target.Type == "pipeline_branch_target" {
-				// This is a branch pipeline but no ref_name, try to infer from trigger
-				ref = "branch"
-			}
-		}
-
-		startedBy := "-"
-		if pipeline.Creator != nil {
-			if pipeline.Creator.DisplayName != "" {
-				startedBy = pipeline.Creator.DisplayName
-			} else if pipeline.Creator.Username != "" {
-				startedBy = pipeline.Creator.Username
-			}
-			startedBy = shared.Truncate(startedBy, 15)
-		}
-
 		rows[i] = []string{
 			fmt.Sprintf("#%d", pipeline.BuildNumber),
 			status,
-			ref,
-			startedBy,
+			pipelineRefName(pipeline),
+			pipelineCreator(pipeline),
 			duration,
 			startedTime,
 		}
@@ -192,6 +252,57 @@ func (cmd *ListCmd) formatTable(runCtx *RunContext, pipelines []*api.Pipeline) e
 	return output.RenderSimpleTable(headers, rows)
 }
 
+// formatCompact prints one line per pipeline: build number, status, ref,
+// creator and start time
+func (cmd *ListCmd) formatCompact(runCtx *RunContext, pipelines []*api.Pipeline) error {
+	lines := make([]string, len(pipelines))
+	for i, pipeline := range pipelines {
+		lines[i] = fmt.Sprintf("#%d %s %s  %s  %s",
+			pipeline.BuildNumber,
+			pipelineStatus(pipeline),
+			pipelineRefName(pipeline),
+			pipelineCreator(pipeline),
+			output.FormatRelativeTime(pipeline.CreatedOn))
+	}
+	return runCtx.Formatter.Format(lines)
+}
+
+// pipelineRefName names what the pipeline ran on: a branch or tag, or the pull
+// request that triggered it
+func pipelineRefName(pipeline *api.Pipeline) string {
+	if pipeline.Target == nil {
+		return "-"
+	}
+
+	// Check if this is a PR-triggered pipeline
+	switch {
+	case pipeline.Target.Type == "pipeline_pullrequest_target":
+		return "PR"
+	case pipeline.Target.PullRequestId != nil:
+		return fmt.Sprintf("PR #%d", *pipeline.Target.PullRequestId)
+	case pipeline.Target.RefName != "":
+		return pipeline.Target.RefName
+	case pipeline.Target.Type == "pipeline_branch_target":
+		// This is a branch pipeline but no ref_name, try to infer from trigger
+		return "branch"
+	default:
+		return "-"
+	}
+}
+
+func pipelineCreator(pipeline *api.Pipeline) string {
+	if pipeline.Creator == nil {
+		return "-"
+	}
+	if pipeline.Creator.DisplayName != "" {
+		return pipeline.Creator.DisplayName
+	}
+	if pipeline.Creator.Username != "" {
+		return pipeline.Creator.Username
+	}
+	return "-"
+}
+
 // formatJSON formats pipelines as JSON
 func (cmd *ListCmd) formatJSON(runCtx *RunContext, pipelines []*api.Pipeline) error {
 	// Create a simplified structure for JSON output
@@ -0,0 +1,63 @@
+package run
+
+import (
+	"context"
+	"testing"
+
+	"github.com/carlosarraes/bt/pkg/api/apitest"
+	"github.com/carlosarraes/bt/pkg/cmd/shared"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListCmd_Run_Fixtures(t *testing.T) {
+	apitest.CommandEnv(t, "ws", "repo")
+	transport := apitest.Transport(t, "testdata/fixtures/list")
+	t.Cleanup(shared.SetClientTransport(transport))
+
+	var runErr error
+	out := captureStdout(func() {
+		cmd := &ListCmd{Limit: 10, Output: "table", NoColor: true}
+		runErr = cmd.Run(context.Background())
+	})
+	require.NoError(t, runErr)
+
+	assert.Contains(t, out, "#42")
+	assert.Contains(t, out, "FAILED")
+	assert.Contains(t, out, "#41")
+	assert.Contains(t, out, "fix/login")
+	assert.Contains(t, out, "Alice Doe")
+
+	if replay, ok := transport.(*apitest.ReplayTransport); ok {
+		requests := replay.Requests()
+		require.Len(t, requests, 1)
+		assert.Contains(t, requests[0].Query, "pagelen=10")
+	}
+}
+
+func TestListCmd_Run_Commit(t *testing.T) {
+	apitest.CommandEnv(t, "ws", "repo")
+	sha := "0123456789abcdef0123456789abcdef01234567"
+	transport := apitest.NewReplayTransport(apitest.Fixture{
+		Method: "GET",
+		Path:   "/repositories/ws/repo/pipelines",
+		Query:  "target.commit.hash=" + sha,
+		Status: 200,
+		Body: []byte(`{"values": [
+			{"uuid": "{b}", "build_number": 8, "state": {"name": "COMPLETED", "result": {"name": "FAILED"}}, "target": {"type": "pipeline_ref_target", "ref_name": "main"}},
+			{"uuid": "{a}", "build_number": 7, "state": {"name": "COMPLETED", "result": {"name": "SUCCESSFUL"}}, "target": {"type": "pipeline_ref_target", "ref_name": "v1.0"}}
+		]}`),
+	})
+	t.Cleanup(shared.SetClientTransport(transport))
+
+	var runErr error
+	out := captureStdout(func() {
+		cmd := &ListCmd{Commit: sha, Status: "failed", Limit: 10, Output: "table", NoColor: true}
+		runErr = cmd.Run(context.Background())
+	})
+	require.NoError(t, runErr)
+
+	assert.Contains(t, out, "#8")
+	assert.NotContains(t, out, "#7")
+	require.Len(t, transport.Requests(), 1)
+}
@@ -536,3 +536,65 @@ func TestPipelineStateColor(t *testing.T) {
 		})
 	}
 }
+
+func TestListCmd_FormatCompact(t *testing.T) {
+	var buf strings.Builder
+	formatter, err := output.NewFormatter(output.FormatCompact, &output.FormatterOptions{Writer: &buf})
+	assert.NoError(t, err)
+
+	prID := 7
+	pipelines := []*api.Pipeline{
+		{
+			BuildNumber: 42,
+			State:       &api.PipelineState{Name: "COMPLETED", Result: &api.PipelineResult{Name: "FAILED"}},
+			Target:      &api.PipelineTarget{RefName: "fix/login-redirect-loop"},
+			Creator:     &api.User{DisplayName: "Alice Doe"},
+		},
+		{
+			BuildNumber: 41,
+			State:       &api.PipelineState{Name: "IN_PROGRESS"},
+			Target:      &api.PipelineTarget{PullRequestId: &prID},
+		},
+	}
+
+	cmd := &ListCmd{Output: "compact"}
+	assert.NoError(t, cmd.formatOutput(&RunContext{Formatter: formatter}, pipelines))
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	assert.Len(t, lines, 2)
+	assert.True(t, strings.HasPrefix(lines[0], "#42 FAILED fix/login-redirect-loop  Alice Doe  "), lines[0])
+	assert.True(t, strings.HasPrefix(lines[1], "#41 IN_PROGRESS PR #7  -  "), lines[1])
+}
+
+func TestResolveCommitSHA(t *testing.T) {
+	full := "0123456789ABCDEF0123456789abcdef01234567"
+	sha, err := resolveCommitSHA(full)
+	assert.NoError(t, err)
+	assert.Equal(t, strings.ToLower(full), sha)
+
+	for _, invalid := range []string{"", "abc", "not-a-sha", full + "0"} {
+		_, err := resolveCommitSHA(invalid)
+		assert.Error(t, err, invalid)
+	}
+}
+
+func TestFilterCommitPipelines(t *testing.T) {
+	pipelines := []*api.Pipeline{
+		{BuildNumber: 3, State: &api.PipelineState{Name: "IN_PROGRESS"}, Target: &api.PipelineTarget{RefName: "main"}},
+		{BuildNumber: 2, State: &api.PipelineState{Name: "COMPLETED", Result: &api.PipelineResult{Name: "FAILED"}}, Target: &api.PipelineTarget{RefName: "main"}},
+		{BuildNumber: 1, State: &api.PipelineState{Name: "COMPLETED", Result: &api.PipelineResult{Name: "SUCCESSFUL"}}, Target: &api.PipelineTarget{RefName: "v1.0"}, Creator: &api.User{DisplayName: "Alice Doe"}},
+	}
+
+	buildNumbers := func(filtered []*api.Pipeline) []int {
+		var numbers []int
+		for _, p := range filtered {
+			numbers = append(numbers, p.BuildNumber)
+		}
+		return numbers
+	}
+
+	assert.Equal(t, []int{3, 2, 1}, buildNumbers(filterCommitPipelines(pipelines, "", "", "")))
+	assert.Equal(t, []int{3}, buildNumbers(filterCommitPipelines(pipelines, "in_progress", "", "")))
+	assert.Equal(t, []int{2}, buildNumbers(filterCommitPipelines(pipelines, "FAILED", "main", "")))
+	assert.Equal(t, []int{1}, buildNumbers(filterCommitPipelines(pipelines, "", "", "alice")))
+}
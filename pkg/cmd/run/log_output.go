@@ -0,0 +1,74 @@
+package run
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/carlosarraes/bt/pkg/cmd/shared"
+	"github.com/carlosarraes/bt/pkg/utils"
+)
+
+// largeLogThreshold is the log size above which run view asks before
+// printing a step's complete log to a terminal
+const largeLogThreshold = 1 << 20
+
+// logTailLines is how many lines are shown of a truncated log
+const logTailLines = 100
+
+// tailLines returns the last n lines and whether any were dropped
+func tailLines(lines []string, n int) ([]string, bool) {
+	if len(lines) <= n {
+		return lines, false
+	}
+	return lines[len(lines)-n:], true
+}
+
+// canConfirmLargeLog reports whether the user can be asked about a large
+// log: only when both stdin and stdout are terminals
+func canConfirmLargeLog() bool {
+	return shared.StdoutIsTerminal() && utils.IsTerminal(os.Stdin)
+}
+
+// confirmLargeLog asks whether to print a large log in full. Anything but
+// yes means the tail is shown instead.
+func confirmLargeLog(in io.Reader, out io.Writer, stepName string, size, lines int) bool {
+	fmt.Fprintf(out, "⚠️  The log of step '%s' is %s (%d lines). Show all of it? Otherwise the last %d lines are shown [y/N]: ",
+		stepName, formatByteSize(size), lines, logTailLines)
+
+	answer, err := bufio.NewReader(in).ReadString('\n')
+	if err != nil {
+		return false
+	}
+
+	switch strings.ToLower(strings.TrimSpace(answer)) {
+	case "y", "yes":
+		return true
+	default:
+		return false
+	}
+}
+
+// writeLogLines writes lines through a buffer so huge logs go out in large
+// chunks instead of one write per line
+func writeLogLines(w io.Writer, lines []string) error {
+	buffered := bufio.NewWriterSize(w, 64*1024)
+	for _, line := range lines {
+		buffered.WriteString(line)
+		buffered.WriteByte('\n')
+	}
+	return buffered.Flush()
+}
+
+func formatByteSize(size int) string {
+	switch {
+	case size >= 1<<20:
+		return fmt.Sprintf("%.1f MB", float64(size)/(1<<20))
+	case size >= 1<<10:
+		return fmt.Sprintf("%.1f KB", float64(size)/(1<<10))
+	default:
+		return fmt.Sprintf("%d B", size)
+	}
+}
@@ -0,0 +1,53 @@
+package run
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTailLines(t *testing.T) {
+	lines := []string{"a", "b", "c", "d"}
+
+	tail, truncated := tailLines(lines, 2)
+	assert.Equal(t, []string{"c", "d"}, tail)
+	assert.True(t, truncated)
+
+	tail, truncated = tailLines(lines, 4)
+	assert.Equal(t, lines, tail)
+	assert.False(t, truncated)
+}
+
+func TestConfirmLargeLog(t *testing.T) {
+	tests := []struct {
+		input string
+		want  bool
+	}{
+		{"y\n", true},
+		{"YES\n", true},
+		{"n\n", false},
+		{"\n", false},
+		{"", false},
+	}
+
+	for _, tt := range tests {
+		var out bytes.Buffer
+		got := confirmLargeLog(strings.NewReader(tt.input), &out, "Build", 3<<20, 50000)
+		assert.Equal(t, tt.want, got, "input %q", tt.input)
+		assert.Contains(t, out.String(), "step 'Build' is 3.0 MB (50000 lines)")
+	}
+}
+
+func TestWriteLogLines(t *testing.T) {
+	var out bytes.Buffer
+	assert.NoError(t, writeLogLines(&out, []string{"first", "", "last"}))
+	assert.Equal(t, "first\n\nlast\n", out.String())
+}
+
+func TestFormatByteSize(t *testing.T) {
+	assert.Equal(t, "512 B", formatByteSize(512))
+	assert.Equal(t, "1.5 KB", formatByteSize(1536))
+	assert.Equal(t, "2.0 MB", formatByteSize(2<<20))
+}
@@ -3,30 +3,68 @@ package run
 import (
 	"context"
 	"fmt"
+	"os"
 	"strings"
 	"time"
 
 	"github.com/carlosarraes/bt/pkg/api"
 	"github.com/carlosarraes/bt/pkg/cmd/shared"
+	"github.com/carlosarraes/bt/pkg/output"
 	"github.com/carlosarraes/bt/pkg/utils"
 )
 
 // LogsCmd handles the run logs command - the killer feature for 5x faster pipeline debugging
 type LogsCmd struct {
-	PipelineID string `arg:"" help:"Pipeline ID (build number or UUID)"`
+	PipelineID string `arg:"" optional:"" help:"Pipeline ID (build number or UUID); omit to pick from recent pipelines"`
 	Step       string `help:"Show logs for specific step only"`
-	ErrorsOnly bool   `help:"Extract and show errors only"`
-	Follow     bool   `short:"f" help:"Follow live logs for running pipelines"`
-	Output     string `short:"o" help:"Output format (text, json, yaml)" enum:"text,json,yaml" default:"text"`
-	NoColor    bool   // NoColor is passed from global flag
-	Context    int    `help:"Number of context lines around errors" default:"3"`
-	Tests      bool   `short:"t" help:"Show test results and failures instead of raw logs"`
-	Workspace  string `help:"Bitbucket workspace (defaults to git remote or config)"`
-	Repository string `help:"Repository name (defaults to git remote)"`
+	// OnlyFailed, OnlySuccessful and OnlyRunning select steps by status and
+	// combine with each other and with Step
+	OnlyFailed     bool   `name:"only-failed" help:"Show logs for failed steps only"`
+	OnlySuccessful bool   `name:"only-successful" help:"Show logs for successful steps only"`
+	OnlyRunning    bool   `name:"only-running" help:"Show logs for running steps only"`
+	ErrorsOnly     bool   `help:"Extract and show errors only"`
+	Follow         bool   `short:"f" help:"Follow live logs for running pipelines"`
+	Output         string `short:"o" help:"Output format (text, json, yaml)" enum:"text,json,yaml" default:"text"`
+	NoColor        bool   // NoColor is passed from global flag
+	Context        int    `help:"Number of context lines around errors" default:"3"`
+	// ContextBefore and ContextAfter override Context on one side; negative means unset
+	ContextBefore    int    `help:"Number of context lines before errors (defaults to --context)"`
+	ContextAfter     int    `help:"Number of context lines after errors (defaults to --context)"`
+	Tests            bool   `short:"t" help:"Show test results and failures instead of raw logs"`
+	MergeSteps       bool   `name:"merge-steps" help:"Show the raw logs of all steps as one stream, prefixed with the step name and ordered by step start time"`
+	NoRedact         bool   `name:"no-redact" help:"Show secrets in logs instead of masking them"`
+	RetryOnTransient bool   `name:"retry-on-transient" help:"With --follow, retry transient API errors up to --max-poll-failures times in a row and stop on any other error"`
+	MaxPollFailures  int    `name:"max-poll-failures" help:"Consecutive transient failures tolerated by --retry-on-transient" default:"5"`
+	Status           string `help:"Without a pipeline ID, only offer pipelines with this status (PENDING, IN_PROGRESS, SUCCESSFUL, FAILED, ERROR, STOPPED)"`
+	Branch           string `help:"Without a pipeline ID, only offer pipelines on this branch"`
+	Events           string `help:"With --follow, print JSON progress events (jsonl) instead of text"`
+	Workspace        string `help:"Bitbucket workspace (defaults to git remote or config)"`
+	Repository       string `help:"Repository name (defaults to git remote)"`
+
+	events   *output.EventWriter
+	redactor *utils.Redactor
+	follow   *followOutput
 }
 
 // Run executes the run logs command
 func (cmd *LogsCmd) Run(ctx context.Context) error {
+	selection := pipelineSelection{Status: cmd.Status, Branch: cmd.Branch}
+	if err := selection.validate(cmd.PipelineID); err != nil {
+		return err
+	}
+
+	if cmd.Events != "" && !cmd.Follow {
+		return fmt.Errorf("--events requires --follow")
+	}
+	if cmd.MergeSteps && (cmd.Follow || cmd.Tests || cmd.ErrorsOnly) {
+		return fmt.Errorf("--merge-steps cannot be combined with --follow, --tests or --errors-only")
+	}
+	events, err := newEventWriter(cmd.Events)
+	if err != nil {
+		return err
+	}
+	cmd.events = events
+
 	// For logs, we handle text output specially - just use table format for the context
 	outputFormat := cmd.Output
 	if outputFormat == "text" {
@@ -40,21 +78,20 @@ func (cmd *LogsCmd) Run(ctx context.Context) error {
 	}
 
 	// Override workspace and repository if provided via flags
-	if cmd.Workspace != "" {
-		runCtx.Workspace = cmd.Workspace
-	}
-	if cmd.Repository != "" {
-		runCtx.Repository = cmd.Repository
-	}
+	runCtx.ApplyRepoFlags(cmd.Workspace, cmd.Repository)
 
 	// Validate workspace and repository are available
 	if err := runCtx.ValidateWorkspaceAndRepo(); err != nil {
 		return err
 	}
 
-	// Validate pipeline ID
+	// Pick a recent pipeline when the ID was omitted
 	if strings.TrimSpace(cmd.PipelineID) == "" {
-		return fmt.Errorf("pipeline ID is required")
+		pipelineID, err := selectPipeline(ctx, runCtx, cmd.Output, selection)
+		if err != nil {
+			return err
+		}
+		cmd.PipelineID = pipelineID
 	}
 
 	// Convert pipeline ID to UUID if it's a build number
@@ -69,12 +106,25 @@ func (cmd *LogsCmd) Run(ctx context.Context) error {
 		return handlePipelineAPIError(err)
 	}
 
+	cmd.redactor, err = newLogRedactor(ctx, runCtx, cmd.NoRedact)
+	if err != nil {
+		return err
+	}
+
 	// Follow mode for running pipelines
 	if cmd.Follow {
 		return cmd.followLogs(ctx, runCtx, pipeline)
 	}
 
-	// Static log viewing
+	// Static log viewing; live and structured output aren't paged
+	if cmd.Output == "text" {
+		stopPager := shared.StartPager(ctx)
+		defer stopPager()
+	}
+
+	if cmd.MergeSteps {
+		return cmd.viewMergedLogs(ctx, runCtx, pipeline)
+	}
 	return cmd.viewLogs(ctx, runCtx, pipeline)
 }
 
@@ -89,12 +139,20 @@ func (cmd *LogsCmd) viewLogs(ctx context.Context, runCtx *RunContext, pipeline *
 	// Filter steps if specific step requested
 	filteredSteps := steps
 	if cmd.Step != "" {
-		filteredSteps = filterStepsByName(steps, cmd.Step)
+		aliases := stepAliases(runCtx)
+		filteredSteps = filterStepsByName(steps, cmd.Step, aliases)
 		if len(filteredSteps) == 0 {
-			return fmt.Errorf("step '%s' not found. Available steps: %s", cmd.Step, getAvailableStepNames(steps))
+			return stepNotFoundError(cmd.Step, aliases, steps)
 		}
 	}
 
+	statusFilter := cmd.statusFilter()
+	filteredSteps = filterStepsByStatus(filteredSteps, statusFilter)
+	if len(filteredSteps) == 0 && cmd.Output == "text" {
+		fmt.Printf("No %s steps in pipeline #%d\n", statusFilter.describe(), pipeline.BuildNumber)
+		return nil
+	}
+
 	// Process logs for each step
 	allResults := make([]*utils.LogAnalysisResult, 0, len(filteredSteps))
 
@@ -104,7 +162,7 @@ func (cmd *LogsCmd) viewLogs(ctx context.Context, runCtx *RunContext, pipeline *
 			// Show test results instead of logs
 			if cmd.Output == "text" {
 				displayStepInfo(step)
-				displayTestResults(ctx, runCtx, pipeline, step)
+				displayTestResults(ctx, runCtx, pipeline, step, false)
 			}
 			// Create a dummy result for this step
 			result := &utils.LogAnalysisResult{
@@ -126,7 +184,7 @@ func (cmd *LogsCmd) viewLogs(ctx context.Context, runCtx *RunContext, pipeline *
 				fmt.Printf("Note: Raw logs not available through API for step '%s': %v\n", step.Name, err)
 
 				fmt.Printf("Checking for test results...\n")
-				displayTestResults(ctx, runCtx, pipeline, step)
+				displayTestResults(ctx, runCtx, pipeline, step, false)
 			}
 			result := &utils.LogAnalysisResult{
 				TotalLines:   0,
@@ -141,9 +199,9 @@ func (cmd *LogsCmd) viewLogs(ctx context.Context, runCtx *RunContext, pipeline *
 		}
 
 		parser := utils.NewLogParser()
-		parser.SetContextLines(cmd.Context)
+		parser.SetContextLines(cmd.contextWindow())
 
-		result, err := parser.AnalyzeLog(logReader, step.Name)
+		result, err := parser.AnalyzeLog(cmd.redactor.Reader(logReader), step.Name)
 		logReader.Close()
 		if err != nil {
 			fmt.Printf("Warning: Could not analyze logs for step '%s': %v\n", step.Name, err)
@@ -162,27 +220,66 @@ func (cmd *LogsCmd) viewLogs(ctx context.Context, runCtx *RunContext, pipeline *
 	return cmd.formatOutput(runCtx, pipeline, filteredSteps, allResults)
 }
 
+func (cmd *LogsCmd) statusFilter() stepStatusFilter {
+	return stepStatusFilter{
+		failed:     cmd.OnlyFailed,
+		successful: cmd.OnlySuccessful,
+		running:    cmd.OnlyRunning,
+	}
+}
+
+// contextWindow returns the lines of context to show before and after errors,
+// falling back to the symmetric --context for sides that were not set
+func (cmd *LogsCmd) contextWindow() (int, int) {
+	before, after := cmd.Context, cmd.Context
+	if cmd.ContextBefore >= 0 {
+		before = cmd.ContextBefore
+	}
+	if cmd.ContextAfter >= 0 {
+		after = cmd.ContextAfter
+	}
+	return before, after
+}
+
 // followLogs provides real-time log streaming for running pipelines
 func (cmd *LogsCmd) followLogs(ctx context.Context, runCtx *RunContext, pipeline *api.Pipeline) error {
 	// Check if pipeline is in a state that can be followed
 	if pipeline.State == nil || (pipeline.State.Name != "IN_PROGRESS" && pipeline.State.Name != "PENDING") {
+		if cmd.events != nil {
+			return cmd.events.Emit(output.Event{Event: output.EventPipelineCompleted, Pipeline: pipeline.BuildNumber, Status: pipelineStatus(pipeline)})
+		}
 		fmt.Printf("Pipeline #%d is %s - following is only available for running pipelines\n",
 			pipeline.BuildNumber, pipeline.State.Name)
 		// Fall back to static view
 		return cmd.viewLogs(ctx, runCtx, pipeline)
 	}
 
-	fmt.Printf("Following logs for pipeline #%d (Ctrl+C to exit)...\n\n", pipeline.BuildNumber)
+	if cmd.events != nil {
+		if err := cmd.events.Emit(output.Event{Event: output.EventWatchStarted, Pipeline: pipeline.BuildNumber, Status: pipelineStatus(pipeline)}); err != nil {
+			return err
+		}
+	} else {
+		fmt.Printf("Following logs for pipeline #%d (Ctrl+C to exit)...\n\n", pipeline.BuildNumber)
+	}
+
+	// Steps are streamed concurrently, so everything printed from here on
+	// goes through one writer
+	cmd.follow = newFollowOutput(os.Stdout)
+	streams := newStepStreams()
+	defer streams.wait()
 
 	// Create log parser for real-time analysis
 	parser := utils.NewLogParser()
-	parser.SetContextLines(cmd.Context)
+	parser.SetContextLines(cmd.contextWindow())
 
 	// Follow loop - check for new steps and stream their logs
 	ticker := time.NewTicker(5 * time.Second)
 	defer ticker.Stop()
 
 	seenSteps := make(map[string]bool)
+	stepEvents := newStepEvents(pipeline.BuildNumber)
+	statusFilter := cmd.statusFilter()
+	polls := newPollTolerance(cmd.RetryOnTransient, cmd.MaxPollFailures)
 
 	for {
 		select {
@@ -192,56 +289,92 @@ func (cmd *LogsCmd) followLogs(ctx context.Context, runCtx *RunContext, pipeline
 			// Get current pipeline steps
 			steps, err := runCtx.Client.Pipelines.GetPipelineSteps(ctx, runCtx.Workspace, runCtx.Repository, pipeline.UUID)
 			if err != nil {
-				fmt.Printf("Error getting pipeline steps: %v\n", err)
+				if !polls.enabled {
+					cmd.notef("Error getting pipeline steps: %v\n", err)
+				} else if !polls.tolerate(err) {
+					return handlePipelineAPIError(err)
+				}
 				continue
 			}
 
 			// Process new or updated steps
+			var followed []*api.PipelineStep
 			for _, step := range steps {
-				if cmd.Step != "" && !matchesStepName(step.Name, cmd.Step) {
+				if cmd.Step != "" && !matchesStepName(step.Name, cmd.Step, stepAliases(runCtx)) {
 					continue
 				}
+				if !statusFilter.matches(step) {
+					continue
+				}
+				followed = append(followed, step)
+			}
+
+			if err := emitEvents(cmd.events, stepEvents.update(followed)); err != nil {
+				return err
+			}
+
+			for _, step := range followed {
 
 				// Check if this is a new step or step we should re-process
 				stepKey := fmt.Sprintf("%s-%s", step.UUID, step.State.Name)
 				if seenSteps[stepKey] {
 					continue
 				}
-				seenSteps[stepKey] = true
-
-				// Display step header
-				fmt.Printf("=== Step: %s (%s) ===\n", step.Name, step.State.Name)
 
-				// Stream logs for this step
-				if err := cmd.streamStepLogs(ctx, runCtx, pipeline, step, parser); err != nil {
-					fmt.Printf("Error streaming logs for step '%s': %v\n", step.Name, err)
+				// A step still being streamed is picked up again once its
+				// stream ends
+				started := streams.start(step.UUID, func() {
+					if cmd.events == nil {
+						cmd.follow.printf("=== Step: %s (%s) ===\n", step.Name, step.State.Name)
+					}
+					if err := cmd.streamStepLogs(ctx, runCtx, pipeline, step, parser, streams); err != nil && ctx.Err() == nil {
+						cmd.notef("Error streaming logs for step '%s': %v\n", step.Name, err)
+					}
+				})
+				if started {
+					seenSteps[stepKey] = true
 				}
 			}
 
 			// Check if pipeline completed
 			updatedPipeline, err := runCtx.Client.Pipelines.GetPipeline(ctx, runCtx.Workspace, runCtx.Repository, pipeline.UUID)
 			if err != nil {
-				fmt.Printf("Error checking pipeline status: %v\n", err)
+				if !polls.enabled {
+					cmd.notef("Error checking pipeline status: %v\n", err)
+				} else if !polls.tolerate(err) {
+					return handlePipelineAPIError(err)
+				}
 				continue
 			}
+			polls.reset()
 
 			if updatedPipeline.State != nil &&
 				updatedPipeline.State.Name != "IN_PROGRESS" &&
 				updatedPipeline.State.Name != "PENDING" {
-				fmt.Printf("\n🏁 Pipeline completed with status: %s\n", updatedPipeline.State.Name)
+				streams.wait()
+				if cmd.events != nil {
+					return cmd.events.Emit(output.Event{Event: output.EventPipelineCompleted, Pipeline: updatedPipeline.BuildNumber, Status: pipelineStatus(updatedPipeline)})
+				}
+				cmd.follow.printf("\n🏁 Pipeline completed with status: %s\n", updatedPipeline.State.Name)
 				return nil
 			}
 		}
 	}
 }
 
-// streamStepLogs streams logs for a single step with real-time error analysis
-func (cmd *LogsCmd) streamStepLogs(ctx context.Context, runCtx *RunContext, pipeline *api.Pipeline, step *api.PipelineStep, parser *utils.LogParser) error {
+// streamStepLogs streams logs for a single step with real-time error
+// analysis. The log is read from its start on every stream, so the lines
+// streams already recorded as shown are skipped.
+func (cmd *LogsCmd) streamStepLogs(ctx context.Context, runCtx *RunContext, pipeline *api.Pipeline, step *api.PipelineStep, parser *utils.LogParser, streams *stepStreams) error {
 	// Use streaming API for real-time logs
 	logChan, errChan := runCtx.Client.Pipelines.StreamStepLogs(ctx, runCtx.Workspace, runCtx.Repository, pipeline.UUID, step.UUID)
 
 	lineNumber := 0
+	skip := streams.linesShown(step.UUID)
+	defer func() { streams.markShown(step.UUID, lineNumber) }()
 	var logLines []string
+	redact := cmd.redactor.Stream()
+	tag := stepTag(step.Name)
 
 	for {
 		select {
@@ -259,19 +392,28 @@ func (cmd *LogsCmd) streamStepLogs(ctx context.Context, runCtx *RunContext, pipe
 				return cmd.processAccumulatedLogs(logLines, step.Name, parser)
 			}
 
+			line = redact.Redact(line)
 			lineNumber++
+			if lineNumber <= skip {
+				continue
+			}
 			logLines = append(logLines, line)
 
+			if cmd.events != nil {
+				if cmd.ErrorsOnly && !cmd.containsError(line, parser) {
+					continue
+				}
+				if err := cmd.events.Emit(output.Event{Event: output.EventLogLine, Pipeline: pipeline.BuildNumber, Step: step.Name, Line: line}); err != nil {
+					return err
+				}
+				continue
+			}
+
 			// For real-time display, show the line immediately unless errors-only mode
 			if !cmd.ErrorsOnly {
-				timestamp := time.Now().Format("15:04:05")
-				fmt.Printf("[%s] %s\n", timestamp, line)
-			} else {
-				// In errors-only mode, analyze each line for errors
-				if cmd.containsError(line, parser) {
-					timestamp := time.Now().Format("15:04:05")
-					fmt.Printf("[%s] ❌ %s\n", timestamp, line)
-				}
+				cmd.followOutput().logLine(tag, "", line)
+			} else if cmd.containsError(line, parser) {
+				cmd.followOutput().logLine(tag, "❌ ", line)
 			}
 		}
 	}
@@ -279,7 +421,7 @@ func (cmd *LogsCmd) streamStepLogs(ctx context.Context, runCtx *RunContext, pipe
 
 // processAccumulatedLogs analyzes all accumulated log lines for errors
 func (cmd *LogsCmd) processAccumulatedLogs(logLines []string, stepName string, parser *utils.LogParser) error {
-	if cmd.ErrorsOnly && len(logLines) > 0 {
+	if cmd.ErrorsOnly && cmd.events == nil && len(logLines) > 0 {
 		// Analyze all logs for comprehensive error detection
 		logContent := strings.Join(logLines, "\n")
 		result, err := parser.AnalyzeLog(strings.NewReader(logContent), stepName)
@@ -289,15 +431,35 @@ func (cmd *LogsCmd) processAccumulatedLogs(logLines []string, stepName string, p
 
 		filtered := parser.FilterErrorsOnly(result)
 		if len(filtered.Errors) > 0 {
-			fmt.Printf("\n📋 Error Summary for %s:\n", stepName)
+			var summary strings.Builder
+			fmt.Fprintf(&summary, "\n📋 Error Summary for %s:\n", stepName)
 			for _, logError := range filtered.Errors {
-				fmt.Printf("  Line %d: %s\n", logError.Line, logError.Content)
+				fmt.Fprintf(&summary, "  Line %d: %s%s\n", logError.Line, logError.Content, occurrences(logError.Count))
 			}
+			cmd.followOutput().printf("%s", summary.String())
 		}
 	}
 	return nil
 }
 
+// notef prints a note about the follow loop, on stderr when stdout carries
+// events
+func (cmd *LogsCmd) notef(format string, args ...interface{}) {
+	if cmd.events != nil {
+		fmt.Fprintf(os.Stderr, format, args...)
+		return
+	}
+	cmd.followOutput().printf(format, args...)
+}
+
+// followOutput is where --follow prints, stdout unless followLogs set one up
+func (cmd *LogsCmd) followOutput() *followOutput {
+	if cmd.follow == nil {
+		cmd.follow = newFollowOutput(os.Stdout)
+	}
+	return cmd.follow
+}
+
 // containsError quickly checks if a log line contains error patterns
 func (cmd *LogsCmd) containsError(line string, parser *utils.LogParser) bool {
 	for _, pattern := range parser.ErrorPatterns {
@@ -354,9 +516,9 @@ func (cmd *LogsCmd) formatText(runCtx *RunContext, pipeline *api.Pipeline, steps
 			if cmd.ErrorsOnly {
 				// Show only errors with context
 				if len(result.Errors) > 0 {
-					fmt.Printf("❌ Found %d error(s):\n\n", len(result.Errors))
+					fmt.Printf("❌ Found %d error(s), %d unique:\n\n", result.ErrorCount, len(result.Errors))
 					for _, logError := range result.Errors {
-						fmt.Printf("Line %d [%s]: %s\n", logError.Line, logError.Category, logError.Content)
+						fmt.Printf("Line %d [%s]: %s%s\n", logError.Line, logError.Category, logError.Content, occurrences(logError.Count))
 						if len(logError.Context) > 0 {
 							fmt.Printf("Context:\n")
 							for _, contextLine := range logError.Context {
@@ -386,8 +548,18 @@ func (cmd *LogsCmd) formatText(runCtx *RunContext, pipeline *api.Pipeline, steps
 		}
 	}
 
+	if cmd.ErrorsOnly {
+		if aggregated := utils.AggregateErrors(results); len(aggregated) > 0 {
+			fmt.Printf("📊 Unique errors across %d step(s):\n", len(results))
+			for _, agg := range aggregated {
+				fmt.Printf("  %4dx [%s] %s (%s)\n", agg.Count, agg.Category, agg.Content, strings.Join(agg.Steps, ", "))
+			}
+		}
+		return nil
+	}
+
 	// Summary
-	if len(results) > 0 && !cmd.ErrorsOnly {
+	if len(results) > 0 {
 		totalErrors := 0
 		totalWarnings := 0
 		for _, result := range results {
@@ -402,6 +574,14 @@ func (cmd *LogsCmd) formatText(runCtx *RunContext, pipeline *api.Pipeline, steps
 	return nil
 }
 
+// occurrences renders a repeat count suffix for deduplicated errors
+func occurrences(count int) string {
+	if count <= 1 {
+		return ""
+	}
+	return fmt.Sprintf(" (×%d)", count)
+}
+
 // formatJSON formats logs as structured JSON for AI/automation
 func (cmd *LogsCmd) formatJSON(runCtx *RunContext, pipeline *api.Pipeline, steps []*api.PipelineStep, results []*utils.LogAnalysisResult) error {
 	output := map[string]interface{}{
@@ -427,6 +607,9 @@ func (cmd *LogsCmd) formatJSON(runCtx *RunContext, pipeline *api.Pipeline, steps
 			"analyzed_at": time.Now(),
 		},
 	}
+	if cmd.ErrorsOnly {
+		output["unique_errors"] = utils.AggregateErrors(results)
+	}
 
 	return runCtx.Formatter.Format(output)
 }
@@ -456,6 +639,9 @@ func (cmd *LogsCmd) formatYAML(runCtx *RunContext, pipeline *api.Pipeline, steps
 			"analyzed_at": time.Now(),
 		},
 	}
+	if cmd.ErrorsOnly {
+		output["unique_errors"] = utils.AggregateErrors(results)
+	}
 
 	return runCtx.Formatter.Format(output)
 }
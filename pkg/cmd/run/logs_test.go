@@ -103,7 +103,7 @@ func TestMatchesStepName(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.stepName+"_vs_"+tt.requestedName, func(t *testing.T) {
-			result := matchesStepName(tt.stepName, tt.requestedName)
+			result := matchesStepName(tt.stepName, tt.requestedName, nil)
 			assert.Equal(t, tt.shouldMatch, result, "Step name '%s' should match '%s': %v", tt.stepName, tt.requestedName, tt.shouldMatch)
 		})
 	}
@@ -147,7 +147,7 @@ func TestFilterStepsByName(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			filtered := filterStepsByName(steps, tt.stepFilter)
+			filtered := filterStepsByName(steps, tt.stepFilter, nil)
 
 			assert.Equal(t, len(tt.expectedUUIDs), len(filtered), "Filtered step count mismatch")
 
@@ -160,6 +160,49 @@ func TestFilterStepsByName(t *testing.T) {
 	}
 }
 
+func TestFilterStepsByStatus(t *testing.T) {
+	completed := func(result string) *api.PipelineState {
+		return &api.PipelineState{Name: "COMPLETED", Result: &api.PipelineResult{Name: result}}
+	}
+	steps := []*api.PipelineStep{
+		{Name: "build", UUID: "step1", State: completed("SUCCESSFUL")},
+		{Name: "test", UUID: "step2", State: completed("FAILED")},
+		{Name: "lint", UUID: "step3", State: &api.PipelineState{Name: "ERROR"}},
+		{Name: "deploy", UUID: "step4", State: &api.PipelineState{Name: "IN_PROGRESS"}},
+		{Name: "notify", UUID: "step5", State: &api.PipelineState{Name: "PENDING"}},
+		{Name: "unknown", UUID: "step6"},
+	}
+
+	tests := []struct {
+		name          string
+		filter        stepStatusFilter
+		expectedUUIDs []string
+	}{
+		{"no filter keeps all", stepStatusFilter{}, []string{"step1", "step2", "step3", "step4", "step5", "step6"}},
+		{"failed", stepStatusFilter{failed: true}, []string{"step2", "step3"}},
+		{"successful", stepStatusFilter{successful: true}, []string{"step1"}},
+		{"running", stepStatusFilter{running: true}, []string{"step4"}},
+		{"failed or running", stepStatusFilter{failed: true, running: true}, []string{"step2", "step3", "step4"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filtered := filterStepsByStatus(steps, tt.filter)
+
+			uuids := make([]string, len(filtered))
+			for i, step := range filtered {
+				uuids[i] = step.UUID
+			}
+			assert.Equal(t, tt.expectedUUIDs, uuids)
+		})
+	}
+}
+
+func TestStepStatusFilter_Describe(t *testing.T) {
+	assert.Equal(t, "failed", stepStatusFilter{failed: true}.describe())
+	assert.Equal(t, "successful or running", stepStatusFilter{successful: true, running: true}.describe())
+}
+
 func TestGetAvailableStepNames(t *testing.T) {
 	steps := []*api.PipelineStep{
 		{Name: "build"},
@@ -470,7 +513,7 @@ func BenchmarkMatchesStepName(b *testing.B) {
 	for i := 0; i < b.N; i++ {
 		stepName := stepNames[i%len(stepNames)]
 		requestedName := requestedNames[i%len(requestedNames)]
-		matchesStepName(stepName, requestedName)
+		matchesStepName(stepName, requestedName, nil)
 	}
 }
 
@@ -485,7 +528,7 @@ func BenchmarkFilterStepsByName(b *testing.B) {
 
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
-		filterStepsByName(steps, "step")
+		filterStepsByName(steps, "step", nil)
 	}
 }
 
@@ -508,3 +551,24 @@ func BenchmarkLogsCmd_ContainsError(b *testing.B) {
 		cmd.containsError(line, parser)
 	}
 }
+
+func TestLogsCmd_ContextWindow(t *testing.T) {
+	tests := []struct {
+		name          string
+		cmd           LogsCmd
+		before, after int
+	}{
+		{"symmetric", LogsCmd{Context: 3, ContextBefore: -1, ContextAfter: -1}, 3, 3},
+		{"before only", LogsCmd{Context: 3, ContextBefore: 8, ContextAfter: -1}, 8, 3},
+		{"after only", LogsCmd{Context: 3, ContextBefore: -1, ContextAfter: 0}, 3, 0},
+		{"both", LogsCmd{Context: 3, ContextBefore: 10, ContextAfter: 1}, 10, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			before, after := tt.cmd.contextWindow()
+			assert.Equal(t, tt.before, before)
+			assert.Equal(t, tt.after, after)
+		})
+	}
+}
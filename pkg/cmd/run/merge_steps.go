@@ -0,0 +1,132 @@
+package run
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/carlosarraes/bt/pkg/api"
+)
+
+// stepLogLines is the raw log of one step, for --merge-steps
+type stepLogLines struct {
+	Step  *api.PipelineStep
+	Lines []string
+}
+
+// mergedLogLine is a log line tagged with the step it came from
+type mergedLogLine struct {
+	Step string `json:"step" yaml:"step"`
+	Line int    `json:"line" yaml:"line"`
+	Text string `json:"text" yaml:"text"`
+}
+
+// mergedLogs is the --merge-steps output for json and yaml
+type mergedLogs struct {
+	Pipeline int             `json:"pipeline" yaml:"pipeline"`
+	Lines    []mergedLogLine `json:"lines" yaml:"lines"`
+}
+
+// mergeStepLogs puts the lines of every step into one stream: steps in the
+// order they started, steps that never started last, and each step's lines
+// in log order. Lines are numbered from 1 within their step.
+func mergeStepLogs(logs []stepLogLines) []mergedLogLine {
+	ordered := make([]stepLogLines, len(logs))
+	copy(ordered, logs)
+	sort.SliceStable(ordered, func(i, j int) bool {
+		a, b := ordered[i].Step.StartedOn, ordered[j].Step.StartedOn
+		if a == nil || b == nil {
+			return a != nil && b == nil
+		}
+		return a.Before(*b)
+	})
+
+	var merged []mergedLogLine
+	for _, log := range ordered {
+		for i, line := range log.Lines {
+			merged = append(merged, mergedLogLine{Step: log.Step.Name, Line: i + 1, Text: line})
+		}
+	}
+	return merged
+}
+
+// writeMergedLogLines writes the lines prefixed with their step name, padded
+// so the log text lines up
+func writeMergedLogLines(w io.Writer, lines []mergedLogLine) error {
+	width := 0
+	for _, line := range lines {
+		if len(line.Step) > width {
+			width = len(line.Step)
+		}
+	}
+
+	prefixed := make([]string, len(lines))
+	for i, line := range lines {
+		prefixed[i] = fmt.Sprintf("[%-*s] %s", width, line.Step, line.Text)
+	}
+	return writeLogLines(w, prefixed)
+}
+
+// viewMergedLogs shows the raw logs of the selected steps as one
+// chronological stream
+func (cmd *LogsCmd) viewMergedLogs(ctx context.Context, runCtx *RunContext, pipeline *api.Pipeline) error {
+	steps, err := runCtx.Client.Pipelines.GetPipelineSteps(ctx, runCtx.Workspace, runCtx.Repository, pipeline.UUID)
+	if err != nil {
+		return handlePipelineAPIError(err)
+	}
+
+	if cmd.Step != "" {
+		aliases := stepAliases(runCtx)
+		filtered := filterStepsByName(steps, cmd.Step, aliases)
+		if len(filtered) == 0 {
+			return stepNotFoundError(cmd.Step, aliases, steps)
+		}
+		steps = filtered
+	}
+	statusFilter := cmd.statusFilter()
+	steps = filterStepsByStatus(steps, statusFilter)
+	if len(steps) == 0 && cmd.Output == "text" {
+		fmt.Printf("No %s steps in pipeline #%d\n", statusFilter.describe(), pipeline.BuildNumber)
+		return nil
+	}
+
+	logs := make([]stepLogLines, 0, len(steps))
+	for _, step := range steps {
+		logReader, err := runCtx.Client.Pipelines.GetStepLogs(ctx, runCtx.Workspace, runCtx.Repository, pipeline.UUID, step.UUID)
+		if err != nil {
+			if isMissingStepLog(err) {
+				continue
+			}
+			return fmt.Errorf("failed to get logs of step '%s': %w", step.Name, err)
+		}
+
+		content, err := io.ReadAll(logReader)
+		logReader.Close()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: could not read logs of step '%s': %v\n", step.Name, err)
+			continue
+		}
+
+		text := strings.TrimSuffix(strings.ReplaceAll(string(content), "\r\n", "\n"), "\n")
+		if text == "" {
+			continue
+		}
+		logs = append(logs, stepLogLines{Step: step, Lines: cmd.redactor.RedactLines(strings.Split(text, "\n"))})
+	}
+
+	merged := mergeStepLogs(logs)
+	switch cmd.Output {
+	case "text":
+		return writeMergedLogLines(os.Stdout, merged)
+	case "json", "yaml":
+		if merged == nil {
+			merged = []mergedLogLine{}
+		}
+		return runCtx.Formatter.Format(&mergedLogs{Pipeline: pipeline.BuildNumber, Lines: merged})
+	default:
+		return fmt.Errorf("unsupported output format: %s", cmd.Output)
+	}
+}
@@ -0,0 +1,74 @@
+package run
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/carlosarraes/bt/pkg/api"
+	"github.com/carlosarraes/bt/pkg/api/apitest"
+	"github.com/carlosarraes/bt/pkg/cmd/shared"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMergeStepLogs(t *testing.T) {
+	start := time.Date(2026, 1, 2, 10, 0, 0, 0, time.UTC)
+	at := func(minutes int) *time.Time {
+		started := start.Add(time.Duration(minutes) * time.Minute)
+		return &started
+	}
+
+	logs := []stepLogLines{
+		{Step: &api.PipelineStep{Name: "deploy"}, Lines: []string{"waiting"}},
+		{Step: &api.PipelineStep{Name: "test", StartedOn: at(2)}, Lines: []string{"go test", "ok"}},
+		{Step: &api.PipelineStep{Name: "build", StartedOn: at(0)}, Lines: []string{"go build"}},
+		{Step: &api.PipelineStep{Name: "lint", StartedOn: at(2)}, Lines: []string{"golangci-lint"}},
+	}
+
+	assert.Equal(t, []mergedLogLine{
+		{Step: "build", Line: 1, Text: "go build"},
+		{Step: "test", Line: 1, Text: "go test"},
+		{Step: "test", Line: 2, Text: "ok"},
+		{Step: "lint", Line: 1, Text: "golangci-lint"},
+		{Step: "deploy", Line: 1, Text: "waiting"},
+	}, mergeStepLogs(logs))
+}
+
+func TestWriteMergedLogLines(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, writeMergedLogLines(&buf, []mergedLogLine{
+		{Step: "build", Line: 1, Text: "go build"},
+		{Step: "unit tests", Line: 1, Text: "go test"},
+	}))
+	assert.Equal(t, "[build     ] go build\n[unit tests] go test\n", buf.String())
+}
+
+func TestLogsCmd_MergeSteps_LogErrors(t *testing.T) {
+	apitest.CommandEnv(t, "ws", "repo")
+	steps := apitest.Fixture{Method: "GET", Path: "/repositories/ws/repo/pipelines/{p-1}/steps", Status: 200,
+		Body: []byte(`{"values": [
+			{"uuid": "{s-1}", "name": "Build", "state": {"name": "COMPLETED", "result": {"name": "SUCCESSFUL"}}},
+			{"uuid": "{s-2}", "name": "Deploy", "state": {"name": "PENDING"}}
+		]}`)}
+	transport := apitest.NewReplayTransport(
+		apitest.Fixture{Method: "GET", Path: "/repositories/ws/repo/pipelines/{p-1}", Status: 200,
+			Body: []byte(`{"uuid": "{p-1}", "build_number": 5, "state": {"name": "IN_PROGRESS"}}`)},
+		steps,
+		apitest.Fixture{Method: "GET", Path: "/repositories/ws/repo/pipelines_config/variables", Status: 200,
+			Body: []byte(`{"values": []}`)},
+		apitest.Fixture{Method: "GET", Path: "/repositories/ws/repo/pipelines/{p-1}/steps/{s-1}/log", Status: 403,
+			Body: []byte(`{"error": {"message": "Forbidden"}}`)},
+		steps,
+	)
+	t.Cleanup(shared.SetClientTransport(transport))
+
+	var runErr error
+	captureStdout(func() {
+		runErr = (&LogsCmd{PipelineID: "{p-1}", MergeSteps: true, Output: "text", NoColor: true}).Run(context.Background())
+	})
+
+	require.Error(t, runErr, "a forbidden log must not be skipped as if the step never ran")
+	assert.Contains(t, runErr.Error(), "failed to get logs of step 'Build'")
+}
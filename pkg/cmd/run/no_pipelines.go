@@ -0,0 +1,12 @@
+package run
+
+import (
+	"context"
+
+	"github.com/carlosarraes/bt/pkg/cmd/shared"
+)
+
+// noPipelinesMessage explains why a repository has no pipelines at all
+func noPipelinesMessage(ctx context.Context, runCtx *RunContext) string {
+	return shared.NoPipelinesMessage(ctx, runCtx)
+}
@@ -0,0 +1,102 @@
+package run
+
+import (
+	"context"
+	"testing"
+
+	"github.com/carlosarraes/bt/pkg/api/apitest"
+	"github.com/carlosarraes/bt/pkg/cmd/shared"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolvePipelineUUID_NoPipelines(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  apitest.Fixture
+		wantErr string
+	}{
+		{
+			name:    "pipelines disabled",
+			config:  apitest.Fixture{Status: 200, Body: []byte(`{"enabled": false}`)},
+			wantErr: "pipelines are not enabled for ws/repo",
+		},
+		{
+			name:    "no pipelines yet",
+			config:  apitest.Fixture{Status: 200, Body: []byte(`{"enabled": true}`)},
+			wantErr: "ws/repo has no pipelines yet",
+		},
+		{
+			name:    "config not readable",
+			config:  apitest.Fixture{Status: 403, Body: []byte(`{"type": "error", "error": {"message": "Forbidden"}}`)},
+			wantErr: "ws/repo has no pipelines yet",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			apitest.CommandEnv(t, "ws", "repo")
+			config := tt.config
+			config.Method, config.Path = "GET", "/repositories/ws/repo/pipelines_config"
+			transport := apitest.NewReplayTransport(
+				apitest.Fixture{Method: "GET", Path: "/repositories/ws/repo/pipelines", Status: 200, Body: []byte(`{"values": []}`)},
+				config,
+			)
+			t.Cleanup(shared.SetClientTransport(transport))
+
+			runCtx, err := shared.NewCommandContext(context.Background(), "table", true)
+			require.NoError(t, err)
+
+			_, err = resolvePipelineUUID(context.Background(), runCtx, "7")
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestResolvePipelineUUID_BuildNumberNotFound(t *testing.T) {
+	apitest.CommandEnv(t, "ws", "repo")
+	transport := apitest.NewReplayTransport(apitest.Fixture{
+		Method: "GET",
+		Path:   "/repositories/ws/repo/pipelines",
+		Status: 200,
+		Body:   []byte(`{"values": [{"uuid": "{b}", "build_number": 12}, {"uuid": "{a}", "build_number": 10}]}`),
+	})
+	t.Cleanup(shared.SetClientTransport(transport))
+
+	runCtx, err := shared.NewCommandContext(context.Background(), "table", true)
+	require.NoError(t, err)
+
+	_, err = resolvePipelineUUID(context.Background(), runCtx, "#99")
+	assert.ErrorContains(t, err, "pipeline #99 not found: the latest pipeline in ws/repo is #12")
+
+	_, err = resolvePipelineUUID(context.Background(), runCtx, "11")
+	assert.ErrorContains(t, err, "pipeline #11 not found in ws/repo; it may have been deleted")
+
+	uuid, err := resolvePipelineUUID(context.Background(), runCtx, "10")
+	require.NoError(t, err)
+	assert.Equal(t, "{a}", uuid)
+}
+
+func TestListCmd_Run_NoPipelines(t *testing.T) {
+	apitest.CommandEnv(t, "ws", "repo")
+	transport := apitest.NewReplayTransport(
+		apitest.Fixture{Method: "GET", Path: "/repositories/ws/repo/pipelines", Status: 200, Body: []byte(`{"values": []}`)},
+		apitest.Fixture{Method: "GET", Path: "/repositories/ws/repo/pipelines_config", Status: 200, Body: []byte(`{"enabled": false}`)},
+	)
+	t.Cleanup(shared.SetClientTransport(transport))
+
+	var runErr error
+	out := captureStdout(func() {
+		cmd := &ListCmd{Limit: 10, Output: "table", NoColor: true}
+		runErr = cmd.Run(context.Background())
+	})
+	require.NoError(t, runErr)
+	assert.Contains(t, out, "No pipeline runs found: pipelines are not enabled for ws/repo")
+
+	out = captureStdout(func() {
+		cmd := &ListCmd{Branch: "main", Limit: 10, Output: "table", NoColor: true}
+		runErr = cmd.Run(context.Background())
+	})
+	require.NoError(t, runErr)
+	assert.Equal(t, "No pipeline runs found\n", out)
+}
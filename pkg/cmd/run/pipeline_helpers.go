@@ -2,60 +2,25 @@ package run
 
 import (
 	"context"
+	"errors"
 	"fmt"
-	"strconv"
 	"strings"
 
 	"github.com/carlosarraes/bt/pkg/api"
+	"github.com/carlosarraes/bt/pkg/cmd/shared"
 	"github.com/carlosarraes/bt/pkg/output"
 )
 
 func resolvePipelineUUID(ctx context.Context, runCtx *RunContext, pipelineID string) (string, error) {
-	pipelineID = strings.TrimSpace(pipelineID)
-
-	if strings.Contains(pipelineID, "-") {
-		return pipelineID, nil
-	}
-
-	if strings.HasPrefix(pipelineID, "#") {
-		pipelineID = pipelineID[1:]
-	}
-
-	buildNumber, err := strconv.Atoi(pipelineID)
-	if err != nil {
-		return "", fmt.Errorf("invalid pipeline ID '%s'. Expected build number (e.g., 123, #123) or UUID", pipelineID)
-	}
-
-	options := &api.PipelineListOptions{
-		PageLen: 100,
-		Page:    1,
-		Sort:    "-created_on",
-	}
-
-	for options.Page <= 5 {
-		result, err := runCtx.Client.Pipelines.ListPipelines(ctx, runCtx.Workspace, runCtx.Repository, options)
-		if err != nil {
-			return "", handlePipelineAPIError(err)
-		}
-
-		pipelines, err := parsePipelineResults(result)
-		if err != nil {
-			return "", fmt.Errorf("failed to parse pipeline results: %w", err)
-		}
-
-		for _, pipeline := range pipelines {
-			if pipeline.BuildNumber == buildNumber {
-				return pipeline.UUID, nil
-			}
-		}
-
-		if result.Next == "" {
-			break
-		}
-		options.Page++
-	}
+	return shared.ResolvePipelineUUID(ctx, runCtx, pipelineID)
+}
 
-	return "", fmt.Errorf("pipeline with build number %d not found", buildNumber)
+// isMissingStepLog reports whether a failure to fetch a step's log only
+// means the step has none, as with steps that never ran (skipped, pending
+// manual). Authentication, rate-limit and server errors are not.
+func isMissingStepLog(err error) bool {
+	var bbErr *api.BitbucketError
+	return errors.As(err, &bbErr) && bbErr.Type == api.ErrorTypeNotFound
 }
 
 func displayStepInfo(step *api.PipelineStep) {
@@ -102,7 +67,10 @@ func displayStepInfo(step *api.PipelineStep) {
 	fmt.Println()
 }
 
-func displayTestResults(ctx context.Context, runCtx *RunContext, pipeline *api.Pipeline, step *api.PipelineStep) {
+// displayTestResults prints the test reports of a step with the details of
+// each failed test case. With full, passing and skipped test cases are
+// listed too, one line each.
+func displayTestResults(ctx context.Context, runCtx *RunContext, pipeline *api.Pipeline, step *api.PipelineStep, full bool) {
 	reports, err := runCtx.Client.Pipelines.GetStepTestReports(ctx, runCtx.Workspace, runCtx.Repository, pipeline.UUID, step.UUID)
 	if err != nil {
 		fmt.Printf("No test reports available: %v\n", err)
@@ -115,9 +83,7 @@ func displayTestResults(ctx context.Context, runCtx *RunContext, pipeline *api.P
 	}
 
 	fmt.Printf("\n🧪 Test Reports Summary:\n")
-	totalPassed := 0
 	totalFailed := 0
-	totalSkipped := 0
 
 	for _, report := range reports {
 		fmt.Printf("  Report: %s\n", report.Name)
@@ -125,9 +91,7 @@ func displayTestResults(ctx context.Context, runCtx *RunContext, pipeline *api.P
 		if report.Total > 0 {
 			fmt.Printf("    Tests: %d total, %d passed, %d failed, %d skipped\n",
 				report.Total, report.Passed, report.Failed, report.Skipped)
-			totalPassed += report.Passed
 			totalFailed += report.Failed
-			totalSkipped += report.Skipped
 		}
 		if report.Duration > 0 {
 			fmt.Printf("    Duration: %.2fs\n", report.Duration)
@@ -135,77 +99,189 @@ func displayTestResults(ctx context.Context, runCtx *RunContext, pipeline *api.P
 		fmt.Println()
 	}
 
-	_ = totalPassed
-	_ = totalSkipped
+	if totalFailed == 0 && !full {
+		fmt.Printf("✅ All tests passed!\n")
+		return
+	}
 
 	if totalFailed > 0 {
 		fmt.Printf("❌ Getting details for %d failed test(s)...\n\n", totalFailed)
+	}
+
+	testCases, err := runCtx.Client.Pipelines.GetStepTestCases(ctx, runCtx.Workspace, runCtx.Repository, pipeline.UUID, step.UUID)
+	if err != nil {
+		fmt.Printf("Could not get detailed test cases: %v\n", err)
+		return
+	}
 
-		testCases, err := runCtx.Client.Pipelines.GetStepTestCases(ctx, runCtx.Workspace, runCtx.Repository, pipeline.UUID, step.UUID)
-		if err != nil {
-			fmt.Printf("Could not get detailed test cases: %v\n", err)
-			return
+	failedTests := 0
+	for _, testCase := range testCases {
+		if !isFailedTestCase(testCase) {
+			if full {
+				displayTestCaseLine(testCase)
+			}
+			continue
 		}
 
-		failedTests := 0
-		for _, testCase := range testCases {
-			if testCase.Status == "FAILED" || testCase.Result == "FAILED" {
-				failedTests++
-				fmt.Printf("❌ Test Failed: %s\n", testCase.Name)
-				if testCase.ClassName != "" {
-					fmt.Printf("   Class: %s\n", testCase.ClassName)
-				}
-				if testCase.TestSuite != "" {
-					fmt.Printf("   Suite: %s\n", testCase.TestSuite)
-				}
-				if testCase.Duration > 0 {
-					fmt.Printf("   Duration: %.2fs\n", testCase.Duration)
-				}
-				if testCase.Message != "" {
-					fmt.Printf("   Message: %s\n", testCase.Message)
-				}
-				if testCase.Stacktrace != "" {
-					fmt.Printf("   Stacktrace:\n%s\n", testCase.Stacktrace)
-				}
+		failedTests++
+		fmt.Printf("❌ Test Failed: %s\n", testCase.Name)
+		if testCase.ClassName != "" {
+			fmt.Printf("   Class: %s\n", testCase.ClassName)
+		}
+		if testCase.TestSuite != "" {
+			fmt.Printf("   Suite: %s\n", testCase.TestSuite)
+		}
+		if testCase.Duration > 0 {
+			fmt.Printf("   Duration: %.2fs\n", testCase.Duration)
+		}
+		if testCase.Message != "" {
+			fmt.Printf("   Message: %s\n", testCase.Message)
+		}
+		if testCase.Stacktrace != "" {
+			fmt.Printf("   Stacktrace:\n%s\n", testCase.Stacktrace)
+		}
 
-				reasons, err := runCtx.Client.Pipelines.GetTestCaseReasons(ctx, runCtx.Workspace, runCtx.Repository, pipeline.UUID, step.UUID, testCase.UUID)
-				if err == nil && len(reasons) > 0 {
-					fmt.Printf("   Detailed Output:\n")
-					for _, reason := range reasons {
-						if reason.Message != "" {
-							fmt.Printf("     %s\n", reason.Message)
-						}
-						if reason.Output != "" {
-							fmt.Printf("     %s\n", reason.Output)
-						}
-					}
+		reasons, err := runCtx.Client.Pipelines.GetTestCaseReasons(ctx, runCtx.Workspace, runCtx.Repository, pipeline.UUID, step.UUID, testCase.UUID)
+		if err == nil && len(reasons) > 0 {
+			fmt.Printf("   Detailed Output:\n")
+			for _, reason := range reasons {
+				if reason.Message != "" {
+					fmt.Printf("     %s\n", reason.Message)
+				}
+				if reason.Output != "" {
+					fmt.Printf("     %s\n", reason.Output)
 				}
-				fmt.Println()
 			}
 		}
+		fmt.Println()
+	}
+
+	switch {
+	case totalFailed > 0 && failedTests == 0:
+		fmt.Printf("Could not find detailed information for failed tests\n")
+	case totalFailed == 0:
+		fmt.Printf("\n✅ All tests passed!\n")
+	}
+}
+
+func isFailedTestCase(testCase *api.TestCase) bool {
+	return testCase.Status == "FAILED" || testCase.Result == "FAILED"
+}
+
+// displayTestCaseLine prints a test case that didn't fail as one line with
+// its outcome and duration
+func displayTestCaseLine(testCase *api.TestCase) {
+	outcome := testCase.Result
+	if outcome == "" {
+		outcome = testCase.Status
+	}
+
+	icon, label := "✅", "Passed"
+	if strings.EqualFold(outcome, "SKIPPED") || strings.EqualFold(outcome, "IGNORED") {
+		icon, label = "⏭️ ", "Skipped"
+	}
+
+	name := testCase.Name
+	if testCase.ClassName != "" {
+		name = testCase.ClassName + "." + name
+	}
+	if testCase.Duration > 0 {
+		fmt.Printf("%s %s: %s (%.2fs)\n", icon, label, name, testCase.Duration)
+		return
+	}
+	fmt.Printf("%s %s: %s\n", icon, label, name)
+}
 
-		if failedTests == 0 {
-			fmt.Printf("Could not find detailed information for failed tests\n")
+// filterStepsByName keeps the steps matching the --step value stepName,
+// resolved through the configured step aliases
+func filterStepsByName(steps []*api.PipelineStep, stepName string, aliases map[string]string) []*api.PipelineStep {
+	var filtered []*api.PipelineStep
+	for _, step := range steps {
+		if matchesStepName(step.Name, stepName, aliases) {
+			filtered = append(filtered, step)
 		}
-	} else {
-		fmt.Printf("✅ All tests passed!\n")
 	}
+	return filtered
+}
+
+// stepStatusFilter selects steps by outcome. Set fields are combined, so
+// failed and running keeps steps that are either; the zero value keeps all.
+type stepStatusFilter struct {
+	failed     bool
+	successful bool
+	running    bool
+}
+
+func (f stepStatusFilter) active() bool {
+	return f.failed || f.successful || f.running
+}
+
+// describe names the selected outcomes for messages, e.g. "failed or running"
+func (f stepStatusFilter) describe() string {
+	var parts []string
+	if f.failed {
+		parts = append(parts, "failed")
+	}
+	if f.successful {
+		parts = append(parts, "successful")
+	}
+	if f.running {
+		parts = append(parts, "running")
+	}
+	return strings.Join(parts, " or ")
+}
+
+func (f stepStatusFilter) matches(step *api.PipelineStep) bool {
+	if !f.active() {
+		return true
+	}
+	if step.State == nil {
+		return false
+	}
+
+	name := step.State.Name
+	result := ""
+	if step.State.Result != nil {
+		result = step.State.Result.Name
+	}
+
+	switch {
+	case f.failed && (name == "FAILED" || name == "ERROR" || result == "FAILED" || result == "ERROR"):
+		return true
+	case f.successful && (name == "SUCCESSFUL" || result == "SUCCESSFUL"):
+		return true
+	case f.running && (name == "IN_PROGRESS" || name == "RUNNING"):
+		return true
+	}
+	return false
 }
 
-func filterStepsByName(steps []*api.PipelineStep, stepName string) []*api.PipelineStep {
+func filterStepsByStatus(steps []*api.PipelineStep, filter stepStatusFilter) []*api.PipelineStep {
+	if !filter.active() {
+		return steps
+	}
+
 	var filtered []*api.PipelineStep
 	for _, step := range steps {
-		if matchesStepName(step.Name, stepName) {
+		if filter.matches(step) {
 			filtered = append(filtered, step)
 		}
 	}
 	return filtered
 }
 
-func matchesStepName(stepName, requestedName string) bool {
+// matchesStepName reports whether a step is selected by requestedName. An
+// alias from run.step_aliases stands for its pattern; a pattern with * is
+// matched against the whole name, anything else as part of it.
+func matchesStepName(stepName, requestedName string, aliases map[string]string) bool {
+	requestedName = resolveStepAlias(requestedName, aliases)
 	stepNameLower := strings.ToLower(stepName)
 	requestedLower := strings.ToLower(requestedName)
 
+	if strings.Contains(requestedLower, "*") {
+		return matchesStepGlob(stepNameLower, requestedLower)
+	}
+
 	if stepNameLower == requestedLower {
 		return true
 	}
@@ -221,6 +297,58 @@ func matchesStepName(stepName, requestedName string) bool {
 	return false
 }
 
+// resolveStepAlias returns the pattern configured for alias, or alias itself
+// when it isn't one. Aliases are case-insensitive.
+func resolveStepAlias(alias string, aliases map[string]string) string {
+	if pattern, ok := aliases[alias]; ok {
+		return pattern
+	}
+	for name, pattern := range aliases {
+		if strings.EqualFold(name, alias) {
+			return pattern
+		}
+	}
+	return alias
+}
+
+// matchesStepGlob matches name against a pattern where * stands for any
+// run of characters, including none
+func matchesStepGlob(name, pattern string) bool {
+	parts := strings.Split(pattern, "*")
+	if !strings.HasPrefix(name, parts[0]) {
+		return false
+	}
+	name = name[len(parts[0]):]
+
+	last := len(parts) - 1
+	for _, part := range parts[1:last] {
+		i := strings.Index(name, part)
+		if i < 0 {
+			return false
+		}
+		name = name[i+len(part):]
+	}
+	return strings.HasSuffix(name, parts[last])
+}
+
+// stepAliases returns the configured run.step_aliases
+func stepAliases(runCtx *RunContext) map[string]string {
+	if runCtx.Config == nil {
+		return nil
+	}
+	return runCtx.Config.Run.StepAliases
+}
+
+// stepNotFoundError reports that no step matched stepName, naming the
+// pattern it stands for when it is an alias
+func stepNotFoundError(stepName string, aliases map[string]string, steps []*api.PipelineStep) error {
+	requested := fmt.Sprintf("'%s'", stepName)
+	if pattern := resolveStepAlias(stepName, aliases); pattern != stepName {
+		requested += fmt.Sprintf(" (alias of '%s')", pattern)
+	}
+	return fmt.Errorf("step %s not found. Available steps: %s", requested, getAvailableStepNames(steps))
+}
+
 func getAvailableStepNames(steps []*api.PipelineStep) string {
 	names := make([]string, len(steps))
 	for i, step := range steps {
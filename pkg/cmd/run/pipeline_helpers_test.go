@@ -0,0 +1,155 @@
+package run
+
+import (
+	"context"
+	"testing"
+
+	"github.com/carlosarraes/bt/pkg/api"
+	"github.com/carlosarraes/bt/pkg/api/apitest"
+	"github.com/carlosarraes/bt/pkg/cmd/shared"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDisplayTestResults_Full(t *testing.T) {
+	const stepPath = "/repositories/ws/repo/pipelines/p1/steps/s1/test_reports"
+
+	tests := []struct {
+		name        string
+		full        bool
+		want        []string
+		notWant     []string
+		wantFetches int
+	}{
+		{
+			name:        "failed only",
+			full:        false,
+			want:        []string{"✅ All tests passed!"},
+			notWant:     []string{"TestLogin", "TestLegacy"},
+			wantFetches: 1,
+		},
+		{
+			name:        "full",
+			full:        true,
+			want:        []string{"✅ Passed: auth.TestLogin (0.25s)", "Skipped: TestLegacy", "✅ All tests passed!"},
+			wantFetches: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			apitest.CommandEnv(t, "ws", "repo")
+			transport := apitest.NewReplayTransport(
+				apitest.Fixture{Method: "GET", Path: stepPath, Status: 200,
+					Body: []byte(`{"values": [{"uuid": "r1", "name": "unit", "status": "PASSED", "total": 2, "passed": 1, "skipped": 1}]}`)},
+				apitest.Fixture{Method: "GET", Path: stepPath + "/test_cases", Status: 200,
+					Body: []byte(`{"values": [
+						{"uuid": "c1", "name": "TestLogin", "class_name": "auth", "status": "SUCCESSFUL", "duration": 0.25},
+						{"uuid": "c2", "name": "TestLegacy", "status": "SKIPPED"}
+					]}`)},
+			)
+			t.Cleanup(shared.SetClientTransport(transport))
+
+			runCtx, err := shared.NewCommandContext(context.Background(), "table", true)
+			require.NoError(t, err)
+
+			out := captureStdout(func() {
+				displayTestResults(context.Background(), runCtx, &api.Pipeline{UUID: "p1"}, &api.PipelineStep{UUID: "s1"}, tt.full)
+			})
+
+			for _, want := range tt.want {
+				assert.Contains(t, out, want)
+			}
+			for _, notWant := range tt.notWant {
+				assert.NotContains(t, out, notWant)
+			}
+			assert.Len(t, transport.Requests(), tt.wantFetches)
+		})
+	}
+}
+
+func TestViewCmd_Run_TestOutputRequiresTests(t *testing.T) {
+	cmd := &ViewCmd{PipelineID: "1", Output: "table", TestOutput: "full"}
+	assert.ErrorContains(t, cmd.Run(context.Background()), "--test-output full requires --tests")
+}
+
+func TestMatchesStepName_Aliases(t *testing.T) {
+	aliases := map[string]string{
+		"ut":     "Build and Run Unit Tests*",
+		"deploy": "deploy to production",
+		"lint":   "lint",
+	}
+
+	tests := []struct {
+		stepName  string
+		requested string
+		want      bool
+	}{
+		{"Build and Run Unit Tests (Go 1.22)", "ut", true},
+		{"Build and Run Unit Tests (Go 1.22)", "UT", true},
+		{"Run Unit Tests", "ut", false},
+		{"Deploy to Production", "deploy", true},
+		{"Deploy to Staging", "deploy", false},
+		{"Lint", "lint", true},
+		{"Build and Run Unit Tests (Go 1.22)", "unit", true},
+		{"Build (Go 1.22)", "build*1.22)", true},
+		{"Build (Go 1.22) extra", "build*1.22)", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.stepName+"_vs_"+tt.requested, func(t *testing.T) {
+			assert.Equal(t, tt.want, matchesStepName(tt.stepName, tt.requested, aliases))
+		})
+	}
+}
+
+func TestStepNotFoundError_Alias(t *testing.T) {
+	steps := []*api.PipelineStep{{Name: "Lint"}}
+	aliases := map[string]string{"ut": "Unit*"}
+
+	err := stepNotFoundError("ut", aliases, steps)
+	assert.EqualError(t, err, "step 'ut' (alias of 'Unit*') not found. Available steps: Lint")
+
+	err = stepNotFoundError("build", aliases, steps)
+	assert.EqualError(t, err, "step 'build' not found. Available steps: Lint")
+}
+
+func TestIsMissingStepLog(t *testing.T) {
+	tests := []struct {
+		name        string
+		status      int
+		wantMissing bool
+	}{
+		{name: "step that never ran", status: 404, wantMissing: true},
+		{name: "authentication failure", status: 401, wantMissing: false},
+		{name: "no permission", status: 403, wantMissing: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			apitest.CommandEnv(t, "ws", "repo")
+			transport := apitest.NewReplayTransport(
+				apitest.Fixture{
+					Method: "GET",
+					Path:   "/repositories/ws/repo/pipelines/{p1}/steps/{s1}/log",
+					Status: tt.status,
+					Body:   []byte(`{"error": {"message": "failed"}}`),
+				},
+				apitest.Fixture{
+					Method: "GET",
+					Path:   "/repositories/ws/repo/pipelines/{p1}/steps",
+					Status: 200,
+					Body:   []byte(`{"values": [{"uuid": "{s1}", "name": "Deploy", "state": {"name": "PENDING"}}]}`),
+				},
+			)
+			t.Cleanup(shared.SetClientTransport(transport))
+
+			runCtx, err := shared.NewCommandContext(context.Background(), "table", true)
+			require.NoError(t, err)
+
+			_, err = runCtx.Client.Pipelines.GetStepLogs(context.Background(), "ws", "repo", "{p1}", "{s1}")
+			require.Error(t, err)
+			assert.Equal(t, tt.wantMissing, isMissingStepLog(err))
+		})
+	}
+}
@@ -0,0 +1,17 @@
+package run
+
+import (
+	"context"
+
+	"github.com/carlosarraes/bt/pkg/cmd/shared"
+	"github.com/carlosarraes/bt/pkg/utils"
+)
+
+// newLogRedactor returns the Redactor pipeline logs are shown through, or nil
+// with --no-redact
+func newLogRedactor(ctx context.Context, runCtx *RunContext, noRedact bool) (*utils.Redactor, error) {
+	if noRedact {
+		return nil, nil
+	}
+	return shared.NewLogRedactor(ctx, runCtx)
+}
@@ -0,0 +1,52 @@
+package run
+
+import (
+	"context"
+	"testing"
+
+	"github.com/carlosarraes/bt/pkg/api/apitest"
+	"github.com/carlosarraes/bt/pkg/cmd/shared"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLogsCmd_RedactsSecrets(t *testing.T) {
+	setup := func(t *testing.T) {
+		apitest.CommandEnv(t, "ws", "repo")
+		transport := apitest.NewReplayTransport(
+			apitest.Fixture{Method: "GET", Path: "/repositories/ws/repo/pipelines/{p-1}", Status: 200,
+				Body: []byte(`{"uuid": "{p-1}", "build_number": 5, "state": {"name": "COMPLETED", "result": {"name": "FAILED"}}}`)},
+			apitest.Fixture{Method: "GET", Path: "/repositories/ws/repo/pipelines/{p-1}/steps", Status: 200,
+				Body: []byte(`{"values": [{"uuid": "{s-1}", "name": "Deploy", "state": {"name": "COMPLETED", "result": {"name": "FAILED"}}}]}`)},
+			apitest.Fixture{Method: "GET", Path: "/repositories/ws/repo/pipelines/{p-1}/steps/{s-1}/log", Status: 200,
+				RawBody: "export DEPLOY_KEY=hunter2hunter2\ncurl -H 'Authorization: Bearer abc.def.ghi'\n"},
+			apitest.Fixture{Method: "GET", Path: "/repositories/ws/repo/pipelines_config/variables", Status: 200,
+				Body: []byte(`{"values": [{"key": "DEPLOY_KEY", "secured": true}, {"key": "REGION", "value": "eu-west-1"}]}`)},
+		)
+		t.Cleanup(shared.SetClientTransport(transport))
+	}
+
+	t.Run("masked by default", func(t *testing.T) {
+		setup(t)
+		var runErr error
+		out := captureStdout(func() {
+			runErr = (&LogsCmd{PipelineID: "{p-1}", MergeSteps: true, Output: "text", NoColor: true}).Run(context.Background())
+		})
+		require.NoError(t, runErr)
+
+		assert.Contains(t, out, "[Deploy] export DEPLOY_KEY=[REDACTED]")
+		assert.Contains(t, out, "Bearer [REDACTED]")
+		assert.NotContains(t, out, "hunter2")
+	})
+
+	t.Run("shown with --no-redact", func(t *testing.T) {
+		setup(t)
+		var runErr error
+		out := captureStdout(func() {
+			runErr = (&LogsCmd{PipelineID: "{p-1}", MergeSteps: true, NoRedact: true, Output: "text", NoColor: true}).Run(context.Background())
+		})
+		require.NoError(t, runErr)
+
+		assert.Contains(t, out, "export DEPLOY_KEY=hunter2hunter2")
+	})
+}
@@ -42,14 +42,7 @@ func (cmd *ReportCmd) Run(ctx context.Context) error {
 		return err
 	}
 
-	if cmd.Workspace != "" {
-		runCtx.Workspace = cmd.Workspace
-	}
-	if cmd.Repository != "" {
-		runCtx.Repository = cmd.Repository
-	}
-
-	if err := runCtx.ValidateWorkspaceAndRepo(); err != nil {
+	if err := runCtx.ResolveRepository(cmd.Workspace, cmd.Repository); err != nil {
 		return err
 	}
 
@@ -98,6 +91,11 @@ func (cmd *ReportCmd) Run(ctx context.Context) error {
 		fmt.Printf("DEBUG: About to generate SonarCloud report\n")
 	}
 
+	if cmd.Output == "table" {
+		stopPager := shared.StartPager(ctx)
+		defer stopPager()
+	}
+
 	return cmd.generateReport(ctx, runCtx, sonarCloudService, pipeline)
 }
 
@@ -7,6 +7,7 @@ import (
 	"os"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/carlosarraes/bt/pkg/api"
 	"github.com/carlosarraes/bt/pkg/cmd/shared"
@@ -340,3 +341,24 @@ func TestFormatQualityGateHeader(t *testing.T) {
 		}
 	})
 }
+
+func TestReportCmd_formatOutput_YAMLRoundTrip(t *testing.T) {
+	prID := 7
+	report := &sonarcloud.Report{
+		ProjectKey:    "acme_api",
+		PullRequestID: &prID,
+		Timestamp:     time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC),
+		QualityGate: &sonarcloud.QualityGateInfo{
+			Status: "ERROR",
+			Conditions: []sonarcloud.QualityGateCondition{
+				{MetricKey: "new_coverage", Status: "ERROR", ActualValue: "61.5", Threshold: "80"},
+			},
+		},
+		Coverage: &sonarcloud.CoverageData{Available: true, OverallCoverage: 72.5, NewCodeCoverage: 61.5},
+	}
+
+	cmd := &ReportCmd{Output: "yaml"}
+	assertYAMLMatchesJSON(t, func(runCtx *RunContext) error {
+		return cmd.formatOutput(runCtx, report, &api.Pipeline{BuildNumber: 42}, sonarcloud.FilterOptions{})
+	})
+}
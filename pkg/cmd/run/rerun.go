@@ -16,6 +16,8 @@ type RerunCmd struct {
 	Failed     bool   `help:"Rerun only failed steps"`
 	Step       string `help:"Rerun specific step"`
 	Force      bool   `short:"f" help:"Force rerun without confirmation"`
+	Watch      bool   `short:"w" help:"Watch the new run after triggering it"`
+	Follow     bool   `help:"Stream the new run's logs after triggering it"`
 	Debug      bool   `help:"Show debug information"`
 	Output     string `short:"o" help:"Output format (table, json, yaml)" enum:"table,json,yaml" default:"table"`
 	NoColor    bool
@@ -24,19 +26,16 @@ type RerunCmd struct {
 }
 
 func (cmd *RerunCmd) Run(ctx context.Context) error {
+	if cmd.Watch && cmd.Follow {
+		return fmt.Errorf("--watch and --follow cannot be used together")
+	}
+
 	runCtx, err := shared.NewCommandContext(ctx, cmd.Output, cmd.NoColor)
 	if err != nil {
 		return err
 	}
 
-	if cmd.Workspace != "" {
-		runCtx.Workspace = cmd.Workspace
-	}
-	if cmd.Repository != "" {
-		runCtx.Repository = cmd.Repository
-	}
-
-	if err := runCtx.ValidateWorkspaceAndRepo(); err != nil {
+	if err := runCtx.ResolveRepository(cmd.Workspace, cmd.Repository); err != nil {
 		return err
 	}
 
@@ -71,7 +70,28 @@ func (cmd *RerunCmd) Run(ctx context.Context) error {
 		return fmt.Errorf("failed to trigger pipeline: %w", err)
 	}
 
-	return cmd.outputSuccess(runCtx, pipeline, newPipeline)
+	if err := cmd.outputSuccess(runCtx, pipeline, newPipeline); err != nil {
+		return err
+	}
+
+	return cmd.monitor(ctx, runCtx, newPipeline)
+}
+
+// monitor hands the new run to run watch (--watch) or run logs --follow
+// (--follow) so a rerun can be followed to completion in one command
+func (cmd *RerunCmd) monitor(ctx context.Context, runCtx *RunContext, newPipeline *api.Pipeline) error {
+	switch {
+	case cmd.Watch:
+		fmt.Println()
+		watch := &WatchCmd{Output: cmd.Output, NoColor: cmd.NoColor}
+		return watch.watchPipeline(ctx, runCtx, newPipeline.UUID)
+	case cmd.Follow:
+		fmt.Println()
+		logs := &LogsCmd{Follow: true, Output: "text", NoColor: cmd.NoColor, Context: 3, ContextBefore: -1, ContextAfter: -1}
+		return logs.followLogs(ctx, runCtx, newPipeline)
+	default:
+		return nil
+	}
 }
 
 func (cmd *RerunCmd) findPullRequestByCommit(ctx context.Context, runCtx *RunContext, commitHash string) (*int, error) {
@@ -287,7 +307,9 @@ func (cmd *RerunCmd) outputTable(originalPipeline *api.Pipeline, newPipeline *ap
 		}
 	}
 
-	fmt.Printf("\nUse 'bt run watch %d' to monitor the new pipeline.\n", newPipeline.BuildNumber)
+	if !cmd.Watch && !cmd.Follow {
+		fmt.Printf("\nUse 'bt run watch %d' to monitor the new pipeline.\n", newPipeline.BuildNumber)
+	}
 	return nil
 }
 
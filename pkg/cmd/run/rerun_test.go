@@ -642,6 +642,20 @@ func TestRerunCmd_GitHubCLICompatibility(t *testing.T) {
 	}
 }
 
+func TestRerunCmd_WatchAndFollowExclusive(t *testing.T) {
+	cmd := &RerunCmd{
+		PipelineID: "123",
+		Watch:      true,
+		Follow:     true,
+		Output:     "table",
+		NoColor:    true,
+	}
+
+	err := cmd.Run(context.Background())
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "--watch and --follow cannot be used together")
+}
+
 func TestRerunCmd_EdgeCases(t *testing.T) {
 	tests := []struct {
 		name        string
@@ -0,0 +1,110 @@
+package run
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/carlosarraes/bt/pkg/api"
+	"github.com/carlosarraes/bt/pkg/cmd/shared"
+	"github.com/carlosarraes/bt/pkg/output"
+)
+
+// pipelineSelectLimit is how many recent pipelines the selector offers
+const pipelineSelectLimit = 30
+
+// pipelineSelection narrows the pipelines offered when the pipeline ID is
+// omitted
+type pipelineSelection struct {
+	Status string
+	Branch string
+}
+
+// validate rejects the selection filters when a pipeline ID was given, since
+// they would otherwise be silently ignored
+func (s pipelineSelection) validate(pipelineID string) error {
+	if s.Status == "" && s.Branch == "" {
+		return nil
+	}
+	if strings.TrimSpace(pipelineID) != "" {
+		return fmt.Errorf("--status and --branch pick among recent pipelines; omit the pipeline ID to use them")
+	}
+	if s.Status != "" {
+		return validateStatus(s.Status)
+	}
+	return nil
+}
+
+// selectPipeline lets the user pick one of the repository's recent pipelines
+// and returns its build number
+func selectPipeline(ctx context.Context, runCtx *RunContext, outputFormat string, selection pipelineSelection) (string, error) {
+	if !shared.CanSelect(outputFormat) {
+		return "", fmt.Errorf("pipeline ID is required")
+	}
+
+	options := &api.PipelineListOptions{
+		PageLen: pipelineSelectLimit,
+		Page:    1,
+		Sort:    "-created_on",
+		Branch:  selection.Branch,
+	}
+	if selection.Status != "" {
+		if isClientSideStatus(selection.Status) {
+			options.PageLen = 100
+		} else {
+			options.Status = strings.ToUpper(selection.Status)
+		}
+	}
+
+	result, err := runCtx.Client.Pipelines.ListPipelines(ctx, runCtx.Workspace, runCtx.Repository, options)
+	if err != nil {
+		return "", handlePipelineAPIError(err)
+	}
+
+	pipelines, err := parsePipelineResults(result)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse pipeline results: %w", err)
+	}
+	if isClientSideStatus(selection.Status) {
+		pipelines = filterPipelines(pipelines, selection.Status, "")
+	}
+	if len(pipelines) > pipelineSelectLimit {
+		pipelines = pipelines[:pipelineSelectLimit]
+	}
+	if len(pipelines) == 0 {
+		if selection.Branch == "" && selection.Status == "" {
+			return "", errors.New(noPipelinesMessage(ctx, runCtx))
+		}
+		return "", fmt.Errorf("no recent pipelines found in %s/%s", runCtx.Workspace, runCtx.Repository)
+	}
+
+	labels := make([]string, len(pipelines))
+	for i, pipeline := range pipelines {
+		labels[i] = pipelineOptionLabel(pipeline)
+	}
+
+	index, err := shared.Select("Select a pipeline", "pipeline", labels)
+	if err != nil {
+		return "", err
+	}
+
+	return strconv.Itoa(pipelines[index].BuildNumber), nil
+}
+
+// pipelineOptionLabel describes a pipeline in the selector by build number,
+// status, branch and age
+func pipelineOptionLabel(pipeline *api.Pipeline) string {
+	ref := "-"
+	if pipeline.Target != nil {
+		if pipeline.Target.RefName != "" {
+			ref = pipeline.Target.RefName
+		} else if pipeline.Target.PullRequestId != nil {
+			ref = fmt.Sprintf("PR #%d", *pipeline.Target.PullRequestId)
+		}
+	}
+
+	return fmt.Sprintf("#%-5d %-11s %s  (%s)", pipeline.BuildNumber, pipelineStatus(pipeline), ref,
+		output.FormatRelativeTime(pipeline.CreatedOn))
+}
@@ -0,0 +1,55 @@
+package run
+
+import (
+	"context"
+	"testing"
+
+	"github.com/carlosarraes/bt/pkg/api"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPipelineSelection_Validate(t *testing.T) {
+	assert.NoError(t, pipelineSelection{}.validate("123"))
+	assert.NoError(t, pipelineSelection{Status: "failed", Branch: "main"}.validate(""))
+
+	err := pipelineSelection{Status: "FAILED"}.validate("123")
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "omit the pipeline ID")
+
+	err = pipelineSelection{Status: "BROKEN"}.validate("")
+	assert.Error(t, err)
+}
+
+func TestSelectPipeline_NonInteractive(t *testing.T) {
+	for _, format := range []string{"table", "text", "json", "yaml"} {
+		_, err := selectPipeline(context.Background(), &RunContext{}, format, pipelineSelection{})
+		assert.EqualError(t, err, "pipeline ID is required", format)
+	}
+}
+
+func TestPipelineOptionLabel(t *testing.T) {
+	prID := 42
+	pipeline := &api.Pipeline{
+		BuildNumber: 123,
+		State: &api.PipelineState{
+			Name:   "COMPLETED",
+			Result: &api.PipelineResult{Name: "FAILED"},
+		},
+		Target: &api.PipelineTarget{RefName: "feature/login"},
+	}
+
+	assert.Equal(t, "#123   FAILED      feature/login  (-)", pipelineOptionLabel(pipeline))
+
+	pipeline.State = &api.PipelineState{Name: "IN_PROGRESS"}
+	pipeline.Target = &api.PipelineTarget{PullRequestId: &prID}
+	assert.Equal(t, "#123   IN_PROGRESS PR #42  (-)", pipelineOptionLabel(pipeline))
+}
+
+func TestPipelineStatus(t *testing.T) {
+	assert.Equal(t, "UNKNOWN", pipelineStatus(&api.Pipeline{}))
+	assert.Equal(t, "PENDING", pipelineStatus(&api.Pipeline{State: &api.PipelineState{Name: "PENDING"}}))
+	assert.Equal(t, "SUCCESSFUL", pipelineStatus(&api.Pipeline{State: &api.PipelineState{
+		Name:   "COMPLETED",
+		Result: &api.PipelineResult{Name: "SUCCESSFUL"},
+	}}))
+}
@@ -0,0 +1,82 @@
+package run
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/carlosarraes/bt/pkg/api"
+	"github.com/carlosarraes/bt/pkg/cmd/shared"
+)
+
+// StatusSetCmd publishes a build status on a commit so scripts and external
+// CI systems can report results through bt
+type StatusSetCmd struct {
+	Commit      string `required:"" help:"Commit SHA the status is attached to"`
+	State       string `required:"" help:"Status state (SUCCESSFUL, FAILED, INPROGRESS, STOPPED)"`
+	Key         string `required:"" help:"Unique key of the status; reusing it updates the existing status"`
+	Name        string `help:"Display name of the status"`
+	URL         string `required:"" help:"Link to the build or report behind the status"`
+	Description string `help:"Short description of the result"`
+	Refname     string `help:"Branch or tag the status applies to"`
+	Output      string `short:"o" help:"Output format (table, json, yaml)" enum:"table,json,yaml" default:"table"`
+	NoColor     bool
+	Workspace   string `help:"Bitbucket workspace (defaults to git remote or config)"`
+	Repository  string `help:"Repository name (defaults to git remote)"`
+}
+
+func (cmd *StatusSetCmd) Run(ctx context.Context) error {
+	status := cmd.buildStatus()
+	if err := api.ValidateCommitStatus(status); err != nil {
+		return err
+	}
+
+	runCtx, err := shared.NewCommandContext(ctx, cmd.Output, cmd.NoColor)
+	if err != nil {
+		return err
+	}
+
+	if err := runCtx.ResolveRepository(cmd.Workspace, cmd.Repository); err != nil {
+		return err
+	}
+
+	result, err := runCtx.Client.CommitStatuses.SetBuildStatus(ctx, runCtx.Workspace, runCtx.Repository, cmd.Commit, status)
+	if err != nil {
+		return handlePipelineAPIError(err)
+	}
+
+	if cmd.Output != "table" {
+		return runCtx.Formatter.Format(result)
+	}
+
+	fmt.Printf("✓ Set status %q to %s on commit %s\n", result.Key, result.State, shortSHA(cmd.Commit))
+	if result.URL != "" {
+		fmt.Printf("  URL: %s\n", result.URL)
+	}
+	return nil
+}
+
+// buildStatus assembles the request from the flags. The state is upper-cased,
+// and IN_PROGRESS, the spelling pipelines use, is accepted for INPROGRESS.
+func (cmd *StatusSetCmd) buildStatus() *api.CommitStatus {
+	state := strings.ToUpper(strings.TrimSpace(cmd.State))
+	if state == "IN_PROGRESS" {
+		state = "INPROGRESS"
+	}
+
+	return &api.CommitStatus{
+		Key:         strings.TrimSpace(cmd.Key),
+		State:       state,
+		Name:        cmd.Name,
+		URL:         strings.TrimSpace(cmd.URL),
+		Description: cmd.Description,
+		Refname:     cmd.Refname,
+	}
+}
+
+func shortSHA(sha string) string {
+	if len(sha) > 8 {
+		return sha[:8]
+	}
+	return sha
+}
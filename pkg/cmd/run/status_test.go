@@ -0,0 +1,48 @@
+package run
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStatusSetCmd_buildStatus(t *testing.T) {
+	cmd := &StatusSetCmd{
+		Commit:      "abc123",
+		State:       " in_progress ",
+		Key:         "e2e",
+		Name:        "E2E",
+		URL:         "https://ci.example.com/e2e/7",
+		Description: "running",
+	}
+
+	status := cmd.buildStatus()
+	assert.Equal(t, "INPROGRESS", status.State)
+	assert.Equal(t, "e2e", status.Key)
+	assert.Equal(t, "E2E", status.Name)
+	assert.Equal(t, "https://ci.example.com/e2e/7", status.URL)
+	assert.Equal(t, "running", status.Description)
+
+	cmd.State = "successful"
+	assert.Equal(t, "SUCCESSFUL", cmd.buildStatus().State)
+}
+
+func TestStatusSetCmd_Run_InvalidState(t *testing.T) {
+	cmd := &StatusSetCmd{
+		Commit: "abc123",
+		State:  "PASSED",
+		Key:    "lint",
+		URL:    "https://ci.example.com/lint/1",
+		Output: "table",
+	}
+
+	err := cmd.Run(context.Background())
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), `invalid status state "PASSED"`)
+}
+
+func TestShortSHA(t *testing.T) {
+	assert.Equal(t, "abcdef12", shortSHA("abcdef1234567890"))
+	assert.Equal(t, "abc", shortSHA("abc"))
+}
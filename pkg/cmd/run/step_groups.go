@@ -0,0 +1,118 @@
+package run
+
+import (
+	"github.com/carlosarraes/bt/pkg/api"
+)
+
+// parallelStepGroup is a parallel block of a pipeline with the steps that
+// ran in it. Status sums up the steps: a failure anywhere fails the group,
+// otherwise it is running while any step is.
+type parallelStepGroup struct {
+	Type   string              `json:"type" yaml:"type"`
+	UUID   string              `json:"uuid,omitempty" yaml:"uuid,omitempty"`
+	Name   string              `json:"name,omitempty" yaml:"name,omitempty"`
+	Status string              `json:"status" yaml:"status"`
+	Steps  []*api.PipelineStep `json:"steps" yaml:"steps"`
+}
+
+// stepEntry is one top-level row of run view: a step, or a parallel group
+type stepEntry struct {
+	Step  *api.PipelineStep
+	Group *parallelStepGroup
+}
+
+// groupParallelSteps gathers consecutive steps of the same parallel group
+// under it, keeping the pipeline's order
+func groupParallelSteps(steps []*api.PipelineStep) []stepEntry {
+	var entries []stepEntry
+	for _, step := range steps {
+		key := parallelGroupKey(step)
+		if key == "" {
+			entries = append(entries, stepEntry{Step: step})
+			continue
+		}
+		if last := len(entries) - 1; last >= 0 && entries[last].Group != nil && parallelGroupKey(entries[last].Group.Steps[0]) == key {
+			entries[last].Group.Steps = append(entries[last].Group.Steps, step)
+			continue
+		}
+		entries = append(entries, stepEntry{Group: &parallelStepGroup{
+			Type:  "parallel_group",
+			UUID:  step.ParallelGroup.UUID,
+			Name:  step.ParallelGroup.Name,
+			Steps: []*api.PipelineStep{step},
+		}})
+	}
+
+	for _, entry := range entries {
+		if entry.Group != nil {
+			entry.Group.Status = parallelGroupStatus(entry.Group.Steps)
+		}
+	}
+	return entries
+}
+
+func parallelGroupKey(step *api.PipelineStep) string {
+	if step.ParallelGroup == nil {
+		return ""
+	}
+	if step.ParallelGroup.UUID != "" {
+		return step.ParallelGroup.UUID
+	}
+	return step.ParallelGroup.Name
+}
+
+// hasParallelGroups reports whether any step ran in a parallel block
+func hasParallelGroups(steps []*api.PipelineStep) bool {
+	for _, step := range steps {
+		if parallelGroupKey(step) != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// groupStatusPriority orders the statuses a group can take from its steps;
+// the first one any step has wins
+var groupStatusPriority = []string{"FAILED", "ERROR", "IN_PROGRESS", "RUNNING", "PENDING", "STOPPED", "SUCCESSFUL"}
+
+func parallelGroupStatus(steps []*api.PipelineStep) string {
+	seen := make(map[string]bool)
+	for _, step := range steps {
+		seen[stepResultName(step)] = true
+	}
+	for _, status := range groupStatusPriority {
+		if seen[status] {
+			return status
+		}
+	}
+	if len(steps) > 0 {
+		return stepResultName(steps[0])
+	}
+	return "UNKNOWN"
+}
+
+// parallelGroupLabel is how a group is named in tables
+func parallelGroupLabel(group *parallelStepGroup) string {
+	if group.Name != "" {
+		return "Parallel: " + group.Name
+	}
+	return "Parallel"
+}
+
+// viewSteps is the steps list of run view's JSON and YAML output: the flat
+// list, with the steps of parallel blocks nested under their group
+func viewSteps(steps []*api.PipelineStep) interface{} {
+	if !hasParallelGroups(steps) {
+		return steps
+	}
+
+	nested := make([]interface{}, 0, len(steps))
+	for _, entry := range groupParallelSteps(steps) {
+		if entry.Group != nil {
+			nested = append(nested, entry.Group)
+		} else {
+			nested = append(nested, entry.Step)
+		}
+	}
+	return nested
+}
@@ -0,0 +1,80 @@
+package run
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/carlosarraes/bt/pkg/api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func parallelTestSteps() []*api.PipelineStep {
+	lint := &api.PipelineStepGroup{UUID: "{g-1}", Name: "Checks"}
+	completed := func(result string) *api.PipelineState {
+		return &api.PipelineState{Name: "COMPLETED", Result: &api.PipelineResult{Name: result}}
+	}
+	return []*api.PipelineStep{
+		{Type: "pipeline_step", Name: "Build", State: completed("SUCCESSFUL")},
+		{Type: "pipeline_step", Name: "Lint", State: completed("SUCCESSFUL"), ParallelGroup: lint},
+		{Type: "pipeline_step", Name: "Unit tests", State: completed("FAILED"), ParallelGroup: lint},
+		{Type: "pipeline_step", Name: "Deploy", State: &api.PipelineState{Name: "PENDING"}},
+	}
+}
+
+func TestGroupParallelSteps(t *testing.T) {
+	entries := groupParallelSteps(parallelTestSteps())
+	require.Len(t, entries, 3)
+
+	assert.Equal(t, "Build", entries[0].Step.Name)
+	require.NotNil(t, entries[1].Group)
+	assert.Equal(t, "Checks", entries[1].Group.Name)
+	assert.Equal(t, "FAILED", entries[1].Group.Status)
+	assert.Len(t, entries[1].Group.Steps, 2)
+	assert.Equal(t, "Deploy", entries[2].Step.Name)
+}
+
+func TestParallelGroupStatus(t *testing.T) {
+	step := func(state, result string) *api.PipelineStep {
+		s := &api.PipelineStep{State: &api.PipelineState{Name: state}}
+		if result != "" {
+			s.State.Result = &api.PipelineResult{Name: result}
+		}
+		return s
+	}
+
+	assert.Equal(t, "SUCCESSFUL", parallelGroupStatus([]*api.PipelineStep{step("COMPLETED", "SUCCESSFUL"), step("COMPLETED", "SUCCESSFUL")}))
+	assert.Equal(t, "IN_PROGRESS", parallelGroupStatus([]*api.PipelineStep{step("COMPLETED", "SUCCESSFUL"), step("IN_PROGRESS", "")}))
+	assert.Equal(t, "FAILED", parallelGroupStatus([]*api.PipelineStep{step("IN_PROGRESS", ""), step("COMPLETED", "FAILED")}))
+	assert.Equal(t, "NOT_RUN", parallelGroupStatus([]*api.PipelineStep{step("COMPLETED", "NOT_RUN")}))
+}
+
+func TestViewSteps_NestsParallelGroups(t *testing.T) {
+	data, err := json.Marshal(viewSteps(parallelTestSteps()))
+	require.NoError(t, err)
+
+	var steps []map[string]interface{}
+	require.NoError(t, json.Unmarshal(data, &steps))
+	require.Len(t, steps, 3)
+	assert.Equal(t, "pipeline_step", steps[0]["type"])
+	assert.Equal(t, "parallel_group", steps[1]["type"])
+	assert.Equal(t, "FAILED", steps[1]["status"])
+	assert.Len(t, steps[1]["steps"], 2)
+
+	flat := []*api.PipelineStep{{Name: "Build"}}
+	assert.Equal(t, flat, viewSteps(flat), "pipelines without parallel blocks keep the flat list")
+}
+
+func TestViewCmd_renderPipelineUpdateParallel(t *testing.T) {
+	pipeline := &api.Pipeline{BuildNumber: 7, State: &api.PipelineState{Name: "IN_PROGRESS"}}
+	frame := (&ViewCmd{}).renderPipelineUpdate(pipeline, parallelTestSteps(), time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC))
+
+	lines := strings.Split(strings.TrimRight(frame, "\n"), "\n")
+	require.Len(t, lines, 6)
+	assert.True(t, strings.HasPrefix(lines[2], "  ✗ Parallel: Checks"), lines[2])
+	assert.True(t, strings.HasPrefix(lines[3], "    ? Lint"), lines[3])
+	assert.True(t, strings.HasPrefix(lines[5], "  ⏳ Deploy"), lines[5])
+	assert.Equal(t, strings.Index(lines[1], "COMPLETED"), strings.Index(lines[3], "COMPLETED"), "grouped steps stay aligned")
+}
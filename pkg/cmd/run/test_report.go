@@ -0,0 +1,271 @@
+package run
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/carlosarraes/bt/pkg/api"
+)
+
+// TestReportSchemaVersion is the version of the TestRunReport shape. It
+// changes only when fields are renamed or removed, never when added.
+const TestReportSchemaVersion = 1
+
+// Test case outcomes in a TestRunReport
+const (
+	OutcomePassed  = "passed"
+	OutcomeFailed  = "failed"
+	OutcomeSkipped = "skipped"
+)
+
+// defaultSuiteName groups the test cases that name neither a suite nor a class
+const defaultSuiteName = "default"
+
+// TestRunReport is the output of run view --tests in json and yaml: the test
+// results of a pipeline's steps, normalized for dashboards to ingest
+type TestRunReport struct {
+	SchemaVersion int                `json:"schema_version" yaml:"schema_version"`
+	Pipeline      TestReportPipeline `json:"pipeline" yaml:"pipeline"`
+	Totals        TestTotals         `json:"totals" yaml:"totals"`
+	Steps         []StepTestReport   `json:"steps" yaml:"steps"`
+}
+
+// TestReportPipeline identifies the pipeline a TestRunReport is about, with
+// what a trend over many pipelines is keyed on
+type TestReportPipeline struct {
+	BuildNumber int        `json:"build_number" yaml:"build_number"`
+	UUID        string     `json:"uuid" yaml:"uuid"`
+	Status      string     `json:"status" yaml:"status"`
+	Branch      string     `json:"branch,omitempty" yaml:"branch,omitempty"`
+	Commit      string     `json:"commit,omitempty" yaml:"commit,omitempty"`
+	CreatedOn   *time.Time `json:"created_on,omitempty" yaml:"created_on,omitempty"`
+}
+
+// TestTotals counts test cases by outcome, with their summed duration
+type TestTotals struct {
+	Total           int     `json:"total" yaml:"total"`
+	Passed          int     `json:"passed" yaml:"passed"`
+	Failed          int     `json:"failed" yaml:"failed"`
+	Skipped         int     `json:"skipped" yaml:"skipped"`
+	DurationSeconds float64 `json:"duration_seconds" yaml:"duration_seconds"`
+}
+
+// StepTestReport holds the test results of one step. Totals come from the
+// step's test reports; Suites carry the test cases grouped by suite.
+type StepTestReport struct {
+	Name   string            `json:"name" yaml:"name"`
+	UUID   string            `json:"uuid" yaml:"uuid"`
+	Status string            `json:"status" yaml:"status"`
+	Totals TestTotals        `json:"totals" yaml:"totals"`
+	Suites []TestSuiteReport `json:"suites" yaml:"suites"`
+	// Error is set when the step's test results could not be fetched
+	Error string `json:"error,omitempty" yaml:"error,omitempty"`
+}
+
+// TestSuiteReport is a test suite of a step. Totals count all its test cases,
+// while Cases lists the failed ones, or all of them with --test-output full.
+type TestSuiteReport struct {
+	Name   string           `json:"name" yaml:"name"`
+	Totals TestTotals       `json:"totals" yaml:"totals"`
+	Cases  []TestCaseReport `json:"cases" yaml:"cases"`
+}
+
+// TestCaseReport is a single test case with its normalized outcome
+type TestCaseReport struct {
+	Name            string   `json:"name" yaml:"name"`
+	ClassName       string   `json:"class_name,omitempty" yaml:"class_name,omitempty"`
+	Outcome         string   `json:"outcome" yaml:"outcome"`
+	DurationSeconds float64  `json:"duration_seconds" yaml:"duration_seconds"`
+	Message         string   `json:"message,omitempty" yaml:"message,omitempty"`
+	Stacktrace      string   `json:"stacktrace,omitempty" yaml:"stacktrace,omitempty"`
+	Output          []string `json:"output,omitempty" yaml:"output,omitempty"` // Failure reasons reported for failed cases
+}
+
+// collectTestReport fetches the test results of steps into a TestRunReport.
+// A step whose results can't be fetched carries the error instead.
+func collectTestReport(ctx context.Context, runCtx *RunContext, pipeline *api.Pipeline, steps []*api.PipelineStep, full bool) *TestRunReport {
+	report := newTestRunReport(pipeline)
+
+	for _, step := range steps {
+		stepReport := StepTestReport{
+			Name:   step.Name,
+			UUID:   step.UUID,
+			Status: stepResultName(step),
+			Suites: []TestSuiteReport{},
+		}
+
+		reports, err := runCtx.Client.Pipelines.GetStepTestReports(ctx, runCtx.Workspace, runCtx.Repository, pipeline.UUID, step.UUID)
+		if err != nil {
+			stepReport.Error = err.Error()
+			report.addStep(stepReport)
+			continue
+		}
+		stepReport.Totals = sumTestTotals(reports)
+
+		if len(reports) > 0 {
+			testCases, err := runCtx.Client.Pipelines.GetStepTestCases(ctx, runCtx.Workspace, runCtx.Repository, pipeline.UUID, step.UUID)
+			if err != nil {
+				stepReport.Error = err.Error()
+			} else {
+				output := make(map[string][]string)
+				for _, tc := range testCases {
+					if !isFailedTestCase(tc) {
+						continue
+					}
+					reasons, err := runCtx.Client.Pipelines.GetTestCaseReasons(ctx, runCtx.Workspace, runCtx.Repository, pipeline.UUID, step.UUID, tc.UUID)
+					if err == nil {
+						output[tc.UUID] = reasonLines(reasons)
+					}
+				}
+				stepReport.Suites = groupTestSuites(testCases, output, full)
+			}
+		}
+
+		report.addStep(stepReport)
+	}
+
+	return report
+}
+
+func newTestRunReport(pipeline *api.Pipeline) *TestRunReport {
+	ref := pipelineRef(pipeline)
+	report := &TestRunReport{
+		SchemaVersion: TestReportSchemaVersion,
+		Pipeline: TestReportPipeline{
+			BuildNumber: ref.BuildNumber,
+			UUID:        ref.UUID,
+			Status:      ref.Status,
+			Commit:      ref.Commit,
+			CreatedOn:   pipeline.CreatedOn,
+		},
+		Steps: []StepTestReport{},
+	}
+	if pipeline.Target != nil {
+		report.Pipeline.Branch = pipeline.Target.RefName
+	}
+	return report
+}
+
+func (r *TestRunReport) addStep(step StepTestReport) {
+	r.Steps = append(r.Steps, step)
+	r.Totals.add(step.Totals)
+}
+
+func (t *TestTotals) add(other TestTotals) {
+	t.Total += other.Total
+	t.Passed += other.Passed
+	t.Failed += other.Failed
+	t.Skipped += other.Skipped
+	t.DurationSeconds += other.DurationSeconds
+}
+
+// sumTestTotals adds up the test reports of a step
+func sumTestTotals(reports []*api.TestReport) TestTotals {
+	var totals TestTotals
+	for _, r := range reports {
+		totals.add(TestTotals{
+			Total:           r.Total,
+			Passed:          r.Passed,
+			Failed:          r.Failed,
+			Skipped:         r.Skipped,
+			DurationSeconds: r.Duration,
+		})
+	}
+	return totals
+}
+
+// caseOutcome normalizes the result of a test case to passed, failed or
+// skipped
+func caseOutcome(tc *api.TestCase) string {
+	if isFailedTestCase(tc) {
+		return OutcomeFailed
+	}
+	switch testOutcome(tc) {
+	case "passed":
+		return OutcomePassed
+	case "failed":
+		return OutcomeFailed
+	}
+	return OutcomeSkipped
+}
+
+// groupTestSuites groups test cases by suite, falling back to the class
+// name. Suites and their cases are sorted by name, so that the same results
+// always give the same report. output holds the failure reasons of failed
+// cases by UUID.
+func groupTestSuites(testCases []*api.TestCase, output map[string][]string, full bool) []TestSuiteReport {
+	bySuite := make(map[string]*TestSuiteReport)
+	var names []string
+
+	for _, tc := range testCases {
+		name := tc.TestSuite
+		if name == "" {
+			name = tc.ClassName
+		}
+		if name == "" {
+			name = defaultSuiteName
+		}
+
+		suite, ok := bySuite[name]
+		if !ok {
+			suite = &TestSuiteReport{Name: name, Cases: []TestCaseReport{}}
+			bySuite[name] = suite
+			names = append(names, name)
+		}
+
+		outcome := caseOutcome(tc)
+		totals := TestTotals{Total: 1, DurationSeconds: tc.Duration}
+		switch outcome {
+		case OutcomePassed:
+			totals.Passed = 1
+		case OutcomeFailed:
+			totals.Failed = 1
+		default:
+			totals.Skipped = 1
+		}
+		suite.Totals.add(totals)
+
+		if outcome != OutcomeFailed && !full {
+			continue
+		}
+		suite.Cases = append(suite.Cases, TestCaseReport{
+			Name:            tc.Name,
+			ClassName:       tc.ClassName,
+			Outcome:         outcome,
+			DurationSeconds: tc.Duration,
+			Message:         tc.Message,
+			Stacktrace:      tc.Stacktrace,
+			Output:          output[tc.UUID],
+		})
+	}
+
+	sort.Strings(names)
+	suites := make([]TestSuiteReport, 0, len(names))
+	for _, name := range names {
+		suite := bySuite[name]
+		sort.SliceStable(suite.Cases, func(i, j int) bool {
+			a, b := suite.Cases[i], suite.Cases[j]
+			if a.ClassName != b.ClassName {
+				return a.ClassName < b.ClassName
+			}
+			return a.Name < b.Name
+		})
+		suites = append(suites, *suite)
+	}
+	return suites
+}
+
+// reasonLines flattens the failure reasons of a test case into lines
+func reasonLines(reasons []*api.TestCaseReason) []string {
+	var lines []string
+	for _, reason := range reasons {
+		if reason.Message != "" {
+			lines = append(lines, reason.Message)
+		}
+		if reason.Output != "" {
+			lines = append(lines, reason.Output)
+		}
+	}
+	return lines
+}
@@ -0,0 +1,89 @@
+package run
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/carlosarraes/bt/pkg/api"
+	"github.com/carlosarraes/bt/pkg/api/apitest"
+	"github.com/carlosarraes/bt/pkg/cmd/shared"
+)
+
+var reportTestCases = []*api.TestCase{
+	{UUID: "{t-3}", Name: "TestZ", TestSuite: "auth", Status: "SUCCESSFUL", Duration: 0.5},
+	{UUID: "{t-1}", Name: "TestLogin", TestSuite: "auth", Result: "FAILED", Duration: 1.5, Message: "expected 200"},
+	{UUID: "{t-2}", Name: "TestSkip", ClassName: "api.Client", Result: "SKIPPED"},
+	{UUID: "{t-4}", Name: "TestBare", Result: "PASSED"},
+}
+
+func TestGroupTestSuites(t *testing.T) {
+	output := map[string][]string{"{t-1}": {"got 500"}}
+
+	suites := groupTestSuites(reportTestCases, output, false)
+	require.Len(t, suites, 3)
+	assert.Equal(t, []string{"api.Client", "auth", "default"}, []string{suites[0].Name, suites[1].Name, suites[2].Name})
+
+	auth := suites[1]
+	assert.Equal(t, TestTotals{Total: 2, Passed: 1, Failed: 1, DurationSeconds: 2}, auth.Totals)
+	assert.Equal(t, []TestCaseReport{{
+		Name: "TestLogin", Outcome: OutcomeFailed, DurationSeconds: 1.5, Message: "expected 200", Output: []string{"got 500"},
+	}}, auth.Cases, "only failed cases are listed by default")
+	assert.Equal(t, TestTotals{Total: 1, Skipped: 1}, suites[0].Totals)
+	assert.Empty(t, suites[0].Cases)
+
+	suites = groupTestSuites(reportTestCases, output, true)
+	require.Len(t, suites[1].Cases, 2)
+	assert.Equal(t, "TestLogin", suites[1].Cases[0].Name, "cases are sorted by name")
+	assert.Equal(t, OutcomePassed, suites[1].Cases[1].Outcome)
+	assert.Equal(t, OutcomeSkipped, suites[0].Cases[0].Outcome)
+}
+
+func TestViewCmd_Run_TestsJSON(t *testing.T) {
+	apitest.CommandEnv(t, "ws", "repo")
+	stepPath := "/repositories/ws/repo/pipelines/{p-1}/steps/{s-1}"
+	transport := apitest.NewReplayTransport(
+		apitest.Fixture{Method: "GET", Path: "/repositories/ws/repo/pipelines/{p-1}", Status: 200,
+			Body: []byte(`{"uuid": "{p-1}", "build_number": 5, "created_on": "2026-10-01T10:00:00Z", "state": {"name": "COMPLETED", "result": {"name": "FAILED"}}, "target": {"type": "pipeline_ref_target", "ref_name": "main", "commit": {"hash": "abc123"}}}`)},
+		apitest.Fixture{Method: "GET", Path: "/repositories/ws/repo/pipelines/{p-1}/steps", Status: 200,
+			Body: []byte(`{"values": [{"uuid": "{s-1}", "name": "Test", "state": {"name": "COMPLETED", "result": {"name": "FAILED"}}}, {"uuid": "{s-2}", "name": "Lint", "state": {"name": "COMPLETED", "result": {"name": "SUCCESSFUL"}}}]}`)},
+		apitest.Fixture{Method: "GET", Path: stepPath + "/test_reports", Status: 200,
+			Body: []byte(`{"values": [{"name": "go test", "total": 2, "passed": 1, "failed": 1, "duration": 2.5}]}`)},
+		apitest.Fixture{Method: "GET", Path: stepPath + "/test_reports/test_cases", Status: 200,
+			Body: []byte(`{"values": [{"uuid": "{t-1}", "name": "TestLogin", "test_suite": "auth", "result": "FAILED", "duration": 1.5, "message": "expected 200"}, {"uuid": "{t-2}", "name": "TestLogout", "test_suite": "auth", "result": "PASSED", "duration": 1}]}`)},
+		apitest.Fixture{Method: "GET", Path: stepPath + "/test_reports/test_cases/{t-1}/test_case_reasons", Status: 200,
+			Body: []byte(`{"values": [{"message": "got 500"}]}`)},
+		apitest.Fixture{Method: "GET", Path: "/repositories/ws/repo/pipelines/{p-1}/steps/{s-2}/test_reports", Status: 200,
+			Body: []byte(`{"values": []}`)},
+	)
+	t.Cleanup(shared.SetClientTransport(transport))
+
+	var runErr error
+	out := captureStdout(func() {
+		runErr = (&ViewCmd{PipelineID: "{p-1}", Tests: true, TestOutput: "failed", Output: "json", NoColor: true}).Run(context.Background())
+	})
+	require.NoError(t, runErr)
+
+	var report TestRunReport
+	require.NoError(t, json.Unmarshal([]byte(out), &report), out)
+
+	assert.Equal(t, TestReportSchemaVersion, report.SchemaVersion)
+	assert.Equal(t, 5, report.Pipeline.BuildNumber)
+	assert.Equal(t, "FAILED", report.Pipeline.Status)
+	assert.Equal(t, "main", report.Pipeline.Branch)
+	assert.Equal(t, "abc123", report.Pipeline.Commit)
+	assert.Equal(t, TestTotals{Total: 2, Passed: 1, Failed: 1, DurationSeconds: 2.5}, report.Totals)
+
+	require.Len(t, report.Steps, 2)
+	assert.Equal(t, "FAILED", report.Steps[0].Status)
+	require.Len(t, report.Steps[0].Suites, 1)
+	assert.Equal(t, []TestCaseReport{{
+		Name: "TestLogin", Outcome: OutcomeFailed, DurationSeconds: 1.5, Message: "expected 200", Output: []string{"got 500"},
+	}}, report.Steps[0].Suites[0].Cases)
+	assert.Equal(t, "Lint", report.Steps[1].Name)
+	assert.Empty(t, report.Steps[1].Suites)
+	assert.Empty(t, report.Steps[1].Error)
+}
@@ -0,0 +1,200 @@
+package run
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/carlosarraes/bt/pkg/api"
+	"github.com/carlosarraes/bt/pkg/output"
+)
+
+// timelineWidth is the number of characters used for the Gantt bars
+const timelineWidth = 40
+
+// StepTiming is the normalized timing of one pipeline step. Offsets are
+// relative to the earliest step start so parallel steps line up.
+type StepTiming struct {
+	Name            string     `json:"name" yaml:"name"`
+	Status          string     `json:"status" yaml:"status"`
+	StartedOn       *time.Time `json:"started_on,omitempty" yaml:"started_on,omitempty"`
+	CompletedOn     *time.Time `json:"completed_on,omitempty" yaml:"completed_on,omitempty"`
+	OffsetSeconds   int        `json:"offset_seconds" yaml:"offset_seconds"`
+	DurationSeconds int        `json:"duration_seconds" yaml:"duration_seconds"`
+	Started         bool       `json:"started" yaml:"started"`
+	Critical        bool       `json:"critical" yaml:"critical"`
+}
+
+// PipelineTiming summarizes when a pipeline's steps ran.
+type PipelineTiming struct {
+	BuildNumber  int          `json:"build_number" yaml:"build_number"`
+	StartedOn    *time.Time   `json:"started_on,omitempty" yaml:"started_on,omitempty"`
+	WallSeconds  int          `json:"wall_seconds" yaml:"wall_seconds"`
+	BuildSeconds int          `json:"build_seconds" yaml:"build_seconds"`
+	Steps        []StepTiming `json:"steps" yaml:"steps"`
+}
+
+// computeStepTiming normalizes step timestamps. Running steps are measured up
+// to now; steps that never started keep their reported build seconds but get
+// no position on the timeline.
+func computeStepTiming(steps []*api.PipelineStep, now time.Time) *PipelineTiming {
+	timing := &PipelineTiming{Steps: make([]StepTiming, 0, len(steps))}
+
+	var origin time.Time
+	for _, step := range steps {
+		if step.StartedOn != nil && (origin.IsZero() || step.StartedOn.Before(origin)) {
+			origin = *step.StartedOn
+		}
+	}
+	if !origin.IsZero() {
+		timing.StartedOn = &origin
+	}
+
+	for _, step := range steps {
+		st := StepTiming{
+			Name:            step.Name,
+			Status:          stepResultName(step),
+			StartedOn:       step.StartedOn,
+			CompletedOn:     step.CompletedOn,
+			DurationSeconds: step.BuildSecondsUsed,
+		}
+
+		if step.StartedOn != nil {
+			st.Started = true
+			st.OffsetSeconds = int(step.StartedOn.Sub(origin).Seconds())
+
+			end := now
+			if step.CompletedOn != nil {
+				end = *step.CompletedOn
+			}
+			if d := int(end.Sub(*step.StartedOn).Seconds()); d > 0 {
+				st.DurationSeconds = d
+			}
+
+			if finish := st.OffsetSeconds + st.DurationSeconds; finish > timing.WallSeconds {
+				timing.WallSeconds = finish
+			}
+		}
+
+		timing.BuildSeconds += st.DurationSeconds
+		timing.Steps = append(timing.Steps, st)
+	}
+
+	markCriticalPath(timing.Steps)
+	return timing
+}
+
+// markCriticalPath flags the chain of steps that determined the total wall
+// time: starting from the step that finished last, repeatedly pick the step
+// that finished last before the current one started.
+func markCriticalPath(steps []StepTiming) {
+	current := -1
+	for i, st := range steps {
+		if st.Started && (current < 0 || stepEnd(st) > stepEnd(steps[current])) {
+			current = i
+		}
+	}
+
+	for current >= 0 {
+		steps[current].Critical = true
+		next := -1
+		for i, st := range steps {
+			if !st.Started || st.Critical || stepEnd(st) > steps[current].OffsetSeconds {
+				continue
+			}
+			if next < 0 || stepEnd(st) > stepEnd(steps[next]) {
+				next = i
+			}
+		}
+		current = next
+	}
+}
+
+func stepEnd(st StepTiming) int {
+	return st.OffsetSeconds + st.DurationSeconds
+}
+
+func stepResultName(step *api.PipelineStep) string {
+	if step.State == nil {
+		return "UNKNOWN"
+	}
+	if step.State.Result != nil && step.State.Result.Name != "" {
+		return step.State.Result.Name
+	}
+	return step.State.Name
+}
+
+// timelineBar renders a step as a fixed-width bar scaled to the wall time.
+func timelineBar(st StepTiming, wallSeconds, width int) string {
+	if !st.Started || wallSeconds <= 0 {
+		return strings.Repeat(" ", width)
+	}
+
+	start := st.OffsetSeconds * width / wallSeconds
+	length := st.DurationSeconds * width / wallSeconds
+	if length < 1 {
+		length = 1
+	}
+	if start >= width {
+		start = width - 1
+	}
+	if start+length > width {
+		length = width - start
+	}
+
+	fill := "▒"
+	if st.Critical {
+		fill = "█"
+	}
+	return strings.Repeat(" ", start) + strings.Repeat(fill, length) + strings.Repeat(" ", width-start-length)
+}
+
+// formatStepTiming prints the step timing table with a Gantt-style timeline
+func (cmd *ViewCmd) formatStepTiming(runCtx *RunContext, pipeline *api.Pipeline, timing *PipelineTiming) error {
+	if cmd.Output != "table" {
+		return runCtx.Formatter.Format(timing)
+	}
+
+	fmt.Printf("Step timing for pipeline #%d (wall %s, build %s)\n\n",
+		pipeline.BuildNumber, output.FormatDuration(timing.WallSeconds), output.FormatDuration(timing.BuildSeconds))
+
+	if len(timing.Steps) == 0 {
+		fmt.Println("No steps found")
+		return nil
+	}
+
+	nameWidth := len("STEP")
+	for _, st := range timing.Steps {
+		if len(st.Name) > nameWidth {
+			nameWidth = len(st.Name)
+		}
+	}
+
+	fmt.Printf("  %-*s  %-8s  %-8s  %s\n", nameWidth, "STEP", "START", "DURATION", "TIMELINE")
+
+	ordered := make([]StepTiming, len(timing.Steps))
+	copy(ordered, timing.Steps)
+	sort.SliceStable(ordered, func(i, j int) bool {
+		if ordered[i].Started != ordered[j].Started {
+			return ordered[i].Started
+		}
+		return ordered[i].OffsetSeconds < ordered[j].OffsetSeconds
+	})
+
+	for _, st := range ordered {
+		marker := " "
+		if st.Critical {
+			marker = "*"
+		}
+		start := "-"
+		if st.Started {
+			start = "+" + output.FormatDuration(st.OffsetSeconds)
+		}
+		fmt.Printf("%s %-*s  %-8s  %-8s  |%s|\n", marker, nameWidth, st.Name, start,
+			output.FormatDuration(st.DurationSeconds), timelineBar(st, timing.WallSeconds, timelineWidth))
+	}
+
+	fmt.Println("\n* critical path (█); steps off the critical path are shown as ▒")
+	return nil
+}
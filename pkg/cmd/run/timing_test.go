@@ -0,0 +1,86 @@
+package run
+
+import (
+	"testing"
+	"time"
+
+	"github.com/carlosarraes/bt/pkg/api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func timedStep(name string, base time.Time, startSec, endSec int) *api.PipelineStep {
+	start := base.Add(time.Duration(startSec) * time.Second)
+	end := base.Add(time.Duration(endSec) * time.Second)
+	return &api.PipelineStep{
+		Name:        name,
+		StartedOn:   &start,
+		CompletedOn: &end,
+		State:       &api.PipelineState{Name: "COMPLETED", Result: &api.PipelineResult{Name: "SUCCESSFUL"}},
+	}
+}
+
+func TestComputeStepTiming(t *testing.T) {
+	base := time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)
+	steps := []*api.PipelineStep{
+		timedStep("build", base, 0, 120),
+		timedStep("lint", base, 0, 30),
+		timedStep("unit", base, 120, 300),
+		timedStep("integration", base, 120, 200),
+		{Name: "deploy", BuildSecondsUsed: 0, State: &api.PipelineState{Name: "PENDING"}},
+	}
+
+	timing := computeStepTiming(steps, base.Add(time.Hour))
+
+	require.Len(t, timing.Steps, 5)
+	assert.Equal(t, 300, timing.WallSeconds)
+	assert.Equal(t, 120+30+180+80, timing.BuildSeconds)
+	assert.Equal(t, base, *timing.StartedOn)
+
+	byName := map[string]StepTiming{}
+	for _, st := range timing.Steps {
+		byName[st.Name] = st
+	}
+
+	assert.Equal(t, 120, byName["unit"].OffsetSeconds)
+	assert.Equal(t, 180, byName["unit"].DurationSeconds)
+	assert.Equal(t, "SUCCESSFUL", byName["unit"].Status)
+	assert.False(t, byName["deploy"].Started)
+
+	assert.True(t, byName["build"].Critical)
+	assert.True(t, byName["unit"].Critical)
+	assert.False(t, byName["lint"].Critical)
+	assert.False(t, byName["integration"].Critical)
+	assert.False(t, byName["deploy"].Critical)
+}
+
+func TestComputeStepTiming_RunningStep(t *testing.T) {
+	base := time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)
+	start := base
+	steps := []*api.PipelineStep{{Name: "build", StartedOn: &start, State: &api.PipelineState{Name: "IN_PROGRESS"}}}
+
+	timing := computeStepTiming(steps, base.Add(90*time.Second))
+
+	assert.Equal(t, 90, timing.Steps[0].DurationSeconds)
+	assert.Equal(t, 90, timing.WallSeconds)
+	assert.Equal(t, "IN_PROGRESS", timing.Steps[0].Status)
+}
+
+func TestTimelineBar(t *testing.T) {
+	tests := []struct {
+		name string
+		st   StepTiming
+		want string
+	}{
+		{"first half critical", StepTiming{Started: true, OffsetSeconds: 0, DurationSeconds: 50, Critical: true}, "█████     "},
+		{"second half", StepTiming{Started: true, OffsetSeconds: 50, DurationSeconds: 50}, "     ▒▒▒▒▒"},
+		{"tiny step gets one cell", StepTiming{Started: true, OffsetSeconds: 0, DurationSeconds: 1}, "▒         "},
+		{"not started", StepTiming{}, "          "},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, timelineBar(tt.st, 100, 10))
+		})
+	}
+}
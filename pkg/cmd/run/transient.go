@@ -0,0 +1,83 @@
+package run
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"os"
+
+	"github.com/carlosarraes/bt/pkg/api"
+)
+
+// defaultMaxPollFailures is how many consecutive transient poll failures
+// --retry-on-transient tolerates before giving up
+const defaultMaxPollFailures = 5
+
+// pollTolerance decides whether a watch or follow loop survives a failed
+// poll. When disabled every error aborts the loop; when enabled transient
+// errors are logged and skipped until max of them happen in a row.
+type pollTolerance struct {
+	enabled     bool
+	max         int
+	consecutive int
+	out         io.Writer
+}
+
+func newPollTolerance(enabled bool, max int) *pollTolerance {
+	if max <= 0 {
+		max = defaultMaxPollFailures
+	}
+	return &pollTolerance{enabled: enabled, max: max, out: os.Stderr}
+}
+
+// tolerate reports whether the loop should skip this failed poll and carry
+// on. It is false for non-transient errors, when tolerance is disabled, and
+// once max transient failures have happened in a row.
+func (p *pollTolerance) tolerate(err error) bool {
+	if !p.enabled || !isTransientError(err) {
+		return false
+	}
+
+	p.consecutive++
+	if p.consecutive >= p.max {
+		fmt.Fprintf(p.out, "⚠️  Giving up after %d consecutive failed polls\n", p.consecutive)
+		return false
+	}
+
+	fmt.Fprintf(p.out, "⚠️  Transient error (%d/%d), retrying: %v\n", p.consecutive, p.max, err)
+	return true
+}
+
+// reset clears the failure streak after a successful poll
+func (p *pollTolerance) reset() {
+	p.consecutive = 0
+}
+
+// isTransientError reports whether retrying the same request later could
+// succeed: network failures, whether wrapped by the API client or raw from
+// net/http (connection resets, timeouts, DNS failures), rate limiting,
+// server errors and request timeouts. Cancellation by the user is never
+// transient.
+func isTransientError(err error) bool {
+	if errors.Is(err, context.Canceled) {
+		return false
+	}
+	if errors.Is(err, context.DeadlineExceeded) {
+		return true
+	}
+
+	var bbErr *api.BitbucketError
+	if errors.As(err, &bbErr) {
+		return bbErr.IsRetryable()
+	}
+
+	var urlErr *url.Error
+	if errors.As(err, &urlErr) {
+		return true
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr)
+}
@@ -0,0 +1,79 @@
+package run
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"syscall"
+	"testing"
+
+	"github.com/carlosarraes/bt/pkg/api"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsTransientError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"network", api.NewNetworkError("connection reset", nil), true},
+		{"server", &api.BitbucketError{Type: api.ErrorTypeServer, StatusCode: 502}, true},
+		{"rate limit", &api.BitbucketError{Type: api.ErrorTypeRateLimit, StatusCode: 429}, true},
+		{"wrapped network", fmt.Errorf("poll: %w", api.NewNetworkError("timeout", nil)), true},
+		{"deadline", context.DeadlineExceeded, true},
+		{"raw connection reset", &url.Error{Op: "Get", URL: "https://api.bitbucket.org", Err: syscall.ECONNRESET}, true},
+		{"raw dns failure", fmt.Errorf("poll: %w", &net.DNSError{Err: "no such host", Name: "api.bitbucket.org", IsTemporary: true}), true},
+		{"raw timeout", &net.OpError{Op: "read", Net: "tcp", Err: syscall.ETIMEDOUT}, true},
+		{"raw canceled", &url.Error{Op: "Get", URL: "https://api.bitbucket.org", Err: context.Canceled}, false},
+		{"not found", &api.BitbucketError{Type: api.ErrorTypeNotFound, StatusCode: 404}, false},
+		{"auth", &api.BitbucketError{Type: api.ErrorTypeAuthentication, StatusCode: 401}, false},
+		{"canceled", context.Canceled, false},
+		{"plain", errors.New("boom"), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, isTransientError(tt.err))
+		})
+	}
+}
+
+func TestPollTolerance(t *testing.T) {
+	transient := api.NewNetworkError("connection reset", nil)
+
+	t.Run("disabled", func(t *testing.T) {
+		polls := newPollTolerance(false, 3)
+		polls.out = io.Discard
+		assert.False(t, polls.tolerate(transient))
+	})
+
+	t.Run("gives up after max consecutive failures", func(t *testing.T) {
+		polls := newPollTolerance(true, 3)
+		polls.out = io.Discard
+		assert.True(t, polls.tolerate(transient))
+		assert.True(t, polls.tolerate(transient))
+		assert.False(t, polls.tolerate(transient))
+	})
+
+	t.Run("success resets the streak", func(t *testing.T) {
+		polls := newPollTolerance(true, 2)
+		polls.out = io.Discard
+		assert.True(t, polls.tolerate(transient))
+		polls.reset()
+		assert.True(t, polls.tolerate(transient))
+	})
+
+	t.Run("non-transient errors abort", func(t *testing.T) {
+		polls := newPollTolerance(true, 3)
+		polls.out = io.Discard
+		assert.False(t, polls.tolerate(&api.BitbucketError{Type: api.ErrorTypeNotFound, StatusCode: 404}))
+	})
+
+	t.Run("non-positive max uses the default", func(t *testing.T) {
+		assert.Equal(t, defaultMaxPollFailures, newPollTolerance(true, 0).max)
+	})
+}
@@ -4,33 +4,61 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"os"
 	"strings"
 	"time"
 
 	"github.com/carlosarraes/bt/pkg/api"
 	"github.com/carlosarraes/bt/pkg/cmd/shared"
 	"github.com/carlosarraes/bt/pkg/output"
+	"github.com/carlosarraes/bt/pkg/utils"
 )
 
 // ViewCmd handles the run view command
 type ViewCmd struct {
-	PipelineID string `arg:"" help:"Pipeline ID (build number or UUID)"`
-	Output     string `short:"o" help:"Output format (table, json, yaml)" enum:"table,json,yaml" default:"table"`
-	NoColor    bool   // NoColor is passed from global flag
-	Watch      bool   `short:"w" help:"Watch for live updates (running pipelines only)"`
-	Log        bool   `help:"View full logs for all steps"`
-	LogFailed  bool   `name:"log-failed" help:"View logs only for failed steps (last 100 lines)"`
-	FullOutput bool   `name:"full-output" help:"Show complete logs (use with --log-failed for full failure logs)"`
-	Tests      bool   `short:"t" help:"Show test results and failures"`
-	Step       string `help:"View specific step only"`
-	Web        bool   `help:"Open pipeline in browser"`
-	URL        bool   `help:"Print pipeline URL instead of opening in browser (use with --web)"`
-	Workspace  string `help:"Bitbucket workspace (defaults to git remote or config)"`
-	Repository string `help:"Repository name (defaults to git remote)"`
+	PipelineID       string `arg:"" optional:"" help:"Pipeline ID (build number or UUID); omit to pick from recent pipelines"`
+	Output           string `short:"o" help:"Output format (table, json, yaml)" enum:"table,json,yaml" default:"table"`
+	NoColor          bool   // NoColor is passed from global flag
+	Watch            bool   `short:"w" help:"Watch for live updates (running pipelines only)"`
+	Append           bool   `help:"With --watch, print each update below the last instead of redrawing in place"`
+	Log              bool   `help:"View full logs for all steps"`
+	LogFailed        bool   `name:"log-failed" help:"View logs only for failed steps (last 100 lines)"`
+	FullOutput       bool   `name:"full-output" help:"Show complete logs (use with --log-failed for full failure logs)"`
+	IncludeLogs      bool   `name:"include-logs" help:"Embed step log text in json/yaml output (steps carry metadata only without it)"`
+	Tests            bool   `short:"t" help:"Show test results and failures"`
+	TestOutput       string `name:"test-output" help:"With --tests, which test cases to list: failed, or full to include passing tests" enum:"failed,full" default:"failed"`
+	History          bool   `help:"With --tests, flag flaky and consistently failing tests across recent pipelines of the same branch"`
+	HistoryLimit     int    `name:"history-limit" help:"Number of pipelines to inspect with --history" default:"10"`
+	Step             string `help:"View specific step only"`
+	StepTiming       bool   `name:"step-timing" help:"Show step start/end times with a timeline of parallel steps and the critical path"`
+	RetryOnTransient bool   `name:"retry-on-transient" help:"With --watch, keep polling through transient API errors"`
+	MaxPollFailures  int    `name:"max-poll-failures" help:"Consecutive transient failures tolerated by --retry-on-transient" default:"5"`
+	NoRedact         bool   `name:"no-redact" help:"With --log or --log-failed, show secrets in logs instead of masking them"`
+	Web              bool   `help:"Open pipeline in browser"`
+	URL              bool   `help:"Print pipeline URL instead of opening in browser (use with --web)"`
+	PR               bool   `name:"pr" help:"Show the pull request the pipeline ran for; with --web open it in the browser"`
+	Status           string `help:"Without a pipeline ID, only offer pipelines with this status (PENDING, IN_PROGRESS, SUCCESSFUL, FAILED, ERROR, STOPPED)"`
+	Branch           string `help:"Without a pipeline ID, only offer pipelines on this branch"`
+	Workspace        string `help:"Bitbucket workspace (defaults to git remote or config)"`
+	Repository       string `help:"Repository name (defaults to git remote)"`
 }
 
 // Run executes the run view command
 func (cmd *ViewCmd) Run(ctx context.Context) error {
+	selection := pipelineSelection{Status: cmd.Status, Branch: cmd.Branch}
+	if err := selection.validate(cmd.PipelineID); err != nil {
+		return err
+	}
+	if cmd.IncludeLogs && cmd.Output == "table" {
+		return fmt.Errorf("--include-logs requires --output json or yaml")
+	}
+	if cmd.TestOutput == "full" && !cmd.Tests {
+		return fmt.Errorf("--test-output full requires --tests")
+	}
+	if err := cmd.validatePR(); err != nil {
+		return err
+	}
+
 	// Create run context with authentication and configuration
 	runCtx, err := shared.NewCommandContext(ctx, cmd.Output, cmd.NoColor)
 	if err != nil {
@@ -38,21 +66,20 @@ func (cmd *ViewCmd) Run(ctx context.Context) error {
 	}
 
 	// Override workspace and repository if provided via flags
-	if cmd.Workspace != "" {
-		runCtx.Workspace = cmd.Workspace
-	}
-	if cmd.Repository != "" {
-		runCtx.Repository = cmd.Repository
-	}
+	runCtx.ApplyRepoFlags(cmd.Workspace, cmd.Repository)
 
 	// Validate workspace and repository are available
 	if err := runCtx.ValidateWorkspaceAndRepo(); err != nil {
 		return err
 	}
 
-	// Validate pipeline ID
+	// Pick a recent pipeline when the ID was omitted
 	if strings.TrimSpace(cmd.PipelineID) == "" {
-		return fmt.Errorf("pipeline ID is required")
+		pipelineID, err := selectPipeline(ctx, runCtx, cmd.Output, selection)
+		if err != nil {
+			return err
+		}
+		cmd.PipelineID = pipelineID
 	}
 
 	// Convert pipeline ID to UUID if it's a build number
@@ -66,12 +93,27 @@ func (cmd *ViewCmd) Run(ctx context.Context) error {
 		return cmd.watchPipeline(ctx, runCtx, pipelineUUID)
 	}
 
+	if cmd.PR {
+		return cmd.viewPullRequest(ctx, runCtx, pipelineUUID)
+	}
+
 	// Handle different view modes based on flags
 	if cmd.Web {
 		return cmd.openInBrowser(ctx, runCtx, pipelineUUID)
 	}
 
-	if cmd.Log || cmd.LogFailed || cmd.Tests || cmd.Step != "" {
+	if cmd.History {
+		if !cmd.Tests {
+			return fmt.Errorf("--history requires --tests")
+		}
+		return cmd.viewTestHistory(ctx, runCtx, pipelineUUID)
+	}
+
+	if cmd.StepTiming {
+		return cmd.viewStepTiming(ctx, runCtx, pipelineUUID)
+	}
+
+	if cmd.Log || cmd.LogFailed || cmd.Tests || cmd.Step != "" || cmd.IncludeLogs {
 		return cmd.viewLogs(ctx, runCtx, pipelineUUID)
 	}
 
@@ -97,6 +139,23 @@ func (cmd *ViewCmd) viewPipeline(ctx context.Context, runCtx *RunContext, pipeli
 	return cmd.formatOutput(runCtx, pipeline, steps)
 }
 
+// viewStepTiming displays when each step ran relative to the others
+func (cmd *ViewCmd) viewStepTiming(ctx context.Context, runCtx *RunContext, pipelineUUID string) error {
+	pipeline, err := runCtx.Client.Pipelines.GetPipeline(ctx, runCtx.Workspace, runCtx.Repository, pipelineUUID)
+	if err != nil {
+		return handlePipelineAPIError(err)
+	}
+
+	steps, err := runCtx.Client.Pipelines.GetPipelineSteps(ctx, runCtx.Workspace, runCtx.Repository, pipelineUUID)
+	if err != nil {
+		return handlePipelineAPIError(err)
+	}
+
+	timing := computeStepTiming(steps, time.Now())
+	timing.BuildNumber = pipeline.BuildNumber
+	return cmd.formatStepTiming(runCtx, pipeline, timing)
+}
+
 // watchPipeline monitors a running pipeline for live updates
 func (cmd *ViewCmd) watchPipeline(ctx context.Context, runCtx *RunContext, pipelineUUID string) error {
 	// First, check if pipeline is running
@@ -119,8 +178,12 @@ func (cmd *ViewCmd) watchPipeline(ctx context.Context, runCtx *RunContext, pipel
 	ticker := time.NewTicker(5 * time.Second)
 	defer ticker.Stop()
 
+	polls := newPollTolerance(cmd.RetryOnTransient, cmd.MaxPollFailures)
+
+	live := cmd.newWatchRegion()
+
 	// Show initial state
-	if err := cmd.displayPipelineUpdate(ctx, runCtx, pipelineUUID); err != nil {
+	if err := cmd.displayPipelineUpdate(ctx, runCtx, pipelineUUID, live); err != nil {
 		return err
 	}
 
@@ -132,13 +195,22 @@ func (cmd *ViewCmd) watchPipeline(ctx context.Context, runCtx *RunContext, pipel
 			// Get updated pipeline status
 			updatedPipeline, err := runCtx.Client.Pipelines.GetPipeline(ctx, runCtx.Workspace, runCtx.Repository, pipelineUUID)
 			if err != nil {
+				if polls.tolerate(err) {
+					live.Release()
+					continue
+				}
 				return handlePipelineAPIError(err)
 			}
 
 			// Display update
-			if err := cmd.displayPipelineUpdate(ctx, runCtx, pipelineUUID); err != nil {
+			if err := cmd.displayPipelineUpdate(ctx, runCtx, pipelineUUID, live); err != nil {
+				if polls.tolerate(err) {
+					live.Release()
+					continue
+				}
 				return err
 			}
+			polls.reset()
 
 			// Check if pipeline completed
 			if updatedPipeline.State != nil &&
@@ -151,8 +223,15 @@ func (cmd *ViewCmd) watchPipeline(ctx context.Context, runCtx *RunContext, pipel
 	}
 }
 
+// newWatchRegion returns where watch updates are drawn: redrawn in place on a
+// terminal, appended with --append or when stdout isn't one
+func (cmd *ViewCmd) newWatchRegion() *output.LiveRegion {
+	inPlace := !cmd.Append && utils.IsTerminal(os.Stdout)
+	return output.NewLiveRegion(os.Stdout, inPlace, utils.TerminalWidth(os.Stdout))
+}
+
 // displayPipelineUpdate shows a compact update during watch mode
-func (cmd *ViewCmd) displayPipelineUpdate(ctx context.Context, runCtx *RunContext, pipelineUUID string) error {
+func (cmd *ViewCmd) displayPipelineUpdate(ctx context.Context, runCtx *RunContext, pipelineUUID string, live *output.LiveRegion) error {
 	// Get current pipeline state
 	pipeline, err := runCtx.Client.Pipelines.GetPipeline(ctx, runCtx.Workspace, runCtx.Repository, pipelineUUID)
 	if err != nil {
@@ -165,6 +244,14 @@ func (cmd *ViewCmd) displayPipelineUpdate(ctx context.Context, runCtx *RunContex
 		return err
 	}
 
+	return live.Update(cmd.renderPipelineUpdate(pipeline, steps, time.Now()))
+}
+
+// renderPipelineUpdate is the compact pipeline and step status shown by watch
+// mode, stamped with now
+func (cmd *ViewCmd) renderPipelineUpdate(pipeline *api.Pipeline, steps []*api.PipelineStep, now time.Time) string {
+	var b strings.Builder
+
 	// Display compact status
 	status := "UNKNOWN"
 	if pipeline.State != nil {
@@ -182,16 +269,17 @@ func (cmd *ViewCmd) displayPipelineUpdate(ctx context.Context, runCtx *RunContex
 		duration = output.FormatDuration(pipeline.BuildSecondsUsed)
 	}
 
-	fmt.Printf("[%s] Pipeline #%d: %s",
-		time.Now().Format("15:04:05"), pipeline.BuildNumber, status)
+	fmt.Fprintf(&b, "[%s] Pipeline #%d: %s",
+		now.Format("15:04:05"), pipeline.BuildNumber, status)
 
 	if duration != "" {
-		fmt.Printf(" (%s)", duration)
+		fmt.Fprintf(&b, " (%s)", duration)
 	}
-	fmt.Println()
+	b.WriteString("\n")
 
-	// Show step progress
-	for _, step := range steps {
+	// Show step progress, with parallel steps under their group
+	nameWidth := stepNameWidth(steps)
+	writeStep := func(indent string, width int, step *api.PipelineStep) {
 		stepStatus := "UNKNOWN"
 		if step.State != nil {
 			stepStatus = step.State.Name
@@ -203,16 +291,26 @@ func (cmd *ViewCmd) displayPipelineUpdate(ctx context.Context, runCtx *RunContex
 		}
 
 		statusIcon := cmd.getStatusIcon(stepStatus)
-		fmt.Printf("  %s %-15s %s", statusIcon, step.Name, stepStatus)
+		fmt.Fprintf(&b, "%s%s %-*s %s", indent, statusIcon, width, output.Truncate(step.Name, width), stepStatus)
 
 		if stepDuration != "" {
-			fmt.Printf(" (%s)", stepDuration)
+			fmt.Fprintf(&b, " (%s)", stepDuration)
+		}
+		b.WriteString("\n")
+	}
+	for _, entry := range groupParallelSteps(steps) {
+		if entry.Group == nil {
+			writeStep("  ", nameWidth, entry.Step)
+			continue
+		}
+		label := parallelGroupLabel(entry.Group)
+		fmt.Fprintf(&b, "  %s %-*s %s\n", cmd.getStatusIcon(entry.Group.Status), nameWidth, output.Truncate(label, nameWidth), entry.Group.Status)
+		for _, step := range entry.Group.Steps {
+			writeStep("    ", nameWidth-parallelStepIndent, step)
 		}
-		fmt.Println()
 	}
 
-	fmt.Println("---")
-	return nil
+	return b.String()
 }
 
 // formatOutput formats and displays the pipeline and step information
@@ -264,7 +362,8 @@ func (cmd *ViewCmd) formatTable(runCtx *RunContext, pipeline *api.Pipeline, step
 	// Commit information
 	if pipeline.Target != nil && pipeline.Target.Commit != nil {
 		commit := pipeline.Target.Commit
-		commitMsg := shared.Truncate(commit.Message, 50)
+		// Leaves room for "Commit:      <hash> (" and ")"
+		commitMsg := output.FitWidth(strings.TrimSpace(strings.SplitN(commit.Message, "\n", 2)[0]), 24)
 		fmt.Printf("Commit:      %s (%s)\n", commit.Hash[:8], commitMsg)
 	}
 
@@ -301,37 +400,51 @@ func (cmd *ViewCmd) formatTable(runCtx *RunContext, pipeline *api.Pipeline, step
 	// Steps section
 	if len(steps) > 0 {
 		fmt.Println("\nSteps:")
-		for _, step := range steps {
-			stepStatus := "UNKNOWN"
-			if step.State != nil {
-				stepStatus = step.State.Name
+		nameWidth := stepNameWidth(steps)
+		for _, entry := range groupParallelSteps(steps) {
+			if entry.Group == nil {
+				cmd.printStepRow("  ", nameWidth, entry.Step)
+				continue
 			}
-
-			stepDuration := ""
-			if step.BuildSecondsUsed > 0 {
-				stepDuration = output.FormatDuration(step.BuildSecondsUsed)
+			label := parallelGroupLabel(entry.Group)
+			fmt.Printf("  %s %-*s   %s\n", cmd.getStatusIcon(entry.Group.Status), nameWidth, output.Truncate(label, nameWidth), entry.Group.Status)
+			for _, step := range entry.Group.Steps {
+				cmd.printStepRow("    ", nameWidth-parallelStepIndent, step)
 			}
+		}
+	}
+
+	return nil
+}
 
-			statusIcon := cmd.getStatusIcon(stepStatus)
+// printStepRow prints a step of the Steps section, its name padded to width
+func (cmd *ViewCmd) printStepRow(indent string, width int, step *api.PipelineStep) {
+	stepStatus := "UNKNOWN"
+	if step.State != nil {
+		stepStatus = step.State.Name
+	}
 
-			fmt.Printf("  %s %-15s", statusIcon, step.Name)
+	stepDuration := ""
+	if step.BuildSecondsUsed > 0 {
+		stepDuration = output.FormatDuration(step.BuildSecondsUsed)
+	}
 
-			if stepDuration != "" {
-				fmt.Printf(" %8s", stepDuration)
-			}
+	statusIcon := cmd.getStatusIcon(stepStatus)
 
-			fmt.Printf("   %s\n", stepStatus)
-		}
+	fmt.Printf("%s%s %-*s", indent, statusIcon, width, output.Truncate(step.Name, width))
+
+	if stepDuration != "" {
+		fmt.Printf(" %8s", stepDuration)
 	}
 
-	return nil
+	fmt.Printf("   %s\n", stepStatus)
 }
 
 // formatJSON formats the pipeline and steps as JSON
 func (cmd *ViewCmd) formatJSON(runCtx *RunContext, pipeline *api.Pipeline, steps []*api.PipelineStep) error {
 	output := map[string]interface{}{
 		"pipeline": pipeline,
-		"steps":    steps,
+		"steps":    viewSteps(steps),
 	}
 
 	return runCtx.Formatter.Format(output)
@@ -341,12 +454,43 @@ func (cmd *ViewCmd) formatJSON(runCtx *RunContext, pipeline *api.Pipeline, steps
 func (cmd *ViewCmd) formatYAML(runCtx *RunContext, pipeline *api.Pipeline, steps []*api.PipelineStep) error {
 	output := map[string]interface{}{
 		"pipeline": pipeline,
-		"steps":    steps,
+		"steps":    viewSteps(steps),
 	}
 
 	return runCtx.Formatter.Format(output)
 }
 
+// stepStatusWidth is room kept after a step name for its status and duration
+const stepStatusWidth = 30
+
+// parallelStepIndent is how much further in than other steps the steps of a
+// parallel group are shown
+const parallelStepIndent = 2
+
+// stepNameWidth aligns step names on the longest one, narrowing the column
+// when the terminal is too small for the statuses to follow. Parallel group
+// labels count too, as do the indented names of their steps.
+func stepNameWidth(steps []*api.PipelineStep) int {
+	width := 15
+	for _, entry := range groupParallelSteps(steps) {
+		if entry.Group == nil {
+			width = max(width, len(entry.Step.Name))
+			continue
+		}
+		width = max(width, len(parallelGroupLabel(entry.Group)))
+		for _, step := range entry.Group.Steps {
+			width = max(width, len(step.Name)+parallelStepIndent)
+		}
+	}
+	if available := output.AvailableWidth(); available > 0 && width > available-stepStatusWidth {
+		width = available - stepStatusWidth
+		if width < 15 {
+			width = 15
+		}
+	}
+	return width
+}
+
 // getStatusIcon returns an appropriate icon for the step status
 func (cmd *ViewCmd) getStatusIcon(status string) string {
 	switch status {
@@ -373,8 +517,7 @@ func (cmd *ViewCmd) openInBrowser(ctx context.Context, runCtx *RunContext, pipel
 		return handlePipelineAPIError(err)
 	}
 
-	url := fmt.Sprintf("https://bitbucket.org/%s/%s/addon/pipelines/home#!/results/%d",
-		runCtx.Workspace, runCtx.Repository, pipeline.BuildNumber)
+	url := pipelineWebURL(runCtx, pipeline)
 
 	if cmd.URL {
 		fmt.Println(url)
@@ -384,6 +527,26 @@ func (cmd *ViewCmd) openInBrowser(ctx context.Context, runCtx *RunContext, pipel
 	return shared.LaunchBrowser(url)
 }
 
+// stepLog is a step of run view --log. Table output prints lines; json and
+// yaml carry them joined in Logs, with --include-logs only.
+type stepLog struct {
+	Step      *api.PipelineStep `json:"step" yaml:"step"`
+	Logs      string            `json:"logs,omitempty" yaml:"logs,omitempty"`
+	Error     string            `json:"error,omitempty" yaml:"error,omitempty"`
+	Truncated bool              `json:"truncated,omitempty" yaml:"truncated,omitempty"`
+
+	lines []string
+}
+
+func newStepLog(step *api.PipelineStep, lines []string, truncated bool) stepLog {
+	return stepLog{
+		Step:      step,
+		Logs:      strings.Join(lines, "\n"),
+		Truncated: truncated,
+		lines:     lines,
+	}
+}
+
 // viewLogs displays logs and test results for pipeline steps
 func (cmd *ViewCmd) viewLogs(ctx context.Context, runCtx *RunContext, pipelineUUID string) error {
 	// Get pipeline details
@@ -401,9 +564,10 @@ func (cmd *ViewCmd) viewLogs(ctx context.Context, runCtx *RunContext, pipelineUU
 	// Filter steps if specific step requested
 	filteredSteps := steps
 	if cmd.Step != "" {
-		filteredSteps = filterStepsByName(steps, cmd.Step)
+		aliases := stepAliases(runCtx)
+		filteredSteps = filterStepsByName(steps, cmd.Step, aliases)
 		if len(filteredSteps) == 0 {
-			return fmt.Errorf("step '%s' not found. Available steps: %s", cmd.Step, getAvailableStepNames(steps))
+			return stepNotFoundError(cmd.Step, aliases, steps)
 		}
 	}
 
@@ -448,24 +612,34 @@ func (cmd *ViewCmd) viewLogs(ctx context.Context, runCtx *RunContext, pipelineUU
 		}
 	}
 
-	type stepLog struct {
-		Step      *api.PipelineStep `json:"step"`
-		Lines     []string          `json:"lines,omitempty"`
-		Error     string            `json:"error,omitempty"`
-		Truncated bool              `json:"truncated,omitempty"`
+	// The redactor lists the repository variables, so it is only built when
+	// logs are shown
+	var redactor *utils.Redactor
+	if !cmd.Tests && (isTable || cmd.IncludeLogs) {
+		if redactor, err = newLogRedactor(ctx, runCtx, cmd.NoRedact); err != nil {
+			return err
+		}
 	}
+
 	var stepLogs []stepLog
 
 	for _, step := range filteredSteps {
 		if cmd.Tests {
 			if isTable {
 				displayStepInfo(step)
-				displayTestResults(ctx, runCtx, pipeline, step)
+				displayTestResults(ctx, runCtx, pipeline, step, cmd.TestOutput == "full")
 			}
 			stepLogs = append(stepLogs, stepLog{Step: step})
 			continue
 		}
 
+		// Structured output embeds logs only on request, keeping the
+		// payload to step metadata by default
+		if !isTable && !cmd.IncludeLogs {
+			stepLogs = append(stepLogs, stepLog{Step: step})
+			continue
+		}
+
 		if isTable {
 			fmt.Printf("Attempting to fetch logs for step: %s (UUID: %s)\n", step.Name, step.UUID)
 			fmt.Printf("Pipeline UUID: %s\n", pipeline.UUID)
@@ -477,7 +651,7 @@ func (cmd *ViewCmd) viewLogs(ctx context.Context, runCtx *RunContext, pipelineUU
 			if isTable {
 				displayStepInfo(step)
 				fmt.Printf("Logs not available: %v\n", err)
-				displayTestResults(ctx, runCtx, pipeline, step)
+				displayTestResults(ctx, runCtx, pipeline, step, cmd.TestOutput == "full")
 			}
 			stepLogs = append(stepLogs, stepLog{Step: step, Error: err.Error()})
 			continue
@@ -490,32 +664,21 @@ func (cmd *ViewCmd) viewLogs(ctx context.Context, runCtx *RunContext, pipelineUU
 			continue
 		}
 
-		logLines := strings.Split(string(logContent), "\n")
+		logLines := redactor.RedactLines(strings.Split(string(logContent), "\n"))
 		truncated := false
 
 		if cmd.LogFailed && !cmd.FullOutput {
-			startLine := len(logLines) - 100
-			if startLine < 0 {
-				startLine = 0
-			}
-			truncated = startLine > 0
-			logLines = logLines[startLine:]
+			logLines, truncated = tailLines(logLines, logTailLines)
+		} else if isTable && len(logContent) > largeLogThreshold && canConfirmLargeLog() &&
+			!confirmLargeLog(os.Stdin, os.Stderr, step.Name, len(logContent), len(logLines)) {
+			logLines, truncated = tailLines(logLines, logTailLines)
 		}
 
-		if isTable {
-			if truncated {
-				fmt.Printf("Showing last %d lines (use --full-output for complete logs)\n", len(logLines))
-			} else {
-				fmt.Printf("Showing all %d lines\n", len(logLines))
-			}
-			fmt.Println(strings.Repeat("=", 80))
-			for _, line := range logLines {
-				fmt.Println(line)
-			}
-			fmt.Println(strings.Repeat("=", 80))
-		}
+		stepLogs = append(stepLogs, newStepLog(step, logLines, truncated))
+	}
 
-		stepLogs = append(stepLogs, stepLog{Step: step, Lines: logLines, Truncated: truncated})
+	if !isTable && cmd.Tests {
+		return runCtx.Formatter.Format(collectTestReport(ctx, runCtx, pipeline, filteredSteps, cmd.TestOutput == "full"))
 	}
 
 	if !isTable {
@@ -526,5 +689,33 @@ func (cmd *ViewCmd) viewLogs(ctx context.Context, runCtx *RunContext, pipelineUU
 		return runCtx.Formatter.Format(output)
 	}
 
+	// Logs are printed once all are fetched, so any large-log prompt is
+	// answered before the pager takes over the terminal
+	paged := false
+	for _, log := range stepLogs {
+		if log.lines == nil {
+			continue
+		}
+		if !paged {
+			defer shared.StartPager(ctx)()
+			paged = true
+		}
+
+		fmt.Printf("\nLogs for step: %s\n", log.Step.Name)
+		switch {
+		case log.Truncated && !cmd.FullOutput:
+			fmt.Printf("Showing last %d lines (use --full-output for complete logs)\n", len(log.lines))
+		case log.Truncated:
+			fmt.Printf("Showing last %d lines\n", len(log.lines))
+		default:
+			fmt.Printf("Showing all %d lines\n", len(log.lines))
+		}
+		fmt.Println(strings.Repeat("=", 80))
+		if err := writeLogLines(os.Stdout, log.lines); err != nil {
+			return err
+		}
+		fmt.Println(strings.Repeat("=", 80))
+	}
+
 	return nil
 }
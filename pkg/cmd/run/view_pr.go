@@ -0,0 +1,88 @@
+package run
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/carlosarraes/bt/pkg/api"
+	"github.com/carlosarraes/bt/pkg/cmd/pr"
+	"github.com/carlosarraes/bt/pkg/cmd/shared"
+)
+
+// pullRequestTarget is the target type of pipelines run for a pull request
+const pullRequestTarget = "pipeline_pullrequest_target"
+
+// validatePR rejects the view modes --pr can't be combined with
+func (cmd *ViewCmd) validatePR() error {
+	if !cmd.PR {
+		return nil
+	}
+	if cmd.Watch || cmd.Log || cmd.LogFailed || cmd.Tests || cmd.Step != "" || cmd.StepTiming || cmd.IncludeLogs {
+		return fmt.Errorf("--pr cannot be combined with --watch, --log, --log-failed, --tests, --step, --step-timing or --include-logs")
+	}
+	return nil
+}
+
+// pipelinePullRequestID returns the ID of the pull request a pipeline ran
+// for, or an error when its target is a branch, tag or commit
+func pipelinePullRequestID(pipeline *api.Pipeline) (int, error) {
+	target := pipeline.Target
+	if target == nil || target.Type != pullRequestTarget {
+		return 0, fmt.Errorf("pipeline #%d was not run for a pull request (target: %s)", pipeline.BuildNumber, describeTarget(target))
+	}
+	if target.PullRequest != nil && target.PullRequest.ID > 0 {
+		return target.PullRequest.ID, nil
+	}
+	if target.PullRequestId != nil && *target.PullRequestId > 0 {
+		return *target.PullRequestId, nil
+	}
+	return 0, fmt.Errorf("pipeline #%d was run for a pull request, but Bitbucket didn't say which", pipeline.BuildNumber)
+}
+
+// describeTarget names a pipeline target for error messages
+func describeTarget(target *api.PipelineTarget) string {
+	switch {
+	case target == nil:
+		return "unknown"
+	case target.RefName != "" && target.RefType != "":
+		return target.RefType + " " + target.RefName
+	case target.RefName != "":
+		return target.RefName
+	case target.Commit != nil && len(target.Commit.Hash) >= 7:
+		return "commit " + target.Commit.Hash[:7]
+	}
+	return target.Type
+}
+
+// viewPullRequest shows the pull request a pipeline ran for with pr view,
+// or with --web opens it in the browser (--url prints the link instead)
+func (cmd *ViewCmd) viewPullRequest(ctx context.Context, runCtx *RunContext, pipelineUUID string) error {
+	pipeline, err := runCtx.Client.Pipelines.GetPipeline(ctx, runCtx.Workspace, runCtx.Repository, pipelineUUID)
+	if err != nil {
+		return handlePipelineAPIError(err)
+	}
+
+	prID, err := pipelinePullRequestID(pipeline)
+	if err != nil {
+		return err
+	}
+
+	if cmd.Web {
+		url := fmt.Sprintf("https://bitbucket.org/%s/%s/pull-requests/%d", runCtx.Workspace, runCtx.Repository, prID)
+		if cmd.URL {
+			fmt.Println(url)
+			return nil
+		}
+		return shared.LaunchBrowser(url)
+	}
+
+	view := &pr.ViewCmd{
+		PRID:       strconv.Itoa(prID),
+		Output:     cmd.Output,
+		NoColor:    cmd.NoColor,
+		Workspace:  runCtx.Workspace,
+		Repository: runCtx.Repository,
+	}
+	return view.Run(ctx)
+}
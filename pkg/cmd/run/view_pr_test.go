@@ -0,0 +1,67 @@
+package run
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/carlosarraes/bt/pkg/api"
+	"github.com/carlosarraes/bt/pkg/api/apitest"
+	"github.com/carlosarraes/bt/pkg/cmd/shared"
+)
+
+func TestPipelinePullRequestID(t *testing.T) {
+	legacyID := 8
+	tests := []struct {
+		name    string
+		target  *api.PipelineTarget
+		want    int
+		wantErr string
+	}{
+		{name: "pullrequest", target: &api.PipelineTarget{Type: pullRequestTarget, PullRequest: &api.PipelinePullRequest{ID: 7}}, want: 7},
+		{name: "pull_request_id", target: &api.PipelineTarget{Type: pullRequestTarget, PullRequestId: &legacyID}, want: 8},
+		{name: "branch", target: &api.PipelineTarget{Type: "pipeline_ref_target", RefType: "branch", RefName: "main"}, wantErr: "pipeline #42 was not run for a pull request (target: branch main)"},
+		{name: "commit", target: &api.PipelineTarget{Type: "pipeline_commit_target", Commit: &api.Commit{Hash: "abc1234def"}}, wantErr: "pipeline #42 was not run for a pull request (target: commit abc1234)"},
+		{name: "no target", wantErr: "pipeline #42 was not run for a pull request (target: unknown)"},
+		{name: "no ID", target: &api.PipelineTarget{Type: pullRequestTarget}, wantErr: "pipeline #42 was run for a pull request, but Bitbucket didn't say which"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			id, err := pipelinePullRequestID(&api.Pipeline{BuildNumber: 42, Target: tt.target})
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, id)
+		})
+	}
+}
+
+func TestViewCmd_validatePR(t *testing.T) {
+	assert.NoError(t, (&ViewCmd{PR: true, Web: true, URL: true}).validatePR())
+	assert.Error(t, (&ViewCmd{PR: true, Watch: true}).validatePR())
+	assert.Error(t, (&ViewCmd{PR: true, Tests: true}).validatePR())
+}
+
+func TestViewCmd_Run_PullRequestURL(t *testing.T) {
+	apitest.CommandEnv(t, "ws", "repo")
+	transport := apitest.NewReplayTransport(apitest.Fixture{
+		Method: "GET",
+		Path:   "/repositories/ws/repo/pipelines/{p-1}",
+		Status: 200,
+		Body:   []byte(`{"uuid": "{p-1}", "build_number": 42, "target": {"type": "pipeline_pullrequest_target", "pullrequest": {"id": 7, "title": "Fix login"}}}`),
+	})
+	t.Cleanup(shared.SetClientTransport(transport))
+
+	var runErr error
+	out := captureStdout(func() {
+		cmd := &ViewCmd{PipelineID: "{p-1}", Output: "table", NoColor: true, PR: true, Web: true, URL: true}
+		runErr = cmd.Run(context.Background())
+	})
+	require.NoError(t, runErr)
+	assert.Equal(t, "https://bitbucket.org/ws/repo/pull-requests/7\n", out)
+}
@@ -1,14 +1,21 @@
 package run
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"strconv"
 	"strings"
 	"testing"
 	"time"
 
 	"github.com/carlosarraes/bt/pkg/api"
+	"github.com/carlosarraes/bt/pkg/api/apitest"
+	"github.com/carlosarraes/bt/pkg/cmd/shared"
+	"github.com/carlosarraes/bt/pkg/output"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
 )
 
 func TestViewCmd_ValidatePipelineID(t *testing.T) {
@@ -324,6 +331,23 @@ func TestViewCmd_WatchFunctionality(t *testing.T) {
 	}
 }
 
+func TestViewCmd_renderPipelineUpdate(t *testing.T) {
+	pipeline := &api.Pipeline{BuildNumber: 42, State: &api.PipelineState{Name: "IN_PROGRESS"}, BuildSecondsUsed: 90}
+	steps := []*api.PipelineStep{
+		{Name: "Build", State: &api.PipelineState{Name: "COMPLETED"}, BuildSecondsUsed: 60},
+		{Name: "Test", State: &api.PipelineState{Name: "IN_PROGRESS"}},
+	}
+	now := time.Date(2026, 1, 2, 15, 4, 5, 0, time.UTC)
+
+	frame := (&ViewCmd{}).renderPipelineUpdate(pipeline, steps, now)
+	lines := strings.Split(strings.TrimSuffix(frame, "\n"), "\n")
+	require.Len(t, lines, 3)
+	assert.True(t, strings.HasPrefix(lines[0], "[15:04:05] Pipeline #42: IN_PROGRESS ("), lines[0])
+	assert.Equal(t, []string{"Build", "COMPLETED", "(1m"}, strings.Fields(lines[1])[1:4])
+	assert.Equal(t, []string{"Test", "IN_PROGRESS"}, strings.Fields(lines[2])[1:])
+	assert.NotContains(t, frame, "---", "separators belong to append mode")
+}
+
 // Integration test helpers (these would require a real API client)
 func TestViewCmd_Integration(t *testing.T) {
 	// Skip integration tests if not in integration test mode
@@ -379,3 +403,117 @@ func BenchmarkResolvePipelineUUID(b *testing.B) {
 		resolvePipelineUUID(context.Background(), nil, uuids[i%len(uuids)])
 	}
 }
+
+// renderAs runs render against a context whose formatter writes format to a buffer
+func renderAs(t *testing.T, format string, render func(*RunContext) error) []byte {
+	t.Helper()
+	buf := &bytes.Buffer{}
+	formatter, err := output.NewFormatter(output.Format(format), &output.FormatterOptions{Writer: buf})
+	require.NoError(t, err)
+	require.NoError(t, render(&RunContext{Formatter: formatter}))
+	return buf.Bytes()
+}
+
+// assertYAMLMatchesJSON checks that the YAML output decodes to the same
+// document as the JSON output
+func assertYAMLMatchesJSON(t *testing.T, render func(*RunContext) error) {
+	t.Helper()
+
+	var fromJSON, fromYAML interface{}
+	require.NoError(t, json.Unmarshal(renderAs(t, "json", render), &fromJSON))
+	require.NoError(t, yaml.Unmarshal(renderAs(t, "yaml", render), &fromYAML))
+
+	// Compare through JSON so YAML ints and JSON float64s line up
+	wantJSON, err := json.Marshal(fromJSON)
+	require.NoError(t, err)
+	gotJSON, err := json.Marshal(fromYAML)
+	require.NoError(t, err)
+	assert.JSONEq(t, string(wantJSON), string(gotJSON))
+}
+
+func TestViewCmd_formatYAML_RoundTrip(t *testing.T) {
+	created := time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC)
+	completed := created.Add(5 * time.Minute)
+	pipeline := &api.Pipeline{
+		UUID:        "{pipeline-uuid}",
+		BuildNumber: 42,
+		State:       &api.PipelineState{Name: "COMPLETED", Result: &api.PipelineResult{Name: "FAILED"}},
+		Target:      &api.PipelineTarget{RefName: "main"},
+		Creator:     &api.User{DisplayName: "yes"},
+		CreatedOn:   &created,
+		CompletedOn: &completed,
+	}
+	steps := []*api.PipelineStep{
+		{UUID: "{step-uuid}", Name: "Build: 123", StartedOn: &created, CompletedOn: &completed},
+		{UUID: "{pending}", Name: "Deploy"},
+	}
+
+	cmd := &ViewCmd{}
+	assertYAMLMatchesJSON(t, func(runCtx *RunContext) error {
+		return cmd.formatYAML(runCtx, pipeline, steps)
+	})
+
+	out := string(renderAs(t, "yaml", func(runCtx *RunContext) error {
+		return cmd.formatYAML(runCtx, pipeline, steps)
+	}))
+	assert.Contains(t, out, "build_number: 42")
+	assert.Contains(t, out, `created_on: "2024-03-01T10:00:00Z"`)
+	assert.Contains(t, out, `display_name: "yes"`)
+	assert.NotContains(t, out, "buildnumber")
+}
+
+func TestViewCmd_IncludeLogs(t *testing.T) {
+	setup := func(t *testing.T) *apitest.ReplayTransport {
+		apitest.CommandEnv(t, "ws", "repo")
+		transport := apitest.NewReplayTransport(
+			apitest.Fixture{Method: "GET", Path: "/repositories/ws/repo/pipelines/{p-1}", Status: 200,
+				Body: []byte(`{"uuid": "{p-1}", "build_number": 5, "state": {"name": "COMPLETED", "result": {"name": "FAILED"}}}`)},
+			apitest.Fixture{Method: "GET", Path: "/repositories/ws/repo/pipelines/{p-1}/steps", Status: 200,
+				Body: []byte(`{"values": [{"uuid": "{s-1}", "name": "Test", "state": {"name": "COMPLETED", "result": {"name": "FAILED"}}}]}`)},
+			apitest.Fixture{Method: "GET", Path: "/repositories/ws/repo/pipelines/{p-1}/steps/{s-1}/log", Status: 200,
+				RawBody: "go test ./...\nFAIL: TestLogin\n"},
+		)
+		t.Cleanup(shared.SetClientTransport(transport))
+		return transport
+	}
+
+	run := func(t *testing.T, cmd *ViewCmd) map[string]interface{} {
+		var runErr error
+		out := captureStdout(func() {
+			runErr = cmd.Run(context.Background())
+		})
+		require.NoError(t, runErr)
+
+		var result map[string]interface{}
+		require.NoError(t, json.Unmarshal([]byte(out), &result))
+		return result
+	}
+
+	t.Run("metadata only by default", func(t *testing.T) {
+		transport := setup(t)
+		result := run(t, &ViewCmd{PipelineID: "{p-1}", LogFailed: true, Output: "json", NoColor: true})
+
+		steps := result["steps"].([]interface{})
+		require.Len(t, steps, 1)
+		assert.NotContains(t, steps[0], "logs")
+		for _, req := range transport.Requests() {
+			assert.NotContains(t, req.Path, "/log", "logs aren't fetched without --include-logs")
+		}
+	})
+
+	t.Run("embedded with --include-logs", func(t *testing.T) {
+		setup(t)
+		result := run(t, &ViewCmd{PipelineID: "{p-1}", LogFailed: true, IncludeLogs: true, Output: "json", NoColor: true})
+
+		steps := result["steps"].([]interface{})
+		require.Len(t, steps, 1)
+		step := steps[0].(map[string]interface{})
+		assert.Equal(t, "go test ./...\nFAIL: TestLogin\n", step["logs"])
+		assert.Equal(t, "Test", step["step"].(map[string]interface{})["name"])
+	})
+
+	t.Run("table output rejects --include-logs", func(t *testing.T) {
+		err := (&ViewCmd{PipelineID: "5", IncludeLogs: true, Output: "table"}).Run(context.Background())
+		assert.ErrorContains(t, err, "--include-logs requires --output json or yaml")
+	})
+}
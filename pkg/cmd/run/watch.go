@@ -13,18 +13,26 @@ import (
 	"github.com/carlosarraes/bt/pkg/api"
 	"github.com/carlosarraes/bt/pkg/cmd/shared"
 	"github.com/carlosarraes/bt/pkg/output"
+	"github.com/carlosarraes/bt/pkg/utils"
 	"github.com/charmbracelet/lipgloss"
 )
 
 // WatchCmd handles the run watch command for real-time pipeline monitoring
 type WatchCmd struct {
 	PipelineID string `arg:"" help:"Pipeline ID (build number or UUID)"`
-	Output     string `short:"o" help:"Output format (table, json)" enum:"table,json" default:"table"`
+	Output     string `short:"o" help:"Output format (table, json, yaml)" enum:"table,json,yaml" default:"table"`
 	NoColor    bool   // NoColor is passed from global flag
 	Workspace  string `help:"Bitbucket workspace (defaults to git remote or config)"`
 	Repository string `help:"Repository name (defaults to git remote)"`
 
+	RetryOnTransient bool   `name:"retry-on-transient" help:"Keep polling through transient API errors"`
+	MaxPollFailures  int    `name:"max-poll-failures" help:"Consecutive transient failures tolerated by --retry-on-transient" default:"5"`
+	Events           string `help:"Print JSON progress events (jsonl) instead of text"`
+	NoRedact         bool   `name:"no-redact" help:"Show secrets in logs instead of masking them"`
+
 	logBuffer *LogBuffer
+	events    *output.EventWriter
+	redactor  *utils.Redactor
 }
 
 type LogBuffer struct {
@@ -63,6 +71,12 @@ func (lb *LogBuffer) Reset() {
 
 // Run executes the run watch command
 func (cmd *WatchCmd) Run(ctx context.Context) error {
+	events, err := newEventWriter(cmd.Events)
+	if err != nil {
+		return err
+	}
+	cmd.events = events
+
 	// Create run context with authentication and configuration
 	runCtx, err := shared.NewCommandContext(ctx, cmd.Output, cmd.NoColor)
 	if err != nil {
@@ -70,12 +84,7 @@ func (cmd *WatchCmd) Run(ctx context.Context) error {
 	}
 
 	// Override workspace and repository if provided via flags
-	if cmd.Workspace != "" {
-		runCtx.Workspace = cmd.Workspace
-	}
-	if cmd.Repository != "" {
-		runCtx.Repository = cmd.Repository
-	}
+	runCtx.ApplyRepoFlags(cmd.Workspace, cmd.Repository)
 
 	// Validate workspace and repository are available
 	if err := runCtx.ValidateWorkspaceAndRepo(); err != nil {
@@ -89,6 +98,11 @@ func (cmd *WatchCmd) Run(ctx context.Context) error {
 	}
 
 	// Start watching the pipeline
+	cmd.redactor, err = newLogRedactor(ctx, runCtx, cmd.NoRedact)
+	if err != nil {
+		return err
+	}
+
 	return cmd.watchPipeline(ctx, runCtx, pipelineUUID)
 }
 
@@ -103,7 +117,11 @@ func (cmd *WatchCmd) watchPipeline(ctx context.Context, runCtx *RunContext, pipe
 	signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM)
 	go func() {
 		<-sigChan
-		fmt.Println("\n🛑 Watch interrupted by user")
+		if cmd.events != nil {
+			cmd.events.Emit(output.Event{Event: output.EventInterrupted})
+		} else {
+			fmt.Println("\n🛑 Watch interrupted by user")
+		}
 		cancel()
 	}()
 
@@ -115,24 +133,37 @@ func (cmd *WatchCmd) watchPipeline(ctx context.Context, runCtx *RunContext, pipe
 
 	// Check if pipeline is in a state that can be watched
 	if pipeline.State == nil || (pipeline.State.Name != "IN_PROGRESS" && pipeline.State.Name != "PENDING") {
+		if cmd.events != nil {
+			return cmd.events.Emit(output.Event{Event: output.EventPipelineCompleted, Pipeline: pipeline.BuildNumber, Status: pipelineStatus(pipeline)})
+		}
+
 		fmt.Printf("Pipeline #%d is %s - watching is only available for running pipelines\n",
 			pipeline.BuildNumber, pipeline.State.Name)
 
 		// Show current state and exit for completed pipelines
-		if cmd.Output == "json" {
-			return cmd.formatJSONOutput(runCtx, pipeline)
+		if cmd.Output != "table" {
+			return cmd.formatStructuredOutput(runCtx, pipeline)
 		} else {
 			return cmd.displayFinalStatus(pipeline)
 		}
 	}
 
-	fmt.Printf("🔍 Watching pipeline #%d (Ctrl+C to exit)...\n", pipeline.BuildNumber)
+	if cmd.events != nil {
+		if err := cmd.events.Emit(output.Event{Event: output.EventWatchStarted, Pipeline: pipeline.BuildNumber, Status: pipelineStatus(pipeline)}); err != nil {
+			return err
+		}
+	} else {
+		fmt.Printf("🔍 Watching pipeline #%d (Ctrl+C to exit)...\n", pipeline.BuildNumber)
+	}
 
 	cmd.logBuffer = NewLogBuffer()
+	stepEvents := newStepEvents(pipeline.BuildNumber)
 
 	updateTicker := time.NewTicker(2 * time.Second)
 	defer updateTicker.Stop()
 
+	polls := newPollTolerance(cmd.RetryOnTransient, cmd.MaxPollFailures)
+
 	var currentStepUUID string
 	var currentStepName string
 	var displayedInitialStatus bool
@@ -145,11 +176,22 @@ func (cmd *WatchCmd) watchPipeline(ctx context.Context, runCtx *RunContext, pipe
 		case <-updateTicker.C:
 			updatedPipeline, err := runCtx.Client.Pipelines.GetPipeline(watchCtx, runCtx.Workspace, runCtx.Repository, pipelineUUID)
 			if err != nil {
+				if polls.tolerate(err) {
+					continue
+				}
 				return handlePipelineAPIError(err)
 			}
 
 			steps, err := runCtx.Client.Pipelines.GetPipelineSteps(watchCtx, runCtx.Workspace, runCtx.Repository, pipelineUUID)
 			if err != nil {
+				if polls.tolerate(err) {
+					continue
+				}
+				return err
+			}
+			polls.reset()
+
+			if err := emitEvents(cmd.events, stepEvents.update(steps)); err != nil {
 				return err
 			}
 
@@ -177,7 +219,13 @@ func (cmd *WatchCmd) watchPipeline(ctx context.Context, runCtx *RunContext, pipe
 				newStepName = activeStep.Name
 			}
 
-			if newStepUUID != currentStepUUID {
+			if cmd.events != nil {
+				if newStepUUID != currentStepUUID {
+					currentStepUUID = newStepUUID
+					currentStepName = newStepName
+					cmd.logBuffer.Reset()
+				}
+			} else if newStepUUID != currentStepUUID {
 				if currentStepUUID != "" && currentStepName != "" && newStepUUID != "" {
 					fmt.Printf("✅ Step completed: %s\n", currentStepName)
 				}
@@ -208,12 +256,18 @@ func (cmd *WatchCmd) watchPipeline(ctx context.Context, runCtx *RunContext, pipe
 				allLogs, err := cmd.getAllLogs(watchCtx, runCtx, pipelineUUID, currentStepUUID)
 				if err == nil {
 					newLines := cmd.logBuffer.GetNewLines(allLogs)
-					dimStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("8"))
-					if cmd.NoColor {
-						dimStyle = lipgloss.NewStyle()
-					}
-					for _, line := range newLines {
-						fmt.Printf("   %s\n", dimStyle.Render(line))
+					if cmd.events != nil {
+						if err := cmd.emitLogLines(updatedPipeline.BuildNumber, currentStepName, newLines); err != nil {
+							return err
+						}
+					} else {
+						dimStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("8"))
+						if cmd.NoColor {
+							dimStyle = lipgloss.NewStyle()
+						}
+						for _, line := range newLines {
+							fmt.Printf("   %s\n", dimStyle.Render(line))
+						}
 					}
 				}
 			}
@@ -223,11 +277,15 @@ func (cmd *WatchCmd) watchPipeline(ctx context.Context, runCtx *RunContext, pipe
 				updatedPipeline.State.Name != "IN_PROGRESS" &&
 				updatedPipeline.State.Name != "PENDING" {
 
+				if cmd.events != nil {
+					return cmd.events.Emit(output.Event{Event: output.EventPipelineCompleted, Pipeline: updatedPipeline.BuildNumber, Status: pipelineStatus(updatedPipeline)})
+				}
+
 				fmt.Printf("🏁 Pipeline #%d completed with status: %s\n",
 					updatedPipeline.BuildNumber, updatedPipeline.State.Name)
 
-				if cmd.Output == "json" {
-					return cmd.formatJSONOutput(runCtx, updatedPipeline)
+				if cmd.Output != "table" {
+					return cmd.formatStructuredOutput(runCtx, updatedPipeline)
 				}
 				return nil
 			}
@@ -235,6 +293,16 @@ func (cmd *WatchCmd) watchPipeline(ctx context.Context, runCtx *RunContext, pipe
 	}
 }
 
+// emitLogLines sends new log lines of the running step as log_line events
+func (cmd *WatchCmd) emitLogLines(pipeline int, step string, lines []string) error {
+	for _, line := range lines {
+		if err := cmd.events.Emit(output.Event{Event: output.EventLogLine, Pipeline: pipeline, Step: step, Line: line}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (cmd *WatchCmd) getAllLogs(ctx context.Context, runCtx *RunContext, pipelineUUID, stepUUID string) ([]string, error) {
 	logReader, err := runCtx.Client.Pipelines.GetStepLogs(ctx, runCtx.Workspace, runCtx.Repository, pipelineUUID, stepUUID)
 	if err != nil {
@@ -255,7 +323,7 @@ func (cmd *WatchCmd) getAllLogs(ctx context.Context, runCtx *RunContext, pipelin
 		return nil, err
 	}
 
-	return lines, nil
+	return cmd.redactor.RedactLines(lines), nil
 }
 
 // getStatusIcon returns an icon for the given status
@@ -307,16 +375,19 @@ func (cmd *WatchCmd) displayFinalStatus(pipeline *api.Pipeline) error {
 	return nil
 }
 
-// formatJSONOutput formats the pipeline status as JSON
-func (cmd *WatchCmd) formatJSONOutput(runCtx *RunContext, pipeline *api.Pipeline) error {
-	// Use the same JSON formatting as the view command for consistency
+// formatStructuredOutput formats the pipeline status as JSON or YAML
+func (cmd *WatchCmd) formatStructuredOutput(runCtx *RunContext, pipeline *api.Pipeline) error {
+	// Use the same formatting as the view command for consistency
 	steps, err := runCtx.Client.Pipelines.GetPipelineSteps(context.Background(),
 		runCtx.Workspace, runCtx.Repository, pipeline.UUID)
 	if err != nil {
 		return err
 	}
 
-	// Create ViewCmd temporarily to reuse JSON formatting
-	viewCmd := &ViewCmd{Output: "json"}
+	// Create ViewCmd temporarily to reuse its formatting
+	viewCmd := &ViewCmd{Output: cmd.Output}
+	if cmd.Output == "yaml" {
+		return viewCmd.formatYAML(runCtx, pipeline, steps)
+	}
 	return viewCmd.formatJSON(runCtx, pipeline, steps)
 }
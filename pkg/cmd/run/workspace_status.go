@@ -0,0 +1,264 @@
+package run
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/carlosarraes/bt/pkg/api"
+	"github.com/carlosarraes/bt/pkg/cmd/shared"
+	"github.com/carlosarraes/bt/pkg/output"
+)
+
+// workspaceStatusConcurrency bounds the repositories fetched at once, and
+// workspaceStatusRate the API requests started per second across them
+const (
+	workspaceStatusConcurrency = 8
+	workspaceStatusRate        = 10
+)
+
+// statusNone is the status of a repository whose branch has no pipelines
+const statusNone = "NONE"
+
+// WorkspaceStatusCmd shows the latest pipeline on the default branch of
+// every repository in a workspace, or of the repositories in Repos
+type WorkspaceStatusCmd struct {
+	Repos     []string
+	Branch    string
+	Failed    bool
+	Output    string
+	NoColor   bool
+	Workspace string
+}
+
+// RepoPipelineStatus is the latest pipeline of one repository's branch
+type RepoPipelineStatus struct {
+	Repository  string     `json:"repository" yaml:"repository"`
+	Branch      string     `json:"branch" yaml:"branch"`
+	Status      string     `json:"status" yaml:"status"`
+	BuildNumber int        `json:"build_number,omitempty" yaml:"build_number,omitempty"`
+	CreatedOn   *time.Time `json:"created_on,omitempty" yaml:"created_on,omitempty"`
+	Error       string     `json:"error,omitempty" yaml:"error,omitempty"`
+}
+
+// failing reports whether the repository's branch is red
+func (s *RepoPipelineStatus) failing() bool {
+	return s.Status == "FAILED" || s.Status == "ERROR"
+}
+
+// Run executes the run status command
+func (cmd *WorkspaceStatusCmd) Run(ctx context.Context) error {
+	// The workspace is all that's needed, so this also works outside a clone
+	runCtx, err := shared.NewCommandContext(ctx, cmd.Output, cmd.NoColor)
+	if err != nil {
+		runCtx, err = shared.NewMinimalContext(ctx, shared.MinimalContextOptions{
+			OutputFormat: cmd.Output,
+			Workspace:    cmd.Workspace,
+			NoColor:      cmd.NoColor,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	runCtx.ApplyRepoFlags(cmd.Workspace, "")
+	if runCtx.Workspace == "" {
+		return fmt.Errorf("workspace is required. Provide it via --workspace flag or configure it")
+	}
+
+	repos, err := cmd.repositories(ctx, runCtx)
+	if err != nil {
+		return err
+	}
+	if len(repos) == 0 {
+		return fmt.Errorf("no repositories found in workspace %s", runCtx.Workspace)
+	}
+
+	statuses := cmd.fetchStatuses(ctx, runCtx, repos)
+	if cmd.Failed {
+		// Repositories that couldn't be checked may be red too
+		failing := statuses[:0]
+		for _, status := range statuses {
+			if status.failing() || status.Error != "" {
+				failing = append(failing, status)
+			}
+		}
+		statuses = failing
+	}
+
+	switch cmd.Output {
+	case "table":
+		return cmd.formatTable(runCtx.Workspace, len(repos), statuses)
+	case "json", "yaml":
+		return runCtx.Formatter.Format(statuses)
+	default:
+		return fmt.Errorf("unsupported output format: %s", cmd.Output)
+	}
+}
+
+// repositories returns the repositories named by --repos, or every
+// repository of the workspace
+func (cmd *WorkspaceStatusCmd) repositories(ctx context.Context, runCtx *RunContext) ([]*api.Repository, error) {
+	if len(cmd.Repos) > 0 {
+		repos := make([]*api.Repository, 0, len(cmd.Repos))
+		for _, name := range cmd.Repos {
+			if name = strings.TrimSpace(name); name != "" {
+				repos = append(repos, &api.Repository{Name: name, FullName: runCtx.Workspace + "/" + name})
+			}
+		}
+		return repos, nil
+	}
+
+	repos, err := runCtx.Client.Repositories.ListAllRepositories(ctx, runCtx.Workspace, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list repositories of workspace %s: %w", runCtx.Workspace, err)
+	}
+	return repos, nil
+}
+
+// fetchStatuses looks up the latest pipeline of every repository, at most
+// workspaceStatusConcurrency at a time and workspaceStatusRate requests a
+// second. The statuses are sorted by repository name.
+func (cmd *WorkspaceStatusCmd) fetchStatuses(ctx context.Context, runCtx *RunContext, repos []*api.Repository) []*RepoPipelineStatus {
+	limiter := newRateLimiter(workspaceStatusRate)
+	defer limiter.Stop()
+
+	statuses := make([]*RepoPipelineStatus, len(repos))
+	var wg sync.WaitGroup
+	semaphore := make(chan struct{}, workspaceStatusConcurrency)
+
+	for i, repo := range repos {
+		wg.Add(1)
+		go func(i int, repo *api.Repository) {
+			defer wg.Done()
+
+			semaphore <- struct{}{}
+			defer func() { <-semaphore }()
+
+			statuses[i] = cmd.fetchStatus(ctx, runCtx, limiter, repo)
+		}(i, repo)
+	}
+	wg.Wait()
+
+	sort.Slice(statuses, func(i, j int) bool {
+		return strings.ToLower(statuses[i].Repository) < strings.ToLower(statuses[j].Repository)
+	})
+	return statuses
+}
+
+func (cmd *WorkspaceStatusCmd) fetchStatus(ctx context.Context, runCtx *RunContext, limiter *rateLimiter, repo *api.Repository) *RepoPipelineStatus {
+	slug := repositorySlug(repo)
+	status := &RepoPipelineStatus{Repository: slug, Branch: cmd.Branch}
+
+	if status.Branch == "" && repo.MainBranch != nil {
+		status.Branch = repo.MainBranch.Name
+	}
+	if status.Branch == "" {
+		// Repositories named by --repos come without their main branch
+		if err := limiter.Wait(ctx); err != nil {
+			status.Error = err.Error()
+			return status
+		}
+		details, err := runCtx.Client.Repositories.GetRepository(ctx, runCtx.Workspace, slug)
+		if err != nil {
+			status.Error = err.Error()
+			return status
+		}
+		if details.MainBranch == nil || details.MainBranch.Name == "" {
+			status.Error = "repository has no default branch"
+			return status
+		}
+		status.Branch = details.MainBranch.Name
+	}
+
+	if err := limiter.Wait(ctx); err != nil {
+		status.Error = err.Error()
+		return status
+	}
+	pipelines, err := runCtx.Client.Pipelines.GetPipelinesByBranch(ctx, runCtx.Workspace, slug, status.Branch, 1)
+	if err != nil {
+		status.Error = err.Error()
+		return status
+	}
+	if len(pipelines) == 0 {
+		status.Status = statusNone
+		return status
+	}
+
+	pipeline := pipelines[0]
+	status.Status = pipelineStatus(pipeline)
+	status.BuildNumber = pipeline.BuildNumber
+	status.CreatedOn = pipeline.CreatedOn
+	return status
+}
+
+func (cmd *WorkspaceStatusCmd) formatTable(workspace string, total int, statuses []*RepoPipelineStatus) error {
+	if len(statuses) == 0 {
+		fmt.Printf("No failing pipelines in %s (%d repositories checked)\n", workspace, total)
+		return nil
+	}
+
+	headers := []string{"Repository", "Branch", "Status", "ID", "Started"}
+	rows := make([][]string, len(statuses))
+	failing, errored := 0, 0
+	for i, status := range statuses {
+		state, id, started := status.Status, "-", "-"
+		if status.Error != "" {
+			state = "error: " + status.Error
+			errored++
+		}
+		if status.failing() {
+			failing++
+		}
+		if status.BuildNumber > 0 {
+			id = fmt.Sprintf("#%d", status.BuildNumber)
+			started = output.FormatRelativeTime(status.CreatedOn)
+		}
+		rows[i] = []string{status.Repository, status.Branch, state, id, started}
+	}
+
+	if err := output.RenderSimpleTable(headers, rows); err != nil {
+		return err
+	}
+
+	fmt.Printf("\n%d of %d repositories failing", failing, total)
+	if errored > 0 {
+		fmt.Printf(", %d could not be checked", errored)
+	}
+	fmt.Println()
+	return nil
+}
+
+// repositorySlug is the slug of repo, taken from its full name when it has
+// one
+func repositorySlug(repo *api.Repository) string {
+	if _, slug, ok := strings.Cut(repo.FullName, "/"); ok && slug != "" {
+		return slug
+	}
+	return repo.Name
+}
+
+// rateLimiter spaces out API requests made from several goroutines
+type rateLimiter struct {
+	ticker *time.Ticker
+}
+
+func newRateLimiter(perSecond int) *rateLimiter {
+	return &rateLimiter{ticker: time.NewTicker(time.Second / time.Duration(perSecond))}
+}
+
+// Wait blocks until the next request may start
+func (l *rateLimiter) Wait(ctx context.Context) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-l.ticker.C:
+		return nil
+	}
+}
+
+func (l *rateLimiter) Stop() {
+	l.ticker.Stop()
+}
@@ -0,0 +1,86 @@
+package run
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/carlosarraes/bt/pkg/api/apitest"
+	"github.com/carlosarraes/bt/pkg/cmd/shared"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWorkspaceStatusCmd_Run(t *testing.T) {
+	apitest.CommandEnv(t, "ws", "repo")
+	transport := apitest.NewReplayTransport(
+		apitest.Fixture{Method: "GET", Path: "/repositories/team", Status: 200,
+			Body: []byte(`{"values": [
+				{"name": "web", "full_name": "team/web", "mainbranch": {"type": "branch", "name": "main"}},
+				{"name": "API", "full_name": "team/api", "mainbranch": {"type": "branch", "name": "master"}},
+				{"name": "docs", "full_name": "team/docs", "mainbranch": {"type": "branch", "name": "main"}}
+			]}`)},
+		apitest.Fixture{Method: "GET", Path: "/repositories/team/web/pipelines", Query: "target.ref_name=main", Status: 200,
+			Body: []byte(`{"values": [{"uuid": "{w}", "build_number": 12, "state": {"name": "COMPLETED", "result": {"name": "FAILED"}}}]}`)},
+		apitest.Fixture{Method: "GET", Path: "/repositories/team/api/pipelines", Query: "target.ref_name=master", Status: 200,
+			Body: []byte(`{"values": [{"uuid": "{a}", "build_number": 40, "state": {"name": "COMPLETED", "result": {"name": "SUCCESSFUL"}}}]}`)},
+		apitest.Fixture{Method: "GET", Path: "/repositories/team/docs/pipelines", Query: "target.ref_name=main", Status: 200,
+			Body: []byte(`{"values": []}`)},
+	)
+	t.Cleanup(shared.SetClientTransport(transport))
+
+	var runErr error
+	out := captureStdout(func() {
+		runErr = (&WorkspaceStatusCmd{Workspace: "team", Output: "json", NoColor: true}).Run(context.Background())
+	})
+	require.NoError(t, runErr)
+
+	var statuses []RepoPipelineStatus
+	require.NoError(t, json.Unmarshal([]byte(out), &statuses))
+	require.Len(t, statuses, 3)
+	assert.Equal(t, RepoPipelineStatus{Repository: "api", Branch: "master", Status: "SUCCESSFUL", BuildNumber: 40}, statuses[0])
+	assert.Equal(t, RepoPipelineStatus{Repository: "docs", Branch: "main", Status: statusNone}, statuses[1])
+	assert.Equal(t, RepoPipelineStatus{Repository: "web", Branch: "main", Status: "FAILED", BuildNumber: 12}, statuses[2])
+}
+
+func TestWorkspaceStatusCmd_RunRepos(t *testing.T) {
+	apitest.CommandEnv(t, "ws", "repo")
+	transport := apitest.NewReplayTransport(
+		apitest.Fixture{Method: "GET", Path: "/repositories/team/web", Status: 200,
+			Body: []byte(`{"name": "web", "full_name": "team/web", "mainbranch": {"type": "branch", "name": "trunk"}}`)},
+		apitest.Fixture{Method: "GET", Path: "/repositories/team/web/pipelines", Query: "target.ref_name=trunk", Status: 200,
+			Body: []byte(`{"values": [{"uuid": "{w}", "build_number": 3, "state": {"name": "COMPLETED", "result": {"name": "FAILED"}}}]}`)},
+		apitest.Fixture{Method: "GET", Path: "/repositories/team/gone", Status: 404,
+			Body: []byte(`{"type": "error", "error": {"message": "Repository team/gone not found"}}`)},
+	)
+	t.Cleanup(shared.SetClientTransport(transport))
+
+	var runErr error
+	out := captureStdout(func() {
+		runErr = (&WorkspaceStatusCmd{Workspace: "team", Repos: []string{"web", "gone"}, Output: "table", NoColor: true}).Run(context.Background())
+	})
+	require.NoError(t, runErr)
+
+	assert.Contains(t, out, "trunk")
+	assert.Contains(t, out, "#3")
+	assert.Contains(t, out, "1 of 2 repositories failing, 1 could not be checked")
+	for _, req := range transport.Requests() {
+		assert.NotEqual(t, "/repositories/team", req.Path, "named repositories aren't listed")
+	}
+}
+
+func TestWorkspaceStatusCmd_RunFailed(t *testing.T) {
+	apitest.CommandEnv(t, "ws", "repo")
+	transport := apitest.NewReplayTransport(
+		apitest.Fixture{Method: "GET", Path: "/repositories/team/api/pipelines", Query: "target.ref_name=main", Status: 200,
+			Body: []byte(`{"values": [{"uuid": "{a}", "build_number": 40, "state": {"name": "COMPLETED", "result": {"name": "SUCCESSFUL"}}}]}`)},
+	)
+	t.Cleanup(shared.SetClientTransport(transport))
+
+	var runErr error
+	out := captureStdout(func() {
+		runErr = (&WorkspaceStatusCmd{Workspace: "team", Repos: []string{"api"}, Branch: "main", Failed: true, Output: "table", NoColor: true}).Run(context.Background())
+	})
+	require.NoError(t, runErr)
+	assert.Contains(t, out, "No failing pipelines in team (1 repositories checked)")
+}
@@ -0,0 +1,34 @@
+package shared
+
+import (
+	"os"
+	"strconv"
+)
+
+const (
+	// EnvNoColor disables colored output when set to a truthy value.
+	EnvNoColor = "BT_NO_COLOR"
+	// EnvNoColorStandard follows https://no-color.org: any non-empty value disables color.
+	EnvNoColorStandard = "NO_COLOR"
+)
+
+// NoColorFromEnv reports whether the environment asks for uncolored output.
+func NoColorFromEnv() bool {
+	if v := os.Getenv(EnvNoColorStandard); v != "" {
+		return true
+	}
+	if v := os.Getenv(EnvNoColor); v != "" {
+		enabled, err := strconv.ParseBool(v)
+		return err != nil || enabled
+	}
+	return false
+}
+
+// ResolveNoColor decides whether color is disabled. An explicit --no-color
+// flag (including --no-color=false) wins over the environment.
+func ResolveNoColor(flagSet, flagValue bool) bool {
+	if flagSet {
+		return flagValue
+	}
+	return NoColorFromEnv()
+}
@@ -0,0 +1,34 @@
+package shared
+
+import "testing"
+
+func TestResolveNoColor(t *testing.T) {
+	tests := []struct {
+		name      string
+		btNoColor string
+		noColor   string
+		flagSet   bool
+		flagValue bool
+		want      bool
+	}{
+		{"nothing set", "", "", false, false, false},
+		{"NO_COLOR any value", "", "0", false, false, true},
+		{"BT_NO_COLOR truthy", "1", "", false, false, true},
+		{"BT_NO_COLOR true", "true", "", false, false, true},
+		{"BT_NO_COLOR false", "false", "", false, false, false},
+		{"BT_NO_COLOR non-boolean", "yes", "", false, false, true},
+		{"flag wins over env", "1", "1", true, false, false},
+		{"flag enables color off", "", "", true, true, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv(EnvNoColor, tt.btNoColor)
+			t.Setenv(EnvNoColorStandard, tt.noColor)
+
+			if got := ResolveNoColor(tt.flagSet, tt.flagValue); got != tt.want {
+				t.Errorf("ResolveNoColor(%v, %v) = %v, want %v", tt.flagSet, tt.flagValue, got, tt.want)
+			}
+		})
+	}
+}
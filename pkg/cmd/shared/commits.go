@@ -0,0 +1,237 @@
+package shared
+
+import (
+	"strings"
+	"time"
+
+	"github.com/carlosarraes/bt/pkg/api"
+	"github.com/carlosarraes/bt/pkg/git"
+	"github.com/carlosarraes/bt/pkg/output"
+)
+
+// CommitSummary is the flattened view of a commit used by commit listings.
+type CommitSummary struct {
+	Hash           string              `json:"hash" yaml:"hash"`
+	Message        string              `json:"message" yaml:"message"`
+	Author         string              `json:"author" yaml:"author"`
+	AuthorEmail    string              `json:"author_email,omitempty" yaml:"author_email,omitempty"`
+	AuthorUsername string              `json:"author_username,omitempty" yaml:"author_username,omitempty"`
+	Committer      string              `json:"committer,omitempty" yaml:"committer,omitempty"`
+	CommitterEmail string              `json:"committer_email,omitempty" yaml:"committer_email,omitempty"`
+	Date           *time.Time          `json:"date,omitempty" yaml:"date,omitempty"`
+	Verification   git.SignatureStatus `json:"verification" yaml:"verification"`
+	Signer         string              `json:"signer,omitempty" yaml:"signer,omitempty"`
+}
+
+// CommittedByOther reports whether someone other than the author committed
+// the commit, as happens when patches are rebased or applied by a maintainer
+func (c CommitSummary) CommittedByOther() bool {
+	if c.Committer == "" && c.CommitterEmail == "" {
+		return false
+	}
+	author := git.Identity{Name: c.Author, Email: c.AuthorEmail}
+	return !author.Same(git.Identity{Name: c.Committer, Email: c.CommitterEmail})
+}
+
+// CommitFilter selects commits by who wrote or committed them. Empty fields
+// match every commit.
+type CommitFilter struct {
+	// AuthorEmail matches the author's email or Bitbucket username
+	AuthorEmail string
+	// Committer matches the committer's name or email
+	Committer string
+}
+
+// Active reports whether the filter selects anything
+func (f CommitFilter) Active() bool {
+	return f.AuthorEmail != "" || f.Committer != ""
+}
+
+// Matches reports whether the commit passes the filter. Matching ignores
+// case.
+func (f CommitFilter) Matches(c CommitSummary) bool {
+	if f.AuthorEmail != "" && !strings.EqualFold(c.AuthorEmail, f.AuthorEmail) && !strings.EqualFold(c.AuthorUsername, f.AuthorEmail) {
+		return false
+	}
+	if f.Committer != "" && !strings.EqualFold(c.CommitterEmail, f.Committer) && !strings.EqualFold(c.Committer, f.Committer) {
+		return false
+	}
+	return true
+}
+
+// FilterCommits keeps the commits that pass the filter
+func FilterCommits(commits []CommitSummary, filter CommitFilter) []CommitSummary {
+	if !filter.Active() {
+		return commits
+	}
+	filtered := make([]CommitSummary, 0, len(commits))
+	for _, c := range commits {
+		if filter.Matches(c) {
+			filtered = append(filtered, c)
+		}
+	}
+	return filtered
+}
+
+// SummarizeCommits flattens API commits of workspace/repository and resolves
+// their signature status.
+func SummarizeCommits(commits []api.Commit, workspace, repository string) []CommitSummary {
+	local := localCommitters(commits, workspace, repository)
+	summaries := make([]CommitSummary, 0, len(commits))
+	for i := range commits {
+		c := &commits[i]
+		summary := CommitSummary{
+			Hash:         c.Hash,
+			Message:      strings.TrimSpace(strings.SplitN(c.Message, "\n", 2)[0]),
+			Author:       CommitAuthorName(c),
+			Date:         c.Date,
+			Verification: CommitVerification(c),
+		}
+		if c.Author != nil {
+			summary.AuthorEmail = git.ParseIdentity(c.Author.Raw).Email
+			if u := c.Author.User; u != nil {
+				summary.AuthorUsername = u.Nickname
+				if summary.AuthorUsername == "" {
+					summary.AuthorUsername = u.Username
+				}
+			}
+		}
+		committer := CommitCommitter(c, local)
+		summary.Committer, summary.CommitterEmail = committer.Name, committer.Email
+		if c.Signature != nil {
+			summary.Signer = c.Signature.Signer
+		}
+		summaries = append(summaries, summary)
+	}
+	return summaries
+}
+
+// CommitVerification returns the signature status of a commit. The status
+// reported by Bitbucket wins; otherwise the local git checkout is asked, which
+// only knows about commits that have been fetched.
+func CommitVerification(c *api.Commit) git.SignatureStatus {
+	if c.Signature != nil {
+		switch {
+		case c.Signature.Verified:
+			return git.SignatureVerified
+		case c.Signature.Signed:
+			return git.SignatureSigned
+		default:
+			return git.SignatureUnsigned
+		}
+	}
+	return git.CommitSignatureStatus(c.Hash)
+}
+
+// CommitCommitter returns who committed a commit. Bitbucket reports it
+// for some commits only; otherwise it is looked up in local, the committers
+// found by localCommitters.
+func CommitCommitter(c *api.Commit, local map[string]git.Identity) git.Identity {
+	if c.Committer != nil && c.Committer.Raw != "" {
+		return git.ParseIdentity(c.Committer.Raw)
+	}
+	return local[c.Hash]
+}
+
+// localCommitters asks the local git checkout, in one git log, who committed
+// the commits Bitbucket reported no committer for. The checkout is only
+// asked when one of its remotes is workspace/repository, and only knows
+// about commits that have been fetched.
+func localCommitters(commits []api.Commit, workspace, repository string) map[string]git.Identity {
+	var hashes []string
+	for i := range commits {
+		c := &commits[i]
+		if c.Hash != "" && (c.Committer == nil || c.Committer.Raw == "") {
+			hashes = append(hashes, c.Hash)
+		}
+	}
+	if len(hashes) == 0 || !checkoutOf(workspace, repository) {
+		return nil
+	}
+
+	committers, err := git.CommittersExec("", hashes)
+	if err != nil {
+		return nil
+	}
+	return committers
+}
+
+// checkoutOf reports whether the current directory is a checkout of
+// workspace/repository, going by its remotes
+func checkoutOf(workspace, repository string) bool {
+	if workspace == "" || repository == "" {
+		return false
+	}
+	gitRepo, err := git.NewRepository("")
+	if err != nil {
+		return false
+	}
+	for _, remote := range gitRepo.GetRemotes() {
+		if strings.EqualFold(remote.Workspace, workspace) && strings.EqualFold(remote.RepoName, repository) {
+			return true
+		}
+	}
+	return false
+}
+
+// CommitAuthorName returns the best display name for a commit author.
+func CommitAuthorName(c *api.Commit) string {
+	if c.Author == nil {
+		return ""
+	}
+	if u := c.Author.User; u != nil {
+		if u.DisplayName != "" {
+			return u.DisplayName
+		}
+		if u.Username != "" {
+			return u.Username
+		}
+	}
+	author := git.ParseIdentity(c.Author.Raw)
+	if author.Name != "" {
+		return author.Name
+	}
+	return author.Email
+}
+
+// SignatureBadge renders the badge shown next to a commit. Unsigned and
+// unknown commits get no badge.
+func SignatureBadge(status git.SignatureStatus) string {
+	switch status {
+	case git.SignatureVerified:
+		return "✓ Verified"
+	case git.SignatureSigned:
+		return "Signed"
+	case git.SignatureBad:
+		return "✗ Bad signature"
+	default:
+		return ""
+	}
+}
+
+// RenderCommitTable prints commits as a table with a verification column.
+// Commits committed by someone other than their author name both.
+func RenderCommitTable(commits []CommitSummary) error {
+	headers := []string{"COMMIT", "AUTHOR", "DATE", "MESSAGE", "VERIFICATION"}
+	rows := make([][]string, 0, len(commits))
+	for _, c := range commits {
+		hash := c.Hash
+		if len(hash) > 7 {
+			hash = hash[:7]
+		}
+		date := ""
+		if c.Date != nil {
+			date = output.FormatRelativeTime(c.Date)
+		}
+		author := c.Author
+		if c.CommittedByOther() {
+			committer := c.Committer
+			if committer == "" {
+				committer = c.CommitterEmail
+			}
+			author += " (committed by " + committer + ")"
+		}
+		rows = append(rows, []string{hash, author, date, c.Message, SignatureBadge(c.Verification)})
+	}
+	return output.RenderSimpleTable(headers, rows)
+}
@@ -0,0 +1,158 @@
+package shared
+
+import (
+	"testing"
+
+	"github.com/carlosarraes/bt/pkg/api"
+	"github.com/carlosarraes/bt/pkg/git"
+)
+
+func TestCommitVerification_FromAPI(t *testing.T) {
+	tests := []struct {
+		name string
+		sig  *api.CommitSignature
+		want git.SignatureStatus
+	}{
+		{"verified", &api.CommitSignature{Signed: true, Verified: true}, git.SignatureVerified},
+		{"signed only", &api.CommitSignature{Signed: true}, git.SignatureSigned},
+		{"unsigned", &api.CommitSignature{}, git.SignatureUnsigned},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &api.Commit{Hash: "0000000000000000000000000000000000000000", Signature: tt.sig}
+			if got := CommitVerification(c); got != tt.want {
+				t.Errorf("CommitVerification() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCommitAuthorName(t *testing.T) {
+	tests := []struct {
+		name   string
+		author *api.CommitAuthor
+		want   string
+	}{
+		{"nil author", nil, ""},
+		{"display name", &api.CommitAuthor{Raw: "Jane <jane@example.com>", User: &api.User{DisplayName: "Jane Doe"}}, "Jane Doe"},
+		{"username", &api.CommitAuthor{User: &api.User{Username: "jdoe"}}, "jdoe"},
+		{"raw identity", &api.CommitAuthor{Raw: "Jane <jane@example.com>"}, "Jane"},
+		{"raw without email", &api.CommitAuthor{Raw: "Jane"}, "Jane"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := CommitAuthorName(&api.Commit{Author: tt.author}); got != tt.want {
+				t.Errorf("CommitAuthorName() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSummarizeCommits(t *testing.T) {
+	commits := []api.Commit{{
+		Hash:      "abc1234",
+		Message:   "Fix login\n\nLonger body",
+		Author:    &api.CommitAuthor{Raw: "Jane <jane@example.com>"},
+		Signature: &api.CommitSignature{Signed: true, Verified: true, Signer: "jane@example.com"},
+	}}
+
+	got := SummarizeCommits(commits, "ws", "repo")
+	if len(got) != 1 {
+		t.Fatalf("SummarizeCommits() returned %d commits, want 1", len(got))
+	}
+	if got[0].Message != "Fix login" {
+		t.Errorf("Message = %q, want %q", got[0].Message, "Fix login")
+	}
+	if got[0].Verification != git.SignatureVerified || got[0].Signer != "jane@example.com" {
+		t.Errorf("Verification = %q signer %q, want verified by jane@example.com", got[0].Verification, got[0].Signer)
+	}
+	if SignatureBadge(got[0].Verification) != "✓ Verified" {
+		t.Errorf("SignatureBadge() = %q", SignatureBadge(got[0].Verification))
+	}
+	if SignatureBadge(git.SignatureUnsigned) != "" {
+		t.Errorf("unsigned commits should not get a badge")
+	}
+}
+
+func TestSummarizeCommits_AuthorAndCommitter(t *testing.T) {
+	commits := []api.Commit{{
+		Hash:      "abc1234",
+		Message:   "Fix login",
+		Author:    &api.CommitAuthor{Raw: "Jane <jane@example.com>", User: &api.User{DisplayName: "Jane Doe", Nickname: "jdoe"}},
+		Committer: &api.CommitAuthor{Raw: "Release Bot <bot@example.com>"},
+	}}
+
+	got := SummarizeCommits(commits, "ws", "repo")[0]
+	if got.AuthorEmail != "jane@example.com" || got.AuthorUsername != "jdoe" {
+		t.Errorf("author = %q/%q, want jane@example.com/jdoe", got.AuthorEmail, got.AuthorUsername)
+	}
+	if got.Committer != "Release Bot" || got.CommitterEmail != "bot@example.com" {
+		t.Errorf("committer = %q <%q>, want Release Bot <bot@example.com>", got.Committer, got.CommitterEmail)
+	}
+	if !got.CommittedByOther() {
+		t.Error("CommittedByOther() = false, want true")
+	}
+
+	got.CommitterEmail = "JANE@example.com"
+	if got.CommittedByOther() {
+		t.Error("CommittedByOther() should be false when the emails match")
+	}
+}
+
+func TestCommitCommitter(t *testing.T) {
+	local := map[string]git.Identity{"abc1234": {Name: "Local", Email: "local@example.com"}}
+
+	fromAPI := &api.Commit{Hash: "abc1234", Committer: &api.CommitAuthor{Raw: "Release Bot <bot@example.com>"}}
+	if got := CommitCommitter(fromAPI, local); got.Name != "Release Bot" {
+		t.Errorf("CommitCommitter() = %+v, want the committer Bitbucket reported", got)
+	}
+	if got := CommitCommitter(&api.Commit{Hash: "abc1234"}, local); got.Name != "Local" {
+		t.Errorf("CommitCommitter() = %+v, want the local committer", got)
+	}
+	if got := CommitCommitter(&api.Commit{Hash: "def5678"}, local); got != (git.Identity{}) {
+		t.Errorf("CommitCommitter() = %+v, want no committer", got)
+	}
+}
+
+func TestLocalCommitters_OtherRepository(t *testing.T) {
+	commits := []api.Commit{{Hash: "abc1234"}}
+	if got := localCommitters(commits, "someone-else", "not-this-checkout"); got != nil {
+		t.Errorf("localCommitters() = %v, want nil for a repository the checkout isn't of", got)
+	}
+}
+
+func TestFilterCommits(t *testing.T) {
+	commits := []CommitSummary{
+		{Hash: "a", Author: "Jane", AuthorEmail: "jane@example.com", AuthorUsername: "jdoe", Committer: "Jane", CommitterEmail: "jane@example.com"},
+		{Hash: "b", Author: "Jane", AuthorEmail: "jane@example.com", AuthorUsername: "jdoe", Committer: "Release Bot", CommitterEmail: "bot@example.com"},
+		{Hash: "c", Author: "Max", AuthorEmail: "max@example.com", Committer: "Release Bot", CommitterEmail: "bot@example.com"},
+	}
+
+	tests := []struct {
+		name   string
+		filter CommitFilter
+		want   string
+	}{
+		{"no filter", CommitFilter{}, "abc"},
+		{"author email", CommitFilter{AuthorEmail: "JANE@example.com"}, "ab"},
+		{"author username", CommitFilter{AuthorEmail: "jdoe"}, "ab"},
+		{"committer name", CommitFilter{Committer: "release bot"}, "bc"},
+		{"committer email", CommitFilter{Committer: "bot@example.com"}, "bc"},
+		{"both", CommitFilter{AuthorEmail: "jane@example.com", Committer: "bot@example.com"}, "b"},
+		{"no match", CommitFilter{AuthorEmail: "nobody@example.com"}, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var hashes string
+			for _, c := range FilterCommits(commits, tt.filter) {
+				hashes += c.Hash
+			}
+			if hashes != tt.want {
+				t.Errorf("FilterCommits() = %q, want %q", hashes, tt.want)
+			}
+		})
+	}
+}
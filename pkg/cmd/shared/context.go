@@ -3,6 +3,7 @@ package shared
 import (
 	"context"
 	"fmt"
+	"net/http"
 	"os"
 
 	"github.com/carlosarraes/bt/pkg/api"
@@ -18,6 +19,28 @@ func GetNoColor(ctx context.Context) bool {
 	return false
 }
 
+func GetVerbose(ctx context.Context) bool {
+	if v := ctx.Value("verbose"); v != nil {
+		return v.(bool)
+	}
+	return false
+}
+
+// clientTransport, when set, replaces the HTTP transport of the API clients
+// built for commands
+var clientTransport http.RoundTripper
+
+// SetClientTransport makes commands send their API requests through
+// transport, typically an apitest.ReplayTransport in tests. It returns a
+// function restoring the previous transport.
+func SetClientTransport(transport http.RoundTripper) func() {
+	previous := clientTransport
+	clientTransport = transport
+	return func() {
+		clientTransport = previous
+	}
+}
+
 type CommandContext struct {
 	Client     *api.Client
 	Config     *config.Config
@@ -25,6 +48,11 @@ type CommandContext struct {
 	Repository string
 	Formatter  output.Formatter
 	Debug      bool
+	// DryRun is set by the global --dry-run flag: mutating commands print
+	// the requests they would send instead of sending them
+	DryRun bool
+
+	resolver *RepoResolver
 }
 
 func NewCommandContext(ctx context.Context, outputFormat string, noColor bool, debug ...bool) (*CommandContext, error) {
@@ -39,38 +67,37 @@ func NewCommandContext(ctx context.Context, outputFormat string, noColor bool, d
 		return nil, fmt.Errorf("failed to load configuration: %w", err)
 	}
 
-	gitRepo, err := git.NewRepository("")
-	var workspace, repository string
-
-	if err != nil {
+	gitRepo, gitErr := git.NewRepository("")
+	if gitErr != nil {
 		if debugEnabled {
-			fmt.Fprintf(os.Stderr, "DEBUG: Not in git repository, error: %v\n", err)
-		}
-		if cfg.Auth.DefaultWorkspace == "" {
-			return nil, fmt.Errorf("not in a git repository and no default workspace configured. Run 'bt auth login' or set default_workspace in config")
+			fmt.Fprintf(os.Stderr, "DEBUG: Not in git repository, error: %v\n", gitErr)
 		}
-		workspace = cfg.Auth.DefaultWorkspace
-		if debugEnabled {
-			fmt.Fprintf(os.Stderr, "DEBUG: Using default workspace from config: %s\n", workspace)
-		}
-	} else {
-		workspace = gitRepo.GetWorkspace()
-		repository = gitRepo.GetName()
+		gitRepo = nil
+	} else if debugEnabled {
+		fmt.Fprintf(os.Stderr, "DEBUG: Git extracted workspace: %s\n", gitRepo.GetWorkspace())
+		fmt.Fprintf(os.Stderr, "DEBUG: Git extracted repository: %s\n", gitRepo.GetName())
 
-		if debugEnabled {
-			fmt.Fprintf(os.Stderr, "DEBUG: Git extracted workspace: %s\n", workspace)
-			fmt.Fprintf(os.Stderr, "DEBUG: Git extracted repository: %s\n", repository)
-
-			remotes := gitRepo.GetRemotes()
-			fmt.Fprintf(os.Stderr, "DEBUG: Git remotes found: %d\n", len(remotes))
-			for name, remote := range remotes {
-				fmt.Fprintf(os.Stderr, "DEBUG: Remote %s: %s (workspace: %s, repo: %s)\n", name, remote.URL, remote.Workspace, remote.RepoName)
-			}
+		remotes := gitRepo.GetRemotes()
+		fmt.Fprintf(os.Stderr, "DEBUG: Git remotes found: %d\n", len(remotes))
+		for name, remote := range remotes {
+			fmt.Fprintf(os.Stderr, "DEBUG: Remote %s: %s (workspace: %s, repo: %s)\n", name, remote.URL, remote.Workspace, remote.RepoName)
 		}
+	}
 
-		if workspace == "" || repository == "" {
-			return nil, fmt.Errorf("unable to detect Bitbucket workspace and repository from git remotes")
-		}
+	resolver := NewRepoResolver(cfg, gitRepo)
+	workspace := resolver.Workspace("")
+	repository := resolver.Repository("")
+
+	if debugEnabled {
+		fmt.Fprintf(os.Stderr, "DEBUG: Using workspace %q from %s\n", workspace.Value, workspace.Source)
+		fmt.Fprintf(os.Stderr, "DEBUG: Using repository %q from %s\n", repository.Value, repository.Source)
+	}
+
+	if gitErr != nil && workspace.Value == "" {
+		return nil, fmt.Errorf("not in a git repository and no default workspace configured. Run 'bt auth login', set %s, or set default_workspace in config", EnvWorkspace)
+	}
+	if gitErr == nil && (workspace.Value == "" || repository.Value == "") {
+		return nil, fmt.Errorf("unable to detect Bitbucket workspace and repository from git remotes")
 	}
 
 	authManager, err := CreateAuthManager()
@@ -81,6 +108,11 @@ func NewCommandContext(ctx context.Context, outputFormat string, noColor bool, d
 	clientConfig := api.DefaultClientConfig()
 	clientConfig.BaseURL = cfg.API.BaseURL
 	clientConfig.Timeout = cfg.API.Timeout
+	clientConfig.MaxIdleConnsPerHost = cfg.API.MaxIdleConnsPerHost
+	clientConfig.IdleConnTimeout = cfg.API.IdleConnTimeout
+	clientConfig.Transport = clientTransport
+	clientConfig.OnRateLimitLow = warnRateLimitLow
+	clientConfig.DryRun = GetDryRun(ctx)
 
 	client, err := api.NewClient(authManager, clientConfig)
 	if err != nil {
@@ -99,13 +131,35 @@ func NewCommandContext(ctx context.Context, outputFormat string, noColor bool, d
 	return &CommandContext{
 		Client:     client,
 		Config:     cfg,
-		Workspace:  workspace,
-		Repository: repository,
+		Workspace:  workspace.Value,
+		Repository: repository.Value,
 		Formatter:  formatter,
 		Debug:      debugEnabled,
+		DryRun:     clientConfig.DryRun,
+		resolver:   resolver,
 	}, nil
 }
 
+// ApplyRepoFlags resolves the workspace and repository again with the
+// --workspace and --repository values of a command, so the flags take their
+// place in the RepoResolver precedence like every other source.
+func (c *CommandContext) ApplyRepoFlags(workspace, repository string) {
+	resolver := c.resolver
+	if resolver == nil {
+		// Contexts not built by a constructor only have what they were given
+		resolver = &RepoResolver{GitWorkspace: c.Workspace, GitRepository: c.Repository}
+	}
+	c.Workspace = resolver.Workspace(workspace).Value
+	c.Repository = resolver.Repository(repository).Value
+}
+
+// ResolveRepository applies a command's --workspace and --repository flags
+// and checks that both the workspace and the repository are known.
+func (c *CommandContext) ResolveRepository(workspace, repository string) error {
+	c.ApplyRepoFlags(workspace, repository)
+	return c.ValidateWorkspaceAndRepo()
+}
+
 func (c *CommandContext) ValidateWorkspaceAndRepo() error {
 	if c.Workspace == "" {
 		return fmt.Errorf("workspace not specified. Either run from a git repository with Bitbucket remote or configure default_workspace")
@@ -116,6 +170,33 @@ func (c *CommandContext) ValidateWorkspaceAndRepo() error {
 	return nil
 }
 
+// ConfiguredBase returns the base branch configured for the repository, in
+// repo.<name>.base or pr.base (of the config file or .bt.yml), with the key
+// it came from. It returns empty strings when none is configured.
+func (c *CommandContext) ConfiguredBase() (string, string) {
+	if c.Config == nil {
+		return "", ""
+	}
+	return c.Config.ConfiguredBase(c.Repository)
+}
+
+// DefaultBase is the branch pull requests target when no base is given: the
+// configured base, else the repository's main branch on Bitbucket.
+func (c *CommandContext) DefaultBase(ctx context.Context) (string, error) {
+	if base, _ := c.ConfiguredBase(); base != "" {
+		return base, nil
+	}
+
+	repo, err := c.Client.Repositories.GetRepository(ctx, c.Workspace, c.Repository)
+	if err != nil {
+		return "", fmt.Errorf("failed to determine the default branch: %w", err)
+	}
+	if repo.MainBranch == nil || repo.MainBranch.Name == "" {
+		return "", fmt.Errorf("repository %s/%s has no main branch", c.Workspace, c.Repository)
+	}
+	return repo.MainBranch.Name, nil
+}
+
 type MinimalContextOptions struct {
 	OutputFormat string
 	Workspace    string
@@ -139,16 +220,20 @@ func NewMinimalContext(ctx context.Context, opts MinimalContextOptions) (*Comman
 	clientConfig := api.DefaultClientConfig()
 	clientConfig.BaseURL = cfg.API.BaseURL
 	clientConfig.Timeout = cfg.API.Timeout
+	clientConfig.MaxIdleConnsPerHost = cfg.API.MaxIdleConnsPerHost
+	clientConfig.IdleConnTimeout = cfg.API.IdleConnTimeout
+	clientConfig.Transport = clientTransport
+	clientConfig.OnRateLimitLow = warnRateLimitLow
+	clientConfig.DryRun = GetDryRun(ctx)
 
 	client, err := api.NewClient(authManager, clientConfig)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create API client: %w", err)
 	}
 
-	workspace := opts.Workspace
-	if workspace == "" {
-		workspace = cfg.Auth.DefaultWorkspace
-	}
+	resolver := NewRepoResolver(cfg, nil)
+	workspace := resolver.Workspace(opts.Workspace).Value
+	repository := resolver.Repository(opts.Repository).Value
 
 	var formatter output.Formatter
 	if opts.OutputFormat != "" {
@@ -165,8 +250,10 @@ func NewMinimalContext(ctx context.Context, opts MinimalContextOptions) (*Comman
 		Client:     client,
 		Config:     cfg,
 		Workspace:  workspace,
-		Repository: opts.Repository,
+		Repository: repository,
 		Formatter:  formatter,
 		Debug:      opts.Debug,
+		DryRun:     clientConfig.DryRun,
+		resolver:   resolver,
 	}, nil
 }
@@ -0,0 +1,66 @@
+package shared
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+)
+
+// GetDryRun reports whether the global --dry-run flag was given
+func GetDryRun(ctx context.Context) bool {
+	if v := ctx.Value("dry-run"); v != nil {
+		return v.(bool)
+	}
+	return false
+}
+
+// PlannedCall is an API request a command would send
+type PlannedCall struct {
+	Method   string      `json:"method" yaml:"method"`
+	Endpoint string      `json:"endpoint" yaml:"endpoint"`
+	Body     interface{} `json:"body,omitempty" yaml:"body,omitempty"`
+}
+
+// DryRunPlan describes what a mutating command would do under --dry-run
+type DryRunPlan struct {
+	DryRun  bool          `json:"dry_run" yaml:"dry_run"`
+	Summary string        `json:"summary" yaml:"summary"`
+	Calls   []PlannedCall `json:"calls" yaml:"calls"`
+}
+
+// NewDryRunPlan starts a plan with a one-line summary of the action
+func NewDryRunPlan(format string, args ...interface{}) *DryRunPlan {
+	return &DryRunPlan{DryRun: true, Summary: fmt.Sprintf(format, args...), Calls: []PlannedCall{}}
+}
+
+// Add records a request the command would send. endpoint is relative to the
+// API base URL, as passed to api.Client.
+func (p *DryRunPlan) Add(method, endpoint string, body interface{}) {
+	p.Calls = append(p.Calls, PlannedCall{Method: method, Endpoint: endpoint, Body: body})
+}
+
+// Write prints the plan for humans
+func (p *DryRunPlan) Write(w io.Writer) {
+	fmt.Fprintf(w, "[dry-run] %s\n", p.Summary)
+	for _, call := range p.Calls {
+		fmt.Fprintf(w, "  %s %s\n", call.Method, call.Endpoint)
+		if call.Body != nil {
+			if body, err := json.Marshal(call.Body); err == nil {
+				fmt.Fprintf(w, "    %s\n", body)
+			}
+		}
+	}
+	fmt.Fprintln(w, "No changes were made.")
+}
+
+// PrintDryRun writes plan to stdout, as text for table output and through
+// the formatter otherwise
+func (c *CommandContext) PrintDryRun(plan *DryRunPlan, outputFormat string) error {
+	if outputFormat == "" || outputFormat == "table" || c.Formatter == nil {
+		plan.Write(os.Stdout)
+		return nil
+	}
+	return c.Formatter.Format(plan)
+}
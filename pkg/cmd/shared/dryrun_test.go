@@ -0,0 +1,30 @@
+package shared
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetDryRun(t *testing.T) {
+	assert.False(t, GetDryRun(context.Background()))
+	assert.True(t, GetDryRun(context.WithValue(context.Background(), "dry-run", true)))
+}
+
+func TestDryRunPlan_Write(t *testing.T) {
+	plan := NewDryRunPlan("Would merge pull request #%d", 42)
+	plan.Add("POST", "repositories/ws/repo/pullrequests/42/merge", map[string]string{"merge_strategy": "squash"})
+	plan.Add("DELETE", "repositories/ws/repo/refs/branches/feature", nil)
+
+	var buf bytes.Buffer
+	plan.Write(&buf)
+
+	assert.Equal(t, `[dry-run] Would merge pull request #42
+  POST repositories/ws/repo/pullrequests/42/merge
+    {"merge_strategy":"squash"}
+  DELETE repositories/ws/repo/refs/branches/feature
+No changes were made.
+`, buf.String())
+}
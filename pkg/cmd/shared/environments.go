@@ -0,0 +1,28 @@
+package shared
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/carlosarraes/bt/pkg/api"
+)
+
+// FindEnvironment matches a deployment environment by UUID (braces
+// optional), slug or case-insensitive name
+func FindEnvironment(environments []*api.DeploymentEnvironment, query string) (*api.DeploymentEnvironment, error) {
+	uuid := strings.Trim(query, "{}")
+	for _, env := range environments {
+		if strings.Trim(env.UUID, "{}") == uuid || env.Slug == query || strings.EqualFold(env.Name, query) {
+			return env, nil
+		}
+	}
+
+	names := make([]string, 0, len(environments))
+	for _, env := range environments {
+		names = append(names, env.Name)
+	}
+	if len(names) == 0 {
+		return nil, fmt.Errorf("deployment environment %q not found: the repository has no deployment environments", query)
+	}
+	return nil, fmt.Errorf("deployment environment %q not found. Available environments: %s", query, strings.Join(names, ", "))
+}
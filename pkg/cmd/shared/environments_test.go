@@ -0,0 +1,28 @@
+package shared
+
+import (
+	"testing"
+
+	"github.com/carlosarraes/bt/pkg/api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFindEnvironment(t *testing.T) {
+	environments := []*api.DeploymentEnvironment{
+		{UUID: "{env-1}", Name: "Staging", Slug: "staging"},
+		{UUID: "{env-2}", Name: "Production", Slug: "production"},
+	}
+
+	for _, query := range []string{"production", "Production", "{env-2}", "env-2"} {
+		env, err := FindEnvironment(environments, query)
+		require.NoError(t, err, query)
+		assert.Equal(t, "{env-2}", env.UUID, query)
+	}
+
+	_, err := FindEnvironment(environments, "qa")
+	assert.EqualError(t, err, `deployment environment "qa" not found. Available environments: Staging, Production`)
+
+	_, err = FindEnvironment(nil, "qa")
+	assert.ErrorContains(t, err, "has no deployment environments")
+}
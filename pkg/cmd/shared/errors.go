@@ -1,9 +1,13 @@
 package shared
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 
 	"github.com/carlosarraes/bt/pkg/api"
+	"github.com/carlosarraes/bt/pkg/sonarcloud"
 )
 
 type APIDomain string
@@ -11,33 +15,50 @@ type APIDomain string
 const (
 	DomainPullRequest APIDomain = "pull_request"
 	DomainPipeline    APIDomain = "pipeline"
+	DomainRepository  APIDomain = "repository"
+	DomainIssue       APIDomain = "issue"
 )
 
 func HandleAPIError(err error, domain APIDomain) error {
 	if bitbucketErr, ok := err.(*api.BitbucketError); ok {
 		switch bitbucketErr.Type {
 		case api.ErrorTypeNotFound:
-			return notFoundError(domain)
+			return &apiError{message: notFoundError(domain).Error(), cause: bitbucketErr}
 		case api.ErrorTypeAuthentication:
-			return fmt.Errorf("authentication failed. Please run 'bt auth login' to authenticate")
+			return &apiError{message: "authentication failed. Please run 'bt auth login' to authenticate", cause: bitbucketErr}
 		case api.ErrorTypePermission:
-			return fmt.Errorf("permission denied. You may not have access to this repository")
+			return &apiError{message: "permission denied. You may not have access to this repository", cause: bitbucketErr}
 		case api.ErrorTypeRateLimit:
-			return fmt.Errorf("rate limit exceeded. Please wait before making more requests")
+			return &apiError{message: "rate limit exceeded. Please wait before making more requests", cause: bitbucketErr}
 		default:
-			return fmt.Errorf("API error: %s", bitbucketErr.Message)
+			return &apiError{message: fmt.Sprintf("API error: %s", bitbucketErr.Message), cause: bitbucketErr}
 		}
 	}
 
 	return fallbackError(err, domain)
 }
 
+// apiError replaces a BitbucketError's message with a user-facing one while
+// keeping the typed error reachable through errors.As.
+type apiError struct {
+	message string
+	cause   *api.BitbucketError
+}
+
+func (e *apiError) Error() string { return e.message }
+
+func (e *apiError) Unwrap() error { return e.cause }
+
 func notFoundError(domain APIDomain) error {
 	switch domain {
 	case DomainPullRequest:
 		return fmt.Errorf("repository not found or no pull requests exist. Verify the repository exists and you have access")
 	case DomainPipeline:
 		return fmt.Errorf("repository not found or pipelines not enabled. Verify the repository exists and has Bitbucket Pipelines enabled")
+	case DomainRepository:
+		return fmt.Errorf("repository or revision not found. Verify the repository exists and you have access")
+	case DomainIssue:
+		return fmt.Errorf("repository or issue not found, or the issue tracker is not enabled. Verify the repository has an issue tracker")
 	default:
 		return fmt.Errorf("resource not found")
 	}
@@ -53,3 +74,76 @@ func fallbackError(err error, domain APIDomain) error {
 		return fmt.Errorf("API request failed: %w", err)
 	}
 }
+
+// ErrorDetail is the machine-readable form of a command failure.
+type ErrorDetail struct {
+	Type        string   `json:"type"`
+	Message     string   `json:"message"`
+	Detail      string   `json:"detail,omitempty"`
+	StatusCode  int      `json:"status_code,omitempty"`
+	Suggestions []string `json:"suggestions,omitempty"`
+	HelpLinks   []string `json:"help_links,omitempty"`
+}
+
+// ErrorPayload wraps ErrorDetail as {"error": {...}}.
+type ErrorPayload struct {
+	Error ErrorDetail `json:"error"`
+}
+
+// NewErrorPayload describes err using the richest typed error in its chain.
+func NewErrorPayload(err error) ErrorPayload {
+	detail := ErrorDetail{Type: "error", Message: err.Error()}
+
+	var scErr *sonarcloud.SonarCloudError
+	var bbErr *api.BitbucketError
+	switch {
+	case errors.As(err, &scErr):
+		detail.Type = "sonarcloud"
+		detail.Detail = scErr.TechnicalDetails
+		detail.StatusCode = scErr.StatusCode
+		detail.Suggestions = scErr.SuggestedActions
+		detail.HelpLinks = scErr.HelpLinks
+	case errors.As(err, &bbErr):
+		detail.Type = string(bbErr.Type)
+		detail.Detail = bbErr.Detail
+		detail.StatusCode = bbErr.StatusCode
+		detail.Suggestions = bitbucketSuggestions(bbErr)
+	}
+
+	return ErrorPayload{Error: detail}
+}
+
+// FormatError renders err for terminal output. When a SonarCloudError is in
+// the chain its suggested actions and help links follow the message.
+func FormatError(err error, verbose bool) string {
+	message := fmt.Sprintf("Error: %v\n", err)
+
+	var scErr *sonarcloud.SonarCloudError
+	if errors.As(err, &scErr) {
+		message += scErr.FormatDetails(verbose)
+	}
+	return message
+}
+
+// WriteJSONError writes err to w as a single-line JSON error payload.
+func WriteJSONError(w io.Writer, err error) error {
+	return json.NewEncoder(w).Encode(NewErrorPayload(err))
+}
+
+func bitbucketSuggestions(err *api.BitbucketError) []string {
+	switch err.Type {
+	case api.ErrorTypeAuthentication:
+		return []string{"Run 'bt auth login' to authenticate", "Check 'bt auth status' for the active credentials"}
+	case api.ErrorTypePermission:
+		return []string{"Verify your account has access to the repository"}
+	case api.ErrorTypeNotFound:
+		return []string{"Check the workspace, repository and ID, or pass --workspace and --repository"}
+	case api.ErrorTypeRateLimit:
+		return []string{"Wait before retrying"}
+	default:
+		if err.IsRetryable() {
+			return []string{"Retry the command"}
+		}
+		return nil
+	}
+}
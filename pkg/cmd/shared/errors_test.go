@@ -0,0 +1,91 @@
+package shared
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/carlosarraes/bt/pkg/api"
+	"github.com/carlosarraes/bt/pkg/sonarcloud"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandleAPIError_KeepsTypedCause(t *testing.T) {
+	bbErr := &api.BitbucketError{Type: api.ErrorTypeAuthentication, Message: "Unauthorized", StatusCode: 401}
+
+	err := HandleAPIError(bbErr, DomainPipeline)
+
+	assert.EqualError(t, err, "authentication failed. Please run 'bt auth login' to authenticate")
+	var cause *api.BitbucketError
+	require.True(t, errors.As(err, &cause))
+	assert.Equal(t, 401, cause.StatusCode)
+}
+
+func TestNewErrorPayload(t *testing.T) {
+	t.Run("plain error", func(t *testing.T) {
+		payload := NewErrorPayload(errors.New("pipeline ID is required"))
+		assert.Equal(t, "error", payload.Error.Type)
+		assert.Equal(t, "pipeline ID is required", payload.Error.Message)
+		assert.Empty(t, payload.Error.Suggestions)
+	})
+
+	t.Run("bitbucket error through HandleAPIError", func(t *testing.T) {
+		bbErr := &api.BitbucketError{Type: api.ErrorTypeNotFound, Message: "Not found", StatusCode: 404}
+		payload := NewErrorPayload(HandleAPIError(bbErr, DomainPullRequest))
+
+		assert.Equal(t, "not_found", payload.Error.Type)
+		assert.Equal(t, 404, payload.Error.StatusCode)
+		assert.Contains(t, payload.Error.Message, "repository not found")
+		assert.NotEmpty(t, payload.Error.Suggestions)
+	})
+
+	t.Run("wrapped sonarcloud error", func(t *testing.T) {
+		scErr := &sonarcloud.SonarCloudError{
+			StatusCode:       403,
+			UserMessage:      "SonarCloud access denied.",
+			TechnicalDetails: "HTTP 403",
+			SuggestedActions: []string{"Set SONARCLOUD_TOKEN"},
+			HelpLinks:        []string{"https://sonarcloud.io/account/security/"},
+		}
+		payload := NewErrorPayload(fmt.Errorf("report failed: %w", scErr))
+
+		assert.Equal(t, "sonarcloud", payload.Error.Type)
+		assert.Equal(t, "report failed: SonarCloud access denied.", payload.Error.Message)
+		assert.Equal(t, []string{"Set SONARCLOUD_TOKEN"}, payload.Error.Suggestions)
+		assert.Equal(t, []string{"https://sonarcloud.io/account/security/"}, payload.Error.HelpLinks)
+	})
+}
+
+func TestWriteJSONError(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, WriteJSONError(&buf, errors.New("boom")))
+
+	var decoded map[string]map[string]interface{}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &decoded))
+	assert.Equal(t, "boom", decoded["error"]["message"])
+	assert.Equal(t, "error", decoded["error"]["type"])
+	assert.NotContains(t, decoded["error"], "suggestions")
+}
+
+func TestFormatError(t *testing.T) {
+	assert.Equal(t, "Error: boom\n", FormatError(errors.New("boom"), false))
+
+	scErr := &sonarcloud.SonarCloudError{
+		UserMessage:      "SonarCloud project not found.",
+		TechnicalDetails: "HTTP 404 from /api/components/show",
+		SuggestedActions: []string{"Check the project key"},
+		HelpLinks:        []string{"https://docs.sonarcloud.io/"},
+	}
+	wrapped := fmt.Errorf("failed to discover SonarCloud project: %w", scErr)
+
+	out := FormatError(wrapped, false)
+	assert.Contains(t, out, "Error: failed to discover SonarCloud project: SonarCloud project not found.\n")
+	assert.Contains(t, out, "Suggested actions:\n  • Check the project key")
+	assert.Contains(t, out, "https://docs.sonarcloud.io/")
+	assert.NotContains(t, out, "Technical details")
+
+	assert.Contains(t, FormatError(wrapped, true), "Technical details: HTTP 404")
+}
@@ -1,9 +1,8 @@
 package shared
 
-// Truncate truncates s to maxLen characters, appending "..." if truncated.
+import "github.com/carlosarraes/bt/pkg/output"
+
+// Truncate truncates s to maxLen display columns, appending "..." if truncated.
 func Truncate(s string, maxLen int) string {
-	if maxLen <= 3 || len(s) <= maxLen {
-		return s
-	}
-	return s[:maxLen-3] + "..."
+	return output.Truncate(s, maxLen)
 }
@@ -0,0 +1,45 @@
+package shared
+
+import (
+	"os"
+	"strings"
+
+	"github.com/carlosarraes/bt/pkg/config"
+	"github.com/carlosarraes/bt/pkg/output"
+)
+
+// EnvOutputFormat overrides defaults.output_format for every command.
+const EnvOutputFormat = "BT_OUTPUT_FORMAT"
+
+// ResolveDefaultOutputFormat returns the format used when -o is not given.
+// Precedence: BT_OUTPUT_FORMAT > defaults.output_format > table. Invalid
+// environment values are ignored so a typo never breaks every command.
+func ResolveDefaultOutputFormat(cfg *config.Config) string {
+	if env := strings.ToLower(strings.TrimSpace(os.Getenv(EnvOutputFormat))); env != "" {
+		if output.ValidateFormat(env) == nil {
+			return env
+		}
+	}
+
+	if cfg != nil && cfg.Defaults.OutputFormat != "" {
+		return cfg.Defaults.OutputFormat
+	}
+
+	return string(output.FormatTable)
+}
+
+// OutputFormatVars maps the resolved default format onto the Kong variables
+// used by the -o flags. Commands whose human-readable format isn't "table"
+// (logs, diff) get their own variable.
+func OutputFormatVars(format string) map[string]string {
+	text, diff := "text", "diff"
+	if format != string(output.FormatTable) {
+		text, diff = format, format
+	}
+
+	return map[string]string{
+		"output_format":      format,
+		"text_output_format": text,
+		"diff_output_format": diff,
+	}
+}
@@ -0,0 +1,75 @@
+package shared
+
+import (
+	"testing"
+
+	"github.com/carlosarraes/bt/pkg/config"
+)
+
+func TestResolveDefaultOutputFormat(t *testing.T) {
+	tests := []struct {
+		name   string
+		env    string
+		config string
+		want   string
+	}{
+		{"nothing set", "", "", "table"},
+		{"config only", "", "yaml", "yaml"},
+		{"env beats config", "json", "yaml", "json"},
+		{"env is case insensitive", "JSON", "", "json"},
+		{"invalid env falls back to config", "xml", "yaml", "yaml"},
+		{"invalid env falls back to table", "xml", "", "table"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv(EnvOutputFormat, tt.env)
+
+			cfg := config.NewDefaultConfig()
+			cfg.Defaults.OutputFormat = tt.config
+
+			if got := ResolveDefaultOutputFormat(cfg); got != tt.want {
+				t.Errorf("ResolveDefaultOutputFormat() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestResolveDefaultOutputFormat_NilConfig(t *testing.T) {
+	t.Setenv(EnvOutputFormat, "")
+
+	if got := ResolveDefaultOutputFormat(nil); got != "table" {
+		t.Errorf("ResolveDefaultOutputFormat(nil) = %q, want %q", got, "table")
+	}
+}
+
+func TestOutputFormatVars(t *testing.T) {
+	tests := []struct {
+		format string
+		want   map[string]string
+	}{
+		{"table", map[string]string{
+			"output_format": "table", "text_output_format": "text",
+			"diff_output_format": "diff",
+		}},
+		{"json", map[string]string{
+			"output_format": "json", "text_output_format": "json",
+			"diff_output_format": "json",
+		}},
+		{"yaml", map[string]string{
+			"output_format": "yaml", "text_output_format": "yaml",
+			"diff_output_format": "yaml",
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			got := OutputFormatVars(tt.format)
+			for k, want := range tt.want {
+				if got[k] != want {
+					t.Errorf("OutputFormatVars(%q)[%q] = %q, want %q", tt.format, k, got[k], want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,97 @@
+package shared
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/carlosarraes/bt/pkg/output"
+	"github.com/carlosarraes/bt/pkg/utils"
+)
+
+// DefaultPager is used when neither BT_PAGER nor PAGER is set. -F makes less
+// exit straight away when the output fits on one screen.
+const DefaultPager = "less -FRX"
+
+// pagedStdout is the real stdout while a pager is running
+var pagedStdout *os.File
+
+func GetNoPager(ctx context.Context) bool {
+	if v := ctx.Value("no-pager"); v != nil {
+		return v.(bool)
+	}
+	return false
+}
+
+// PagerCommand returns the pager to run, or "" when paging is turned off by
+// setting BT_PAGER or PAGER to "cat" or an empty value
+func PagerCommand() string {
+	for _, name := range []string{"BT_PAGER", "PAGER"} {
+		if value, ok := os.LookupEnv(name); ok {
+			value = strings.TrimSpace(value)
+			if value == "cat" {
+				return ""
+			}
+			return value
+		}
+	}
+	return DefaultPager
+}
+
+// StartPager routes stdout through the pager when stdout is a terminal and
+// --no-pager wasn't given. The returned function restores stdout and waits
+// for the pager to exit; callers defer it. If the pager can't be started the
+// output is written directly.
+func StartPager(ctx context.Context) func() {
+	noop := func() {}
+	if GetNoPager(ctx) || pagedStdout != nil || !utils.IsTerminal(os.Stdout) {
+		return noop
+	}
+
+	args := strings.Fields(PagerCommand())
+	if len(args) == 0 {
+		return noop
+	}
+
+	r, w, err := os.Pipe()
+	if err != nil {
+		return noop
+	}
+
+	pager := exec.Command(args[0], args[1:]...)
+	pager.Stdin = r
+	pager.Stdout = os.Stdout
+	pager.Stderr = os.Stderr
+	if _, ok := os.LookupEnv("LESS"); !ok {
+		pager.Env = append(os.Environ(), "LESS=FRX")
+	}
+
+	if err := pager.Start(); err != nil {
+		r.Close()
+		w.Close()
+		return noop
+	}
+	r.Close()
+
+	pagedStdout = os.Stdout
+	os.Stdout = w
+	output.SetPagedTerminal(pagedStdout)
+
+	return func() {
+		os.Stdout = pagedStdout
+		pagedStdout = nil
+		output.SetPagedTerminal(nil)
+		w.Close()
+		_ = pager.Wait()
+	}
+}
+
+// StdoutIsTerminal reports whether output ends up on a terminal, including
+// while it is routed through a pager
+func StdoutIsTerminal() bool {
+	if pagedStdout != nil {
+		return true
+	}
+	return utils.IsTerminal(os.Stdout)
+}
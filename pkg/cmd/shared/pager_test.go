@@ -0,0 +1,69 @@
+package shared
+
+import (
+	"context"
+	"os"
+	"testing"
+)
+
+// unsetEnv removes name for the duration of the test
+func unsetEnv(t *testing.T, name string) {
+	t.Helper()
+	t.Setenv(name, "")
+	os.Unsetenv(name)
+}
+
+func TestPagerCommand(t *testing.T) {
+	tests := []struct {
+		name    string
+		btPager *string
+		pager   *string
+		want    string
+	}{
+		{"default", nil, nil, DefaultPager},
+		{"PAGER", nil, strPtr("more"), "more"},
+		{"BT_PAGER wins", strPtr("less -S"), strPtr("more"), "less -S"},
+		{"cat disables", strPtr("cat"), nil, ""},
+		{"empty disables", nil, strPtr(""), ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			unsetEnv(t, "BT_PAGER")
+			unsetEnv(t, "PAGER")
+			if tt.btPager != nil {
+				t.Setenv("BT_PAGER", *tt.btPager)
+			}
+			if tt.pager != nil {
+				t.Setenv("PAGER", *tt.pager)
+			}
+
+			if got := PagerCommand(); got != tt.want {
+				t.Errorf("PagerCommand() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStartPager_NotTerminal(t *testing.T) {
+	stdout := os.Stdout
+
+	for _, ctx := range []context.Context{
+		context.Background(),
+		context.WithValue(context.Background(), "no-pager", true),
+	} {
+		stop := StartPager(ctx)
+		if os.Stdout != stdout {
+			t.Error("StartPager() replaced stdout although it isn't a terminal")
+		}
+		stop()
+	}
+
+	if StdoutIsTerminal() {
+		t.Error("StdoutIsTerminal() = true under go test")
+	}
+}
+
+func strPtr(s string) *string {
+	return &s
+}
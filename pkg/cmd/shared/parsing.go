@@ -3,6 +3,9 @@ package shared
 import (
 	"encoding/json"
 	"fmt"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/carlosarraes/bt/pkg/api"
 )
@@ -28,3 +31,34 @@ func ParsePaginatedResults[T any](result *api.PaginatedResponse) ([]*T, error) {
 
 	return items, nil
 }
+
+// ParseAge parses a period such as "14d", "2w" or any time.ParseDuration
+// value ("36h"). Days and weeks are whole 24-hour days.
+func ParseAge(value string) (time.Duration, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0, fmt.Errorf("duration is empty")
+	}
+
+	var unit time.Duration
+	switch value[len(value)-1] {
+	case 'd':
+		unit = 24 * time.Hour
+	case 'w':
+		unit = 7 * 24 * time.Hour
+	}
+
+	if unit != 0 {
+		n, err := strconv.Atoi(value[:len(value)-1])
+		if err != nil || n <= 0 {
+			return 0, fmt.Errorf("invalid duration %q: expected a positive number like 14d or 2w", value)
+		}
+		return time.Duration(n) * unit, nil
+	}
+
+	d, err := time.ParseDuration(value)
+	if err != nil || d <= 0 {
+		return 0, fmt.Errorf("invalid duration %q: use a value like 14d, 2w or 36h", value)
+	}
+	return d, nil
+}
@@ -0,0 +1,31 @@
+package shared
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseAge(t *testing.T) {
+	tests := []struct {
+		value string
+		want  time.Duration
+	}{
+		{"14d", 14 * 24 * time.Hour},
+		{"2w", 14 * 24 * time.Hour},
+		{"36h", 36 * time.Hour},
+		{" 1d ", 24 * time.Hour},
+	}
+	for _, tt := range tests {
+		got, err := ParseAge(tt.value)
+		require.NoError(t, err, tt.value)
+		assert.Equal(t, tt.want, got, tt.value)
+	}
+
+	for _, value := range []string{"", "d", "0d", "-3d", "xd", "abc", "-1h"} {
+		_, err := ParseAge(value)
+		assert.Error(t, err, value)
+	}
+}
@@ -0,0 +1,126 @@
+package shared
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/carlosarraes/bt/pkg/api"
+	"github.com/carlosarraes/bt/pkg/utils"
+)
+
+// ResolvePipelineUUID turns a pipeline ID, a UUID or a build number with or
+// without a leading #, into the pipeline's UUID. Build numbers are looked up
+// among the 500 most recent pipelines.
+func ResolvePipelineUUID(ctx context.Context, cmdCtx *CommandContext, pipelineID string) (string, error) {
+	pipelineID = strings.TrimSpace(pipelineID)
+
+	if strings.Contains(pipelineID, "-") {
+		return pipelineID, nil
+	}
+
+	if strings.HasPrefix(pipelineID, "#") {
+		pipelineID = pipelineID[1:]
+	}
+
+	buildNumber, err := strconv.Atoi(pipelineID)
+	if err != nil {
+		return "", fmt.Errorf("invalid pipeline ID '%s'. Expected build number (e.g., 123, #123) or UUID", pipelineID)
+	}
+
+	options := &api.PipelineListOptions{
+		PageLen: 100,
+		Page:    1,
+		Sort:    "-created_on",
+	}
+
+	latest, searched, exhausted := 0, 0, false
+	for options.Page <= 5 {
+		result, err := cmdCtx.Client.Pipelines.ListPipelines(ctx, cmdCtx.Workspace, cmdCtx.Repository, options)
+		if err != nil {
+			return "", HandleAPIError(err, DomainPipeline)
+		}
+
+		pipelines, err := ParsePaginatedResults[api.Pipeline](result)
+		if err != nil {
+			return "", fmt.Errorf("failed to parse pipeline results: %w", err)
+		}
+		if options.Page == 1 && len(pipelines) == 0 {
+			return "", errors.New(NoPipelinesMessage(ctx, cmdCtx))
+		}
+
+		for _, pipeline := range pipelines {
+			if pipeline.BuildNumber == buildNumber {
+				return pipeline.UUID, nil
+			}
+			latest = max(latest, pipeline.BuildNumber)
+		}
+		searched += len(pipelines)
+
+		if result.Next == "" {
+			exhausted = true
+			break
+		}
+		options.Page++
+	}
+
+	return "", buildNumberNotFoundError(cmdCtx, buildNumber, latest, searched, exhausted)
+}
+
+// NoPipelinesMessage explains why a repository has no pipelines at all:
+// Pipelines are turned off for it, or nothing has run yet. When the
+// configuration can't be read, Pipelines are assumed to be enabled.
+func NoPipelinesMessage(ctx context.Context, cmdCtx *CommandContext) string {
+	repo := cmdCtx.Workspace + "/" + cmdCtx.Repository
+
+	config, err := cmdCtx.Client.Pipelines.GetPipelinesConfig(ctx, cmdCtx.Workspace, cmdCtx.Repository)
+	if err == nil && !config.Enabled {
+		return fmt.Sprintf("pipelines are not enabled for %s; enable them under Repository settings > Pipelines > Settings", repo)
+	}
+	return fmt.Sprintf("%s has no pipelines yet; they start once a bitbucket-pipelines.yml is pushed", repo)
+}
+
+// buildNumberNotFoundError reports a build number missing from the
+// searched pipelines, latest being the newest of them and searched their
+// count. Numbers past the latest build haven't run yet; older ones may
+// have been deleted or fall outside the search.
+func buildNumberNotFoundError(cmdCtx *CommandContext, buildNumber, latest, searched int, exhausted bool) error {
+	repo := cmdCtx.Workspace + "/" + cmdCtx.Repository
+	switch {
+	case buildNumber > latest:
+		return fmt.Errorf("pipeline #%d not found: the latest pipeline in %s is #%d", buildNumber, repo, latest)
+	case exhausted:
+		return fmt.Errorf("pipeline #%d not found in %s; it may have been deleted", buildNumber, repo)
+	default:
+		return fmt.Errorf("pipeline #%d not found among the %d most recent pipelines in %s; pass its UUID instead", buildNumber, searched, repo)
+	}
+}
+
+// NewLogRedactor returns the Redactor pipeline logs are shown through. On
+// top of the built-in secret patterns it masks run.redact_patterns and
+// assignments to the repository's secured variables. Bitbucket never
+// returns secured values and listing variables needs admin access, so when
+// the list can't be read only the patterns apply.
+func NewLogRedactor(ctx context.Context, cmdCtx *CommandContext) (*utils.Redactor, error) {
+	var patterns []string
+	if cmdCtx.Config != nil {
+		patterns = cmdCtx.Config.Run.RedactPatterns
+	}
+
+	var names, values []string
+	if variables, err := cmdCtx.Client.Variables.ListVariables(ctx, cmdCtx.Workspace, cmdCtx.Repository, ""); err == nil {
+		for _, variable := range variables {
+			if !variable.Secured {
+				continue
+			}
+			names = append(names, variable.Key)
+			if variable.Value != "" {
+				values = append(values, variable.Value)
+			}
+		}
+	}
+
+	return utils.NewRedactor(patterns, names, values)
+}
@@ -0,0 +1,40 @@
+package shared
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/carlosarraes/bt/pkg/api"
+)
+
+// FormatRateLimit sums up a quota, e.g. "42 of 1000 requests left, resets
+// in 12m0s"
+func FormatRateLimit(limit api.RateLimit, now time.Time) string {
+	var quota string
+	switch {
+	case limit.Remaining != nil && limit.Limit > 0:
+		quota = fmt.Sprintf("%d of %d requests left", *limit.Remaining, limit.Limit)
+	case limit.Remaining != nil:
+		quota = fmt.Sprintf("%d requests left", *limit.Remaining)
+	case limit.Limit > 0:
+		quota = fmt.Sprintf("limit of %d requests", limit.Limit)
+	default:
+		quota = "quota not reported"
+	}
+
+	if limit.Reset != nil {
+		wait := limit.Reset.Sub(now).Round(time.Second)
+		if wait < 0 {
+			wait = 0
+		}
+		quota += fmt.Sprintf(", resets in %s", wait)
+	}
+	return quota
+}
+
+// warnRateLimitLow is the API clients' OnRateLimitLow hook: commands fanning
+// out requests warn once before Bitbucket starts throttling them
+func warnRateLimitLow(limit api.RateLimit) {
+	fmt.Fprintf(os.Stderr, "Warning: Bitbucket API rate limit is nearly used up (%s); requests may be throttled. Run 'bt api rate-limit' for details.\n", FormatRateLimit(limit, time.Now()))
+}
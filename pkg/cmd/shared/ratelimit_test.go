@@ -0,0 +1,22 @@
+package shared
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/carlosarraes/bt/pkg/api"
+)
+
+func TestFormatRateLimit(t *testing.T) {
+	now := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	reset := now.Add(12 * time.Minute)
+	remaining := 42
+
+	assert.Equal(t, "42 of 1000 requests left, resets in 12m0s",
+		FormatRateLimit(api.RateLimit{Limit: 1000, Remaining: &remaining, Reset: &reset}, now))
+	assert.Equal(t, "42 requests left", FormatRateLimit(api.RateLimit{Remaining: &remaining}, now))
+	assert.Equal(t, "limit of 1000 requests", FormatRateLimit(api.RateLimit{Limit: 1000}, now))
+	assert.Equal(t, "quota not reported, resets in 0s", FormatRateLimit(api.RateLimit{Reset: &now}, reset))
+}
@@ -7,6 +7,7 @@ import (
 	"strconv"
 	"strings"
 
+	"github.com/carlosarraes/bt/pkg/output"
 	"github.com/carlosarraes/bt/pkg/sonarcloud"
 )
 
@@ -195,13 +196,9 @@ func (f *ReportFormatter) FormatQualityGateHeader(report *sonarcloud.Report) {
 // FormatCoverageSection prints the coverage summary table, uncovered line details, and goals.
 func (f *ReportFormatter) FormatCoverageSection(coverage *sonarcloud.CoverageData, filters sonarcloud.FilterOptions) {
 	fmt.Printf("📊 Coverage Summary:\n")
-	fmt.Printf("┌─────────────────────────────────────────┬──────────┬─────────────────┬──────────────┐\n")
-	fmt.Printf("│ File                                    │ Coverage │ Uncovered Lines │ New Coverage │\n")
-	fmt.Printf("├─────────────────────────────────────────┼──────────┼─────────────────┼──────────────┤\n")
-
-	displayedFiles := 0
+	var rows [][]string
 	for _, file := range coverage.Files {
-		if displayedFiles >= filters.Limit {
+		if len(rows) >= filters.Limit {
 			break
 		}
 
@@ -209,20 +206,27 @@ func (f *ReportFormatter) FormatCoverageSection(coverage *sonarcloud.CoverageDat
 			continue
 		}
 
-		fileName := Truncate(file.Name, 39)
-
 		newCovStr := "-"
 		if file.NewUncoveredLines > 0 || file.NewCoverage > 0 {
 			newCovStr = fmt.Sprintf("%.1f%% (%d/%d)",
 				file.NewCoverage, file.NewUncoveredLines, file.NewUncoveredLines)
 		}
 
-		fmt.Printf("│ %-39s │ %7.1f%% │ %15d │ %12s │\n",
-			fileName, file.Coverage, file.UncoveredLines, newCovStr)
-		displayedFiles++
+		rows = append(rows, []string{
+			file.Name,
+			fmt.Sprintf("%.1f%%", file.Coverage),
+			strconv.Itoa(file.UncoveredLines),
+			newCovStr,
+		})
 	}
 
-	fmt.Printf("└─────────────────────────────────────────┴──────────┴─────────────────┴──────────────┘\n\n")
+	output.RenderBoxTable(os.Stdout, []output.BoxColumn{
+		{Header: "File"},
+		{Header: "Coverage", Right: true},
+		{Header: "Uncovered Lines", Right: true},
+		{Header: "New Coverage", Right: true},
+	}, rows)
+	fmt.Println()
 
 	if len(coverage.CoverageDetails) > 0 {
 		f.DisplayUncoveredLinesDetails(coverage.CoverageDetails, filters)
@@ -416,41 +420,34 @@ func (f *ReportFormatter) FormatIssuesSection(issues *sonarcloud.IssuesData, met
 
 	if len(issues.Issues) > 0 {
 		fmt.Printf("🔥 Issues:\n")
-		fmt.Printf("┌────────────────────┬─────────────────────────────────────────┬──────┬──────────┬──────────────────────────────────────────┐\n")
-		fmt.Printf("│ Impact             │ File                                    │ Line │ Effort   │ Description                              │\n")
-		fmt.Printf("├────────────────────┼─────────────────────────────────────────┼──────┼──────────┼──────────────────────────────────────────┤\n")
-
-		displayedIssues := 0
+		var rows [][]string
 		for _, issue := range issues.Issues {
-			if displayedIssues >= filters.Limit {
+			if len(rows) >= filters.Limit {
 				break
 			}
 
-			fileName := Truncate(issue.File, 39)
-
 			lineStr := "-"
 			if issue.Line != nil {
 				lineStr = strconv.Itoa(*issue.Line)
 			}
 
-			description := Truncate(issue.Message, 40)
-
 			effort := issue.Effort
 			if effort == "" {
 				effort = "-"
 			}
-			if len(effort) > 8 {
-				effort = effort[:8]
-			}
-
-			impact := FormatImpact(issue.Impacts)
+			effort = output.Truncate(effort, 8)
 
-			fmt.Printf("│ %-18s │ %-39s │ %4s │ %-8s │ %-40s │\n",
-				impact, fileName, lineStr, effort, description)
-			displayedIssues++
+			rows = append(rows, []string{FormatImpact(issue.Impacts), issue.File, lineStr, effort, issue.Message})
 		}
 
-		fmt.Printf("└────────────────────┴─────────────────────────────────────────┴──────┴──────────┴──────────────────────────────────────────┘\n\n")
+		output.RenderBoxTable(os.Stdout, []output.BoxColumn{
+			{Header: "Impact"},
+			{Header: "File"},
+			{Header: "Line", Right: true},
+			{Header: "Effort"},
+			{Header: "Description"},
+		}, rows)
+		fmt.Println()
 	}
 
 	if metrics != nil {
@@ -473,22 +470,26 @@ func (f *ReportFormatter) FormatDuplicationsSection(duplications *sonarcloud.Dup
 
 	if len(duplications.Files) > 0 {
 		fmt.Printf("📁 Files with Duplications:\n")
-		fmt.Printf("┌─────────────────────────────────────────┬──────────────┬─────────┬────────┐\n")
-		fmt.Printf("│ File                                    │ Duplication  │ Lines   │ Blocks │\n")
-		fmt.Printf("├─────────────────────────────────────────┼──────────────┼─────────┼────────┤\n")
-
-		displayed := 0
+		var rows [][]string
 		for _, file := range duplications.Files {
-			if displayed >= filters.Limit {
+			if len(rows) >= filters.Limit {
 				break
 			}
-			fileName := Truncate(file.Name, 39)
-			fmt.Printf("│ %-39s │ %11.1f%% │ %7d │ %6d │\n",
-				fileName, file.DuplicatedDensity, file.DuplicatedLines, file.DuplicatedBlocks)
-			displayed++
-		}
-
-		fmt.Printf("└─────────────────────────────────────────┴──────────────┴─────────┴────────┘\n\n")
+			rows = append(rows, []string{
+				file.Name,
+				fmt.Sprintf("%.1f%%", file.DuplicatedDensity),
+				strconv.Itoa(file.DuplicatedLines),
+				strconv.Itoa(file.DuplicatedBlocks),
+			})
+		}
+
+		output.RenderBoxTable(os.Stdout, []output.BoxColumn{
+			{Header: "File"},
+			{Header: "Duplication", Right: true},
+			{Header: "Lines", Right: true},
+			{Header: "Blocks", Right: true},
+		}, rows)
+		fmt.Println()
 	}
 
 	if len(duplications.Details) > 0 {
@@ -0,0 +1,92 @@
+package shared
+
+import (
+	"os"
+
+	"github.com/carlosarraes/bt/pkg/config"
+	"github.com/carlosarraes/bt/pkg/git"
+)
+
+// Environment variables that select the target workspace and repository.
+const (
+	EnvWorkspace  = "BITBUCKET_WORKSPACE"
+	EnvRepository = "BITBUCKET_REPOSITORY"
+)
+
+// ValueSource describes where a resolved setting came from.
+type ValueSource string
+
+const (
+	SourceFlag      ValueSource = "flag"
+	SourceEnv       ValueSource = "environment"
+	SourceGitRemote ValueSource = "git remote"
+	SourceConfig    ValueSource = "config"
+	SourceUnset     ValueSource = "unset"
+)
+
+// ResolvedValue is an effective setting together with its origin.
+type ResolvedValue struct {
+	Value  string      `json:"value" yaml:"value"`
+	Source ValueSource `json:"source" yaml:"source"`
+}
+
+// RepoResolver resolves the target workspace and repository.
+//
+// Precedence, highest first:
+//  1. --workspace / --repository flags
+//  2. BITBUCKET_WORKSPACE / BITBUCKET_REPOSITORY
+//  3. the Bitbucket remote of the current git repository
+//  4. auth.default_workspace from the config (workspace only)
+type RepoResolver struct {
+	GitWorkspace     string
+	GitRepository    string
+	DefaultWorkspace string
+}
+
+// NewRepoResolver builds a resolver from the config and, when one is passed,
+// the git repository of the working directory.
+func NewRepoResolver(cfg *config.Config, gitRepo *git.Repository) *RepoResolver {
+	r := &RepoResolver{}
+	if cfg != nil {
+		r.DefaultWorkspace = cfg.Auth.DefaultWorkspace
+	}
+	if gitRepo != nil {
+		r.GitWorkspace = gitRepo.GetWorkspace()
+		r.GitRepository = gitRepo.GetName()
+	}
+	return r
+}
+
+// Workspace returns the effective workspace for the given flag value.
+func (r *RepoResolver) Workspace(flag string) ResolvedValue {
+	return firstSet(r.WorkspaceSources(flag)...)
+}
+
+// WorkspaceSources returns the workspace every source gives, highest
+// precedence first, including the ones that are unset.
+func (r *RepoResolver) WorkspaceSources(flag string) []ResolvedValue {
+	return []ResolvedValue{
+		{flag, SourceFlag},
+		{os.Getenv(EnvWorkspace), SourceEnv},
+		{r.GitWorkspace, SourceGitRemote},
+		{r.DefaultWorkspace, SourceConfig},
+	}
+}
+
+// Repository returns the effective repository for the given flag value.
+func (r *RepoResolver) Repository(flag string) ResolvedValue {
+	return firstSet(
+		ResolvedValue{flag, SourceFlag},
+		ResolvedValue{os.Getenv(EnvRepository), SourceEnv},
+		ResolvedValue{r.GitRepository, SourceGitRemote},
+	)
+}
+
+func firstSet(candidates ...ResolvedValue) ResolvedValue {
+	for _, c := range candidates {
+		if c.Value != "" {
+			return c
+		}
+	}
+	return ResolvedValue{Source: SourceUnset}
+}
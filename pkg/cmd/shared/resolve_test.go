@@ -0,0 +1,107 @@
+package shared
+
+import "testing"
+
+func TestRepoResolver_Workspace(t *testing.T) {
+	tests := []struct {
+		name       string
+		flag       string
+		env        string
+		git        string
+		config     string
+		wantValue  string
+		wantSource ValueSource
+	}{
+		{"flag wins", "flag-ws", "env-ws", "git-ws", "cfg-ws", "flag-ws", SourceFlag},
+		{"env beats git", "", "env-ws", "git-ws", "cfg-ws", "env-ws", SourceEnv},
+		{"git beats config", "", "", "git-ws", "cfg-ws", "git-ws", SourceGitRemote},
+		{"config fallback", "", "", "", "cfg-ws", "cfg-ws", SourceConfig},
+		{"nothing set", "", "", "", "", "", SourceUnset},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv(EnvWorkspace, tt.env)
+
+			r := &RepoResolver{GitWorkspace: tt.git, DefaultWorkspace: tt.config}
+			got := r.Workspace(tt.flag)
+			if got.Value != tt.wantValue || got.Source != tt.wantSource {
+				t.Errorf("Workspace(%q) = %+v, want {%s %s}", tt.flag, got, tt.wantValue, tt.wantSource)
+			}
+		})
+	}
+}
+
+func TestRepoResolver_Repository(t *testing.T) {
+	tests := []struct {
+		name       string
+		flag       string
+		env        string
+		git        string
+		wantValue  string
+		wantSource ValueSource
+	}{
+		{"flag wins", "flag-repo", "env-repo", "git-repo", "flag-repo", SourceFlag},
+		{"env beats git", "", "env-repo", "git-repo", "env-repo", SourceEnv},
+		{"git fallback", "", "", "git-repo", "git-repo", SourceGitRemote},
+		{"nothing set", "", "", "", "", SourceUnset},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv(EnvRepository, tt.env)
+
+			r := &RepoResolver{GitRepository: tt.git}
+			got := r.Repository(tt.flag)
+			if got.Value != tt.wantValue || got.Source != tt.wantSource {
+				t.Errorf("Repository(%q) = %+v, want {%s %s}", tt.flag, got, tt.wantValue, tt.wantSource)
+			}
+		})
+	}
+}
+
+func TestRepoResolver_WorkspaceSources(t *testing.T) {
+	t.Setenv(EnvWorkspace, "")
+
+	r := &RepoResolver{GitWorkspace: "git-ws", DefaultWorkspace: "cfg-ws"}
+	got := r.WorkspaceSources("")
+	want := []ResolvedValue{
+		{"", SourceFlag},
+		{"", SourceEnv},
+		{"git-ws", SourceGitRemote},
+		{"cfg-ws", SourceConfig},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("WorkspaceSources() = %+v, want %+v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("WorkspaceSources()[%d] = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestCommandContext_ResolveRepository(t *testing.T) {
+	t.Setenv(EnvWorkspace, "")
+	t.Setenv(EnvRepository, "env-repo")
+
+	c := &CommandContext{resolver: &RepoResolver{GitWorkspace: "git-ws", GitRepository: "git-repo", DefaultWorkspace: "cfg-ws"}}
+	if err := c.ResolveRepository("flag-ws", ""); err != nil {
+		t.Fatalf("ResolveRepository() error = %v", err)
+	}
+	if c.Workspace != "flag-ws" || c.Repository != "env-repo" {
+		t.Errorf("ResolveRepository() = %s/%s, want flag-ws/env-repo", c.Workspace, c.Repository)
+	}
+
+	// Resolving again without flags goes back to the other sources
+	c.ApplyRepoFlags("", "")
+	if c.Workspace != "git-ws" || c.Repository != "env-repo" {
+		t.Errorf("ApplyRepoFlags() = %s/%s, want git-ws/env-repo", c.Workspace, c.Repository)
+	}
+
+	t.Setenv(EnvRepository, "")
+	empty := &CommandContext{resolver: &RepoResolver{}}
+	if err := empty.ResolveRepository("", ""); err == nil {
+		t.Error("ResolveRepository() without a workspace or repository should fail")
+	}
+}
@@ -0,0 +1,29 @@
+package shared
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/carlosarraes/bt/pkg/ui"
+	"github.com/carlosarraes/bt/pkg/utils"
+)
+
+// CanSelect reports whether an omitted argument can be picked interactively:
+// both ends are terminals and the output is for humans
+func CanSelect(output string) bool {
+	if output == "json" || output == "yaml" {
+		return false
+	}
+	return StdoutIsTerminal() && utils.IsTerminal(os.Stdin)
+}
+
+// Select lets the user pick one of labels, drawing the list on stderr so
+// stdout stays clean. Leaving the selector fails with "no <item> selected".
+func Select(prompt, item string, labels []string) (int, error) {
+	index, err := ui.NewSelector(os.Stdin, os.Stderr).Select(prompt, labels)
+	if errors.Is(err, ui.ErrCanceled) {
+		return 0, fmt.Errorf("no %s selected", item)
+	}
+	return index, err
+}
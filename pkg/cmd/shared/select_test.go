@@ -0,0 +1,13 @@
+package shared
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCanSelect_NonInteractive(t *testing.T) {
+	for _, output := range []string{"table", "text", "json", "yaml"} {
+		assert.False(t, CanSelect(output), output)
+	}
+}
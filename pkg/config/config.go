@@ -2,6 +2,8 @@ package config
 
 import (
 	"fmt"
+	"net/url"
+	"regexp"
 	"strings"
 	"time"
 
@@ -17,6 +19,35 @@ type Config struct {
 	PR       PRConfig      `koanf:"pr" yaml:"pr"`
 	LLM      LLMConfig     `koanf:"llm" yaml:"llm"`
 	Pick     PickConfig    `koanf:"pick" yaml:"pick"`
+	UI       UIConfig      `koanf:"ui" yaml:"ui"`
+	Jira     JiraConfig    `koanf:"jira" yaml:"jira"`
+	Run      RunConfig     `koanf:"run" yaml:"run"`
+	// Aliases maps alias names to the bt command line they stand for, as
+	// set by bt alias set
+	Aliases map[string]string `koanf:"aliases" yaml:"aliases"`
+	// Repos holds settings of single repositories, keyed by repository
+	// slug, as in repo.<name>.base
+	Repos map[string]RepoSettings `koanf:"repo" yaml:"repo"`
+}
+
+// RepoSettings are the settings of one repository
+type RepoSettings struct {
+	// Base is the branch pull requests of the repository target when no
+	// base is given, ahead of pr.base
+	Base string `koanf:"base" yaml:"base"`
+}
+
+// ConfiguredBase returns the base branch configured for repository, from
+// repo.<name>.base or else pr.base, with the key it came from. It returns
+// empty strings when neither is set.
+func (c *Config) ConfiguredBase(repository string) (string, string) {
+	if settings, ok := c.Repos[repository]; ok && settings.Base != "" {
+		return settings.Base, "repo." + repository + ".base"
+	}
+	if c.PR.Base != "" {
+		return c.PR.Base, "pr.base"
+	}
+	return "", ""
 }
 
 // AuthConfig holds authentication-related configuration
@@ -27,8 +58,10 @@ type AuthConfig struct {
 
 // APIConfig holds API-related configuration
 type APIConfig struct {
-	BaseURL string        `koanf:"base_url" yaml:"base_url"`
-	Timeout time.Duration `koanf:"timeout" yaml:"timeout"`
+	BaseURL             string        `koanf:"base_url" yaml:"base_url"`
+	Timeout             time.Duration `koanf:"timeout" yaml:"timeout"`
+	MaxIdleConnsPerHost int           `koanf:"max_idle_conns_per_host" yaml:"max_idle_conns_per_host"`
+	IdleConnTimeout     time.Duration `koanf:"idle_conn_timeout" yaml:"idle_conn_timeout"`
 }
 
 // DefaultConfig holds default preferences
@@ -37,7 +70,77 @@ type DefaultConfig struct {
 }
 
 type PRConfig struct {
+	// Base is the branch pr create targets when --base is omitted and no
+	// branch suffix matches; empty uses the repository's default branch
+	Base                string            `koanf:"base" yaml:"base"`
 	BranchSuffixMapping map[string]string `koanf:"branch_suffix_mapping" yaml:"branch_suffix_mapping"`
+	Checklist           ChecklistConfig   `koanf:"checklist" yaml:"checklist"`
+	CommitLint          CommitLintConfig  `koanf:"commit_lint" yaml:"commit_lint"`
+	SizeThresholds      SizeThresholds    `koanf:"size_thresholds" yaml:"size_thresholds"`
+	// MaxSize is the number of changed lines above which pr create warns
+	// that a pull request is too big; 0 disables the warning
+	MaxSize int `koanf:"max_size" yaml:"max_size"`
+	// CreateAsDraft makes pr create open drafts unless --no-draft is given
+	CreateAsDraft bool `koanf:"create_as_draft" yaml:"create_as_draft"`
+}
+
+// SizeThresholds are the most changed lines (added plus removed) a pull
+// request can have and still be sized XS, S, M or L; anything bigger is XL
+type SizeThresholds struct {
+	XS int `koanf:"xs" yaml:"xs"`
+	S  int `koanf:"s" yaml:"s"`
+	M  int `koanf:"m" yaml:"m"`
+	L  int `koanf:"l" yaml:"l"`
+}
+
+// ChecklistConfig lists what pr create --require-checklist expects in a
+// pull request description: a section heading and/or checked items.
+type ChecklistConfig struct {
+	Section string   `koanf:"section" yaml:"section"`
+	Items   []string `koanf:"items" yaml:"items"`
+}
+
+// CommitLintConfig sets the commit message rules pr create checks with
+// --lint-commits, or on every create when Enabled is set. Subjects must
+// follow conventional commits unless Pattern gives a regex instead.
+type CommitLintConfig struct {
+	Enabled          bool     `koanf:"enabled" yaml:"enabled"`
+	Mode             string   `koanf:"mode" yaml:"mode"`
+	Pattern          string   `koanf:"pattern" yaml:"pattern"`
+	Types            []string `koanf:"types" yaml:"types"`
+	MaxSubjectLength int      `koanf:"max_subject_length" yaml:"max_subject_length"`
+}
+
+// CommitLint modes: warn reports violations and creates the pull request
+// anyway, block refuses to create it
+const (
+	CommitLintModeWarn  = "warn"
+	CommitLintModeBlock = "block"
+)
+
+// UIConfig holds terminal display settings
+type UIConfig struct {
+	// MaxWidth is the width tables are fitted to instead of the detected
+	// terminal width; 0 detects it
+	MaxWidth int `koanf:"max_width" yaml:"max_width"`
+}
+
+// JiraConfig holds the JIRA instance pr create --jira fetches tickets from
+type JiraConfig struct {
+	// BaseURL is the JIRA site, such as https://yourcompany.atlassian.net;
+	// the token is read from JIRA_API_TOKEN
+	BaseURL string `koanf:"base_url" yaml:"base_url"`
+}
+
+// RunConfig holds pipeline settings for the run commands
+type RunConfig struct {
+	// RedactPatterns are regular expressions masked in pipeline logs on top
+	// of the built-in secret patterns; with a capture group only the group
+	// is masked
+	RedactPatterns []string `koanf:"redact_patterns" yaml:"redact_patterns"`
+	// StepAliases maps short names to step name patterns for --step, as in
+	// ut: "Build and Run Unit Tests*"
+	StepAliases map[string]string `koanf:"step_aliases" yaml:"step_aliases"`
 }
 
 type LLMConfig struct {
@@ -93,8 +196,10 @@ func NewDefaultConfig() *Config {
 			DefaultWorkspace: "",
 		},
 		API: APIConfig{
-			BaseURL: "https://api.bitbucket.org/2.0",
-			Timeout: 30 * time.Second,
+			BaseURL:             "https://api.bitbucket.org/2.0",
+			Timeout:             30 * time.Second,
+			MaxIdleConnsPerHost: 16,
+			IdleConnTimeout:     90 * time.Second,
 		},
 		Defaults: DefaultConfig{
 			OutputFormat: "table",
@@ -104,6 +209,7 @@ func NewDefaultConfig() *Config {
 				"hml": "homolog",
 				"prd": "main",
 			},
+			SizeThresholds: DefaultSizeThresholds(),
 		},
 		LLM: LLMConfig{
 			Model: "gpt-5.4-mini",
@@ -136,12 +242,42 @@ func (c *Config) Validate() error {
 		return ErrInvalidTimeout
 	}
 
+	if c.API.MaxIdleConnsPerHost < 0 || c.API.IdleConnTimeout < 0 {
+		return ErrInvalidConnPool
+	}
+
+	if c.UI.MaxWidth < 0 {
+		return ErrInvalidMaxWidth
+	}
+
+	if c.Jira.BaseURL != "" && !isValidJiraBaseURL(c.Jira.BaseURL) {
+		return ErrInvalidJiraBaseURL
+	}
+
+	for _, pattern := range c.Run.RedactPatterns {
+		if _, err := regexp.Compile(pattern); err != nil {
+			return fmt.Errorf("%w: %v", ErrInvalidRedactPattern, err)
+		}
+	}
+
 	if c.Defaults.OutputFormat != "" {
 		if !isValidOutputFormat(c.Defaults.OutputFormat) {
 			return ErrInvalidOutputFormat
 		}
 	}
 
+	if err := c.PR.CommitLint.validate(); err != nil {
+		return err
+	}
+
+	if !c.PR.SizeThresholds.valid() {
+		return ErrInvalidSizeThresholds
+	}
+
+	if c.PR.MaxSize < 0 {
+		return ErrInvalidMaxSize
+	}
+
 	if len(c.Pick.Prefix) == 0 {
 		return ErrEmptyPickPrefix
 	}
@@ -160,6 +296,37 @@ func (c *Config) Validate() error {
 	return nil
 }
 
+func (c *CommitLintConfig) validate() error {
+	switch c.Mode {
+	case "", CommitLintModeWarn, CommitLintModeBlock:
+	default:
+		return ErrInvalidCommitLintMode
+	}
+
+	if c.Pattern != "" {
+		if _, err := regexp.Compile(c.Pattern); err != nil {
+			return fmt.Errorf("%w: %v", ErrInvalidCommitLintPattern, err)
+		}
+	}
+
+	if c.MaxSubjectLength < 0 {
+		return ErrInvalidCommitLintLength
+	}
+
+	return nil
+}
+
+// DefaultSizeThresholds returns the pull request size thresholds used
+// unless pr.size_thresholds overrides them
+func DefaultSizeThresholds() SizeThresholds {
+	return SizeThresholds{XS: 10, S: 100, M: 500, L: 1000}
+}
+
+// valid reports whether the thresholds are positive and increasing
+func (t SizeThresholds) valid() bool {
+	return t.XS > 0 && t.XS < t.S && t.S < t.M && t.M < t.L
+}
+
 // AuthMethod constants
 const (
 	AuthMethodAppPassword = "app_password"
@@ -184,6 +351,13 @@ func isValidAuthMethod(method string) bool {
 	}
 }
 
+// isValidJiraBaseURL checks that the JIRA site is an https URL, since the
+// JIRA credentials are sent to it
+func isValidJiraBaseURL(raw string) bool {
+	u, err := url.Parse(raw)
+	return err == nil && u.Scheme == "https" && u.Host != ""
+}
+
 // isValidOutputFormat checks if the provided output format is valid
 func isValidOutputFormat(format string) bool {
 	switch format {
@@ -1,6 +1,7 @@
 package config
 
 import (
+	"errors"
 	"testing"
 	"time"
 )
@@ -80,6 +81,27 @@ func TestConfigValidate(t *testing.T) {
 			wantErr: true,
 			errType: ErrInvalidTimeout,
 		},
+		{
+			name: "negative connection pool size",
+			config: &Config{
+				Version: 1,
+				Auth:    AuthConfig{Method: AuthMethodAppPassword},
+				API:     APIConfig{BaseURL: "https://api.bitbucket.org/2.0", Timeout: 30 * time.Second, MaxIdleConnsPerHost: -1},
+			},
+			wantErr: true,
+			errType: ErrInvalidConnPool,
+		},
+		{
+			name: "negative max width",
+			config: &Config{
+				Version: 1,
+				Auth:    AuthConfig{Method: AuthMethodAppPassword},
+				API:     APIConfig{BaseURL: "https://api.bitbucket.org/2.0", Timeout: 30 * time.Second},
+				UI:      UIConfig{MaxWidth: -1},
+			},
+			wantErr: true,
+			errType: ErrInvalidMaxWidth,
+		},
 		{
 			name: "invalid output format",
 			config: &Config{
@@ -91,6 +113,63 @@ func TestConfigValidate(t *testing.T) {
 			wantErr: true,
 			errType: ErrInvalidOutputFormat,
 		},
+		{
+			name: "invalid commit lint mode",
+			config: &Config{
+				Version: 1,
+				Auth:    AuthConfig{Method: AuthMethodAppPassword},
+				API:     APIConfig{BaseURL: "https://api.bitbucket.org/2.0", Timeout: 30 * time.Second},
+				PR:      PRConfig{CommitLint: CommitLintConfig{Mode: "strict"}},
+			},
+			wantErr: true,
+			errType: ErrInvalidCommitLintMode,
+		},
+		{
+			name: "size thresholds out of order",
+			config: &Config{
+				Version: 1,
+				Auth:    AuthConfig{Method: AuthMethodAppPassword},
+				API:     APIConfig{BaseURL: "https://api.bitbucket.org/2.0", Timeout: 30 * time.Second},
+				PR:      PRConfig{SizeThresholds: SizeThresholds{XS: 10, S: 500, M: 100, L: 1000}},
+			},
+			wantErr: true,
+			errType: ErrInvalidSizeThresholds,
+		},
+		{
+			name: "negative max size",
+			config: &Config{
+				Version: 1,
+				Auth:    AuthConfig{Method: AuthMethodAppPassword},
+				API:     APIConfig{BaseURL: "https://api.bitbucket.org/2.0", Timeout: 30 * time.Second},
+				PR:      PRConfig{SizeThresholds: DefaultSizeThresholds(), MaxSize: -1},
+			},
+			wantErr: true,
+			errType: ErrInvalidMaxSize,
+		},
+		{
+			name: "jira base url without a scheme",
+			config: &Config{
+				Version: 1,
+				Auth:    AuthConfig{Method: AuthMethodAppPassword},
+				API:     APIConfig{BaseURL: "https://api.bitbucket.org/2.0", Timeout: 30 * time.Second},
+				PR:      PRConfig{SizeThresholds: DefaultSizeThresholds()},
+				Jira:    JiraConfig{BaseURL: "yourcompany.atlassian.net"},
+			},
+			wantErr: true,
+			errType: ErrInvalidJiraBaseURL,
+		},
+		{
+			name: "jira base url over http",
+			config: &Config{
+				Version: 1,
+				Auth:    AuthConfig{Method: AuthMethodAppPassword},
+				API:     APIConfig{BaseURL: "https://api.bitbucket.org/2.0", Timeout: 30 * time.Second},
+				PR:      PRConfig{SizeThresholds: DefaultSizeThresholds()},
+				Jira:    JiraConfig{BaseURL: "http://yourcompany.atlassian.net"},
+			},
+			wantErr: true,
+			errType: ErrInvalidJiraBaseURL,
+		},
 	}
 
 	for _, tt := range tests {
@@ -107,6 +186,32 @@ func TestConfigValidate(t *testing.T) {
 	}
 }
 
+func TestConfigValidate_CommitLintPattern(t *testing.T) {
+	cfg := NewDefaultConfig()
+	cfg.PR.CommitLint = CommitLintConfig{Mode: CommitLintModeBlock, Pattern: `^[A-Z]+-\d+ .+`}
+	if err := cfg.Validate(); err != nil {
+		t.Errorf("Config.Validate() error = %v, want nil", err)
+	}
+
+	cfg.PR.CommitLint.Pattern = "(unclosed"
+	if err := cfg.Validate(); !errors.Is(err, ErrInvalidCommitLintPattern) {
+		t.Errorf("Config.Validate() error = %v, want %v", err, ErrInvalidCommitLintPattern)
+	}
+}
+
+func TestConfigValidate_RedactPatterns(t *testing.T) {
+	cfg := NewDefaultConfig()
+	cfg.Run.RedactPatterns = []string{`acme_[a-z0-9]{32}`}
+	if err := cfg.Validate(); err != nil {
+		t.Errorf("Config.Validate() error = %v, want nil", err)
+	}
+
+	cfg.Run.RedactPatterns = append(cfg.Run.RedactPatterns, "(unclosed")
+	if err := cfg.Validate(); !errors.Is(err, ErrInvalidRedactPattern) {
+		t.Errorf("Config.Validate() error = %v, want %v", err, ErrInvalidRedactPattern)
+	}
+}
+
 func TestIsValidAuthMethod(t *testing.T) {
 	tests := []struct {
 		method string
@@ -148,3 +253,32 @@ func TestIsValidOutputFormat(t *testing.T) {
 		})
 	}
 }
+
+func TestConfig_ConfiguredBase(t *testing.T) {
+	cfg := NewDefaultConfig()
+	cfg.PR.Base = "develop"
+	cfg.Repos = map[string]RepoSettings{"api": {Base: "integration"}, "web": {}}
+
+	tests := []struct {
+		repository string
+		base       string
+		key        string
+	}{
+		{"api", "integration", "repo.api.base"},
+		{"web", "develop", "pr.base"},
+		{"other", "develop", "pr.base"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.repository, func(t *testing.T) {
+			base, key := cfg.ConfiguredBase(tt.repository)
+			if base != tt.base || key != tt.key {
+				t.Errorf("ConfiguredBase(%s) = %q, %q, want %q, %q", tt.repository, base, key, tt.base, tt.key)
+			}
+		})
+	}
+
+	if base, key := NewDefaultConfig().ConfiguredBase("api"); base != "" || key != "" {
+		t.Errorf("ConfiguredBase without a base = %q, %q, want empty strings", base, key)
+	}
+}
@@ -8,6 +8,8 @@ var (
 	ErrInvalidAuthMethod   = errors.New("invalid authentication method")
 	ErrEmptyBaseURL        = errors.New("API base URL cannot be empty")
 	ErrInvalidTimeout      = errors.New("API timeout must be positive")
+	ErrInvalidConnPool     = errors.New("API connection pool settings cannot be negative")
+	ErrInvalidMaxWidth     = errors.New("ui.max_width cannot be negative")
 	ErrInvalidOutputFormat = errors.New("invalid output format")
 	ErrConfigNotFound      = errors.New("configuration file not found")
 	ErrConfigLoad          = errors.New("failed to load configuration")
@@ -15,4 +17,12 @@ var (
 	ErrEmptyPickPrefix     = errors.New("pick prefix cannot be empty")
 	ErrEmptyPickSuffixPrd  = errors.New("pick PRD suffix cannot be empty")
 	ErrEmptyPickSuffixHml  = errors.New("pick HML suffix cannot be empty")
+
+	ErrInvalidCommitLintMode    = errors.New("pr.commit_lint.mode must be warn or block")
+	ErrInvalidCommitLintPattern = errors.New("invalid pr.commit_lint.pattern")
+	ErrInvalidCommitLintLength  = errors.New("pr.commit_lint.max_subject_length cannot be negative")
+	ErrInvalidSizeThresholds    = errors.New("pr.size_thresholds must be positive and increase from xs to l")
+	ErrInvalidMaxSize           = errors.New("pr.max_size cannot be negative")
+	ErrInvalidJiraBaseURL       = errors.New("jira.base_url must be an https URL")
+	ErrInvalidRedactPattern     = errors.New("invalid run.redact_patterns")
 )
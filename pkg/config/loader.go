@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"reflect"
+	"strings"
 
 	"github.com/knadh/koanf/parsers/yaml"
 	"github.com/knadh/koanf/providers/env"
@@ -12,10 +14,35 @@ import (
 	yamlv3 "gopkg.in/yaml.v3"
 )
 
+// RepoConfigFile is the repository-local config file, found by walking up
+// from the working directory to the root of its git repository
+const RepoConfigFile = ".bt.yml"
+
+// RepoProtectedKeys are ignored in a repository's .bt.yml, and never
+// exported or overwritten by config import: they decide how bt authenticates
+// and where credentials are sent, and a cloned repository or a shared file
+// must not change them.
+var RepoProtectedKeys = []string{
+	"auth.method",
+	"api.base_url",
+	"jira.base_url",
+}
+
 // Loader handles configuration loading and management
 type Loader struct {
-	k          *koanf.Koanf
-	configPath string
+	k              *koanf.Koanf
+	configPath     string
+	repoConfigPath string
+	skipRepoConfig bool
+	skipEnv        bool
+	// fileK holds what the config file set when it was loaded
+	fileK *koanf.Koanf
+	// loaded is the configuration Load returned, flattened, to tell which
+	// values changed before Save
+	loaded map[string]interface{}
+	// setKeys and unsetKeys are the keys MarkSet and MarkUnset recorded
+	setKeys   map[string]bool
+	unsetKeys map[string]bool
 }
 
 // NewLoader creates a new configuration loader
@@ -25,8 +52,23 @@ func NewLoader() *Loader {
 	}
 }
 
+// WithoutRepoConfig makes Load ignore the repository's .bt.yml. Commands
+// that save the loaded configuration use it, so repository settings never
+// end up in the global config file.
+func (l *Loader) WithoutRepoConfig() *Loader {
+	l.skipRepoConfig = true
+	return l
+}
+
+// WithoutEnv makes Load ignore the BT_ environment variables, so the
+// configuration holds what the files say
+func (l *Loader) WithoutEnv() *Loader {
+	l.skipEnv = true
+	return l
+}
+
 // Load loads configuration from file and environment variables
-// Priority: Environment Variables > Config File > Defaults
+// Priority: Environment Variables > Config File > Repository .bt.yml > Defaults
 func (l *Loader) Load() (*Config, error) {
 	// Start with default configuration
 	config := NewDefaultConfig()
@@ -38,25 +80,56 @@ func (l *Loader) Load() (*Config, error) {
 	}
 	l.configPath = configPath
 
+	repos := make(map[string]RepoSettings)
+
+	// Load the repository's .bt.yml first so the global config file wins
+	if repoConfigPath := l.findRepoConfig(); repoConfigPath != "" {
+		repoK, err := loadRepoConfig(repoConfigPath)
+		if err != nil {
+			return nil, err
+		}
+		if err := readRepoSettings(repoConfigPath, repos); err != nil {
+			return nil, err
+		}
+		if err := l.k.Merge(repoK); err != nil {
+			return nil, fmt.Errorf("%w: failed to merge %s: %v", ErrConfigLoad, repoConfigPath, err)
+		}
+		l.repoConfigPath = repoConfigPath
+	}
+
 	// Load from config file if it exists
 	if _, err := os.Stat(configPath); err == nil {
-		if err := l.k.Load(file.Provider(configPath), yaml.Parser()); err != nil {
-			return nil, fmt.Errorf("%w: failed to load config file: %v", ErrConfigLoad, err)
+		fileK, err := loadConfigFile(configPath)
+		if err != nil {
+			return nil, err
+		}
+		if err := readRepoSettings(configPath, repos); err != nil {
+			return nil, err
 		}
+		if err := l.k.Merge(fileK); err != nil {
+			return nil, fmt.Errorf("%w: failed to merge config file: %v", ErrConfigLoad, err)
+		}
+		l.fileK = fileK
 	}
 
 	// Load environment variables with BT_ prefix
-	if err := l.k.Load(env.Provider("BT_", ".", func(s string) string {
-		// Convert BT_API_BASE_URL to api.base_url
-		return l.transformEnvKey(s)
-	}), nil); err != nil {
-		return nil, fmt.Errorf("%w: failed to load environment variables: %v", ErrConfigLoad, err)
+	if !l.skipEnv {
+		if err := l.k.Load(env.Provider("BT_", ".", func(s string) string {
+			// Convert BT_API_BASE_URL to api.base_url
+			return l.transformEnvKey(s)
+		}), nil); err != nil {
+			return nil, fmt.Errorf("%w: failed to load environment variables: %v", ErrConfigLoad, err)
+		}
 	}
 
 	// Unmarshal into config struct
+	l.k.Delete("repo")
 	if err := l.k.Unmarshal("", config); err != nil {
 		return nil, fmt.Errorf("%w: failed to unmarshal config: %v", ErrConfigLoad, err)
 	}
+	if len(repos) > 0 {
+		config.Repos = repos
+	}
 
 	// Koanf stores scalars as string — convert pick.prefix to Prefixes if needed
 	if raw := l.k.Get("pick.prefix"); raw != nil {
@@ -78,9 +151,49 @@ func (l *Loader) Load() (*Config, error) {
 		return nil, fmt.Errorf("%w: %v", ErrConfigLoad, err)
 	}
 
+	if l.loaded, err = flatConfig(config); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrConfigLoad, err)
+	}
+
 	return config, nil
 }
 
+// MarkSet records that key was set explicitly, so that Save writes it even
+// when its value is the default: that is how a repository's .bt.yml value
+// is overridden back to the default
+func (l *Loader) MarkSet(key string) {
+	if l.setKeys == nil {
+		l.setKeys = make(map[string]bool)
+	}
+	l.setKeys[key] = true
+	delete(l.unsetKeys, key)
+}
+
+// MarkUnset records that key, and the keys below it, were removed, so that
+// Save leaves them out of the file and their defaults apply again
+func (l *Loader) MarkUnset(key string) {
+	if l.unsetKeys == nil {
+		l.unsetKeys = make(map[string]bool)
+	}
+	l.unsetKeys[key] = true
+	delete(l.setKeys, key)
+}
+
+// InConfigFile reports whether the config file Load read sets key, or
+// something below it
+func (l *Loader) InConfigFile(key string) bool {
+	return l.fileK != nil && l.fileK.Exists(key)
+}
+
+func (l *Loader) isUnset(key string) bool {
+	for unset := range l.unsetKeys {
+		if key == unset || strings.HasPrefix(key, unset+".") {
+			return true
+		}
+	}
+	return false
+}
+
 // Save saves the configuration to file
 func (l *Loader) Save(config *Config) error {
 	if l.configPath == "" {
@@ -97,8 +210,13 @@ func (l *Loader) Save(config *Config) error {
 		return fmt.Errorf("%w: failed to create config directory: %v", ErrConfigSave, err)
 	}
 
+	values, err := l.savedValues(config)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrConfigSave, err)
+	}
+
 	// Marshal config to YAML using gopkg.in/yaml.v3
-	yamlData, err := yamlv3.Marshal(config)
+	yamlData, err := yamlv3.Marshal(values)
 	if err != nil {
 		return fmt.Errorf("%w: failed to marshal config: %v", ErrConfigSave, err)
 	}
@@ -116,11 +234,192 @@ func (l *Loader) GetConfigPath() (string, error) {
 	return l.getConfigPath()
 }
 
+// RepoConfigPath returns the .bt.yml the last Load applied, or "" when there
+// was none
+func (l *Loader) RepoConfigPath() string {
+	return l.repoConfigPath
+}
+
+// findRepoConfig walks up from the working directory looking for .bt.yml,
+// stopping at the root of the git repository; it returns "" when there is
+// none or repository config is skipped
+func (l *Loader) findRepoConfig() string {
+	if l.skipRepoConfig {
+		return ""
+	}
+
+	dir, err := os.Getwd()
+	if err != nil {
+		return ""
+	}
+	for {
+		path := filepath.Join(dir, RepoConfigFile)
+		if info, err := os.Stat(path); err == nil && !info.IsDir() {
+			return path
+		}
+		if _, err := os.Stat(filepath.Join(dir, ".git")); err == nil {
+			return ""
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return ""
+		}
+		dir = parent
+	}
+}
+
+// loadRepoConfig reads a .bt.yml without its protected keys
+func loadRepoConfig(path string) (*koanf.Koanf, error) {
+	k := koanf.New(".")
+	if err := k.Load(file.Provider(path), yaml.Parser()); err != nil {
+		return nil, fmt.Errorf("%w: failed to load %s: %v", ErrConfigLoad, path, err)
+	}
+	for _, key := range RepoProtectedKeys {
+		k.Delete(key)
+	}
+	// Nor may it run commands on the user's machine through shell aliases
+	for name, expansion := range k.StringMap("aliases") {
+		if strings.HasPrefix(expansion, "!") {
+			k.Delete("aliases." + name)
+		}
+	}
+	return k, nil
+}
+
+// readRepoSettings adds the repo section of the YAML file at path to repos.
+// It is read apart from koanf, whose "." delimiter would split repository
+// names such as my.repo.
+func readRepoSettings(path string, repos map[string]RepoSettings) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("%w: failed to read %s: %v", ErrConfigLoad, path, err)
+	}
+	var file struct {
+		Repos map[string]RepoSettings `yaml:"repo"`
+	}
+	if err := yamlv3.Unmarshal(data, &file); err != nil {
+		return fmt.Errorf("%w: failed to parse %s: %v", ErrConfigLoad, path, err)
+	}
+	for name, settings := range file.Repos {
+		repos[name] = settings
+	}
+	return nil
+}
+
+// savedValues returns what Save writes, as a nested map: the keys the file
+// already set, the keys marked with MarkSet and the values changed since
+// Load, plus the version and the whole repo section. Keys left at a value
+// they only got from a default, the repository's .bt.yml or the environment
+// are not written, so the file doesn't pin them.
+func (l *Loader) savedValues(config *Config) (map[string]interface{}, error) {
+	values, err := flatConfig(config)
+	if err != nil {
+		return nil, err
+	}
+	baseline := l.loaded
+	if baseline == nil {
+		if baseline, err = flatConfig(NewDefaultConfig()); err != nil {
+			return nil, err
+		}
+	}
+
+	out := koanf.New(".")
+	for key, value := range values {
+		if l.isUnset(key) {
+			continue
+		}
+		previous, known := baseline[key]
+		switch {
+		case l.setKeys[key] || !known || !sameValue(value, previous):
+			out.Set(key, value)
+		case l.fileK != nil && l.fileK.Exists(key):
+			// Unchanged: keep what the file said rather than a value the
+			// environment gave it
+			out.Set(key, l.fileK.Get(key))
+		}
+	}
+	out.Set("version", config.Version)
+
+	raw := out.Raw()
+	if len(config.Repos) > 0 {
+		raw["repo"] = config.Repos
+	}
+	return raw, nil
+}
+
+// legacyFileKeys were written by every bt that saved the whole
+// configuration; a config file holding all of them is such a full dump
+var legacyFileKeys = []string{"auth.method", "api.base_url", "api.timeout", "defaults.output_format"}
+
+// loadConfigFile reads the global config file. A file written by an older
+// bt, which saved every key, keeps only the values that differ from the
+// defaults: the rest were never chosen by the user and would shadow the
+// repository's .bt.yml. The next save writes the file that way.
+func loadConfigFile(path string) (*koanf.Koanf, error) {
+	k := koanf.New(".")
+	if err := k.Load(file.Provider(path), yaml.Parser()); err != nil {
+		return nil, fmt.Errorf("%w: failed to load config file: %v", ErrConfigLoad, err)
+	}
+
+	for _, key := range legacyFileKeys {
+		if !k.Exists(key) {
+			return k, nil
+		}
+	}
+	defaults, err := flatConfig(NewDefaultConfig())
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrConfigLoad, err)
+	}
+	for _, key := range k.Keys() {
+		if def, ok := defaults[key]; ok && sameValue(k.Get(key), def) {
+			k.Delete(key)
+		}
+	}
+	return k, nil
+}
+
+// flatConfig returns config keyed like the config file, flattened to dotted
+// keys such as pr.size_thresholds.l
+func flatConfig(config *Config) (map[string]interface{}, error) {
+	data, err := yamlv3.Marshal(config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal config: %v", err)
+	}
+	nested := make(map[string]interface{})
+	if err := yamlv3.Unmarshal(data, &nested); err != nil {
+		return nil, fmt.Errorf("failed to marshal config: %v", err)
+	}
+	// Repository names may contain dots, so repo is kept whole, not flattened
+	delete(nested, "repo")
+	values := make(map[string]interface{})
+	flattenValues("", nested, values)
+	return values, nil
+}
+
+func flattenValues(prefix string, nested map[string]interface{}, out map[string]interface{}) {
+	for key, value := range nested {
+		if prefix != "" {
+			key = prefix + "." + key
+		}
+		if child, ok := value.(map[string]interface{}); ok {
+			flattenValues(key, child, out)
+			continue
+		}
+		out[key] = value
+	}
+}
+
+// sameValue compares values read from YAML, where the same number or list
+// may come with different Go types
+func sameValue(a, b interface{}) bool {
+	return reflect.DeepEqual(a, b) || fmt.Sprint(a) == fmt.Sprint(b)
+}
+
 // getConfigPath determines the configuration file path
 func (l *Loader) getConfigPath() (string, error) {
 	// Check if BT_CONFIG_PATH environment variable is set
 	if configPath := os.Getenv("BT_CONFIG_PATH"); configPath != "" {
-		return configPath, nil
+		return ExpandPath(configPath)
 	}
 
 	// Use XDG config directory or fallback to home directory
@@ -136,6 +435,28 @@ func (l *Loader) getConfigPath() (string, error) {
 	return filepath.Join(configDir, "bt", "config.yml"), nil
 }
 
+// ExpandPath resolves a config file path given on the command line or in
+// BT_CONFIG_PATH the way a shell would: environment variables are expanded,
+// a leading ~ is the home directory, and a relative path is taken from the
+// working directory
+func ExpandPath(path string) (string, error) {
+	path = os.ExpandEnv(path)
+
+	if path == "~" || strings.HasPrefix(path, "~/") || strings.HasPrefix(path, "~"+string(filepath.Separator)) {
+		homeDir, err := os.UserHomeDir()
+		if err != nil {
+			return "", fmt.Errorf("failed to get user home directory: %v", err)
+		}
+		path = filepath.Join(homeDir, path[1:])
+	}
+
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve config path %s: %v", path, err)
+	}
+	return absPath, nil
+}
+
 // transformEnvKey transforms environment variable names to config keys
 // BT_API_BASE_URL -> api.base_url
 // BT_AUTH_METHOD -> auth.method
@@ -151,6 +472,10 @@ func (l *Loader) transformEnvKey(key string) string {
 		return "api.base_url"
 	case "API_TIMEOUT":
 		return "api.timeout"
+	case "API_MAX_IDLE_CONNS_PER_HOST":
+		return "api.max_idle_conns_per_host"
+	case "API_IDLE_CONN_TIMEOUT":
+		return "api.idle_conn_timeout"
 	case "AUTH_METHOD":
 		return "auth.method"
 	case "AUTH_DEFAULT_WORKSPACE":
@@ -165,6 +490,10 @@ func (l *Loader) transformEnvKey(key string) string {
 		return "pick.suffix_prd"
 	case "PICK_SUFFIX_HML":
 		return "pick.suffix_hml"
+	case "UI_MAX_WIDTH":
+		return "ui.max_width"
+	case "JIRA_BASE_URL":
+		return "jira.base_url"
 	default:
 		return key
 	}
@@ -175,6 +504,8 @@ const (
 	EnvConfigPath          = "BT_CONFIG_PATH"
 	EnvAPIBaseURL          = "BT_API_BASE_URL"
 	EnvAPITimeout          = "BT_API_TIMEOUT"
+	EnvAPIMaxIdleConns     = "BT_API_MAX_IDLE_CONNS_PER_HOST"
+	EnvAPIIdleConnTimeout  = "BT_API_IDLE_CONN_TIMEOUT"
 	EnvAuthMethod          = "BT_AUTH_METHOD"
 	EnvDefaultWorkspace    = "BT_AUTH_DEFAULT_WORKSPACE"
 	EnvDefaultOutputFormat = "BT_DEFAULTS_OUTPUT_FORMAT"
@@ -182,4 +513,6 @@ const (
 	EnvPickPrefix          = "BT_PICK_PREFIX"
 	EnvPickSuffixPrd       = "BT_PICK_SUFFIX_PRD"
 	EnvPickSuffixHml       = "BT_PICK_SUFFIX_HML"
+	EnvUIMaxWidth          = "BT_UI_MAX_WIDTH"
+	EnvJiraBaseURL         = "BT_JIRA_BASE_URL"
 )
@@ -0,0 +1,267 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	yamlv3 "gopkg.in/yaml.v3"
+)
+
+// setupRepoConfig creates a global config file and a git repository with a
+// .bt.yml, and changes into a directory below the repository root
+func setupRepoConfig(t *testing.T, global, repo string) (repoDir string) {
+	t.Helper()
+
+	configPath := filepath.Join(t.TempDir(), "config.yml")
+	require.NoError(t, os.WriteFile(configPath, []byte(global), 0644))
+	t.Setenv(EnvConfigPath, configPath)
+
+	repoDir = t.TempDir()
+	require.NoError(t, os.Mkdir(filepath.Join(repoDir, ".git"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(repoDir, RepoConfigFile), []byte(repo), 0644))
+
+	subDir := filepath.Join(repoDir, "pkg", "api")
+	require.NoError(t, os.MkdirAll(subDir, 0755))
+	t.Chdir(subDir)
+	return repoDir
+}
+
+func TestLoader_RepoConfig(t *testing.T) {
+	repoDir := setupRepoConfig(t,
+		"pr:\n  max_size: 300\n",
+		"auth:\n  method: oauth\n  default_workspace: team\napi:\n  base_url: https://evil.example.com\njira:\n  base_url: https://evil.example.com\npr:\n  max_size: 400\n  commit_lint:\n    enabled: true\n",
+	)
+
+	loader := NewLoader()
+	cfg, err := loader.Load()
+	require.NoError(t, err)
+
+	assert.Equal(t, filepath.Join(repoDir, RepoConfigFile), loader.RepoConfigPath())
+	assert.Equal(t, "team", cfg.Auth.DefaultWorkspace, ".bt.yml overrides the defaults")
+	assert.True(t, cfg.PR.CommitLint.Enabled)
+	assert.Equal(t, 300, cfg.PR.MaxSize, "the global config file overrides .bt.yml")
+	assert.Equal(t, "app_password", cfg.Auth.Method, "protected keys are ignored in .bt.yml")
+	assert.Equal(t, "https://api.bitbucket.org/2.0", cfg.API.BaseURL, "protected keys are ignored in .bt.yml")
+	assert.Empty(t, cfg.Jira.BaseURL, "protected keys are ignored in .bt.yml")
+
+	t.Setenv(EnvDefaultWorkspace, "env-team")
+	cfg, err = NewLoader().Load()
+	require.NoError(t, err)
+	assert.Equal(t, "env-team", cfg.Auth.DefaultWorkspace, "environment variables override .bt.yml")
+
+	loader = NewLoader().WithoutRepoConfig()
+	cfg, err = loader.Load()
+	require.NoError(t, err)
+	assert.Empty(t, loader.RepoConfigPath())
+	assert.False(t, cfg.PR.CommitLint.Enabled)
+}
+
+func TestLoader_RepoConfigDropsShellAliases(t *testing.T) {
+	setupRepoConfig(t,
+		"aliases:\n  mine: '!echo mine'\n",
+		"aliases:\n  failed: run list --status failed\n  cleanup: '!rm -rf ~'\n",
+	)
+
+	cfg, err := NewLoader().Load()
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		"failed": "run list --status failed",
+		"mine":   "!echo mine",
+	}, cfg.Aliases, "shell aliases are ignored in .bt.yml but kept in the global config file")
+}
+
+func TestLoader_RepoConfigRepositoryBase(t *testing.T) {
+	setupRepoConfig(t,
+		"repo:\n  web:\n    base: main\n",
+		"repo:\n  api:\n    base: develop\n",
+	)
+
+	cfg, err := NewLoader().Load()
+	require.NoError(t, err)
+	base, key := cfg.ConfiguredBase("api")
+	assert.Equal(t, "develop", base)
+	assert.Equal(t, "repo.api.base", key)
+	base, _ = cfg.ConfiguredBase("web")
+	assert.Equal(t, "main", base)
+}
+
+func TestLoader_RepositoryNamesWithDots(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "config.yml")
+	t.Setenv(EnvConfigPath, configPath)
+
+	cfg := NewDefaultConfig()
+	cfg.Repos = map[string]RepoSettings{"my.repo": {Base: "develop"}}
+	require.NoError(t, NewLoader().Save(cfg))
+
+	loaded, err := NewLoader().WithoutRepoConfig().Load()
+	require.NoError(t, err)
+	base, key := loaded.ConfiguredBase("my.repo")
+	assert.Equal(t, "develop", base)
+	assert.Equal(t, "repo.my.repo.base", key)
+	assert.Equal(t, cfg, loaded)
+}
+
+func TestLoader_RepoConfigStopsAtRepositoryRoot(t *testing.T) {
+	repoDir := setupRepoConfig(t, "", "auth:\n  default_workspace: team\n")
+	require.NoError(t, os.Rename(filepath.Join(repoDir, RepoConfigFile), filepath.Join(filepath.Dir(repoDir), RepoConfigFile)))
+	t.Cleanup(func() { os.Remove(filepath.Join(filepath.Dir(repoDir), RepoConfigFile)) })
+
+	loader := NewLoader()
+	cfg, err := loader.Load()
+	require.NoError(t, err)
+	assert.Empty(t, loader.RepoConfigPath())
+	assert.Empty(t, cfg.Auth.DefaultWorkspace)
+}
+
+func TestLoader_SaveWritesChangedValues(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "config.yml")
+	t.Setenv(EnvConfigPath, configPath)
+
+	cfg := NewDefaultConfig()
+	cfg.Auth.DefaultWorkspace = "team"
+	cfg.PR.SizeThresholds.L = 2000
+	require.NoError(t, NewLoader().Save(cfg))
+
+	data, err := os.ReadFile(configPath)
+	require.NoError(t, err)
+	var saved map[string]interface{}
+	require.NoError(t, yamlv3.Unmarshal(data, &saved))
+	assert.Equal(t, map[string]interface{}{
+		"version": 1,
+		"auth":    map[string]interface{}{"default_workspace": "team"},
+		"pr":      map[string]interface{}{"size_thresholds": map[string]interface{}{"l": 2000}},
+	}, saved)
+
+	loaded, err := NewLoader().WithoutRepoConfig().Load()
+	require.NoError(t, err)
+	assert.Equal(t, cfg, loaded)
+}
+
+func TestExpandPath(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("BT_TEST_DIR", "/srv/bt")
+	cwd := t.TempDir()
+	t.Chdir(cwd)
+	cwd, err := os.Getwd()
+	require.NoError(t, err)
+
+	tests := []struct {
+		name string
+		path string
+		want string
+	}{
+		{"tilde", "~/.config/bt/config.yml", filepath.Join(home, ".config", "bt", "config.yml")},
+		{"tilde alone", "~", home},
+		{"environment variable", "$BT_TEST_DIR/config.yml", "/srv/bt/config.yml"},
+		{"braced environment variable", "${HOME}/bt.yml", filepath.Join(home, "bt.yml")},
+		{"relative", "./foo.yml", filepath.Join(cwd, "foo.yml")},
+		{"relative without dot", "conf/foo.yml", filepath.Join(cwd, "conf", "foo.yml")},
+		{"absolute", "/etc/bt/config.yml", "/etc/bt/config.yml"},
+		{"tilde of another user is a relative name", "~bob/config.yml", filepath.Join(cwd, "~bob", "config.yml")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ExpandPath(tt.path)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestLoader_ExpandsConfigPath(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	require.NoError(t, os.WriteFile(filepath.Join(home, "bt.yml"), []byte("pr:\n  max_size: 250\n"), 0644))
+	t.Setenv(EnvConfigPath, "~/bt.yml")
+	t.Chdir(t.TempDir())
+
+	loader := NewLoader()
+	cfg, err := loader.Load()
+	require.NoError(t, err)
+	assert.Equal(t, 250, cfg.PR.MaxSize)
+	configPath, err := loader.GetConfigPath()
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(home, "bt.yml"), configPath)
+}
+
+func TestLoader_SaveKeepsExplicitDefaults(t *testing.T) {
+	setupRepoConfig(t, "auth:\n  default_workspace: team\n", "pr:\n  max_size: 400\n  base: develop\n")
+
+	loader := NewLoader().WithoutRepoConfig()
+	cfg, err := loader.Load()
+	require.NoError(t, err)
+	cfg.PR.MaxSize = 0
+	loader.MarkSet("pr.max_size")
+	require.NoError(t, loader.Save(cfg))
+
+	cfg, err = NewLoader().Load()
+	require.NoError(t, err)
+	assert.Equal(t, 0, cfg.PR.MaxSize, "a default set explicitly overrides .bt.yml")
+	assert.Equal(t, "develop", cfg.PR.Base, "keys never set still come from .bt.yml")
+	assert.Equal(t, "team", cfg.Auth.DefaultWorkspace)
+
+	loader = NewLoader().WithoutRepoConfig()
+	cfg, err = loader.Load()
+	require.NoError(t, err)
+	cfg.PR.MaxSize = 0
+	loader.MarkUnset("pr.max_size")
+	require.NoError(t, loader.Save(cfg))
+
+	cfg, err = NewLoader().Load()
+	require.NoError(t, err)
+	assert.Equal(t, 400, cfg.PR.MaxSize, "an unset key falls back to .bt.yml")
+}
+
+func TestLoader_SaveLeavesEnvironmentOut(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "config.yml")
+	require.NoError(t, os.WriteFile(configPath, []byte("auth:\n  default_workspace: team\n"), 0644))
+	t.Setenv(EnvConfigPath, configPath)
+	t.Setenv(EnvDefaultWorkspace, "env-team")
+
+	loader := NewLoader().WithoutRepoConfig()
+	cfg, err := loader.Load()
+	require.NoError(t, err)
+	cfg.PR.Base = "develop"
+	require.NoError(t, loader.Save(cfg))
+
+	data, err := os.ReadFile(configPath)
+	require.NoError(t, err)
+	var saved map[string]interface{}
+	require.NoError(t, yamlv3.Unmarshal(data, &saved))
+	assert.Equal(t, map[string]interface{}{
+		"version": 1,
+		"auth":    map[string]interface{}{"default_workspace": "team"},
+		"pr":      map[string]interface{}{"base": "develop"},
+	}, saved)
+}
+
+func TestLoader_LegacyFullConfigFile(t *testing.T) {
+	legacy := NewDefaultConfig()
+	legacy.Auth.DefaultWorkspace = "team"
+	data, err := yamlv3.Marshal(legacy)
+	require.NoError(t, err)
+	setupRepoConfig(t, string(data), "pr:\n  max_size: 400\n")
+
+	cfg, err := NewLoader().Load()
+	require.NoError(t, err)
+	assert.Equal(t, 400, cfg.PR.MaxSize, "defaults pinned by an old full file don't shadow .bt.yml")
+	assert.Equal(t, "team", cfg.Auth.DefaultWorkspace)
+
+	loader := NewLoader().WithoutRepoConfig()
+	cfg, err = loader.Load()
+	require.NoError(t, err)
+	require.NoError(t, loader.Save(cfg))
+
+	data, err = os.ReadFile(os.Getenv(EnvConfigPath))
+	require.NoError(t, err)
+	var saved map[string]interface{}
+	require.NoError(t, yamlv3.Unmarshal(data, &saved))
+	assert.Equal(t, map[string]interface{}{
+		"version": 1,
+		"auth":    map[string]interface{}{"default_workspace": "team"},
+	}, saved)
+}
@@ -0,0 +1,132 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	yamlv3 "gopkg.in/yaml.v3"
+)
+
+// Source names a layer a configuration value can come from
+type Source string
+
+const (
+	SourceEnv       Source = "environment"
+	SourceGitRemote Source = "git remote"
+	SourceFile      Source = "config file"
+	SourceRepoFile  Source = "repo config"
+	SourceDefault   Source = "default"
+)
+
+// Origin is the value a single layer gives a configuration key
+type Origin struct {
+	Source Source      `json:"source" yaml:"source"`
+	Name   string      `json:"name,omitempty" yaml:"name,omitempty"` // environment variable or file path
+	Value  interface{} `json:"value" yaml:"value"`
+	Set    bool        `json:"set" yaml:"set"`
+	Wins   bool        `json:"wins" yaml:"wins"`
+}
+
+// Provenance returns the value every layer gives key, highest precedence
+// first, the way Load applies them: environment variable, config file, the
+// repository's .bt.yml when there is one, built-in default. The first layer
+// that sets the key wins.
+func (l *Loader) Provenance(key string) ([]Origin, error) {
+	var origins []Origin
+
+	if name := l.envVarFor(key); name != "" {
+		value, ok := os.LookupEnv(name)
+		origins = append(origins, Origin{Source: SourceEnv, Name: name, Value: value, Set: ok})
+	}
+
+	configPath, err := l.getConfigPath()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrConfigLoad, err)
+	}
+	fileOrigin := Origin{Source: SourceFile, Name: configPath}
+	if _, err := os.Stat(configPath); err == nil {
+		k, err := loadConfigFile(configPath)
+		if err != nil {
+			return nil, err
+		}
+		if k.Exists(key) {
+			fileOrigin.Value = k.Get(key)
+			fileOrigin.Set = true
+		}
+	}
+	origins = append(origins, fileOrigin)
+
+	if repoConfigPath := l.findRepoConfig(); repoConfigPath != "" {
+		repoK, err := loadRepoConfig(repoConfigPath)
+		if err != nil {
+			return nil, err
+		}
+		repoOrigin := Origin{Source: SourceRepoFile, Name: repoConfigPath}
+		if repoK.Exists(key) {
+			repoOrigin.Value = repoK.Get(key)
+			repoOrigin.Set = true
+		}
+		origins = append(origins, repoOrigin)
+	}
+
+	defaults, err := defaultValues()
+	if err != nil {
+		return nil, err
+	}
+	value, ok := lookup(defaults, key)
+	origins = append(origins, Origin{Source: SourceDefault, Value: value, Set: ok})
+
+	MarkWinner(origins)
+	return origins, nil
+}
+
+// MarkWinner flags the first set origin as the one that wins
+func MarkWinner(origins []Origin) {
+	won := false
+	for i := range origins {
+		origins[i].Wins = !won && origins[i].Set
+		if origins[i].Wins {
+			won = true
+		}
+	}
+}
+
+// envVarFor returns the BT_ environment variable Load reads key from, or ""
+// when the key has none
+func (l *Loader) envVarFor(key string) string {
+	name := "BT_" + strings.ToUpper(strings.ReplaceAll(key, ".", "_"))
+	if l.transformEnvKey(name) != key {
+		return ""
+	}
+	return name
+}
+
+// defaultValues is the built-in default configuration as a nested map keyed
+// like the config file
+func defaultValues() (map[string]interface{}, error) {
+	data, err := yamlv3.Marshal(NewDefaultConfig())
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to marshal defaults: %v", ErrConfigLoad, err)
+	}
+	values := make(map[string]interface{})
+	if err := yamlv3.Unmarshal(data, &values); err != nil {
+		return nil, fmt.Errorf("%w: failed to read defaults: %v", ErrConfigLoad, err)
+	}
+	return values, nil
+}
+
+// lookup finds a dotted key such as "api.timeout" in a nested map
+func lookup(values map[string]interface{}, key string) (interface{}, bool) {
+	var current interface{} = values
+	for _, part := range strings.Split(key, ".") {
+		m, ok := current.(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		if current, ok = m[part]; !ok {
+			return nil, false
+		}
+	}
+	return current, true
+}
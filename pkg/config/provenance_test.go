@@ -0,0 +1,62 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoader_Provenance(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "config.yml")
+	require.NoError(t, os.WriteFile(configPath, []byte("api:\n  timeout: 45s\nauth:\n  default_workspace: team\n"), 0644))
+	t.Setenv(EnvConfigPath, configPath)
+	t.Setenv(EnvAPITimeout, "60s")
+
+	loader := NewLoader()
+
+	origins, err := loader.Provenance("api.timeout")
+	require.NoError(t, err)
+	assert.Equal(t, []Origin{
+		{Source: SourceEnv, Name: EnvAPITimeout, Value: "60s", Set: true, Wins: true},
+		{Source: SourceFile, Name: configPath, Value: "45s", Set: true},
+		{Source: SourceDefault, Value: "30s", Set: true},
+	}, origins)
+
+	origins, err = loader.Provenance("auth.default_workspace")
+	require.NoError(t, err)
+	require.Len(t, origins, 3)
+	assert.Equal(t, Origin{Source: SourceEnv, Name: EnvDefaultWorkspace, Value: ""}, origins[0])
+	assert.True(t, origins[1].Wins, "the config file wins without the environment variable")
+	assert.Equal(t, "team", origins[1].Value)
+
+	// pr.max_size has no environment variable and isn't in the file
+	origins, err = loader.Provenance("pr.max_size")
+	require.NoError(t, err)
+	assert.Equal(t, []Origin{
+		{Source: SourceFile, Name: configPath},
+		{Source: SourceDefault, Value: 0, Set: true, Wins: true},
+	}, origins)
+}
+
+func TestMarkWinner(t *testing.T) {
+	origins := []Origin{{Source: SourceEnv}, {Source: SourceFile, Set: true}, {Source: SourceDefault, Set: true, Wins: true}}
+	MarkWinner(origins)
+	assert.False(t, origins[0].Wins)
+	assert.True(t, origins[1].Wins)
+	assert.False(t, origins[2].Wins)
+}
+
+func TestLoader_ProvenanceRepoConfig(t *testing.T) {
+	repoDir := setupRepoConfig(t, "pr:\n  max_size: 300\n", "pr:\n  max_size: 400\n")
+
+	origins, err := NewLoader().Provenance("pr.max_size")
+	require.NoError(t, err)
+	require.Len(t, origins, 3)
+	assert.True(t, origins[0].Wins)
+	assert.Equal(t, 300, origins[0].Value)
+	assert.Equal(t, Origin{Source: SourceRepoFile, Name: filepath.Join(repoDir, RepoConfigFile), Value: 400, Set: true}, origins[1])
+	assert.Equal(t, SourceDefault, origins[2].Source)
+}
@@ -23,7 +23,8 @@ type BranchInfo struct {
 	IsTracking   bool   `json:"is_tracking"`
 }
 
-// GetCurrentBranch returns information about the current branch
+// GetCurrentBranch returns information about the current branch, or
+// ErrDetachedHEAD when no branch is checked out
 func (r *Repository) GetCurrentBranch() (*BranchInfo, error) {
 	head, err := r.repo.Head()
 	if err != nil {
@@ -31,7 +32,7 @@ func (r *Repository) GetCurrentBranch() (*BranchInfo, error) {
 	}
 
 	if !head.Name().IsBranch() {
-		return nil, fmt.Errorf("HEAD is not pointing to a branch (detached HEAD)")
+		return nil, ErrDetachedHEAD
 	}
 
 	branchName := head.Name().Short()
@@ -54,6 +55,28 @@ func (r *Repository) GetCurrentBranch() (*BranchInfo, error) {
 	return info, nil
 }
 
+// HeadCommit returns the hash of the commit HEAD points to, detached or not
+func (r *Repository) HeadCommit() (string, error) {
+	head, err := r.repo.Head()
+	if err != nil {
+		return "", fmt.Errorf("failed to get HEAD: %w", err)
+	}
+	return head.Hash().String(), nil
+}
+
+// CheckoutCommit checks out a commit on a detached HEAD
+func (r *Repository) CheckoutCommit(hash string) error {
+	workTree, err := r.repo.Worktree()
+	if err != nil {
+		return fmt.Errorf("failed to get worktree: %w", err)
+	}
+
+	if err := workTree.Checkout(&git.CheckoutOptions{Hash: plumbing.NewHash(hash)}); err != nil {
+		return fmt.Errorf("failed to checkout commit %s: %w", hash, err)
+	}
+	return nil
+}
+
 // GetAllBranches returns information about all local branches
 func (r *Repository) GetAllBranches() ([]*BranchInfo, error) {
 	branches, err := r.repo.Branches()
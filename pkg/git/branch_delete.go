@@ -0,0 +1,36 @@
+package git
+
+import (
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// IsAncestorExec reports whether ancestor is reachable from commit, which
+// includes the two being the same commit. It is false when either revision
+// is unknown locally.
+func IsAncestorExec(repoDir, ancestor, commit string) bool {
+	cmd := exec.Command("git", "merge-base", "--is-ancestor", ancestor, commit)
+	cmd.Dir = repoDir
+	return cmd.Run() == nil
+}
+
+// DeleteBranchExec deletes a local branch and its remote-tracking ref, if
+// any. It uses git branch -D because a squash-merged branch never looks
+// merged to git, so callers must check the branch is safe to delete first.
+func DeleteBranchExec(repoDir, branch, remote string) error {
+	cmd := exec.Command("git", "branch", "-D", branch)
+	cmd.Dir = repoDir
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("failed to delete branch %s: %s", branch, strings.TrimSpace(string(output)))
+	}
+
+	if remote != "" {
+		// The remote-tracking ref may already be pruned
+		cmd = exec.Command("git", "branch", "-r", "-D", remote+"/"+branch)
+		cmd.Dir = repoDir
+		_ = cmd.Run()
+	}
+
+	return nil
+}
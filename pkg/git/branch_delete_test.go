@@ -0,0 +1,47 @@
+package git
+
+import (
+	"os/exec"
+	"testing"
+)
+
+func TestIsAncestorExec(t *testing.T) {
+	repoDir := setupTestRepo(t)
+	first := createTestCommit(t, repoDir, "main", "first")
+	second := createTestCommit(t, repoDir, "main", "second")
+
+	if !IsAncestorExec(repoDir, first, second) {
+		t.Error("IsAncestorExec(first, second) = false, want true")
+	}
+	if !IsAncestorExec(repoDir, second, second) {
+		t.Error("IsAncestorExec(second, second) = false, want true")
+	}
+	if IsAncestorExec(repoDir, second, first) {
+		t.Error("IsAncestorExec(second, first) = true, want false")
+	}
+	if IsAncestorExec(repoDir, "0123456789abcdef0123456789abcdef01234567", second) {
+		t.Error("IsAncestorExec() with an unknown commit = true, want false")
+	}
+}
+
+func TestDeleteBranchExec(t *testing.T) {
+	repoDir := setupTestRepo(t)
+	createTestCommit(t, repoDir, "main", "first")
+	createTestCommit(t, repoDir, "feature-done", "feature work")
+
+	if out, err := exec.Command("git", "-C", repoDir, "checkout", "main").CombinedOutput(); err != nil {
+		t.Fatalf("checkout failed: %v\n%s", err, out)
+	}
+
+	// Unmerged into main, so git branch -d would refuse
+	if err := DeleteBranchExec(repoDir, "feature-done", "origin"); err != nil {
+		t.Fatalf("DeleteBranchExec() error = %v", err)
+	}
+	if exists, _ := BranchExistsExec(repoDir, "feature-done"); exists {
+		t.Error("branch feature-done still exists")
+	}
+
+	if err := DeleteBranchExec(repoDir, "feature-missing", ""); err == nil {
+		t.Error("DeleteBranchExec() of a missing branch expected error, got nil")
+	}
+}
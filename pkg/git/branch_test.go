@@ -1,6 +1,8 @@
 package git
 
 import (
+	"errors"
+	"os/exec"
 	"testing"
 )
 
@@ -325,3 +327,47 @@ func TestBranchNameEdgeCases(t *testing.T) {
 		})
 	}
 }
+
+func TestGetCurrentBranch_DetachedHEAD(t *testing.T) {
+	repoDir := setupTestRepo(t)
+	first := createTestCommit(t, repoDir, "main", "first commit")
+	second := createTestCommit(t, repoDir, "main", "second commit")
+
+	cmd := exec.Command("git", "remote", "add", "origin", "git@bitbucket.org:ws/repo.git")
+	cmd.Dir = repoDir
+	if err := cmd.Run(); err != nil {
+		t.Fatalf("Failed to add remote: %v", err)
+	}
+
+	repo, err := NewRepository(repoDir)
+	if err != nil {
+		t.Fatalf("NewRepository failed: %v", err)
+	}
+
+	if err := repo.CheckoutCommit(first); err != nil {
+		t.Fatalf("CheckoutCommit failed: %v", err)
+	}
+
+	if _, err := repo.GetCurrentBranch(); !errors.Is(err, ErrDetachedHEAD) {
+		t.Errorf("GetCurrentBranch() error = %v, want ErrDetachedHEAD", err)
+	}
+
+	head, err := repo.HeadCommit()
+	if err != nil {
+		t.Fatalf("HeadCommit failed: %v", err)
+	}
+	if head != first {
+		t.Errorf("HeadCommit() = %s, want %s", head, first)
+	}
+
+	if err := repo.CheckoutBranch("main", false); err != nil {
+		t.Fatalf("CheckoutBranch failed: %v", err)
+	}
+	branch, err := repo.GetCurrentBranch()
+	if err != nil {
+		t.Fatalf("GetCurrentBranch failed: %v", err)
+	}
+	if branch.ShortName != "main" || branch.Hash != second {
+		t.Errorf("GetCurrentBranch() = %s at %s, want main at %s", branch.ShortName, branch.Hash, second)
+	}
+}
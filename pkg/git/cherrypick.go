@@ -69,7 +69,11 @@ func GetCurrentBranchExec(repoDir string) (string, error) {
 	if err != nil {
 		return "", fmt.Errorf("failed to get current branch: %w", err)
 	}
-	return strings.TrimSpace(string(output)), nil
+	branch := strings.TrimSpace(string(output))
+	if branch == "" {
+		return "", ErrDetachedHEAD
+	}
+	return branch, nil
 }
 
 func GetCurrentUserExec(repoDir string) (string, error) {
@@ -361,6 +365,85 @@ func CherryPickContinue(repoDir string) error {
 	return nil
 }
 
+// CherryPickConflictError is returned by CherryPickExec when a commit does
+// not apply cleanly. The cherry-pick is left in progress so the conflicts can
+// be resolved and the pick continued or aborted.
+type CherryPickConflictError struct {
+	Commit string
+	Files  []string
+}
+
+func (e *CherryPickConflictError) Error() string {
+	return fmt.Sprintf("cherry-pick of %s stopped with conflicts in %d file(s)", shortHash(e.Commit), len(e.Files))
+}
+
+// CherryPickExec cherry-picks commits, oldest first, onto the current branch.
+// Each new commit records the commit it was picked from (-x). When a commit
+// conflicts the pick stops there and a *CherryPickConflictError is returned.
+func CherryPickExec(repoDir string, commits []string) error {
+	for _, commit := range commits {
+		cmd := exec.Command("git", "cherry-pick", "-x", commit)
+		cmd.Dir = repoDir
+		output, err := cmd.CombinedOutput()
+		if err == nil {
+			continue
+		}
+		if IsCherryPickInProgress(repoDir) {
+			files, _ := ConflictedFilesExec(repoDir)
+			return &CherryPickConflictError{Commit: commit, Files: files}
+		}
+		return fmt.Errorf("failed to cherry-pick %s: %s", shortHash(commit), strings.TrimSpace(string(output)))
+	}
+	return nil
+}
+
+// ConflictedFilesExec lists the files with unresolved merge conflicts
+func ConflictedFilesExec(repoDir string) ([]string, error) {
+	cmd := exec.Command("git", "diff", "--name-only", "--diff-filter=U")
+	cmd.Dir = repoDir
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list conflicted files: %w", err)
+	}
+	var files []string
+	for _, line := range strings.Split(strings.TrimSpace(string(output)), "\n") {
+		if line != "" {
+			files = append(files, line)
+		}
+	}
+	return files, nil
+}
+
+// CreateBranchExec creates branch at startPoint and checks it out, without
+// tracking startPoint
+func CreateBranchExec(repoDir, branch, startPoint string) error {
+	cmd := exec.Command("git", "checkout", "--no-track", "-b", branch, startPoint)
+	cmd.Dir = repoDir
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("failed to create branch '%s' from '%s': %s", branch, startPoint, strings.TrimSpace(string(output)))
+	}
+	return nil
+}
+
+// HasUncommittedChangesExec reports whether tracked files have staged or
+// unstaged changes
+func HasUncommittedChangesExec(repoDir string) (bool, error) {
+	cmd := exec.Command("git", "status", "--porcelain", "--untracked-files=no")
+	cmd.Dir = repoDir
+	output, err := cmd.Output()
+	if err != nil {
+		return false, fmt.Errorf("failed to check working tree status: %w", err)
+	}
+	return len(strings.TrimSpace(string(output))) > 0, nil
+}
+
+func shortHash(hash string) string {
+	if len(hash) > 7 {
+		return hash[:7]
+	}
+	return hash
+}
+
 func ParseBranchName(branchName, prefix, suffix string) (string, error) {
 	if !strings.HasPrefix(branchName, prefix) {
 		return "", fmt.Errorf("branch '%s' doesn't start with prefix '%s'", branchName, prefix)
@@ -1,6 +1,7 @@
 package git
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"os/exec"
@@ -87,6 +88,21 @@ func TestGetCurrentBranchExec(t *testing.T) {
 	}
 }
 
+func TestGetCurrentBranchExec_DetachedHEAD(t *testing.T) {
+	repoDir := setupTestRepo(t)
+	hash := createTestCommit(t, repoDir, "main", "test commit")
+
+	cmd := exec.Command("git", "checkout", "--detach", hash)
+	cmd.Dir = repoDir
+	if err := cmd.Run(); err != nil {
+		t.Fatalf("Failed to detach HEAD: %v", err)
+	}
+
+	if _, err := GetCurrentBranchExec(repoDir); !errors.Is(err, ErrDetachedHEAD) {
+		t.Errorf("Expected ErrDetachedHEAD, got %v", err)
+	}
+}
+
 func TestGetCurrentUserExec(t *testing.T) {
 	repoDir := setupTestRepo(t)
 
@@ -604,3 +620,111 @@ func TestPickCommitSignature(t *testing.T) {
 		t.Errorf("Expected multiline commit signature %q, got %q", expected, commitMultiline.Signature())
 	}
 }
+
+func commitFile(t *testing.T, repoDir, name, content, message string) string {
+	t.Helper()
+
+	if err := os.WriteFile(filepath.Join(repoDir, name), []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write %s: %v", name, err)
+	}
+	for _, args := range [][]string{{"add", name}, {"commit", "-m", message}} {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = repoDir
+		if output, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v failed: %v\n%s", args, err, output)
+		}
+	}
+	hash, err := ResolveCommitExec(repoDir, "HEAD")
+	if err != nil {
+		t.Fatalf("Failed to resolve HEAD: %v", err)
+	}
+	return hash
+}
+
+func TestCherryPickExec(t *testing.T) {
+	repoDir := setupTestRepo(t)
+	createTestCommit(t, repoDir, "main", "base commit")
+	createTestCommit(t, repoDir, "feature", "feature base")
+	fix := commitFile(t, repoDir, "fix.txt", "fixed\n", "fix the bug")
+
+	if err := CreateBranchExec(repoDir, "backport/main", "main"); err != nil {
+		t.Fatalf("CreateBranchExec failed: %v", err)
+	}
+	if err := CherryPickExec(repoDir, []string{fix}); err != nil {
+		t.Fatalf("CherryPickExec failed: %v", err)
+	}
+
+	cmd := exec.Command("git", "log", "-1", "--format=%B")
+	cmd.Dir = repoDir
+	output, err := cmd.Output()
+	if err != nil {
+		t.Fatalf("Failed to read the picked commit: %v", err)
+	}
+	message := string(output)
+	if !strings.HasPrefix(message, "fix the bug") {
+		t.Errorf("Expected the picked commit's message, got %q", message)
+	}
+	if !strings.Contains(message, "(cherry picked from commit "+fix+")") {
+		t.Errorf("Expected the message to record the original commit, got %q", message)
+	}
+	if _, err := os.Stat(filepath.Join(repoDir, "fix.txt")); err != nil {
+		t.Errorf("Expected fix.txt on the backport branch: %v", err)
+	}
+}
+
+func TestCherryPickExec_Conflict(t *testing.T) {
+	repoDir := setupTestRepo(t)
+	createTestCommit(t, repoDir, "main", "base commit")
+	commitFile(t, repoDir, "shared.txt", "main\n", "main change")
+
+	createTestCommit(t, repoDir, "feature", "feature base")
+	cmd := exec.Command("git", "checkout", "-B", "feature", "main~1")
+	cmd.Dir = repoDir
+	if err := cmd.Run(); err != nil {
+		t.Fatalf("Failed to reset feature: %v", err)
+	}
+	conflicting := commitFile(t, repoDir, "shared.txt", "feature\n", "feature change")
+
+	if err := CreateBranchExec(repoDir, "backport/main", "main"); err != nil {
+		t.Fatalf("CreateBranchExec failed: %v", err)
+	}
+	err := CherryPickExec(repoDir, []string{conflicting})
+
+	var conflict *CherryPickConflictError
+	if !errors.As(err, &conflict) {
+		t.Fatalf("Expected a CherryPickConflictError, got %v", err)
+	}
+	if conflict.Commit != conflicting {
+		t.Errorf("Expected the conflict on %s, got %s", conflicting, conflict.Commit)
+	}
+	if len(conflict.Files) != 1 || conflict.Files[0] != "shared.txt" {
+		t.Errorf("Expected shared.txt to conflict, got %v", conflict.Files)
+	}
+	if !IsCherryPickInProgress(repoDir) {
+		t.Error("Expected the cherry-pick to be left in progress")
+	}
+}
+
+func TestHasUncommittedChangesExec(t *testing.T) {
+	repoDir := setupTestRepo(t)
+	commitFile(t, repoDir, "a.txt", "one\n", "add a")
+
+	dirty, err := HasUncommittedChangesExec(repoDir)
+	if err != nil {
+		t.Fatalf("HasUncommittedChangesExec failed: %v", err)
+	}
+	if dirty {
+		t.Error("Expected a clean working tree")
+	}
+
+	if err := os.WriteFile(filepath.Join(repoDir, "a.txt"), []byte("two\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	dirty, err = HasUncommittedChangesExec(repoDir)
+	if err != nil {
+		t.Fatalf("HasUncommittedChangesExec failed: %v", err)
+	}
+	if !dirty {
+		t.Error("Expected a modified file to count as uncommitted")
+	}
+}
@@ -0,0 +1,197 @@
+package git
+
+import (
+	"fmt"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// CommitMessage is a commit's hash with its message split into the subject
+// line and the body
+type CommitMessage struct {
+	Hash    string
+	Subject string
+	Body    string
+}
+
+// ListCommitsExec returns the non-merge commits reachable from head but not
+// from base (git log base..head), newest first
+func ListCommitsExec(repoDir, base, head string) ([]CommitMessage, error) {
+	// Fields are NUL separated and records end with an ASCII record separator
+	cmd := exec.Command("git", "log", "--no-merges", "--format=%H%x00%s%x00%b%x1e", base+".."+head, "--")
+	cmd.Dir = repoDir
+	output, err := cmd.Output()
+	if err != nil {
+		if exitErr, ok := err.(*exec.ExitError); ok {
+			return nil, fmt.Errorf("failed to list commits in %s..%s: %s", base, head, strings.TrimSpace(string(exitErr.Stderr)))
+		}
+		return nil, fmt.Errorf("failed to list commits in %s..%s: %w", base, head, err)
+	}
+
+	var commits []CommitMessage
+	for _, record := range strings.Split(string(output), "\x1e") {
+		record = strings.TrimLeft(record, "\n")
+		if record == "" {
+			continue
+		}
+		fields := strings.SplitN(record, "\x00", 3)
+		if len(fields) < 3 {
+			continue
+		}
+		commits = append(commits, CommitMessage{
+			Hash:    fields[0],
+			Subject: fields[1],
+			Body:    strings.TrimSpace(fields[2]),
+		})
+	}
+	return commits, nil
+}
+
+// FirstCommitExec returns the earliest non-merge commit reachable from head
+// but not from base, usually the one that started the branch
+func FirstCommitExec(repoDir, base, head string) (*CommitMessage, error) {
+	commits, err := ListCommitsExec(repoDir, base, head)
+	if err != nil {
+		return nil, err
+	}
+	if len(commits) == 0 {
+		return nil, fmt.Errorf("no commits in %s..%s", base, head)
+	}
+	return &commits[len(commits)-1], nil
+}
+
+// ResolveCommitExec expands rev, such as an abbreviated SHA, to the full
+// hash of the commit it names
+func ResolveCommitExec(repoDir, rev string) (string, error) {
+	cmd := exec.Command("git", "rev-parse", "--verify", "--quiet", rev+"^{commit}")
+	cmd.Dir = repoDir
+	output, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("%s is not a commit in the local repository", rev)
+	}
+	return strings.TrimSpace(string(output)), nil
+}
+
+// CommitSubjectExec returns the first line of the message of the commit rev
+// names
+func CommitSubjectExec(repoDir, rev string) (string, error) {
+	cmd := exec.Command("git", "log", "-1", "--format=%s", rev, "--")
+	cmd.Dir = repoDir
+	output, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("failed to read the message of %s: %w", rev, err)
+	}
+	return strings.TrimSpace(string(output)), nil
+}
+
+// CommitDateExec returns when the commit rev names was committed. For an
+// annotated tag that is the date of the tagged commit, not of the tag.
+func CommitDateExec(repoDir, rev string) (time.Time, error) {
+	cmd := exec.Command("git", "log", "-1", "--format=%cI", rev, "--")
+	cmd.Dir = repoDir
+	output, err := cmd.Output()
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%s is not a tag or commit in the local repository", rev)
+	}
+	date, err := time.Parse(time.RFC3339, strings.TrimSpace(string(output)))
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to read the date of %s: %w", rev, err)
+	}
+	return date, nil
+}
+
+// CommittersExec returns who committed each of hashes, which differs from
+// the author for rebased, cherry-picked or applied patches. It runs a single
+// git log; commits missing from the local repository are left out of the
+// result.
+func CommittersExec(repoDir string, hashes []string) (map[string]Identity, error) {
+	committers := make(map[string]Identity, len(hashes))
+	if len(hashes) == 0 {
+		return committers, nil
+	}
+
+	args := append([]string{"log", "--no-walk=unsorted", "--ignore-missing", "--format=%H%x00%cn%x00%ce"}, hashes...)
+	cmd := exec.Command("git", append(args, "--")...)
+	cmd.Dir = repoDir
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read committers: %w", err)
+	}
+
+	for _, line := range strings.Split(strings.TrimSpace(string(output)), "\n") {
+		fields := strings.Split(line, "\x00")
+		if len(fields) != 3 {
+			continue
+		}
+		committers[fields[0]] = Identity{Name: fields[1], Email: fields[2]}
+	}
+	return committers, nil
+}
+
+// InferBaseBranchExec guesses the branch head was created from: of the other
+// local and origin branches, the one whose merge base with head is the fewest
+// commits behind head. Branches that already contain head are skipped, and
+// ties go to preferred, usually the default branch. It returns "" when no
+// branch qualifies.
+func InferBaseBranchExec(repoDir, head, preferred string) (string, error) {
+	headHash, err := ResolveCommitExec(repoDir, head)
+	if err != nil {
+		return "", err
+	}
+
+	cmd := exec.Command("git", "for-each-ref", "--format=%(refname)", "refs/heads", "refs/remotes/origin")
+	cmd.Dir = repoDir
+	output, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("failed to list branches: %w", err)
+	}
+
+	best, bestDistance := "", -1
+	seen := make(map[string]bool)
+	for _, ref := range strings.Fields(string(output)) {
+		name := strings.TrimPrefix(strings.TrimPrefix(ref, "refs/heads/"), "refs/remotes/origin/")
+		if name == head || name == "HEAD" || seen[name] {
+			continue
+		}
+		seen[name] = true
+
+		distance, ok := commitsSinceMergeBase(repoDir, headHash, ref)
+		if !ok {
+			continue
+		}
+		if bestDistance < 0 || distance < bestDistance || (distance == bestDistance && name == preferred) {
+			best, bestDistance = name, distance
+		}
+	}
+	return best, nil
+}
+
+// commitsSinceMergeBase counts the commits on headHash since its merge base
+// with ref; ok is false when ref already contains headHash or shares no
+// history with it
+func commitsSinceMergeBase(repoDir, headHash, ref string) (int, bool) {
+	cmd := exec.Command("git", "merge-base", headHash, ref)
+	cmd.Dir = repoDir
+	output, err := cmd.Output()
+	if err != nil {
+		return 0, false
+	}
+	mergeBase := strings.TrimSpace(string(output))
+	if mergeBase == headHash {
+		return 0, false
+	}
+
+	cmd = exec.Command("git", "rev-list", "--count", mergeBase+".."+headHash)
+	cmd.Dir = repoDir
+	output, err = cmd.Output()
+	if err != nil {
+		return 0, false
+	}
+	count, err := strconv.Atoi(strings.TrimSpace(string(output)))
+	if err != nil {
+		return 0, false
+	}
+	return count, true
+}
@@ -0,0 +1,168 @@
+package git
+
+import (
+	"os/exec"
+	"testing"
+	"time"
+)
+
+func TestListCommitsExec(t *testing.T) {
+	repoDir := setupTestRepo(t)
+	createTestCommit(t, repoDir, "main", "initial commit")
+	createTestCommit(t, repoDir, "feature", "feat: add login")
+	newest := createTestCommit(t, repoDir, "feature", "fix login redirect\n\nThe redirect looped on expired sessions.")
+
+	commits, err := ListCommitsExec(repoDir, "main", "feature")
+	if err != nil {
+		t.Fatalf("ListCommitsExec() error = %v", err)
+	}
+	if len(commits) != 2 {
+		t.Fatalf("ListCommitsExec() returned %d commits, want 2", len(commits))
+	}
+
+	if commits[0].Hash != newest {
+		t.Errorf("commits[0].Hash = %s, want %s", commits[0].Hash, newest)
+	}
+	if commits[0].Subject != "fix login redirect" {
+		t.Errorf("commits[0].Subject = %q", commits[0].Subject)
+	}
+	if commits[0].Body != "The redirect looped on expired sessions." {
+		t.Errorf("commits[0].Body = %q", commits[0].Body)
+	}
+	if commits[1].Subject != "feat: add login" || commits[1].Body != "" {
+		t.Errorf("commits[1] = %+v", commits[1])
+	}
+
+	if _, err := ListCommitsExec(repoDir, "missing", "feature"); err == nil {
+		t.Error("ListCommitsExec() with an unknown base should fail")
+	}
+}
+
+func TestFirstCommitExec(t *testing.T) {
+	repoDir := setupTestRepo(t)
+	createTestCommit(t, repoDir, "main", "initial commit")
+	first := createTestCommit(t, repoDir, "feature", "feat: add login\n\nUsers can sign in with their email.")
+	createTestCommit(t, repoDir, "feature", "fix typo")
+
+	commit, err := FirstCommitExec(repoDir, "main", "feature")
+	if err != nil {
+		t.Fatalf("FirstCommitExec() error = %v", err)
+	}
+	if commit.Hash != first {
+		t.Errorf("FirstCommitExec().Hash = %s, want %s", commit.Hash, first)
+	}
+	if commit.Subject != "feat: add login" || commit.Body != "Users can sign in with their email." {
+		t.Errorf("FirstCommitExec() = %+v", commit)
+	}
+
+	if _, err := FirstCommitExec(repoDir, "feature", "main"); err == nil {
+		t.Error("FirstCommitExec() with no commits in the range should fail")
+	}
+}
+
+func TestCommitDateExec(t *testing.T) {
+	repoDir := setupTestRepo(t)
+	createTestCommit(t, repoDir, "main", "initial commit")
+
+	cmd := exec.Command("git", "tag", "-a", "v1.0.0", "-m", "release")
+	cmd.Dir = repoDir
+	if err := cmd.Run(); err != nil {
+		t.Fatalf("Failed to tag: %v", err)
+	}
+
+	date, err := CommitDateExec(repoDir, "v1.0.0")
+	if err != nil {
+		t.Fatalf("CommitDateExec() error = %v", err)
+	}
+	if time.Since(date) > time.Hour || date.After(time.Now().Add(time.Minute)) {
+		t.Errorf("CommitDateExec() = %v, want the commit time", date)
+	}
+
+	if _, err := CommitDateExec(repoDir, "v9.9.9"); err == nil {
+		t.Error("CommitDateExec() with an unknown tag should fail")
+	}
+}
+
+func TestCommittersExec(t *testing.T) {
+	repoDir := setupTestRepo(t)
+	createTestCommit(t, repoDir, "main", "initial commit")
+
+	cmd := exec.Command("git", "commit", "--amend", "--no-edit", "--author", "Jane Doe <jane@example.com>")
+	cmd.Dir = repoDir
+	if err := cmd.Run(); err != nil {
+		t.Fatalf("Failed to amend the author: %v", err)
+	}
+
+	head, err := ResolveCommitExec(repoDir, "HEAD")
+	if err != nil {
+		t.Fatalf("ResolveCommitExec() error = %v", err)
+	}
+
+	missing := "0123456789abcdef0123456789abcdef01234567"
+	committers, err := CommittersExec(repoDir, []string{missing, head})
+	if err != nil {
+		t.Fatalf("CommittersExec() error = %v", err)
+	}
+	if want := (Identity{Name: "Test User", Email: "test@example.com"}); committers[head] != want {
+		t.Errorf("CommittersExec()[HEAD] = %+v, want %+v", committers[head], want)
+	}
+	if _, ok := committers[missing]; ok {
+		t.Error("CommittersExec() should leave out commits missing from the repository")
+	}
+}
+
+func TestResolveCommitExec(t *testing.T) {
+	repoDir := setupTestRepo(t)
+	hash := createTestCommit(t, repoDir, "main", "initial commit")
+
+	resolved, err := ResolveCommitExec(repoDir, hash[:7])
+	if err != nil {
+		t.Fatalf("ResolveCommitExec() error = %v", err)
+	}
+	if resolved != hash {
+		t.Errorf("ResolveCommitExec() = %s, want %s", resolved, hash)
+	}
+
+	if _, err := ResolveCommitExec(repoDir, "deadbeef"); err == nil {
+		t.Error("ResolveCommitExec() with an unknown SHA should fail")
+	}
+}
+
+func TestInferBaseBranchExec(t *testing.T) {
+	repoDir := setupTestRepo(t)
+	createTestCommit(t, repoDir, "main", "initial commit")
+	createTestCommit(t, repoDir, "feature-a", "feat: a1")
+	createTestCommit(t, repoDir, "feature-a", "feat: a2")
+	createTestCommit(t, repoDir, "feature-b", "feat: b1")
+
+	cmd := exec.Command("git", "branch", "release", "main")
+	cmd.Dir = repoDir
+	if err := cmd.Run(); err != nil {
+		t.Fatalf("Failed to create release branch: %v", err)
+	}
+
+	tests := []struct {
+		head      string
+		preferred string
+		want      string
+	}{
+		{"feature-b", "main", "feature-a"},
+		// feature-b contains feature-a, so it can't be its base; main and
+		// release tie and the preferred branch wins
+		{"feature-a", "main", "main"},
+		{"feature-a", "release", "release"},
+	}
+	for _, tt := range tests {
+		got, err := InferBaseBranchExec(repoDir, tt.head, tt.preferred)
+		if err != nil {
+			t.Fatalf("InferBaseBranchExec(%s) error = %v", tt.head, err)
+		}
+		if got != tt.want {
+			t.Errorf("InferBaseBranchExec(%s, %s) = %q, want %q", tt.head, tt.preferred, got, tt.want)
+		}
+	}
+
+	if _, err := InferBaseBranchExec(repoDir, "missing", "main"); err == nil {
+		t.Error("InferBaseBranchExec() with an unknown head should fail")
+	}
+}
@@ -0,0 +1,57 @@
+package git
+
+import (
+	"strings"
+)
+
+// Identity is a git author or committer split into name and email
+type Identity struct {
+	Name  string `json:"name,omitempty" yaml:"name,omitempty"`
+	Email string `json:"email,omitempty" yaml:"email,omitempty"`
+}
+
+// ParseIdentity splits a raw git identity such as "Jane Doe <jane@example.com>"
+// into its name and email. It accepts a bare name, a bare email, an email in
+// angle brackets and a missing closing bracket; whitespace is trimmed.
+func ParseIdentity(raw string) Identity {
+	raw = strings.TrimSpace(raw)
+
+	open := strings.LastIndex(raw, "<")
+	if open < 0 {
+		if strings.Contains(raw, "@") && !strings.Contains(raw, " ") {
+			return Identity{Email: raw}
+		}
+		return Identity{Name: raw}
+	}
+
+	name := strings.TrimSpace(raw[:open])
+	email := raw[open+1:]
+	if end := strings.Index(email, ">"); end >= 0 {
+		email = email[:end]
+	}
+	return Identity{
+		Name:  strings.Trim(name, `"`),
+		Email: strings.TrimSpace(email),
+	}
+}
+
+// String formats the identity the way git does
+func (i Identity) String() string {
+	switch {
+	case i.Name == "":
+		return i.Email
+	case i.Email == "":
+		return i.Name
+	default:
+		return i.Name + " <" + i.Email + ">"
+	}
+}
+
+// Same reports whether two identities are the same person: the same email,
+// ignoring case, or the same name when either email is unknown
+func (i Identity) Same(other Identity) bool {
+	if i.Email != "" && other.Email != "" {
+		return strings.EqualFold(i.Email, other.Email)
+	}
+	return i.Name == other.Name
+}
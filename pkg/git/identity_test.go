@@ -0,0 +1,45 @@
+package git
+
+import "testing"
+
+func TestParseIdentity(t *testing.T) {
+	tests := []struct {
+		raw  string
+		want Identity
+	}{
+		{"Jane Doe <jane@example.com>", Identity{Name: "Jane Doe", Email: "jane@example.com"}},
+		{"  Jane Doe   < jane@example.com >  ", Identity{Name: "Jane Doe", Email: "jane@example.com"}},
+		{`"Doe, Jane" <jane@example.com>`, Identity{Name: "Doe, Jane", Email: "jane@example.com"}},
+		{"<jane@example.com>", Identity{Email: "jane@example.com"}},
+		{"jane@example.com", Identity{Email: "jane@example.com"}},
+		{"Jane Doe <jane@example.com", Identity{Name: "Jane Doe", Email: "jane@example.com"}},
+		{"Jane <Doe> <jane@example.com>", Identity{Name: "Jane <Doe>", Email: "jane@example.com"}},
+		{"Jane Doe", Identity{Name: "Jane Doe"}},
+		{"", Identity{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.raw, func(t *testing.T) {
+			if got := ParseIdentity(tt.raw); got != tt.want {
+				t.Errorf("ParseIdentity(%q) = %+v, want %+v", tt.raw, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIdentity_Same(t *testing.T) {
+	jane := Identity{Name: "Jane", Email: "jane@example.com"}
+
+	if !jane.Same(Identity{Name: "Jane D.", Email: "JANE@example.com"}) {
+		t.Error("identities with the same email should match")
+	}
+	if jane.Same(Identity{Name: "Jane", Email: "bot@example.com"}) {
+		t.Error("identities with different emails should not match")
+	}
+	if !jane.Same(Identity{Name: "Jane"}) {
+		t.Error("without an email, identities with the same name should match")
+	}
+	if got := jane.String(); got != "Jane <jane@example.com>" {
+		t.Errorf("String() = %q", got)
+	}
+}
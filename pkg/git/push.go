@@ -0,0 +1,109 @@
+package git
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// PushOptions controls PushBranchExec
+type PushOptions struct {
+	Remote   string    // defaults to origin
+	NoVerify bool      // skip the pre-push hook
+	Output   io.Writer // receives git's output as it arrives; nil discards it
+}
+
+// PushError is returned when git push fails. Output holds everything git
+// printed, including hook and remote messages.
+type PushError struct {
+	Output       string
+	HookRejected bool
+	Err          error
+}
+
+func (e *PushError) Error() string {
+	switch {
+	case e.HookRejected:
+		return fmt.Sprintf("pre-push hook rejected the push (%v)", e.Err)
+	case strings.Contains(e.Output, "[remote rejected]"):
+		return fmt.Sprintf("remote rejected the push (%v)", e.Err)
+	case strings.Contains(e.Output, "[rejected]"):
+		return fmt.Sprintf("push rejected: the remote branch has commits you don't have locally (%v)", e.Err)
+	default:
+		return fmt.Sprintf("git push failed: %v", e.Err)
+	}
+}
+
+func (e *PushError) Unwrap() error {
+	return e.Err
+}
+
+// PushBranchExec pushes branch and sets its upstream, streaming git's
+// stdout and stderr to opts.Output so hook output and the remote's messages
+// (such as "create pull request" links) reach the user.
+func PushBranchExec(repoDir, branch string, opts PushOptions) error {
+	remote := opts.Remote
+	if remote == "" {
+		remote = "origin"
+	}
+
+	var captured bytes.Buffer
+	var out io.Writer = &captured
+	if opts.Output != nil {
+		out = io.MultiWriter(opts.Output, &captured)
+	}
+
+	cmd := exec.Command("git", pushArgs(remote, branch, opts.NoVerify)...)
+	cmd.Dir = repoDir
+	cmd.Stdout = out
+	cmd.Stderr = out
+
+	if err := cmd.Run(); err != nil {
+		output := captured.String()
+		return &PushError{
+			Output:       output,
+			HookRejected: !opts.NoVerify && hookRejected(output) && hasPrePushHook(repoDir),
+			Err:          err,
+		}
+	}
+	return nil
+}
+
+func pushArgs(remote, branch string, noVerify bool) []string {
+	args := []string{"push", "--set-upstream"}
+	if noVerify {
+		args = append(args, "--no-verify")
+	}
+	return append(args, remote, branch)
+}
+
+// hookRejected reports whether git's output reads like a push stopped by the
+// pre-push hook: git gives up on the refs without the remote having refused
+// any of them. Connection and authentication failures end before that
+// message, so a hook that merely exists isn't blamed for them.
+func hookRejected(output string) bool {
+	return strings.Contains(output, "failed to push some refs") && !strings.Contains(output, "rejected]")
+}
+
+// hasPrePushHook reports whether git would run a pre-push hook, honouring
+// core.hooksPath
+func hasPrePushHook(repoDir string) bool {
+	cmd := exec.Command("git", "rev-parse", "--git-path", "hooks/pre-push")
+	cmd.Dir = repoDir
+	out, err := cmd.Output()
+	if err != nil {
+		return false
+	}
+
+	hook := strings.TrimSpace(string(out))
+	if !filepath.IsAbs(hook) {
+		hook = filepath.Join(repoDir, hook)
+	}
+
+	info, err := os.Stat(hook)
+	return err == nil && info.Mode()&0111 != 0
+}
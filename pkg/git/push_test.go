@@ -0,0 +1,142 @@
+package git
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// setupPushRepo returns a repository with one commit on main and a bare
+// origin to push to
+func setupPushRepo(t *testing.T) string {
+	t.Helper()
+	repoDir := setupTestRepo(t)
+	createTestCommit(t, repoDir, "main", "initial commit")
+
+	remoteDir := t.TempDir()
+	for _, args := range [][]string{
+		{"init", "--bare", remoteDir},
+		{"-C", repoDir, "remote", "add", "origin", remoteDir},
+	} {
+		if out, err := exec.Command("git", args...).CombinedOutput(); err != nil {
+			t.Fatalf("git %v failed: %v\n%s", args, err, out)
+		}
+	}
+	return repoDir
+}
+
+func writePrePushHook(t *testing.T, repoDir, script string) {
+	t.Helper()
+	hook := filepath.Join(repoDir, ".git", "hooks", "pre-push")
+	if err := os.MkdirAll(filepath.Dir(hook), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(hook, []byte("#!/bin/sh\n"+script+"\n"), 0755); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestPushBranchExec(t *testing.T) {
+	repoDir := setupPushRepo(t)
+	branch := "main"
+
+	var out bytes.Buffer
+	if err := PushBranchExec(repoDir, branch, PushOptions{Output: &out}); err != nil {
+		t.Fatalf("push failed: %v\n%s", err, out.String())
+	}
+
+	upstream, err := exec.Command("git", "-C", repoDir, "rev-parse", "--abbrev-ref", branch+"@{upstream}").Output()
+	if err != nil {
+		t.Fatalf("upstream not set: %v", err)
+	}
+	if got := strings.TrimSpace(string(upstream)); got != "origin/"+branch {
+		t.Errorf("upstream = %q, want origin/%s", got, branch)
+	}
+}
+
+func TestPushBranchExec_HookRejected(t *testing.T) {
+	repoDir := setupPushRepo(t)
+	branch := "main"
+	writePrePushHook(t, repoDir, `echo "lint failed: fix main.go" >&2; exit 1`)
+
+	var out bytes.Buffer
+	err := PushBranchExec(repoDir, branch, PushOptions{Output: &out})
+
+	var pushErr *PushError
+	if !errors.As(err, &pushErr) {
+		t.Fatalf("expected *PushError, got %v", err)
+	}
+	if !pushErr.HookRejected {
+		t.Errorf("expected HookRejected, output:\n%s", pushErr.Output)
+	}
+	if !strings.Contains(out.String(), "lint failed: fix main.go") {
+		t.Errorf("hook output was not streamed, got:\n%s", out.String())
+	}
+	if !strings.Contains(pushErr.Output, "lint failed") {
+		t.Errorf("hook output was not captured, got:\n%s", pushErr.Output)
+	}
+
+	if err := PushBranchExec(repoDir, branch, PushOptions{NoVerify: true}); err != nil {
+		t.Fatalf("push with NoVerify failed: %v", err)
+	}
+}
+
+func TestPushBranchExec_FailureWithHookIsNotBlamedOnIt(t *testing.T) {
+	repoDir := setupPushRepo(t)
+	writePrePushHook(t, repoDir, "exit 0")
+	if out, err := exec.Command("git", "-C", repoDir, "remote", "set-url", "origin", filepath.Join(t.TempDir(), "missing")).CombinedOutput(); err != nil {
+		t.Fatalf("git remote set-url failed: %v\n%s", err, out)
+	}
+
+	err := PushBranchExec(repoDir, "main", PushOptions{})
+
+	var pushErr *PushError
+	if !errors.As(err, &pushErr) {
+		t.Fatalf("expected *PushError, got %v", err)
+	}
+	if pushErr.HookRejected {
+		t.Errorf("an unreachable remote was blamed on the hook, output:\n%s", pushErr.Output)
+	}
+	if !strings.Contains(err.Error(), "exit status") {
+		t.Errorf("Error() = %q, want the git exit status", err.Error())
+	}
+}
+
+func TestHookRejected(t *testing.T) {
+	tests := []struct {
+		name   string
+		output string
+		want   bool
+	}{
+		{"hook failed", "lint failed\nerror: failed to push some refs to 'origin'\n", true},
+		{"non-fast-forward", " ! [rejected]        main -> main (fetch first)\nerror: failed to push some refs to 'origin'\n", false},
+		{"remote hook declined", " ! [remote rejected] main -> main (pre-receive hook declined)\nerror: failed to push some refs to 'origin'\n", false},
+		{"authentication", "fatal: Authentication failed for 'https://bitbucket.org/ws/repo.git/'\n", false},
+	}
+
+	for _, tt := range tests {
+		if got := hookRejected(tt.output); got != tt.want {
+			t.Errorf("%s: hookRejected() = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestPushArgs(t *testing.T) {
+	tests := []struct {
+		noVerify bool
+		want     string
+	}{
+		{false, "push --set-upstream origin feature"},
+		{true, "push --set-upstream --no-verify origin feature"},
+	}
+
+	for _, tt := range tests {
+		if got := strings.Join(pushArgs("origin", "feature", tt.noVerify), " "); got != tt.want {
+			t.Errorf("pushArgs(noVerify=%v) = %q, want %q", tt.noVerify, got, tt.want)
+		}
+	}
+}
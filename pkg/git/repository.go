@@ -46,6 +46,7 @@ var (
 	ErrNotGitRepository = errors.New("not a git repository")
 	ErrNoRemotes        = errors.New("no remotes found")
 	ErrInvalidRemoteURL = errors.New("invalid remote URL format")
+	ErrDetachedHEAD     = errors.New("HEAD is detached, not on a branch")
 )
 
 // NewRepository creates a new Repository instance from the given path
@@ -302,25 +303,12 @@ func FindRepositoryRoot(startPath string) (string, error) {
 	return "", ErrNotGitRepository
 }
 
+// GetDiff returns the changes of targetBranch since it forked from
+// baseBranch, as git diff base...target shows them
 func (r *Repository) GetDiff(baseBranch, targetBranch string) (string, error) {
-	baseHash, err := r.repo.ResolveRevision(plumbing.Revision(baseBranch))
-	if err != nil {
-		return "", fmt.Errorf("failed to resolve base branch %s: %w", baseBranch, err)
-	}
-
-	targetHash, err := r.repo.ResolveRevision(plumbing.Revision(targetBranch))
-	if err != nil {
-		return "", fmt.Errorf("failed to resolve target branch %s: %w", targetBranch, err)
-	}
-
-	baseCommit, err := r.repo.CommitObject(*baseHash)
-	if err != nil {
-		return "", fmt.Errorf("failed to get base commit: %w", err)
-	}
-
-	targetCommit, err := r.repo.CommitObject(*targetHash)
+	baseCommit, targetCommit, err := r.diffCommits(baseBranch, targetBranch)
 	if err != nil {
-		return "", fmt.Errorf("failed to get target commit: %w", err)
+		return "", err
 	}
 
 	baseTree, err := baseCommit.Tree()
@@ -341,25 +329,11 @@ func (r *Repository) GetDiff(baseBranch, targetBranch string) (string, error) {
 	return patch.String(), nil
 }
 
+// GetChangedFiles returns the files GetDiff shows changes to
 func (r *Repository) GetChangedFiles(baseBranch, targetBranch string) ([]string, error) {
-	baseHash, err := r.repo.ResolveRevision(plumbing.Revision(baseBranch))
+	baseCommit, targetCommit, err := r.diffCommits(baseBranch, targetBranch)
 	if err != nil {
-		return nil, fmt.Errorf("failed to resolve base branch %s: %w", baseBranch, err)
-	}
-
-	targetHash, err := r.repo.ResolveRevision(plumbing.Revision(targetBranch))
-	if err != nil {
-		return nil, fmt.Errorf("failed to resolve target branch %s: %w", targetBranch, err)
-	}
-
-	baseCommit, err := r.repo.CommitObject(*baseHash)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get base commit: %w", err)
-	}
-
-	targetCommit, err := r.repo.CommitObject(*targetHash)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get target commit: %w", err)
+		return nil, err
 	}
 
 	baseTree, err := baseCommit.Tree()
@@ -390,6 +364,41 @@ func (r *Repository) GetChangedFiles(baseBranch, targetBranch string) ([]string,
 	return files, nil
 }
 
+// diffCommits resolves the commits a diff of targetBranch against
+// baseBranch compares: their merge base, and the tip of targetBranch. Work
+// that landed on baseBranch after targetBranch forked is left out.
+func (r *Repository) diffCommits(baseBranch, targetBranch string) (*object.Commit, *object.Commit, error) {
+	baseHash, err := r.repo.ResolveRevision(plumbing.Revision(baseBranch))
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to resolve base branch %s: %w", baseBranch, err)
+	}
+
+	targetHash, err := r.repo.ResolveRevision(plumbing.Revision(targetBranch))
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to resolve target branch %s: %w", targetBranch, err)
+	}
+
+	baseCommit, err := r.repo.CommitObject(*baseHash)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get base commit: %w", err)
+	}
+
+	targetCommit, err := r.repo.CommitObject(*targetHash)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get target commit: %w", err)
+	}
+
+	mergeBases, err := baseCommit.MergeBase(targetCommit)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to find the merge base of %s and %s: %w", baseBranch, targetBranch, err)
+	}
+	if len(mergeBases) > 0 {
+		baseCommit = mergeBases[0]
+	}
+
+	return baseCommit, targetCommit, nil
+}
+
 func (r *Repository) GetCommitMessages(baseBranch, targetBranch string) ([]string, error) {
 	baseHash, err := r.repo.ResolveRevision(plumbing.Revision(baseBranch))
 	if err != nil {
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"os/exec"
 	"path/filepath"
+	"strings"
 	"testing"
 )
 
@@ -357,3 +358,43 @@ func TestNewRepositoryWorktree(t *testing.T) {
 		t.Errorf("worktree repo name = %q, want %q", repo.GetName(), "repo")
 	}
 }
+
+func TestGetChangedFiles_SinceMergeBase(t *testing.T) {
+	repoDir := setupTestRepo(t)
+	createTestCommit(t, repoDir, "main", "initial commit")
+	createTestCommit(t, repoDir, "feature", "feat: add login")
+
+	cmd := exec.Command("git", "checkout", "main")
+	cmd.Dir = repoDir
+	if err := cmd.Run(); err != nil {
+		t.Fatalf("Failed to switch to main: %v", err)
+	}
+	createTestCommit(t, repoDir, "main", "fix: landed after feature forked")
+
+	cmd = exec.Command("git", "remote", "add", "origin", "git@bitbucket.org:ws/repo.git")
+	cmd.Dir = repoDir
+	if err := cmd.Run(); err != nil {
+		t.Fatalf("Failed to add remote: %v", err)
+	}
+
+	repo, err := NewRepository(repoDir)
+	if err != nil {
+		t.Fatalf("NewRepository() error = %v", err)
+	}
+
+	files, err := repo.GetChangedFiles("main", "feature")
+	if err != nil {
+		t.Fatalf("GetChangedFiles() error = %v", err)
+	}
+	if len(files) != 1 || !strings.HasPrefix(filepath.Base(files[0]), "test_feature_") {
+		t.Errorf("GetChangedFiles() = %v, want only the feature branch's file", files)
+	}
+
+	diff, err := repo.GetDiff("main", "feature")
+	if err != nil {
+		t.Fatalf("GetDiff() error = %v", err)
+	}
+	if strings.Contains(diff, "test_main_") {
+		t.Errorf("GetDiff() includes changes made on main:\n%s", diff)
+	}
+}
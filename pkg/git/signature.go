@@ -0,0 +1,52 @@
+package git
+
+import (
+	"os/exec"
+	"strings"
+)
+
+// SignatureStatus is the verification state of a commit signature.
+type SignatureStatus string
+
+const (
+	SignatureVerified SignatureStatus = "verified"
+	SignatureSigned   SignatureStatus = "signed"
+	SignatureBad      SignatureStatus = "bad"
+	SignatureUnsigned SignatureStatus = "unsigned"
+	SignatureUnknown  SignatureStatus = "unknown"
+)
+
+// ParseSignatureCode maps git's %G? placeholder onto a SignatureStatus.
+// Signatures git can check but not fully trust (unknown validity, expired or
+// revoked keys, missing public key) count as signed rather than verified.
+func ParseSignatureCode(code string) SignatureStatus {
+	switch strings.TrimSpace(code) {
+	case "G":
+		return SignatureVerified
+	case "U", "X", "Y", "R", "E":
+		return SignatureSigned
+	case "B":
+		return SignatureBad
+	case "N":
+		return SignatureUnsigned
+	default:
+		return SignatureUnknown
+	}
+}
+
+// CommitSignatureStatus checks the signature of a commit with the local git
+// installation and its configured keys. Commits that are not present locally
+// report SignatureUnknown.
+func CommitSignatureStatus(hash string) SignatureStatus {
+	if hash == "" {
+		return SignatureUnknown
+	}
+
+	cmd := exec.Command("git", "log", "-1", "--format=%G?", hash, "--")
+	out, err := cmd.Output()
+	if err != nil {
+		return SignatureUnknown
+	}
+
+	return ParseSignatureCode(string(out))
+}
@@ -0,0 +1,34 @@
+package git
+
+import "testing"
+
+func TestParseSignatureCode(t *testing.T) {
+	tests := []struct {
+		code string
+		want SignatureStatus
+	}{
+		{"G", SignatureVerified},
+		{"G\n", SignatureVerified},
+		{"U", SignatureSigned},
+		{"X", SignatureSigned},
+		{"Y", SignatureSigned},
+		{"R", SignatureSigned},
+		{"E", SignatureSigned},
+		{"B", SignatureBad},
+		{"N", SignatureUnsigned},
+		{"", SignatureUnknown},
+		{"?", SignatureUnknown},
+	}
+
+	for _, tt := range tests {
+		if got := ParseSignatureCode(tt.code); got != tt.want {
+			t.Errorf("ParseSignatureCode(%q) = %q, want %q", tt.code, got, tt.want)
+		}
+	}
+}
+
+func TestCommitSignatureStatus_EmptyHash(t *testing.T) {
+	if got := CommitSignatureStatus(""); got != SignatureUnknown {
+		t.Errorf("CommitSignatureStatus(\"\") = %q, want %q", got, SignatureUnknown)
+	}
+}
@@ -0,0 +1,44 @@
+package output
+
+import (
+	"fmt"
+	"strings"
+)
+
+// CompactFormatter writes one terse line per item, for status bars, prompts
+// and quick glances. Commands render their items to lines themselves and
+// pass them as a []string.
+type CompactFormatter struct {
+	*BaseFormatter
+}
+
+// NewCompactFormatter creates a new compact formatter
+func NewCompactFormatter(opts *FormatterOptions) *CompactFormatter {
+	return &CompactFormatter{
+		BaseFormatter: NewBaseFormatter(opts),
+	}
+}
+
+// Format writes each line of data, which must be a string or []string
+func (c *CompactFormatter) Format(data interface{}) error {
+	var lines []string
+	switch v := data.(type) {
+	case nil:
+		return nil
+	case string:
+		lines = []string{v}
+	case []string:
+		lines = v
+	default:
+		return fmt.Errorf("compact output is not supported for %T", data)
+	}
+
+	for _, line := range lines {
+		// Every item stays on exactly one line
+		line = strings.ReplaceAll(strings.TrimRight(line, "\r\n"), "\n", " ")
+		if _, err := c.WriteString(line + "\n"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
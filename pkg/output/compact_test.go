@@ -0,0 +1,45 @@
+package output
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestCompactFormatter_Format(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    interface{}
+		want    string
+		wantErr bool
+	}{
+		{name: "lines", data: []string{"#2 OPEN a → main", "#1 MERGED b → main"}, want: "#2 OPEN a → main\n#1 MERGED b → main\n"},
+		{name: "single line", data: "#7 FAILED main", want: "#7 FAILED main\n"},
+		{name: "embedded newline", data: []string{"#3 OPEN c → main  \"two\nlines\""}, want: "#3 OPEN c → main  \"two lines\"\n"},
+		{name: "nil", data: nil, want: ""},
+		{name: "unsupported", data: map[string]int{"a": 1}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			formatter, err := NewFormatter(FormatCompact, &FormatterOptions{Writer: &buf})
+			if err != nil {
+				t.Fatalf("NewFormatter() error = %v", err)
+			}
+
+			err = formatter.Format(tt.data)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Format() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got := buf.String(); got != tt.want {
+				t.Errorf("Format() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateFormat_RejectsCompact(t *testing.T) {
+	if err := ValidateFormat(string(FormatCompact)); err == nil {
+		t.Error("compact is offered per command and must not be accepted as a default format")
+	}
+}
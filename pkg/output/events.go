@@ -0,0 +1,86 @@
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+)
+
+// EventsJSONL is the --events format: one JSON object per line
+const EventsJSONL = "jsonl"
+
+// Progress events emitted by watch and follow loops with --events jsonl
+const (
+	// EventWatchStarted opens every stream, with the pipeline or pull request
+	EventWatchStarted = "watch_started"
+	// EventStepStarted is sent when a step starts running
+	EventStepStarted = "step_started"
+	// EventStepCompleted is sent once per step, with its result as Status
+	EventStepCompleted = "step_completed"
+	// EventLogLine carries one line of a step's log
+	EventLogLine = "log_line"
+	// EventCheckUpdated is sent when a pull request check changes status
+	EventCheckUpdated = "check_updated"
+	// EventPipelineCompleted closes a pipeline stream, with its result
+	EventPipelineCompleted = "pipeline_completed"
+	// EventChecksCompleted closes a pull request checks stream
+	EventChecksCompleted = "checks_completed"
+	// EventInterrupted is sent when the user stops the stream
+	EventInterrupted = "interrupted"
+)
+
+// Event is one progress event. Fields that don't apply to an event are
+// omitted; Event and TS are always present.
+type Event struct {
+	Event       string    `json:"event"`
+	TS          time.Time `json:"ts"`
+	Pipeline    int       `json:"pipeline,omitempty"`
+	PullRequest int       `json:"pull_request,omitempty"`
+	Step        string    `json:"step,omitempty"`
+	Check       string    `json:"check,omitempty"`
+	Status      string    `json:"status,omitempty"`
+	Line        string    `json:"line,omitempty"`
+	Message     string    `json:"message,omitempty"`
+}
+
+// EventWriter writes events as newline-delimited JSON. It is safe for
+// concurrent use, and a nil EventWriter discards events so callers can emit
+// unconditionally.
+type EventWriter struct {
+	mu      sync.Mutex
+	encoder *json.Encoder
+	now     func() time.Time
+}
+
+// NewEventWriter creates an EventWriter writing to w
+func NewEventWriter(w io.Writer) *EventWriter {
+	encoder := json.NewEncoder(w)
+	encoder.SetEscapeHTML(false)
+	return &EventWriter{encoder: encoder, now: time.Now}
+}
+
+// Emit writes event, stamping it with the current time when TS is unset
+func (e *EventWriter) Emit(event Event) error {
+	if e == nil {
+		return nil
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if event.TS.IsZero() {
+		event.TS = e.now().UTC()
+	}
+	return e.encoder.Encode(event)
+}
+
+// ValidateEventsFormat checks the value of an --events flag; empty means
+// events are off
+func ValidateEventsFormat(format string) error {
+	if format != "" && format != EventsJSONL {
+		return fmt.Errorf("unsupported events format %q (supported: %s)", format, EventsJSONL)
+	}
+	return nil
+}
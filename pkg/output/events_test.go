@@ -0,0 +1,41 @@
+package output
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEventWriter_Emit(t *testing.T) {
+	var buf bytes.Buffer
+	w := NewEventWriter(&buf)
+	w.now = func() time.Time { return time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC) }
+
+	require.NoError(t, w.Emit(Event{Event: EventStepCompleted, Pipeline: 42, Step: "Run Tests", Status: "FAILED"}))
+	require.NoError(t, w.Emit(Event{Event: EventLogLine, Pipeline: 42, Step: "Run Tests", Line: "a <b> & c"}))
+
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	require.Len(t, lines, 2)
+	assert.Equal(t, `{"event":"step_completed","ts":"2026-01-02T03:04:05Z","pipeline":42,"step":"Run Tests","status":"FAILED"}`, lines[0])
+
+	var event Event
+	require.NoError(t, json.Unmarshal([]byte(lines[1]), &event))
+	assert.Equal(t, "a <b> & c", event.Line)
+	assert.Contains(t, lines[1], `"line":"a <b> & c"`)
+}
+
+func TestEventWriter_Nil(t *testing.T) {
+	var w *EventWriter
+	assert.NoError(t, w.Emit(Event{Event: EventWatchStarted}))
+}
+
+func TestValidateEventsFormat(t *testing.T) {
+	assert.NoError(t, ValidateEventsFormat(""))
+	assert.NoError(t, ValidateEventsFormat(EventsJSONL))
+	assert.Error(t, ValidateEventsFormat("json"))
+}
@@ -23,6 +23,10 @@ const (
 	FormatTable Format = "table"
 	FormatJSON  Format = "json"
 	FormatYAML  Format = "yaml"
+
+	// FormatCompact prints one line per item. Only list commands offer it,
+	// so it is not one of the formats ValidateFormat accepts as a default.
+	FormatCompact Format = "compact"
 )
 
 // FormatterOptions holds configuration for formatters
@@ -50,6 +54,8 @@ func NewFormatter(format Format, opts *FormatterOptions) (Formatter, error) {
 		return NewJSONFormatter(opts), nil
 	case FormatYAML:
 		return NewYAMLFormatter(opts), nil
+	case FormatCompact:
+		return NewCompactFormatter(opts), nil
 	default:
 		return nil, fmt.Errorf("unsupported output format: %s", format)
 	}
@@ -0,0 +1,214 @@
+package output
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/carlosarraes/bt/pkg/utils"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// minColumnWidth is the narrowest a column is squeezed to when a table
+// doesn't fit, unless its header and cells are narrower still
+const minColumnWidth = 8
+
+// columnGap separates the columns of RenderSimpleTable
+const columnGap = "  "
+
+// Table layout, set once at startup by SetTableLayout
+var (
+	layoutMaxWidth   int
+	layoutNoTruncate bool
+)
+
+// pagedTerminal is the terminal the pager draws on while stdout feeds it
+var pagedTerminal *os.File
+
+// SetPagedTerminal makes AvailableWidth measure terminal while stdout is
+// routed through a pager, since stdout is then a pipe; nil measures stdout
+// again
+func SetPagedTerminal(terminal *os.File) {
+	pagedTerminal = terminal
+}
+
+// SetTableLayout configures how tables fit the screen. A positive maxWidth
+// (ui.max_width) replaces the detected terminal width; noTruncate
+// (--no-truncate) prints every cell in full.
+func SetTableLayout(maxWidth int, noTruncate bool) {
+	layoutMaxWidth = maxWidth
+	layoutNoTruncate = noTruncate
+}
+
+// AvailableWidth returns the width tables should fit in, or 0 for no limit:
+// ui.max_width when set, otherwise the width of the terminal on stdout, or
+// of the pager's terminal. Output that isn't going to a terminal is never
+// truncated, so piping a table keeps every value intact.
+func AvailableWidth() int {
+	if layoutNoTruncate {
+		return 0
+	}
+	if layoutMaxWidth > 0 {
+		return layoutMaxWidth
+	}
+	if pagedTerminal != nil {
+		return utils.TerminalWidth(pagedTerminal)
+	}
+	return utils.TerminalWidth(os.Stdout)
+}
+
+// Truncate shortens s to width display columns, ending it with "...". A
+// width of 0 or less leaves s alone, as do cells carrying ANSI colors,
+// which can't be cut safely.
+func Truncate(s string, width int) string {
+	if width <= 3 || lipgloss.Width(s) <= width || strings.Contains(s, "\x1b") {
+		return s
+	}
+
+	var b strings.Builder
+	used := 0
+	for _, r := range s {
+		w := lipgloss.Width(string(r))
+		if used+w > width-3 {
+			break
+		}
+		b.WriteRune(r)
+		used += w
+	}
+	return b.String() + "..."
+}
+
+// FitWidth truncates s to what is left of the available width after
+// reserved columns, leaving it whole when there is no limit
+func FitWidth(s string, reserved int) string {
+	available := AvailableWidth()
+	if available <= 0 {
+		return s
+	}
+	return Truncate(s, available-reserved)
+}
+
+// fitColumns shrinks widths until they and the overhead between them fit in
+// available, taking a column at a time from the widest. Columns never get
+// narrower than minColumnWidth or their header, whichever is less.
+func fitColumns(widths, headerWidths []int, overhead, available int) []int {
+	fitted := append([]int(nil), widths...)
+	if available <= 0 {
+		return fitted
+	}
+
+	total := overhead
+	for _, w := range fitted {
+		total += w
+	}
+
+	for total > available {
+		widest := -1
+		for i, w := range fitted {
+			if w > columnFloor(widths[i], headerWidths[i]) && (widest < 0 || w > fitted[widest]) {
+				widest = i
+			}
+		}
+		if widest < 0 {
+			break
+		}
+		fitted[widest]--
+		total--
+	}
+	return fitted
+}
+
+func columnFloor(width, headerWidth int) int {
+	floor := minColumnWidth
+	if headerWidth > floor {
+		floor = headerWidth
+	}
+	if width < floor {
+		return width
+	}
+	return floor
+}
+
+// pad left-aligns s in width display columns
+func pad(s string, width int) string {
+	if gap := width - lipgloss.Width(s); gap > 0 {
+		return s + strings.Repeat(" ", gap)
+	}
+	return s
+}
+
+// padLeft right-aligns s in width display columns
+func padLeft(s string, width int) string {
+	if gap := width - lipgloss.Width(s); gap > 0 {
+		return strings.Repeat(" ", gap) + s
+	}
+	return s
+}
+
+// BoxColumn describes a column of RenderBoxTable
+type BoxColumn struct {
+	Header string
+	// Right aligns the column to the right, for numbers
+	Right bool
+}
+
+// RenderBoxTable writes rows as a table drawn with box characters, sizing
+// the columns to their content and squeezing the widest ones when the table
+// is wider than AvailableWidth
+func RenderBoxTable(w io.Writer, columns []BoxColumn, rows [][]string) {
+	widths := make([]int, len(columns))
+	headerWidths := make([]int, len(columns))
+	for i, column := range columns {
+		headerWidths[i] = lipgloss.Width(column.Header)
+		widths[i] = headerWidths[i]
+	}
+	for _, row := range rows {
+		for i, cell := range row {
+			if i < len(widths) && lipgloss.Width(cell) > widths[i] {
+				widths[i] = lipgloss.Width(cell)
+			}
+		}
+	}
+
+	// "│ " before every cell, " " after it, and the closing "│"
+	widths = fitColumns(widths, headerWidths, 3*len(columns)+1, AvailableWidth())
+
+	border := func(left, middle, right string) {
+		segments := make([]string, len(widths))
+		for i, width := range widths {
+			segments[i] = strings.Repeat("─", width+2)
+		}
+		fmt.Fprintf(w, "%s%s%s\n", left, strings.Join(segments, middle), right)
+	}
+	line := func(cells []string, header bool) {
+		var b strings.Builder
+		for i, width := range widths {
+			cell := ""
+			if i < len(cells) {
+				cell = Truncate(cells[i], width)
+			}
+			b.WriteString("│ ")
+			if columns[i].Right && !header {
+				b.WriteString(padLeft(cell, width))
+			} else {
+				b.WriteString(pad(cell, width))
+			}
+			b.WriteString(" ")
+		}
+		fmt.Fprintf(w, "%s│\n", b.String())
+	}
+
+	headers := make([]string, len(columns))
+	for i, column := range columns {
+		headers[i] = column.Header
+	}
+
+	border("┌", "┬", "┐")
+	line(headers, true)
+	border("├", "┼", "┤")
+	for _, row := range rows {
+		line(row, false)
+	}
+	border("└", "┴", "┘")
+}
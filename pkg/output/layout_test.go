@@ -0,0 +1,106 @@
+package output
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func withTableLayout(t *testing.T, maxWidth int, noTruncate bool) {
+	t.Helper()
+	SetTableLayout(maxWidth, noTruncate)
+	t.Cleanup(func() { SetTableLayout(0, false) })
+}
+
+func TestTruncate(t *testing.T) {
+	assert.Equal(t, "hello", Truncate("hello", 10))
+	assert.Equal(t, "hello w...", Truncate("hello world", 10))
+	assert.Equal(t, "hello world", Truncate("hello world", 0))
+	assert.Equal(t, "héllo w...", Truncate("héllo wörld", 10), "counts runes, not bytes")
+	assert.Equal(t, "日本...", Truncate("日本語のテキスト", 7), "wide runes take two columns")
+	assert.Equal(t, "\x1b[31mlong colored text\x1b[0m", Truncate("\x1b[31mlong colored text\x1b[0m", 8))
+}
+
+func TestAvailableWidth(t *testing.T) {
+	withTableLayout(t, 90, false)
+	assert.Equal(t, 90, AvailableWidth())
+
+	SetTableLayout(90, true)
+	assert.Equal(t, 0, AvailableWidth(), "--no-truncate lifts the limit")
+
+	// Test output isn't a terminal, so nothing is detected
+	SetTableLayout(0, false)
+	assert.Equal(t, 0, AvailableWidth())
+}
+
+func TestFitColumns(t *testing.T) {
+	// 10 + 40 + 6 plus 4 between = 60; shrink to 40
+	fitted := fitColumns([]int{10, 40, 6}, []int{2, 5, 6}, 4, 40)
+	assert.Equal(t, []int{10, 20, 6}, fitted)
+
+	// The widest columns share the cut
+	fitted = fitColumns([]int{30, 30}, []int{4, 4}, 2, 42)
+	assert.Equal(t, []int{20, 20}, fitted)
+
+	// Columns stop at their floor even if the table still doesn't fit
+	fitted = fitColumns([]int{12, 12}, []int{10, 3}, 2, 10)
+	assert.Equal(t, []int{10, 8}, fitted)
+
+	// No limit
+	assert.Equal(t, []int{10, 40}, fitColumns([]int{10, 40}, []int{2, 5}, 2, 0))
+}
+
+func captureStdout(t *testing.T, fn func()) string {
+	t.Helper()
+	stdout := os.Stdout
+	r, w, err := os.Pipe()
+	require.NoError(t, err)
+	os.Stdout = w
+
+	fn()
+
+	w.Close()
+	os.Stdout = stdout
+	var out bytes.Buffer
+	io.Copy(&out, r)
+	return out.String()
+}
+
+func TestRenderSimpleTable_FitsWidth(t *testing.T) {
+	headers := []string{"ID", "TITLE", "STATE"}
+	rows := [][]string{{"#1", "A pull request title that is far too long for a narrow terminal", "OPEN"}}
+
+	withTableLayout(t, 40, false)
+	out := captureStdout(t, func() { require.NoError(t, RenderSimpleTable(headers, rows)) })
+	for _, line := range strings.Split(strings.TrimRight(out, "\n"), "\n") {
+		assert.LessOrEqual(t, len(line), 40, line)
+	}
+	assert.Contains(t, out, "A pull request title that ...")
+
+	SetTableLayout(40, true)
+	out = captureStdout(t, func() { require.NoError(t, RenderSimpleTable(headers, rows)) })
+	assert.Contains(t, out, rows[0][1])
+}
+
+func TestRenderBoxTable(t *testing.T) {
+	withTableLayout(t, 30, false)
+
+	var buf bytes.Buffer
+	RenderBoxTable(&buf, []BoxColumn{{Header: "File"}, {Header: "Lines", Right: true}}, [][]string{
+		{"pkg/some/deeply/nested/file.go", "12"},
+		{"main.go", "3"},
+	})
+
+	assert.Equal(t, `┌────────────────────┬───────┐
+│ File               │ Lines │
+├────────────────────┼───────┤
+│ pkg/some/deeply... │    12 │
+│ main.go            │     3 │
+└────────────────────┴───────┘
+`, buf.String())
+}
@@ -0,0 +1,71 @@
+package output
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// LiveRegion shows successive frames of a block of text, such as a status
+// table that is polled for changes. In place, each frame moves the cursor
+// back over the previous one and overwrites it, so the block stays in a
+// fixed spot on the terminal. Otherwise frames are appended, each followed
+// by a "---" separator, which suits logs and pipes.
+type LiveRegion struct {
+	w       io.Writer
+	inPlace bool
+	width   int // terminal columns, for counting wrapped lines; 0 if unknown
+	rows    int // rows the last frame took up
+}
+
+// NewLiveRegion returns a region writing to w. inPlace should only be set
+// when w is a terminal; width is its width in columns, or 0 when unknown.
+func NewLiveRegion(w io.Writer, inPlace bool, width int) *LiveRegion {
+	return &LiveRegion{w: w, inPlace: inPlace, width: width}
+}
+
+// Update replaces the previous frame with frame
+func (r *LiveRegion) Update(frame string) error {
+	frame = strings.TrimSuffix(frame, "\n")
+	if !r.inPlace {
+		_, err := fmt.Fprintf(r.w, "%s\n---\n", frame)
+		return err
+	}
+
+	var b strings.Builder
+	if r.rows > 0 {
+		// Back to the first column of the previous frame's first row
+		fmt.Fprintf(&b, "\x1b[%dF", r.rows)
+	}
+	rows := 0
+	for _, line := range strings.Split(frame, "\n") {
+		b.WriteString(line)
+		b.WriteString("\x1b[K\n") // clear what's left of the old row
+		rows += r.rowsFor(line)
+	}
+	b.WriteString("\x1b[J") // clear rows left over from a longer frame
+
+	if _, err := io.WriteString(r.w, b.String()); err != nil {
+		return err
+	}
+	r.rows = rows
+	return nil
+}
+
+// Release leaves the current frame on screen; the next Update draws below
+// whatever was printed since. Call it after writing anything else to the
+// terminal between frames.
+func (r *LiveRegion) Release() {
+	r.rows = 0
+}
+
+// rowsFor is the number of terminal rows line wraps onto
+func (r *LiveRegion) rowsFor(line string) int {
+	width := lipgloss.Width(line)
+	if r.width <= 0 || width <= r.width {
+		return 1
+	}
+	return (width + r.width - 1) / r.width
+}
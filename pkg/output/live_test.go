@@ -0,0 +1,46 @@
+package output
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLiveRegion_Append(t *testing.T) {
+	var buf bytes.Buffer
+	region := NewLiveRegion(&buf, false, 80)
+
+	require.NoError(t, region.Update("one\n"))
+	require.NoError(t, region.Update("two"))
+	assert.Equal(t, "one\n---\ntwo\n---\n", buf.String())
+}
+
+func TestLiveRegion_InPlace(t *testing.T) {
+	var buf bytes.Buffer
+	region := NewLiveRegion(&buf, true, 80)
+
+	require.NoError(t, region.Update("a\nb\n"))
+	assert.Equal(t, "a\x1b[K\nb\x1b[K\n\x1b[J", buf.String(), "the first frame draws where the cursor is")
+
+	buf.Reset()
+	require.NoError(t, region.Update("c"))
+	assert.Equal(t, "\x1b[2Fc\x1b[K\n\x1b[J", buf.String(), "later frames move up over the previous one")
+
+	buf.Reset()
+	region.Release()
+	require.NoError(t, region.Update("d"))
+	assert.Equal(t, "d\x1b[K\n\x1b[J", buf.String(), "a released frame is left alone")
+}
+
+func TestLiveRegion_CountsWrappedRows(t *testing.T) {
+	var buf bytes.Buffer
+	region := NewLiveRegion(&buf, true, 10)
+
+	require.NoError(t, region.Update(strings.Repeat("x", 25)+"\nshort"))
+	buf.Reset()
+	require.NoError(t, region.Update("next"))
+	assert.True(t, strings.HasPrefix(buf.String(), "\x1b[4F"), "a 25 column line takes 3 rows of 10: %q", buf.String())
+}
@@ -298,28 +298,34 @@ func (t *TableFormatter) SetNoColor(noColor bool) {
 	t.initializeStyle()
 }
 
+// RenderSimpleTable prints rows under headers in aligned columns, squeezing
+// the widest columns when the table is wider than AvailableWidth
 func RenderSimpleTable(headers []string, rows [][]string) error {
 	if len(rows) == 0 {
 		return nil
 	}
 
 	colWidths := make([]int, len(headers))
+	headerWidths := make([]int, len(headers))
 	for i, header := range headers {
-		colWidths[i] = len(header)
+		headerWidths[i] = lipgloss.Width(header)
+		colWidths[i] = headerWidths[i]
 	}
 
 	for _, row := range rows {
 		for i, cell := range row {
-			if i < len(colWidths) && len(cell) > colWidths[i] {
-				colWidths[i] = len(cell)
+			if i < len(colWidths) && lipgloss.Width(cell) > colWidths[i] {
+				colWidths[i] = lipgloss.Width(cell)
 			}
 		}
 	}
 
+	colWidths = fitColumns(colWidths, headerWidths, len(columnGap)*(len(headers)-1), AvailableWidth())
+
 	for i, header := range headers {
-		fmt.Printf("%-*s", colWidths[i], header)
+		fmt.Print(pad(Truncate(header, colWidths[i]), colWidths[i]))
 		if i < len(headers)-1 {
-			fmt.Print("  ")
+			fmt.Print(columnGap)
 		}
 	}
 	fmt.Println()
@@ -327,7 +333,7 @@ func RenderSimpleTable(headers []string, rows [][]string) error {
 	for i, width := range colWidths {
 		fmt.Print(strings.Repeat("-", width))
 		if i < len(colWidths)-1 {
-			fmt.Print("  ")
+			fmt.Print(columnGap)
 		}
 	}
 	fmt.Println()
@@ -335,9 +341,9 @@ func RenderSimpleTable(headers []string, rows [][]string) error {
 	for _, row := range rows {
 		for i, cell := range row {
 			if i < len(colWidths) {
-				fmt.Printf("%-*s", colWidths[i], cell)
+				fmt.Print(pad(Truncate(cell, colWidths[i]), colWidths[i]))
 				if i < len(row)-1 {
-					fmt.Print("  ")
+					fmt.Print(columnGap)
 				}
 			}
 		}
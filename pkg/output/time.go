@@ -47,3 +47,23 @@ func FormatDuration(seconds int) string {
 	minutes := (seconds % 3600) / 60
 	return fmt.Sprintf("%dh %dm", hours, minutes)
 }
+
+// FormatAge renders how long ago t was in compact form (45m, 3h, 16d),
+// for columns where FormatRelativeTime's calendar dates hide the age.
+func FormatAge(t *time.Time) string {
+	if t == nil {
+		return "-"
+	}
+
+	diff := time.Since(*t)
+	switch {
+	case diff < time.Minute:
+		return "now"
+	case diff < time.Hour:
+		return fmt.Sprintf("%dm", int(diff.Minutes()))
+	case diff < 24*time.Hour:
+		return fmt.Sprintf("%dh", int(diff.Hours()))
+	default:
+		return fmt.Sprintf("%dd", int(diff.Hours()/24))
+	}
+}
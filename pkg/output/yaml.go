@@ -1,6 +1,10 @@
 package output
 
 import (
+	"bytes"
+	"encoding/json"
+	"strings"
+
 	"gopkg.in/yaml.v3"
 )
 
@@ -25,12 +29,17 @@ func (y *YAMLFormatter) Format(data interface{}) error {
 		return err
 	}
 
+	node, err := toYAMLNode(data)
+	if err != nil {
+		return err
+	}
+
 	// Create encoder with custom indentation
 	encoder := yaml.NewEncoder(y.writer)
 	encoder.SetIndent(y.indent)
 
 	// Encode the data
-	err := encoder.Encode(data)
+	err = encoder.Encode(node)
 	if err != nil {
 		return err
 	}
@@ -46,3 +55,46 @@ func (y *YAMLFormatter) SetIndent(indent int) {
 	}
 	y.indent = indent
 }
+
+// toYAMLNode converts data to a YAML document by way of its JSON encoding.
+// The API types only carry json tags, so encoding them directly would emit
+// Go field names, ignore omitempty and render time.Time as a struct; going
+// through JSON keeps YAML output field-for-field identical to --output json
+// while preserving struct field order.
+func toYAMLNode(data interface{}) (*yaml.Node, error) {
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(data); err != nil {
+		return nil, err
+	}
+
+	var node yaml.Node
+	if err := yaml.Unmarshal(buf.Bytes(), &node); err != nil {
+		return nil, err
+	}
+
+	resetYAMLStyle(&node)
+	return &node, nil
+}
+
+// resetYAMLStyle drops the flow and quoting styles inherited from the JSON
+// input so the encoder picks block style and quotes only where needed.
+// Strings that YAML 1.1 readers would take for booleans stay quoted.
+func resetYAMLStyle(node *yaml.Node) {
+	node.Style = 0
+	if node.Kind == yaml.ScalarNode && node.Tag == "!!str" && isYAML11Bool(node.Value) {
+		node.Style = yaml.DoubleQuotedStyle
+	}
+	for _, child := range node.Content {
+		resetYAMLStyle(child)
+	}
+}
+
+func isYAML11Bool(value string) bool {
+	switch strings.ToLower(value) {
+	case "y", "yes", "n", "no", "on", "off":
+		return true
+	}
+	return false
+}
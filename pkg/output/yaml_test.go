@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"strings"
 	"testing"
+	"time"
 )
 
 func TestYAMLFormatter_Format(t *testing.T) {
@@ -183,3 +184,36 @@ func TestYAMLFormatter_ComplexData(t *testing.T) {
 		}
 	}
 }
+
+func TestYAMLFormatter_FollowsJSONEncoding(t *testing.T) {
+	type step struct {
+		Name      string     `json:"name"`
+		StartedOn *time.Time `json:"started_on,omitempty"`
+		Skipped   *bool      `json:"skipped,omitempty"`
+	}
+
+	started := time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC)
+	first := &step{Name: "build", StartedOn: &started}
+	data := map[string]interface{}{
+		"pipeline": &first,
+		"steps":    []*step{{Name: "yes"}, nil},
+		"note":     "<b>&</b>",
+	}
+
+	buf := &bytes.Buffer{}
+	formatter := NewYAMLFormatter(&FormatterOptions{Writer: buf})
+	if err := formatter.Format(data); err != nil {
+		t.Fatalf("YAMLFormatter.Format() error = %v", err)
+	}
+
+	expected := "note: <b>&</b>\n" +
+		"pipeline:\n" +
+		"  name: build\n" +
+		"  started_on: \"2024-03-01T10:00:00Z\"\n" +
+		"steps:\n" +
+		"  - name: \"yes\"\n" +
+		"  - null\n"
+	if result := buf.String(); result != expected {
+		t.Errorf("YAMLFormatter.Format() = %q, expected %q", result, expected)
+	}
+}
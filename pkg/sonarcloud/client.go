@@ -446,9 +446,13 @@ func (e *SonarCloudError) Error() string {
 }
 
 func (e *SonarCloudError) Format(verbose bool) string {
-	var output strings.Builder
+	return fmt.Sprintf("❌ %s\n", e.UserMessage) + e.FormatDetails(verbose)
+}
 
-	output.WriteString(fmt.Sprintf("❌ %s\n", e.UserMessage))
+// FormatDetails renders the suggested actions, technical details (verbose
+// only) and help links without the headline message.
+func (e *SonarCloudError) FormatDetails(verbose bool) string {
+	var output strings.Builder
 
 	if len(e.SuggestedActions) > 0 {
 		output.WriteString("\nSuggested actions:\n")
@@ -0,0 +1,150 @@
+// Package ui holds the interactive prompts shared by bt commands
+package ui
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// MaxVisibleOptions caps how many options are listed at once; the rest are
+// reached by typing a filter
+const MaxVisibleOptions = 20
+
+// ErrCanceled is returned when the user leaves the selector without picking
+var ErrCanceled = errors.New("selection canceled")
+
+// Selector is a line-based fuzzy select list. Typing text narrows the list,
+// typing a number picks that entry and an empty line picks the only entry
+// left or cancels.
+type Selector struct {
+	In  io.Reader
+	Out io.Writer
+}
+
+// NewSelector creates a selector reading answers from in and drawing on out
+func NewSelector(in io.Reader, out io.Writer) *Selector {
+	return &Selector{In: in, Out: out}
+}
+
+// Select shows options and returns the index of the chosen one
+func (s *Selector) Select(prompt string, options []string) (int, error) {
+	if len(options) == 0 {
+		return 0, fmt.Errorf("nothing to select")
+	}
+
+	reader := bufio.NewReader(s.In)
+	visible := make([]int, len(options))
+	for i := range options {
+		visible[i] = i
+	}
+
+	for {
+		s.printOptions(options, visible)
+		fmt.Fprintf(s.Out, "%s [number, text to filter, Enter to cancel]: ", prompt)
+
+		line, err := reader.ReadString('\n')
+		if err != nil && (err != io.EOF || line == "") {
+			fmt.Fprintln(s.Out)
+			return 0, ErrCanceled
+		}
+		answer := strings.TrimSpace(line)
+
+		if answer == "" {
+			if len(visible) == 1 {
+				return visible[0], nil
+			}
+			return 0, ErrCanceled
+		}
+
+		if n, err := strconv.Atoi(answer); err == nil {
+			if n >= 1 && n <= len(visible) && n <= MaxVisibleOptions {
+				return visible[n-1], nil
+			}
+			fmt.Fprintf(s.Out, "No entry %d in the list\n\n", n)
+			continue
+		}
+
+		matches := Filter(answer, options)
+		if len(matches) == 0 {
+			fmt.Fprintf(s.Out, "No matches for %q\n\n", answer)
+			continue
+		}
+		visible = matches
+		fmt.Fprintln(s.Out)
+	}
+}
+
+func (s *Selector) printOptions(options []string, visible []int) {
+	for n, i := range visible {
+		if n == MaxVisibleOptions {
+			fmt.Fprintf(s.Out, "  … %d more, type to narrow down\n", len(visible)-n)
+			break
+		}
+		fmt.Fprintf(s.Out, "%3d) %s\n", n+1, options[i])
+	}
+}
+
+// Filter returns the indexes of the options fuzzy matching query, substring
+// matches ahead of scattered ones and otherwise in their original order
+func Filter(query string, options []string) []int {
+	type match struct {
+		index int
+		score int
+	}
+
+	var matches []match
+	for i, option := range options {
+		if score := fuzzyScore(query, option); score > 0 {
+			matches = append(matches, match{i, score})
+		}
+	}
+	sort.SliceStable(matches, func(a, b int) bool {
+		return matches[a].score > matches[b].score
+	})
+
+	indexes := make([]int, len(matches))
+	for i, m := range matches {
+		indexes[i] = m.index
+	}
+	return indexes
+}
+
+// FuzzyMatch reports whether the letters of query appear in text in order,
+// ignoring case and whitespace in the query
+func FuzzyMatch(query, text string) bool {
+	return fuzzyScore(query, text) > 0
+}
+
+func fuzzyScore(query, text string) int {
+	query = strings.ToLower(strings.Map(func(r rune) rune {
+		if unicode.IsSpace(r) {
+			return -1
+		}
+		return r
+	}, query))
+	text = strings.ToLower(text)
+
+	if query == "" {
+		return 1
+	}
+	if strings.Contains(text, query) {
+		return 2
+	}
+
+	remaining := []rune(query)
+	for _, r := range text {
+		if r == remaining[0] {
+			remaining = remaining[1:]
+			if len(remaining) == 0 {
+				return 1
+			}
+		}
+	}
+	return 0
+}
@@ -0,0 +1,93 @@
+package ui
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+)
+
+var prOptions = []string{
+	"#12  Fix login redirect  (alice, fix/login → main)",
+	"#15  Add pipeline cache  (bob, feature/cache → main)",
+	"#21  Login page redesign  (carol, feature/login-ui → develop)",
+}
+
+func TestFuzzyMatch(t *testing.T) {
+	tests := []struct {
+		query string
+		text  string
+		want  bool
+	}{
+		{"login", "Fix login redirect", true},
+		{"LOGIN", "Fix login redirect", true},
+		{"flr", "Fix login redirect", true},
+		{"fix login", "Fix login redirect", true},
+		{"rlf", "Fix login redirect", false},
+		{"cache", "Fix login redirect", false},
+		{"", "anything", true},
+	}
+
+	for _, tt := range tests {
+		if got := FuzzyMatch(tt.query, tt.text); got != tt.want {
+			t.Errorf("FuzzyMatch(%q, %q) = %v, want %v", tt.query, tt.text, got, tt.want)
+		}
+	}
+}
+
+func TestFilter(t *testing.T) {
+	got := Filter("login", prOptions)
+	want := []int{0, 2}
+	if len(got) != len(want) || got[0] != want[0] || got[1] != want[1] {
+		t.Errorf("Filter(login) = %v, want %v", got, want)
+	}
+
+	// Substring matches rank ahead of scattered ones
+	got = Filter("cache", []string{"c-a-c-h-e", "pipeline cache"})
+	if len(got) != 2 || got[0] != 1 {
+		t.Errorf("Filter(cache) = %v, want substring match first", got)
+	}
+}
+
+func TestSelector_Select(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    int
+		wantErr error
+	}{
+		{"pick by number", "2\n", 1, nil},
+		{"filter then pick", "login\n2\n", 2, nil},
+		{"filter to one then enter", "cache\n\n", 1, nil},
+		{"no match keeps list", "zzz\n3\n", 2, nil},
+		{"out of range number", "9\n1\n", 0, nil},
+		{"enter cancels", "\n", 0, ErrCanceled},
+		{"eof cancels", "", 0, ErrCanceled},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out bytes.Buffer
+			got, err := NewSelector(strings.NewReader(tt.input), &out).Select("Pick", prOptions)
+
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("Select() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Select() unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Select() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSelector_Select_Empty(t *testing.T) {
+	if _, err := NewSelector(strings.NewReader("1\n"), &bytes.Buffer{}).Select("Pick", nil); err == nil {
+		t.Error("Select() with no options expected error, got nil")
+	}
+}
@@ -37,6 +37,9 @@ const (
 	DiffDelPrefix      = "-"
 )
 
+// RenameIndicator starts the line AnnotateRenames adds under renamed files
+const RenameIndicator = "⇄ renamed: "
+
 func ExtractChangedFiles(diff string) []string {
 	files := make([]string, 0)
 	scanner := bufio.NewScanner(strings.NewReader(diff))
@@ -133,8 +136,38 @@ func FormatDiff(diff string, useColors bool) string {
 	return result.String()
 }
 
+// AnnotateRenames adds a "⇄ renamed: old → new" line below the header of
+// every file whose old and new paths differ. It is for display only; the
+// result is no longer a valid patch.
+func AnnotateRenames(diff string) string {
+	var result strings.Builder
+	scanner := bufio.NewScanner(strings.NewReader(diff))
+
+	for scanner.Scan() {
+		line := scanner.Text()
+		result.WriteString(line + "\n")
+
+		if !strings.HasPrefix(line, DiffHeaderPrefix) {
+			continue
+		}
+		parts := strings.Fields(line)
+		if len(parts) < 4 {
+			continue
+		}
+		fromPath := strings.TrimPrefix(parts[2], "a/")
+		toPath := strings.TrimPrefix(parts[3], "b/")
+		if fromPath != toPath {
+			result.WriteString(RenameIndicator + FormatFilePath(fromPath, toPath) + "\n")
+		}
+	}
+
+	return result.String()
+}
+
 func formatDiffLine(line string) string {
 	switch {
+	case strings.HasPrefix(line, RenameIndicator):
+		return ColorYellow + line + ColorReset
 	case strings.HasPrefix(line, DiffHeaderPrefix):
 		return ColorBlue + ColorBold + line + ColorReset
 	case strings.HasPrefix(line, DiffIndexPrefix):
@@ -192,6 +225,16 @@ func IsTerminal(f *os.File) bool {
 	return err == nil
 }
 
+// TerminalWidth returns the width in columns of the terminal f is attached
+// to, or 0 when f isn't a terminal
+func TerminalWidth(f *os.File) int {
+	width, _, err := getTerminalSize(int(f.Fd()))
+	if err != nil {
+		return 0
+	}
+	return width
+}
+
 func getTerminalSize(fd int) (width, height int, err error) {
 	var dimensions [4]uint16
 
@@ -163,6 +163,35 @@ index 123..456 100644
 	assert.Equal(t, expected, result)
 }
 
+const renameDiff = `diff --git a/pkg/old.go b/pkg/new.go
+similarity index 92%
+rename from pkg/old.go
+rename to pkg/new.go
+index 123..456 100644
+--- a/pkg/old.go
++++ b/pkg/new.go
+@@ -1 +1 @@
+-package old
++package new
+`
+
+func TestAnnotateRenames(t *testing.T) {
+	annotated := AnnotateRenames(renameDiff)
+
+	lines := strings.Split(annotated, "\n")
+	assert.Equal(t, "diff --git a/pkg/old.go b/pkg/new.go", lines[0])
+	assert.Equal(t, RenameIndicator+"pkg/old.go → pkg/new.go", lines[1])
+	assert.Equal(t, renameDiff, strings.Replace(annotated, lines[1]+"\n", "", 1))
+
+	unchanged := "diff --git a/same.go b/same.go\n--- a/same.go\n+++ b/same.go\n"
+	assert.Equal(t, unchanged, AnnotateRenames(unchanged))
+}
+
+func TestRenames_NameOnlyAndPatch(t *testing.T) {
+	assert.Equal(t, []string{"pkg/new.go"}, ExtractChangedFiles(renameDiff))
+	assert.Equal(t, renameDiff, CleanDiffForPatch(renameDiff))
+}
+
 func TestCalculateDiffStats(t *testing.T) {
 	tests := []struct {
 		name     string
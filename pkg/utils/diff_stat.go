@@ -0,0 +1,116 @@
+package utils
+
+import (
+	"bufio"
+	"strings"
+)
+
+// Diff file statuses, using Bitbucket's diffstat vocabulary
+const (
+	DiffStatusAdded    = "added"
+	DiffStatusRemoved  = "removed"
+	DiffStatusModified = "modified"
+	DiffStatusRenamed  = "renamed"
+)
+
+// DiffFile is the canonical JSON/YAML shape of one changed file, shared by
+// every command that reports changed files. Path is the new path, or the old
+// one for removed files.
+type DiffFile struct {
+	Path         string `json:"path"`
+	OldPath      string `json:"old_path,omitempty"`
+	NewPath      string `json:"new_path,omitempty"`
+	Status       string `json:"status"`
+	LinesAdded   int    `json:"lines_added"`
+	LinesRemoved int    `json:"lines_removed"`
+	Binary       bool   `json:"binary"`
+}
+
+// SummarizeDiffFiles totals the line counts of files
+func SummarizeDiffFiles(files []DiffFile) DiffStats {
+	stats := DiffStats{FilesChanged: len(files)}
+	for _, file := range files {
+		stats.LinesAdded += file.LinesAdded
+		stats.LinesRemoved += file.LinesRemoved
+	}
+	return stats
+}
+
+// ParseDiffFiles builds the per-file stats of a unified git diff
+func ParseDiffFiles(diff string) []DiffFile {
+	files := make([]DiffFile, 0)
+	scanner := bufio.NewScanner(strings.NewReader(diff))
+
+	var current *DiffFile
+	inHunk := false
+	flush := func() {
+		if current == nil {
+			return
+		}
+		switch {
+		case current.NewPath == "":
+			current.Status = DiffStatusRemoved
+		case current.OldPath == "":
+			current.Status = DiffStatusAdded
+		case current.OldPath != current.NewPath:
+			current.Status = DiffStatusRenamed
+		default:
+			current.Status = DiffStatusModified
+		}
+		current.Path = current.NewPath
+		if current.Path == "" {
+			current.Path = current.OldPath
+		}
+		files = append(files, *current)
+		current = nil
+	}
+
+	for scanner.Scan() {
+		line := scanner.Text()
+
+		if strings.HasPrefix(line, DiffHeaderPrefix) {
+			flush()
+			inHunk = false
+			current = &DiffFile{}
+			parts := strings.Fields(line)
+			if len(parts) >= 4 {
+				current.OldPath = strings.TrimPrefix(parts[2], "a/")
+				current.NewPath = strings.TrimPrefix(parts[3], "b/")
+			}
+			continue
+		}
+
+		if current == nil {
+			continue
+		}
+
+		// Within a hunk "--- x" is a removed line, not a file header
+		if inHunk {
+			switch {
+			case strings.HasPrefix(line, DiffAddPrefix):
+				current.LinesAdded++
+			case strings.HasPrefix(line, DiffDelPrefix):
+				current.LinesRemoved++
+			}
+			continue
+		}
+
+		switch {
+		case strings.HasPrefix(line, DiffHunkPrefix):
+			inHunk = true
+		case strings.HasPrefix(line, "new file mode"):
+			current.OldPath = ""
+		case strings.HasPrefix(line, "deleted file mode"):
+			current.NewPath = ""
+		case strings.HasPrefix(line, "rename from "):
+			current.OldPath = strings.TrimPrefix(line, "rename from ")
+		case strings.HasPrefix(line, "rename to "):
+			current.NewPath = strings.TrimPrefix(line, "rename to ")
+		case strings.HasPrefix(line, "Binary files"), strings.HasPrefix(line, "GIT binary patch"):
+			current.Binary = true
+		}
+	}
+	flush()
+
+	return files
+}
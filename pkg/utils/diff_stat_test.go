@@ -0,0 +1,62 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const statDiff = `diff --git a/main.go b/main.go
+index 1111111..2222222 100644
+--- a/main.go
++++ b/main.go
+@@ -1,3 +1,3 @@
+ package main
+--- old comment
++// new comment
++func main() {}
+diff --git a/docs/new.md b/docs/new.md
+new file mode 100644
+index 0000000..3333333
+--- /dev/null
++++ b/docs/new.md
+@@ -0,0 +1,2 @@
++# New
++text
+diff --git a/old.txt b/old.txt
+deleted file mode 100644
+index 4444444..0000000
+--- a/old.txt
++++ /dev/null
+@@ -1 +0,0 @@
+-gone
+diff --git a/a.go b/b.go
+similarity index 90%
+rename from a.go
+rename to b.go
+diff --git a/logo.png b/logo.png
+index 5555555..6666666 100644
+Binary files a/logo.png and b/logo.png differ
+`
+
+func TestParseDiffFiles(t *testing.T) {
+	files := ParseDiffFiles(statDiff)
+
+	expected := []DiffFile{
+		{Path: "main.go", OldPath: "main.go", NewPath: "main.go", Status: DiffStatusModified, LinesAdded: 2, LinesRemoved: 1},
+		{Path: "docs/new.md", NewPath: "docs/new.md", Status: DiffStatusAdded, LinesAdded: 2},
+		{Path: "old.txt", OldPath: "old.txt", Status: DiffStatusRemoved, LinesRemoved: 1},
+		{Path: "b.go", OldPath: "a.go", NewPath: "b.go", Status: DiffStatusRenamed},
+		{Path: "logo.png", OldPath: "logo.png", NewPath: "logo.png", Status: DiffStatusModified, Binary: true},
+	}
+	assert.Equal(t, expected, files)
+}
+
+func TestParseDiffFiles_Empty(t *testing.T) {
+	assert.Empty(t, ParseDiffFiles(""))
+}
+
+func TestSummarizeDiffFiles(t *testing.T) {
+	stats := SummarizeDiffFiles(ParseDiffFiles(statDiff))
+	assert.Equal(t, DiffStats{FilesChanged: 5, LinesAdded: 4, LinesRemoved: 2}, stats)
+}
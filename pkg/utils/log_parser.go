@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"io"
 	"regexp"
+	"sort"
 	"strings"
 	"time"
 )
@@ -12,7 +13,8 @@ import (
 // LogParser provides advanced log analysis and error extraction capabilities
 type LogParser struct {
 	ErrorPatterns []ErrorPattern
-	ContextLines  int
+	ContextBefore int // Lines of context kept above each error
+	ContextAfter  int // Lines of context kept below each error
 	CaseSensitive bool
 }
 
@@ -28,16 +30,38 @@ type ErrorPattern struct {
 
 // ExtractedError represents an error found in log output
 type ExtractedError struct {
-	Line      int       `json:"line"`      // Line number where error was found
-	Content   string    `json:"content"`   // The actual error line content
-	Pattern   string    `json:"pattern"`   // Name of the pattern that matched
-	Category  string    `json:"category"`  // Error category
-	Severity  string    `json:"severity"`  // Error severity
-	Context   []string  `json:"context"`   // Surrounding lines for context
-	Timestamp time.Time `json:"timestamp"` // When the error was extracted
-	StepName  string    `json:"step_name"` // Pipeline step where error occurred
+	Line      int       `json:"line"`                // Line number where error was found
+	Content   string    `json:"content"`             // The actual error line content
+	Pattern   string    `json:"pattern"`             // Name of the pattern that matched
+	Category  string    `json:"category"`            // Error category
+	Severity  string    `json:"severity"`            // Error severity
+	Context   []string  `json:"context"`             // Surrounding lines for context
+	Timestamp time.Time `json:"timestamp"`           // When the error was extracted
+	StepName  string    `json:"step_name"`           // Pipeline step where error occurred
+	Signature string    `json:"signature,omitempty"` // Normalized content used for deduplication
+	Count     int       `json:"count,omitempty"`     // Occurrences collapsed into this error
 }
 
+// AggregatedError is a unique error signature counted across pipeline steps
+type AggregatedError struct {
+	Signature string   `json:"signature"`
+	Content   string   `json:"content"` // First occurrence of the error
+	Pattern   string   `json:"pattern"`
+	Category  string   `json:"category"`
+	Severity  string   `json:"severity"`
+	Count     int      `json:"count"`
+	Steps     []string `json:"steps"`
+}
+
+// Patterns stripped from error lines so repeated errors share a signature
+var (
+	signatureTimestamp = regexp.MustCompile(`\d{4}-\d{2}-\d{2}[T ]\d{2}:\d{2}:\d{2}(\.\d+)?(Z|[+-]\d{2}:?\d{2})?`)
+	signatureUUID      = regexp.MustCompile(`(?i)\b[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}\b`)
+	signatureHex       = regexp.MustCompile(`(?i)\b0x[0-9a-f]+\b`)
+	signatureNumber    = regexp.MustCompile(`\d+`)
+	signatureSpace     = regexp.MustCompile(`\s+`)
+)
+
 // LogAnalysisResult contains the complete analysis of a log stream
 type LogAnalysisResult struct {
 	TotalLines   int              `json:"total_lines"`
@@ -52,7 +76,8 @@ type LogAnalysisResult struct {
 func NewLogParser() *LogParser {
 	return &LogParser{
 		ErrorPatterns: GetDefaultErrorPatterns(),
-		ContextLines:  3,
+		ContextBefore: 3,
+		ContextAfter:  3,
 		CaseSensitive: false,
 	}
 }
@@ -299,7 +324,7 @@ func (lp *LogParser) AnalyzeLog(reader io.Reader, stepName string) (*LogAnalysis
 		for _, pattern := range lp.ErrorPatterns {
 			if lp.matchesPattern(line, pattern.Regex) {
 				// Extract context lines around the error
-				context := lp.extractContext(lines, i, lp.ContextLines)
+				context := lp.extractContext(lines, i, lp.ContextBefore, lp.ContextAfter)
 
 				error := ExtractedError{
 					Line:      lineNumber,
@@ -330,7 +355,10 @@ func (lp *LogParser) AnalyzeLog(reader io.Reader, stepName string) (*LogAnalysis
 	return result, nil
 }
 
-// FilterErrorsOnly returns only errors from the analysis result, excluding warnings
+// FilterErrorsOnly returns only errors from the analysis result, excluding warnings.
+// Errors sharing a normalized signature (repeated stack traces, retries) are
+// collapsed into their first occurrence with Count set to the number of hits;
+// ErrorCount and Summary still count every occurrence.
 func (lp *LogParser) FilterErrorsOnly(result *LogAnalysisResult) *LogAnalysisResult {
 	filtered := &LogAnalysisResult{
 		TotalLines:  result.TotalLines,
@@ -338,17 +366,96 @@ func (lp *LogParser) FilterErrorsOnly(result *LogAnalysisResult) *LogAnalysisRes
 		ProcessedAt: result.ProcessedAt,
 	}
 
+	seen := make(map[string]int)
 	for _, err := range result.Errors {
-		if err.Severity == "error" || err.Severity == "critical" {
-			filtered.Errors = append(filtered.Errors, err)
-			filtered.ErrorCount++
-			filtered.Summary[err.Category]++
+		if err.Severity != "error" && err.Severity != "critical" {
+			continue
+		}
+
+		filtered.ErrorCount++
+		filtered.Summary[err.Category]++
+
+		signature := NormalizeErrorSignature(err.Content)
+		if idx, ok := seen[signature]; ok {
+			filtered.Errors[idx].Count++
+			continue
 		}
+
+		err.Signature = signature
+		err.Count = 1
+		seen[signature] = len(filtered.Errors)
+		filtered.Errors = append(filtered.Errors, err)
 	}
 
 	return filtered
 }
 
+// NormalizeErrorSignature reduces an error line to a comparable signature by
+// dropping timestamps, UUIDs, addresses and numbers that vary between repeats
+func NormalizeErrorSignature(content string) string {
+	signature := signatureTimestamp.ReplaceAllString(content, "<time>")
+	signature = signatureUUID.ReplaceAllString(signature, "<uuid>")
+	signature = signatureHex.ReplaceAllString(signature, "<addr>")
+	signature = signatureNumber.ReplaceAllString(signature, "N")
+	signature = signatureSpace.ReplaceAllString(signature, " ")
+	return strings.ToLower(strings.TrimSpace(signature))
+}
+
+// AggregateErrors merges errors from several step results by signature,
+// most frequent first. Errors are counted by their Count when set, so results
+// from FilterErrorsOnly aggregate correctly.
+func AggregateErrors(results []*LogAnalysisResult) []AggregatedError {
+	aggregated := make([]AggregatedError, 0)
+	index := make(map[string]int)
+	steps := make(map[string]map[string]bool)
+
+	for _, result := range results {
+		if result == nil {
+			continue
+		}
+		for _, err := range result.Errors {
+			if err.Severity != "error" && err.Severity != "critical" {
+				continue
+			}
+
+			signature := err.Signature
+			if signature == "" {
+				signature = NormalizeErrorSignature(err.Content)
+			}
+			count := err.Count
+			if count == 0 {
+				count = 1
+			}
+
+			idx, ok := index[signature]
+			if !ok {
+				idx = len(aggregated)
+				index[signature] = idx
+				steps[signature] = make(map[string]bool)
+				aggregated = append(aggregated, AggregatedError{
+					Signature: signature,
+					Content:   err.Content,
+					Pattern:   err.Pattern,
+					Category:  err.Category,
+					Severity:  err.Severity,
+				})
+			}
+
+			agg := &aggregated[idx]
+			agg.Count += count
+			if err.StepName != "" && !steps[signature][err.StepName] {
+				steps[signature][err.StepName] = true
+				agg.Steps = append(agg.Steps, err.StepName)
+			}
+		}
+	}
+
+	sort.SliceStable(aggregated, func(i, j int) bool {
+		return aggregated[i].Count > aggregated[j].Count
+	})
+	return aggregated
+}
+
 // matchesPattern checks if a line matches the given pattern
 func (lp *LogParser) matchesPattern(line string, pattern *regexp.Regexp) bool {
 	if !lp.CaseSensitive {
@@ -358,9 +465,15 @@ func (lp *LogParser) matchesPattern(line string, pattern *regexp.Regexp) bool {
 }
 
 // extractContext extracts surrounding lines around the target line for context
-func (lp *LogParser) extractContext(lines []string, targetIndex, contextLines int) []string {
-	start := targetIndex - contextLines
-	end := targetIndex + contextLines + 1
+func (lp *LogParser) extractContext(lines []string, targetIndex, before, after int) []string {
+	return ExtractContext(lines, targetIndex, before, after)
+}
+
+// ExtractContext returns the lines around targetIndex, indented, with the
+// target line marked by an arrow
+func ExtractContext(lines []string, targetIndex, before, after int) []string {
+	start := targetIndex - before
+	end := targetIndex + after + 1
 
 	if start < 0 {
 		start = 0
@@ -403,15 +516,21 @@ func (lp *LogParser) AddCustomPattern(pattern ErrorPattern) error {
 	return nil
 }
 
-// SetContextLines configures how many lines of context to include around errors
-func (lp *LogParser) SetContextLines(lines int) {
+// SetContextLines configures how many lines of context to include before and
+// after errors
+func (lp *LogParser) SetContextLines(before, after int) {
+	lp.ContextBefore = clampContextLines(before)
+	lp.ContextAfter = clampContextLines(after)
+}
+
+func clampContextLines(lines int) int {
 	if lines < 0 {
-		lines = 0
+		return 0
 	}
 	if lines > 10 {
-		lines = 10 // Reasonable maximum
+		return 10 // Reasonable maximum
 	}
-	lp.ContextLines = lines
+	return lines
 }
 
 // GetPatternsByCategory returns all patterns for a specific category
@@ -14,7 +14,8 @@ func TestNewLogParser(t *testing.T) {
 
 	assert.NotNil(t, parser)
 	assert.NotEmpty(t, parser.ErrorPatterns)
-	assert.Equal(t, 3, parser.ContextLines)
+	assert.Equal(t, 3, parser.ContextBefore)
+	assert.Equal(t, 3, parser.ContextAfter)
 	assert.False(t, parser.CaseSensitive)
 
 	// Verify we have patterns for all major categories
@@ -311,7 +312,7 @@ Line 5: Process completed
 `
 
 	parser := NewLogParser()
-	parser.SetContextLines(2)
+	parser.SetContextLines(2, 2)
 
 	reader := strings.NewReader(logContent)
 	result, err := parser.AnalyzeLog(reader, "test-step")
@@ -369,6 +370,73 @@ info: process started
 	assert.Equal(t, 2, filtered.ErrorCount, "ErrorCount should match")
 }
 
+func TestLogParser_FilterErrorsOnly_Dedup(t *testing.T) {
+	logContent := `
+2024-01-01T10:00:01Z error: connection to db-1 failed after 3 attempts
+2024-01-01T10:00:01Z error: connection to db-1 failed after 3 attempts
+2024-01-01T10:00:05Z error: connection to db-2 failed after 5 attempts
+warning: retrying
+panic: runtime error at 0xc000123
+2024-01-01T10:00:09Z error: connection to db-1 failed after 3 attempts
+`
+
+	parser := NewLogParser()
+	result, err := parser.AnalyzeLog(strings.NewReader(logContent), "integration")
+	require.NoError(t, err)
+
+	filtered := parser.FilterErrorsOnly(result)
+
+	require.Len(t, filtered.Errors, 2)
+	assert.Equal(t, 5, filtered.ErrorCount, "ErrorCount should include collapsed occurrences")
+	assert.Equal(t, 4, filtered.Errors[0].Count)
+	assert.Equal(t, 2, filtered.Errors[0].Line, "First occurrence should be kept")
+	assert.Equal(t, 1, filtered.Errors[1].Count)
+	assert.Equal(t, "critical", filtered.Errors[1].Severity)
+}
+
+func TestNormalizeErrorSignature(t *testing.T) {
+	tests := []struct {
+		a, b string
+		same bool
+	}{
+		{"2024-01-01T10:00:00Z error: timeout", "2024-02-03 11:12:13 error: timeout", true},
+		{"panic at 0xc000010", "panic at 0xdeadbeef", true},
+		{"job 3f2504e0-4f89-11d3-9a0c-0305e82c3301 failed", "job 9b2c8f3a-1234-4abc-9def-001122334455 failed", true},
+		{"Error:   exit code 1", "error: exit code 2", true},
+		{"error: module foo not found", "error: module bar not found", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.a, func(t *testing.T) {
+			same := NormalizeErrorSignature(tt.a) == NormalizeErrorSignature(tt.b)
+			assert.Equal(t, tt.same, same)
+		})
+	}
+}
+
+func TestAggregateErrors(t *testing.T) {
+	parser := NewLogParser()
+
+	build, err := parser.AnalyzeLog(strings.NewReader("error: cache miss\nnpm error: ENOENT\nnpm error: ENOENT\n"), "build")
+	require.NoError(t, err)
+	test, err := parser.AnalyzeLog(strings.NewReader("npm error: ENOENT\nwarning: slow test\n"), "test")
+	require.NoError(t, err)
+
+	aggregated := AggregateErrors([]*LogAnalysisResult{parser.FilterErrorsOnly(build), parser.FilterErrorsOnly(test), nil})
+
+	require.Len(t, aggregated, 2)
+	assert.Equal(t, "npm error: ENOENT", aggregated[0].Content)
+	assert.Equal(t, 3, aggregated[0].Count)
+	assert.Equal(t, []string{"build", "test"}, aggregated[0].Steps)
+	assert.Equal(t, 1, aggregated[1].Count)
+	assert.Equal(t, []string{"build"}, aggregated[1].Steps)
+
+	retry, err := parser.AnalyzeLog(strings.NewReader("npm error: ENOENT\n"), "build")
+	require.NoError(t, err)
+	aggregated = AggregateErrors([]*LogAnalysisResult{build, test, retry})
+	assert.Equal(t, []string{"build", "test"}, aggregated[0].Steps, "a step is listed once, even when it comes back")
+}
+
 func TestLogParser_AddCustomPattern(t *testing.T) {
 	parser := NewLogParser()
 	initialPatternCount := len(parser.ErrorPatterns)
@@ -409,16 +477,37 @@ func TestLogParser_SetContextLines(t *testing.T) {
 	parser := NewLogParser()
 
 	// Test valid values
-	parser.SetContextLines(5)
-	assert.Equal(t, 5, parser.ContextLines)
+	parser.SetContextLines(5, 5)
+	assert.Equal(t, 5, parser.ContextBefore)
+	assert.Equal(t, 5, parser.ContextAfter)
+
+	// Test asymmetric values
+	parser.SetContextLines(8, 1)
+	assert.Equal(t, 8, parser.ContextBefore)
+	assert.Equal(t, 1, parser.ContextAfter)
 
 	// Test negative value (should be set to 0)
-	parser.SetContextLines(-1)
-	assert.Equal(t, 0, parser.ContextLines)
+	parser.SetContextLines(-1, 2)
+	assert.Equal(t, 0, parser.ContextBefore)
+	assert.Equal(t, 2, parser.ContextAfter)
 
 	// Test too large value (should be capped at 10)
-	parser.SetContextLines(15)
-	assert.Equal(t, 10, parser.ContextLines)
+	parser.SetContextLines(15, 15)
+	assert.Equal(t, 10, parser.ContextBefore)
+	assert.Equal(t, 10, parser.ContextAfter)
+}
+
+func TestLogParser_AsymmetricContext(t *testing.T) {
+	logContent := "setup 1\nsetup 2\nsetup 3\nerror: boom\nteardown 1\nteardown 2\n"
+
+	parser := NewLogParser()
+	parser.SetContextLines(3, 1)
+
+	result, err := parser.AnalyzeLog(strings.NewReader(logContent), "test-step")
+	require.NoError(t, err)
+	require.Len(t, result.Errors, 1)
+
+	assert.Equal(t, []string{"  setup 1", "  setup 2", "  setup 3", "→ error: boom", "  teardown 1"}, result.Errors[0].Context)
 }
 
 func TestLogParser_GetPatternsByCategory(t *testing.T) {
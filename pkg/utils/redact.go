@@ -0,0 +1,186 @@
+package utils
+
+import (
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// RedactedText replaces every secret the Redactor masks
+const RedactedText = "[REDACTED]"
+
+// DefaultRedactPatterns match common secrets that leak into pipeline logs.
+// Where a pattern has a capture group only the first group is masked, so
+// the surrounding text (a variable name, "Bearer ") stays readable.
+var DefaultRedactPatterns = []string{
+	`\b(?:AKIA|ASIA)[0-9A-Z]{16}\b`,
+	`(?i)aws_secret_access_key["']?\s*[:=]\s*["']?([A-Za-z0-9/+=]{40})`,
+	`(?i)\bbearer\s+([A-Za-z0-9\-._~+/]+=*)`,
+	`(?i)\b[a-z0-9_]*(?:token|secret|password|passwd|api_?key)["']?\s*[:=]\s*["']?([^\s"']{8,})`,
+	`(?i)\b[a-z][a-z0-9+.-]*://[^/\s:@]+:([^/\s@]+)@`,
+	`-----BEGIN [A-Z ]*PRIVATE KEY-----(.+?)(?:-----END [A-Z ]*PRIVATE KEY-----|$)`,
+}
+
+// minRedactValueLength keeps short secured values, which would mask common
+// words all over the log, out of the literal replacements
+const minRedactValueLength = 4
+
+var (
+	privateKeyBegin = regexp.MustCompile(`-----BEGIN [A-Z ]*PRIVATE KEY-----\s*$`)
+	privateKeyEnd   = regexp.MustCompile(`^\s*-----END [A-Z ]*PRIVATE KEY-----`)
+)
+
+// Redactor masks secrets in log lines: known secret values, assignments to
+// secured variable names, and regular expressions for common secret formats.
+// A nil Redactor leaves lines unchanged.
+type Redactor struct {
+	values   []string
+	patterns []*regexp.Regexp
+}
+
+// NewRedactor builds a Redactor from the default patterns plus extra ones,
+// the names of secured variables whose assignments are masked, and secret
+// values masked wherever they appear
+func NewRedactor(extra []string, securedNames []string, securedValues []string) (*Redactor, error) {
+	r := &Redactor{}
+
+	for _, pattern := range append(append([]string{}, DefaultRedactPatterns...), extra...) {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid redact pattern %q: %w", pattern, err)
+		}
+		r.patterns = append(r.patterns, re)
+	}
+
+	for _, name := range securedNames {
+		if name == "" {
+			continue
+		}
+		r.patterns = append(r.patterns, regexp.MustCompile(`\b`+regexp.QuoteMeta(name)+`["']?\s*[:=]\s*["']?([^\s"']+)`))
+	}
+
+	for _, value := range securedValues {
+		if len(value) >= minRedactValueLength {
+			r.values = append(r.values, value)
+		}
+	}
+	// Longest first, so a value containing another is masked whole
+	sort.Slice(r.values, func(i, j int) bool { return len(r.values[i]) > len(r.values[j]) })
+
+	return r, nil
+}
+
+// Redact masks the secrets in a single line
+func (r *Redactor) Redact(line string) string {
+	if r == nil {
+		return line
+	}
+	for _, value := range r.values {
+		line = strings.ReplaceAll(line, value, RedactedText)
+	}
+	for _, re := range r.patterns {
+		line = redactMatches(re, line)
+	}
+	return line
+}
+
+// RedactLines masks the secrets in lines, including the body of private
+// keys printed over several lines
+func (r *Redactor) RedactLines(lines []string) []string {
+	if r == nil {
+		return lines
+	}
+	stream := r.Stream()
+	redacted := make([]string, len(lines))
+	for i, line := range lines {
+		redacted[i] = stream.Redact(line)
+	}
+	return redacted
+}
+
+// Reader returns src with every line redacted. The whole of src is read on
+// the first Read.
+func (r *Redactor) Reader(src io.Reader) io.Reader {
+	if r == nil {
+		return src
+	}
+	return &redactingReader{redactor: r, src: src}
+}
+
+// Stream returns a RedactStream for masking the lines of one log as they
+// arrive
+func (r *Redactor) Stream() *RedactStream {
+	return &RedactStream{redactor: r}
+}
+
+// RedactStream masks a log line by line, remembering whether it is inside a
+// private key block
+type RedactStream struct {
+	redactor     *Redactor
+	inPrivateKey bool
+}
+
+// Redact masks the secrets in the next line of the log
+func (s *RedactStream) Redact(line string) string {
+	if s.redactor == nil {
+		return line
+	}
+	if s.inPrivateKey {
+		if privateKeyEnd.MatchString(line) {
+			s.inPrivateKey = false
+			return line
+		}
+		return RedactedText
+	}
+	if privateKeyBegin.MatchString(line) {
+		s.inPrivateKey = true
+		return line
+	}
+	return s.redactor.Redact(line)
+}
+
+// redactMatches masks every match of re in line, or only its first group
+// when it has one
+func redactMatches(re *regexp.Regexp, line string) string {
+	matches := re.FindAllStringSubmatchIndex(line, -1)
+	if matches == nil {
+		return line
+	}
+
+	var b strings.Builder
+	last := 0
+	for _, m := range matches {
+		start, end := m[0], m[1]
+		if len(m) >= 4 {
+			if m[2] < 0 {
+				continue
+			}
+			start, end = m[2], m[3]
+		}
+		b.WriteString(line[last:start])
+		b.WriteString(RedactedText)
+		last = end
+	}
+	b.WriteString(line[last:])
+	return b.String()
+}
+
+type redactingReader struct {
+	redactor *Redactor
+	src      io.Reader
+	redacted *strings.Reader
+}
+
+func (rr *redactingReader) Read(p []byte) (int, error) {
+	if rr.redacted == nil {
+		content, err := io.ReadAll(rr.src)
+		if err != nil {
+			return 0, err
+		}
+		lines := rr.redactor.RedactLines(strings.Split(string(content), "\n"))
+		rr.redacted = strings.NewReader(strings.Join(lines, "\n"))
+	}
+	return rr.redacted.Read(p)
+}
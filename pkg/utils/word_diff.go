@@ -0,0 +1,242 @@
+package utils
+
+import (
+	"bufio"
+	"strings"
+	"unicode"
+)
+
+const (
+	colorReverse    = "\033[7m"
+	colorReverseOff = "\033[27m"
+)
+
+// maxWordDiffCells bounds the token table compared for one pair of lines;
+// longer pairs are shown as whole removed and added lines
+const maxWordDiffCells = 250000
+
+// FormatWordDiff formats diff like FormatDiff, additionally marking the words
+// that changed between each removed line and the added line replacing it.
+// With colors, changed words are shown in reverse video on top of the usual
+// red and green. Without them, each pair of lines becomes one line in the
+// style of git diff --word-diff, with [-removed-] and {+added+} markers.
+func FormatWordDiff(diff string, useColors bool) string {
+	var result strings.Builder
+	var removed, added []string
+	inHunk := false
+
+	flush := func() {
+		writeWordDiffBlock(&result, removed, added, useColors)
+		removed, added = nil, nil
+	}
+
+	scanner := bufio.NewScanner(strings.NewReader(diff))
+	scanner.Buffer(make([]byte, 0, 64*1024), 10*1024*1024)
+	for scanner.Scan() {
+		line := scanner.Text()
+
+		switch {
+		case strings.HasPrefix(line, DiffHeaderPrefix):
+			inHunk = false
+		case strings.HasPrefix(line, DiffHunkPrefix):
+			inHunk = true
+		case inHunk && strings.HasPrefix(line, DiffDelPrefix):
+			// A removal block that follows additions starts a new change
+			if len(added) > 0 {
+				flush()
+			}
+			removed = append(removed, line[1:])
+			continue
+		case inHunk && strings.HasPrefix(line, DiffAddPrefix):
+			added = append(added, line[1:])
+			continue
+		}
+
+		flush()
+		result.WriteString(formatWordDiffLine(line, useColors) + "\n")
+	}
+	flush()
+
+	return result.String()
+}
+
+func formatWordDiffLine(line string, useColors bool) string {
+	if !useColors {
+		return line
+	}
+	return formatDiffLine(line)
+}
+
+// writeWordDiffBlock writes a run of removed lines followed by the lines
+// added in their place. Lines are paired in order; the leftovers, and pairs
+// with no words in common, are written as plain removals and additions.
+func writeWordDiffBlock(w *strings.Builder, removed, added []string, useColors bool) {
+	var pendingAdded []string
+	for i, oldLine := range removed {
+		if i >= len(added) {
+			writeWholeLine(w, DiffDelPrefix, oldLine, useColors)
+			continue
+		}
+		ops, ok := diffWords(tokenizeWords(oldLine), tokenizeWords(added[i]))
+		if !ok {
+			writeWholeLine(w, DiffDelPrefix, oldLine, useColors)
+			pendingAdded = append(pendingAdded, added[i])
+			continue
+		}
+		// Additions that couldn't be paired stay ahead of later pairs
+		for _, line := range pendingAdded {
+			writeWholeLine(w, DiffAddPrefix, line, useColors)
+		}
+		pendingAdded = nil
+		writeWordDiffPair(w, ops, useColors)
+	}
+	for _, line := range pendingAdded {
+		writeWholeLine(w, DiffAddPrefix, line, useColors)
+	}
+	if len(added) > len(removed) {
+		for _, line := range added[len(removed):] {
+			writeWholeLine(w, DiffAddPrefix, line, useColors)
+		}
+	}
+}
+
+func writeWholeLine(w *strings.Builder, prefix, text string, useColors bool) {
+	w.WriteString(formatWordDiffLine(prefix+text, useColors) + "\n")
+}
+
+func writeWordDiffPair(w *strings.Builder, ops []wordOp, useColors bool) {
+	if !useColors {
+		w.WriteString(" ")
+		for _, op := range ops {
+			switch op.Kind {
+			case wordRemoved:
+				w.WriteString("[-" + op.Text + "-]")
+			case wordAdded:
+				w.WriteString("{+" + op.Text + "+}")
+			default:
+				w.WriteString(op.Text)
+			}
+		}
+		w.WriteString("\n")
+		return
+	}
+
+	writeSide := func(prefix, color string, skip wordOpKind) {
+		w.WriteString(color + prefix)
+		for _, op := range ops {
+			switch op.Kind {
+			case skip:
+			case wordEqual:
+				w.WriteString(op.Text)
+			default:
+				w.WriteString(colorReverse + op.Text + colorReverseOff)
+			}
+		}
+		w.WriteString(ColorReset + "\n")
+	}
+	writeSide(DiffDelPrefix, ColorRed, wordAdded)
+	writeSide(DiffAddPrefix, ColorGreen, wordRemoved)
+}
+
+type wordOpKind int
+
+const (
+	wordEqual wordOpKind = iota
+	wordRemoved
+	wordAdded
+)
+
+// wordOp is a run of text kept, removed or added between two lines
+type wordOp struct {
+	Kind wordOpKind
+	Text string
+}
+
+// tokenizeWords splits a line into words, runs of whitespace and single
+// punctuation characters, so that foo(bar) compares as foo, (, bar and )
+func tokenizeWords(line string) []string {
+	var tokens []string
+	runes := []rune(line)
+	for start := 0; start < len(runes); {
+		end := start + 1
+		switch {
+		case isWordRune(runes[start]):
+			for end < len(runes) && isWordRune(runes[end]) {
+				end++
+			}
+		case unicode.IsSpace(runes[start]):
+			for end < len(runes) && unicode.IsSpace(runes[end]) {
+				end++
+			}
+		}
+		tokens = append(tokens, string(runes[start:end]))
+		start = end
+	}
+	return tokens
+}
+
+func isWordRune(r rune) bool {
+	return r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+// diffWords compares two token lists by their longest common subsequence
+// and returns the merged runs. It reports false when the lines have no
+// words in common, or are too long to compare.
+func diffWords(oldTokens, newTokens []string) ([]wordOp, bool) {
+	n, m := len(oldTokens), len(newTokens)
+	if n == 0 || m == 0 || (n+1)*(m+1) > maxWordDiffCells {
+		return nil, false
+	}
+
+	// lcs[i][j] is the common subsequence length of oldTokens[i:] and newTokens[j:]
+	lcs := make([][]int, n+1)
+	for i := range lcs {
+		lcs[i] = make([]int, m+1)
+	}
+	for i := n - 1; i >= 0; i-- {
+		for j := m - 1; j >= 0; j-- {
+			if oldTokens[i] == newTokens[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	var ops []wordOp
+	emit := func(kind wordOpKind, text string) {
+		if last := len(ops) - 1; last >= 0 && ops[last].Kind == kind {
+			ops[last].Text += text
+			return
+		}
+		ops = append(ops, wordOp{Kind: kind, Text: text})
+	}
+
+	shared := false
+	i, j := 0, 0
+	for i < n && j < m {
+		switch {
+		case oldTokens[i] == newTokens[j]:
+			if strings.TrimSpace(oldTokens[i]) != "" {
+				shared = true
+			}
+			emit(wordEqual, oldTokens[i])
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			emit(wordRemoved, oldTokens[i])
+			i++
+		default:
+			emit(wordAdded, newTokens[j])
+			j++
+		}
+	}
+	for ; i < n; i++ {
+		emit(wordRemoved, oldTokens[i])
+	}
+	for ; j < m; j++ {
+		emit(wordAdded, newTokens[j])
+	}
+
+	return ops, shared
+}
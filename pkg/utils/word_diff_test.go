@@ -0,0 +1,67 @@
+package utils
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const wordDiffInput = `diff --git a/main.go b/main.go
+--- a/main.go
++++ b/main.go
+@@ -1,4 +1,5 @@
+ func main() {
+-	fmt.Println("Hello, World!")
++	log.Println("Hello, Bitbucket!")
+-	return
++	os.Exit(1)
++	// done
+ }
+`
+
+func TestFormatWordDiff_Plain(t *testing.T) {
+	got := FormatWordDiff(wordDiffInput, false)
+
+	expected := `diff --git a/main.go b/main.go
+--- a/main.go
++++ b/main.go
+@@ -1,4 +1,5 @@
+ func main() {
+ 	[-fmt-]{+log+}.Println("Hello, [-World-]{+Bitbucket+}!")
+-	return
++	os.Exit(1)
++	// done
+ }
+`
+	assert.Equal(t, expected, got)
+}
+
+func TestFormatWordDiff_Colors(t *testing.T) {
+	got := FormatWordDiff(wordDiffInput, true)
+	lines := strings.Split(got, "\n")
+
+	assert.Equal(t, ColorRed+"-\t"+colorReverse+"fmt"+colorReverseOff+`.Println("Hello, `+colorReverse+"World"+colorReverseOff+`!")`+ColorReset, lines[5])
+	assert.Equal(t, ColorGreen+"+\t"+colorReverse+"log"+colorReverseOff+`.Println("Hello, `+colorReverse+"Bitbucket"+colorReverseOff+`!")`+ColorReset, lines[6])
+	assert.Equal(t, ColorRed+"-\treturn"+ColorReset, lines[7])
+	assert.Equal(t, ColorBold+"--- a/main.go"+ColorReset, lines[1], "file headers aren't treated as removals")
+}
+
+func TestFormatWordDiff_UnpairedLines(t *testing.T) {
+	diff := `@@ -1,2 +1,1 @@
+-removed only
+-second
++replacement text
+`
+	expected := `@@ -1,2 +1,1 @@
+-removed only
+-second
++replacement text
+`
+	assert.Equal(t, expected, FormatWordDiff(diff, false))
+}
+
+func TestTokenizeWords(t *testing.T) {
+	assert.Equal(t, []string{"foo", "(", "bar_1", ",", "  ", "baz", ")"}, tokenizeWords("foo(bar_1,  baz)"))
+	assert.Empty(t, tokenizeWords(""))
+}
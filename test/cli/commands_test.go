@@ -77,7 +77,7 @@ func (suite *CLITestSuite) TestHelpCommand() {
 
 // TestCommandHelp tests help for individual commands
 func (suite *CLITestSuite) TestCommandHelp() {
-	commands := []string{"version", "auth", "repo", "pr", "run", "api"}
+	commands := []string{"version", "auth", "repo", "pr", "run", "api", "config", "issue", "pick", "skill", "alias"}
 
 	for _, cmd := range commands {
 		suite.T().Run(fmt.Sprintf("Help_%s", cmd), func(t *testing.T) {
@@ -90,6 +90,31 @@ func (suite *CLITestSuite) TestCommandHelp() {
 	}
 }
 
+// TestSubcommandHelp tests that --help on a subcommand prints its usage
+// instead of running it
+func (suite *CLITestSuite) TestSubcommandHelp() {
+	testCases := []struct {
+		args []string
+		want string
+	}{
+		{[]string{"pr", "create", "--help"}, "--draft"},
+		{[]string{"pr", "merge", "123", "--help"}, "--squash"},
+		{[]string{"config", "set", "-h"}, "Usage: bt config set"},
+		{[]string{"run", "view", "--help"}, "--log-failed"},
+		{[]string{"--help", "issue", "create"}, "Usage: bt issue create"},
+	}
+
+	for _, tc := range testCases {
+		suite.T().Run(strings.Join(tc.args, "_"), func(t *testing.T) {
+			result := utils.RunBTCommand(tc.args...)
+			utils.AssertCommandSuccess(t, result)
+
+			assert.Contains(t, result.Stdout, tc.want)
+			assert.Contains(t, result.Stdout, "Global flags:")
+		})
+	}
+}
+
 // TestGlobalFlags tests global flags functionality
 func (suite *CLITestSuite) TestGlobalFlags() {
 	testCases := []struct {